@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// DefaultTargetPendingRequests is the default number of pending requests of a replica of the scale target
+const DefaultTargetPendingRequests = 100
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=httpscaledobjects,scope=Namespaced,shortName=httpso
+// +kubebuilder:printcolumn:name="ScaleTargetName",type="string",JSONPath=".spec.scaleTargetRef.name"
+// +kubebuilder:printcolumn:name="Service",type="string",JSONPath=".spec.scaleTargetRef.service"
+// +kubebuilder:printcolumn:name="Min",type="integer",JSONPath=".spec.replicas.min"
+// +kubebuilder:printcolumn:name="Max",type="integer",JSONPath=".spec.replicas.max"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// HTTPScaledObject routes the requests of its hosts and paths through the interceptor to the Service of the scale target
+// and scales the target, from zero too, on the number of pending requests. The requests are held by the interceptor
+// until the Service has ready endpoints.
+type HTTPScaledObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HTTPScaledObjectSpec `json:"spec"`
+	// +optional
+	Status HTTPScaledObjectStatus `json:"status,omitempty"`
+}
+
+// HTTPScaledObjectSpec is the spec for a HTTPScaledObject resource
+type HTTPScaledObjectSpec struct {
+	// Hosts are the values of the Host header of requests routed to the scale target
+	// +kubebuilder:validation:MinItems=1
+	Hosts []string `json:"hosts"`
+	// PathPrefixes limit the routed requests to the paths with the prefixes, all paths are routed if none is given
+	// +optional
+	PathPrefixes   []string           `json:"pathPrefixes,omitempty"`
+	ScaleTargetRef HTTPScaleTargetRef `json:"scaleTargetRef"`
+	// +optional
+	Replicas *ReplicaStruct `json:"replicas,omitempty"`
+	// TargetPendingRequests is the number of pending requests of a replica, 100 by default
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetPendingRequests *int32 `json:"targetPendingRequests,omitempty"`
+	// CooldownPeriod is the period in seconds to wait after the last request before scaling the target to zero
+	// +optional
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+}
+
+// HTTPScaleTargetRef is the scale target and its Service receiving the routed requests
+type HTTPScaleTargetRef struct {
+	Name string `json:"name"`
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Service is the name of the Service of the scale target in the namespace of the HTTPScaledObject
+	Service string `json:"service"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// ReplicaStruct is the replica count limits of the scale target
+type ReplicaStruct struct {
+	// Min is the minimum replica count, 0 by default
+	// +optional
+	Min *int32 `json:"min,omitempty"`
+	// +optional
+	Max *int32 `json:"max,omitempty"`
+}
+
+// HTTPScaledObjectStatus is the status for a HTTPScaledObject resource
+type HTTPScaledObjectStatus struct {
+	// ScaledObjectName is the name of the ScaledObject scaling the target, it's owned by the HTTPScaledObject
+	// +optional
+	ScaledObjectName string `json:"scaledObjectName,omitempty"`
+	// +optional
+	Conditions kedav1alpha1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HTTPScaledObjectList is a list of HTTPScaledObject resources
+type HTTPScaledObjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []HTTPScaledObject `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HTTPScaledObject{}, &HTTPScaledObjectList{})
+}
+
+// QueueKey is the key of the pending requests of the HTTPScaledObject reported by the interceptor
+func (h *HTTPScaledObject) QueueKey() string {
+	return h.Namespace + "/" + h.Name
+}
+
+// GetTargetPendingRequests returns the number of pending requests of a replica of the scale target
+func (h *HTTPScaledObject) GetTargetPendingRequests() int32 {
+	if h.Spec.TargetPendingRequests != nil {
+		return *h.Spec.TargetPendingRequests
+	}
+	return DefaultTargetPendingRequests
+}
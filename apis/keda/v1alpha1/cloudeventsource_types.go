@@ -0,0 +1,163 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Types of CloudEvents emitted by KEDA
+const (
+	// CloudEventScaledObjectReady is emitted when a ScaledObject becomes ready
+	CloudEventScaledObjectReady = "keda.scaledobject.ready.v1"
+	// CloudEventScaledObjectActivated is emitted when the scale target of a ScaledObject is activated
+	CloudEventScaledObjectActivated = "keda.scaledobject.activated.v1"
+	// CloudEventScaledObjectDeactivated is emitted when the scale target of a ScaledObject is deactivated to a non zero replica count
+	CloudEventScaledObjectDeactivated = "keda.scaledobject.deactivated.v1"
+	// CloudEventScaledObjectScaledToZero is emitted when the scale target of a ScaledObject is scaled to zero
+	CloudEventScaledObjectScaledToZero = "keda.scaledobject.scaledtozero.v1"
+	// CloudEventScalerFailed is emitted when a scaler of a ScaledObject or ScaledJob fails
+	CloudEventScalerFailed = "keda.scaler.failed.v1"
+)
+
+// CloudEventTypes are all types of CloudEvents emitted by KEDA
+var CloudEventTypes = []string{
+	CloudEventScaledObjectReady,
+	CloudEventScaledObjectActivated,
+	CloudEventScaledObjectDeactivated,
+	CloudEventScaledObjectScaledToZero,
+	CloudEventScalerFailed,
+}
+
+// DefaultCloudEventClusterName is the cluster name in the source of CloudEvents if none is given
+const DefaultCloudEventClusterName = "kubernetes-default"
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=cloudeventsources,scope=Namespaced
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CloudEventSource sends CloudEvents of KEDA about ScaledObjects and ScaledJobs of its namespace to the destination
+type CloudEventSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CloudEventSourceSpec `json:"spec"`
+}
+
+// CloudEventSourceSpec is the spec for a CloudEventSource resource
+type CloudEventSourceSpec struct {
+	// ClusterName identifies the cluster in the source and subject of the CloudEvents, kubernetes-default by default
+	// +optional
+	ClusterName string                `json:"clusterName,omitempty"`
+	Destination CloudEventDestination `json:"destination"`
+	// +optional
+	EventSubscription CloudEventSubscription `json:"eventSubscription,omitempty"`
+}
+
+// CloudEventDestination is the receiver of the CloudEvents, exactly one of the destinations has to be given
+type CloudEventDestination struct {
+	// +optional
+	HTTP *CloudEventHTTP `json:"http,omitempty"`
+	// +optional
+	AzureEventGridTopic *AzureEventGridTopic `json:"azureEventGridTopic,omitempty"`
+	// +optional
+	Webhook *WebhookDestination `json:"webhook,omitempty"`
+}
+
+// CloudEventHTTP is an HTTP endpoint receiving CloudEvents in structured content mode
+type CloudEventHTTP struct {
+	URI string `json:"uri"`
+}
+
+// AzureEventGridTopic is an Azure Event Grid topic with the CloudEvents schema
+type AzureEventGridTopic struct {
+	Endpoint string `json:"endpoint"`
+	// AuthenticationRef refers to the TriggerAuthentication or ClusterTriggerAuthentication with accessKey of the topic
+	AuthenticationRef *ScaledObjectAuthRef `json:"authenticationRef"`
+}
+
+// Formats of the payloads posted to webhooks
+const (
+	WebhookFormatGeneric = "generic"
+	WebhookFormatSlack   = "slack"
+	WebhookFormatTeams   = "teams"
+)
+
+// WebhookDestination is a webhook receiving notifications about the CloudEvents, eg. an incoming webhook of Slack or
+// Microsoft Teams
+type WebhookDestination struct {
+	URL string `json:"url"`
+	// Format of the payload, the CloudEvent is posted as it is by generic format, it's ignored if a template is given
+	// +kubebuilder:validation:Enum=generic;slack;teams
+	// +optional
+	Format string `json:"format,omitempty"`
+	// Template is a Go template of the payload executed with the CloudEvent, eg. {{ .Data.Namespace }}/{{ .Data.Name }},
+	// the json function encodes a value as JSON
+	// +optional
+	Template string `json:"template,omitempty"`
+	// ContentType of the payload, application/json by default
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// CloudEventSubscription filters the types of sent CloudEvents, all types are sent by default
+type CloudEventSubscription struct {
+	// +optional
+	IncludedEventTypes []string `json:"includedEventTypes,omitempty"`
+	// +optional
+	ExcludedEventTypes []string `json:"excludedEventTypes,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CloudEventSourceList is a list of CloudEventSource resources
+type CloudEventSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []CloudEventSource `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CloudEventSource{}, &CloudEventSourceList{})
+}
+
+// GetClusterName returns the cluster name in the source of the CloudEvents
+func (s *CloudEventSource) GetClusterName() string {
+	if s.Spec.ClusterName != "" {
+		return s.Spec.ClusterName
+	}
+	return DefaultCloudEventClusterName
+}
+
+// IsSubscribed returns whether CloudEvents of the type are sent to the destination
+func (s *CloudEventSubscription) IsSubscribed(eventType string) bool {
+	for _, excluded := range s.ExcludedEventTypes {
+		if excluded == eventType {
+			return false
+		}
+	}
+	if len(s.IncludedEventTypes) == 0 {
+		return true
+	}
+	for _, included := range s.IncludedEventTypes {
+		if included == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -32,6 +32,9 @@ const (
 	ConditionActive ConditionType = "Active"
 	// ConditionFallback specifies that the resource has a fallback active.
 	ConditionFallback ConditionType = "Fallback"
+	// ConditionCircuitOpen specifies that checks of some triggers are skipped after consecutive failures.
+	// It isn't part of the initialized Conditions, it's added once a circuit opens.
+	ConditionCircuitOpen ConditionType = "CircuitOpen"
 )
 
 const (
@@ -39,6 +42,9 @@ const (
 	ScaledObjectConditionReadySucccesReason = "ScaledObjectReady"
 	// ScaledObjectConditionReadySuccessMessage defines the default Message for correct ScaledObject
 	ScaledObjectConditionReadySuccessMessage = "ScaledObject is defined correctly and is ready for scaling"
+	// ScaledObjectConditionConflictReason defines the Reason of not ready ScaledObject which targets a workload
+	// already scaled by another ScaledObject or HPA
+	ScaledObjectConditionConflictReason = "ScaledObjectConflict"
 )
 
 // Condition to store the condition state
@@ -147,6 +153,19 @@ func (c *Conditions) SetFallbackCondition(status metav1.ConditionStatus, reason
 	c.setCondition(ConditionFallback, status, reason, message)
 }
 
+// SetCircuitOpenCondition modifies CircuitOpen Condition according to input parameters, the Condition is added if it's missing
+func (c *Conditions) SetCircuitOpenCondition(status metav1.ConditionStatus, reason string, message string) {
+	for i := range *c {
+		if (*c)[i].Type == ConditionCircuitOpen {
+			(*c)[i].Status = status
+			(*c)[i].Reason = reason
+			(*c)[i].Message = message
+			return
+		}
+	}
+	*c = append(*c, Condition{Type: ConditionCircuitOpen, Status: status, Reason: reason, Message: message})
+}
+
 // GetActiveCondition returns Condition of type Active
 func (c *Conditions) GetActiveCondition() Condition {
 	if *c == nil {
@@ -171,6 +190,11 @@ func (c *Conditions) GetFallbackCondition() Condition {
 	return c.getCondition(ConditionFallback)
 }
 
+// GetCircuitOpenCondition returns Condition of type CircuitOpen, it's empty if no circuit has opened yet
+func (c *Conditions) GetCircuitOpenCondition() Condition {
+	return c.getCondition(ConditionCircuitOpen)
+}
+
 func (c Conditions) getCondition(conditionType ConditionType) Condition {
 	for i := range c {
 		if c[i].Type == conditionType {
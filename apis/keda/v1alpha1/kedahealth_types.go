@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KedaHealthName is the name of the singleton KedaHealth resource maintained by KEDA Operator
+const KedaHealthName = "keda"
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=kedahealths,scope=Cluster,shortName=kh
+// +kubebuilder:printcolumn:name="ScaledObjects",type="integer",JSONPath=".status.scaledObjects"
+// +kubebuilder:printcolumn:name="ScaledJobs",type="integer",JSONPath=".status.scaledJobs"
+// +kubebuilder:printcolumn:name="Paused",type="integer",JSONPath=".status.pausedScaledObjects"
+// +kubebuilder:printcolumn:name="NotReady",type="integer",JSONPath=".status.notReadyScaledObjects"
+// +kubebuilder:printcolumn:name="AdapterConnected",type="boolean",JSONPath=".status.adapterConnected"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// KedaHealth is a cluster-scoped singleton summarizing the health of all resources managed by KEDA
+type KedaHealth struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Status KedaHealthStatus `json:"status,omitempty"`
+}
+
+// KedaHealthStatus is the aggregated status of all ScaledObjects and ScaledJobs in the cluster
+type KedaHealthStatus struct {
+	// +optional
+	ScaledObjects int32 `json:"scaledObjects"`
+	// +optional
+	ScaledJobs int32 `json:"scaledJobs"`
+	// +optional
+	PausedScaledObjects int32 `json:"pausedScaledObjects"`
+	// +optional
+	NotReadyScaledObjects int32 `json:"notReadyScaledObjects"`
+	// +optional
+	NotReadyScaledJobs int32 `json:"notReadyScaledJobs"`
+	// FailingTriggers holds the number of failing triggers by trigger type, triggers of ScaledObjects are failing based on
+	// their health status, all triggers of not ready ScaledJobs are failing
+	// +optional
+	FailingTriggers map[string]int32 `json:"failingTriggers,omitempty"`
+	// AdapterConnected reports whether KEDA Metrics Server is connected to the Metrics Service of KEDA Operator
+	// +optional
+	AdapterConnected bool `json:"adapterConnected"`
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KedaHealthList is a list of KedaHealth resources
+type KedaHealthList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []KedaHealth `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KedaHealth{}, &KedaHealthList{})
+}
@@ -21,6 +21,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// ScaledJobGenerationLabel is the label of Jobs with the generation of the ScaledJob they were created from,
+// the default rollout strategy deletes only the Jobs created from previous generations
+const ScaledJobGenerationLabel = "scaledjob.keda.sh/generation"
+
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
@@ -48,8 +52,12 @@ type ScaledJobSpec struct {
 	// +optional
 	PollingInterval *int32 `json:"pollingInterval,omitempty"`
 	// +optional
+	AdaptivePolling *AdaptivePollingConfig `json:"adaptivePolling,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=0
 	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
 	// +optional
+	// +kubebuilder:validation:Minimum=0
 	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
 	// +optional
 	RolloutStrategy string `json:"rolloutStrategy,omitempty"`
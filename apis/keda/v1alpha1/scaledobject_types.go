@@ -17,6 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -48,12 +52,20 @@ type ScaledObject struct {
 
 const ScaledObjectOwnerAnnotation = "scaledobject.keda.sh/name"
 
+// ScaledObjectTransferHpaOwnershipAnnotation set to "true" makes the ScaledObject adopt the existing HPA given by
+// advanced.horizontalPodAutoscalerConfig.name instead of reporting a conflict with it
+const ScaledObjectTransferHpaOwnershipAnnotation = "scaledobject.keda.sh/transfer-hpa-ownership"
+
 // HealthStatus is the status for a ScaledObject's health
 type HealthStatus struct {
 	// +optional
 	NumberOfFailures *int32 `json:"numberOfFailures,omitempty"`
 	// +optional
 	Status HealthStatusType `json:"status,omitempty"`
+	// ErrorCategory is the category of the last error of the trigger (Auth, Network, Throttling, BadQuery or Internal),
+	// it's set only when the status is failing
+	// +optional
+	ErrorCategory string `json:"errorCategory,omitempty"`
 }
 
 // HealthStatusType is an indication of whether the health status is happy or failing
@@ -73,7 +85,14 @@ type ScaledObjectSpec struct {
 	// +optional
 	PollingInterval *int32 `json:"pollingInterval,omitempty"`
 	// +optional
+	AdaptivePolling *AdaptivePollingConfig `json:"adaptivePolling,omitempty"`
+	// +optional
 	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+	// InitialCooldownPeriod is the period in seconds after the creation of the ScaledObject during which
+	// the scale target isn't scaled to zero (or idleReplicaCount)
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialCooldownPeriod *int32 `json:"initialCooldownPeriod,omitempty"`
 	// +optional
 	IdleReplicaCount *int32 `json:"idleReplicaCount,omitempty"`
 	// +optional
@@ -84,8 +103,92 @@ type ScaledObjectSpec struct {
 	Advanced *AdvancedConfig `json:"advanced,omitempty"`
 
 	Triggers []ScaleTriggers `json:"triggers"`
+	// ActivationStrategy specifies whether the scale target is activated when any of the triggers is active (AnyOf,
+	// the default) or only when all of them are (AllOf), cpu and memory triggers don't take part in AllOf
+	// +kubebuilder:validation:Enum=AnyOf;AllOf
+	// +optional
+	ActivationStrategy string `json:"activationStrategy,omitempty"`
 	// +optional
 	Fallback *Fallback `json:"fallback,omitempty"`
+	// +optional
+	DecisionHistory *DecisionHistoryConfig `json:"decisionHistory,omitempty"`
+}
+
+// Activation strategies of ScaledObjects
+const (
+	// ActivationStrategyAnyOf activates the scale target when any of the triggers is active
+	ActivationStrategyAnyOf = "AnyOf"
+	// ActivationStrategyAllOf activates the scale target only when all of the triggers are active
+	ActivationStrategyAllOf = "AllOf"
+)
+
+// AdaptivePollingConfig specifies how the polling interval is lengthened while metric values of all triggers are stable,
+// the interval is doubled after every StableChecks checks with stable values up to MaxPollingInterval
+// and it's reset to pollingInterval as soon as any of the values changes or a check fails
+type AdaptivePollingConfig struct {
+	// +kubebuilder:validation:Minimum=1
+	MaxPollingInterval int32 `json:"maxPollingInterval"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	StableChecks *int32 `json:"stableChecks,omitempty"`
+	// Tolerance is the relative change of a metric value which is still considered stable, eg. "0.1" for 10%
+	// +optional
+	Tolerance string `json:"tolerance,omitempty"`
+}
+
+// DecisionHistoryConfig specifies how many of the latest scaling decisions are kept in status.scalingDecisions
+// and, optionally, in a ConfigMap in the namespace of the ScaledObject, so it can be reconstructed why the scale
+// target was scaled. Only decisions taking an action on the scale target are recorded.
+type DecisionHistoryConfig struct {
+	// Limit is the number of decisions kept in status, 10 by default
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	Limit *int32 `json:"limit,omitempty"`
+	// ConfigMapName is the ConfigMap the decisions are kept in as well, under the key of the ScaledObject,
+	// it's created if it doesn't exist
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// ConfigMapLimit is the number of decisions kept in the ConfigMap, 100 by default
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	ConfigMapLimit *int32 `json:"configMapLimit,omitempty"`
+}
+
+// Actions of scaling decisions
+const (
+	// ScalingActionActivate scales the scale target from zero or idleReplicaCount
+	ScalingActionActivate = "Activate"
+	// ScalingActionDeactivate scales the scale target to zero or idleReplicaCount
+	ScalingActionDeactivate = "Deactivate"
+	// ScalingActionScaleToMinReplicas scales the scale target up to minReplicaCount
+	ScalingActionScaleToMinReplicas = "ScaleToMinReplicas"
+	// ScalingActionFallback scales the scale target to the fallback replicas while the triggers fail
+	ScalingActionFallback = "Fallback"
+	// ScalingActionPause scales the scale target to the paused replica count
+	ScalingActionPause = "Pause"
+)
+
+// ScalingDecision is a decision of KEDA to scale the scale target of a ScaledObject
+type ScalingDecision struct {
+	Time   metav1.Time `json:"time"`
+	Action string      `json:"action"`
+	// Active is whether any trigger was active
+	Active bool `json:"active"`
+	// TriggerError is whether any trigger failed
+	// +optional
+	TriggerError bool `json:"triggerError,omitempty"`
+	// Metrics are the metric values of the triggers by metric name
+	// +optional
+	Metrics map[string]string `json:"metrics,omitempty"`
+	// CurrentReplicas is the replica count of the scale target before the decision
+	CurrentReplicas int32 `json:"currentReplicas"`
+	// TargetReplicas is the replica count computed by the decision
+	TargetReplicas int32 `json:"targetReplicas"`
+	// Error is the error of scaling the scale target, if it failed
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // Fallback is the spec for fallback options
@@ -100,6 +203,75 @@ type AdvancedConfig struct {
 	HorizontalPodAutoscalerConfig *HorizontalPodAutoscalerConfig `json:"horizontalPodAutoscalerConfig,omitempty"`
 	// +optional
 	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
+	// +optional
+	ActivationReadiness *ActivationReadinessConfig `json:"activationReadiness,omitempty"`
+	// +optional
+	ReplicaCalculator *ReplicaCalculator `json:"replicaCalculator,omitempty"`
+	// ClampMaxReplicaCountToParallelism limits maxReplicaCount of the HPA to the parallelism of partitioned sources
+	// of the triggers (eg. number of Kafka partitions) reported in status.maxParallelism
+	// +optional
+	ClampMaxReplicaCountToParallelism bool `json:"clampMaxReplicaCountToParallelism,omitempty"`
+	// ScaleToZeroInactiveChecks is the number of consecutive polls with all triggers inactive required
+	// before the scale target is scaled to zero (or idleReplicaCount), in addition to the cooldownPeriod
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ScaleToZeroInactiveChecks *int32 `json:"scaleToZeroInactiveChecks,omitempty"`
+}
+
+// ReplicaCalculator defines the strategy used to post-process the number of replicas proportional to the metric value
+// before the metric is exposed to the HPA, it's applied only to triggers with metric of type AverageValue
+type ReplicaCalculator struct {
+	// Strategy is one of: default, step, logistic
+	// +kubebuilder:validation:Enum=default;step;logistic
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+	// StepSize is used by the step strategy, the number of replicas is rounded up to a multiple of it
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	StepSize *int32 `json:"stepSize,omitempty"`
+	// LogisticMidpoint is used by the logistic strategy, it's the number of proportional replicas
+	// at which half of maxReplicaCount is reached, defaults to half of maxReplicaCount
+	// +optional
+	LogisticMidpoint string `json:"logisticMidpoint,omitempty"`
+	// LogisticGrowthRate is used by the logistic strategy, it's the steepness of the curve, defaults to 1
+	// +optional
+	LogisticGrowthRate string `json:"logisticGrowthRate,omitempty"`
+}
+
+// Validate returns an error if the parameters of the strategy are invalid
+func (c *ReplicaCalculator) Validate() error {
+	switch c.Strategy {
+	case "step":
+		if c.StepSize == nil || *c.StepSize < 1 {
+			return fmt.Errorf("stepSize must be a positive integer with strategy step")
+		}
+	case "logistic":
+		if c.LogisticMidpoint != "" {
+			midpoint, err := strconv.ParseFloat(c.LogisticMidpoint, 64)
+			if err != nil || math.IsNaN(midpoint) || math.IsInf(midpoint, 0) || midpoint < 0 {
+				return fmt.Errorf("logisticMidpoint must be a non-negative number, got %q", c.LogisticMidpoint)
+			}
+		}
+		if c.LogisticGrowthRate != "" {
+			growthRate, err := strconv.ParseFloat(c.LogisticGrowthRate, 64)
+			if err != nil || math.IsNaN(growthRate) || math.IsInf(growthRate, 0) || growthRate <= 0 {
+				return fmt.Errorf("logisticGrowthRate must be a positive number, got %q", c.LogisticGrowthRate)
+			}
+		}
+	}
+	return nil
+}
+
+// ActivationReadinessConfig specifies that activation of the scale target is reported only once the target is ready
+type ActivationReadinessConfig struct {
+	// Condition is the type of the condition in status of the scale target that has to be True,
+	// if not set the scale target is ready once it has at least one ready replica (status.readyReplicas)
+	// +optional
+	Condition string `json:"condition,omitempty"`
+	// TimeoutSeconds after which a warning event is emitted if the scale target isn't ready yet
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 }
 
 // HorizontalPodAutoscalerConfig specifies horizontal scale config
@@ -124,16 +296,49 @@ type ScaleTarget struct {
 // ScaleTriggers reference the scaler that will be used
 type ScaleTriggers struct {
 	Type string `json:"type"`
+	// Name of the trigger, it must be unique among the triggers, also once characters other than letters, digits, '_' and '-'
+	// are replaced by '-', external metric names of the trigger are derived from it instead of the index of the trigger,
+	// so they don't change once the triggers are reordered
 	// +optional
 	Name string `json:"name,omitempty"`
+	// Enabled turns the trigger off if it's false, the trigger isn't checked and no metrics are reported for it,
+	// so it's disabled temporarily (eg. during a migration of the broker) without losing its configuration
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
 
 	UseCachedMetrics bool `json:"useCachedMetrics,omitempty"`
 
 	Metadata map[string]string `json:"metadata"`
 	// +optional
 	AuthenticationRef *ScaledObjectAuthRef `json:"authenticationRef,omitempty"`
+	// MetricType is the target type of the metric of the trigger in the HPA, Utilization is supported by cpu and memory triggers only
+	// +kubebuilder:validation:Enum=AverageValue;Value;Utilization
 	// +optional
 	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// MetricFormula is an arithmetic expression transforming the metric value of the trigger before it's handed to the HPA
+	// or used to scale the jobs of ScaledJob. The metric value is referenced as value, eg. "value / 4" or "max(value - 100, 0)",
+	// other values known to the scaler can't be referenced and the activity of the trigger isn't affected
+	// +optional
+	MetricFormula string `json:"metricFormula,omitempty"`
+}
+
+// IsEnabled returns true unless the trigger is explicitly disabled
+func (t ScaleTriggers) IsEnabled() bool {
+	return t.Enabled == nil || *t.Enabled
+}
+
+// MaintenanceWindow is a recurring window during which the trigger is ignored,
+// it starts on the Start schedule and lasts until the next End schedule
+type MaintenanceWindow struct {
+	// Start is a cron expression of the start of the window
+	Start string `json:"start"`
+	// End is a cron expression of the end of the window
+	End string `json:"end"`
+	// Timezone of the schedules, eg. Europe/Prague, UTC is used by default
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -161,6 +366,25 @@ type ScaledObjectStatus struct {
 	PausedReplicaCount *int32 `json:"pausedReplicaCount,omitempty"`
 	// +optional
 	HpaName string `json:"hpaName,omitempty"`
+	// PendingActivationTime is set when the scale target was activated but it isn't ready yet
+	// +optional
+	PendingActivationTime *metav1.Time `json:"pendingActivationTime,omitempty"`
+	// MaxParallelism is the number of replicas able to consume partitioned sources of the triggers in parallel
+	// (eg. number of Kafka partitions), the lowest one if there are several partitioned sources
+	// +optional
+	MaxParallelism *int32 `json:"maxParallelism,omitempty"`
+	// RecommendedReplicaCount is the replica count KEDA would scale the scale target to,
+	// it's recorded instead of scaling when the ScaledObject is in dry-run mode
+	// +optional
+	RecommendedReplicaCount *int32 `json:"recommendedReplicaCount,omitempty"`
+	// DesiredReplicaCount is the replica count KEDA and the HPA scale the scale target to for the latest metric values
+	// of the triggers, ie. the paused, fallback, idle or minimum replica count, or the replica count calculated for
+	// the metric values within the bounds of the HPA, it can be used to pre-warm the scale target in another cluster
+	// +optional
+	DesiredReplicaCount *int32 `json:"desiredReplicaCount,omitempty"`
+	// ScalingDecisions are the latest scaling decisions if the decision history is enabled, the oldest first
+	// +optional
+	ScalingDecisions []ScalingDecision `json:"scalingDecisions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
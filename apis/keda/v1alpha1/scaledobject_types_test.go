@@ -0,0 +1,40 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/utils/pointer"
+)
+
+type replicaCalculatorTestData struct {
+	name       string
+	calculator ReplicaCalculator
+	isError    bool
+}
+
+var replicaCalculatorTests = []replicaCalculatorTestData{
+	{name: "default strategy", calculator: ReplicaCalculator{}, isError: false},
+	{name: "step strategy", calculator: ReplicaCalculator{Strategy: "step", StepSize: pointer.Int32(3)}, isError: false},
+	{name: "step strategy without step size", calculator: ReplicaCalculator{Strategy: "step"}, isError: true},
+	{name: "step strategy with zero step size", calculator: ReplicaCalculator{Strategy: "step", StepSize: pointer.Int32(0)}, isError: true},
+	{name: "logistic strategy with defaults", calculator: ReplicaCalculator{Strategy: "logistic"}, isError: false},
+	{name: "logistic strategy", calculator: ReplicaCalculator{Strategy: "logistic", LogisticMidpoint: "0", LogisticGrowthRate: "1.5"}, isError: false},
+	{name: "logistic strategy with unparsable midpoint", calculator: ReplicaCalculator{Strategy: "logistic", LogisticMidpoint: "half"}, isError: true},
+	{name: "logistic strategy with negative midpoint", calculator: ReplicaCalculator{Strategy: "logistic", LogisticMidpoint: "-1"}, isError: true},
+	{name: "logistic strategy with infinite midpoint", calculator: ReplicaCalculator{Strategy: "logistic", LogisticMidpoint: "Inf"}, isError: true},
+	{name: "logistic strategy with unparsable growth rate", calculator: ReplicaCalculator{Strategy: "logistic", LogisticGrowthRate: "fast"}, isError: true},
+	{name: "logistic strategy with zero growth rate", calculator: ReplicaCalculator{Strategy: "logistic", LogisticGrowthRate: "0"}, isError: true},
+	{name: "logistic strategy with NaN growth rate", calculator: ReplicaCalculator{Strategy: "logistic", LogisticGrowthRate: "NaN"}, isError: true},
+}
+
+func TestReplicaCalculatorValidate(t *testing.T) {
+	for _, test := range replicaCalculatorTests {
+		err := test.calculator.Validate()
+		if test.isError && err == nil {
+			t.Errorf("test %s: expected error but got success", test.name)
+		}
+		if !test.isError && err != nil {
+			t.Errorf("test %s: expected success but got error: %s", test.name, err)
+		}
+	}
+}
@@ -43,6 +43,21 @@ var restMapper meta.RESTMapper
 var memoryString = "memory"
 var cpuString = "cpu"
 
+// defaultMaxReplicaCount is the maxReplicaCount of the HPA generated for ScaledObjects without it
+var defaultMaxReplicaCount int32 = 100
+
+// TriggerValidator validates type and metadata of a trigger, eg. whether the type is supported
+// and all metadata required by the scaler of the type are given
+// +kubebuilder:object:generate=false
+type TriggerValidator func(trigger ScaleTriggers) error
+
+var triggerValidator TriggerValidator
+
+// SetTriggerValidator sets the validator of triggers of ScaledObjects, triggers aren't validated without it
+func SetTriggerValidator(validator TriggerValidator) {
+	triggerValidator = validator
+}
+
 func (so *ScaledObject) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	kc = mgr.GetClient()
 	restMapper = mgr.GetRESTMapper()
@@ -74,7 +89,19 @@ func (so *ScaledObject) ValidateDelete() error {
 
 func validateWorkload(so *ScaledObject, action string) error {
 	prommetrics.RecordScaledObjectValidatingTotal(so.Namespace, action)
-	err := verifyCPUMemoryScalers(so, action)
+	err := verifyReplicaCounts(so, action)
+	if err != nil {
+		return err
+	}
+	err = verifyReplicaCalculator(so, action)
+	if err != nil {
+		return err
+	}
+	err = verifyTriggers(so, action)
+	if err != nil {
+		return err
+	}
+	err = verifyCPUMemoryScalers(so, action)
 	if err != nil {
 		return err
 	}
@@ -91,6 +118,55 @@ func validateWorkload(so *ScaledObject, action string) error {
 	return nil
 }
 
+func verifyReplicaCounts(incomingSo *ScaledObject, action string) error {
+	minReplicaCount := int32(0)
+	if incomingSo.Spec.MinReplicaCount != nil {
+		minReplicaCount = *incomingSo.Spec.MinReplicaCount
+	}
+	maxReplicaCount := defaultMaxReplicaCount
+	if incomingSo.Spec.MaxReplicaCount != nil {
+		maxReplicaCount = *incomingSo.Spec.MaxReplicaCount
+	}
+
+	if minReplicaCount > maxReplicaCount {
+		err := fmt.Errorf("minReplicaCount=%d must be less than or equal to maxReplicaCount=%d", minReplicaCount, maxReplicaCount)
+		scaledobjectlog.Error(err, "validation error")
+		prommetrics.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "wrong-replica-counts")
+		return err
+	}
+	return nil
+}
+
+func verifyReplicaCalculator(incomingSo *ScaledObject, action string) error {
+	if incomingSo.Spec.Advanced == nil || incomingSo.Spec.Advanced.ReplicaCalculator == nil {
+		return nil
+	}
+
+	if err := incomingSo.Spec.Advanced.ReplicaCalculator.Validate(); err != nil {
+		err = fmt.Errorf("replicaCalculator is invalid: %w", err)
+		scaledobjectlog.Error(err, "validation error")
+		prommetrics.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "invalid-replica-calculator")
+		return err
+	}
+	return nil
+}
+
+func verifyTriggers(incomingSo *ScaledObject, action string) error {
+	if triggerValidator == nil {
+		return nil
+	}
+
+	for i, trigger := range incomingSo.Spec.Triggers {
+		if err := triggerValidator(trigger); err != nil {
+			err = fmt.Errorf("trigger %d is invalid: %w", i, err)
+			scaledobjectlog.Error(err, "validation error")
+			prommetrics.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "invalid-trigger")
+			return err
+		}
+	}
+	return nil
+}
+
 func verifyHpas(incomingSo *ScaledObject, action string) error {
 	hpaList := &autoscalingv2.HorizontalPodAutoscalerList{}
 	opt := &client.ListOptions{
@@ -185,6 +261,10 @@ func verifyScaledObjects(incomingSo *ScaledObject, action string) error {
 func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string) error {
 	var podSpec *corev1.PodSpec
 	for _, trigger := range incomingSo.Spec.Triggers {
+		// disabled triggers aren't checked, they don't need any requests
+		if !trigger.IsEnabled() {
+			continue
+		}
 		if trigger.Type == cpuString || trigger.Type == memoryString {
 			if podSpec == nil {
 				key := types.NamespacedName{
@@ -247,7 +327,7 @@ func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string) error {
 			// return an error because it will never scale to zero
 			scaleToZeroErr := true
 			for _, trig := range incomingSo.Spec.Triggers {
-				if trig.Type != cpuString && trig.Type != memoryString {
+				if trig.IsEnabled() && trig.Type != cpuString && trig.Type != memoryString {
 					scaleToZeroErr = false
 					break
 				}
@@ -120,6 +120,7 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
+	SetTriggerValidator(validateTestTrigger)
 	err = (&ScaledObject{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -433,6 +434,47 @@ var _ = It("shouldn't validate so creation with cpu scaler requirements not bein
 	Expect(err).To(HaveOccurred())
 })
 
+var _ = It("should validate the so creation with disabled cpu and memory triggers when deployment hasn't got requests", func() {
+	namespaceName := "deployment-disabled-cpu-memory"
+	namespace := createNamespace(namespaceName)
+	workload := createDeployment(namespaceName, false, false)
+	so := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", true)
+	for i := range so.Spec.Triggers {
+		if so.Spec.Triggers[i].Type == cpuString || so.Spec.Triggers[i].Type == memoryString {
+			so.Spec.Triggers[i].Enabled = pointer.Bool(false)
+		}
+	}
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = k8sClient.Create(context.Background(), workload)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = k8sClient.Create(context.Background(), so)
+	Expect(err).ToNot(HaveOccurred())
+})
+
+var _ = It("shouldn't validate so creation with cpu scaler and disabled external trigger for scaling to 0", func() {
+	namespaceName := "scale-to-zero-disabled-external-trigger-bad"
+	namespace := createNamespace(namespaceName)
+	workload := createDeployment(namespaceName, true, false)
+
+	scaledobject := createScaledObjectSTZ(soName, namespaceName, workloadName, 0, 5, true)
+	for i := range scaledobject.Spec.Triggers {
+		if scaledobject.Spec.Triggers[i].Type != cpuString {
+			scaledobject.Spec.Triggers[i].Enabled = pointer.Bool(false)
+		}
+	}
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+	err = k8sClient.Create(context.Background(), workload)
+	Expect(err).ToNot(HaveOccurred())
+	err = k8sClient.Create(context.Background(), scaledobject)
+	Expect(err).To(HaveOccurred())
+})
+
 var _ = It("should validate so creation when min replicas is > 0 with only cpu scaler given", func() {
 	namespaceName := "scale-to-zero-no-external-trigger-good"
 	namespace := createNamespace(namespaceName)
@@ -449,6 +491,104 @@ var _ = It("should validate so creation when min replicas is > 0 with only cpu s
 
 })
 
+var _ = It("shouldn't validate so creation when min replicas is greater than max replicas", func() {
+	namespaceName := "min-replicas-greater-than-max"
+	namespace := createNamespace(namespaceName)
+
+	scaledobject := createScaledObjectSTZ(soName, namespaceName, workloadName, 5, 1, true)
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+	err = k8sClient.Create(context.Background(), scaledobject)
+	Expect(err).To(HaveOccurred())
+})
+
+var _ = It("shouldn't validate so creation with unknown trigger type", func() {
+	namespaceName := "unknown-trigger-type"
+	namespace := createNamespace(namespaceName)
+
+	scaledobject := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false)
+	scaledobject.Spec.Triggers[0].Type = "cronjob"
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+	err = k8sClient.Create(context.Background(), scaledobject)
+	Expect(err).To(HaveOccurred())
+})
+
+var _ = It("shouldn't validate so creation with missing required trigger metadata", func() {
+	namespaceName := "missing-trigger-metadata"
+	namespace := createNamespace(namespaceName)
+
+	scaledobject := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false)
+	delete(scaledobject.Spec.Triggers[0].Metadata, "timezone")
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+	err = k8sClient.Create(context.Background(), scaledobject)
+	Expect(err).To(HaveOccurred())
+})
+
+var _ = It("should validate so creation with valid logistic replica calculator", func() {
+	namespaceName := "valid-logistic-replica-calculator"
+	namespace := createNamespace(namespaceName)
+
+	scaledobject := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false)
+	scaledobject.Spec.Advanced = &AdvancedConfig{
+		ReplicaCalculator: &ReplicaCalculator{Strategy: "logistic", LogisticMidpoint: "5", LogisticGrowthRate: "0.5"},
+	}
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+	err = k8sClient.Create(context.Background(), scaledobject)
+	Expect(err).ToNot(HaveOccurred())
+})
+
+var _ = It("shouldn't validate so creation with invalid logistic midpoint", func() {
+	namespaceName := "invalid-logistic-midpoint"
+	namespace := createNamespace(namespaceName)
+
+	scaledobject := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false)
+	scaledobject.Spec.Advanced = &AdvancedConfig{
+		ReplicaCalculator: &ReplicaCalculator{Strategy: "logistic", LogisticMidpoint: "-1"},
+	}
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+	err = k8sClient.Create(context.Background(), scaledobject)
+	Expect(err).To(HaveOccurred())
+})
+
+var _ = It("shouldn't validate so creation with invalid logistic growth rate", func() {
+	namespaceName := "invalid-logistic-growth-rate"
+	namespace := createNamespace(namespaceName)
+
+	scaledobject := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false)
+	scaledobject.Spec.Advanced = &AdvancedConfig{
+		ReplicaCalculator: &ReplicaCalculator{Strategy: "logistic", LogisticGrowthRate: "fast"},
+	}
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+	err = k8sClient.Create(context.Background(), scaledobject)
+	Expect(err).To(HaveOccurred())
+})
+
+var _ = It("shouldn't validate so creation with step replica calculator without step size", func() {
+	namespaceName := "missing-step-size"
+	namespace := createNamespace(namespaceName)
+
+	scaledobject := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false)
+	scaledobject.Spec.Advanced = &AdvancedConfig{
+		ReplicaCalculator: &ReplicaCalculator{Strategy: "step"},
+	}
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+	err = k8sClient.Create(context.Background(), scaledobject)
+	Expect(err).To(HaveOccurred())
+})
+
 var _ = AfterSuite(func() {
 	cancel()
 	By("tearing down the test environment")
@@ -456,6 +596,26 @@ var _ = AfterSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 })
 
+// validateTestTrigger validates the triggers used by the tests like the trigger validator of KEDA Admission Webhooks
+func validateTestTrigger(trigger ScaleTriggers) error {
+	requiredMetadata := map[string][]string{
+		"cron":                {"timezone", "start", "end", "desiredReplicas"},
+		"cpu":                 {"value"},
+		"memory":              {"value"},
+		"kubernetes-workload": {"podSelector", "value"},
+	}
+	keys, ok := requiredMetadata[trigger.Type]
+	if !ok {
+		return fmt.Errorf("no scaler found for type: %s", trigger.Type)
+	}
+	for _, key := range keys {
+		if trigger.Metadata[key] == "" {
+			return fmt.Errorf("missing required metadata of %s trigger: %s", trigger.Type, key)
+		}
+	}
+	return nil
+}
+
 func createNamespace(name string) *v1.Namespace {
 	return &v1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{Name: name},
@@ -78,6 +78,12 @@ type TriggerAuthenticationSpec struct {
 
 	// +optional
 	AzureKeyVault *AzureKeyVault `json:"azureKeyVault,omitempty"`
+
+	// +optional
+	AwsSecretManager *AwsSecretManager `json:"awsSecretManager,omitempty"`
+
+	// +optional
+	GCPSecretManager *GCPSecretManager `json:"gcpSecretManager,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -115,8 +121,38 @@ const (
 // mechanism
 type AuthPodIdentity struct {
 	Provider PodIdentityProvider `json:"provider"`
+	// IdentityID is the client ID of the Azure identity or the email of the GCP service account impersonated by KEDA
 	// +optional
 	IdentityID string `json:"identityId"`
+	// RoleArn is the AWS role assumed with aws-eks and aws-kiam providers instead of the role of the workload
+	// +optional
+	RoleArn string `json:"roleArn,omitempty"`
+	// IdentityOwner selects whose identity is used if IdentityID or RoleArn isn't set, the identity of KEDA Operator
+	// (keda) or the identity given by the service account or pod annotations of the workload (workload).
+	// It defaults to workload for aws-eks and aws-kiam providers and to keda for the other providers.
+	// +kubebuilder:validation:Enum=keda;workload
+	// +optional
+	IdentityOwner string `json:"identityOwner,omitempty"`
+}
+
+// Owners of the identity used with pod identity
+const (
+	IdentityOwnerKeda     = "keda"
+	IdentityOwnerWorkload = "workload"
+)
+
+// Annotations of service accounts of workloads with their Azure Workload Identity and GCP Workload Identity
+const (
+	PodIdentityAnnotationAzureWorkload = "azure.workload.identity/client-id"
+	PodIdentityAnnotationGCP           = "iam.gke.io/gcp-service-account"
+)
+
+// IsWorkloadIdentityOwner returns true if the identity of the workload is used
+func (p *AuthPodIdentity) IsWorkloadIdentityOwner() bool {
+	if p.IdentityOwner == "" {
+		return p.Provider == PodIdentityProviderAwsEKS || p.Provider == PodIdentityProviderAwsKiam
+	}
+	return p.IdentityOwner == IdentityOwnerWorkload
 }
 
 // AuthSecretTargetRef is used to authenticate using a reference to a secret
@@ -124,6 +160,10 @@ type AuthSecretTargetRef struct {
 	Parameter string `json:"parameter"`
 	Name      string `json:"name"`
 	Key       string `json:"key"`
+	// Namespace of the secret, it's supported by ClusterTriggerAuthentication only and the namespace must be allowed
+	// by KEDA_CLUSTER_TRIGGER_AUTHENTICATION_ALLOWED_NAMESPACES, the cluster object namespace is used if it isn't set
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // AuthEnvironment is used to authenticate using environment variables
@@ -227,6 +267,72 @@ type AzureKeyVaultCloudInfo struct {
 	ActiveDirectoryEndpoint string `json:"activeDirectoryEndpoint"`
 }
 
+// AwsSecretManager is used to authenticate using AWS Secrets Manager
+type AwsSecretManager struct {
+	Secrets []AwsSecretManagerSecret `json:"secrets"`
+	// +optional
+	Credentials *AwsSecretManagerCredentials `json:"credentials,omitempty"`
+	// +optional
+	PodIdentity *AuthPodIdentity `json:"podIdentity,omitempty"`
+	// +optional
+	Region string `json:"region,omitempty"`
+}
+
+// AwsSecretManagerCredentials are static credentials of AWS given by Kubernetes Secrets
+type AwsSecretManagerCredentials struct {
+	AccessKey       *AwsSecretManagerValue `json:"accessKey"`
+	AccessSecretKey *AwsSecretManagerValue `json:"accessSecretKey"`
+	// +optional
+	AccessToken *AwsSecretManagerValue `json:"accessToken,omitempty"`
+}
+
+type AwsSecretManagerValue struct {
+	ValueFrom ValueFromSecret `json:"valueFrom"`
+}
+
+// AwsSecretManagerSecret is a secret of AWS Secrets Manager, the whole secret value is used unless SecretKey
+// of a secret with JSON value is given
+type AwsSecretManagerSecret struct {
+	Parameter string `json:"parameter"`
+	Name      string `json:"name"`
+	// +optional
+	VersionID string `json:"versionId,omitempty"`
+	// +optional
+	VersionStage string `json:"versionStage,omitempty"`
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+}
+
+// GCPSecretManager is used to authenticate using GCP Secret Manager
+type GCPSecretManager struct {
+	Secrets []GCPSecretManagerSecret `json:"secrets"`
+	// +optional
+	Credentials *GCPCredentials `json:"credentials,omitempty"`
+	// +optional
+	PodIdentity *AuthPodIdentity `json:"podIdentity,omitempty"`
+	// ProjectID is the project of secrets given by their IDs, the project of the credentials is used if it isn't set
+	// +optional
+	ProjectID string `json:"projectId,omitempty"`
+}
+
+// GCPCredentials is the service account key in JSON format given by a Kubernetes Secret
+type GCPCredentials struct {
+	ClientSecret GCPSecretManagerClientSecret `json:"clientSecret"`
+}
+
+type GCPSecretManagerClientSecret struct {
+	ValueFrom ValueFromSecret `json:"valueFrom"`
+}
+
+// GCPSecretManagerSecret is a secret of GCP Secret Manager given by its ID or its resource name
+// (projects/<project>/secrets/<id>), the latest version is read if the version isn't set
+type GCPSecretManagerSecret struct {
+	Parameter string `json:"parameter"`
+	ID        string `json:"id"`
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(&ClusterTriggerAuthentication{}, &ClusterTriggerAuthenticationList{})
 	SchemeBuilder.Register(&TriggerAuthentication{}, &TriggerAuthenticationList{})
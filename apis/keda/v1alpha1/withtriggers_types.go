@@ -44,8 +44,9 @@ type WithTriggers struct {
 
 // WithTriggersSpec is the spec for a an object with triggers resource
 type WithTriggersSpec struct {
-	PollingInterval *int32          `json:"pollingInterval,omitempty"`
-	Triggers        []ScaleTriggers `json:"triggers"`
+	PollingInterval *int32                 `json:"pollingInterval,omitempty"`
+	AdaptivePolling *AdaptivePollingConfig `json:"adaptivePolling,omitempty"`
+	Triggers        []ScaleTriggers        `json:"triggers"`
 }
 
 // Assert that we implement the interfaces necessary to
@@ -105,6 +106,7 @@ func AsDuckWithTriggers(scalableObject interface{}) (*WithTriggers, error) {
 			InternalKind: "ScaledObject",
 			Spec: WithTriggersSpec{
 				PollingInterval: obj.Spec.PollingInterval,
+				AdaptivePolling: obj.Spec.AdaptivePolling,
 				Triggers:        obj.Spec.Triggers,
 			},
 		}, nil
@@ -115,6 +117,7 @@ func AsDuckWithTriggers(scalableObject interface{}) (*WithTriggers, error) {
 			InternalKind: "ScaledJob",
 			Spec: WithTriggersSpec{
 				PollingInterval: obj.Spec.PollingInterval,
+				AdaptivePolling: obj.Spec.AdaptivePolling,
 				Triggers:        obj.Spec.Triggers,
 			},
 		}, nil
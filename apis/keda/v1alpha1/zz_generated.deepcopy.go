@@ -27,6 +27,46 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActivationReadinessConfig) DeepCopyInto(out *ActivationReadinessConfig) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActivationReadinessConfig.
+func (in *ActivationReadinessConfig) DeepCopy() *ActivationReadinessConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ActivationReadinessConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptivePollingConfig) DeepCopyInto(out *AdaptivePollingConfig) {
+	*out = *in
+	if in.StableChecks != nil {
+		in, out := &in.StableChecks, &out.StableChecks
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptivePollingConfig.
+func (in *AdaptivePollingConfig) DeepCopy() *AdaptivePollingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptivePollingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
 	*out = *in
@@ -35,6 +75,21 @@ func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
 		*out = new(HorizontalPodAutoscalerConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ActivationReadiness != nil {
+		in, out := &in.ActivationReadiness, &out.ActivationReadiness
+		*out = new(ActivationReadinessConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicaCalculator != nil {
+		in, out := &in.ReplicaCalculator, &out.ReplicaCalculator
+		*out = new(ReplicaCalculator)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleToZeroInactiveChecks != nil {
+		in, out := &in.ScaleToZeroInactiveChecks, &out.ScaleToZeroInactiveChecks
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedConfig.
@@ -92,6 +147,117 @@ func (in *AuthSecretTargetRef) DeepCopy() *AuthSecretTargetRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsSecretManager) DeepCopyInto(out *AwsSecretManager) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]AwsSecretManagerSecret, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(AwsSecretManagerCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodIdentity != nil {
+		in, out := &in.PodIdentity, &out.PodIdentity
+		*out = new(AuthPodIdentity)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsSecretManager.
+func (in *AwsSecretManager) DeepCopy() *AwsSecretManager {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsSecretManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsSecretManagerCredentials) DeepCopyInto(out *AwsSecretManagerCredentials) {
+	*out = *in
+	if in.AccessKey != nil {
+		in, out := &in.AccessKey, &out.AccessKey
+		*out = new(AwsSecretManagerValue)
+		**out = **in
+	}
+	if in.AccessSecretKey != nil {
+		in, out := &in.AccessSecretKey, &out.AccessSecretKey
+		*out = new(AwsSecretManagerValue)
+		**out = **in
+	}
+	if in.AccessToken != nil {
+		in, out := &in.AccessToken, &out.AccessToken
+		*out = new(AwsSecretManagerValue)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsSecretManagerCredentials.
+func (in *AwsSecretManagerCredentials) DeepCopy() *AwsSecretManagerCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsSecretManagerCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsSecretManagerSecret) DeepCopyInto(out *AwsSecretManagerSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsSecretManagerSecret.
+func (in *AwsSecretManagerSecret) DeepCopy() *AwsSecretManagerSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsSecretManagerSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsSecretManagerValue) DeepCopyInto(out *AwsSecretManagerValue) {
+	*out = *in
+	out.ValueFrom = in.ValueFrom
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsSecretManagerValue.
+func (in *AwsSecretManagerValue) DeepCopy() *AwsSecretManagerValue {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsSecretManagerValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureEventGridTopic) DeepCopyInto(out *AzureEventGridTopic) {
+	*out = *in
+	if in.AuthenticationRef != nil {
+		in, out := &in.AuthenticationRef, &out.AuthenticationRef
+		*out = new(ScaledObjectAuthRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureEventGridTopic.
+func (in *AzureEventGridTopic) DeepCopy() *AzureEventGridTopic {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureEventGridTopic)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureKeyVault) DeepCopyInto(out *AzureKeyVault) {
 	*out = *in
@@ -193,6 +359,151 @@ func (in *AzureKeyVaultSecret) DeepCopy() *AzureKeyVaultSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventDestination) DeepCopyInto(out *CloudEventDestination) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(CloudEventHTTP)
+		**out = **in
+	}
+	if in.AzureEventGridTopic != nil {
+		in, out := &in.AzureEventGridTopic, &out.AzureEventGridTopic
+		*out = new(AzureEventGridTopic)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookDestination)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventDestination.
+func (in *CloudEventDestination) DeepCopy() *CloudEventDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventHTTP) DeepCopyInto(out *CloudEventHTTP) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventHTTP.
+func (in *CloudEventHTTP) DeepCopy() *CloudEventHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventSource) DeepCopyInto(out *CloudEventSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSource.
+func (in *CloudEventSource) DeepCopy() *CloudEventSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudEventSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventSourceList) DeepCopyInto(out *CloudEventSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudEventSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSourceList.
+func (in *CloudEventSourceList) DeepCopy() *CloudEventSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudEventSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventSourceSpec) DeepCopyInto(out *CloudEventSourceSpec) {
+	*out = *in
+	in.Destination.DeepCopyInto(&out.Destination)
+	in.EventSubscription.DeepCopyInto(&out.EventSubscription)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSourceSpec.
+func (in *CloudEventSourceSpec) DeepCopy() *CloudEventSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventSubscription) DeepCopyInto(out *CloudEventSubscription) {
+	*out = *in
+	if in.IncludedEventTypes != nil {
+		in, out := &in.IncludedEventTypes, &out.IncludedEventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedEventTypes != nil {
+		in, out := &in.ExcludedEventTypes, &out.ExcludedEventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSubscription.
+func (in *CloudEventSubscription) DeepCopy() *CloudEventSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterTriggerAuthentication) DeepCopyInto(out *ClusterTriggerAuthentication) {
 	*out = *in
@@ -300,6 +611,31 @@ func (in *Credential) DeepCopy() *Credential {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DecisionHistoryConfig) DeepCopyInto(out *DecisionHistoryConfig) {
+	*out = *in
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConfigMapLimit != nil {
+		in, out := &in.ConfigMapLimit, &out.ConfigMapLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DecisionHistoryConfig.
+func (in *DecisionHistoryConfig) DeepCopy() *DecisionHistoryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DecisionHistoryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Fallback) DeepCopyInto(out *Fallback) {
 	*out = *in
@@ -315,6 +651,83 @@ func (in *Fallback) DeepCopy() *Fallback {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPCredentials) DeepCopyInto(out *GCPCredentials) {
+	*out = *in
+	out.ClientSecret = in.ClientSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPCredentials.
+func (in *GCPCredentials) DeepCopy() *GCPCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPSecretManager) DeepCopyInto(out *GCPSecretManager) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]GCPSecretManagerSecret, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(GCPCredentials)
+		**out = **in
+	}
+	if in.PodIdentity != nil {
+		in, out := &in.PodIdentity, &out.PodIdentity
+		*out = new(AuthPodIdentity)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPSecretManager.
+func (in *GCPSecretManager) DeepCopy() *GCPSecretManager {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPSecretManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPSecretManagerClientSecret) DeepCopyInto(out *GCPSecretManagerClientSecret) {
+	*out = *in
+	out.ValueFrom = in.ValueFrom
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPSecretManagerClientSecret.
+func (in *GCPSecretManagerClientSecret) DeepCopy() *GCPSecretManagerClientSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPSecretManagerClientSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPSecretManagerSecret) DeepCopyInto(out *GCPSecretManagerSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPSecretManagerSecret.
+func (in *GCPSecretManagerSecret) DeepCopy() *GCPSecretManagerSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPSecretManagerSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupVersionKindResource) DeepCopyInto(out *GroupVersionKindResource) {
 	*out = *in
@@ -395,6 +808,125 @@ func (in *HorizontalPodAutoscalerConfig) DeepCopy() *HorizontalPodAutoscalerConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KedaHealth) DeepCopyInto(out *KedaHealth) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KedaHealth.
+func (in *KedaHealth) DeepCopy() *KedaHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(KedaHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KedaHealth) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KedaHealthList) DeepCopyInto(out *KedaHealthList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KedaHealth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KedaHealthList.
+func (in *KedaHealthList) DeepCopy() *KedaHealthList {
+	if in == nil {
+		return nil
+	}
+	out := new(KedaHealthList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KedaHealthList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KedaHealthStatus) DeepCopyInto(out *KedaHealthStatus) {
+	*out = *in
+	if in.FailingTriggers != nil {
+		in, out := &in.FailingTriggers, &out.FailingTriggers
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KedaHealthStatus.
+func (in *KedaHealthStatus) DeepCopy() *KedaHealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KedaHealthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaCalculator) DeepCopyInto(out *ReplicaCalculator) {
+	*out = *in
+	if in.StepSize != nil {
+		in, out := &in.StepSize, &out.StepSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaCalculator.
+func (in *ReplicaCalculator) DeepCopy() *ReplicaCalculator {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaCalculator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rollout) DeepCopyInto(out *Rollout) {
 	*out = *in
@@ -428,6 +960,11 @@ func (in *ScaleTarget) DeepCopy() *ScaleTarget {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleTriggers) DeepCopyInto(out *ScaleTriggers) {
 	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = make(map[string]string, len(*in))
@@ -440,6 +977,11 @@ func (in *ScaleTriggers) DeepCopyInto(out *ScaleTriggers) {
 		*out = new(ScaledObjectAuthRef)
 		**out = **in
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleTriggers.
@@ -524,6 +1066,11 @@ func (in *ScaledJobSpec) DeepCopyInto(out *ScaledJobSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.AdaptivePolling != nil {
+		in, out := &in.AdaptivePolling, &out.AdaptivePolling
+		*out = new(AdaptivePollingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SuccessfulJobsHistoryLimit != nil {
 		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
 		*out = new(int32)
@@ -676,11 +1223,21 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.AdaptivePolling != nil {
+		in, out := &in.AdaptivePolling, &out.AdaptivePolling
+		*out = new(AdaptivePollingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CooldownPeriod != nil {
 		in, out := &in.CooldownPeriod, &out.CooldownPeriod
 		*out = new(int32)
 		**out = **in
 	}
+	if in.InitialCooldownPeriod != nil {
+		in, out := &in.InitialCooldownPeriod, &out.InitialCooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
 	if in.IdleReplicaCount != nil {
 		in, out := &in.IdleReplicaCount, &out.IdleReplicaCount
 		*out = new(int32)
@@ -713,6 +1270,11 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 		*out = new(Fallback)
 		**out = **in
 	}
+	if in.DecisionHistory != nil {
+		in, out := &in.DecisionHistory, &out.DecisionHistory
+		*out = new(DecisionHistoryConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectSpec.
@@ -769,6 +1331,32 @@ func (in *ScaledObjectStatus) DeepCopyInto(out *ScaledObjectStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.PendingActivationTime != nil {
+		in, out := &in.PendingActivationTime, &out.PendingActivationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.MaxParallelism != nil {
+		in, out := &in.MaxParallelism, &out.MaxParallelism
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RecommendedReplicaCount != nil {
+		in, out := &in.RecommendedReplicaCount, &out.RecommendedReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DesiredReplicaCount != nil {
+		in, out := &in.DesiredReplicaCount, &out.DesiredReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScalingDecisions != nil {
+		in, out := &in.ScalingDecisions, &out.ScalingDecisions
+		*out = make([]ScalingDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectStatus.
@@ -781,6 +1369,29 @@ func (in *ScaledObjectStatus) DeepCopy() *ScaledObjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingDecision) DeepCopyInto(out *ScalingDecision) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingDecision.
+func (in *ScalingDecision) DeepCopy() *ScalingDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalingStrategy) DeepCopyInto(out *ScalingStrategy) {
 	*out = *in
@@ -907,6 +1518,16 @@ func (in *TriggerAuthenticationSpec) DeepCopyInto(out *TriggerAuthenticationSpec
 		*out = new(AzureKeyVault)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AwsSecretManager != nil {
+		in, out := &in.AwsSecretManager, &out.AwsSecretManager
+		*out = new(AwsSecretManager)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCPSecretManager != nil {
+		in, out := &in.GCPSecretManager, &out.GCPSecretManager
+		*out = new(GCPSecretManager)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationSpec.
@@ -950,6 +1571,21 @@ func (in *VaultSecret) DeepCopy() *VaultSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookDestination) DeepCopyInto(out *WebhookDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookDestination.
+func (in *WebhookDestination) DeepCopy() *WebhookDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WithTriggers) DeepCopyInto(out *WithTriggers) {
 	*out = *in
@@ -1016,6 +1652,11 @@ func (in *WithTriggersSpec) DeepCopyInto(out *WithTriggersSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.AdaptivePolling != nil {
+		in, out := &in.AdaptivePolling, &out.AdaptivePolling
+		*out = new(AdaptivePollingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
 		*out = make([]ScaleTriggers, len(*in))
@@ -21,20 +21,19 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
-	kubeinformers "k8s.io/client-go/informers"
-	"k8s.io/client-go/kubernetes"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/apiserver/pkg/server/healthz"
 	"k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
-	"k8s.io/klog/v2/klogr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	basecmd "sigs.k8s.io/custom-metrics-apiserver/pkg/cmd"
@@ -42,10 +41,14 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollers "github.com/kedacore/keda/v2/controllers/keda"
+	"github.com/kedacore/keda/v2/pkg/diagnostics"
+	"github.com/kedacore/keda/v2/pkg/health"
+	"github.com/kedacore/keda/v2/pkg/k8s"
+	"github.com/kedacore/keda/v2/pkg/logging"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
 	prommetrics "github.com/kedacore/keda/v2/pkg/prommetrics/adapter"
 	kedaprovider "github.com/kedacore/keda/v2/pkg/provider"
-	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -55,9 +58,15 @@ type Adapter struct {
 
 	// Message is printed on successful startup
 	Message string
+
+	// triggerCheckers are the Metrics Service clients of the shards of KEDA Operator checking triggers for kedaprovider.TriggerChecksPath
+	triggerCheckers []kedaprovider.TriggerChecker
+
+	// informerCache is the cache of the manager of the ScaledObject controller, it's checked by the health checks
+	informerCache cache.Cache
 }
 
-var logger = klogr.New().WithName("keda_metrics_adapter")
+var logger = logr.Discard()
 
 var (
 	prometheusMetricsPort     int
@@ -67,9 +76,12 @@ var (
 	metricsAPIServerPort      int
 	disableCompression        bool
 	metricsServiceAddr        string
+	watchNamespace            string
+	livenessGracePeriod       time.Duration
+	diagnosticsAddr           string
 )
 
-func (a *Adapter) makeProvider(ctx context.Context, globalHTTPTimeout time.Duration, maxConcurrentReconciles int) (provider.MetricsProvider, <-chan struct{}, error) {
+func (a *Adapter) makeProvider(ctx context.Context, maxConcurrentReconciles int) (provider.MetricsProvider, <-chan struct{}, error) {
 	scheme := scheme.Scheme
 	if err := appsv1.SchemeBuilder.AddToScheme(scheme); err != nil {
 		logger.Error(err, "failed to add apps/v1 scheme to runtime scheme")
@@ -79,7 +91,7 @@ func (a *Adapter) makeProvider(ctx context.Context, globalHTTPTimeout time.Durat
 		logger.Error(err, "failed to add keda scheme to runtime scheme")
 		return nil, nil, fmt.Errorf("failed to add keda scheme to runtime scheme (%s)", err)
 	}
-	namespace, err := getWatchNamespace()
+	namespace, err := k8s.ResolveWatchNamespace(watchNamespace, a.Flags().Changed("namespace"))
 	if err != nil {
 		logger.Error(err, "failed to get watch namespace")
 		return nil, nil, fmt.Errorf("failed to get watch namespace (%s)", err)
@@ -103,12 +115,6 @@ func (a *Adapter) makeProvider(ctx context.Context, globalHTTPTimeout time.Durat
 		return nil, nil, fmt.Errorf("invalid KEDA_METRICS_LEADER_ELECTION_RETRY_PERIOD (%s)", err)
 	}
 
-	useMetricsServiceGrpc, err := kedautil.ResolveOsEnvBool("KEDA_USE_METRICS_SERVICE_GRPC", true)
-	if err != nil {
-		logger.Error(err, "Invalid KEDA_USE_METRICS_SERVICE_GRPC")
-		return nil, nil, fmt.Errorf("invalid KEDA_USE_METRICS_SERVICE_GRPC (%s)", err)
-	}
-
 	// Get a config to talk to the apiserver
 	cfg := ctrl.GetConfigOrDie()
 	cfg.QPS = adapterClientRequestQPS
@@ -116,39 +122,30 @@ func (a *Adapter) makeProvider(ctx context.Context, globalHTTPTimeout time.Durat
 	cfg.DisableCompression = disableCompression
 
 	metricsBindAddress := fmt.Sprintf(":%v", metricsAPIServerPort)
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+	options := ctrl.Options{
 		MetricsBindAddress: metricsBindAddress,
 		Scheme:             scheme,
-		Namespace:          namespace,
 		LeaseDuration:      leaseDuration,
 		RenewDeadline:      renewDeadline,
 		RetryPeriod:        retryPeriod,
-	})
+	}
+	// WATCH_NAMESPACE can be a comma separated list of namespaces
+	k8s.ApplyWatchNamespace(&options, namespace)
+
+	mgr, err := ctrl.NewManager(cfg, options)
 	if err != nil {
 		logger.Error(err, "failed to setup manager")
 		return nil, nil, err
 	}
 
-	broadcaster := record.NewBroadcaster()
-	recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: "keda-metrics-adapter"})
+	a.informerCache = mgr.GetCache()
 
-	kubeClientset, err := kubernetes.NewForConfig(cfg)
-	if err != nil {
-		logger.Error(err, "Unable to create kube clientset")
-		return nil, nil, err
-	}
-	objectNamespace, err := kedautil.GetClusterObjectNamespace()
-	if err != nil {
-		logger.Error(err, "Unable to get cluster object namespace")
-		return nil, nil, err
+	if diagnosticsAddr != "" {
+		if err := mgr.Add(diagnostics.NewServer(diagnosticsAddr)); err != nil {
+			logger.Error(err, "unable to set up diagnostics server")
+			return nil, nil, err
+		}
 	}
-	// the namespaced kubeInformerFactory is used to restrict secret informer to only list/watch secrets in KEDA cluster object namespace,
-	// refer to https://github.com/kedacore/keda/issues/3668
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, 1*time.Hour, kubeinformers.WithNamespace(objectNamespace))
-	secretInformer := kubeInformerFactory.Core().V1().Secrets()
-
-	handler := scaling.NewScaleHandler(mgr.GetClient(), nil, scheme, globalHTTPTimeout, recorder, secretInformer.Lister())
-	kubeInformerFactory.Start(ctx.Done())
 
 	externalMetricsInfo := &[]provider.ExternalMetricInfo{}
 	externalMetricsInfoLock := &sync.RWMutex{}
@@ -157,24 +154,56 @@ func (a *Adapter) makeProvider(ctx context.Context, globalHTTPTimeout time.Durat
 	go func() { prometheusServer.NewServer(fmt.Sprintf(":%v", prometheusMetricsPort), prometheusMetricsPath) }()
 
 	stopCh := make(chan struct{})
-	if err := runScaledObjectController(ctx, mgr, handler, logger, externalMetricsInfo, externalMetricsInfoLock, maxConcurrentReconciles, stopCh, secretInformer.Informer().HasSynced); err != nil {
+	if err := runScaledObjectController(ctx, mgr, logger, externalMetricsInfo, externalMetricsInfoLock, maxConcurrentReconciles, stopCh); err != nil {
 		return nil, nil, err
 	}
 
-	logger.Info("Connecting Metrics Service gRPC client to the server", "address", metricsServiceAddr)
-	grpcClient, err := metricsservice.NewGrpcClient(metricsServiceAddr, a.SecureServing.ServerCert.CertDirectory)
+	// scalers are built only in KEDA Operator, which serves metric values through the gRPC Metrics Service,
+	// so KEDA Metrics Server doesn't need access to Secrets or the sources of the scalers
+	metricsServiceAddrs, err := getMetricsServiceAddrs(metricsServiceAddr)
 	if err != nil {
-		logger.Error(err, "error connecting Metrics Service gRPC client to the server", "address", metricsServiceAddr)
+		logger.Error(err, "invalid address of Metrics Service")
 		return nil, nil, err
 	}
+	grpcClients := make([]metricsservice.GrpcClient, 0, len(metricsServiceAddrs))
+	for _, addr := range metricsServiceAddrs {
+		logger.Info("Connecting Metrics Service gRPC client to the server", "address", addr)
+		grpcClient, err := metricsservice.NewGrpcClient(addr, a.SecureServing.ServerCert.CertDirectory)
+		if err != nil {
+			logger.Error(err, "error connecting Metrics Service gRPC client to the server", "address", addr)
+			return nil, nil, err
+		}
+		grpcClients = append(grpcClients, *grpcClient)
+		a.triggerCheckers = append(a.triggerCheckers, grpcClient)
+	}
 
-	return kedaprovider.NewProvider(ctx, logger, handler, mgr.GetClient(), *grpcClient, useMetricsServiceGrpc, namespace, externalMetricsInfo, externalMetricsInfoLock), stopCh, nil
+	return kedaprovider.NewProvider(ctx, logger, grpcClients, namespace, externalMetricsInfo, externalMetricsInfoLock), stopCh, nil
 }
 
-func runScaledObjectController(ctx context.Context, mgr manager.Manager, scaleHandler scaling.ScaleHandler, logger logr.Logger, externalMetricsInfo *[]provider.ExternalMetricInfo, externalMetricsInfoLock *sync.RWMutex, maxConcurrentReconciles int, stopCh chan<- struct{}, secretSynced cache.InformerSynced) error {
+// getMetricsServiceAddrs returns the addresses of the Metrics Service of all shards of KEDA Operator, if sharding
+// is enabled the address must contain %d which is replaced by the index of the shard,
+// eg. keda-operator-%d.keda-operator.keda.svc.cluster.local:9666 for KEDA Operator deployed as StatefulSet
+func getMetricsServiceAddrs(addr string) ([]string, error) {
+	shardCount, err := kedautil.ResolveOsEnvInt(sharding.ShardCountEnvVar, 1)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", sharding.ShardCountEnvVar, err)
+	}
+	if shardCount <= 1 {
+		return []string{addr}, nil
+	}
+	if strings.Count(addr, "%d") != 1 {
+		return nil, fmt.Errorf("metrics-service-address %q must contain %%d replaced by the index of the shard if %s is set", addr, sharding.ShardCountEnvVar)
+	}
+	addrs := make([]string, shardCount)
+	for i := range addrs {
+		addrs[i] = fmt.Sprintf(addr, i)
+	}
+	return addrs, nil
+}
+
+func runScaledObjectController(ctx context.Context, mgr manager.Manager, logger logr.Logger, externalMetricsInfo *[]provider.ExternalMetricInfo, externalMetricsInfoLock *sync.RWMutex, maxConcurrentReconciles int, stopCh chan<- struct{}) error {
 	if err := (&kedacontrollers.MetricsScaledObjectReconciler{
 		Client:                  mgr.GetClient(),
-		ScaleHandler:            scaleHandler,
 		ExternalMetricsInfo:     externalMetricsInfo,
 		ExternalMetricsInfoLock: externalMetricsInfoLock,
 	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}); err != nil {
@@ -189,28 +218,66 @@ func runScaledObjectController(ctx context.Context, mgr manager.Manager, scaleHa
 		}
 	}()
 
-	if ok := cache.WaitForCacheSync(ctx.Done(), secretSynced); !ok {
-		return fmt.Errorf("failed to wait Secrets cache synced")
+	return nil
+}
+
+// addHealthChecks adds the checks of the informer cache and the API server to /readyz, and to /healthz and /livez once
+// they've been failing for longer than livenessGracePeriod. The certificates of the metrics API server and of the Metrics
+// Service client are checked by all of them, the client doesn't reload its certificates, so the adapter is restarted.
+func (a *Adapter) addHealthChecks(config *genericapiserver.Config) error {
+	discoveryClient, err := a.DiscoveryClient()
+	if err != nil {
+		return err
 	}
+	cacheSynced := health.CacheSynced(a.informerCache)
+	apiServerReachable := health.APIServerReachable(discoveryClient.RESTClient())
+	certificatesValid := health.CertificatesValid(a.certificateFiles()...)
+
+	livenessChecks := []healthz.HealthChecker{
+		healthz.NamedCheck("informer-cache", health.FailingLongerThan(cacheSynced, livenessGracePeriod)),
+		healthz.NamedCheck("apiserver", health.FailingLongerThan(apiServerReachable, livenessGracePeriod)),
+		healthz.NamedCheck("certificates", certificatesValid),
+	}
+	config.HealthzChecks = append(config.HealthzChecks, livenessChecks...)
+	config.LivezChecks = append(config.LivezChecks, livenessChecks...)
+	config.ReadyzChecks = append(config.ReadyzChecks,
+		healthz.NamedCheck("informer-cache", cacheSynced),
+		healthz.NamedCheck("apiserver", apiServerReachable),
+		healthz.NamedCheck("certificates", certificatesValid),
+	)
 	return nil
 }
 
+// certificateFiles returns the certificate of the metrics API server, unless it's generated in memory, and the certificate
+// and CA of the Metrics Service client
+func (a *Adapter) certificateFiles() []string {
+	certDir := a.SecureServing.ServerCert.CertDirectory
+	servingCert := a.SecureServing.ServerCert.CertKey.CertFile
+	if servingCert == "" && certDir != "" {
+		servingCert = path.Join(certDir, a.SecureServing.ServerCert.PairName+".crt")
+	}
+	candidates := []string{servingCert}
+	if certDir != "" {
+		candidates = append(candidates, path.Join(certDir, "tls.crt"), path.Join(certDir, "ca.crt"))
+	}
+
+	files := []string{}
+	seen := map[string]bool{}
+	for _, file := range candidates {
+		if file != "" && !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
 // generateDefaultMetricsServiceAddr generates default Metrics Service gRPC Server address based on the current Namespace.
 // By default the Metrics Service gRPC Server runs in the same namespace on the keda-operator pod.
 func generateDefaultMetricsServiceAddr() string {
 	return fmt.Sprintf("keda-operator.%s.svc.cluster.local:9666", kedautil.GetPodNamespace())
 }
 
-// getWatchNamespace returns the namespace the operator should be watching for changes
-func getWatchNamespace() (string, error) {
-	const WatchNamespaceEnvVar = "WATCH_NAMESPACE"
-	ns, found := os.LookupEnv(WatchNamespaceEnvVar)
-	if !found {
-		return "", fmt.Errorf("%s must be set", WatchNamespaceEnvVar)
-	}
-	return ns, nil
-}
-
 // printWelcomeMsg prints welcome message during the start of the adater
 func printWelcomeMsg(cmd *Adapter) error {
 	clientset, err := cmd.DiscoveryClient()
@@ -239,32 +306,35 @@ func main() {
 
 	defer klog.Flush()
 	klog.InitFlags(nil)
+	logOpts := logging.Options{}
+	logOpts.BindFlags(flag.CommandLine)
 
 	cmd := &Adapter{}
 	cmd.Name = "keda-adapter"
 
 	cmd.Flags().StringVar(&cmd.Message, "msg", "starting adapter...", "startup message")
-	cmd.Flags().AddGoFlagSet(flag.CommandLine) // make sure we get the klog flags
+	cmd.Flags().AddGoFlagSet(flag.CommandLine) // make sure we get the klog and zap flags
 	cmd.Flags().IntVar(&metricsAPIServerPort, "port", 8080, "Set the port for the metrics API server")
 	cmd.Flags().IntVar(&prometheusMetricsPort, "metrics-port", 9022, "Set the port to expose prometheus metrics")
 	cmd.Flags().StringVar(&prometheusMetricsPath, "metrics-path", "/metrics", "Set the path for the prometheus metrics endpoint")
-	cmd.Flags().StringVar(&metricsServiceAddr, "metrics-service-address", generateDefaultMetricsServiceAddr(), "The address of the gRPRC Metrics Service Server.")
+	cmd.Flags().StringVar(&metricsServiceAddr, "metrics-service-address", generateDefaultMetricsServiceAddr(), "The address of the gRPRC Metrics Service Server, it must contain %d replaced by the index of the shard if KEDA_OPERATOR_SHARD_COUNT is set.")
+	cmd.Flags().StringVar(&watchNamespace, "namespace", "", "Comma separated list of namespaces to watch, all namespaces are watched if it's empty. Overrides WATCH_NAMESPACE")
 	cmd.Flags().Float32Var(&adapterClientRequestQPS, "kube-api-qps", 20.0, "Set the QPS rate for throttling requests sent to the apiserver")
 	cmd.Flags().IntVar(&adapterClientRequestBurst, "kube-api-burst", 30, "Set the burst for throttling requests sent to the apiserver")
 	cmd.Flags().BoolVar(&disableCompression, "disable-compression", true, "Disable response compression for k8s restAPI in client-go. ")
+	cmd.Flags().DurationVar(&livenessGracePeriod, "health-probe-liveness-grace-period", health.DefaultLivenessGracePeriod, "The period the informer cache can be out of sync or the API server unreachable for until the liveness probe fails.")
+	cmd.Flags().StringVar(&diagnosticsAddr, "diagnostics-bind-address", "", "The address the pprof and expvar endpoints (/debug/pprof/, /debug/vars) bind to, they are disabled if it's empty.")
 
 	if err := cmd.Flags().Parse(os.Args); err != nil {
 		return
 	}
 
-	ctrl.SetLogger(logger)
-
-	// default to 3 seconds if they don't pass the env var
-	globalHTTPTimeoutMS, err := kedautil.ResolveOsEnvInt("KEDA_HTTP_DEFAULT_TIMEOUT", 3000)
-	if err != nil {
-		logger.Error(err, "Invalid KEDA_HTTP_DEFAULT_TIMEOUT")
-		return
-	}
+	// logs of the Kubernetes libraries logging with klog are written by the zap logger as well,
+	// their verbose logs are written only if both -v and --zap-log-level enable their level
+	rootLogger := logging.New(&logOpts)
+	klog.SetLogger(rootLogger)
+	ctrl.SetLogger(rootLogger)
+	logger = rootLogger.WithName("keda_metrics_adapter")
 
 	controllerMaxReconciles, err := kedautil.ResolveOsEnvInt("KEDA_METRICS_CTRL_MAX_RECONCILES", 1)
 	if err != nil {
@@ -277,13 +347,32 @@ func main() {
 		return
 	}
 
-	kedaProvider, stopCh, err := cmd.makeProvider(ctx, time.Duration(globalHTTPTimeoutMS)*time.Millisecond, controllerMaxReconciles)
+	kedaProvider, stopCh, err := cmd.makeProvider(ctx, controllerMaxReconciles)
 	if err != nil {
 		logger.Error(err, "making provider")
 		return
 	}
 	cmd.WithExternalMetrics(kedaProvider)
 
+	// the caller of the trigger checks is authorized by the handler, against the namespace of the checked ScaledObject
+	cmd.Authorization.WithAlwaysAllowPaths(kedaprovider.TriggerChecksPath)
+	config, err := cmd.Config()
+	if err != nil {
+		logger.Error(err, "making config of the metrics API server")
+		return
+	}
+	if err = cmd.addHealthChecks(config.GenericConfig); err != nil {
+		logger.Error(err, "making health checks")
+		return
+	}
+	server, err := cmd.Server()
+	if err != nil {
+		logger.Error(err, "making metrics API server")
+		return
+	}
+	server.GenericAPIServer.Handler.NonGoRestfulMux.Handle(kedaprovider.TriggerChecksPath,
+		kedaprovider.NewTriggerChecksHandler(logger, config.GenericConfig.Authorization.Authorizer, cmd.triggerCheckers))
+
 	logger.Info(cmd.Message)
 	if err = cmd.Run(stopCh); err != nil {
 		return
@@ -0,0 +1,165 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	httpv1alpha1 "github.com/kedacore/keda/v2/apis/http/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/interceptor"
+	"github.com/kedacore/keda/v2/pkg/k8s"
+	"github.com/kedacore/keda/v2/pkg/logging"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+var (
+	scheme   = apimachineryruntime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(httpv1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	var metricsAddr string
+	var probeAddr string
+	var proxyAddr string
+	var adminAddr string
+	var waitTimeout time.Duration
+	var interceptorClientRequestQPS float32
+	var interceptorClientRequestBurst int
+	pflag.StringVar(&metricsAddr, "metrics-bind-address", ":8082", "The address the metric endpoint binds to.")
+	pflag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	pflag.StringVar(&proxyAddr, "proxy-bind-address", ":8080", "The address the proxy of routed requests binds to.")
+	pflag.StringVar(&adminAddr, "admin-bind-address", ":9090", "The address the queue endpoint queried by KEDA binds to.")
+	pflag.DurationVar(&waitTimeout, "wait-timeout", interceptor.DefaultWaitTimeout, "The longest time a request is held while its target is scaled from zero.")
+	pflag.Float32Var(&interceptorClientRequestQPS, "kube-api-qps", 20.0, "Set the QPS rate for throttling requests sent to the apiserver")
+	pflag.IntVar(&interceptorClientRequestBurst, "kube-api-burst", 30, "Set the burst for throttling requests sent to the apiserver")
+
+	opts := logging.Options{}
+	opts.BindFlags(flag.CommandLine)
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+
+	ctrl.SetLogger(logging.New(&opts))
+
+	ctx := ctrl.SetupSignalHandler()
+
+	cfg := ctrl.GetConfigOrDie()
+	cfg.QPS = interceptorClientRequestQPS
+	cfg.Burst = interceptorClientRequestBurst
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:                 scheme,
+		LeaderElection:         false,
+		MetricsBindAddress:     metricsAddr,
+		HealthProbeBindAddress: probeAddr,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to start interceptor")
+		os.Exit(1)
+	}
+
+	_, kubeVersion, err := k8s.InitScaleClient(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to init scale client")
+		os.Exit(1)
+	}
+
+	kedautil.PrintWelcome(setupLog, kubeVersion, "http interceptor")
+
+	// HTTPScaledObjects and Endpoints are read from the cache of the manager, requests are served once it's synced
+	for _, object := range []client.Object{&httpv1alpha1.HTTPScaledObject{}, &corev1.Endpoints{}} {
+		if _, err := mgr.GetCache().GetInformer(ctx, object); err != nil {
+			setupLog.Error(err, "unable to set up informer", "kind", fmt.Sprintf("%T", object))
+			os.Exit(1)
+		}
+	}
+	counter := interceptor.NewCounter()
+	proxy := interceptor.NewProxy(mgr.GetClient(), counter, waitTimeout, ctrl.Log.WithName("proxy"))
+	if err := mgr.Add(newServer("proxy", proxyAddr, proxy, mgr)); err != nil {
+		setupLog.Error(err, "unable to set up proxy server")
+		os.Exit(1)
+	}
+	adminMux := http.NewServeMux()
+	adminMux.Handle("/queue", interceptor.QueueHandler(counter))
+	if err := mgr.Add(newServer("admin", adminAddr, adminMux, mgr)); err != nil {
+		setupLog.Error(err, "unable to set up admin server")
+		os.Exit(1)
+	}
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
+
+	if err := mgr.Start(ctx); err != nil {
+		setupLog.Error(err, "problem running interceptor")
+		os.Exit(1)
+	}
+}
+
+// newServer returns a runnable serving the handler at the address after the cache of the manager is synced
+func newServer(name, addr string, handler http.Handler, mgr manager.Manager) manager.RunnableFunc {
+	return func(ctx context.Context) error {
+		if !mgr.GetCache().WaitForCacheSync(ctx) {
+			return errors.New("failed to wait for cache sync")
+		}
+
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		}()
+
+		setupLog.Info("starting server", "server", name, "address", addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
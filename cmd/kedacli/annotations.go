@@ -0,0 +1,147 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+)
+
+// newActivationCommand returns a command setting the force-activation annotation of a ScaledObject to the value,
+// the annotation is removed if the value is empty
+func newActivationCommand(c *cli, use, short, value string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " NAME",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := c.setAnnotation(cmd.Context(), args[0], kedacontrollerutil.ForceActivationAnnotation, value); err != nil {
+				return err
+			}
+			switch value {
+			case "true":
+				fmt.Fprintf(c.out, "ScaledObject %s/%s is forced active, cooldownPeriod still applies when it's deactivated\n", c.namespace, args[0])
+			case "false":
+				fmt.Fprintf(c.out, "ScaledObject %s/%s is forced inactive, it's scaled in after cooldownPeriod\n", c.namespace, args[0])
+			default:
+				fmt.Fprintf(c.out, "ScaledObject %s/%s is activated by its triggers\n", c.namespace, args[0])
+			}
+			return nil
+		},
+	}
+}
+
+func newPauseCommand(c *cli) *cobra.Command {
+	var replicas int32
+	cmd := &cobra.Command{
+		Use:   "pause NAME",
+		Short: "Pause autoscaling of a ScaledObject at the given or current replica count",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if !cmd.Flags().Changed("replicas") {
+				var err error
+				if replicas, err = c.getScaleTargetReplicas(ctx, args[0]); err != nil {
+					return fmt.Errorf("%w, set --replicas", err)
+				}
+			}
+			if replicas < 0 {
+				return fmt.Errorf("--replicas must not be negative, got %d", replicas)
+			}
+			if err := c.setAnnotation(ctx, args[0], kedacontrollerutil.PausedReplicasAnnotation, strconv.Itoa(int(replicas))); err != nil {
+				return err
+			}
+			fmt.Fprintf(c.out, "ScaledObject %s/%s is paused at %d replicas\n", c.namespace, args[0], replicas)
+			return nil
+		},
+	}
+	cmd.Flags().Int32Var(&replicas, "replicas", 0, "The replica count the scale target is paused at, its current replica count by default.")
+	return cmd
+}
+
+func newResumeCommand(c *cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume NAME",
+		Short: "Resume autoscaling of a paused ScaledObject",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := c.setAnnotation(cmd.Context(), args[0], kedacontrollerutil.PausedReplicasAnnotation, ""); err != nil {
+				return err
+			}
+			fmt.Fprintf(c.out, "ScaledObject %s/%s is resumed\n", c.namespace, args[0])
+			return nil
+		},
+	}
+}
+
+// setAnnotation sets the annotation of the ScaledObject to the value or removes it if the value is empty
+func (c *cli) setAnnotation(ctx context.Context, name, annotation, value string) error {
+	scaledObject, err := c.getScaledObject(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(scaledObject.DeepCopy())
+	annotations := scaledObject.GetAnnotations()
+	if value == "" {
+		if _, ok := annotations[annotation]; !ok {
+			return nil
+		}
+		delete(annotations, annotation)
+	} else {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[annotation] = value
+	}
+	scaledObject.SetAnnotations(annotations)
+
+	if err := c.client.Patch(ctx, scaledObject, patch); err != nil {
+		return fmt.Errorf("error patching ScaledObject %s/%s: %w", c.namespace, name, err)
+	}
+	return nil
+}
+
+// getScaleTargetReplicas returns the replica count in the spec of the scale target of the ScaledObject
+func (c *cli) getScaleTargetReplicas(ctx context.Context, name string) (int32, error) {
+	scaledObject, err := c.getScaledObject(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	if scaledObject.Status.ScaleTargetGVKR == nil || scaledObject.Spec.ScaleTargetRef == nil {
+		return 0, fmt.Errorf("scale target of ScaledObject %s/%s isn't resolved yet", c.namespace, name)
+	}
+
+	target := &unstructured.Unstructured{}
+	target.SetGroupVersionKind(scaledObject.Status.ScaleTargetGVKR.GroupVersionKind())
+	key := client.ObjectKey{Namespace: c.namespace, Name: scaledObject.Spec.ScaleTargetRef.Name}
+	if err := c.client.Get(ctx, key, target); err != nil {
+		return 0, fmt.Errorf("error getting scale target %s: %w", scaledObject.Status.ScaleTargetKind, err)
+	}
+	replicas, found, err := unstructured.NestedInt64(target.Object, "spec", "replicas")
+	if err != nil || !found {
+		return 0, fmt.Errorf("replica count of scale target %s/%s isn't known", scaledObject.Status.ScaleTargetKind, key.Name)
+	}
+	return int32(replicas), nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+	"k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+)
+
+const externalMetricsPath = "/apis/external.metrics.k8s.io/v1beta1"
+
+// metricsGetter returns the current values of an external metric of a ScaledObject
+type metricsGetter interface {
+	GetExternalMetric(ctx context.Context, namespace, metricName, scaledObjectName string) ([]string, error)
+}
+
+// externalMetricsGetter queries KEDA Metrics Server through the external metrics API, the same way the HPA does
+type externalMetricsGetter struct {
+	restClient rest.Interface
+}
+
+func (g *externalMetricsGetter) GetExternalMetric(ctx context.Context, namespace, metricName, scaledObjectName string) ([]string, error) {
+	body, err := g.restClient.Get().
+		AbsPath(externalMetricsPath, "namespaces", namespace, metricName).
+		Param("labelSelector", fmt.Sprintf("%s=%s", kedav1alpha1.ScaledObjectOwnerAnnotation, scaledObjectName)).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &v1beta1.ExternalMetricValueList{}
+	if err := json.Unmarshal(body, list); err != nil {
+		return nil, fmt.Errorf("error decoding external metrics: %w", err)
+	}
+	values := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		values = append(values, item.Value.String())
+	}
+	return values, nil
+}
+
+func newListCommand(c *cli) *cobra.Command {
+	var allNamespaces bool
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List ScaledObjects with their live metric values",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.list(cmd.Context(), allNamespaces)
+		},
+	}
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List ScaledObjects of all namespaces.")
+	return cmd
+}
+
+func (c *cli) list(ctx context.Context, allNamespaces bool) error {
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	var opts []client.ListOption
+	if !allNamespaces {
+		opts = append(opts, client.InNamespace(c.namespace))
+	}
+	if err := c.client.List(ctx, scaledObjects, opts...); err != nil {
+		return fmt.Errorf("error listing ScaledObjects: %w", err)
+	}
+	sort.Slice(scaledObjects.Items, func(i, j int) bool {
+		a, b := scaledObjects.Items[i], scaledObjects.Items[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	w := tabwriter.NewWriter(c.out, 0, 4, 3, ' ', 0)
+	if allNamespaces {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
+	fmt.Fprintln(w, "NAME\tTARGET\tREADY\tACTIVE\tPAUSED\tFORCED\tMETRICS")
+	for i := range scaledObjects.Items {
+		scaledObject := &scaledObjects.Items[i]
+		if allNamespaces {
+			fmt.Fprintf(w, "%s\t", scaledObject.Namespace)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			scaledObject.Name,
+			scaleTargetName(scaledObject),
+			scaledObject.Status.Conditions.GetReadyCondition().Status,
+			scaledObject.Status.Conditions.GetActiveCondition().Status,
+			valueOrNone(scaledObject.GetAnnotations()[kedacontrollerutil.PausedReplicasAnnotation]),
+			valueOrNone(scaledObject.GetAnnotations()[kedacontrollerutil.ForceActivationAnnotation]),
+			c.formatMetrics(ctx, scaledObject))
+	}
+	return w.Flush()
+}
+
+func scaleTargetName(scaledObject *kedav1alpha1.ScaledObject) string {
+	if scaledObject.Spec.ScaleTargetRef == nil {
+		return "-"
+	}
+	kind := scaledObject.Spec.ScaleTargetRef.Kind
+	if kind == "" {
+		kind = "Deployment"
+	}
+	return fmt.Sprintf("%s/%s", kind, scaledObject.Spec.ScaleTargetRef.Name)
+}
+
+// formatMetrics returns the live values of the external metrics of the ScaledObject as comma separated name=value pairs,
+// the error is reported in place of the value if the metric can't be queried
+func (c *cli) formatMetrics(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) string {
+	if len(scaledObject.Status.ExternalMetricNames) == 0 {
+		return "-"
+	}
+
+	metrics := make([]string, 0, len(scaledObject.Status.ExternalMetricNames))
+	for _, metricName := range scaledObject.Status.ExternalMetricNames {
+		values, err := c.metrics.GetExternalMetric(ctx, scaledObject.Namespace, metricName, scaledObject.Name)
+		switch {
+		case err != nil:
+			metrics = append(metrics, fmt.Sprintf("%s=<error: %v>", metricName, err))
+		case len(values) == 0:
+			metrics = append(metrics, fmt.Sprintf("%s=<none>", metricName))
+		default:
+			metrics = append(metrics, fmt.Sprintf("%s=%s", metricName, strings.Join(values, "/")))
+		}
+	}
+	return strings.Join(metrics, ",")
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
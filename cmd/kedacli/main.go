@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kedacli is a troubleshooting CLI for KEDA, installed as a kubectl plugin when the binary is named kubectl-keda.
+// It lists ScaledObjects with their live metric values served by KEDA Metrics Server, forces activation or deactivation
+// of ScaledObjects, pauses and resumes their autoscaling and checks triggers of a ScaledObject manifest against a cluster.
+//
+// Usage:
+//
+//	kubectl keda list
+//	kubectl keda activate my-scaledobject
+//	kubectl keda pause my-scaledobject --replicas 2
+//	kubectl keda test -f scaledobject.yaml
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+var scheme = apimachineryruntime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+}
+
+// cli holds the clients and options shared by the commands
+type cli struct {
+	clientConfig clientcmd.ClientConfig
+	restConfig   *rest.Config
+	client       client.Client
+	metrics      metricsGetter
+	namespace    string
+	out          io.Writer
+}
+
+func main() {
+	if err := newRootCommand(&cli{out: os.Stdout}).Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand(c *cli) *cobra.Command {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	overrides := &clientcmd.ConfigOverrides{}
+
+	cmd := &cobra.Command{
+		Use:          "kedacli",
+		Short:        "Troubleshoot KEDA ScaledObjects",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			c.clientConfig = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+			return c.init()
+		},
+	}
+	cmd.PersistentFlags().StringVar(&loadingRules.ExplicitPath, "kubeconfig", "", "Path to the kubeconfig file.")
+	cmd.PersistentFlags().StringVar(&overrides.CurrentContext, "context", "", "The name of the kubeconfig context to use.")
+	cmd.PersistentFlags().StringVarP(&overrides.Context.Namespace, "namespace", "n", "", "The namespace of the ScaledObjects, the namespace of the kubeconfig context by default.")
+
+	cmd.AddCommand(
+		newListCommand(c),
+		newActivationCommand(c, "activate", "Force activation of a ScaledObject regardless of its triggers", "true"),
+		newActivationCommand(c, "deactivate", "Force deactivation of a ScaledObject regardless of its triggers", "false"),
+		newActivationCommand(c, "unforce", "Let the triggers of a ScaledObject decide its activation again", ""),
+		newPauseCommand(c),
+		newResumeCommand(c),
+		newTestCommand(c),
+	)
+	return cmd
+}
+
+// init creates the clients for the cluster of the kubeconfig, the clients set before (eg. by tests) are kept
+func (c *cli) init() error {
+	if c.client != nil {
+		return nil
+	}
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return fmt.Errorf("error getting namespace: %w", err)
+	}
+	c.namespace = namespace
+
+	c.restConfig, err = c.clientConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("error loading kubeconfig: %w", err)
+	}
+	c.client, err = client.New(c.restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(c.restConfig)
+	if err != nil {
+		return fmt.Errorf("error creating clientset: %w", err)
+	}
+	c.metrics = &externalMetricsGetter{restClient: clientset.Discovery().RESTClient()}
+	return nil
+}
+
+// getScaledObject returns the ScaledObject of the namespace of the CLI
+func (c *cli) getScaledObject(ctx context.Context, name string) (*kedav1alpha1.ScaledObject, error) {
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: c.namespace, Name: name}, scaledObject); err != nil {
+		return nil, fmt.Errorf("error getting ScaledObject %s/%s: %w", c.namespace, name, err)
+	}
+	return scaledObject, nil
+}
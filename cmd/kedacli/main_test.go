@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+)
+
+type fakeMetricsGetter map[string][]string
+
+func (g fakeMetricsGetter) GetExternalMetric(_ context.Context, _, metricName, _ string) ([]string, error) {
+	values, ok := g[metricName]
+	if !ok {
+		return nil, errors.New("metric not found")
+	}
+	return values, nil
+}
+
+func newTestCli(objects ...client.Object) (*cli, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return &cli{
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		metrics:   fakeMetricsGetter{"s0-queue": {"5"}},
+		namespace: "default",
+		out:       out,
+	}, out
+}
+
+func newTestScaledObject(name string, annotations map[string]string) *kedav1alpha1.ScaledObject {
+	return &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "app"},
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			ScaleTargetKind:     "apps/v1.Deployment",
+			ScaleTargetGVKR:     &kedav1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"},
+			ExternalMetricNames: []string{"s0-queue", "s1-lag"},
+		},
+	}
+}
+
+func runCommand(c *cli, args ...string) error {
+	cmd := newRootCommand(c)
+	cmd.SetArgs(args)
+	cmd.SetOut(c.out)
+	cmd.SetErr(c.out)
+	return cmd.Execute()
+}
+
+func TestList(t *testing.T) {
+	c, out := newTestCli(
+		newTestScaledObject("b", map[string]string{kedacontrollerutil.PausedReplicasAnnotation: "2"}),
+		newTestScaledObject("a", map[string]string{kedacontrollerutil.ForceActivationAnnotation: "true"}),
+	)
+
+	assert.NoError(t, runCommand(c, "list"))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, []string{"NAME", "TARGET", "READY", "ACTIVE", "PAUSED", "FORCED", "METRICS"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"a", "Deployment/app", "Unknown", "Unknown", "-", "true", "s0-queue=5,s1-lag=<error:", "metric", "not", "found>"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"b", "Deployment/app", "Unknown", "Unknown", "2", "-"}, strings.Fields(lines[2])[:6])
+}
+
+func TestActivation(t *testing.T) {
+	c, _ := newTestCli(newTestScaledObject("so", nil))
+
+	assert.NoError(t, runCommand(c, "activate", "so"))
+	assert.Equal(t, "true", getAnnotation(t, c, "so", kedacontrollerutil.ForceActivationAnnotation))
+
+	assert.NoError(t, runCommand(c, "deactivate", "so"))
+	assert.Equal(t, "false", getAnnotation(t, c, "so", kedacontrollerutil.ForceActivationAnnotation))
+
+	assert.NoError(t, runCommand(c, "unforce", "so"))
+	assert.Equal(t, "", getAnnotation(t, c, "so", kedacontrollerutil.ForceActivationAnnotation))
+
+	assert.Error(t, runCommand(c, "activate", "unknown"))
+}
+
+func TestPauseAndResume(t *testing.T) {
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	c, _ := newTestCli(newTestScaledObject("so", nil), deployment)
+
+	assert.NoError(t, runCommand(c, "pause", "so"))
+	assert.Equal(t, "3", getAnnotation(t, c, "so", kedacontrollerutil.PausedReplicasAnnotation))
+
+	assert.NoError(t, runCommand(c, "pause", "so", "--replicas", "0"))
+	assert.Equal(t, "0", getAnnotation(t, c, "so", kedacontrollerutil.PausedReplicasAnnotation))
+
+	assert.NoError(t, runCommand(c, "resume", "so"))
+	assert.Equal(t, "", getAnnotation(t, c, "so", kedacontrollerutil.PausedReplicasAnnotation))
+
+	assert.Error(t, runCommand(c, "pause", "so", "--replicas", "-1"))
+}
+
+func TestPauseWithoutScaleTarget(t *testing.T) {
+	c, _ := newTestCli(newTestScaledObject("so", nil))
+
+	err := runCommand(c, "pause", "so")
+	assert.ErrorContains(t, err, "set --replicas")
+}
+
+func TestTestInvalidManifest(t *testing.T) {
+	c, _ := newTestCli()
+
+	assert.ErrorContains(t, c.test(context.TODO(), []byte("spec: {unknownField: 1}")), "error decoding ScaledObject manifest")
+	assert.ErrorContains(t, c.test(context.TODO(), []byte("metadata: {name: so}")), "no triggers defined")
+}
+
+func getAnnotation(t *testing.T, c *cli, name, annotation string) string {
+	scaledObject, err := c.getScaledObject(context.TODO(), name)
+	assert.NoError(t, err)
+	return scaledObject.GetAnnotations()[annotation]
+}
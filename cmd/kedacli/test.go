@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+)
+
+func newTestCommand(c *cli) *cobra.Command {
+	var file string
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "test -f FILE",
+		Short: "Check the triggers of a ScaledObject manifest against the cluster once",
+		Long: `Check the triggers of a ScaledObject manifest against the cluster once and print their metric values and activity.
+
+The scalers are built the same way KEDA Operator builds them, including TriggerAuthentications and the environment
+of the scale target if it's referenced. They are queried from this machine with the credentials of the kubeconfig,
+so pod identities of KEDA Operator aren't available. ClusterTriggerAuthentications read secrets from the namespace
+given by KEDA_CLUSTER_OBJECT_NAMESPACE.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("error reading ScaledObject manifest: %w", err)
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			return c.test(ctx, content)
+		},
+	}
+	cmd.Flags().StringVarP(&file, "filename", "f", "", "The ScaledObject manifest.")
+	cmd.Flags().DurationVar(&timeout, "timeout", time.Minute, "The timeout of checking all triggers.")
+	_ = cmd.MarkFlagRequired("filename")
+	return cmd
+}
+
+func (c *cli) test(ctx context.Context, manifest []byte) error {
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	if err := yaml.UnmarshalStrict(manifest, scaledObject); err != nil {
+		return fmt.Errorf("error decoding ScaledObject manifest: %w", err)
+	}
+	if scaledObject.Namespace == "" {
+		scaledObject.Namespace = c.namespace
+	}
+
+	// events about failing triggers are dropped, the failures are printed instead
+	handler := scaling.NewScaleHandler(c.client, nil, scheme, 0, &record.FakeRecorder{}, &secretsLister{client: c.client}, nil, nil)
+	checks, err := handler.CheckTriggers(ctx, scaledObject)
+	if err != nil {
+		return fmt.Errorf("error checking triggers: %w", err)
+	}
+
+	failed := 0
+	w := tabwriter.NewWriter(c.out, 0, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "TRIGGER\tTYPE\tMETRIC\tVALUE\tACTIVE\tERROR")
+	for _, check := range checks {
+		trigger := fmt.Sprintf("%d", check.TriggerIndex)
+		if check.TriggerName != "" {
+			trigger = fmt.Sprintf("%d (%s)", check.TriggerIndex, check.TriggerName)
+		}
+		if check.Err != nil {
+			failed++
+			fmt.Fprintf(w, "%s\t%s\t%s\t-\t-\t%v\n", trigger, check.TriggerType, valueOrNone(check.MetricName), check.Err)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t-\n", trigger, check.TriggerType, check.MetricName, valueOrNone(strings.Join(check.MetricValues, "/")), check.IsActive)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d checks of triggers of ScaledObject %s/%s failed", failed, scaledObject.Namespace, scaledObject.Name)
+	}
+	return nil
+}
+
+// secretsLister reads secrets of ClusterTriggerAuthentications directly from the API server,
+// so the CLI doesn't need to list and watch all secrets of the namespace of KEDA
+type secretsLister struct {
+	client    client.Client
+	namespace string
+}
+
+func (l *secretsLister) List(selector labels.Selector) ([]*corev1.Secret, error) {
+	secrets := &corev1.SecretList{}
+	opts := []client.ListOption{client.MatchingLabelsSelector{Selector: selector}}
+	if l.namespace != "" {
+		opts = append(opts, client.InNamespace(l.namespace))
+	}
+	if err := l.client.List(context.Background(), secrets, opts...); err != nil {
+		return nil, err
+	}
+	result := make([]*corev1.Secret, 0, len(secrets.Items))
+	for i := range secrets.Items {
+		result = append(result, &secrets.Items[i])
+	}
+	return result, nil
+}
+
+func (l *secretsLister) Secrets(namespace string) corev1listers.SecretNamespaceLister {
+	return &secretsLister{client: l.client, namespace: namespace}
+}
+
+func (l *secretsLister) Get(name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := l.client.Get(context.Background(), client.ObjectKey{Namespace: l.namespace, Name: name}, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
@@ -17,14 +17,16 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
-	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -33,14 +35,26 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
+	httpv1alpha1 "github.com/kedacore/keda/v2/apis/http/v1alpha1"
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	httpcontrollers "github.com/kedacore/keda/v2/controllers/http"
 	kedacontrollers "github.com/kedacore/keda/v2/controllers/keda"
 	"github.com/kedacore/keda/v2/pkg/certificates"
+	"github.com/kedacore/keda/v2/pkg/diagnostics"
+	"github.com/kedacore/keda/v2/pkg/eventemitter"
+	"github.com/kedacore/keda/v2/pkg/health"
 	"github.com/kedacore/keda/v2/pkg/k8s"
+	"github.com/kedacore/keda/v2/pkg/logging"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
+	"github.com/kedacore/keda/v2/pkg/scalers/eventgrid"
+	"github.com/kedacore/keda/v2/pkg/scalers/metricsproxy"
+	"github.com/kedacore/keda/v2/pkg/scalers/ratelimiter"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	scalingcache "github.com/kedacore/keda/v2/pkg/scaling/cache"
+	"github.com/kedacore/keda/v2/pkg/sharding"
+	"github.com/kedacore/keda/v2/pkg/telemetry"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 	//+kubebuilder:scaffold:imports
 )
@@ -54,23 +68,17 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+	utilruntime.Must(httpv1alpha1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
-// getWatchNamespace returns the namespace the operator should be watching for changes
-func getWatchNamespace() (string, error) {
-	const WatchNamespaceEnvVar = "WATCH_NAMESPACE"
-	ns, found := os.LookupEnv(WatchNamespaceEnvVar)
-	if !found {
-		return "", fmt.Errorf("%s must be set", WatchNamespaceEnvVar)
-	}
-	return ns, nil
-}
-
 func main() {
 	var metricsAddr string
 	var probeAddr string
+	var livenessGracePeriod time.Duration
 	var metricsServiceAddr string
+	var eventGridAddr string
+	var diagnosticsAddr string
 	var enableLeaderElection bool
 	var adapterClientRequestQPS float32
 	var adapterClientRequestBurst int
@@ -82,9 +90,13 @@ func main() {
 	var webhooksServiceName string
 	var enableCertRotation bool
 	var validatingWebhookName string
+	var watchNamespace string
 	pflag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	pflag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	pflag.DurationVar(&livenessGracePeriod, "health-probe-liveness-grace-period", health.DefaultLivenessGracePeriod, "The period the informer caches can be out of sync or the API server unreachable for until the liveness probe fails.")
 	pflag.StringVar(&metricsServiceAddr, "metrics-service-bind-address", ":9666", "The address the gRPRC Metrics Service endpoint binds to.")
+	pflag.StringVar(&eventGridAddr, "eventgrid-bind-address", "", "The address the webhook receiving Azure Event Grid events of azure-eventgrid triggers binds to, the receiver is disabled if it's empty.")
+	pflag.StringVar(&diagnosticsAddr, "diagnostics-bind-address", "", "The address the pprof and expvar endpoints (/debug/pprof/, /debug/vars) bind to, they are disabled if it's empty.")
 	pflag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -98,14 +110,15 @@ func main() {
 	pflag.StringVar(&webhooksServiceName, "webhooks-service-name", "keda-admission-webhooks", "Webhook service name. Defaults to keda-admission-webhooks")
 	pflag.BoolVar(&enableCertRotation, "enable-cert-rotation", false, "enable automatic generation and rotation of TLS certificates/keys")
 	pflag.StringVar(&validatingWebhookName, "validating-webhook-name", "keda-admission", "ValidatingWebhookConfiguration name. Defaults to keda-admission")
-	opts := zap.Options{}
+	pflag.StringVar(&watchNamespace, "namespace", "", "Comma separated list of namespaces to watch, all namespaces are watched if it's empty. Overrides WATCH_NAMESPACE")
+	opts := logging.Options{}
 	opts.BindFlags(flag.CommandLine)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 	pflag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	ctrl.SetLogger(logging.New(&opts))
 	ctx := ctrl.SetupSignalHandler()
-	namespace, err := getWatchNamespace()
+	namespace, err := k8s.ResolveWatchNamespace(watchNamespace, pflag.CommandLine.Changed("namespace"))
 	if err != nil {
 		setupLog.Error(err, "failed to get watch namespace")
 		os.Exit(1)
@@ -129,28 +142,67 @@ func main() {
 		os.Exit(1)
 	}
 
+	// ScaledObjects and ScaledJobs are split across instances of KEDA Operator only if the number of shards is set,
+	// each shard elects its own leader
+	shard, err := sharding.ResolveShard()
+	if err != nil {
+		setupLog.Error(err, "invalid sharding configuration")
+		os.Exit(1)
+	}
+	if shard.Enabled() {
+		setupLog.Info("Running shard of KEDA Operator", "index", shard.Index, "count", shard.Count)
+	}
+
 	cfg := ctrl.GetConfigOrDie()
 	cfg.QPS = adapterClientRequestQPS
 	cfg.Burst = adapterClientRequestBurst
 	cfg.DisableCompression = disableCompression
 
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+	options := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
 		Port:                   9443,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "operator.keda.sh",
+		LeaderElectionID:       shard.Name("operator.keda.sh"),
 		LeaseDuration:          leaseDuration,
 		RenewDeadline:          renewDeadline,
 		RetryPeriod:            retryPeriod,
-		Namespace:              namespace,
-	})
+		// the lease is released once scale loops are stopped, so the next leader takes over without waiting for it to expire
+		LeaderElectionReleaseOnCancel: true,
+	}
+	// WATCH_NAMESPACE can be a comma separated list of namespaces
+	k8s.ApplyWatchNamespace(&options, namespace)
+
+	mgr, err := ctrl.NewManager(cfg, options)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	// traces and metrics are exported over OTLP only if an endpoint is given by the OTEL_EXPORTER_OTLP_* environment variables
+	telemetryResource, err := telemetry.NewResource(ctx, "keda-operator")
+	if err != nil {
+		setupLog.Error(err, "unable to create OpenTelemetry resource")
+		os.Exit(1)
+	}
+	shutdownTracing, err := telemetry.SetupTracing(ctx, telemetryResource)
+	if err != nil {
+		setupLog.Error(err, "unable to set up OpenTelemetry tracing")
+		os.Exit(1)
+	}
+	metricsExporter, err := telemetry.NewMetricsExporter(ctrlmetrics.Registry, telemetryResource)
+	if err != nil {
+		setupLog.Error(err, "unable to set up OpenTelemetry metrics export")
+		os.Exit(1)
+	}
+	if metricsExporter != nil {
+		if err := mgr.Add(metricsExporter); err != nil {
+			setupLog.Error(err, "unable to set up OpenTelemetry metrics export")
+			os.Exit(1)
+		}
+	}
+
 	// default to 3 seconds if they don't pass the env var
 	globalHTTPTimeoutMS, err := kedautil.ResolveOsEnvInt("KEDA_HTTP_DEFAULT_TIMEOUT", 3000)
 	if err != nil {
@@ -188,13 +240,166 @@ func main() {
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, 1*time.Hour, kubeinformers.WithNamespace(objectNamespace))
 	secretInformer := kubeInformerFactory.Core().V1().Secrets()
 
+	// CloudEvents of the events recorded by KEDA are sent to the destinations of CloudEventSources by the leader
+	eventEmitter := eventemitter.NewEmitter(mgr.GetClient(), secretInformer.Lister(), globalHTTPTimeout)
+	if err := mgr.Add(eventEmitter); err != nil {
+		setupLog.Error(err, "unable to set up CloudEvents emitter")
+		os.Exit(1)
+	}
+	eventRecorder = eventemitter.NewEventRecorder(eventRecorder, eventEmitter)
+
 	scaleClient, kubeVersion, err := k8s.InitScaleClient(mgr)
 	if err != nil {
 		setupLog.Error(err, "unable to init scale client")
 		os.Exit(1)
 	}
 
-	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, secretInformer.Lister())
+	// the cache is handed off to the next leader only if the interval is set
+	cacheHandoffInterval, err := kedautil.ResolveOsEnvDuration("KEDA_OPERATOR_CACHE_HANDOFF_INTERVAL")
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_OPERATOR_CACHE_HANDOFF_INTERVAL")
+		os.Exit(1)
+	}
+	var cacheHandoff *scaling.CacheHandoff
+	if cacheHandoffInterval != nil && *cacheHandoffInterval > 0 {
+		cacheHandoff = scaling.NewCacheHandoff(mgr.GetClient(), mgr.GetAPIReader(), objectNamespace, shard.Name(scaling.CacheHandoffConfigMapName), *cacheHandoffInterval)
+		if err := mgr.Add(cacheHandoff); err != nil {
+			setupLog.Error(err, "unable to set up cache handoff")
+			os.Exit(1)
+		}
+	}
+
+	// windowed calculations of the scale loops are pre-warmed from the metrics history only if the remote-read URL is set
+	var metricsHistory *scaling.MetricsHistory
+	if metricsHistoryURL := os.Getenv("KEDA_OPERATOR_METRICS_HISTORY_REMOTE_READ_URL"); metricsHistoryURL != "" {
+		metricsHistoryLookback, err := kedautil.ResolveOsEnvDuration("KEDA_OPERATOR_METRICS_HISTORY_LOOKBACK")
+		if err != nil {
+			setupLog.Error(err, "invalid KEDA_OPERATOR_METRICS_HISTORY_LOOKBACK")
+			os.Exit(1)
+		}
+		if metricsHistoryLookback == nil {
+			defaultLookback := 10 * time.Minute
+			metricsHistoryLookback = &defaultLookback
+		}
+		metricsHistory = scaling.NewMetricsHistory(metricsHistoryURL, *metricsHistoryLookback, kedautil.CreateHTTPClient(globalHTTPTimeout, false))
+		if err := mgr.Add(metricsHistory); err != nil {
+			setupLog.Error(err, "unable to set up metrics history")
+			os.Exit(1)
+		}
+	}
+
+	// triggers opting into the shared metrics proxy with useMetricsProxy share cached results of cloud metric API queries
+	metricsProxyCacheTTL, err := kedautil.ResolveOsEnvDuration("KEDA_METRICS_PROXY_CACHE_TTL")
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_METRICS_PROXY_CACHE_TTL")
+		os.Exit(1)
+	}
+	metricsProxyQueriesPerSecond, err := kedautil.ResolveOsEnvInt("KEDA_METRICS_PROXY_QUERIES_PER_SECOND", 0)
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_METRICS_PROXY_QUERIES_PER_SECOND")
+		os.Exit(1)
+	}
+	if metricsProxyCacheTTL == nil {
+		defaultCacheTTL := metricsproxy.DefaultCacheTTL
+		metricsProxyCacheTTL = &defaultCacheTTL
+	}
+	metricsproxy.Configure(*metricsProxyCacheTTL, metricsProxyQueriesPerSecond)
+
+	// requests of all triggers to rate limited cloud metric APIs are limited per API and account, eg. azure-monitor=3,datadog=0.5
+	scalerRateLimits, err := ratelimiter.ParseLimits(os.Getenv("KEDA_SCALER_RATE_LIMITS"))
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_SCALER_RATE_LIMITS")
+		os.Exit(1)
+	}
+	scalerRateLimitJitter, err := kedautil.ResolveOsEnvDuration("KEDA_SCALER_RATE_LIMIT_JITTER")
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_SCALER_RATE_LIMIT_JITTER")
+		os.Exit(1)
+	}
+	if scalerRateLimitJitter == nil {
+		defaultJitter := ratelimiter.DefaultJitter
+		scalerRateLimitJitter = &defaultJitter
+	}
+	ratelimiter.Configure(scalerRateLimits, *scalerRateLimitJitter)
+
+	// scalers of a ScaledObject are checked concurrently, each of them within the timeout
+	scalerCheckConcurrency, err := kedautil.ResolveOsEnvInt("KEDA_SCALER_CHECK_CONCURRENCY", scaling.DefaultScalerCheckConcurrency)
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_SCALER_CHECK_CONCURRENCY")
+		os.Exit(1)
+	}
+	scalerCheckTimeout, err := kedautil.ResolveOsEnvDuration("KEDA_SCALER_CHECK_TIMEOUT")
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_SCALER_CHECK_TIMEOUT")
+		os.Exit(1)
+	}
+	if scalerCheckTimeout == nil {
+		defaultScalerCheckTimeout := scaling.DefaultScalerCheckTimeout
+		scalerCheckTimeout = &defaultScalerCheckTimeout
+	}
+	scaling.ConfigureScalerChecks(scalerCheckConcurrency, *scalerCheckTimeout)
+
+	// ScaledObjects and ScaledJobs are checked by a shared scheduler, this many of them concurrently
+	scaleLoopWorkers, err := kedautil.ResolveOsEnvInt("KEDA_SCALE_LOOP_WORKERS", scaling.DefaultScaleLoopWorkers)
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_SCALE_LOOP_WORKERS")
+		os.Exit(1)
+	}
+	scaling.ConfigureScaleLoops(scaleLoopWorkers)
+
+	// scalers failing consecutively aren't queried until they are probed, the probes back off exponentially
+	circuitBreakerThreshold, err := kedautil.ResolveOsEnvInt("KEDA_SCALER_CIRCUIT_BREAKER_THRESHOLD", scalingcache.DefaultCircuitBreakerFailureThreshold)
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_SCALER_CIRCUIT_BREAKER_THRESHOLD")
+		os.Exit(1)
+	}
+	circuitBreakerMaxOpenPeriod, err := kedautil.ResolveOsEnvDuration("KEDA_SCALER_CIRCUIT_BREAKER_MAX_OPEN_PERIOD")
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_SCALER_CIRCUIT_BREAKER_MAX_OPEN_PERIOD")
+		os.Exit(1)
+	}
+	if circuitBreakerMaxOpenPeriod == nil {
+		defaultCircuitBreakerMaxOpenPeriod := scalingcache.DefaultCircuitBreakerMaxOpenPeriod
+		circuitBreakerMaxOpenPeriod = &defaultCircuitBreakerMaxOpenPeriod
+	}
+	scalingcache.ConfigureCircuitBreaker(circuitBreakerThreshold, *circuitBreakerMaxOpenPeriod)
+
+	if diagnosticsAddr != "" {
+		if err := mgr.Add(diagnostics.NewServer(diagnosticsAddr)); err != nil {
+			setupLog.Error(err, "unable to set up diagnostics server")
+			os.Exit(1)
+		}
+	}
+
+	// the receiver runs on the leader only like the scale loops of the triggers subscribed to its events
+	if eventGridAddr != "" {
+		eventGridReceiver := eventgrid.NewReceiver(eventGridAddr)
+		eventgrid.SetDefaultReceiver(eventGridReceiver)
+		if err := mgr.Add(eventGridReceiver); err != nil {
+			setupLog.Error(err, "unable to set up Azure Event Grid receiver")
+			os.Exit(1)
+		}
+	}
+
+	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, secretInformer.Lister(), cacheHandoff, metricsHistory)
+	eventEmitter.SetMetricsSource(scaledHandler)
+	// scale loops are stopped and their scalers closed when the operator stops leading
+	if err := mgr.Add(scaledHandler); err != nil {
+		setupLog.Error(err, "unable to set up scale handler")
+		os.Exit(1)
+	}
+
+	// the pod of the leader is labeled only if its name is given, the keda-operator Service selects the label
+	if podName := os.Getenv("POD_NAME"); podName != "" {
+		leaderPodLabeler := k8s.NewLeaderPodLabeler(mgr.GetClient(), kedautil.GetPodNamespace(), podName)
+		if err := leaderPodLabeler.RemoveLabel(ctx); err != nil {
+			setupLog.Error(err, "unable to remove leader label from pod", "pod", podName)
+		}
+		if err := mgr.Add(leaderPodLabeler); err != nil {
+			setupLog.Error(err, "unable to set up leader pod label")
+			os.Exit(1)
+		}
+	}
 
 	if err = (&kedacontrollers.ScaledObjectReconciler{
 		Client:       mgr.GetClient(),
@@ -202,6 +407,7 @@ func main() {
 		Recorder:     eventRecorder,
 		ScaleClient:  scaleClient,
 		ScaleHandler: scaledHandler,
+		Shard:        shard,
 	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: scaledObjectMaxReconciles}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledObject")
 		os.Exit(1)
@@ -211,8 +417,10 @@ func main() {
 		Scheme:            mgr.GetScheme(),
 		GlobalHTTPTimeout: globalHTTPTimeout,
 		Recorder:          eventRecorder,
+		ScaleHandler:      scaledHandler,
 		SecretsLister:     secretInformer.Lister(),
 		SecretsSynced:     secretInformer.Informer().HasSynced,
+		Shard:             shard,
 	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: scaledJobMaxReconciles}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledJob")
 		os.Exit(1)
@@ -220,6 +428,7 @@ func main() {
 	if err = (&kedacontrollers.TriggerAuthenticationReconciler{
 		Client:        mgr.GetClient(),
 		EventRecorder: eventRecorder,
+		ScaleHandler:  scaledHandler,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "TriggerAuthentication")
 		os.Exit(1)
@@ -227,18 +436,39 @@ func main() {
 	if err = (&kedacontrollers.ClusterTriggerAuthenticationReconciler{
 		Client:        mgr.GetClient(),
 		EventRecorder: eventRecorder,
+		ScaleHandler:  scaledHandler,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterTriggerAuthentication")
 		os.Exit(1)
 	}
-	//+kubebuilder:scaffold:builder
-
-	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up health check")
+	authenticationSecretReconciler := &kedacontrollers.AuthenticationSecretReconciler{
+		Client:                 mgr.GetClient(),
+		ScaleHandler:           scaledHandler,
+		ClusterObjectNamespace: objectNamespace,
+	}
+	// KEDA Operator isn't allowed to watch Secrets outside of the cluster object namespace if the access to Secrets is restricted
+	if strings.EqualFold(kedautil.GetRestrictSecretAccess(), "true") {
+		authenticationSecretReconciler.SecretsInformer = secretInformer.Informer()
+	}
+	if err = authenticationSecretReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AuthenticationSecret")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up ready check")
+	// HTTPScaledObjects are reconciled only if the interceptor is deployed, their ScaledObjects query its queue endpoint
+	if interceptorAdminURL := os.Getenv("KEDA_HTTP_INTERCEPTOR_ADMIN_URL"); interceptorAdminURL != "" {
+		if err = (&httpcontrollers.HTTPScaledObjectReconciler{
+			Client:              mgr.GetClient(),
+			InterceptorAdminURL: interceptorAdminURL,
+			Shard:               shard,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "HTTPScaledObject")
+			os.Exit(1)
+		}
+	}
+	//+kubebuilder:scaffold:builder
+
+	if err := addHealthChecks(mgr, livenessGracePeriod); err != nil {
+		setupLog.Error(err, "unable to set up health checks")
 		os.Exit(1)
 	}
 
@@ -271,6 +501,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	// KedaHealth is a cluster wide singleton, it's maintained by the first shard only
+	if shard.Index == 0 {
+		if err = (&kedacontrollers.KedaHealthReconciler{
+			Client:              mgr.GetClient(),
+			AdapterConnectivity: &grpcServer,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "KedaHealth")
+			os.Exit(1)
+		}
+	}
+
 	kedautil.PrintWelcome(setupLog, kubeVersion, "manager")
 
 	kubeInformerFactory.Start(ctx.Done())
@@ -280,8 +521,46 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := mgr.Start(ctx); err != nil {
+	err = mgr.Start(ctx)
+	// the spans of the last reconciliations and scaler checks are flushed before exiting
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if shutdownErr := shutdownTracing(shutdownCtx); shutdownErr != nil {
+		setupLog.Error(shutdownErr, "unable to flush OpenTelemetry traces")
+	}
+	cancel()
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// addHealthChecks adds the checks of the informer caches and the API server to /readyz, and to /healthz once they've been
+// failing for longer than livenessGracePeriod
+func addHealthChecks(mgr ctrl.Manager, livenessGracePeriod time.Duration) error {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+	cacheSynced := health.CacheSynced(mgr.GetCache())
+	apiServerReachable := health.APIServerReachable(discoveryClient.RESTClient())
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		return err
+	}
+	if err := mgr.AddHealthzCheck("informer-cache", health.FailingLongerThan(cacheSynced, livenessGracePeriod)); err != nil {
+		return err
+	}
+	if err := mgr.AddHealthzCheck("apiserver", health.FailingLongerThan(apiServerReachable, livenessGracePeriod)); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("informer-cache", cacheSynced); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("apiserver", apiServerReachable); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	httpv1alpha1 "github.com/kedacore/keda/v2/apis/http/v1alpha1"
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/sharding"
+)
+
+const (
+	// HTTPScaledObjectLabel is the label of ScaledObjects created for HTTPScaledObjects, its value is the name of the HTTPScaledObject
+	HTTPScaledObjectLabel = "http.keda.sh/httpscaledobject"
+
+	httpScaledObjectReadyReason        = "HTTPScaledObjectReady"
+	httpScaledObjectReadyMessage       = "ScaledObject scaling the target on pending requests is created"
+	httpScaledObjectReconcileErrReason = "ScaledObjectReconcileError"
+)
+
+// HTTPScaledObjectReconciler reconciles the ScaledObjects scaling the targets of HTTPScaledObjects
+// on the pending requests reported by the interceptor
+type HTTPScaledObjectReconciler struct {
+	client.Client
+	// InterceptorAdminURL is the URL of the queue endpoint of the interceptor
+	InterceptorAdminURL string
+	Shard               sharding.Shard
+}
+
+// +kubebuilder:rbac:groups=http.keda.sh,resources=httpscaledobjects;httpscaledobjects/status,verbs="*"
+
+// Reconcile creates or updates the ScaledObject of the HTTPScaledObject, it's deleted with the HTTPScaledObject by its owner reference
+func (r *HTTPScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	httpScaledObject := &httpv1alpha1.HTTPScaledObject{}
+	err := r.Client.Get(ctx, req.NamespacedName, httpScaledObject)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		reqLogger.Error(err, "Failed to get HTTPScaledObject")
+		return ctrl.Result{}, err
+	}
+
+	if httpScaledObject.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: httpScaledObject.Name, Namespace: httpScaledObject.Namespace},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, scaledObject, func() error {
+		return r.updateScaledObject(httpScaledObject, scaledObject)
+	})
+
+	// Ready is the only condition of HTTPScaledObjects
+	conditions := httpScaledObject.Status.Conditions.DeepCopy()
+	if len(conditions) == 0 {
+		conditions = kedav1alpha1.Conditions{{Type: kedav1alpha1.ConditionReady, Status: metav1.ConditionUnknown}}
+	}
+	if err != nil {
+		reqLogger.Error(err, "Failed to reconcile ScaledObject of HTTPScaledObject")
+		conditions.SetReadyCondition(metav1.ConditionFalse, httpScaledObjectReconcileErrReason, err.Error())
+	} else {
+		conditions.SetReadyCondition(metav1.ConditionTrue, httpScaledObjectReadyReason, httpScaledObjectReadyMessage)
+	}
+	if statusErr := r.updateStatus(ctx, reqLogger, httpScaledObject, scaledObject.Name, conditions); statusErr != nil {
+		return ctrl.Result{}, statusErr
+	}
+	return ctrl.Result{}, err
+}
+
+// updateScaledObject sets the spec of the ScaledObject scaling the target of the HTTPScaledObject,
+// it's active while the HTTPScaledObject has pending requests
+func (r *HTTPScaledObjectReconciler) updateScaledObject(httpScaledObject *httpv1alpha1.HTTPScaledObject, scaledObject *kedav1alpha1.ScaledObject) error {
+	if labels := scaledObject.GetLabels(); labels == nil {
+		scaledObject.SetLabels(map[string]string{HTTPScaledObjectLabel: httpScaledObject.Name})
+	} else {
+		labels[HTTPScaledObjectLabel] = httpScaledObject.Name
+	}
+
+	minReplicas := int32(0)
+	var maxReplicas *int32
+	if replicas := httpScaledObject.Spec.Replicas; replicas != nil {
+		if replicas.Min != nil {
+			minReplicas = *replicas.Min
+		}
+		maxReplicas = replicas.Max
+	}
+
+	target := httpScaledObject.Spec.ScaleTargetRef
+	scaledObject.Spec.ScaleTargetRef = &kedav1alpha1.ScaleTarget{
+		Name:       target.Name,
+		APIVersion: target.APIVersion,
+		Kind:       target.Kind,
+	}
+	scaledObject.Spec.MinReplicaCount = &minReplicas
+	scaledObject.Spec.MaxReplicaCount = maxReplicas
+	scaledObject.Spec.CooldownPeriod = httpScaledObject.Spec.CooldownPeriod
+	scaledObject.Spec.Triggers = []kedav1alpha1.ScaleTriggers{{
+		Type: "http",
+		Metadata: map[string]string{
+			"backend":           "interceptor",
+			"host":              httpScaledObject.QueueKey(),
+			"interceptorURL":    r.InterceptorAdminURL,
+			"interceptorMetric": "concurrency",
+			"targetValue":       strconv.Itoa(int(httpScaledObject.GetTargetPendingRequests())),
+		},
+	}}
+
+	if err := controllerutil.SetControllerReference(httpScaledObject, scaledObject, r.Scheme()); err != nil {
+		return fmt.Errorf("error setting owner of ScaledObject: %w", err)
+	}
+	return nil
+}
+
+func (r *HTTPScaledObjectReconciler) updateStatus(ctx context.Context, logger logr.Logger, httpScaledObject *httpv1alpha1.HTTPScaledObject, scaledObjectName string, conditions kedav1alpha1.Conditions) error {
+	patch := client.MergeFrom(httpScaledObject.DeepCopy())
+	httpScaledObject.Status.ScaledObjectName = scaledObjectName
+	httpScaledObject.Status.Conditions = conditions
+	if err := r.Client.Status().Patch(ctx, httpScaledObject, patch); err != nil {
+		logger.Error(err, "Failed to update status of HTTPScaledObject")
+		return err
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HTTPScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&httpv1alpha1.HTTPScaledObject{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}, r.Shard.Predicate())).
+		Owns(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}, r.Shard.Predicate())).
+		Complete(r)
+}
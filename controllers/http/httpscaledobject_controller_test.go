@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	httpv1alpha1 "github.com/kedacore/keda/v2/apis/http/v1alpha1"
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestReconcileCreatesScaledObject(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	assert.NoError(t, httpv1alpha1.AddToScheme(scheme))
+
+	maxReplicas := int32(10)
+	targetPendingRequests := int32(20)
+	httpScaledObject := &httpv1alpha1.HTTPScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: httpv1alpha1.HTTPScaledObjectSpec{
+			Hosts:                 []string{"example.com"},
+			ScaleTargetRef:        httpv1alpha1.HTTPScaleTargetRef{Name: "web-deployment", Service: "web", Port: 8080},
+			Replicas:              &httpv1alpha1.ReplicaStruct{Max: &maxReplicas},
+			TargetPendingRequests: &targetPendingRequests,
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(httpScaledObject).Build()
+	reconciler := &HTTPScaledObjectReconciler{
+		Client:              fakeClient,
+		InterceptorAdminURL: "http://keda-http-interceptor-admin.keda:9090/queue",
+	}
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "web"}}
+	_, err := reconciler.Reconcile(context.Background(), request)
+	assert.NoError(t, err)
+
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	assert.NoError(t, fakeClient.Get(context.Background(), request.NamespacedName, scaledObject))
+	assert.Equal(t, "web-deployment", scaledObject.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, int32(0), *scaledObject.Spec.MinReplicaCount)
+	assert.Equal(t, int32(10), *scaledObject.Spec.MaxReplicaCount)
+	assert.Equal(t, "web", scaledObject.Labels[HTTPScaledObjectLabel])
+	assert.True(t, metav1.IsControlledBy(scaledObject, httpScaledObject))
+	assert.Equal(t, []kedav1alpha1.ScaleTriggers{{
+		Type: "http",
+		Metadata: map[string]string{
+			"backend":           "interceptor",
+			"host":              "default/web",
+			"interceptorURL":    "http://keda-http-interceptor-admin.keda:9090/queue",
+			"interceptorMetric": "concurrency",
+			"targetValue":       "20",
+		},
+	}}, scaledObject.Spec.Triggers)
+
+	assert.NoError(t, fakeClient.Get(context.Background(), request.NamespacedName, httpScaledObject))
+	assert.Equal(t, "web", httpScaledObject.Status.ScaledObjectName)
+	assert.Equal(t, metav1.ConditionTrue, httpScaledObject.Status.Conditions.GetReadyCondition().Status)
+
+	// the ScaledObject follows updates of the HTTPScaledObject
+	minReplicas := int32(1)
+	httpScaledObject.Spec.Replicas.Min = &minReplicas
+	httpScaledObject.Spec.TargetPendingRequests = nil
+	assert.NoError(t, fakeClient.Update(context.Background(), httpScaledObject))
+	_, err = reconciler.Reconcile(context.Background(), request)
+	assert.NoError(t, err)
+
+	assert.NoError(t, fakeClient.Get(context.Background(), request.NamespacedName, scaledObject))
+	assert.Equal(t, int32(1), *scaledObject.Spec.MinReplicaCount)
+	assert.Equal(t, "100", scaledObject.Spec.Triggers[0].Metadata["targetValue"])
+}
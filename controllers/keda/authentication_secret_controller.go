@@ -0,0 +1,141 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+)
+
+// AuthenticationSecretReconciler rebuilds scalers authenticated by TriggerAuthentications and ClusterTriggerAuthentications
+// once a Secret they reference changes (eg. credentials are rotated), instead of waiting for the scalers to fail
+type AuthenticationSecretReconciler struct {
+	client.Client
+	ScaleHandler scaling.ScaleHandler
+	// ClusterObjectNamespace is the namespace of Secrets referenced by ClusterTriggerAuthentications
+	ClusterObjectNamespace string
+	// SecretsInformer watches Secrets of ClusterObjectNamespace if the access to Secrets is restricted to it,
+	// all authentications read Secrets from ClusterObjectNamespace then
+	SecretsInformer ctrlcache.Informer
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AuthenticationSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Secrets are rarely created for an authentication which already exists, so only their updates and deletions are handled,
+	// otherwise all authentications are refreshed once the Secrets are listed at the start of the operator
+	secretChanged := predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSecret, oldOk := e.ObjectOld.(*corev1.Secret)
+			newSecret, newOk := e.ObjectNew.(*corev1.Secret)
+			return !oldOk || !newOk || !equality.Semantic.DeepEqual(oldSecret.Data, newSecret.Data)
+		},
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).Named("authentication-secret")
+	if r.SecretsInformer != nil {
+		controllerBuilder = controllerBuilder.Watches(&source.Informer{Informer: r.SecretsInformer}, &handler.EnqueueRequestForObject{}, ctrlbuilder.WithPredicates(secretChanged))
+	} else {
+		controllerBuilder = controllerBuilder.Watches(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForObject{}, ctrlbuilder.WithPredicates(secretChanged))
+	}
+	return controllerBuilder.Complete(r)
+}
+
+// Reconcile refreshes scalers of the authentications referencing the changed Secret, the Secret may not exist anymore
+func (r *AuthenticationSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	var listOptions []client.ListOption
+	if r.SecretsInformer == nil {
+		listOptions = append(listOptions, client.InNamespace(req.Namespace))
+	}
+	triggerAuthentications := &kedav1alpha1.TriggerAuthenticationList{}
+	if err := r.Client.List(ctx, triggerAuthentications, listOptions...); err != nil {
+		reqLogger.Error(err, "Failed to list TriggerAuthentications")
+		return ctrl.Result{}, err
+	}
+	for _, triggerAuthentication := range triggerAuthentications.Items {
+		if referencesSecret(&triggerAuthentication.Spec, req.Namespace, req.NamespacedName) {
+			reqLogger.Info("Refreshing scalers authenticated by the changed Secret", "TriggerAuthentication.Namespace", triggerAuthentication.Namespace, "TriggerAuthentication.Name", triggerAuthentication.Name)
+			r.ScaleHandler.RefreshScalersCachesForAuthentication(ctx, "TriggerAuthentication", triggerAuthentication.Namespace, triggerAuthentication.Name)
+		}
+	}
+
+	clusterTriggerAuthentications := &kedav1alpha1.ClusterTriggerAuthenticationList{}
+	if err := r.Client.List(ctx, clusterTriggerAuthentications); err != nil {
+		reqLogger.Error(err, "Failed to list ClusterTriggerAuthentications")
+		return ctrl.Result{}, err
+	}
+	for _, clusterTriggerAuthentication := range clusterTriggerAuthentications.Items {
+		if referencesSecret(&clusterTriggerAuthentication.Spec, r.ClusterObjectNamespace, req.NamespacedName) {
+			reqLogger.Info("Refreshing scalers authenticated by the changed Secret", "ClusterTriggerAuthentication.Name", clusterTriggerAuthentication.Name)
+			r.ScaleHandler.RefreshScalersCachesForAuthentication(ctx, "ClusterTriggerAuthentication", "", clusterTriggerAuthentication.Name)
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// referencesSecret returns true if the authentication reads parameters or credentials of secret stores from the Secret,
+// Secrets are read from the default namespace of the authentication unless the namespace of secretTargetRef is given
+func referencesSecret(spec *kedav1alpha1.TriggerAuthenticationSpec, defaultNamespace string, secret types.NamespacedName) bool {
+	for _, ref := range spec.SecretTargetRef {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+		if namespace == secret.Namespace && ref.Name == secret.Name {
+			return true
+		}
+	}
+	if defaultNamespace != secret.Namespace {
+		return false
+	}
+	name := secret.Name
+	if spec.AzureKeyVault != nil && spec.AzureKeyVault.Credentials != nil && spec.AzureKeyVault.Credentials.ClientSecret != nil &&
+		spec.AzureKeyVault.Credentials.ClientSecret.ValueFrom.SecretKeyRef.Name == name {
+		return true
+	}
+	if spec.AwsSecretManager != nil && spec.AwsSecretManager.Credentials != nil {
+		credentials := spec.AwsSecretManager.Credentials
+		for _, value := range []*kedav1alpha1.AwsSecretManagerValue{credentials.AccessKey, credentials.AccessSecretKey, credentials.AccessToken} {
+			if value != nil && value.ValueFrom.SecretKeyRef.Name == name {
+				return true
+			}
+		}
+	}
+	if spec.GCPSecretManager != nil && spec.GCPSecretManager.Credentials != nil &&
+		spec.GCPSecretManager.Credentials.ClientSecret.ValueFrom.SecretKeyRef.Name == name {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+var _ = Describe("AuthenticationSecret", func() {
+	It("should find Secrets referenced by authentications", func() {
+		valueFrom := func(name string) v1alpha1.ValueFromSecret {
+			return v1alpha1.ValueFromSecret{SecretKeyRef: v1alpha1.SecretKeyRef{Name: name, Key: "key"}}
+		}
+		spec := &v1alpha1.TriggerAuthenticationSpec{
+			SecretTargetRef: []v1alpha1.AuthSecretTargetRef{{Parameter: "password", Name: "target", Key: "password"}},
+			AzureKeyVault: &v1alpha1.AzureKeyVault{
+				Credentials: &v1alpha1.AzureKeyVaultCredentials{ClientSecret: &v1alpha1.AzureKeyVaultClientSecret{ValueFrom: valueFrom("azure")}},
+			},
+			AwsSecretManager: &v1alpha1.AwsSecretManager{
+				Credentials: &v1alpha1.AwsSecretManagerCredentials{
+					AccessKey:       &v1alpha1.AwsSecretManagerValue{ValueFrom: valueFrom("aws-key")},
+					AccessSecretKey: &v1alpha1.AwsSecretManagerValue{ValueFrom: valueFrom("aws-secret")},
+				},
+			},
+			GCPSecretManager: &v1alpha1.GCPSecretManager{
+				Credentials: &v1alpha1.GCPCredentials{ClientSecret: v1alpha1.GCPSecretManagerClientSecret{ValueFrom: valueFrom("gcp")}},
+			},
+		}
+
+		for _, name := range []string{"target", "azure", "aws-key", "aws-secret", "gcp"} {
+			Expect(referencesSecret(spec, "keda", types.NamespacedName{Namespace: "keda", Name: name})).To(BeTrue(), name)
+			Expect(referencesSecret(spec, "keda", types.NamespacedName{Namespace: "other", Name: name})).To(BeFalse(), name)
+		}
+		Expect(referencesSecret(spec, "keda", types.NamespacedName{Namespace: "keda", Name: "other"})).To(BeFalse())
+		Expect(referencesSecret(&v1alpha1.TriggerAuthenticationSpec{}, "keda", types.NamespacedName{Namespace: "keda", Name: "target"})).To(BeFalse())
+	})
+
+	It("should find Secrets of other namespaces referenced by secretTargetRef", func() {
+		spec := &v1alpha1.TriggerAuthenticationSpec{
+			SecretTargetRef: []v1alpha1.AuthSecretTargetRef{{Parameter: "password", Name: "target", Key: "password", Namespace: "shared"}},
+		}
+
+		Expect(referencesSecret(spec, "keda", types.NamespacedName{Namespace: "shared", Name: "target"})).To(BeTrue())
+		Expect(referencesSecret(spec, "keda", types.NamespacedName{Namespace: "keda", Name: "target"})).To(BeFalse())
+	})
+})
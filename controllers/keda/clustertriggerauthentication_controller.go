@@ -30,14 +30,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/prommetrics"
+	"github.com/kedacore/keda/v2/pkg/scaling"
 )
 
 // ClusterTriggerAuthenticationReconciler reconciles a ClusterTriggerAuthentication object
 type ClusterTriggerAuthenticationReconciler struct {
 	client.Client
 	record.EventRecorder
+	ScaleHandler scaling.ScaleHandler
 }
 
 type clusterTriggerAuthMetricsData struct {
@@ -79,6 +82,11 @@ func (r *ClusterTriggerAuthenticationReconciler) Reconcile(ctx context.Context,
 	}
 	r.updatePromMetrics(clusterTriggerAuthentication, req.NamespacedName.String())
 
+	// scalers authenticated by the previous generation are rebuilt with the updated authentication
+	if clusterTriggerAuthentication.ObjectMeta.Generation > 1 {
+		r.ScaleHandler.RefreshScalersCachesForAuthentication(ctx, "ClusterTriggerAuthentication", "", clusterTriggerAuthentication.Name)
+	}
+
 	if clusterTriggerAuthentication.ObjectMeta.Generation == 1 {
 		r.EventRecorder.Event(clusterTriggerAuthentication, corev1.EventTypeNormal, eventreason.ClusterTriggerAuthenticationAdded, "New ClusterTriggerAuthentication configured")
 	}
@@ -89,7 +97,7 @@ func (r *ClusterTriggerAuthenticationReconciler) Reconcile(ctx context.Context,
 func (r *ClusterTriggerAuthenticationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kedav1alpha1.ClusterTriggerAuthentication{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Complete(r)
+		Complete(kedacontrollerutil.WithReconcileMetrics(prommetrics.ClusterTriggerAuthenticationResource, r))
 }
 
 func (r *ClusterTriggerAuthenticationReconciler) updatePromMetrics(clusterTriggerAuth *kedav1alpha1.ClusterTriggerAuthentication, namespacedName string) {
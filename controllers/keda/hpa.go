@@ -19,18 +19,23 @@ package keda
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"unicode"
 
 	"github.com/go-logr/logr"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/scaling/executor"
 	version "github.com/kedacore/keda/v2/version"
 )
@@ -51,6 +56,14 @@ func (r *ScaledObjectReconciler) createAndDeployNewHPA(ctx context.Context, logg
 	}
 
 	err = r.Client.Create(ctx, hpa)
+	if errors.IsAlreadyExists(err) && isHpaOwnershipTransferRequested(scaledObject) {
+		// e.g. the HPA was renamed to an existing HPA which should be adopted
+		foundHpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: hpaName, Namespace: scaledObject.Namespace}, foundHpa); err != nil {
+			return err
+		}
+		return r.adoptHPA(ctx, logger, scaledObject, foundHpa, gvkr)
+	}
 	if err != nil {
 		logger.Error(err, "Failed to create new HPA in cluster", "HPA.Namespace", scaledObject.Namespace, "HPA.Name", hpaName)
 		return err
@@ -69,6 +82,37 @@ func (r *ScaledObjectReconciler) createAndDeployNewHPA(ctx context.Context, logg
 	return nil
 }
 
+// adoptHPA transfers the ownership of an existing HPA to the ScaledObject, the HPA is replaced by the HPA specified
+// in the ScaledObject without recreating it, so the workload keeps being scaled during the migration to KEDA,
+// HPAs owned by another ScaledObject are never adopted
+func (r *ScaledObjectReconciler) adoptHPA(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, foundHpa *autoscalingv2.HorizontalPodAutoscaler, gvkr *kedav1alpha1.GroupVersionKindResource) error {
+	if isOwnedByScaledObject(foundHpa) && !metav1.IsControlledBy(foundHpa, scaledObject) {
+		err := fmt.Errorf("HPA %s is owned by another ScaledObject, its ownership can't be transferred", foundHpa.Name)
+		logger.Error(err, "Failed to transfer ownership of HPA", "HPA.Namespace", foundHpa.Namespace, "HPA.Name", foundHpa.Name)
+		return err
+	}
+	logger.Info("Transferring ownership of existing HPA to ScaledObject", "HPA.Namespace", foundHpa.Namespace, "HPA.Name", foundHpa.Name)
+	hpa, err := r.newHPAForScaledObject(ctx, logger, scaledObject, gvkr)
+	if err != nil {
+		logger.Error(err, "Failed to create new HPA resource", "HPA.Namespace", scaledObject.Namespace, "HPA.Name", foundHpa.Name)
+		return err
+	}
+	hpa.ResourceVersion = foundHpa.ResourceVersion
+
+	if err := r.Client.Update(ctx, hpa); err != nil {
+		logger.Error(err, "Failed to transfer ownership of HPA", "HPA.Namespace", foundHpa.Namespace, "HPA.Name", foundHpa.Name)
+		return err
+	}
+
+	status := scaledObject.Status.DeepCopy()
+	status.HpaName = hpa.Name
+	if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "Error updating scaledObject status with used hpaName")
+		return err
+	}
+	return nil
+}
+
 // newHPAForScaledObject returns HPA as it is specified in ScaledObject
 func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) (*autoscalingv2.HorizontalPodAutoscaler, error) {
 	scaledObjectMetricSpecs, err := r.getScaledObjectMetricSpecs(ctx, logger, scaledObject)
@@ -226,8 +270,12 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context,
 
 	// sort metrics in ScaledObject, this way we always check the same resource in Reconcile loop and we can prevent unnecessary HPA updates,
 	// see https://github.com/kedacore/keda/issues/1531 for details
-	sort.Slice(scaledObjectMetricSpecs, func(i, j int) bool {
-		return scaledObjectMetricSpecs[i].Type < scaledObjectMetricSpecs[j].Type
+	// external metrics are sorted by their names as well, so reordering of named triggers doesn't update the HPA
+	sort.SliceStable(scaledObjectMetricSpecs, func(i, j int) bool {
+		if scaledObjectMetricSpecs[i].Type != scaledObjectMetricSpecs[j].Type {
+			return scaledObjectMetricSpecs[i].Type < scaledObjectMetricSpecs[j].Type
+		}
+		return externalMetricSpecName(scaledObjectMetricSpecs[i]) < externalMetricSpecName(scaledObjectMetricSpecs[j])
 	})
 
 	// store External.MetricNames,Resource.MetricsNames used by scalers defined in the ScaledObject
@@ -235,6 +283,8 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context,
 	status.ExternalMetricNames = externalMetricNames
 	status.ResourceMetricNames = resourceMetricNames
 
+	r.updateMaxParallelismStatus(logger, scaledObject, cache.GetMaxParallelism(ctx), status)
+
 	updateHealthStatus(scaledObject, externalMetricNames, status)
 
 	err = kedacontrollerutil.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status)
@@ -246,6 +296,43 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context,
 	return scaledObjectMetricSpecs, nil
 }
 
+// externalMetricSpecName returns the name of the external metric, empty for the other metrics
+func externalMetricSpecName(metricSpec autoscalingv2.MetricSpec) string {
+	if metricSpec.External == nil {
+		return ""
+	}
+	return metricSpec.External.Metric.Name
+}
+
+// updateMaxParallelismStatus stores the parallelism limit of partitioned sources of the triggers,
+// an event is emitted if maxReplicaCount exceeds it, when the limit or the ScaledObject changes
+func (r *ScaledObjectReconciler) updateMaxParallelismStatus(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, maxParallelism int64, status *kedav1alpha1.ScaledObjectStatus) {
+	if maxParallelism <= 0 {
+		status.MaxParallelism = nil
+		return
+	}
+	if maxParallelism > math.MaxInt32 {
+		maxParallelism = math.MaxInt32
+	}
+	limit := int32(maxParallelism)
+	status.MaxParallelism = &limit
+
+	maxReplicaCount := getHPAMaxReplicaCount(scaledObject)
+	if maxReplicaCount <= limit {
+		return
+	}
+	limitChanged := scaledObject.Status.MaxParallelism == nil || *scaledObject.Status.MaxParallelism != limit
+	specChanged, _ := r.scaledObjectGenerationChanged(logger, scaledObject)
+	if limitChanged || specChanged {
+		message := fmt.Sprintf("maxReplicaCount %d exceeds the parallelism %d of partitioned sources of the triggers, at most %d replicas consume in parallel", maxReplicaCount, limit, limit)
+		if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.ClampMaxReplicaCountToParallelism {
+			message = fmt.Sprintf("%s, maxReplicaCount of the HPA is clamped to it", message)
+		}
+		logger.Info(message)
+		r.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAMaxReplicaCountExceedsParallelism, message)
+	}
+}
+
 func updateHealthStatus(scaledObject *kedav1alpha1.ScaledObject, externalMetricNames []string, status *kedav1alpha1.ScaledObjectStatus) {
 	health := scaledObject.Status.Health
 	newHealth := make(map[string]kedav1alpha1.HealthStatus)
@@ -279,8 +366,23 @@ func getHPAMinReplicas(scaledObject *kedav1alpha1.ScaledObject) *int32 {
 	return &tmp
 }
 
-// getHPAMaxReplicas returns MaxReplicas based on definition in ScaledObject or default value if not defined
+// getHPAMaxReplicas returns MaxReplicas based on definition in ScaledObject or default value if not defined,
+// it's clamped to the parallelism of partitioned sources of the triggers if requested
 func getHPAMaxReplicas(scaledObject *kedav1alpha1.ScaledObject) int32 {
+	maxReplicas := getHPAMaxReplicaCount(scaledObject)
+	if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.ClampMaxReplicaCountToParallelism &&
+		scaledObject.Status.MaxParallelism != nil && *scaledObject.Status.MaxParallelism < maxReplicas {
+		maxReplicas = *scaledObject.Status.MaxParallelism
+		// MaxReplicas of HPA can't be lower than MinReplicas
+		if minReplicas := *getHPAMinReplicas(scaledObject); maxReplicas < minReplicas {
+			maxReplicas = minReplicas
+		}
+	}
+	return maxReplicas
+}
+
+// getHPAMaxReplicaCount returns maxReplicaCount defined in ScaledObject or default value if not defined
+func getHPAMaxReplicaCount(scaledObject *kedav1alpha1.ScaledObject) int32 {
 	if scaledObject.Spec.MaxReplicaCount != nil {
 		return *scaledObject.Spec.MaxReplicaCount
 	}
@@ -118,6 +118,57 @@ var _ = Describe("hpa", func() {
 		Expect(capturedScaledObject.Status.Health).To(Equal(expectedHealth))
 	})
 
+	It("should clamp maxReplicas to the parallelism of partitioned sources", func() {
+		maxReplicaCount := int32(50)
+		maxParallelism := int32(12)
+		scaledObject := &v1alpha1.ScaledObject{
+			Spec: v1alpha1.ScaledObjectSpec{
+				MaxReplicaCount: &maxReplicaCount,
+			},
+			Status: v1alpha1.ScaledObjectStatus{
+				MaxParallelism: &maxParallelism,
+			},
+		}
+		Expect(getHPAMaxReplicas(scaledObject)).To(Equal(int32(50)))
+
+		scaledObject.Spec.Advanced = &v1alpha1.AdvancedConfig{ClampMaxReplicaCountToParallelism: true}
+		Expect(getHPAMaxReplicas(scaledObject)).To(Equal(int32(12)))
+
+		minReplicaCount := int32(20)
+		scaledObject.Spec.MinReplicaCount = &minReplicaCount
+		Expect(getHPAMaxReplicas(scaledObject)).To(Equal(int32(20)))
+	})
+
+	It("should not adopt HPA owned by another ScaledObject", func() {
+		isController := true
+		scaledObject := &v1alpha1.ScaledObject{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        "so-b",
+				Namespace:   "default",
+				UID:         "uid-b",
+				Annotations: map[string]string{v1alpha1.ScaledObjectTransferHpaOwnershipAnnotation: "true"},
+			},
+		}
+		foundHpa := &v2.HorizontalPodAutoscaler{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "keda-hpa-so-a",
+				Namespace: "default",
+				OwnerReferences: []v1.OwnerReference{{
+					APIVersion: v1alpha1.SchemeGroupVersion.String(),
+					Kind:       "ScaledObject",
+					Name:       "so-a",
+					UID:        "uid-a",
+					Controller: &isController,
+				}},
+			},
+		}
+
+		// the HPA is neither updated nor assigned to the ScaledObject
+		err := reconciler.adoptHPA(context.Background(), logger, scaledObject, foundHpa, &v1alpha1.GroupVersionKindResource{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("owned by another ScaledObject"))
+	})
+
 })
 
 func setupTest(health map[string]v1alpha1.HealthStatus, scaler *mock_scalers.MockScaler, scaleHandler *mock_scaling.MockScaleHandler) *v1alpha1.ScaledObject {
@@ -0,0 +1,183 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+// kedaHealthRefreshInterval specifies how often the KedaHealth status is refreshed,
+// even if there aren't any changes in ScaledObjects or ScaledJobs (eg. to detect changes in Metrics Server connectivity)
+const kedaHealthRefreshInterval = 30 * time.Second
+
+// AdapterConnectivityChecker reports whether KEDA Metrics Server is connected to KEDA Operator
+type AdapterConnectivityChecker interface {
+	IsClientConnected() bool
+}
+
+// +kubebuilder:rbac:groups=keda.sh,resources=kedahealths;kedahealths/status,verbs="*"
+
+// KedaHealthReconciler maintains the KedaHealth singleton with the aggregated status of ScaledObjects and ScaledJobs
+type KedaHealthReconciler struct {
+	Client              client.Client
+	AdapterConnectivity AdapterConnectivityChecker
+}
+
+// SetupWithManager initializes the KedaHealthReconciler instance and starts a new controller managed by the passed Manager instance.
+func (r *KedaHealthReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kedav1alpha1.KedaHealth{}).
+		Watches(&source.Kind{Type: &kedav1alpha1.ScaledObject{}}, handler.EnqueueRequestsFromMapFunc(mapToKedaHealth)).
+		Watches(&source.Kind{Type: &kedav1alpha1.ScaledJob{}}, handler.EnqueueRequestsFromMapFunc(mapToKedaHealth)).
+		Complete(r)
+}
+
+// mapToKedaHealth enqueues the KedaHealth singleton for any change in the watched resources
+func mapToKedaHealth(client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: kedav1alpha1.KedaHealthName}}}
+}
+
+// Reconcile recomputes the status of the KedaHealth singleton, creating the singleton if it doesn't exist
+func (r *KedaHealthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	if req.Name != kedav1alpha1.KedaHealthName {
+		reqLogger.V(1).Info("Ignoring KedaHealth, only a singleton with this name is maintained", "expectedName", kedav1alpha1.KedaHealthName)
+		return ctrl.Result{}, nil
+	}
+
+	kedaHealth := &kedav1alpha1.KedaHealth{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: kedav1alpha1.KedaHealthName}, kedaHealth)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			reqLogger.Error(err, "Failed to get KedaHealth")
+			return ctrl.Result{}, err
+		}
+		kedaHealth = &kedav1alpha1.KedaHealth{ObjectMeta: metav1.ObjectMeta{Name: kedav1alpha1.KedaHealthName}}
+		if err := r.Client.Create(ctx, kedaHealth); err != nil {
+			reqLogger.Error(err, "Failed to create KedaHealth")
+			return ctrl.Result{}, err
+		}
+	}
+
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := r.Client.List(ctx, scaledObjects); err != nil {
+		reqLogger.Error(err, "Failed to list ScaledObjects")
+		return ctrl.Result{}, err
+	}
+	scaledJobs := &kedav1alpha1.ScaledJobList{}
+	if err := r.Client.List(ctx, scaledJobs); err != nil {
+		reqLogger.Error(err, "Failed to list ScaledJobs")
+		return ctrl.Result{}, err
+	}
+
+	adapterConnected := r.AdapterConnectivity != nil && r.AdapterConnectivity.IsClientConnected()
+	status := getKedaHealthStatus(scaledObjects.Items, scaledJobs.Items, adapterConnected)
+
+	// LastUpdateTime is not part of the comparison, otherwise we would update the status on every refresh
+	status.LastUpdateTime = kedaHealth.Status.LastUpdateTime
+	if !equality.Semantic.DeepEqual(status, kedaHealth.Status) {
+		now := metav1.Now()
+		status.LastUpdateTime = &now
+
+		patch := client.MergeFrom(kedaHealth.DeepCopy())
+		kedaHealth.Status = status
+		if err := r.Client.Status().Patch(ctx, kedaHealth, patch); err != nil {
+			reqLogger.Error(err, "Failed to patch KedaHealth Status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: kedaHealthRefreshInterval}, nil
+}
+
+// getKedaHealthStatus aggregates status of the input ScaledObjects and ScaledJobs
+func getKedaHealthStatus(scaledObjects []kedav1alpha1.ScaledObject, scaledJobs []kedav1alpha1.ScaledJob, adapterConnected bool) kedav1alpha1.KedaHealthStatus {
+	status := kedav1alpha1.KedaHealthStatus{
+		ScaledObjects:    int32(len(scaledObjects)),
+		ScaledJobs:       int32(len(scaledJobs)),
+		AdapterConnected: adapterConnected,
+	}
+
+	failingTriggers := map[string]int32{}
+	for _, so := range scaledObjects {
+		if _, paused := so.GetAnnotations()[kedacontrollerutil.PausedReplicasAnnotation]; paused {
+			status.PausedScaledObjects++
+		}
+		readyCondition := so.Status.Conditions.GetReadyCondition()
+		if readyCondition.IsFalse() {
+			status.NotReadyScaledObjects++
+		}
+		for _, trigger := range getFailingTriggers(so.Spec.Triggers, so.Status.Health) {
+			failingTriggers[trigger.Type]++
+		}
+	}
+
+	for _, sj := range scaledJobs {
+		readyCondition := sj.Status.Conditions.GetReadyCondition()
+		if readyCondition.IsFalse() {
+			status.NotReadyScaledJobs++
+			// ScaledJobs don't report health of their triggers, all triggers of a not ready ScaledJob are failing
+			for _, trigger := range sj.Spec.Triggers {
+				failingTriggers[trigger.Type]++
+			}
+		}
+	}
+
+	if len(failingTriggers) > 0 {
+		status.FailingTriggers = failingTriggers
+	}
+
+	return status
+}
+
+// getFailingTriggers returns the triggers of a ScaledObject whose metrics are failing according to its health status,
+// health of the metrics is keyed by their names prefixed by the index or the name of the trigger
+func getFailingTriggers(triggers []kedav1alpha1.ScaleTriggers, health map[string]kedav1alpha1.HealthStatus) []kedav1alpha1.ScaleTriggers {
+	var failing []kedav1alpha1.ScaleTriggers
+	for i, trigger := range triggers {
+		for metricName, metricHealth := range health {
+			if metricHealth.Status != kedav1alpha1.HealthStatusFailing {
+				continue
+			}
+			if strings.HasPrefix(metricName, fmt.Sprintf("s%d-", i)) ||
+				scalers.RemoveTriggerNameFromMetricName(i, trigger.Name, metricName) != metricName {
+				failing = append(failing, trigger)
+				break
+			}
+		}
+	}
+	return failing
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+)
+
+var _ = Describe("KedaHealth", func() {
+	It("should aggregate status of ScaledObjects and ScaledJobs", func() {
+		notReady := v1alpha1.Conditions{{Type: v1alpha1.ConditionReady, Status: metav1.ConditionFalse}}
+		ready := v1alpha1.Conditions{{Type: v1alpha1.ConditionReady, Status: metav1.ConditionTrue}}
+		failing := map[string]v1alpha1.HealthStatus{
+			"s0-kafka-topic":  {Status: v1alpha1.HealthStatusFailing},
+			"s1-cron-default": {Status: v1alpha1.HealthStatusFailing},
+		}
+
+		scaledObjects := []v1alpha1.ScaledObject{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "ready"},
+				Spec:       v1alpha1.ScaledObjectSpec{Triggers: []v1alpha1.ScaleTriggers{{Type: "kafka"}}},
+				Status:     v1alpha1.ScaledObjectStatus{Conditions: ready},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "failing"},
+				Spec:       v1alpha1.ScaledObjectSpec{Triggers: []v1alpha1.ScaleTriggers{{Type: "kafka"}, {Type: "cron"}}},
+				Status:     v1alpha1.ScaledObjectStatus{Conditions: notReady, Health: failing},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "paused", Annotations: map[string]string{kedacontrollerutil.PausedReplicasAnnotation: "0"}},
+				Spec:       v1alpha1.ScaledObjectSpec{Triggers: []v1alpha1.ScaleTriggers{{Type: "prometheus"}}},
+			},
+		}
+		scaledJobs := []v1alpha1.ScaledJob{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "failing"},
+				Spec:       v1alpha1.ScaledJobSpec{Triggers: []v1alpha1.ScaleTriggers{{Type: "kafka"}}},
+				Status:     v1alpha1.ScaledJobStatus{Conditions: notReady},
+			},
+		}
+
+		status := getKedaHealthStatus(scaledObjects, scaledJobs, true)
+
+		Expect(status.ScaledObjects).To(Equal(int32(3)))
+		Expect(status.ScaledJobs).To(Equal(int32(1)))
+		Expect(status.PausedScaledObjects).To(Equal(int32(1)))
+		Expect(status.NotReadyScaledObjects).To(Equal(int32(1)))
+		Expect(status.NotReadyScaledJobs).To(Equal(int32(1)))
+		Expect(status.FailingTriggers).To(Equal(map[string]int32{"kafka": 2, "cron": 1}))
+		Expect(status.AdapterConnected).To(BeTrue())
+	})
+
+	It("should report triggers of not ready ScaledJobs as failing", func() {
+		scaledJobs := []v1alpha1.ScaledJob{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "ready"},
+				Spec:       v1alpha1.ScaledJobSpec{Triggers: []v1alpha1.ScaleTriggers{{Type: "kafka"}}},
+				Status:     v1alpha1.ScaledJobStatus{Conditions: v1alpha1.Conditions{{Type: v1alpha1.ConditionReady, Status: metav1.ConditionTrue}}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "not-ready"},
+				Spec:       v1alpha1.ScaledJobSpec{Triggers: []v1alpha1.ScaleTriggers{{Type: "rabbitmq"}, {Type: "rabbitmq"}, {Type: "cron"}}},
+				Status:     v1alpha1.ScaledJobStatus{Conditions: v1alpha1.Conditions{{Type: v1alpha1.ConditionReady, Status: metav1.ConditionFalse}}},
+			},
+		}
+
+		status := getKedaHealthStatus(nil, scaledJobs, true)
+
+		Expect(status.ScaledJobs).To(Equal(int32(2)))
+		Expect(status.NotReadyScaledJobs).To(Equal(int32(1)))
+		Expect(status.FailingTriggers).To(Equal(map[string]int32{"rabbitmq": 2, "cron": 1}))
+	})
+
+	It("should report only failing triggers of ScaledObjects with healthy and failing triggers", func() {
+		scaledObjects := []v1alpha1.ScaledObject{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "mixed"},
+				Spec: v1alpha1.ScaledObjectSpec{Triggers: []v1alpha1.ScaleTriggers{
+					{Type: "cpu"},
+					{Type: "prometheus"},
+					{Type: "kafka"},
+					{Type: "rabbitmq", Name: "orders queue"},
+					{Type: "rabbitmq", Name: "payments"},
+				}},
+				Status: v1alpha1.ScaledObjectStatus{
+					Conditions: v1alpha1.Conditions{{Type: v1alpha1.ConditionReady, Status: metav1.ConditionFalse}},
+					Health: map[string]v1alpha1.HealthStatus{
+						"s1-prometheus":                  {Status: v1alpha1.HealthStatusHappy},
+						"s2-kafka-topic":                 {Status: v1alpha1.HealthStatusFailing},
+						"s.orders-queue.rabbitmq-orders": {Status: v1alpha1.HealthStatusFailing},
+						"s.payments.rabbitmq-payments":   {Status: v1alpha1.HealthStatusHappy},
+					},
+				},
+			},
+		}
+
+		status := getKedaHealthStatus(scaledObjects, nil, true)
+
+		Expect(status.NotReadyScaledObjects).To(Equal(int32(1)))
+		Expect(status.FailingTriggers).To(Equal(map[string]int32{"kafka": 1, "rabbitmq": 1}))
+	})
+
+	It("should not report failing triggers when all resources are ready", func() {
+		status := getKedaHealthStatus(nil, nil, false)
+
+		Expect(status.ScaledObjects).To(Equal(int32(0)))
+		Expect(status.FailingTriggers).To(BeNil())
+		Expect(status.AdapterConnected).To(BeFalse())
+	})
+})
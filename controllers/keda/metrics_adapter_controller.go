@@ -30,12 +30,10 @@ import (
 	"sigs.k8s.io/custom-metrics-apiserver/pkg/provider"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
-	"github.com/kedacore/keda/v2/pkg/scaling"
 )
 
 type MetricsScaledObjectReconciler struct {
 	Client                  client.Client
-	ScaleHandler            scaling.ScaleHandler
 	ExternalMetricsInfo     *[]provider.ExternalMetricInfo
 	ExternalMetricsInfoLock *sync.RWMutex
 	MaxConcurrentReconciles int
@@ -57,12 +55,8 @@ func (r *MetricsScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
-			err := r.ScaleHandler.ClearScalersCache(ctx, scaledObject)
-			if err != nil {
-				reqLogger.Error(err, "error clearing scalers cache")
-			}
 			r.removeFromMetricsCache(req.NamespacedName.String())
-			return ctrl.Result{}, err
+			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		reqLogger.Error(err, "Failed to get ScaledObject")
@@ -73,12 +67,8 @@ func (r *MetricsScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.
 	// indicated by the deletion timestamp being set.
 	// This depends on the preexisting finalizer setup in ScaledObjectController.
 	if scaledObject.GetDeletionTimestamp() != nil {
-		err := r.ScaleHandler.ClearScalersCache(ctx, scaledObject)
-		if err != nil {
-			reqLogger.Error(err, "error clearing scalers cache")
-		}
 		r.removeFromMetricsCache(req.NamespacedName.String())
-		return ctrl.Result{}, err
+		return ctrl.Result{}, nil
 	}
 
 	reqLogger.V(1).Info("Reconciling ScaledObject", "externalMetricNames", scaledObject.Status.ExternalMetricNames)
@@ -89,11 +79,7 @@ func (r *MetricsScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 
 	r.addToMetricsCache(req.NamespacedName.String(), scaledObject.Status.ExternalMetricNames)
-	err = r.ScaleHandler.ClearScalersCache(ctx, scaledObject)
-	if err != nil {
-		reqLogger.Error(err, "error clearing scalers cache")
-	}
-	return ctrl.Result{}, err
+	return ctrl.Result{}, nil
 }
 
 func (r *MetricsScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
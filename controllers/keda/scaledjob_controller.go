@@ -19,6 +19,7 @@ package keda
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -43,6 +44,7 @@ import (
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/prommetrics"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 )
 
 // +kubebuilder:rbac:groups=keda.sh,resources=scaledjobs;scaledjobs/finalizers;scaledjobs/status,verbs="*"
@@ -54,9 +56,12 @@ type ScaledJobReconciler struct {
 	Scheme            *runtime.Scheme
 	GlobalHTTPTimeout time.Duration
 	Recorder          record.EventRecorder
+	// ScaleHandler is shared with the ScaledObjectReconciler, a dedicated one is created if it isn't set
+	ScaleHandler scaling.ScaleHandler
+	// Shard filters ScaledJobs reconciled by this instance of KEDA Operator, all of them are reconciled if it isn't set
+	Shard sharding.Shard
 
 	scaledJobGenerations *sync.Map
-	scaleHandler         scaling.ScaleHandler
 	SecretsLister        corev1listers.SecretLister
 	SecretsSynced        cache.InformerSynced
 }
@@ -78,20 +83,27 @@ func init() {
 
 // SetupWithManager initializes the ScaledJobReconciler instance and starts a new controller managed by the passed Manager instance.
 func (r *ScaledJobReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
-	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, mgr.GetEventRecorderFor("scale-handler"), r.SecretsLister)
+	if r.ScaleHandler == nil {
+		r.ScaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, mgr.GetEventRecorderFor("scale-handler"), r.SecretsLister, nil, nil)
+	}
 	r.scaledJobGenerations = &sync.Map{}
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// Ignore updates to ScaledJob Status (in this case metadata.Generation does not change)
 		// so reconcile loop is not started on Status updates
-		For(&kedav1alpha1.ScaledJob{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Complete(r)
+		For(&kedav1alpha1.ScaledJob{}, builder.WithPredicates(r.Shard.Predicate(), predicate.GenerationChangedPredicate{})).
+		Complete(kedacontrollerutil.WithReconcileMetrics(prommetrics.ScaledJobResource, r))
 }
 
 // Reconcile performs reconciliation on the identified ScaledJob resource based on the request information passed, returns the result and an error (if any).
 func (r *ScaledJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reqLogger := log.FromContext(ctx)
 
+	// ScaledJobs of other shards aren't reconciled even if they are enqueued, eg. by a requeue before resharding
+	if !r.Shard.Owns(req.Namespace, req.Name) {
+		return ctrl.Result{}, nil
+	}
+
 	// Fetch the ScaledJob instance
 	scaledJob := &kedav1alpha1.ScaledJob{}
 	err := r.Client.Get(ctx, req.NamespacedName, scaledJob)
@@ -167,12 +179,20 @@ func (r *ScaledJobReconciler) reconcileScaledJob(ctx context.Context, logger log
 	}
 
 	// Check ScaledJob is Ready or not
-	_, err = r.scaleHandler.GetScalersCache(ctx, scaledJob)
+	_, err = r.ScaleHandler.GetScalersCache(ctx, scaledJob)
 	if err != nil {
 		logger.Error(err, "Error getting scalers")
 		return "Failed to ensure ScaledJob is correctly created", err
 	}
 
+	switch scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation {
+	case "", "max", "min", "avg", "sum":
+	default:
+		err := fmt.Errorf("unknown multipleScalersCalculation: %s", scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation)
+		logger.Error(err, "multipleScalersCalculation must be one of max, min, avg or sum")
+		return "ScaledJob has an unknown multipleScalersCalculation", err
+	}
+
 	for _, trigger := range scaledJob.Spec.Triggers {
 		if trigger.UseCachedMetrics {
 			logger.Info("Warning: property useCachedMetrics is not supported for ScaledJobs.")
@@ -217,10 +237,19 @@ func (r *ScaledJobReconciler) deletePreviousVersionScaleJobs(ctx context.Context
 			return "Cannot get list of Jobs owned by this scaledJob", err
 		}
 
-		if len(jobs.Items) > 0 {
-			logger.Info("RolloutStrategy: immediate, Deleting jobs owned by the previous version of the scaledJob", "numJobsToDelete", len(jobs.Items))
+		// jobs created from the current generation are kept, the reconcile may not be caused by a change of the spec,
+		// e.g. when KEDA Operator restarts
+		previousJobs := make([]batchv1.Job, 0, len(jobs.Items))
+		for i := range jobs.Items {
+			if !isJobOfCurrentGeneration(&jobs.Items[i], scaledJob) {
+				previousJobs = append(previousJobs, jobs.Items[i])
+			}
+		}
+
+		if len(previousJobs) > 0 {
+			logger.Info("RolloutStrategy: immediate, Deleting jobs owned by the previous version of the scaledJob", "numJobsToDelete", len(previousJobs))
 		}
-		for _, job := range jobs.Items {
+		for _, job := range previousJobs {
 			job := job
 
 			propagationPolicy := metav1.DeletePropagationBackground
@@ -232,11 +261,18 @@ func (r *ScaledJobReconciler) deletePreviousVersionScaleJobs(ctx context.Context
 				return "Not able to delete job: " + job.Name, err
 			}
 		}
-		return fmt.Sprintf("RolloutStrategy: immediate, deleted jobs owned by the previous version of the scaleJob: %d jobs deleted", len(jobs.Items)), nil
+		return fmt.Sprintf("RolloutStrategy: immediate, deleted jobs owned by the previous version of the scaleJob: %d jobs deleted", len(previousJobs)), nil
 	}
 	return fmt.Sprintf("RolloutStrategy: %s", scaledJob.Spec.RolloutStrategy), nil
 }
 
+// isJobOfCurrentGeneration returns true if the job was created from the current generation of the scaledJob,
+// jobs created before the generation label was introduced are considered to be of a previous generation
+func isJobOfCurrentGeneration(job *batchv1.Job, scaledJob *kedav1alpha1.ScaledJob) bool {
+	generation, ok := job.GetLabels()[kedav1alpha1.ScaledJobGenerationLabel]
+	return ok && generation == strconv.FormatInt(scaledJob.GetGeneration(), 10)
+}
+
 // requestScaleLoop request ScaleLoop handler for the respective ScaledJob
 func (r *ScaledJobReconciler) requestScaleLoop(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) error {
 	logger.V(1).Info("Starting a new ScaleLoop")
@@ -247,7 +283,7 @@ func (r *ScaledJobReconciler) requestScaleLoop(ctx context.Context, logger logr.
 		return err
 	}
 
-	if err = r.scaleHandler.HandleScalableObject(ctx, scaledJob); err != nil {
+	if err = r.ScaleHandler.HandleScalableObject(ctx, scaledJob); err != nil {
 		return err
 	}
 
@@ -266,7 +302,7 @@ func (r *ScaledJobReconciler) stopScaleLoop(ctx context.Context, logger logr.Log
 		return err
 	}
 
-	if err = r.scaleHandler.DeleteScalableObject(ctx, scaledJob); err != nil {
+	if err = r.ScaleHandler.DeleteScalableObject(ctx, scaledJob); err != nil {
 		return err
 	}
 
@@ -0,0 +1,156 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+)
+
+// scaleTargetConflictError is returned if the scale target of a ScaledObject is already scaled by another
+// ScaledObject or by an HPA not managed by KEDA, two HPAs of the same workload would fight over its replicas
+type scaleTargetConflictError struct {
+	message string
+}
+
+func (e *scaleTargetConflictError) Error() string {
+	return e.message
+}
+
+// checkScaleTargetConflicts returns scaleTargetConflictError if another ScaledObject or an HPA not managed by KEDA
+// targets the same workload. Conflicting ScaledObjects are resolved by age, the oldest one (by creation, then by name)
+// keeps scaling the workload, so an existing setup isn't broken by a newly created ScaledObject.
+func (r *ScaledObjectReconciler) checkScaleTargetConflicts(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) error {
+	scaledObjectList := &kedav1alpha1.ScaledObjectList{}
+	if err := r.Client.List(ctx, scaledObjectList, client.InNamespace(scaledObject.Namespace)); err != nil {
+		return err
+	}
+	for i := range scaledObjectList.Items {
+		other := &scaledObjectList.Items[i]
+		if other.Name == scaledObject.Name || other.GetDeletionTimestamp() != nil || other.Spec.ScaleTargetRef == nil {
+			continue
+		}
+		if !r.isSameScaleTarget(logger, gvkr, scaledObject.Spec.ScaleTargetRef.Name, other.Spec.ScaleTargetRef.APIVersion, other.Spec.ScaleTargetRef.Kind, other.Spec.ScaleTargetRef.Name) {
+			continue
+		}
+		if isOlderScaledObject(scaledObject, other) {
+			logger.V(1).Info("Workload is targeted by a newer ScaledObject too, it won't be scaled by it", "scaledObject", other.Name)
+			continue
+		}
+		return &scaleTargetConflictError{message: fmt.Sprintf("the workload '%s' of type '%s' is already managed by the ScaledObject '%s'",
+			scaledObject.Spec.ScaleTargetRef.Name, gvkr.GVKString(), other.Name)}
+	}
+
+	hpaList := &autoscalingv2.HorizontalPodAutoscalerList{}
+	if err := r.Client.List(ctx, hpaList, client.InNamespace(scaledObject.Namespace)); err != nil {
+		return err
+	}
+	for _, hpa := range hpaList.Items {
+		// HPAs of other ScaledObjects are resolved by the check of ScaledObjects
+		if isOwnedByScaledObject(&hpa) {
+			continue
+		}
+		// the HPA is going to be adopted by the ScaledObject
+		if isHpaOwnershipTransferRequested(scaledObject) && hpa.Name == getHPAName(scaledObject) {
+			continue
+		}
+		if r.isSameScaleTarget(logger, gvkr, scaledObject.Spec.ScaleTargetRef.Name, hpa.Spec.ScaleTargetRef.APIVersion, hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name) {
+			return &scaleTargetConflictError{message: fmt.Sprintf("the workload '%s' of type '%s' is already managed by the hpa '%s'",
+				scaledObject.Spec.ScaleTargetRef.Name, gvkr.GVKString(), hpa.Name)}
+		}
+	}
+	return nil
+}
+
+// isSameScaleTarget returns true if the target given by apiVersion, kind and name is the workload of gvkr and targetName
+func (r *ScaledObjectReconciler) isSameScaleTarget(logger logr.Logger, gvkr *kedav1alpha1.GroupVersionKindResource, targetName, apiVersion, kind, name string) bool {
+	if name != targetName {
+		return false
+	}
+	otherGvkr, err := kedav1alpha1.ParseGVKR(r.restMapper, apiVersion, kind)
+	if err != nil {
+		logger.V(1).Info("Failed to parse Group, Version, Kind, Resource of scale target", "apiVersion", apiVersion, "kind", kind, "error", err.Error())
+		return false
+	}
+	return otherGvkr.GroupVersionKind() == gvkr.GroupVersionKind()
+}
+
+// isOlderScaledObject returns true if scaledObject was created before other, ScaledObjects created
+// in the same second are ordered by name
+func isOlderScaledObject(scaledObject, other *kedav1alpha1.ScaledObject) bool {
+	if !scaledObject.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return scaledObject.CreationTimestamp.Before(&other.CreationTimestamp)
+	}
+	return scaledObject.Name < other.Name
+}
+
+func isOwnedByScaledObject(hpa *autoscalingv2.HorizontalPodAutoscaler) bool {
+	for _, owner := range hpa.OwnerReferences {
+		if owner.Kind == "ScaledObject" && owner.APIVersion == kedav1alpha1.SchemeGroupVersion.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// isHpaOwnershipTransferRequested returns true if the ScaledObject should adopt an existing HPA of the same name
+func isHpaOwnershipTransferRequested(scaledObject *kedav1alpha1.ScaledObject) bool {
+	return scaledObject.GetAnnotations()[kedav1alpha1.ScaledObjectTransferHpaOwnershipAnnotation] == "true"
+}
+
+// releaseScaleTarget stops the scale loop of the conflicting ScaledObject and deletes its HPA, if it was created
+// before the conflict was detected, so the workload is scaled only by the ScaledObject or HPA managing it
+func (r *ScaledObjectReconciler) releaseScaleTarget(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	if err := r.stopScaleLoop(ctx, logger, scaledObject); err != nil {
+		return err
+	}
+
+	if scaledObject.Status.HpaName == "" {
+		return nil
+	}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: scaledObject.Status.HpaName, Namespace: scaledObject.Namespace}, hpa)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	owned := false
+	for _, owner := range hpa.OwnerReferences {
+		owned = owned || owner.UID == scaledObject.UID
+	}
+	if !owned {
+		return nil
+	}
+
+	logger.Info("Deleting HPA of ScaledObject in conflict", "HPA.Namespace", hpa.Namespace, "HPA.Name", hpa.Name)
+	if err := r.Client.Delete(ctx, hpa); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	status := scaledObject.Status.DeepCopy()
+	status.HpaName = ""
+	return kedacontrollerutil.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status)
+}
@@ -45,7 +45,9 @@ import (
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/prommetrics"
+	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 )
 
 // +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects;scaledobjects/finalizers;scaledobjects/status,verbs="*"
@@ -65,6 +67,8 @@ type ScaledObjectReconciler struct {
 	Recorder     record.EventRecorder
 	ScaleClient  scale.ScalesGetter
 	ScaleHandler scaling.ScaleHandler
+	// Shard filters ScaledObjects reconciled by this instance of KEDA Operator, all of them are reconciled if it isn't set
+	Shard sharding.Shard
 
 	restMapper               meta.RESTMapper
 	scaledObjectsGenerations *sync.Map
@@ -72,7 +76,9 @@ type ScaledObjectReconciler struct {
 
 type scaledObjectMetricsData struct {
 	namespace    string
+	name         string
 	triggerTypes []string
+	hpaName      string
 }
 
 var (
@@ -120,20 +126,28 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 		// (in this case metadata.Generation does not change)
 		// so reconcile loop is not started on Status updates
 		For(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(
+			r.Shard.Predicate(),
 			predicate.Or(
 				kedacontrollerutil.PausedReplicasPredicate{},
+				kedacontrollerutil.DryRunPredicate{},
 				kedacontrollerutil.ScaleObjectReadyConditionPredicate{},
 				predicate.GenerationChangedPredicate{},
 			),
 		)).
 		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
-		Complete(r)
+		Complete(kedacontrollerutil.WithReconcileMetrics(prommetrics.ScaledObjectResource, r))
 }
 
 // Reconcile performs reconciliation on the identified ScaledObject resource based on the request information passed, returns the result and an error (if any).
 func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reqLogger := log.FromContext(ctx)
 
+	// the shard predicate filters events of ScaledObjects only, events of the owned HPAs enqueue their ScaledObject
+	// on every shard, the ScaledObject is reconciled only by the shard owning it
+	if !r.Shard.Owns(req.Namespace, req.Name) {
+		return ctrl.Result{}, nil
+	}
+
 	// Fetch the ScaledObject instance
 	scaledObject := &kedav1alpha1.ScaledObject{}
 	err := r.Client.Get(ctx, req.NamespacedName, scaledObject)
@@ -174,7 +188,12 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	// reconcile ScaledObject and set status appropriately
 	msg, err := r.reconcileScaledObject(ctx, reqLogger, scaledObject)
 	conditions := scaledObject.Status.Conditions.DeepCopy()
-	if err != nil {
+	if _, ok := err.(*scaleTargetConflictError); ok {
+		reqLogger.Error(err, "ScaledObject is in conflict with another ScaledObject or HPA")
+		conditions.SetReadyCondition(metav1.ConditionFalse, kedav1alpha1.ScaledObjectConditionConflictReason, msg)
+		conditions.SetActiveCondition(metav1.ConditionUnknown, "UnkownState", "ScaledObject is in conflict")
+		r.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.ScaledObjectConflict, msg)
+	} else if err != nil {
 		reqLogger.Error(err, msg)
 		conditions.SetReadyCondition(metav1.ConditionFalse, "ScaledObjectCheckFailed", msg)
 		conditions.SetActiveCondition(metav1.ConditionUnknown, "UnkownState", "ScaledObject check failed")
@@ -191,6 +210,7 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if err := kedacontrollerutil.SetStatusConditions(ctx, r.Client, reqLogger, scaledObject, &conditions); err != nil {
 		return ctrl.Result{}, err
 	}
+	prommetrics.RecordScaledObjectStatus(scaledObject)
 
 	return ctrl.Result{}, err
 }
@@ -220,15 +240,39 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		return "ScaledObject doesn't have correct Idle/Min/Max Replica Counts specification", err
 	}
 
+	// Check the parameters of replica calculator are valid, it falls back to the default calculator otherwise
+	if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.ReplicaCalculator != nil {
+		if err := scaledObject.Spec.Advanced.ReplicaCalculator.Validate(); err != nil {
+			return "ScaledObject doesn't have correct replicaCalculator specification", err
+		}
+	}
+
+	// Check the scale target isn't scaled by another ScaledObject or HPA, a second HPA isn't created for it
+	err = r.checkScaleTargetConflicts(ctx, logger, scaledObject, &gvkr)
+	if conflictErr, ok := err.(*scaleTargetConflictError); ok {
+		if err := r.releaseScaleTarget(ctx, logger, scaledObject); err != nil {
+			return "Failed to release scale target of ScaledObject in conflict", err
+		}
+		return conflictErr.Error(), conflictErr
+	} else if err != nil {
+		return "Failed to check other ScaledObjects and HPAs targeting the workload", err
+	}
+
 	err = r.checkTriggers(logger, scaledObject)
 	if err != nil {
 		return "ScaledObject doesn't have correct triggers specification", err
 	}
 
-	// Create a new HPA or update existing one according to ScaledObject
-	newHPACreated, err := r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
-	if err != nil {
-		return "Failed to ensure HPA is correctly created for ScaledObject", err
+	// Create a new HPA or update existing one according to ScaledObject,
+	// the HPA isn't managed in dry-run mode, the scale loop only records the recommended replica count
+	newHPACreated := false
+	if kedacontrollerutil.IsDryRunEnabled(scaledObject) {
+		logger.V(1).Info("ScaledObject is in dry-run mode, HPA isn't managed")
+	} else {
+		newHPACreated, err = r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
+		if err != nil {
+			return "Failed to ensure HPA is correctly created for ScaledObject", err
+		}
 	}
 	scaleObjectSpecChanged := false
 	if !newHPACreated {
@@ -342,6 +386,8 @@ func (r *ScaledObjectReconciler) checkTriggers(logger logr.Logger, scaledObject
 
 	if triggersCount > 1 {
 		triggerNames := make(map[string]bool, triggersCount)
+		// metric names are derived from sanitized trigger names, see scalers.GenerateMetricNameWithTriggerName
+		sanitizedTriggerNames := make(map[string]string, triggersCount)
 		for i := 0; i < triggersCount; i++ {
 			trigger := scaledObject.Spec.Triggers[i]
 
@@ -365,6 +411,12 @@ func (r *ScaledObjectReconciler) checkTriggers(logger logr.Logger, scaledObject
 					return fmt.Errorf("triggerName %q is defined multiple times in the ScaledObject, but it must be unique", name)
 				}
 				triggerNames[name] = true
+
+				sanitizedName := scalers.SanitizeTriggerName(name)
+				if otherName, found := sanitizedTriggerNames[sanitizedName]; found {
+					return fmt.Errorf("triggerName %q and %q result in the same metric names, they must differ in letters, digits, '_' or '-'", otherName, name)
+				}
+				sanitizedTriggerNames[sanitizedName] = name
 			}
 		}
 	}
@@ -417,6 +469,19 @@ func (r *ScaledObjectReconciler) ensureHPAForScaledObjectExists(ctx context.Cont
 		return false, err
 	}
 
+	// the HPA wasn't created for this ScaledObject, it's adopted only if its ownership transfer is requested
+	if !metav1.IsControlledBy(foundHpa, scaledObject) {
+		if !isHpaOwnershipTransferRequested(scaledObject) {
+			return false, fmt.Errorf("HPA %s already exists and isn't owned by the ScaledObject, set annotation %s to \"true\" to transfer its ownership",
+				foundHpa.Name, kedav1alpha1.ScaledObjectTransferHpaOwnershipAnnotation)
+		}
+		if err := r.adoptHPA(ctx, logger, scaledObject, foundHpa, gvkr); err != nil {
+			return false, err
+		}
+		// the adopted HPA is driven by KEDA from now on -> notify Reconcile function so it could fire a new ScaleLoop
+		return true, nil
+	}
+
 	// check if hpa name is changed, and if so we need to delete the old hpa before creating new one
 	if isHpaRenamed(scaledObject, foundHpa) {
 		err = r.renameHPA(ctx, logger, scaledObject, foundHpa, gvkr)
@@ -427,6 +492,8 @@ func (r *ScaledObjectReconciler) ensureHPAForScaledObjectExists(ctx context.Cont
 		return true, nil
 	}
 
+	r.updateHPAPromMetrics(scaledObject, foundHpa)
+
 	// HPA was found -> let's check if we need to update it
 	err = r.updateHPAIfNeeded(ctx, logger, scaledObject, foundHpa, gvkr)
 	if err != nil {
@@ -515,6 +582,7 @@ func (r *ScaledObjectReconciler) updatePromMetrics(scaledObject *kedav1alpha1.Sc
 
 	prommetrics.IncrementCRDTotal(prommetrics.ScaledObjectResource, scaledObject.Namespace)
 	metricsData.namespace = scaledObject.Namespace
+	metricsData.name = scaledObject.Name
 
 	triggerTypes := make([]string, len(scaledObject.Spec.Triggers))
 	for _, trigger := range scaledObject.Spec.Triggers {
@@ -526,6 +594,27 @@ func (r *ScaledObjectReconciler) updatePromMetrics(scaledObject *kedav1alpha1.Sc
 	scaledObjectPromMetricsMap[namespacedName] = metricsData
 }
 
+// updateHPAPromMetrics records status of the HPA generated for the ScaledObject
+func (r *ScaledObjectReconciler) updateHPAPromMetrics(scaledObject *kedav1alpha1.ScaledObject, hpa *autoscalingv2.HorizontalPodAutoscaler) {
+	scaledObjectPromMetricsLock.Lock()
+	defer scaledObjectPromMetricsLock.Unlock()
+
+	namespacedName := client.ObjectKeyFromObject(scaledObject).String()
+	metricsData, ok := scaledObjectPromMetricsMap[namespacedName]
+	if !ok {
+		return
+	}
+
+	// the HPA was renamed, remove the metrics of the previous one
+	if metricsData.hpaName != "" && metricsData.hpaName != hpa.Name {
+		prommetrics.DeleteHPAStatus(metricsData.namespace, metricsData.name, metricsData.hpaName)
+	}
+	prommetrics.RecordHPAStatus(scaledObject.Namespace, scaledObject.Name, hpa)
+	metricsData.hpaName = hpa.Name
+
+	scaledObjectPromMetricsMap[namespacedName] = metricsData
+}
+
 func (r *ScaledObjectReconciler) updatePromMetricsOnDelete(namespacedName string) {
 	scaledObjectPromMetricsLock.Lock()
 	defer scaledObjectPromMetricsLock.Unlock()
@@ -535,6 +624,13 @@ func (r *ScaledObjectReconciler) updatePromMetricsOnDelete(namespacedName string
 		for _, triggerType := range metricsData.triggerTypes {
 			prommetrics.DecrementTriggerTotal(triggerType)
 		}
+		if metricsData.hpaName != "" {
+			prommetrics.DeleteHPAStatus(metricsData.namespace, metricsData.name, metricsData.hpaName)
+		}
+		prommetrics.DeleteScaledObjectStatus(metricsData.namespace, metricsData.name)
+		prommetrics.DeleteRecommendedReplicas(metricsData.namespace, metricsData.name)
+		prommetrics.DeleteDesiredReplicas(metricsData.namespace, metricsData.name)
+		prommetrics.DeletePendingActivation(metricsData.namespace, metricsData.name)
 	}
 
 	delete(scaledObjectPromMetricsMap, namespacedName)
@@ -33,12 +33,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_scaling"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 )
 
 type GinkgoTestReporter struct{}
@@ -231,6 +233,32 @@ var _ = Describe("ScaledObjectController", func() {
 		})
 	})
 
+	Describe("Sharding", func() {
+		It("reconciles ScaledObjects and ScaledJobs only by the shard owning them", func() {
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "sharded"}}
+			owner := sharding.ShardOf(request.Namespace, request.Name, 2)
+
+			for index := 0; index < 2; index++ {
+				ctrl := gomock.NewController(GinkgoTestReporter{})
+				mockClient := mock_client.NewMockClient(ctrl)
+				if index == owner {
+					// the object was deleted, so the owning shard returns right after getting it
+					notFound := errors.NewNotFound(kedav1alpha1.Resource("scaledobject"), request.Name)
+					mockClient.EXPECT().Get(gomock.Any(), request.NamespacedName, gomock.Any()).Return(notFound).Times(2)
+				}
+
+				shard := sharding.Shard{Index: index, Count: 2}
+				soReconciler := ScaledObjectReconciler{Client: mockClient, Shard: shard}
+				_, err := soReconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+				sjReconciler := ScaledJobReconciler{Client: mockClient, Shard: shard}
+				_, err = sjReconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+				ctrl.Finish()
+			}
+		})
+	})
+
 	Describe("functional tests", func() {
 		It("cleans up a deleted trigger from the HPA", func() {
 			// Create the scaling target.
@@ -661,6 +689,220 @@ var _ = Describe("ScaledObjectController", func() {
 			}, 20*time.Second).Should(Equal(metav1.ConditionFalse))
 		})
 
+		It("doesn't allow an invalid logisticGrowthRate in replicaCalculator", func() {
+			deploymentName := "invalid-growth-rate"
+			soName := "so-" + deploymentName
+
+			// Create the scaling target.
+			err := k8sClient.Create(context.Background(), generateDeployment(deploymentName))
+			Expect(err).ToNot(HaveOccurred())
+
+			// Create the ScaledObject
+			so := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: soName, Namespace: "default"},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+						Name: deploymentName,
+					},
+					Advanced: &kedav1alpha1.AdvancedConfig{
+						ReplicaCalculator: &kedav1alpha1.ReplicaCalculator{
+							Strategy:           "logistic",
+							LogisticGrowthRate: "-1",
+						},
+					},
+					Triggers: []kedav1alpha1.ScaleTriggers{
+						{
+							Type: "cron",
+							Metadata: map[string]string{
+								"timezone":        "UTC",
+								"start":           "0 * * * *",
+								"end":             "1 * * * *",
+								"desiredReplicas": "1",
+							},
+						},
+					},
+				},
+			}
+			err = k8sClient.Create(context.Background(), so)
+			Ω(err).ToNot(HaveOccurred())
+
+			Eventually(func() metav1.ConditionStatus {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: soName, Namespace: "default"}, so)
+				Ω(err).ToNot(HaveOccurred())
+				return so.Status.Conditions.GetReadyCondition().Status
+			}, 20*time.Second).Should(Equal(metav1.ConditionFalse))
+			Expect(so.Status.Conditions.GetReadyCondition().Reason).To(Equal("ScaledObjectCheckFailed"))
+		})
+
+		It("doesn't create a second HPA for a workload scaled by another ScaledObject", func() {
+			deploymentName := "conflicting-so"
+			soName := "so-" + deploymentName
+
+			// Create the scaling target.
+			err := k8sClient.Create(context.Background(), generateDeployment(deploymentName))
+			Expect(err).ToNot(HaveOccurred())
+
+			newScaledObject := func(name string) *kedav1alpha1.ScaledObject {
+				return &kedav1alpha1.ScaledObject{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+					Spec: kedav1alpha1.ScaledObjectSpec{
+						ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+							Name: deploymentName,
+						},
+						Triggers: []kedav1alpha1.ScaleTriggers{
+							{
+								Type: "cron",
+								Metadata: map[string]string{
+									"timezone":        "UTC",
+									"start":           "0 * * * *",
+									"end":             "1 * * * *",
+									"desiredReplicas": "1",
+								},
+							},
+						},
+					},
+				}
+			}
+			err = k8sClient.Create(context.Background(), newScaledObject(soName))
+			Expect(err).ToNot(HaveOccurred())
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Eventually(func() error {
+				return k8sClient.Get(context.Background(), types.NamespacedName{Name: fmt.Sprintf("keda-hpa-%s", soName), Namespace: "default"}, hpa)
+			}).ShouldNot(HaveOccurred())
+
+			// Create the second ScaledObject targeting the same workload
+			so := newScaledObject(soName + "-second")
+			err = k8sClient.Create(context.Background(), so)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(func() string {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: so.Name, Namespace: "default"}, so)
+				Ω(err).ToNot(HaveOccurred())
+				return so.Status.Conditions.GetReadyCondition().Reason
+			}, 20*time.Second).Should(Equal(kedav1alpha1.ScaledObjectConditionConflictReason))
+			Expect(so.Status.Conditions.GetReadyCondition().Status).To(Equal(metav1.ConditionFalse))
+
+			err = k8sClient.Get(context.Background(), types.NamespacedName{Name: fmt.Sprintf("keda-hpa-%s", so.Name), Namespace: "default"}, hpa)
+			Expect(errors.IsNotFound(err)).To(Equal(true))
+		})
+
+		It("doesn't create an HPA for a workload scaled by an HPA not managed by KEDA", func() {
+			deploymentName := "conflicting-hpa"
+			soName := "so-" + deploymentName
+
+			// Create the scaling target and its HPA.
+			err := k8sClient.Create(context.Background(), generateDeployment(deploymentName))
+			Expect(err).ToNot(HaveOccurred())
+			var maxReplicas int32 = 5
+			err = k8sClient.Create(context.Background(), &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "user-hpa", Namespace: "default"},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: deploymentName},
+					MaxReplicas:    maxReplicas,
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			so := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: soName, Namespace: "default"},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+						Name: deploymentName,
+					},
+					Triggers: []kedav1alpha1.ScaleTriggers{
+						{
+							Type: "cron",
+							Metadata: map[string]string{
+								"timezone":        "UTC",
+								"start":           "0 * * * *",
+								"end":             "1 * * * *",
+								"desiredReplicas": "1",
+							},
+						},
+					},
+				},
+			}
+			err = k8sClient.Create(context.Background(), so)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(func() string {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: soName, Namespace: "default"}, so)
+				Ω(err).ToNot(HaveOccurred())
+				return so.Status.Conditions.GetReadyCondition().Message
+			}, 20*time.Second).Should(ContainSubstring("is already managed by the hpa 'user-hpa'"))
+
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			err = k8sClient.Get(context.Background(), types.NamespacedName{Name: fmt.Sprintf("keda-hpa-%s", soName), Namespace: "default"}, hpa)
+			Expect(errors.IsNotFound(err)).To(Equal(true))
+		})
+
+		It("transfers ownership of an existing HPA not managed by KEDA", func() {
+			deploymentName := "transfer-hpa"
+			soName := "so-" + deploymentName
+			hpaName := "user-hpa-" + deploymentName
+
+			// Create the scaling target and its HPA.
+			err := k8sClient.Create(context.Background(), generateDeployment(deploymentName))
+			Expect(err).ToNot(HaveOccurred())
+			var maxReplicas int32 = 5
+			err = k8sClient.Create(context.Background(), &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: hpaName, Namespace: "default"},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: deploymentName},
+					MaxReplicas:    maxReplicas,
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			var maxReplicaCount int32 = 10
+			so := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        soName,
+					Namespace:   "default",
+					Annotations: map[string]string{kedav1alpha1.ScaledObjectTransferHpaOwnershipAnnotation: "true"},
+				},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+						Name: deploymentName,
+					},
+					MaxReplicaCount: &maxReplicaCount,
+					Advanced: &kedav1alpha1.AdvancedConfig{
+						HorizontalPodAutoscalerConfig: &kedav1alpha1.HorizontalPodAutoscalerConfig{
+							Name: hpaName,
+						},
+					},
+					Triggers: []kedav1alpha1.ScaleTriggers{
+						{
+							Type: "cron",
+							Metadata: map[string]string{
+								"timezone":        "UTC",
+								"start":           "0 * * * *",
+								"end":             "1 * * * *",
+								"desiredReplicas": "1",
+							},
+						},
+					},
+				},
+			}
+			err = k8sClient.Create(context.Background(), so)
+			Expect(err).ToNot(HaveOccurred())
+
+			// The existing HPA is adopted by the ScaledObject and updated according to it.
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Eventually(func() int32 {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: hpaName, Namespace: "default"}, hpa)
+				Ω(err).ToNot(HaveOccurred())
+				return hpa.Spec.MaxReplicas
+			}, 20*time.Second).Should(Equal(maxReplicaCount))
+			Expect(metav1.IsControlledBy(hpa, so)).To(BeTrue())
+
+			Eventually(func() string {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: soName, Namespace: "default"}, so)
+				Ω(err).ToNot(HaveOccurred())
+				return so.Status.HpaName
+			}, 20*time.Second).Should(Equal(hpaName))
+		})
+
 		It("doesn't allow non-unique triggerName in ScaledObject", func() {
 			deploymentName := "non-unique-triggername"
 			soName := "so-" + deploymentName
@@ -716,6 +958,55 @@ var _ = Describe("ScaledObjectController", func() {
 				return so.Status.Conditions.GetReadyCondition().Status
 			}, 20*time.Second).Should(Equal(metav1.ConditionFalse))
 		})
+
+		It("doesn't allow triggerNames resulting in the same metric names in ScaledObject", func() {
+			deploymentName := "colliding-triggername"
+			soName := "so-" + deploymentName
+
+			// Create the scaling target.
+			err := k8sClient.Create(context.Background(), generateDeployment(deploymentName))
+			Expect(err).ToNot(HaveOccurred())
+
+			// Create the ScaledObject with two triggers, their names differ only in characters replaced in metric names
+			so := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: soName, Namespace: "default"},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+						Name: deploymentName,
+					},
+					Triggers: []kedav1alpha1.ScaleTriggers{
+						{
+							Type: "cron",
+							Name: "orders.queue",
+							Metadata: map[string]string{
+								"timezone":        "UTC",
+								"start":           "0 * * * *",
+								"end":             "1 * * * *",
+								"desiredReplicas": "1",
+							},
+						},
+						{
+							Type: "cron",
+							Name: "orders-queue",
+							Metadata: map[string]string{
+								"timezone":        "UTC",
+								"start":           "10 * * * *",
+								"end":             "11 * * * *",
+								"desiredReplicas": "1",
+							},
+						},
+					},
+				},
+			}
+			err = k8sClient.Create(context.Background(), so)
+			Ω(err).ToNot(HaveOccurred())
+
+			Eventually(func() metav1.ConditionStatus {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: soName, Namespace: "default"}, so)
+				Ω(err).ToNot(HaveOccurred())
+				return so.Status.Conditions.GetReadyCondition().Status
+			}, 20*time.Second).Should(Equal(metav1.ConditionFalse))
+		})
 	})
 
 	It("scaleobject ready condition 'False/Unknow' to 'True' will requeue", func() {
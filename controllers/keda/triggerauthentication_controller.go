@@ -30,14 +30,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/prommetrics"
+	"github.com/kedacore/keda/v2/pkg/scaling"
 )
 
 // TriggerAuthenticationReconciler reconciles a TriggerAuthentication object
 type TriggerAuthenticationReconciler struct {
 	client.Client
 	record.EventRecorder
+	ScaleHandler scaling.ScaleHandler
 }
 
 type triggerAuthMetricsData struct {
@@ -79,6 +82,11 @@ func (r *TriggerAuthenticationReconciler) Reconcile(ctx context.Context, req ctr
 	}
 	r.updatePromMetrics(triggerAuthentication, req.NamespacedName.String())
 
+	// scalers authenticated by the previous generation are rebuilt with the updated authentication
+	if triggerAuthentication.ObjectMeta.Generation > 1 {
+		r.ScaleHandler.RefreshScalersCachesForAuthentication(ctx, "TriggerAuthentication", triggerAuthentication.Namespace, triggerAuthentication.Name)
+	}
+
 	if triggerAuthentication.ObjectMeta.Generation == 1 {
 		r.EventRecorder.Event(triggerAuthentication, corev1.EventTypeNormal, eventreason.TriggerAuthenticationAdded, "New TriggerAuthentication configured")
 	}
@@ -90,7 +98,7 @@ func (r *TriggerAuthenticationReconciler) Reconcile(ctx context.Context, req ctr
 func (r *TriggerAuthenticationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kedav1alpha1.TriggerAuthentication{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Complete(r)
+		Complete(kedacontrollerutil.WithReconcileMetrics(prommetrics.TriggerAuthenticationResource, r))
 }
 
 func (r *TriggerAuthenticationReconciler) updatePromMetrics(triggerAuth *kedav1alpha1.TriggerAuthentication, namespacedName string) {
@@ -9,6 +9,14 @@ import (
 
 const PausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
 
+// DryRunAnnotation makes KEDA evaluate the triggers of the ScaledObject and record the recommended replica count
+// without managing the HPA or scaling the scale target, when it's set to "true"
+const DryRunAnnotation = "autoscaling.keda.sh/dry-run"
+
+// ForceActivationAnnotation overrides the activity of the triggers of the ScaledObject, the scale target is activated
+// when it's set to "true" and deactivated when it's set to "false", regardless of the triggers
+const ForceActivationAnnotation = "autoscaling.keda.sh/force-activation"
+
 type PausedReplicasPredicate struct {
 	predicate.Funcs
 }
@@ -31,6 +39,36 @@ func (PausedReplicasPredicate) Update(e event.UpdateEvent) bool {
 	return false
 }
 
+// IsDryRunEnabled returns true if the ScaledObject has the dry-run annotation set to "true"
+func IsDryRunEnabled(scaledObject *kedav1alpha1.ScaledObject) bool {
+	return scaledObject.GetAnnotations()[DryRunAnnotation] == "true"
+}
+
+// GetForcedActivation returns the activity forced by the force-activation annotation of the ScaledObject,
+// the second value is false if the activity isn't forced or the annotation has an unknown value
+func GetForcedActivation(scaledObject *kedav1alpha1.ScaledObject) (bool, bool) {
+	switch scaledObject.GetAnnotations()[ForceActivationAnnotation] {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+type DryRunPredicate struct {
+	predicate.Funcs
+}
+
+func (DryRunPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	return e.ObjectNew.GetAnnotations()[DryRunAnnotation] != e.ObjectOld.GetAnnotations()[DryRunAnnotation]
+}
+
 type ScaleObjectReadyConditionPredicate struct {
 	predicate.Funcs
 }
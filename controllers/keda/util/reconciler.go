@@ -0,0 +1,41 @@
+package util
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kedacore/keda/v2/pkg/prommetrics"
+	"github.com/kedacore/keda/v2/pkg/telemetry"
+)
+
+// metricsReconciler records duration and errors of reconciliations of the wrapped reconciler in Prometheus metrics
+// and traces them
+type metricsReconciler struct {
+	resourceType string
+	reconciler   reconcile.Reconciler
+}
+
+// WithReconcileMetrics wraps the reconciler of the resource type (eg. prommetrics.ScaledObjectResource),
+// so duration and errors of its reconciliations are recorded in Prometheus metrics and in spans of traces
+func WithReconcileMetrics(resourceType string, reconciler reconcile.Reconciler) reconcile.Reconciler {
+	return &metricsReconciler{resourceType: resourceType, reconciler: reconciler}
+}
+
+func (r *metricsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "reconcile "+r.resourceType, trace.WithAttributes(
+		attribute.String("keda.resource", r.resourceType),
+		attribute.String("k8s.namespace.name", req.Namespace),
+		attribute.String("keda.name", req.Name),
+	))
+	start := time.Now()
+	result, err := r.reconciler.Reconcile(ctx, req)
+	prommetrics.RecordReconcileDuration(r.resourceType, time.Since(start), err)
+	telemetry.EndSpan(span, err)
+	return result, err
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// rbac-gen generates namespace-scoped RBAC for KEDA Operator watching a list of namespaces (WATCH_NAMESPACE).
+// Rules of the generated ClusterRole are split into a Role with the namespaced rules in every watched namespace
+// and a reduced ClusterRole with rules for cluster-scoped resources only, so the operator doesn't need
+// cluster-wide access to workloads.
+//
+// Usage:
+//
+//	go run ./hack/rbac-gen --namespaces team-a,team-b > keda-rbac.yaml
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const namespacedRoleSuffix = "-namespaced"
+
+// clusterScopedResources are the cluster-scoped resources KEDA Operator works with, per API group
+var clusterScopedResources = map[string]map[string]bool{
+	"":                             {"namespaces": true, "nodes": true},
+	"admissionregistration.k8s.io": {"validatingwebhookconfigurations": true, "mutatingwebhookconfigurations": true},
+	"apiextensions.k8s.io":         {"customresourcedefinitions": true},
+	"apiregistration.k8s.io":       {"apiservices": true},
+	"keda.sh":                      {"clustertriggerauthentications": true, "kedahealths": true},
+}
+
+func main() {
+	var clusterRolePath, namespaces, serviceAccount, serviceAccountNamespace string
+	flag.StringVar(&clusterRolePath, "cluster-role", "config/rbac/role.yaml", "Path to the RBAC generated by controller-gen.")
+	flag.StringVar(&namespaces, "namespaces", "", "Comma separated list of namespaces watched by KEDA Operator.")
+	flag.StringVar(&serviceAccount, "service-account", "keda-operator", "Name of the KEDA Operator service account.")
+	flag.StringVar(&serviceAccountNamespace, "service-account-namespace", "keda", "Namespace of the KEDA Operator service account.")
+	flag.Parse()
+
+	if err := run(clusterRolePath, namespaces, serviceAccount, serviceAccountNamespace, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "rbac-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(clusterRolePath, namespaces, serviceAccount, serviceAccountNamespace string, out io.Writer) error {
+	watchNamespaces := kedautil.ParseCommaSeparatedList(namespaces)
+	if len(watchNamespaces) == 0 {
+		return errors.New("--namespaces is required")
+	}
+
+	content, err := os.ReadFile(clusterRolePath)
+	if err != nil {
+		return fmt.Errorf("error reading RBAC: %w", err)
+	}
+	clusterRole, roles, err := parseRBAC(content)
+	if err != nil {
+		return err
+	}
+
+	subject := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: serviceAccount, Namespace: serviceAccountNamespace}
+	objects := generate(clusterRole, roles, watchNamespaces, subject)
+	return writeObjects(out, objects)
+}
+
+// parseRBAC returns the ClusterRole and Roles from multi-document YAML
+func parseRBAC(content []byte) (*rbacv1.ClusterRole, []rbacv1.Role, error) {
+	var clusterRole *rbacv1.ClusterRole
+	var roles []rbacv1.Role
+	for _, document := range bytes.Split(content, []byte("\n---")) {
+		if len(bytes.TrimSpace(bytes.TrimPrefix(bytes.TrimSpace(document), []byte("---")))) == 0 {
+			continue
+		}
+		typeMeta := metav1.TypeMeta{}
+		if err := yaml.Unmarshal(document, &typeMeta); err != nil {
+			return nil, nil, fmt.Errorf("error parsing RBAC: %w", err)
+		}
+		switch typeMeta.Kind {
+		case "ClusterRole":
+			if clusterRole != nil {
+				return nil, nil, errors.New("expected a single ClusterRole")
+			}
+			clusterRole = &rbacv1.ClusterRole{}
+			if err := yaml.Unmarshal(document, clusterRole); err != nil {
+				return nil, nil, fmt.Errorf("error parsing ClusterRole: %w", err)
+			}
+		case "Role":
+			role := rbacv1.Role{}
+			if err := yaml.Unmarshal(document, &role); err != nil {
+				return nil, nil, fmt.Errorf("error parsing Role: %w", err)
+			}
+			roles = append(roles, role)
+		default:
+			return nil, nil, fmt.Errorf("unexpected kind %q", typeMeta.Kind)
+		}
+	}
+	if clusterRole == nil {
+		return nil, nil, errors.New("no ClusterRole found")
+	}
+	return clusterRole, roles, nil
+}
+
+// isClusterScoped returns whether the resource (or its subresource) in the API group is cluster-scoped
+func isClusterScoped(apiGroup, resource string) bool {
+	resource = strings.SplitN(resource, "/", 2)[0]
+	return clusterScopedResources[apiGroup][resource]
+}
+
+// splitRules splits rules to rules for cluster-scoped and namespaced resources,
+// rules with wildcards in API groups or resources are considered namespaced
+func splitRules(rules []rbacv1.PolicyRule) (clusterRules, namespacedRules []rbacv1.PolicyRule) {
+	for _, rule := range rules {
+		if len(rule.NonResourceURLs) > 0 {
+			clusterRules = append(clusterRules, rule)
+			continue
+		}
+
+		clusterRule := *rule.DeepCopy()
+		clusterRule.Resources = nil
+		namespacedRule := *rule.DeepCopy()
+		namespacedRule.Resources = nil
+		for _, resource := range rule.Resources {
+			clusterScoped := len(rule.APIGroups) > 0
+			for _, apiGroup := range rule.APIGroups {
+				clusterScoped = clusterScoped && isClusterScoped(apiGroup, resource)
+			}
+			if clusterScoped {
+				clusterRule.Resources = append(clusterRule.Resources, resource)
+			} else {
+				namespacedRule.Resources = append(namespacedRule.Resources, resource)
+			}
+		}
+		if len(clusterRule.Resources) > 0 {
+			clusterRules = append(clusterRules, clusterRule)
+		}
+		if len(namespacedRule.Resources) > 0 {
+			namespacedRules = append(namespacedRules, namespacedRule)
+		}
+	}
+	return clusterRules, namespacedRules
+}
+
+// generate returns the reduced ClusterRole, Roles for the watched namespaces, the existing Roles and bindings for all of them
+func generate(clusterRole *rbacv1.ClusterRole, roles []rbacv1.Role, namespaces []string, subject rbacv1.Subject) []interface{} {
+	clusterRules, namespacedRules := splitRules(clusterRole.Rules)
+	subjects := []rbacv1.Subject{subject}
+
+	var objects []interface{}
+	if len(clusterRules) > 0 {
+		objects = append(objects,
+			&rbacv1.ClusterRole{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+				ObjectMeta: metav1.ObjectMeta{Name: clusterRole.Name},
+				Rules:      clusterRules,
+			},
+			&rbacv1.ClusterRoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Name: clusterRole.Name},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: clusterRole.Name},
+				Subjects:   subjects,
+			})
+	}
+
+	// KEDA namespace needs the namespaced rules too, eg. for leader election leases and events
+	namespaces = append(namespaces, subject.Namespace)
+	sort.Strings(namespaces)
+	for i, namespace := range namespaces {
+		if i > 0 && namespaces[i-1] == namespace {
+			continue
+		}
+		name := clusterRole.Name + namespacedRoleSuffix
+		objects = append(objects, newRole(name, namespace, namespacedRules), newRoleBinding(name, namespace, subjects))
+	}
+
+	for _, role := range roles {
+		objects = append(objects, newRole(role.Name, role.Namespace, role.Rules), newRoleBinding(role.Name, role.Namespace, subjects))
+	}
+	return objects
+}
+
+func newRole(name, namespace string, rules []rbacv1.PolicyRule) *rbacv1.Role {
+	return &rbacv1.Role{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Rules:      rules,
+	}
+}
+
+func newRoleBinding(name, namespace string, subjects []rbacv1.Subject) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+		Subjects:   subjects,
+	}
+}
+
+func writeObjects(out io.Writer, objects []interface{}) error {
+	for _, object := range objects {
+		content, err := yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+		// drop creationTimestamp: null, it's noise in generated manifests
+		content = bytes.ReplaceAll(content, []byte("  creationTimestamp: null\n"), nil)
+		if _, err := fmt.Fprintf(out, "---\n%s", content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
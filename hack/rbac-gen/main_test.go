@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const testRBAC = `---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  creationTimestamp: null
+  name: keda-operator
+rules:
+- apiGroups:
+  - '*'
+  resources:
+  - '*/scale'
+  verbs:
+  - '*'
+- apiGroups:
+  - keda.sh
+  resources:
+  - clustertriggerauthentications
+  - clustertriggerauthentications/status
+  - scaledobjects
+  verbs:
+  - '*'
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  creationTimestamp: null
+  name: keda-operator
+  namespace: keda
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - secrets
+  verbs:
+  - get
+`
+
+func TestSplitRules(t *testing.T) {
+	clusterRole, roles, err := parseRBAC([]byte(testRBAC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 1 {
+		t.Fatalf("expected 1 Role, got %d", len(roles))
+	}
+
+	clusterRules, namespacedRules := splitRules(clusterRole.Rules)
+	if len(clusterRules) != 1 || strings.Join(clusterRules[0].Resources, ",") != "clustertriggerauthentications,clustertriggerauthentications/status" {
+		t.Errorf("unexpected cluster rules: %+v", clusterRules)
+	}
+	if len(namespacedRules) != 2 || namespacedRules[0].Resources[0] != "*/scale" || strings.Join(namespacedRules[1].Resources, ",") != "scaledobjects" {
+		t.Errorf("unexpected namespaced rules: %+v", namespacedRules)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	clusterRole, roles, err := parseRBAC([]byte(testRBAC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "keda-operator", Namespace: "keda"}
+	objects := generate(clusterRole, roles, []string{"team-b", "keda", "team-a"}, subject)
+
+	var names []string
+	for _, object := range objects {
+		switch o := object.(type) {
+		case *rbacv1.ClusterRole:
+			names = append(names, "ClusterRole/"+o.Name)
+		case *rbacv1.ClusterRoleBinding:
+			names = append(names, "ClusterRoleBinding/"+o.Name)
+		case *rbacv1.Role:
+			names = append(names, "Role/"+o.Namespace+"/"+o.Name)
+		case *rbacv1.RoleBinding:
+			names = append(names, "RoleBinding/"+o.Namespace+"/"+o.Name)
+		}
+	}
+	expected := []string{
+		"ClusterRole/keda-operator",
+		"ClusterRoleBinding/keda-operator",
+		"Role/keda/keda-operator-namespaced",
+		"RoleBinding/keda/keda-operator-namespaced",
+		"Role/team-a/keda-operator-namespaced",
+		"RoleBinding/team-a/keda-operator-namespaced",
+		"Role/team-b/keda-operator-namespaced",
+		"RoleBinding/team-b/keda-operator-namespaced",
+		"Role/keda/keda-operator",
+		"RoleBinding/keda/keda-operator",
+	}
+	if strings.Join(names, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected objects generated:\n%s", strings.Join(names, "\n"))
+	}
+}
+
+func TestRun(t *testing.T) {
+	rbacFile := filepath.Join(t.TempDir(), "role.yaml")
+	if err := os.WriteFile(rbacFile, []byte(testRBAC), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run(rbacFile, "", "keda-operator", "keda", &bytes.Buffer{}); err == nil {
+		t.Error("expected error without namespaces")
+	}
+
+	out := &bytes.Buffer{}
+	if err := run(rbacFile, "team-a", "keda-operator", "keda", out); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "creationTimestamp") || !strings.Contains(out.String(), "namespace: team-a") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// scaler-gen scaffolds a new scaler from a short spec file: the scaler with its typed metadata
+// struct and parser, unit tests, the registration in the scalers builder and a documentation stub.
+//
+// Usage:
+//
+//	go run ./hack/scaler-gen --spec hack/scaler-gen/example.yaml [--docs-dir ../keda-docs/content/docs/2.11/scalers]
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	scalersDir         = "pkg/scalers"
+	scalersBuilderFile = "pkg/scaling/scalers_builder.go"
+
+	triggersStartMarker = "// TRIGGERS-START"
+	triggersEndMarker   = "// TRIGGERS-END"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+var (
+	triggerNameRegex   = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+	parameterNameRegex = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+	supportedParameterTypes = map[string]bool{"string": true, "int64": true, "float64": true, "bool": true}
+	// reservedParameterNames would clash with identifiers used in the generated parser
+	reservedParameterNames = map[string]bool{"config": true, "meta": true, "err": true, "ok": true, "val": true, "scalerIndex": true}
+)
+
+// Spec describes the scaler to be generated
+type Spec struct {
+	// Name is the trigger type, eg. `my-source`
+	Name string `json:"name"`
+	// DisplayName is the human readable name of the event source, eg. `My Source`
+	DisplayName string `json:"displayName"`
+	// Description is a short sentence used in the documentation stub
+	Description string `json:"description,omitempty"`
+	// Maintainer of the scaler, used in the documentation stub
+	Maintainer string `json:"maintainer,omitempty"`
+	// TargetParameter is the metadata parameter holding the target value, defaults to `targetValue`
+	TargetParameter string `json:"targetParameter,omitempty"`
+	// ActivationParameter is the metadata parameter holding the activation value, defaults to `activation<TargetParameter>`
+	ActivationParameter string `json:"activationParameter,omitempty"`
+	// Parameters are the additional metadata parameters of the scaler
+	Parameters []Parameter `json:"parameters,omitempty"`
+}
+
+// Parameter describes a single metadata parameter of the scaler
+type Parameter struct {
+	Name string `json:"name"`
+	// Type is one of `string`, `int64`, `float64` or `bool`, defaults to `string`
+	Type        string `json:"type,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+	// FromAuth allows setting the parameter through TriggerAuthentication
+	FromAuth bool `json:"fromAuth,omitempty"`
+	// FromEnv allows setting the parameter from an environment variable of the scale target through `<name>FromEnv`
+	FromEnv bool `json:"fromEnv,omitempty"`
+	// Example value used in the generated tests and documentation
+	Example string `json:"example,omitempty"`
+}
+
+// scalerData is the data passed to the templates
+type scalerData struct {
+	Spec
+	// FileName is the base name of the generated scaler files, eg. `my_source`
+	FileName string
+	// TypeName is the unexported Go identifier prefix, eg. `mySource`
+	TypeName string
+	// ExportedName is the exported Go identifier prefix, eg. `MySource`
+	ExportedName string
+	// TargetField and ActivationField are the metadata struct fields holding target and activation values
+	TargetField     string
+	ActivationField string
+	Fields          []fieldData
+}
+
+type fieldData struct {
+	Parameter
+	Field string
+}
+
+func main() {
+	var specPath, repoRoot, docsDir string
+	var force bool
+	flag.StringVar(&specPath, "spec", "", "Path to the spec file describing the scaler.")
+	flag.StringVar(&repoRoot, "repo-root", ".", "Path to the root of the KEDA repository.")
+	flag.StringVar(&docsDir, "docs-dir", "", "Directory where the documentation stub is generated, eg. the scalers directory of keda-docs. The stub is not generated if empty.")
+	flag.BoolVar(&force, "force", false, "Overwrite already existing scaler files.")
+	flag.Parse()
+
+	if err := run(specPath, repoRoot, docsDir, force); err != nil {
+		fmt.Fprintf(os.Stderr, "scaler-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, repoRoot, docsDir string, force bool) error {
+	if specPath == "" {
+		return errors.New("--spec is required")
+	}
+	content, err := os.ReadFile(specPath)
+	if err != nil {
+		return fmt.Errorf("error reading spec: %w", err)
+	}
+	spec := Spec{}
+	if err := yaml.UnmarshalStrict(content, &spec); err != nil {
+		return fmt.Errorf("error parsing spec: %w", err)
+	}
+	data, err := newScalerData(spec)
+	if err != nil {
+		return err
+	}
+
+	scalerFile := filepath.Join(repoRoot, scalersDir, data.FileName+"_scaler.go")
+	if err := renderGoFile("scaler.go.tmpl", data, scalerFile, force); err != nil {
+		return err
+	}
+	testFile := filepath.Join(repoRoot, scalersDir, data.FileName+"_scaler_test.go")
+	if err := renderGoFile("scaler_test.go.tmpl", data, testFile, force); err != nil {
+		return err
+	}
+
+	builderFile := filepath.Join(repoRoot, scalersBuilderFile)
+	builder, err := os.ReadFile(builderFile)
+	if err != nil {
+		return fmt.Errorf("error reading scalers builder: %w", err)
+	}
+	builder, err = registerScaler(builder, data.Name, data.ExportedName)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(builderFile, builder, 0600); err != nil {
+		return fmt.Errorf("error writing scalers builder: %w", err)
+	}
+
+	if docsDir != "" {
+		docsFile := filepath.Join(docsDir, data.Name+".md")
+		if err := renderFile("docs.md.tmpl", data, docsFile, force, nil); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Scaler %q generated in %s\n", data.Name, scalerFile)
+	fmt.Println("Next steps:")
+	fmt.Printf("  - implement getMetricValue in %s\n", scalerFile)
+	fmt.Printf("  - add e2e tests to tests/scalers/%s\n", data.FileName)
+	fmt.Printf("  - add '- **General**: Introduce new %s Scaler' to CHANGELOG.md\n", data.DisplayName)
+	return nil
+}
+
+// newScalerData validates the spec and computes the names used in the templates
+func newScalerData(spec Spec) (*scalerData, error) {
+	if !triggerNameRegex.MatchString(spec.Name) {
+		return nil, fmt.Errorf("invalid scaler name %q, it must be lower case words separated by '-'", spec.Name)
+	}
+	if spec.DisplayName == "" {
+		return nil, errors.New("displayName is required")
+	}
+	if spec.TargetParameter == "" {
+		spec.TargetParameter = "targetValue"
+	}
+	if spec.ActivationParameter == "" {
+		spec.ActivationParameter = "activation" + upperFirst(spec.TargetParameter)
+	}
+
+	data := &scalerData{
+		Spec:            spec,
+		FileName:        strings.ReplaceAll(spec.Name, "-", "_"),
+		TypeName:        lowerFirst(toCamelCase(spec.Name)),
+		ExportedName:    toCamelCase(spec.Name),
+		TargetField:     spec.TargetParameter,
+		ActivationField: spec.ActivationParameter,
+	}
+
+	seen := map[string]bool{spec.TargetParameter: true, spec.ActivationParameter: true}
+	for _, param := range spec.Parameters {
+		if !parameterNameRegex.MatchString(param.Name) {
+			return nil, fmt.Errorf("invalid parameter name %q, it must be lower camel case", param.Name)
+		}
+		if reservedParameterNames[param.Name] {
+			return nil, fmt.Errorf("parameter name %q is reserved", param.Name)
+		}
+		if seen[param.Name] {
+			return nil, fmt.Errorf("duplicated parameter %q", param.Name)
+		}
+		seen[param.Name] = true
+		if param.Type == "" {
+			param.Type = "string"
+		}
+		if !supportedParameterTypes[param.Type] {
+			return nil, fmt.Errorf("unsupported type %q of parameter %q", param.Type, param.Name)
+		}
+		if param.Required && param.Default != "" {
+			return nil, fmt.Errorf("parameter %q can't be both required and have a default value", param.Name)
+		}
+		if param.Example == "" {
+			param.Example = exampleValue(param)
+		}
+		data.Fields = append(data.Fields, fieldData{Parameter: param, Field: param.Name})
+	}
+	return data, nil
+}
+
+// registerScaler adds the scaler to the switch in the scalers builder, keeping the cases sorted
+func registerScaler(builder []byte, name, exportedName string) ([]byte, error) {
+	content := string(builder)
+	start := strings.Index(content, triggersStartMarker)
+	end := strings.Index(content, triggersEndMarker)
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("markers %q and %q not found in scalers builder", triggersStartMarker, triggersEndMarker)
+	}
+
+	caseLine := fmt.Sprintf("\tcase %q:", name)
+	if strings.Contains(content[start:end], caseLine+"\n") {
+		return nil, fmt.Errorf("scaler %q is already registered", name)
+	}
+	registration := fmt.Sprintf("%s\n\t\treturn scalers.New%sScaler(config)\n", caseLine, exportedName)
+
+	// insert before the first case that sorts after the new one, or before the default case
+	lines := strings.SplitAfter(content[start:end], "\n")
+	offset := start
+	for _, line := range lines {
+		if strings.HasPrefix(line, "\tcase ") && line > caseLine || strings.HasPrefix(line, "\tdefault:") {
+			// keep comments preceding the case together with it
+			insertAt := offset
+			for insertAt > start {
+				prev := strings.LastIndex(content[:insertAt-1], "\n") + 1
+				if !strings.HasPrefix(strings.TrimSpace(content[prev:insertAt]), "//") {
+					break
+				}
+				insertAt = prev
+			}
+			return []byte(content[:insertAt] + registration + content[insertAt:]), nil
+		}
+		offset += len(line)
+	}
+	return nil, errors.New("unable to find where to register the scaler in scalers builder")
+}
+
+func renderGoFile(templateName string, data *scalerData, path string, force bool) error {
+	return renderFile(templateName, data, path, force, format.Source)
+}
+
+func renderFile(templateName string, data *scalerData, path string, force bool, formatter func([]byte) ([]byte, error)) error {
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("file %s already exists, use --force to overwrite it", path)
+	}
+
+	tmpl, err := template.New(templateName).Funcs(template.FuncMap{
+		"upperFirst": upperFirst,
+		"quote":      func(s string) string { return fmt.Sprintf("%q", s) },
+	}).ParseFS(templatesFS, "templates/"+templateName)
+	if err != nil {
+		return fmt.Errorf("error parsing template %s: %w", templateName, err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return fmt.Errorf("error executing template %s: %w", templateName, err)
+	}
+	content := buf.Bytes()
+	if formatter != nil {
+		if content, err = formatter(content); err != nil {
+			return fmt.Errorf("error formatting %s: %w", path, err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0600)
+}
+
+func exampleValue(param Parameter) string {
+	if param.Default != "" {
+		return param.Default
+	}
+	switch param.Type {
+	case "int64":
+		return "10"
+	case "float64":
+		return "1.5"
+	case "bool":
+		return "true"
+	default:
+		return "example"
+	}
+}
+
+// toCamelCase converts `my-source` to `MySource`
+func toCamelCase(name string) string {
+	parts := strings.Split(name, "-")
+	for i, part := range parts {
+		parts[i] = upperFirst(part)
+	}
+	return strings.Join(parts, "")
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
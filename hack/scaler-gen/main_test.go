@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testBuilder = `func buildScaler() {
+	// TRIGGERS-START
+	switch triggerType {
+	case "activemq":
+		return scalers.NewActiveMQScaler(config)
+	case "external":
+		return scalers.NewExternalScaler(config)
+	// TODO: use other way for test.
+	case "external-mock":
+		return scalers.NewExternalMockScaler(config)
+	case "kafka":
+		return scalers.NewKafkaScaler(config)
+	default:
+		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
+	}
+	// TRIGGERS-END
+}
+`
+
+func TestRegisterScaler(t *testing.T) {
+	tests := []struct {
+		name          string
+		exportedName  string
+		expectedAfter string
+		isError       bool
+	}{
+		{"aaa", "Aaa", "// TRIGGERS-START\n\tswitch triggerType {\n", false},
+		{"external-abc", "ExternalAbc", "return scalers.NewExternalScaler(config)\n", false},
+		{"foo", "Foo", "return scalers.NewExternalMockScaler(config)\n", false},
+		{"zzz", "Zzz", "return scalers.NewKafkaScaler(config)\n", false},
+		{"kafka", "Kafka", "", true},
+	}
+
+	for _, test := range tests {
+		result, err := registerScaler([]byte(testBuilder), test.name, test.exportedName)
+		if test.isError {
+			if err == nil {
+				t.Errorf("%s: expected error but got success", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: expected success but got error: %v", test.name, err)
+			continue
+		}
+		registration := "\tcase \"" + test.name + "\":\n\t\treturn scalers.New" + test.exportedName + "Scaler(config)\n"
+		if !strings.Contains(string(result), test.expectedAfter+registration) {
+			t.Errorf("%s: registration not found at expected position:\n%s", test.name, result)
+		}
+	}
+}
+
+func TestNewScalerData(t *testing.T) {
+	tests := []struct {
+		spec    Spec
+		isError bool
+	}{
+		{Spec{Name: "my-source", DisplayName: "My Source"}, false},
+		{Spec{Name: "my-source", DisplayName: "My Source", Parameters: []Parameter{{Name: "host", FromAuth: true}, {Name: "port", Type: "int64", Default: "80"}}}, false},
+		{Spec{Name: "My_Source", DisplayName: "My Source"}, true},
+		{Spec{Name: "my-source"}, true},
+		{Spec{Name: "my-source", DisplayName: "My Source", Parameters: []Parameter{{Name: "host"}, {Name: "host"}}}, true},
+		{Spec{Name: "my-source", DisplayName: "My Source", Parameters: []Parameter{{Name: "targetValue"}}}, true},
+		{Spec{Name: "my-source", DisplayName: "My Source", Parameters: []Parameter{{Name: "meta"}}}, true},
+		{Spec{Name: "my-source", DisplayName: "My Source", Parameters: []Parameter{{Name: "port", Type: "uint"}}}, true},
+		{Spec{Name: "my-source", DisplayName: "My Source", Parameters: []Parameter{{Name: "port", Required: true, Default: "80"}}}, true},
+	}
+
+	for _, test := range tests {
+		_, err := newScalerData(test.spec)
+		if err != nil && !test.isError {
+			t.Errorf("%+v: expected success but got error: %v", test.spec, err)
+		}
+		if err == nil && test.isError {
+			t.Errorf("%+v: expected error but got success", test.spec)
+		}
+	}
+
+	data, err := newScalerData(Spec{Name: "my-source", DisplayName: "My Source"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.FileName != "my_source" || data.TypeName != "mySource" || data.ExportedName != "MySource" || data.ActivationParameter != "activationTargetValue" {
+		t.Errorf("unexpected names generated: %+v", data)
+	}
+}
+
+func TestRenderTemplates(t *testing.T) {
+	data, err := newScalerData(Spec{Name: "my-source", DisplayName: "My Source", Parameters: []Parameter{
+		{Name: "host", Required: true, FromAuth: true},
+		{Name: "password", FromAuth: true, FromEnv: true},
+		{Name: "port", Type: "int64", Default: "80"},
+		{Name: "ratio", Type: "float64"},
+		{Name: "unsafeSsl", Type: "bool", Default: "false"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for _, templateName := range []string{"scaler.go.tmpl", "scaler_test.go.tmpl"} {
+		if err := renderGoFile(templateName, data, filepath.Join(dir, templateName+".go"), false); err != nil {
+			t.Errorf("error rendering %s: %v", templateName, err)
+		}
+	}
+	docsFile := filepath.Join(dir, "docs.md")
+	if err := renderFile("docs.md.tmpl", data, docsFile, false, nil); err != nil {
+		t.Errorf("error rendering docs: %v", err)
+	}
+	if err := renderFile("docs.md.tmpl", data, docsFile, false, nil); err == nil {
+		t.Error("expected error when overwriting existing file without force")
+	}
+	docs, err := os.ReadFile(docsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(docs), "- type: my-source") {
+		t.Errorf("unexpected docs generated:\n%s", docs)
+	}
+}
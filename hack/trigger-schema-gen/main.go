@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// trigger-schema-gen adds validation of trigger metadata to the CRDs of ScaledObjects and ScaledJobs, generated
+// from the typed metadata of scalers (see scalers.MetadataField). Metadata of triggers is a map of strings
+// in the structural schema, so only required metadata are validated by the CRDs, types and allowed values
+// are validated by KEDA Admission Webhooks.
+//
+// Usage:
+//
+//	go run ./hack/trigger-schema-gen --crds config/crd/bases
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+// crdFiles are the CRDs with triggers in their spec
+var crdFiles = []string{"keda.sh_scaledobjects.yaml", "keda.sh_scaledjobs.yaml"}
+
+func main() {
+	var crdsPath string
+	flag.StringVar(&crdsPath, "crds", "config/crd/bases", "Path to the CRDs generated by controller-gen.")
+	flag.Parse()
+
+	schema, err := generateTriggerSchema()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "trigger-schema-gen: %v\n", err)
+		os.Exit(1)
+	}
+	for _, crdFile := range crdFiles {
+		if err := patchCRDFile(filepath.Join(crdsPath, crdFile), schema); err != nil {
+			fmt.Fprintf(os.Stderr, "trigger-schema-gen: %s: %v\n", crdFile, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// generateTriggerSchema returns the allOf rules of the trigger schema, one rule per typed trigger type,
+// the rule is satisfied by triggers of other types or by triggers with all required metadata
+func generateTriggerSchema() ([]interface{}, error) {
+	var rules []interface{}
+	for _, triggerType := range scalers.GetTypedTriggerTypes() {
+		fields, _, err := scalers.GetTriggerMetadataFields(triggerType)
+		if err != nil {
+			return nil, fmt.Errorf("error getting metadata fields of %s trigger: %w", triggerType, err)
+		}
+
+		var required []interface{}
+		var requiredFromEnv []interface{}
+		for _, field := range fields {
+			if !field.IsRequired() {
+				continue
+			}
+			if field.HasSource(scalers.ResolvedEnvSource) {
+				// the metadata can be given by <name>FromEnv too
+				requiredFromEnv = append(requiredFromEnv, map[string]interface{}{
+					"anyOf": []interface{}{
+						map[string]interface{}{"required": []interface{}{field.Name}},
+						map[string]interface{}{"required": []interface{}{field.Name + "FromEnv"}},
+					},
+				})
+				continue
+			}
+			required = append(required, field.Name)
+		}
+		if len(required) == 0 && len(requiredFromEnv) == 0 {
+			continue
+		}
+
+		metadataSchema := map[string]interface{}{}
+		if len(required) > 0 {
+			metadataSchema["required"] = required
+		}
+		if len(requiredFromEnv) > 0 {
+			metadataSchema["allOf"] = requiredFromEnv
+		}
+		rules = append(rules, map[string]interface{}{
+			"anyOf": []interface{}{
+				map[string]interface{}{
+					"properties": map[string]interface{}{
+						"type": map[string]interface{}{"not": map[string]interface{}{"enum": []interface{}{triggerType}}},
+					},
+				},
+				map[string]interface{}{
+					"properties": map[string]interface{}{"metadata": metadataSchema},
+					"required":   []interface{}{"metadata"},
+				},
+			},
+		})
+	}
+	return rules, nil
+}
+
+func patchCRDFile(path string, schema []interface{}) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	patched, err := patchCRD(content, schema)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, patched, 0600)
+}
+
+// patchCRD sets the rules of the trigger schema in all versions of the CRD, existing rules are replaced
+func patchCRD(content []byte, schema []interface{}) ([]byte, error) {
+	crd := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &crd); err != nil {
+		return nil, fmt.Errorf("error parsing CRD: %w", err)
+	}
+
+	versions, _ := getPath(crd, "spec", "versions").([]interface{})
+	if len(versions) == 0 {
+		return nil, errors.New("no versions found in CRD")
+	}
+	for _, version := range versions {
+		items, ok := getPath(version, "schema", "openAPIV3Schema", "properties", "spec", "properties", "triggers", "items").(map[string]interface{})
+		if !ok {
+			return nil, errors.New("no triggers found in schema of CRD")
+		}
+		if len(schema) > 0 {
+			items["allOf"] = schema
+		} else {
+			delete(items, "allOf")
+		}
+	}
+
+	patched, err := yaml.Marshal(crd)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(content, []byte("---\n")) {
+		patched = append([]byte("---\n"), patched...)
+	}
+	return patched, nil
+}
+
+func getPath(object interface{}, path ...string) interface{} {
+	for _, key := range path {
+		m, ok := object.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		object = m[key]
+	}
+	return object
+}
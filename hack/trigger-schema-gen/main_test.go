@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCRD = `---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: scaledobjects.keda.sh
+spec:
+  versions:
+  - name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        properties:
+          spec:
+            properties:
+              triggers:
+                items:
+                  properties:
+                    metadata:
+                      additionalProperties:
+                        type: string
+                      type: object
+                    type:
+                      type: string
+                  type: object
+                type: array
+            type: object
+        type: object
+`
+
+func TestGenerateTriggerSchema(t *testing.T) {
+	schema, err := generateTriggerSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schema) == 0 {
+		t.Fatal("expected rules for typed trigger types")
+	}
+
+	patched, err := patchCRD([]byte(testCRD), schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(patched)
+	if !strings.HasPrefix(content, "---\n") {
+		t.Error("expected document separator to be kept")
+	}
+	for _, expected := range []string{"                  allOf:\n", "- loki\n", "- serverAddress\n", "- query\n", "- threshold\n"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("expected %q in patched CRD:\n%s", expected, content)
+		}
+	}
+
+	// patching is idempotent
+	repatched, err := patchCRD(patched, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(repatched) != content {
+		t.Error("expected the same CRD when patched again")
+	}
+}
+
+func TestPatchCRDWithoutTriggers(t *testing.T) {
+	if _, err := patchCRD([]byte("spec:\n  versions:\n  - name: v1alpha1\n"), nil); err == nil {
+		t.Error("expected error for CRD without triggers")
+	}
+}
+
+// TestCRDsAreUpToDate checks that the CRDs have the trigger schema generated from the current typed metadata
+func TestCRDsAreUpToDate(t *testing.T) {
+	schema, err := generateTriggerSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, crdFile := range crdFiles {
+		content, err := os.ReadFile(filepath.Join("..", "..", "config", "crd", "bases", crdFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		patched, err := patchCRD(content, schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(patched) != string(content) {
+			t.Errorf("%s is out of date, run make manifests", crdFile)
+		}
+	}
+}
@@ -69,6 +69,8 @@ func (cm CertManager) AddCertificateRotation(ctx context.Context, mgr manager.Ma
 	}
 	extraDNSNames := []string{}
 	extraDNSNames = append(extraDNSNames, getDNSNames(cm.OperatorService)...)
+	// shards of KEDA Operator are addressed by the DNS names of their pods in the headless operator service
+	extraDNSNames = append(extraDNSNames, getPodDNSNames(cm.OperatorService)...)
 	extraDNSNames = append(extraDNSNames, getDNSNames(cm.WebhookService)...)
 	extraDNSNames = append(extraDNSNames, getDNSNames(cm.MetricsServerService)...)
 
@@ -109,6 +111,15 @@ func getDNSNames(service string) []string {
 	}
 }
 
+// getPodDNSNames creates wildcard DNS names of pods of a given headless service
+func getPodDNSNames(service string) []string {
+	namespace := kedautil.GetPodNamespace()
+	return []string{
+		fmt.Sprintf("*.%s.%s.svc", service, namespace),
+		fmt.Sprintf("*.%s.%s.svc.cluster.local", service, namespace),
+	}
+}
+
 // ensureSecret ensures that the secret used for storing TLS certificates exists
 func (cm CertManager) ensureSecret(ctx context.Context, mgr manager.Manager, secretName string) error {
 	secrets := &corev1.SecretList{}
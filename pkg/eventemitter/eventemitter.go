@@ -0,0 +1,254 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventemitter sends CloudEvents about ScaledObjects and ScaledJobs to the destinations of CloudEventSources
+// of their namespaces. The CloudEvents are derived from the Kubernetes events recorded by KEDA Operator, see
+// NewEventRecorder.
+package eventemitter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	cloudEventSpecVersion = "1.0"
+	// queueSize is the number of CloudEvents waiting to be sent, further CloudEvents are dropped
+	queueSize = 1000
+
+	cloudEventContentType      = "application/cloudevents+json; charset=utf-8"
+	cloudEventBatchContentType = "application/cloudevents-batch+json; charset=utf-8"
+	azureEventGridKeyHeader    = "aeg-sas-key"
+)
+
+// CloudEvent is a CloudEvent in the JSON format of the structured content mode
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            EventData `json:"data"`
+}
+
+// EventData is the data of CloudEvents emitted by KEDA
+type EventData struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+	// Replicas is the replica count of the scale target after its activation or deactivation
+	Replicas *int32 `json:"replicas,omitempty"`
+	// ScaleTargetName and ScaleTargetKind are the workload scaled by a ScaledObject
+	ScaleTargetName string `json:"scaleTargetName,omitempty"`
+	ScaleTargetKind string `json:"scaleTargetKind,omitempty"`
+	// TriggerName and TriggerType are the trigger of a failed scaler
+	TriggerName string `json:"triggerName,omitempty"`
+	TriggerType string `json:"triggerType,omitempty"`
+	// Metrics are the metric values of the last check of a ScaledObject by metric name
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+}
+
+type queuedEvent struct {
+	eventType string
+	data      EventData
+	time      time.Time
+}
+
+// +kubebuilder:rbac:groups=keda.sh,resources=cloudeventsources,verbs=get;list;watch
+
+// Emitter sends CloudEvents to the destinations of CloudEventSources in the namespaces of the events,
+// the events are queued and sent in the order of emission
+type Emitter struct {
+	client        client.Client
+	secretsLister corev1listers.SecretLister
+	httpClient    *http.Client
+	queue         chan queuedEvent
+	metricsSource MetricsSource
+	logger        logr.Logger
+}
+
+// NewEmitter creates a new Emitter, it sends the queued CloudEvents once it's started
+func NewEmitter(client client.Client, secretsLister corev1listers.SecretLister, httpTimeout time.Duration) *Emitter {
+	return &Emitter{
+		client:        client,
+		secretsLister: secretsLister,
+		httpClient:    kedautil.CreateHTTPClient(httpTimeout, false),
+		queue:         make(chan queuedEvent, queueSize),
+		logger:        logf.Log.WithName("eventemitter"),
+	}
+}
+
+// SetMetricsSource sets the source of the metric values of ScaledObjects in the CloudEvents
+func (e *Emitter) SetMetricsSource(metricsSource MetricsSource) {
+	e.metricsSource = metricsSource
+}
+
+func (e *Emitter) getMetricValues(namespace, name string) map[string]float64 {
+	if e.metricsSource == nil {
+		return nil
+	}
+	return e.metricsSource.GetScaledObjectMetricValues(namespace, name)
+}
+
+// Emit queues the CloudEvent of the type, it's dropped if the queue is full
+func (e *Emitter) Emit(eventType string, data EventData) {
+	select {
+	case e.queue <- queuedEvent{eventType: eventType, data: data, time: time.Now()}:
+	default:
+		e.logger.Info("CloudEvent queue is full, dropping event", "type", eventType, "namespace", data.Namespace, "name", data.Name)
+	}
+}
+
+// Start sends the queued CloudEvents until the context is done
+func (e *Emitter) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-e.queue:
+			e.send(ctx, event)
+		}
+	}
+}
+
+// send sends the CloudEvent to the destinations of the CloudEventSources subscribed to its type
+func (e *Emitter) send(ctx context.Context, event queuedEvent) {
+	cloudEventSources := &kedav1alpha1.CloudEventSourceList{}
+	if err := e.client.List(ctx, cloudEventSources, client.InNamespace(event.data.Namespace)); err != nil {
+		e.logger.Error(err, "error listing CloudEventSources", "namespace", event.data.Namespace)
+		return
+	}
+
+	for i := range cloudEventSources.Items {
+		cloudEventSource := &cloudEventSources.Items[i]
+		if !cloudEventSource.Spec.EventSubscription.IsSubscribed(event.eventType) {
+			continue
+		}
+		if err := e.sendTo(ctx, cloudEventSource, newCloudEvent(cloudEventSource, event)); err != nil {
+			e.logger.Error(err, "error sending CloudEvent", "cloudEventSource", cloudEventSource.Namespace+"/"+cloudEventSource.Name, "type", event.eventType)
+		}
+	}
+}
+
+func newCloudEvent(cloudEventSource *kedav1alpha1.CloudEventSource, event queuedEvent) CloudEvent {
+	clusterName := cloudEventSource.GetClusterName()
+	return CloudEvent{
+		SpecVersion:     cloudEventSpecVersion,
+		ID:              uuid.NewString(),
+		Source:          fmt.Sprintf("/%s/%s/keda", clusterName, event.data.Namespace),
+		Type:            event.eventType,
+		Subject:         fmt.Sprintf("/%s/%s/%s/%s", clusterName, event.data.Namespace, event.data.Kind, event.data.Name),
+		Time:            event.time.UTC(),
+		DataContentType: "application/json",
+		Data:            event.data,
+	}
+}
+
+func (e *Emitter) sendTo(ctx context.Context, cloudEventSource *kedav1alpha1.CloudEventSource, cloudEvent CloudEvent) error {
+	destination := cloudEventSource.Spec.Destination
+	switch {
+	case countDestinations(destination) > 1:
+		return fmt.Errorf("only one destination can be given")
+	case destination.HTTP != nil:
+		body, err := json.Marshal(cloudEvent)
+		if err != nil {
+			return err
+		}
+		return e.post(ctx, destination.HTTP.URI, cloudEventContentType, body, nil)
+	case destination.AzureEventGridTopic != nil:
+		// Event Grid topics with the CloudEvents schema accept batches of events
+		body, err := json.Marshal([]CloudEvent{cloudEvent})
+		if err != nil {
+			return err
+		}
+		authParams, _, err := resolver.ResolveAuthRefAndPodIdentity(ctx, e.client, e.logger, destination.AzureEventGridTopic.AuthenticationRef, nil, cloudEventSource.Namespace, e.secretsLister)
+		if err != nil {
+			return err
+		}
+		accessKey := authParams["accessKey"]
+		if accessKey == "" {
+			return fmt.Errorf("no accessKey of Azure Event Grid topic given by authenticationRef")
+		}
+		return e.post(ctx, destination.AzureEventGridTopic.Endpoint, cloudEventBatchContentType, body, map[string]string{azureEventGridKeyHeader: accessKey})
+	case destination.Webhook != nil:
+		body, err := renderWebhookPayload(destination.Webhook, cloudEvent)
+		if err != nil {
+			return err
+		}
+		contentType := destination.Webhook.ContentType
+		if contentType == "" {
+			contentType = webhookContentType
+		}
+		return e.post(ctx, destination.Webhook.URL, contentType, body, nil)
+	default:
+		return fmt.Errorf("no destination given")
+	}
+}
+
+func countDestinations(destination kedav1alpha1.CloudEventDestination) int {
+	count := 0
+	if destination.HTTP != nil {
+		count++
+	}
+	if destination.AzureEventGridTopic != nil {
+		count++
+	}
+	if destination.Webhook != nil {
+		count++
+	}
+	return count
+}
+
+func (e *Emitter) post(ctx context.Context, uri, contentType string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("destination returned status %d: %s", resp.StatusCode, string(message))
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventemitter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newDestination(t *testing.T) (*httptest.Server, chan receivedRequest) {
+	requests := make(chan receivedRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests <- receivedRequest{header: r.Header, body: body}
+	}))
+	return server, requests
+}
+
+func newTestEmitter(t *testing.T, objects ...client.Object) *Emitter {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	return NewEmitter(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(), nil, 0)
+}
+
+// sendQueued sends the CloudEvents queued by the emitter
+func sendQueued(emitter *Emitter) {
+	for len(emitter.queue) > 0 {
+		emitter.send(context.Background(), <-emitter.queue)
+	}
+}
+
+func TestEmitterSendsToHTTPDestination(t *testing.T) {
+	server, requests := newDestination(t)
+	defer server.Close()
+
+	emitter := newTestEmitter(t,
+		&kedav1alpha1.CloudEventSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "all", Namespace: "default"},
+			Spec: kedav1alpha1.CloudEventSourceSpec{
+				ClusterName: "cluster",
+				Destination: kedav1alpha1.CloudEventDestination{HTTP: &kedav1alpha1.CloudEventHTTP{URI: server.URL}},
+			},
+		},
+		&kedav1alpha1.CloudEventSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "other"},
+			Spec: kedav1alpha1.CloudEventSourceSpec{
+				Destination: kedav1alpha1.CloudEventDestination{HTTP: &kedav1alpha1.CloudEventHTTP{URI: server.URL}},
+			},
+		},
+	)
+	recorder := NewEventRecorder(record.NewFakeRecorder(10), emitter)
+
+	scaledObject := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "so", Namespace: "default"}}
+	recorder.AnnotatedEventf(scaledObject, map[string]string{ReplicasAnnotation: "0"}, corev1.EventTypeNormal, eventreason.KEDAScaleTargetDeactivated, "Deactivated %s from %d to %d", "apps/v1.Deployment", 2, 0)
+	// events without CloudEvents aren't emitted
+	recorder.Event(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScalersStarted, "Started scalers watch")
+	sendQueued(emitter)
+
+	request := <-requests
+	assert.Equal(t, cloudEventContentType, request.header.Get("Content-Type"))
+	cloudEvent := CloudEvent{}
+	assert.NoError(t, json.Unmarshal(request.body, &cloudEvent))
+	assert.Equal(t, "1.0", cloudEvent.SpecVersion)
+	assert.NotEmpty(t, cloudEvent.ID)
+	assert.Equal(t, kedav1alpha1.CloudEventScaledObjectScaledToZero, cloudEvent.Type)
+	assert.Equal(t, "/cluster/default/keda", cloudEvent.Source)
+	assert.Equal(t, "/cluster/default/ScaledObject/so", cloudEvent.Subject)
+	assert.Equal(t, "Deactivated apps/v1.Deployment from 2 to 0", cloudEvent.Data.Message)
+	assert.Equal(t, int32(0), *cloudEvent.Data.Replicas)
+	assert.Empty(t, requests)
+}
+
+func TestEmitterFiltersEventTypes(t *testing.T) {
+	server, requests := newDestination(t)
+	defer server.Close()
+
+	emitter := newTestEmitter(t, &kedav1alpha1.CloudEventSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "failures", Namespace: "default"},
+		Spec: kedav1alpha1.CloudEventSourceSpec{
+			Destination:       kedav1alpha1.CloudEventDestination{HTTP: &kedav1alpha1.CloudEventHTTP{URI: server.URL}},
+			EventSubscription: kedav1alpha1.CloudEventSubscription{IncludedEventTypes: []string{kedav1alpha1.CloudEventScalerFailed}},
+		},
+	})
+	recorder := NewEventRecorder(record.NewFakeRecorder(10), emitter)
+
+	scaledJob := &kedav1alpha1.ScaledJob{ObjectMeta: metav1.ObjectMeta{Name: "sj", Namespace: "default"}}
+	scaledObject := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "so", Namespace: "default"}}
+	recorder.Event(scaledObject, corev1.EventTypeNormal, eventreason.ScaledObjectReady, "ScaledObject is ready for scaling")
+	recorder.Event(scaledJob, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "connection refused")
+	sendQueued(emitter)
+
+	request := <-requests
+	cloudEvent := CloudEvent{}
+	assert.NoError(t, json.Unmarshal(request.body, &cloudEvent))
+	assert.Equal(t, kedav1alpha1.CloudEventScalerFailed, cloudEvent.Type)
+	assert.Equal(t, "ScaledJob", cloudEvent.Data.Kind)
+	assert.Empty(t, requests)
+}
+
+func TestEmitterSendsToAzureEventGridTopic(t *testing.T) {
+	server, requests := newDestination(t)
+	defer server.Close()
+
+	emitter := newTestEmitter(t,
+		&kedav1alpha1.CloudEventSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "event-grid", Namespace: "default"},
+			Spec: kedav1alpha1.CloudEventSourceSpec{
+				Destination: kedav1alpha1.CloudEventDestination{AzureEventGridTopic: &kedav1alpha1.AzureEventGridTopic{
+					Endpoint:          server.URL,
+					AuthenticationRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "event-grid"},
+				}},
+			},
+		},
+		&kedav1alpha1.TriggerAuthentication{
+			ObjectMeta: metav1.ObjectMeta{Name: "event-grid", Namespace: "default"},
+			Spec: kedav1alpha1.TriggerAuthenticationSpec{
+				SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "accessKey", Name: "event-grid", Key: "key"}},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "event-grid", Namespace: "default"},
+			Data:       map[string][]byte{"key": []byte("secret-key")},
+		},
+	)
+
+	emitter.Emit(kedav1alpha1.CloudEventScaledObjectActivated, EventData{Namespace: "default", Name: "so", Kind: "ScaledObject"})
+	sendQueued(emitter)
+
+	request := <-requests
+	assert.Equal(t, cloudEventBatchContentType, request.header.Get("Content-Type"))
+	assert.Equal(t, "secret-key", request.header.Get(azureEventGridKeyHeader))
+	cloudEvents := []CloudEvent{}
+	assert.NoError(t, json.Unmarshal(request.body, &cloudEvents))
+	assert.Len(t, cloudEvents, 1)
+	assert.Equal(t, kedav1alpha1.CloudEventScaledObjectActivated, cloudEvents[0].Type)
+}
+
+type testMetricsSource map[string]float64
+
+func (s testMetricsSource) GetScaledObjectMetricValues(string, string) map[string]float64 {
+	return s
+}
+
+func TestEmitterSendsToWebhook(t *testing.T) {
+	server, requests := newDestination(t)
+	defer server.Close()
+
+	emitter := newTestEmitter(t, &kedav1alpha1.CloudEventSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "default"},
+		Spec: kedav1alpha1.CloudEventSourceSpec{
+			Destination: kedav1alpha1.CloudEventDestination{Webhook: &kedav1alpha1.WebhookDestination{
+				URL:      server.URL,
+				Template: `{{ .Data.ScaleTargetKind }} {{ .Data.ScaleTargetName }} {{ .Data.TriggerType }}/{{ .Data.TriggerName }} {{ index .Data.Metrics "s0-queue" }}`,
+			}},
+		},
+	})
+	emitter.SetMetricsSource(testMetricsSource{"s0-queue": 5})
+	recorder := NewEventRecorder(record.NewFakeRecorder(10), emitter)
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "so", Namespace: "default"},
+		Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "worker"}},
+		Status:     kedav1alpha1.ScaledObjectStatus{ScaleTargetKind: "apps/v1.Deployment"},
+	}
+	recorder.AnnotatedEventf(scaledObject, TriggerAnnotations("queue", "rabbitmq"), corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "%s", "connection refused")
+	sendQueued(emitter)
+
+	request := <-requests
+	assert.Equal(t, webhookContentType, request.header.Get("Content-Type"))
+	assert.Equal(t, "apps/v1.Deployment worker rabbitmq/queue 5", string(request.body))
+}
+
+func TestEmitterRejectsSeveralDestinations(t *testing.T) {
+	emitter := newTestEmitter(t)
+	cloudEventSource := &kedav1alpha1.CloudEventSource{
+		Spec: kedav1alpha1.CloudEventSourceSpec{
+			Destination: kedav1alpha1.CloudEventDestination{
+				HTTP:    &kedav1alpha1.CloudEventHTTP{URI: "http://localhost"},
+				Webhook: &kedav1alpha1.WebhookDestination{URL: "http://localhost"},
+			},
+		},
+	}
+	assert.Error(t, emitter.sendTo(context.Background(), cloudEventSource, CloudEvent{}))
+}
+
+func TestEmitterDropsEventsWhenQueueIsFull(t *testing.T) {
+	emitter := newTestEmitter(t)
+	for i := 0; i < queueSize+10; i++ {
+		emitter.Emit(kedav1alpha1.CloudEventScalerFailed, EventData{Namespace: "default", Name: "so", Kind: "ScaledObject"})
+	}
+	assert.Len(t, emitter.queue, queueSize)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventemitter
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+const (
+	// ReplicasAnnotation is the annotation of activation and deactivation events with the replica count of the scale target
+	ReplicasAnnotation = "keda.sh/replicas"
+	// TriggerNameAnnotation is the annotation of scaler failure events with the name of the trigger
+	TriggerNameAnnotation = "keda.sh/trigger-name"
+	// TriggerTypeAnnotation is the annotation of scaler failure events with the type of the trigger
+	TriggerTypeAnnotation = "keda.sh/trigger-type"
+)
+
+// TriggerAnnotations returns the annotations of events about the trigger
+func TriggerAnnotations(triggerName, triggerType string) map[string]string {
+	annotations := map[string]string{TriggerTypeAnnotation: triggerType}
+	if triggerName != "" {
+		annotations[TriggerNameAnnotation] = triggerName
+	}
+	return annotations
+}
+
+// MetricsSource returns the metric values of the last check of ScaledObjects
+type MetricsSource interface {
+	GetScaledObjectMetricValues(namespace, name string) map[string]float64
+}
+
+// eventRecorder records Kubernetes events and emits the CloudEvents of their reasons
+type eventRecorder struct {
+	record.EventRecorder
+	emitter *Emitter
+}
+
+// NewEventRecorder returns a recorder recording the events by the recorder and emitting CloudEvents of them by the emitter
+func NewEventRecorder(recorder record.EventRecorder, emitter *Emitter) record.EventRecorder {
+	return &eventRecorder{EventRecorder: recorder, emitter: emitter}
+}
+
+func (r *eventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.EventRecorder.Event(object, eventtype, reason, message)
+	r.emit(object, nil, reason, message)
+}
+
+func (r *eventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+	r.emit(object, nil, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *eventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	r.emit(object, annotations, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *eventRecorder) emit(object runtime.Object, annotations map[string]string, reason, message string) {
+	data, ok := newEventData(object, reason, message)
+	if !ok {
+		return
+	}
+	if value, found := annotations[ReplicasAnnotation]; found {
+		if replicas, err := strconv.ParseInt(value, 10, 32); err == nil {
+			replicas32 := int32(replicas)
+			data.Replicas = &replicas32
+		}
+	}
+	data.TriggerName = annotations[TriggerNameAnnotation]
+	data.TriggerType = annotations[TriggerTypeAnnotation]
+
+	eventType := getCloudEventType(data)
+	if eventType == "" {
+		return
+	}
+	// the metric values are read when the event is recorded, they might be outdated once the event is sent
+	if data.Kind == "ScaledObject" {
+		data.Metrics = r.emitter.getMetricValues(data.Namespace, data.Name)
+	}
+	r.emitter.Emit(eventType, data)
+}
+
+func newEventData(object runtime.Object, reason, message string) (EventData, bool) {
+	data := EventData{Reason: reason, Message: message}
+	switch obj := object.(type) {
+	case *kedav1alpha1.ScaledObject:
+		data.Namespace, data.Name, data.Kind = obj.Namespace, obj.Name, "ScaledObject"
+		data.ScaleTargetKind = obj.Status.ScaleTargetKind
+		if obj.Spec.ScaleTargetRef != nil {
+			data.ScaleTargetName = obj.Spec.ScaleTargetRef.Name
+		}
+	case *kedav1alpha1.ScaledJob:
+		data.Namespace, data.Name, data.Kind = obj.Namespace, obj.Name, "ScaledJob"
+	case *kedav1alpha1.WithTriggers:
+		data.Namespace, data.Name, data.Kind = obj.Namespace, obj.Name, obj.InternalKind
+	default:
+		return data, false
+	}
+	return data, true
+}
+
+// getCloudEventType returns the type of CloudEvent emitted for the event, it's empty if none is emitted
+func getCloudEventType(data EventData) string {
+	switch data.Reason {
+	case eventreason.ScaledObjectReady:
+		return kedav1alpha1.CloudEventScaledObjectReady
+	case eventreason.KEDAScaleTargetActivated:
+		return kedav1alpha1.CloudEventScaledObjectActivated
+	case eventreason.KEDAScaleTargetDeactivated:
+		if data.Replicas != nil && *data.Replicas == 0 {
+			return kedav1alpha1.CloudEventScaledObjectScaledToZero
+		}
+		return kedav1alpha1.CloudEventScaledObjectDeactivated
+	case eventreason.KEDAScalerFailed:
+		return kedav1alpha1.CloudEventScalerFailed
+	default:
+		return ""
+	}
+}
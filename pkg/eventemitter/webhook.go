@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventemitter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const webhookContentType = "application/json"
+
+// Templates of the payloads of the webhook formats
+const (
+	slackTemplate = `{"text": {{ printf "[%s] %s %s/%s: %s" .Type .Data.Kind .Data.Namespace .Data.Name .Data.Message | json }}}`
+	teamsTemplate = `{
+  "@type": "MessageCard",
+  "@context": "http://schema.org/extensions",
+  "summary": {{ .Type | json }},
+  "title": {{ printf "%s %s/%s" .Type .Data.Namespace .Data.Name | json }},
+  "text": {{ .Data.Message | json }}
+}`
+)
+
+var webhookTemplateFuncs = template.FuncMap{
+	"json": func(value interface{}) (string, error) {
+		b, err := json.Marshal(value)
+		return string(b), err
+	},
+}
+
+// renderWebhookPayload returns the payload of the CloudEvent posted to the webhook, by its template or format
+func renderWebhookPayload(webhook *kedav1alpha1.WebhookDestination, cloudEvent CloudEvent) ([]byte, error) {
+	text := webhook.Template
+	if text == "" {
+		switch webhook.Format {
+		case kedav1alpha1.WebhookFormatSlack:
+			text = slackTemplate
+		case kedav1alpha1.WebhookFormatTeams:
+			text = teamsTemplate
+		case "", kedav1alpha1.WebhookFormatGeneric:
+			return json.Marshal(cloudEvent)
+		default:
+			return nil, fmt.Errorf("unknown webhook format %q", webhook.Format)
+		}
+	}
+
+	tmpl, err := template.New("webhook").Funcs(webhookTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing webhook template: %w", err)
+	}
+	var payload bytes.Buffer
+	if err := tmpl.Execute(&payload, cloudEvent); err != nil {
+		return nil, fmt.Errorf("error executing webhook template: %w", err)
+	}
+	return payload.Bytes(), nil
+}
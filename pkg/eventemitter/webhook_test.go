@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventemitter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+var testWebhookCloudEvent = CloudEvent{
+	SpecVersion: cloudEventSpecVersion,
+	Type:        kedav1alpha1.CloudEventScalerFailed,
+	Data: EventData{
+		Namespace:   "default",
+		Name:        "so",
+		Kind:        "ScaledObject",
+		Message:     "connection \"refused\"",
+		TriggerName: "queue",
+		TriggerType: "rabbitmq",
+		Metrics:     map[string]float64{"s0-rabbitmq-queue": 12},
+	},
+}
+
+func TestRenderWebhookPayload(t *testing.T) {
+	type testCase struct {
+		name     string
+		webhook  kedav1alpha1.WebhookDestination
+		expected map[string]interface{}
+		isError  bool
+	}
+	testCases := []testCase{
+		{
+			name:    "slack",
+			webhook: kedav1alpha1.WebhookDestination{Format: kedav1alpha1.WebhookFormatSlack},
+			expected: map[string]interface{}{
+				"text": "[keda.scaler.failed.v1] ScaledObject default/so: connection \"refused\"",
+			},
+		},
+		{
+			name:    "teams",
+			webhook: kedav1alpha1.WebhookDestination{Format: kedav1alpha1.WebhookFormatTeams},
+			expected: map[string]interface{}{
+				"@type":    "MessageCard",
+				"@context": "http://schema.org/extensions",
+				"summary":  "keda.scaler.failed.v1",
+				"title":    "keda.scaler.failed.v1 default/so",
+				"text":     "connection \"refused\"",
+			},
+		},
+		{
+			name: "template",
+			webhook: kedav1alpha1.WebhookDestination{
+				Format:   kedav1alpha1.WebhookFormatSlack,
+				Template: `{"trigger": {{ printf "%s/%s" .Data.TriggerType .Data.TriggerName | json }}, "value": {{ index .Data.Metrics "s0-rabbitmq-queue" }}}`,
+			},
+			expected: map[string]interface{}{"trigger": "rabbitmq/queue", "value": float64(12)},
+		},
+		{
+			name:    "malformed template",
+			webhook: kedav1alpha1.WebhookDestination{Template: "{{ .Data.Name "},
+			isError: true,
+		},
+		{
+			name:    "unknown field in template",
+			webhook: kedav1alpha1.WebhookDestination{Template: "{{ .Data.Workload }}"},
+			isError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := renderWebhookPayload(&tc.webhook, testWebhookCloudEvent)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			actual := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(payload, &actual))
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestRenderWebhookPayloadGeneric(t *testing.T) {
+	payload, err := renderWebhookPayload(&kedav1alpha1.WebhookDestination{}, testWebhookCloudEvent)
+	assert.NoError(t, err)
+	cloudEvent := CloudEvent{}
+	assert.NoError(t, json.Unmarshal(payload, &cloudEvent))
+	assert.Equal(t, testWebhookCloudEvent.Data, cloudEvent.Data)
+}
@@ -26,6 +26,9 @@ const (
 	// ScaledObjectCheckFailed is for event when ScaledObject validation check fails
 	ScaledObjectCheckFailed = "ScaledObjectCheckFailed"
 
+	// ScaledObjectConflict is for event when ScaledObject targets a workload already scaled by another ScaledObject or HPA
+	ScaledObjectConflict = "ScaledObjectConflict"
+
 	// ScaledJobCheckFailed is for event when ScaledJob validation check fails
 	ScaledJobCheckFailed = "ScaledJobCheckFailed"
 
@@ -44,6 +47,9 @@ const (
 	// KEDAScalerFailed is for event when a scaler fails for a ScaledJob or a ScaledObject
 	KEDAScalerFailed = "KEDAScalerFailed"
 
+	// KEDAScalerCircuitOpen is for event when checks of a trigger of a ScaledObject are skipped after consecutive failures
+	KEDAScalerCircuitOpen = "KEDAScalerCircuitOpen"
+
 	// KEDAScaleTargetActivated is for event when the scale target of ScaledObject was activated
 	KEDAScaleTargetActivated = "KEDAScaleTargetActivated"
 
@@ -56,6 +62,12 @@ const (
 	// KEDAScaleTargetDeactivationFailed is for event when the deactivation of the scale target for ScaledObject fails
 	KEDAScaleTargetDeactivationFailed = "KEDAScaleTargetDeactivationFailed"
 
+	// KEDAScaleTargetReadinessTimeout is for event when the activated scale target of ScaledObject doesn't become ready in time
+	KEDAScaleTargetReadinessTimeout = "KEDAScaleTargetReadinessTimeout"
+
+	// KEDAMaxReplicaCountExceedsParallelism is for event when maxReplicaCount of ScaledObject exceeds the parallelism of partitioned sources of its triggers
+	KEDAMaxReplicaCountExceedsParallelism = "KEDAMaxReplicaCountExceedsParallelism"
+
 	// KEDAJobsCreated is for event when jobs for ScaledJob are created
 	KEDAJobsCreated = "KEDAJobsCreated"
 
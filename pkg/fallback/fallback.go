@@ -27,6 +27,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers"
 )
 
 var log = logf.Log.WithName("fallback")
@@ -54,6 +55,7 @@ func GetMetricsWithFallback(ctx context.Context, client runtimeclient.Client, me
 		zero := int32(0)
 		healthStatus.NumberOfFailures = &zero
 		healthStatus.Status = kedav1alpha1.HealthStatusHappy
+		healthStatus.ErrorCategory = ""
 		status.Health[metricName] = *healthStatus
 
 		updateStatus(ctx, client, scaledObject, status, metricSpec)
@@ -61,6 +63,7 @@ func GetMetricsWithFallback(ctx context.Context, client runtimeclient.Client, me
 	}
 
 	healthStatus.Status = kedav1alpha1.HealthStatusFailing
+	healthStatus.ErrorCategory = string(scalers.GetScalerErrorCategory(suppressedError))
 	*healthStatus.NumberOfFailures++
 	status.Health[metricName] = *healthStatus
 
@@ -34,6 +34,7 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
 	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scalers"
 )
 
 const metricName = "some_metric_name"
@@ -153,6 +154,20 @@ var _ = Describe("fallback", func() {
 		Expect(so.Status.Health[metricName]).To(haveFailureAndStatus(1, kedav1alpha1.HealthStatusFailing))
 	})
 
+	It("should set the error category in the health status when metrics call fails", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, scalers.NewHTTPStatusError(401, errors.New("Some error")))
+
+		so := buildScaledObject(nil, nil)
+		metricSpec := createMetricSpec(3)
+		expectStatusPatch(ctrl, client)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		_, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
+
+		Expect(err).ShouldNot(BeNil())
+		Expect(so.Status.Health[metricName].ErrorCategory).Should(Equal(string(scalers.ScalerErrorCategoryAuth)))
+	})
+
 	It("should return a normalised metric when number of failures are beyond threshold", func() {
 		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("Some error"))
 		startingNumberOfFailures := int32(3)
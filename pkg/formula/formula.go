@@ -0,0 +1,271 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package formula evaluates arithmetic expressions transforming metric values of triggers, eg. "value / 4"
+// or "max(value - 100, 0)". The expressions support numbers, the variable value, the operators + - * /,
+// parentheses and the functions min, max, abs, ceil and floor.
+package formula
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Formula is a parsed expression
+type Formula struct {
+	expression string
+	eval       func(value float64) (float64, error)
+}
+
+type operand func(value float64) (float64, error)
+
+// Parse parses the expression, it returns an error if the expression isn't valid
+func Parse(expression string) (*Formula, error) {
+	p := &parser{input: expression}
+	p.next()
+	eval, err := p.parseExpression()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing formula %q: %w", expression, err)
+	}
+	if p.token != "" {
+		return nil, fmt.Errorf("error parsing formula %q: unexpected %q", expression, p.token)
+	}
+	return &Formula{expression: expression, eval: eval}, nil
+}
+
+// Evaluate returns the result of the formula for the metric value
+func (f *Formula) Evaluate(value float64) (float64, error) {
+	result, err := f.eval(value)
+	if err != nil {
+		return 0, fmt.Errorf("error evaluating formula %q: %w", f.expression, err)
+	}
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, fmt.Errorf("error evaluating formula %q: result isn't a finite number", f.expression)
+	}
+	return result, nil
+}
+
+var functions = map[string]struct {
+	args int
+	call func(args []float64) float64
+}{
+	"min":   {2, func(args []float64) float64 { return math.Min(args[0], args[1]) }},
+	"max":   {2, func(args []float64) float64 { return math.Max(args[0], args[1]) }},
+	"abs":   {1, func(args []float64) float64 { return math.Abs(args[0]) }},
+	"ceil":  {1, func(args []float64) float64 { return math.Ceil(args[0]) }},
+	"floor": {1, func(args []float64) float64 { return math.Floor(args[0]) }},
+}
+
+// parser is a recursive descent parser of the expressions, the current token is empty at the end of the input
+type parser struct {
+	input string
+	pos   int
+	token string
+}
+
+func (p *parser) next() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	switch {
+	case p.pos >= len(p.input):
+		p.token = ""
+		return
+	case isNumberChar(p.input[p.pos]):
+		for p.pos < len(p.input) && isNumberChar(p.input[p.pos]) {
+			p.pos++
+		}
+	case isIdentifierChar(p.input[p.pos]):
+		for p.pos < len(p.input) && isIdentifierChar(p.input[p.pos]) {
+			p.pos++
+		}
+	default:
+		p.pos++
+	}
+	p.token = p.input[start:p.pos]
+}
+
+// parseExpression parses terms separated by + and -
+func (p *parser) parseExpression() (operand, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.token == "+" || p.token == "-" {
+		op := p.token
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = binary(op, left, right)
+	}
+	return left, nil
+}
+
+// parseTerm parses factors separated by * and /
+func (p *parser) parseTerm() (operand, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.token == "*" || p.token == "/" {
+		op := p.token
+		p.next()
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = binary(op, left, right)
+	}
+	return left, nil
+}
+
+// parseFactor parses a number, the variable, a function call, a parenthesized expression or a signed factor
+func (p *parser) parseFactor() (operand, error) {
+	token := p.token
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of formula")
+	case token == "-" || token == "+":
+		p.next()
+		factor, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		if token == "+" {
+			return factor, nil
+		}
+		return func(value float64) (float64, error) {
+			result, err := factor(value)
+			return -result, err
+		}, nil
+	case token == "(":
+		p.next()
+		expression, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return expression, nil
+	case isNumberChar(token[0]):
+		number, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", token)
+		}
+		p.next()
+		return func(float64) (float64, error) { return number, nil }, nil
+	case token == "value":
+		p.next()
+		return func(value float64) (float64, error) { return value, nil }, nil
+	case isIdentifierChar(token[0]):
+		return p.parseCall()
+	default:
+		return nil, fmt.Errorf("unexpected %q", token)
+	}
+}
+
+func (p *parser) parseCall() (operand, error) {
+	name := p.token
+	function, found := functions[strings.ToLower(name)]
+	if !found {
+		return nil, fmt.Errorf("unknown variable or function %q, only value is supported", name)
+	}
+	p.next()
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	args := make([]operand, 0, function.args)
+	for {
+		arg, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.token != "," {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	if len(args) != function.args {
+		return nil, fmt.Errorf("function %s expects %d arguments, got %d", name, function.args, len(args))
+	}
+	return func(value float64) (float64, error) {
+		values := make([]float64, len(args))
+		for i, arg := range args {
+			result, err := arg(value)
+			if err != nil {
+				return 0, err
+			}
+			values[i] = result
+		}
+		return function.call(values), nil
+	}, nil
+}
+
+func (p *parser) expect(token string) error {
+	if p.token != token {
+		if p.token == "" {
+			return fmt.Errorf("expected %q, got end of formula", token)
+		}
+		return fmt.Errorf("expected %q, got %q", token, p.token)
+	}
+	p.next()
+	return nil
+}
+
+func binary(op string, left, right operand) operand {
+	return func(value float64) (float64, error) {
+		l, err := left(value)
+		if err != nil {
+			return 0, err
+		}
+		r, err := right(value)
+		if err != nil {
+			return 0, err
+		}
+		switch op {
+		case "+":
+			return l + r, nil
+		case "-":
+			return l - r, nil
+		case "*":
+			return l * r, nil
+		default:
+			if r == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			return l / r, nil
+		}
+	}
+}
+
+func isNumberChar(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '.'
+}
+
+func isIdentifierChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package formula
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	cases := []struct {
+		expression string
+		value      float64
+		expected   float64
+	}{
+		{"value", 10, 10},
+		{"value / 4", 10, 2.5},
+		{"value * 0.5 + 1", 10, 6},
+		{"1 + value * 0.5", 10, 6},
+		{"(value + 2) * 3", 10, 36},
+		{"value - 2 - 3", 10, 5},
+		{"value / 2 / 5", 10, 1},
+		{"-value + 20", 10, 10},
+		{"max(value - 100, 0)", 10, 0},
+		{"min(value, 5)", 10, 5},
+		{"ceil(value / 3)", 10, 4},
+		{"floor(value / 3)", 10, 3},
+		{"abs(value - 15)", 10, 5},
+		{"MAX(value, 20)", 10, 20},
+	}
+
+	for _, c := range cases {
+		f, err := Parse(c.expression)
+		assert.NoError(t, err, c.expression)
+		result, err := f.Evaluate(c.value)
+		assert.NoError(t, err, c.expression)
+		assert.InDelta(t, c.expected, result, 1e-9, c.expression)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, expression := range []string{"", "value +", "(value", "value)", "lag / 2", "max(value)", "min(value, 1, 2)", "1..2", "value $ 2", "value 2"} {
+		_, err := Parse(expression)
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	f, err := Parse("10 / value")
+	assert.NoError(t, err)
+	_, err = f.Evaluate(0)
+	assert.Error(t, err)
+
+	f, err = Parse("value * value")
+	assert.NoError(t, err)
+	_, err = f.Evaluate(1e308)
+	assert.Error(t, err)
+}
@@ -32,6 +32,10 @@ func (c *FakeKedaV1alpha1) ClusterTriggerAuthentications() v1alpha1.ClusterTrigg
 	return &FakeClusterTriggerAuthentications{c}
 }
 
+func (c *FakeKedaV1alpha1) KedaHealths() v1alpha1.KedaHealthInterface {
+	return &FakeKedaHealths{c}
+}
+
 func (c *FakeKedaV1alpha1) ScaledJobs(namespace string) v1alpha1.ScaledJobInterface {
 	return &FakeScaledJobs{c, namespace}
 }
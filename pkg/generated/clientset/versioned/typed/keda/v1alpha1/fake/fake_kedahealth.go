@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeKedaHealths implements KedaHealthInterface
+type FakeKedaHealths struct {
+	Fake *FakeKedaV1alpha1
+}
+
+var kedahealthsResource = schema.GroupVersionResource{Group: "keda", Version: "v1alpha1", Resource: "kedahealths"}
+
+var kedahealthsKind = schema.GroupVersionKind{Group: "keda", Version: "v1alpha1", Kind: "KedaHealth"}
+
+// Get takes name of the kedaHealth, and returns the corresponding kedaHealth object, and an error if there is any.
+func (c *FakeKedaHealths) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.KedaHealth, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(kedahealthsResource, name), &v1alpha1.KedaHealth{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KedaHealth), err
+}
+
+// List takes label and field selectors, and returns the list of KedaHealths that match those selectors.
+func (c *FakeKedaHealths) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.KedaHealthList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(kedahealthsResource, kedahealthsKind, opts), &v1alpha1.KedaHealthList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.KedaHealthList{ListMeta: obj.(*v1alpha1.KedaHealthList).ListMeta}
+	for _, item := range obj.(*v1alpha1.KedaHealthList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested kedaHealths.
+func (c *FakeKedaHealths) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(kedahealthsResource, opts))
+}
+
+// Create takes the representation of a kedaHealth and creates it.  Returns the server's representation of the kedaHealth, and an error, if there is any.
+func (c *FakeKedaHealths) Create(ctx context.Context, kedaHealth *v1alpha1.KedaHealth, opts v1.CreateOptions) (result *v1alpha1.KedaHealth, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(kedahealthsResource, kedaHealth), &v1alpha1.KedaHealth{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KedaHealth), err
+}
+
+// Update takes the representation of a kedaHealth and updates it. Returns the server's representation of the kedaHealth, and an error, if there is any.
+func (c *FakeKedaHealths) Update(ctx context.Context, kedaHealth *v1alpha1.KedaHealth, opts v1.UpdateOptions) (result *v1alpha1.KedaHealth, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(kedahealthsResource, kedaHealth), &v1alpha1.KedaHealth{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KedaHealth), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeKedaHealths) UpdateStatus(ctx context.Context, kedaHealth *v1alpha1.KedaHealth, opts v1.UpdateOptions) (*v1alpha1.KedaHealth, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(kedahealthsResource, "status", kedaHealth), &v1alpha1.KedaHealth{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KedaHealth), err
+}
+
+// Delete takes name of the kedaHealth and deletes it. Returns an error if one occurs.
+func (c *FakeKedaHealths) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(kedahealthsResource, name, opts), &v1alpha1.KedaHealth{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeKedaHealths) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(kedahealthsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.KedaHealthList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched kedaHealth.
+func (c *FakeKedaHealths) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KedaHealth, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(kedahealthsResource, name, pt, data, subresources...), &v1alpha1.KedaHealth{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KedaHealth), err
+}
@@ -20,6 +20,8 @@ package v1alpha1
 
 type ClusterTriggerAuthenticationExpansion interface{}
 
+type KedaHealthExpansion interface{}
+
 type ScaledJobExpansion interface{}
 
 type ScaledObjectExpansion interface{}
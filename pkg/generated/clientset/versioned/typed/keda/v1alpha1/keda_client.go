@@ -29,6 +29,7 @@ import (
 type KedaV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ClusterTriggerAuthenticationsGetter
+	KedaHealthsGetter
 	ScaledJobsGetter
 	ScaledObjectsGetter
 	TriggerAuthenticationsGetter
@@ -43,6 +44,10 @@ func (c *KedaV1alpha1Client) ClusterTriggerAuthentications() ClusterTriggerAuthe
 	return newClusterTriggerAuthentications(c)
 }
 
+func (c *KedaV1alpha1Client) KedaHealths() KedaHealthInterface {
+	return newKedaHealths(c)
+}
+
 func (c *KedaV1alpha1Client) ScaledJobs(namespace string) ScaledJobInterface {
 	return newScaledJobs(c, namespace)
 }
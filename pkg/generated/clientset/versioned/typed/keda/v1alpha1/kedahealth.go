@@ -0,0 +1,184 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	scheme "github.com/kedacore/keda/v2/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// KedaHealthsGetter has a method to return a KedaHealthInterface.
+// A group's client should implement this interface.
+type KedaHealthsGetter interface {
+	KedaHealths() KedaHealthInterface
+}
+
+// KedaHealthInterface has methods to work with KedaHealth resources.
+type KedaHealthInterface interface {
+	Create(ctx context.Context, kedaHealth *v1alpha1.KedaHealth, opts v1.CreateOptions) (*v1alpha1.KedaHealth, error)
+	Update(ctx context.Context, kedaHealth *v1alpha1.KedaHealth, opts v1.UpdateOptions) (*v1alpha1.KedaHealth, error)
+	UpdateStatus(ctx context.Context, kedaHealth *v1alpha1.KedaHealth, opts v1.UpdateOptions) (*v1alpha1.KedaHealth, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.KedaHealth, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.KedaHealthList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KedaHealth, err error)
+	KedaHealthExpansion
+}
+
+// kedaHealths implements KedaHealthInterface
+type kedaHealths struct {
+	client rest.Interface
+}
+
+// newKedaHealths returns a KedaHealths
+func newKedaHealths(c *KedaV1alpha1Client) *kedaHealths {
+	return &kedaHealths{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the kedaHealth, and returns the corresponding kedaHealth object, and an error if there is any.
+func (c *kedaHealths) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.KedaHealth, err error) {
+	result = &v1alpha1.KedaHealth{}
+	err = c.client.Get().
+		Resource("kedahealths").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of KedaHealths that match those selectors.
+func (c *kedaHealths) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.KedaHealthList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.KedaHealthList{}
+	err = c.client.Get().
+		Resource("kedahealths").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested kedaHealths.
+func (c *kedaHealths) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("kedahealths").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a kedaHealth and creates it.  Returns the server's representation of the kedaHealth, and an error, if there is any.
+func (c *kedaHealths) Create(ctx context.Context, kedaHealth *v1alpha1.KedaHealth, opts v1.CreateOptions) (result *v1alpha1.KedaHealth, err error) {
+	result = &v1alpha1.KedaHealth{}
+	err = c.client.Post().
+		Resource("kedahealths").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kedaHealth).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a kedaHealth and updates it. Returns the server's representation of the kedaHealth, and an error, if there is any.
+func (c *kedaHealths) Update(ctx context.Context, kedaHealth *v1alpha1.KedaHealth, opts v1.UpdateOptions) (result *v1alpha1.KedaHealth, err error) {
+	result = &v1alpha1.KedaHealth{}
+	err = c.client.Put().
+		Resource("kedahealths").
+		Name(kedaHealth.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kedaHealth).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *kedaHealths) UpdateStatus(ctx context.Context, kedaHealth *v1alpha1.KedaHealth, opts v1.UpdateOptions) (result *v1alpha1.KedaHealth, err error) {
+	result = &v1alpha1.KedaHealth{}
+	err = c.client.Put().
+		Resource("kedahealths").
+		Name(kedaHealth.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kedaHealth).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the kedaHealth and deletes it. Returns an error if one occurs.
+func (c *kedaHealths) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("kedahealths").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *kedaHealths) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("kedahealths").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched kedaHealth.
+func (c *kedaHealths) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KedaHealth, err error) {
+	result = &v1alpha1.KedaHealth{}
+	err = c.client.Patch(pt).
+		Resource("kedahealths").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
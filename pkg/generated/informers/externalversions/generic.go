@@ -55,6 +55,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=keda, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("clustertriggerauthentications"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ClusterTriggerAuthentications().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("kedahealths"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().KedaHealths().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("scaledjobs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ScaledJobs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("scaledobjects"):
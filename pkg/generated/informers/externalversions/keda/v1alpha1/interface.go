@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// ClusterTriggerAuthentications returns a ClusterTriggerAuthenticationInformer.
 	ClusterTriggerAuthentications() ClusterTriggerAuthenticationInformer
+	// KedaHealths returns a KedaHealthInformer.
+	KedaHealths() KedaHealthInformer
 	// ScaledJobs returns a ScaledJobInformer.
 	ScaledJobs() ScaledJobInformer
 	// ScaledObjects returns a ScaledObjectInformer.
@@ -50,6 +52,11 @@ func (v *version) ClusterTriggerAuthentications() ClusterTriggerAuthenticationIn
 	return &clusterTriggerAuthenticationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// KedaHealths returns a KedaHealthInformer.
+func (v *version) KedaHealths() KedaHealthInformer {
+	return &kedaHealthInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ScaledJobs returns a ScaledJobInformer.
 func (v *version) ScaledJobs() ScaledJobInformer {
 	return &scaledJobInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -22,6 +22,10 @@ package v1alpha1
 // ClusterTriggerAuthenticationLister.
 type ClusterTriggerAuthenticationListerExpansion interface{}
 
+// KedaHealthListerExpansion allows custom methods to be added to
+// KedaHealthLister.
+type KedaHealthListerExpansion interface{}
+
 // ScaledJobListerExpansion allows custom methods to be added to
 // ScaledJobLister.
 type ScaledJobListerExpansion interface{}
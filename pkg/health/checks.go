@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health implements the checks of /healthz and /readyz endpoints of KEDA Operator and KEDA Metrics Server.
+// Readiness checks fail as soon as the component can't serve, liveness checks fail only once the component has been
+// failing for a grace period, so Kubernetes restarts instances that are wedged, not the ones recovering on their own.
+package health
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// Checker is a health check, it's compatible with healthz.Checker of controller-runtime
+type Checker = func(req *http.Request) error
+
+const (
+	// DefaultLivenessGracePeriod is the default period a check has to be failing for until liveness fails
+	DefaultLivenessGracePeriod = 2 * time.Minute
+
+	cacheSyncTimeout = time.Second
+	apiServerTimeout = 5 * time.Second
+)
+
+// CacheSynced checks the informers of the cache are started and synced
+func CacheSynced(c cache.Cache) Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), cacheSyncTimeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return fmt.Errorf("informer caches aren't synced")
+		}
+		return nil
+	}
+}
+
+// APIServerReachable checks the Kubernetes API server is reachable, client is a client of the core API, eg. the one
+// of a discovery client
+func APIServerReachable(client rest.Interface) Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), apiServerTimeout)
+		defer cancel()
+		if err := client.Get().AbsPath("/version").Do(ctx).Error(); err != nil {
+			return fmt.Errorf("kubernetes API server isn't reachable: %w", err)
+		}
+		return nil
+	}
+}
+
+// CertificatesValid checks all certificates in the given PEM files are valid now, they are read on every check
+// so rotated certificates are picked up
+func CertificatesValid(files ...string) Checker {
+	return func(_ *http.Request) error {
+		now := time.Now()
+		for _, file := range files {
+			if err := checkCertificates(file, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func checkCertificates(file string, now time.Time) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("error reading certificate: %w", err)
+	}
+	found := false
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("error parsing certificate %s: %w", file, err)
+		}
+		if now.Before(cert.NotBefore) {
+			return fmt.Errorf("certificate %s (%s) isn't valid until %s", file, cert.Subject.CommonName, cert.NotBefore.Format(time.RFC3339))
+		}
+		if now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate %s (%s) expired at %s", file, cert.Subject.CommonName, cert.NotAfter.Format(time.RFC3339))
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("no certificate found in %s", file)
+	}
+	return nil
+}
+
+// FailingLongerThan returns a check which fails only once the given check has been failing continuously for longer
+// than gracePeriod
+func FailingLongerThan(check Checker, gracePeriod time.Duration) Checker {
+	return (&gracedChecker{check: check, gracePeriod: gracePeriod, now: time.Now}).Check
+}
+
+type gracedChecker struct {
+	check       Checker
+	gracePeriod time.Duration
+	now         func() time.Time
+
+	lock         sync.Mutex
+	failingSince time.Time
+}
+
+func (c *gracedChecker) Check(req *http.Request) error {
+	err := c.check(req)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err == nil {
+		c.failingSince = time.Time{}
+		return nil
+	}
+	now := c.now()
+	if c.failingSince.IsZero() {
+		c.failingSince = now
+	}
+	if failing := now.Sub(c.failingSince); failing > c.gracePeriod {
+		return fmt.Errorf("failing for %s: %w", failing.Round(time.Second), err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+type fakeCache struct {
+	cache.Cache
+	synced bool
+}
+
+func (c *fakeCache) WaitForCacheSync(ctx context.Context) bool {
+	if !c.synced {
+		<-ctx.Done()
+	}
+	return c.synced
+}
+
+func TestCacheSynced(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	assert.NoError(t, CacheSynced(&fakeCache{synced: true})(req))
+	assert.ErrorContains(t, CacheSynced(&fakeCache{synced: false})(req), "informer caches aren't synced")
+}
+
+func TestAPIServerReachable(t *testing.T) {
+	healthy := atomic.Bool{}
+	healthy.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() || r.URL.Path != "/version" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"major": "1", "minor": "26"}`))
+	}))
+	defer server.Close()
+	client, err := discovery.NewDiscoveryClientForConfig(&rest.Config{Host: server.URL})
+	assert.NoError(t, err)
+	check := APIServerReachable(client.RESTClient())
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	assert.NoError(t, check(req))
+	healthy.Store(false)
+	assert.ErrorContains(t, check(req), "kubernetes API server isn't reachable")
+}
+
+func TestCertificatesValid(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	valid := writeCertificate(t, dir, "valid.crt", now.Add(-time.Hour), now.Add(time.Hour))
+	expired := writeCertificate(t, dir, "expired.crt", now.Add(-2*time.Hour), now.Add(-time.Hour))
+	notYetValid := writeCertificate(t, dir, "future.crt", now.Add(time.Hour), now.Add(2*time.Hour))
+	empty := path.Join(dir, "empty.crt")
+	assert.NoError(t, os.WriteFile(empty, []byte("not a certificate"), 0600))
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	assert.NoError(t, CertificatesValid(valid)(req))
+	assert.ErrorContains(t, CertificatesValid(valid, expired)(req), "expired at")
+	assert.ErrorContains(t, CertificatesValid(notYetValid)(req), "isn't valid until")
+	assert.ErrorContains(t, CertificatesValid(empty)(req), "no certificate found")
+	assert.ErrorContains(t, CertificatesValid(path.Join(dir, "missing.crt"))(req), "error reading certificate")
+}
+
+func TestFailingLongerThan(t *testing.T) {
+	var checkErr error
+	now := time.Now()
+	checker := &gracedChecker{
+		check:       func(_ *http.Request) error { return checkErr },
+		gracePeriod: time.Minute,
+		now:         func() time.Time { return now },
+	}
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+
+	assert.NoError(t, checker.Check(req))
+
+	checkErr = errors.New("wedged")
+	assert.NoError(t, checker.Check(req), "failures within the grace period are tolerated")
+	now = now.Add(time.Minute)
+	assert.NoError(t, checker.Check(req))
+	now = now.Add(time.Second)
+	assert.ErrorContains(t, checker.Check(req), "failing for 1m1s: wedged")
+
+	checkErr = nil
+	assert.NoError(t, checker.Check(req))
+	checkErr = errors.New("wedged")
+	assert.NoError(t, checker.Check(req), "recovery resets the grace period")
+}
+
+func writeCertificate(t *testing.T, dir, name string, notBefore, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	file := path.Join(dir, name)
+	assert.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return file
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interceptor
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateWindow is the period of the average request rate reported by the interceptor
+const rateWindow = time.Minute
+
+// Counts are the pending requests and the average request rate per second of a HTTPScaledObject,
+// they're decoded by the interceptor backend of the http scaler
+type Counts struct {
+	Concurrency int     `json:"Concurrency"`
+	RPS         float64 `json:"RPS"`
+}
+
+// Counter counts the pending requests and the request rate of HTTPScaledObjects by their queue keys
+type Counter struct {
+	lock    sync.Mutex
+	entries map[string]*counterEntry
+	now     func() time.Time
+}
+
+type counterEntry struct {
+	pending int
+	// buckets count the requests of each second of the rate window
+	buckets [int(rateWindow / time.Second)]int
+	// bucketTimes are the seconds counted by the buckets
+	bucketTimes [int(rateWindow / time.Second)]int64
+}
+
+// NewCounter creates a new Counter
+func NewCounter() *Counter {
+	return &Counter{
+		entries: map[string]*counterEntry{},
+		now:     time.Now,
+	}
+}
+
+// Start counts a new pending request of the key, the returned function has to be called once the request is done
+func (c *Counter) Start(key string) func() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, found := c.entries[key]
+	if !found {
+		entry = &counterEntry{}
+		c.entries[key] = entry
+	}
+	entry.pending++
+
+	second := c.now().Unix()
+	bucket := second % int64(len(entry.buckets))
+	if entry.bucketTimes[bucket] != second {
+		entry.bucketTimes[bucket] = second
+		entry.buckets[bucket] = 0
+	}
+	entry.buckets[bucket]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			entry.pending--
+		})
+	}
+}
+
+// Counts returns the counts of keys with pending requests or requests in the rate window,
+// keys without them are forgotten
+func (c *Counter) Counts() map[string]Counts {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	windowStart := c.now().Add(-rateWindow).Unix()
+	counts := make(map[string]Counts, len(c.entries))
+	for key, entry := range c.entries {
+		requests := 0
+		for i, second := range entry.bucketTimes {
+			if second > windowStart {
+				requests += entry.buckets[i]
+			}
+		}
+		if entry.pending == 0 && requests == 0 {
+			delete(c.entries, key)
+			continue
+		}
+		counts[key] = Counts{
+			Concurrency: entry.pending,
+			RPS:         float64(requests) / rateWindow.Seconds(),
+		}
+	}
+	return counts
+}
+
+// QueueHandler serves the counts of all keys as JSON object, it's queried by the interceptor backend of the http scaler
+func QueueHandler(counter *Counter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(counter.Counts()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
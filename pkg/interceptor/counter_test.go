@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interceptor
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	counter := NewCounter()
+	counter.now = func() time.Time { return now }
+
+	done := counter.Start("default/web")
+	for i := 0; i < 5; i++ {
+		counter.Start("default/api")()
+	}
+	assert.Equal(t, map[string]Counts{
+		"default/web": {Concurrency: 1, RPS: 1.0 / 60},
+		"default/api": {Concurrency: 0, RPS: 5.0 / 60},
+	}, counter.Counts())
+
+	// done requests are counted only once
+	done()
+	done()
+	now = now.Add(30 * time.Second)
+	counter.Start("default/api")()
+	assert.Equal(t, map[string]Counts{
+		"default/web": {Concurrency: 0, RPS: 1.0 / 60},
+		"default/api": {Concurrency: 0, RPS: 6.0 / 60},
+	}, counter.Counts())
+
+	// requests out of the rate window aren't counted and keys without requests are forgotten
+	now = now.Add(45 * time.Second)
+	assert.Equal(t, map[string]Counts{
+		"default/api": {Concurrency: 0, RPS: 1.0 / 60},
+	}, counter.Counts())
+	now = now.Add(time.Minute)
+	assert.Empty(t, counter.Counts())
+	assert.Empty(t, counter.entries)
+}
+
+func TestQueueHandler(t *testing.T) {
+	counter := NewCounter()
+	counter.Start("default/web")
+
+	recorder := httptest.NewRecorder()
+	QueueHandler(counter).ServeHTTP(recorder, httptest.NewRequest("GET", "/queue", nil))
+
+	counts := map[string]Counts{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &counts))
+	assert.Equal(t, 1, counts["default/web"].Concurrency)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	httpv1alpha1 "github.com/kedacore/keda/v2/apis/http/v1alpha1"
+)
+
+// DefaultWaitTimeout is the default longest time a request is held while its target is scaled from zero
+const DefaultWaitTimeout = 20 * time.Second
+
+// endpointsPollInterval is the interval of checks of endpoints of the Service of a held request
+const endpointsPollInterval = 250 * time.Millisecond
+
+// +kubebuilder:rbac:groups=http.keda.sh,resources=httpscaledobjects,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
+
+// Proxy routes requests to the Services of HTTPScaledObjects, the requests are held until the Service has ready
+// endpoints and they're counted as pending requests of the HTTPScaledObject until they're done
+type Proxy struct {
+	reader      client.Reader
+	counter     *Counter
+	waitTimeout time.Duration
+	transport   http.RoundTripper
+	logger      logr.Logger
+
+	// targetHost returns the address of the Service port
+	targetHost func(namespace, service string, port int32) string
+}
+
+// NewProxy creates a new Proxy reading HTTPScaledObjects and Endpoints by the reader
+func NewProxy(reader client.Reader, counter *Counter, waitTimeout time.Duration, logger logr.Logger) *Proxy {
+	return &Proxy{
+		reader:      reader,
+		counter:     counter,
+		waitTimeout: waitTimeout,
+		transport:   http.DefaultTransport,
+		logger:      logger,
+		targetHost: func(namespace, service string, port int32) string {
+			return fmt.Sprintf("%s.%s:%d", service, namespace, port)
+		},
+	}
+}
+
+// ServeHTTP routes the request to the Service of the matching HTTPScaledObject
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	httpScaledObjects := &httpv1alpha1.HTTPScaledObjectList{}
+	if err := p.reader.List(r.Context(), httpScaledObjects); err != nil {
+		p.logger.Error(err, "error listing HTTPScaledObjects")
+		http.Error(w, "error routing request", http.StatusInternalServerError)
+		return
+	}
+
+	route := FindRoute(httpScaledObjects.Items, r.Host, r.URL.Path)
+	if route == nil {
+		http.Error(w, fmt.Sprintf("no route for host %s and path %s", r.Host, r.URL.Path), http.StatusNotFound)
+		return
+	}
+
+	done := p.counter.Start(route.QueueKey())
+	defer done()
+
+	target := route.Spec.ScaleTargetRef
+	if err := p.waitForEndpoints(r.Context(), route.Namespace, target.Service); err != nil {
+		p.logger.V(1).Info("target of request isn't available", "httpScaledObject", route.QueueKey(), "error", err.Error())
+		http.Error(w, fmt.Sprintf("service %s isn't available", target.Service), http.StatusBadGateway)
+		return
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(&url.URL{
+		Scheme: "http",
+		Host:   p.targetHost(route.Namespace, target.Service, target.Port),
+	})
+	proxy.Transport = p.transport
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		p.logger.V(1).Info("error proxying request", "httpScaledObject", route.QueueKey(), "error", err.Error())
+		w.WriteHeader(http.StatusBadGateway)
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+// waitForEndpoints blocks until the Service has a ready endpoint, the wait timeout passes or the request is canceled
+func (p *Proxy) waitForEndpoints(ctx context.Context, namespace, service string) error {
+	ctx, cancel := context.WithTimeout(ctx, p.waitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(endpointsPollInterval)
+	defer ticker.Stop()
+	for {
+		ready, err := p.hasReadyEndpoints(ctx, namespace, service)
+		if err != nil {
+			return err
+		}
+		if ready {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("no ready endpoints of service %s/%s: %w", namespace, service, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Proxy) hasReadyEndpoints(ctx context.Context, namespace, service string) (bool, error) {
+	endpoints := &corev1.Endpoints{}
+	if err := p.reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: service}, endpoints); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
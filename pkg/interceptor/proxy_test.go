@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interceptor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	httpv1alpha1 "github.com/kedacore/keda/v2/apis/http/v1alpha1"
+)
+
+func newTestProxy(t *testing.T, backend *httptest.Server, objects ...client.Object) (*Proxy, client.Client) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, httpv1alpha1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	proxy := NewProxy(fakeClient, NewCounter(), 2*time.Second, logr.Discard())
+	backendURL, err := url.Parse(backend.URL)
+	assert.NoError(t, err)
+	proxy.targetHost = func(string, string, int32) string { return backendURL.Host }
+	return proxy, fakeClient
+}
+
+func newEndpoints(ready bool) *corev1.Endpoints {
+	endpoints := &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	if ready {
+		endpoints.Subsets = []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}}
+	}
+	return endpoints
+}
+
+func TestProxyRoutesRequests(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "example.com", r.Host)
+		_, _ = w.Write([]byte("hello " + r.URL.Path))
+	}))
+	defer backend.Close()
+
+	httpScaledObject := newHTTPScaledObject("web", []string{"example.com"}, nil)
+	proxy, _ := newTestProxy(t, backend, &httpScaledObject, newEndpoints(true))
+
+	recorder := httptest.NewRecorder()
+	proxy.ServeHTTP(recorder, httptest.NewRequest("GET", "http://example.com/index.html", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "hello /index.html", recorder.Body.String())
+	assert.Equal(t, 0, proxy.counter.Counts()["default/web"].Concurrency)
+
+	recorder = httptest.NewRecorder()
+	proxy.ServeHTTP(recorder, httptest.NewRequest("GET", "http://other.com/", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestProxyHoldsRequestsUntilEndpointsAreReady(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer backend.Close()
+
+	httpScaledObject := newHTTPScaledObject("web", []string{"example.com"}, nil)
+	proxy, fakeClient := newTestProxy(t, backend, &httpScaledObject, newEndpoints(false))
+
+	result := make(chan int)
+	go func() {
+		recorder := httptest.NewRecorder()
+		proxy.ServeHTTP(recorder, httptest.NewRequest("GET", "http://example.com/", nil))
+		result <- recorder.Code
+	}()
+
+	// the held request is pending until the target is scaled from zero
+	assert.Eventually(t, func() bool {
+		return proxy.counter.Counts()["default/web"].Concurrency == 1
+	}, time.Second, 10*time.Millisecond)
+	endpoints := &corev1.Endpoints{}
+	assert.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "web"}, endpoints))
+	endpoints.Subsets = newEndpoints(true).Subsets
+	assert.NoError(t, fakeClient.Update(context.Background(), endpoints))
+	assert.Equal(t, http.StatusOK, <-result)
+}
+
+func TestProxyTimesOutWithoutEndpoints(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer backend.Close()
+
+	httpScaledObject := newHTTPScaledObject("web", []string{"example.com"}, nil)
+	proxy, _ := newTestProxy(t, backend, &httpScaledObject)
+
+	recorder := httptest.NewRecorder()
+	proxy.ServeHTTP(recorder, httptest.NewRequest("GET", "http://example.com/", nil))
+	assert.Equal(t, http.StatusBadGateway, recorder.Code)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package interceptor implements the interceptor of HTTP requests routed by HTTPScaledObjects, it holds the requests
+// while KEDA scales their targets from zero and reports the pending requests of each HTTPScaledObject to the http
+// scaler.
+package interceptor
+
+import (
+	"net"
+	"strings"
+
+	httpv1alpha1 "github.com/kedacore/keda/v2/apis/http/v1alpha1"
+)
+
+// FindRoute returns the HTTPScaledObject routing the request of the host and path, the HTTPScaledObject with the
+// longest matching path prefix wins, nil is returned if no HTTPScaledObject routes the request
+func FindRoute(httpScaledObjects []httpv1alpha1.HTTPScaledObject, host, path string) *httpv1alpha1.HTTPScaledObject {
+	host = normalizeHost(host)
+
+	var route *httpv1alpha1.HTTPScaledObject
+	longestPrefix := -1
+	for i := range httpScaledObjects {
+		httpScaledObject := &httpScaledObjects[i]
+		if httpScaledObject.GetDeletionTimestamp() != nil || !matchesHost(httpScaledObject.Spec.Hosts, host) {
+			continue
+		}
+		prefix := matchingPathPrefix(httpScaledObject.Spec.PathPrefixes, path)
+		if prefix > longestPrefix {
+			route = httpScaledObject
+			longestPrefix = prefix
+		}
+	}
+	return route
+}
+
+func matchesHost(hosts []string, host string) bool {
+	for _, h := range hosts {
+		if normalizeHost(h) == host {
+			return true
+		}
+	}
+	return false
+}
+
+// matchingPathPrefix returns the length of the longest prefix matching the path, 0 if no prefixes are given
+// and -1 if none of them matches
+func matchingPathPrefix(prefixes []string, path string) int {
+	if len(prefixes) == 0 {
+		return 0
+	}
+	longest := -1
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) && len(prefix) > longest {
+			longest = len(prefix)
+		}
+	}
+	return longest
+}
+
+// normalizeHost strips the port and the case of the host
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(host)
+}
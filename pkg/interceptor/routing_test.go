@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interceptor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	httpv1alpha1 "github.com/kedacore/keda/v2/apis/http/v1alpha1"
+)
+
+func newHTTPScaledObject(name string, hosts []string, pathPrefixes []string) httpv1alpha1.HTTPScaledObject {
+	return httpv1alpha1.HTTPScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: httpv1alpha1.HTTPScaledObjectSpec{
+			Hosts:          hosts,
+			PathPrefixes:   pathPrefixes,
+			ScaleTargetRef: httpv1alpha1.HTTPScaleTargetRef{Name: name, Service: name, Port: 8080},
+		},
+	}
+}
+
+func TestFindRoute(t *testing.T) {
+	httpScaledObjects := []httpv1alpha1.HTTPScaledObject{
+		newHTTPScaledObject("web", []string{"example.com", "www.example.com"}, nil),
+		newHTTPScaledObject("api", []string{"example.com"}, []string{"/api"}),
+		newHTTPScaledObject("api-v2", []string{"example.com"}, []string{"/api/v2", "/v2"}),
+	}
+
+	cases := []struct {
+		host     string
+		path     string
+		expected string
+	}{
+		{"example.com", "/", "web"},
+		{"Example.com:8080", "/index.html", "web"},
+		{"www.example.com", "/api", "web"},
+		{"example.com", "/api/users", "api"},
+		{"example.com", "/api/v2/users", "api-v2"},
+		{"example.com", "/v2", "api-v2"},
+		{"other.com", "/", ""},
+	}
+	for _, c := range cases {
+		route := FindRoute(httpScaledObjects, c.host, c.path)
+		if c.expected == "" {
+			assert.Nil(t, route, c.host+c.path)
+			continue
+		}
+		if assert.NotNil(t, route, c.host+c.path) {
+			assert.Equal(t, c.expected, route.Name, c.host+c.path)
+		}
+	}
+}
+
+func TestFindRouteSkipsDeleted(t *testing.T) {
+	httpScaledObject := newHTTPScaledObject("web", []string{"example.com"}, nil)
+	now := metav1.Now()
+	httpScaledObject.DeletionTimestamp = &now
+
+	assert.Nil(t, FindRoute([]httpv1alpha1.HTTPScaledObject{httpScaledObject}, "example.com", "/"))
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups="",namespace=keda,resources=pods,verbs=patch
+
+// LeaderPodLabel is set on the pod of the elected KEDA Operator, the keda-operator Service selects it, so KEDA Metrics
+// Server is connected to the Metrics Service of the replica running scale loops when multiple replicas are deployed
+const LeaderPodLabel = "keda.sh/operator-leader"
+
+// leaderPodLabelRemoveTimeout is how long the label is being removed once the pod stops leading
+const leaderPodLabelRemoveTimeout = 3 * time.Second
+
+var leaderLog = logf.Log.WithName("leader_pod_label")
+
+// LeaderPodLabeler sets LeaderPodLabel on the pod while it's the leader, it implements manager.LeaderElectionRunnable
+type LeaderPodLabeler struct {
+	client    client.Client
+	namespace string
+	name      string
+}
+
+// NewLeaderPodLabeler creates a new LeaderPodLabeler of the pod with the name in the namespace
+func NewLeaderPodLabeler(client client.Client, namespace, name string) *LeaderPodLabeler {
+	return &LeaderPodLabeler{client: client, namespace: namespace, name: name}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, the label is set once the pod is elected
+func (l *LeaderPodLabeler) NeedLeaderElection() bool {
+	return true
+}
+
+// Start sets the label and removes it once the pod stops leading, errors are only logged,
+// the replica keeps scaling even if its pod can't be labeled
+func (l *LeaderPodLabeler) Start(ctx context.Context) error {
+	if err := l.patchLabel(ctx, "true"); err != nil {
+		leaderLog.Error(err, "error setting leader label on pod", "label", LeaderPodLabel, "pod", l.name)
+	} else {
+		leaderLog.Info("Set leader label on pod", "label", LeaderPodLabel, "pod", l.name)
+	}
+
+	<-ctx.Done()
+	// the manager context is already canceled, the label is removed before the leader lease is released
+	removeCtx, cancel := context.WithTimeout(context.Background(), leaderPodLabelRemoveTimeout)
+	defer cancel()
+	if err := l.RemoveLabel(removeCtx); err != nil {
+		leaderLog.Error(err, "error removing leader label from pod", "label", LeaderPodLabel, "pod", l.name)
+	}
+	return nil
+}
+
+// RemoveLabel removes the label from the pod, it's called on startup too, the label can be left on the pod
+// by a previous run of the container which didn't stop cleanly
+func (l *LeaderPodLabeler) RemoveLabel(ctx context.Context) error {
+	return l.patchLabel(ctx, nil)
+}
+
+func (l *LeaderPodLabeler) patchLabel(ctx context.Context, value interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{LeaderPodLabel: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: l.name, Namespace: l.namespace}}
+	return l.client.Patch(ctx, pod, client.RawPatch(types.MergePatchType, patch))
+}
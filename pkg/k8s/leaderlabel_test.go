@@ -0,0 +1,59 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLeaderPodLabeler(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "keda-operator-1", Namespace: "keda", Labels: map[string]string{"app": "keda-operator"}}}
+	client := fake.NewClientBuilder().WithObjects(pod).Build()
+	labeler := NewLeaderPodLabeler(client, "keda", "keda-operator-1")
+	assert.True(t, labeler.NeedLeaderElection())
+
+	getLabels := func() map[string]string {
+		current := &corev1.Pod{}
+		require.NoError(t, client.Get(context.Background(), types.NamespacedName{Name: "keda-operator-1", Namespace: "keda"}, current))
+		return current.Labels
+	}
+
+	ctx, stopLeading := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		assert.NoError(t, labeler.Start(ctx))
+		close(stopped)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return getLabels()[LeaderPodLabel] == "true"
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "keda-operator", getLabels()["app"])
+
+	stopLeading()
+	<-stopped
+	assert.Equal(t, map[string]string{"app": "keda-operator"}, getLabels())
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"fmt"
+	"os"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// WatchNamespaceEnvVar is the comma separated list of namespaces watched by KEDA, it's overridden by --namespace flag
+const WatchNamespaceEnvVar = "WATCH_NAMESPACE"
+
+// ResolveWatchNamespace returns the comma separated list of namespaces to watch, the value of --namespace flag
+// is used if it's set, otherwise WATCH_NAMESPACE must be set, empty value means all namespaces are watched
+func ResolveWatchNamespace(flagValue string, flagSet bool) (string, error) {
+	if flagSet {
+		return flagValue, nil
+	}
+	ns, found := os.LookupEnv(WatchNamespaceEnvVar)
+	if !found {
+		return "", fmt.Errorf("%s or --namespace must be set", WatchNamespaceEnvVar)
+	}
+	return ns, nil
+}
+
+// ApplyWatchNamespace restricts the manager to the namespaces in the comma separated list,
+// with multiple namespaces only namespace-scoped Roles in these namespaces are needed for namespaced resources
+func ApplyWatchNamespace(options *ctrl.Options, watchNamespace string) {
+	namespaces := kedautil.ParseCommaSeparatedList(watchNamespace)
+	switch len(namespaces) {
+	case 0:
+		options.Namespace = ""
+	case 1:
+		options.Namespace = namespaces[0]
+	default:
+		options.Namespace = ""
+		options.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestApplyWatchNamespace(t *testing.T) {
+	tests := []struct {
+		watchNamespace     string
+		expectedNamespace  string
+		expectedMultiCache bool
+	}{
+		{"", "", false},
+		{"keda", "keda", false},
+		{" keda, ", "keda", false},
+		{"team-a,team-b", "", true},
+	}
+
+	for _, test := range tests {
+		options := ctrl.Options{}
+		ApplyWatchNamespace(&options, test.watchNamespace)
+		assert.Equal(t, test.expectedNamespace, options.Namespace, test.watchNamespace)
+		assert.Equal(t, test.expectedMultiCache, options.NewCache != nil, test.watchNamespace)
+	}
+}
+
+func TestResolveWatchNamespace(t *testing.T) {
+	t.Setenv(WatchNamespaceEnvVar, "team-a,team-b")
+
+	namespace, err := ResolveWatchNamespace("", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "team-a,team-b", namespace)
+
+	namespace, err = ResolveWatchNamespace("team-c", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "team-c", namespace)
+
+	// all namespaces are watched if the flag is set to empty value
+	namespace, err = ResolveWatchNamespace("", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "", namespace)
+
+	assert.NoError(t, os.Unsetenv(WatchNamespaceEnvVar))
+	_, err = ResolveWatchNamespace("", false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging creates the zap loggers of KEDA components. Logs are JSON encoded by default so they can be parsed
+// by log pipelines, the console encoder is meant for development. Besides the zap flags of controller-runtime
+// (--zap-log-level, --zap-encoder, --zap-devel, --zap-stacktrace-level and --zap-time-encoding), the level of named
+// loggers and their descendants can be overridden, eg. --zap-log-level-overrides=scalers_cache=debug,eventemitter=error
+package logging
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	ctrlzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// Options are the options of the logger
+type Options struct {
+	ctrlzap.Options
+	// LevelOverrides are the levels of named loggers by name, the level of the logger with the longest matching name applies
+	LevelOverrides map[string]zapcore.Level
+}
+
+// BindFlags binds the zap flags of controller-runtime and --zap-log-level-overrides to the options
+func (o *Options) BindFlags(fs *flag.FlagSet) {
+	o.Options.BindFlags(fs)
+	fs.Var(&levelOverridesFlag{overrides: &o.LevelOverrides}, "zap-log-level-overrides",
+		"Comma separated list of levels of named loggers and their descendants, eg. 'scalers_cache=debug,eventemitter=error'. "+
+			"Levels are one of 'debug', 'info', 'error' or any integer value > 0 like --zap-log-level")
+}
+
+// New returns a logger configured by the options
+func New(o *Options) logr.Logger {
+	opts := o.Options
+	if len(o.LevelOverrides) > 0 {
+		defaultLevel := opts.Level
+		if defaultLevel == nil {
+			defaultLevel = zapcore.InfoLevel
+			if opts.Development {
+				defaultLevel = zapcore.DebugLevel
+			}
+		}
+
+		// the core writes entries of all levels enabled by the default level or any override,
+		// the wrapping core drops the entries below the level of their logger
+		lowest := zapcore.FatalLevel
+		for level := zapcore.Level(-127); level < zapcore.FatalLevel; level++ {
+			if defaultLevel.Enabled(level) {
+				lowest = level
+				break
+			}
+		}
+		overrides := make([]levelOverride, 0, len(o.LevelOverrides))
+		for name, level := range o.LevelOverrides {
+			overrides = append(overrides, levelOverride{name: name, level: level})
+			if level < lowest {
+				lowest = level
+			}
+		}
+		// longer names are more specific
+		sort.Slice(overrides, func(i, j int) bool { return len(overrides[i].name) > len(overrides[j].name) })
+
+		opts.Level = lowest
+		opts.ZapOpts = append(append([]zap.Option{}, opts.ZapOpts...), zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &levelOverrideCore{Core: core, defaultLevel: defaultLevel, overrides: overrides}
+		}))
+	}
+	return ctrlzap.New(ctrlzap.UseFlagOptions(&opts))
+}
+
+type levelOverride struct {
+	name  string
+	level zapcore.Level
+}
+
+// levelOverrideCore drops the entries below the level of their logger
+type levelOverrideCore struct {
+	zapcore.Core
+	defaultLevel zapcore.LevelEnabler
+	overrides    []levelOverride
+}
+
+func (c *levelOverrideCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelOverrideCore{Core: c.Core.With(fields), defaultLevel: c.defaultLevel, overrides: c.overrides}
+}
+
+func (c *levelOverrideCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levelOf(entry.LoggerName).Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// levelOf returns the level of the logger, names of descendants of a logger are prefixed by its name and a dot
+func (c *levelOverrideCore) levelOf(loggerName string) zapcore.LevelEnabler {
+	for _, override := range c.overrides {
+		if loggerName == override.name || strings.HasPrefix(loggerName, override.name+".") {
+			return override.level
+		}
+	}
+	return c.defaultLevel
+}
+
+// levelOverridesFlag parses comma separated name=level pairs
+type levelOverridesFlag struct {
+	overrides *map[string]zapcore.Level
+	value     string
+}
+
+var _ flag.Value = &levelOverridesFlag{}
+
+func (f *levelOverridesFlag) Set(value string) error {
+	overrides := map[string]zapcore.Level{}
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, levelValue, found := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return fmt.Errorf("invalid log level override %q, expected name=level", pair)
+		}
+		level, err := parseLevel(strings.TrimSpace(levelValue))
+		if err != nil {
+			return err
+		}
+		overrides[name] = level
+	}
+	*f.overrides = overrides
+	f.value = value
+	return nil
+}
+
+func (f *levelOverridesFlag) String() string {
+	return f.value
+}
+
+func (f *levelOverridesFlag) Type() string {
+	return "levelOverrides"
+}
+
+// parseLevel parses levels like --zap-log-level, an integer value n > 0 is the debug level n of logr
+func parseLevel(value string) (zapcore.Level, error) {
+	switch strings.ToLower(value) {
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "info":
+		return zapcore.InfoLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	}
+	level, err := strconv.Atoi(value)
+	if err != nil || level <= 0 || level > 127 {
+		return 0, fmt.Errorf("invalid log level %q", value)
+	}
+	return zapcore.Level(-level), nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// logMessages returns the messages of the JSON encoded logs
+func logMessages(t *testing.T, output *bytes.Buffer) []string {
+	messages := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		entry := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		messages = append(messages, entry["msg"].(string))
+	}
+	return messages
+}
+
+func TestLevelOverrides(t *testing.T) {
+	output := &bytes.Buffer{}
+	opts := Options{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	opts.BindFlags(fs)
+	require.NoError(t, fs.Parse([]string{"--zap-log-level=info", "--zap-log-level-overrides=scalers_cache=debug, scalers_cache.kafka=error,eventemitter=2"}))
+	opts.DestWriter = output
+
+	logger := New(&opts)
+	logger.V(1).Info("default debug")
+	logger.Info("default info")
+	logger.WithName("scalers_cache").V(1).Info("scalers_cache debug")
+	logger.WithName("scalers_cache").V(2).Info("scalers_cache debug 2")
+	logger.WithName("scalers_cache").WithName("rabbitmq").V(1).Info("descendant debug")
+	logger.WithName("scalers_cache").WithName("kafka").Info("more specific info")
+	logger.WithName("scalers_cache").WithName("kafka").Error(nil, "more specific error")
+	logger.WithName("scalers_cache_other").V(1).Info("other debug")
+	logger.WithName("eventemitter").WithValues("key", "value").V(2).Info("eventemitter debug 2")
+
+	assert.Equal(t, []string{
+		"default info",
+		"scalers_cache debug",
+		"descendant debug",
+		"more specific error",
+		"eventemitter debug 2",
+	}, logMessages(t, output))
+}
+
+func TestConsoleEncoder(t *testing.T) {
+	output := &bytes.Buffer{}
+	opts := Options{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	opts.BindFlags(fs)
+	require.NoError(t, fs.Parse([]string{"--zap-encoder=console"}))
+	opts.DestWriter = output
+
+	New(&opts).Info("console", "key", "value")
+	assert.Contains(t, output.String(), "console\t{\"key\": \"value\"}")
+}
+
+func TestLevelOverridesFlag(t *testing.T) {
+	type testCase struct {
+		value    string
+		expected map[string]zapcore.Level
+		isError  bool
+	}
+	testCases := []testCase{
+		{value: "a=debug,b=info,c=error,d=3", expected: map[string]zapcore.Level{"a": zapcore.DebugLevel, "b": zapcore.InfoLevel, "c": zapcore.ErrorLevel, "d": zapcore.Level(-3)}},
+		{value: "", expected: map[string]zapcore.Level{}},
+		{value: "a", isError: true},
+		{value: "=debug", isError: true},
+		{value: "a=verbose", isError: true},
+		{value: "a=0", isError: true},
+	}
+
+	for _, tc := range testCases {
+		overrides := map[string]zapcore.Level{}
+		err := (&levelOverridesFlag{overrides: &overrides}).Set(tc.value)
+		if tc.isError {
+			assert.Error(t, err, tc.value)
+			continue
+		}
+		assert.NoError(t, err, tc.value)
+		assert.Equal(t, tc.expected, overrides, tc.value)
+	}
+}
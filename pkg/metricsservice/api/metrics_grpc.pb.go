@@ -34,7 +34,8 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	MetricsService_GetMetrics_FullMethodName = "/api.MetricsService/GetMetrics"
+	MetricsService_GetMetrics_FullMethodName    = "/api.MetricsService/GetMetrics"
+	MetricsService_CheckTriggers_FullMethodName = "/api.MetricsService/CheckTriggers"
 )
 
 // MetricsServiceClient is the client API for MetricsService service.
@@ -42,6 +43,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MetricsServiceClient interface {
 	GetMetrics(ctx context.Context, in *ScaledObjectRef, opts ...grpc.CallOption) (*Response, error)
+	// CheckTriggers builds the scalers of the triggers of a ScaledObject, which doesn't need to exist, and checks them once
+	CheckTriggers(ctx context.Context, in *CheckTriggersRequest, opts ...grpc.CallOption) (*CheckTriggersResponse, error)
 }
 
 type metricsServiceClient struct {
@@ -61,11 +64,22 @@ func (c *metricsServiceClient) GetMetrics(ctx context.Context, in *ScaledObjectR
 	return out, nil
 }
 
+func (c *metricsServiceClient) CheckTriggers(ctx context.Context, in *CheckTriggersRequest, opts ...grpc.CallOption) (*CheckTriggersResponse, error) {
+	out := new(CheckTriggersResponse)
+	err := c.cc.Invoke(ctx, MetricsService_CheckTriggers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MetricsServiceServer is the server API for MetricsService service.
 // All implementations must embed UnimplementedMetricsServiceServer
 // for forward compatibility
 type MetricsServiceServer interface {
 	GetMetrics(context.Context, *ScaledObjectRef) (*Response, error)
+	// CheckTriggers builds the scalers of the triggers of a ScaledObject, which doesn't need to exist, and checks them once
+	CheckTriggers(context.Context, *CheckTriggersRequest) (*CheckTriggersResponse, error)
 	mustEmbedUnimplementedMetricsServiceServer()
 }
 
@@ -76,6 +90,9 @@ type UnimplementedMetricsServiceServer struct {
 func (UnimplementedMetricsServiceServer) GetMetrics(context.Context, *ScaledObjectRef) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
 }
+func (UnimplementedMetricsServiceServer) CheckTriggers(context.Context, *CheckTriggersRequest) (*CheckTriggersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckTriggers not implemented")
+}
 func (UnimplementedMetricsServiceServer) mustEmbedUnimplementedMetricsServiceServer() {}
 
 // UnsafeMetricsServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -107,6 +124,24 @@ func _MetricsService_GetMetrics_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetricsService_CheckTriggers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckTriggersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricsServiceServer).CheckTriggers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricsService_CheckTriggers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricsServiceServer).CheckTriggers(ctx, req.(*CheckTriggersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MetricsService_ServiceDesc is the grpc.ServiceDesc for MetricsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -118,6 +153,10 @@ var MetricsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMetrics",
 			Handler:    _MetricsService_GetMetrics_Handler,
 		},
+		{
+			MethodName: "CheckTriggers",
+			Handler:    _MetricsService_CheckTriggers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "metrics.proto",
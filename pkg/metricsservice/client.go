@@ -18,17 +18,23 @@ package metricsservice
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	"k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
 	"github.com/kedacore/keda/v2/pkg/metricsservice/utils"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 type GrpcClient struct {
@@ -68,6 +74,9 @@ func NewGrpcClient(url, certDir string) (*GrpcClient, error) {
 func (c *GrpcClient) GetMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string) (*external_metrics.ExternalMetricValueList, *api.PromMetricsMsg, error) {
 	// nosemgrep: trailofbits.go.invalid-usage-of-modified-variable.invalid-usage-of-modified-variable
 	response, err := c.client.GetMetrics(ctx, &api.ScaledObjectRef{Name: scaledObjectName, Namespace: scaledObjectNamespace, MetricName: metricName})
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil, response.GetPromMetrics(), &scaling.MetricUnavailableError{MetricName: metricName, Reason: status.Convert(err).Message()}
+	}
 	if err != nil {
 		// in certain cases we would like to get Prometheus metrics even if there's an error
 		// so we can expose information about the error in the client
@@ -85,23 +94,33 @@ func (c *GrpcClient) GetMetrics(ctx context.Context, scaledObjectName, scaledObj
 	return extMetrics, response.GetPromMetrics(), nil
 }
 
+// CheckTriggers checks the triggers of the ScaledObject once in KEDA Operator, the ScaledObject doesn't need to exist
+func (c *GrpcClient) CheckTriggers(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) ([]*api.TriggerCheck, error) {
+	content, err := json.Marshal(scaledObject)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding ScaledObject: %w", err)
+	}
+	response, err := c.client.CheckTriggers(ctx, &api.CheckTriggersRequest{ScaledObject: content})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetTriggerChecks(), nil
+}
+
 // WaitForConnectionReady waits for gRPC connection to be ready
 // returns true if the connection was successful, false if we hit a timeut from context
 func (c *GrpcClient) WaitForConnectionReady(ctx context.Context, logger logr.Logger) bool {
 	currentState := c.connection.GetState()
 	if currentState != connectivity.Ready {
 		logger.Info("Waiting for establishing a gRPC connection to KEDA Metrics Server")
+		backoff := &kedautil.Backoff{InitialInterval: 500 * time.Millisecond, MaxInterval: 5 * time.Second, Jitter: 0.2}
 		for {
-			select {
-			case <-ctx.Done():
+			c.connection.Connect()
+			if err := backoff.Wait(ctx); err != nil {
 				return false
-			default:
-				c.connection.Connect()
-				time.Sleep(500 * time.Millisecond)
-				currentState := c.connection.GetState()
-				if currentState == connectivity.Ready {
-					return true
-				}
+			}
+			if c.connection.GetState() == connectivity.Ready {
+				return true
 			}
 		}
 	}
@@ -18,13 +18,20 @@ package metricsservice
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
+	"sync/atomic"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
 	"k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
 	"github.com/kedacore/keda/v2/pkg/metricsservice/utils"
 	"github.com/kedacore/keda/v2/pkg/scaling"
@@ -38,15 +45,45 @@ type GrpcServer struct {
 	certDir       string
 	certsReady    chan struct{}
 	scalerHandler *scaling.ScaleHandler
+	connections   *connectionsCounter
 	api.UnimplementedMetricsServiceServer
 }
 
+// connectionsCounter is a gRPC stats.Handler tracking the number of open client connections
+type connectionsCounter struct {
+	active int64
+}
+
+func (c *connectionsCounter) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (c *connectionsCounter) HandleRPC(context.Context, stats.RPCStats) {}
+
+func (c *connectionsCounter) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (c *connectionsCounter) HandleConn(_ context.Context, s stats.ConnStats) {
+	switch s.(type) {
+	case *stats.ConnBegin:
+		atomic.AddInt64(&c.active, 1)
+	case *stats.ConnEnd:
+		atomic.AddInt64(&c.active, -1)
+	}
+}
+
 // GetMetrics returns metrics values in form of ExternalMetricValueList for specified ScaledObject reference
 func (s *GrpcServer) GetMetrics(ctx context.Context, in *api.ScaledObjectRef) (*api.Response, error) {
 	response := api.Response{}
 	v1beta1ExtMetrics := &v1beta1.ExternalMetricValueList{}
 	extMetrics, exportedMetrics, err := (*s.scalerHandler).GetScaledObjectMetrics(ctx, in.Name, in.Namespace, in.MetricName)
 	response.PromMetrics = exportedMetrics
+	var unavailableErr *scaling.MetricUnavailableError
+	if errors.As(err, &unavailableErr) {
+		// not Unavailable code, which would be retried by the client and the failing scaler queried again
+		return &response, status.Error(codes.FailedPrecondition, unavailableErr.Reason)
+	}
 	if err != nil {
 		return &response, fmt.Errorf("error when getting metric values %w", err)
 	}
@@ -62,6 +99,37 @@ func (s *GrpcServer) GetMetrics(ctx context.Context, in *api.ScaledObjectRef) (*
 	return &response, nil
 }
 
+// CheckTriggers checks the triggers of the ScaledObject in the request once, the ScaledObject doesn't need to exist
+func (s *GrpcServer) CheckTriggers(ctx context.Context, in *api.CheckTriggersRequest) (*api.CheckTriggersResponse, error) {
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	if err := json.Unmarshal(in.ScaledObject, scaledObject); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error decoding ScaledObject: %v", err)
+	}
+
+	checks, err := (*s.scalerHandler).CheckTriggers(ctx, scaledObject)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	log.V(1).WithValues("scaledObjectName", scaledObject.Name, "scaledObjectNamespace", scaledObject.Namespace).Info("Checked triggers")
+	response := &api.CheckTriggersResponse{}
+	for _, check := range checks {
+		triggerCheck := &api.TriggerCheck{
+			TriggerIndex: int32(check.TriggerIndex),
+			TriggerName:  check.TriggerName,
+			TriggerType:  check.TriggerType,
+			MetricName:   check.MetricName,
+			IsActive:     check.IsActive,
+			MetricValues: check.MetricValues,
+		}
+		if check.Err != nil {
+			triggerCheck.Error = check.Err.Error()
+		}
+		response.TriggerChecks = append(response.TriggerChecks, triggerCheck)
+	}
+	return response, nil
+}
+
 // NewGrpcServer creates a new instance of GrpcServer
 func NewGrpcServer(scaleHandler *scaling.ScaleHandler, address, certDir string, certsReady chan struct{}) GrpcServer {
 	return GrpcServer{
@@ -69,9 +137,15 @@ func NewGrpcServer(scaleHandler *scaling.ScaleHandler, address, certDir string,
 		scalerHandler: scaleHandler,
 		certDir:       certDir,
 		certsReady:    certsReady,
+		connections:   &connectionsCounter{},
 	}
 }
 
+// IsClientConnected returns true if there is at least one client (KEDA Metrics Server) connected to this server
+func (s *GrpcServer) IsClientConnected() bool {
+	return atomic.LoadInt64(&s.connections.active) > 0
+}
+
 func (s *GrpcServer) startServer() error {
 	lis, err := net.Listen("tcp", s.address)
 	if err != nil {
@@ -94,7 +168,7 @@ func (s *GrpcServer) Start(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
-		s.server = grpc.NewServer(grpc.Creds(creds))
+		s.server = grpc.NewServer(grpc.Creds(creds), grpc.StatsHandler(s.connections))
 		api.RegisterMetricsServiceServer(s.server, s)
 	}
 
@@ -35,6 +35,30 @@ func (m *MockScaleExecutor) EXPECT() *MockScaleExecutorMockRecorder {
 	return m.recorder
 }
 
+// RecordDesiredReplicas mocks base method.
+func (m *MockScaleExecutor) RecordDesiredReplicas(ctx context.Context, scaledObject *v1alpha1.ScaledObject, isActive, isError bool, metricReplicaCount int32) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordDesiredReplicas", ctx, scaledObject, isActive, isError, metricReplicaCount)
+}
+
+// RecordDesiredReplicas indicates an expected call of RecordDesiredReplicas.
+func (mr *MockScaleExecutorMockRecorder) RecordDesiredReplicas(ctx, scaledObject, isActive, isError, metricReplicaCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordDesiredReplicas", reflect.TypeOf((*MockScaleExecutor)(nil).RecordDesiredReplicas), ctx, scaledObject, isActive, isError, metricReplicaCount)
+}
+
+// RequestDryRunScale mocks base method.
+func (m *MockScaleExecutor) RequestDryRunScale(ctx context.Context, scaledObject *v1alpha1.ScaledObject, isActive, isError bool, metricReplicaCount int32) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RequestDryRunScale", ctx, scaledObject, isActive, isError, metricReplicaCount)
+}
+
+// RequestDryRunScale indicates an expected call of RequestDryRunScale.
+func (mr *MockScaleExecutorMockRecorder) RequestDryRunScale(ctx, scaledObject, isActive, isError, metricReplicaCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDryRunScale", reflect.TypeOf((*MockScaleExecutor)(nil).RequestDryRunScale), ctx, scaledObject, isActive, isError, metricReplicaCount)
+}
+
 // RequestJobScale mocks base method.
 func (m *MockScaleExecutor) RequestJobScale(ctx context.Context, scaledJob *v1alpha1.ScaledJob, isActive bool, scaleTo, maxScale int64) {
 	m.ctrl.T.Helper()
@@ -48,13 +72,25 @@ func (mr *MockScaleExecutorMockRecorder) RequestJobScale(ctx, scaledJob, isActiv
 }
 
 // RequestScale mocks base method.
-func (m *MockScaleExecutor) RequestScale(ctx context.Context, scaledObject *v1alpha1.ScaledObject, isActive, isError bool) {
+func (m *MockScaleExecutor) RequestScale(ctx context.Context, scaledObject *v1alpha1.ScaledObject, isActive, isError, isPoll bool, metricValues map[string]float64) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RequestScale", ctx, scaledObject, isActive, isError)
+	m.ctrl.Call(m, "RequestScale", ctx, scaledObject, isActive, isError, isPoll, metricValues)
 }
 
 // RequestScale indicates an expected call of RequestScale.
-func (mr *MockScaleExecutorMockRecorder) RequestScale(ctx, scaledObject, isActive, isError interface{}) *gomock.Call {
+func (mr *MockScaleExecutorMockRecorder) RequestScale(ctx, scaledObject, isActive, isError, isPoll, metricValues interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestScale", reflect.TypeOf((*MockScaleExecutor)(nil).RequestScale), ctx, scaledObject, isActive, isError, isPoll, metricValues)
+}
+
+// ResetInactiveChecks mocks base method.
+func (m *MockScaleExecutor) ResetInactiveChecks(scaledObject *v1alpha1.ScaledObject) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetInactiveChecks", scaledObject)
+}
+
+// ResetInactiveChecks indicates an expected call of ResetInactiveChecks.
+func (mr *MockScaleExecutorMockRecorder) ResetInactiveChecks(scaledObject interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestScale", reflect.TypeOf((*MockScaleExecutor)(nil).RequestScale), ctx, scaledObject, isActive, isError)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetInactiveChecks", reflect.TypeOf((*MockScaleExecutor)(nil).ResetInactiveChecks), scaledObject)
 }
@@ -9,7 +9,9 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	v1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	api "github.com/kedacore/keda/v2/pkg/metricsservice/api"
+	scaling "github.com/kedacore/keda/v2/pkg/scaling"
 	cache "github.com/kedacore/keda/v2/pkg/scaling/cache"
 	external_metrics "k8s.io/metrics/pkg/apis/external_metrics"
 )
@@ -37,6 +39,21 @@ func (m *MockScaleHandler) EXPECT() *MockScaleHandlerMockRecorder {
 	return m.recorder
 }
 
+// CheckTriggers mocks base method.
+func (m *MockScaleHandler) CheckTriggers(ctx context.Context, scaledObject *v1alpha1.ScaledObject) ([]scaling.TriggerCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckTriggers", ctx, scaledObject)
+	ret0, _ := ret[0].([]scaling.TriggerCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckTriggers indicates an expected call of CheckTriggers.
+func (mr *MockScaleHandlerMockRecorder) CheckTriggers(ctx, scaledObject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTriggers", reflect.TypeOf((*MockScaleHandler)(nil).CheckTriggers), ctx, scaledObject)
+}
+
 // ClearScalersCache mocks base method.
 func (m *MockScaleHandler) ClearScalersCache(ctx context.Context, scalableObject interface{}) error {
 	m.ctrl.T.Helper()
@@ -65,6 +82,20 @@ func (mr *MockScaleHandlerMockRecorder) DeleteScalableObject(ctx, scalableObject
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScalableObject", reflect.TypeOf((*MockScaleHandler)(nil).DeleteScalableObject), ctx, scalableObject)
 }
 
+// GetScaledObjectMetricValues mocks base method.
+func (m *MockScaleHandler) GetScaledObjectMetricValues(namespace, name string) map[string]float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScaledObjectMetricValues", namespace, name)
+	ret0, _ := ret[0].(map[string]float64)
+	return ret0
+}
+
+// GetScaledObjectMetricValues indicates an expected call of GetScaledObjectMetricValues.
+func (mr *MockScaleHandlerMockRecorder) GetScaledObjectMetricValues(namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScaledObjectMetricValues", reflect.TypeOf((*MockScaleHandler)(nil).GetScaledObjectMetricValues), namespace, name)
+}
+
 // GetScaledObjectMetrics mocks base method.
 func (m *MockScaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string) (*external_metrics.ExternalMetricValueList, *api.PromMetricsMsg, error) {
 	m.ctrl.T.Helper()
@@ -109,3 +140,43 @@ func (mr *MockScaleHandlerMockRecorder) HandleScalableObject(ctx, scalableObject
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleScalableObject", reflect.TypeOf((*MockScaleHandler)(nil).HandleScalableObject), ctx, scalableObject)
 }
+
+// NeedLeaderElection mocks base method.
+func (m *MockScaleHandler) NeedLeaderElection() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedLeaderElection")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedLeaderElection indicates an expected call of NeedLeaderElection.
+func (mr *MockScaleHandlerMockRecorder) NeedLeaderElection() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedLeaderElection", reflect.TypeOf((*MockScaleHandler)(nil).NeedLeaderElection))
+}
+
+// RefreshScalersCachesForAuthentication mocks base method.
+func (m *MockScaleHandler) RefreshScalersCachesForAuthentication(ctx context.Context, authenticationKind, authenticationNamespace, authenticationName string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RefreshScalersCachesForAuthentication", ctx, authenticationKind, authenticationNamespace, authenticationName)
+}
+
+// RefreshScalersCachesForAuthentication indicates an expected call of RefreshScalersCachesForAuthentication.
+func (mr *MockScaleHandlerMockRecorder) RefreshScalersCachesForAuthentication(ctx, authenticationKind, authenticationNamespace, authenticationName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshScalersCachesForAuthentication", reflect.TypeOf((*MockScaleHandler)(nil).RefreshScalersCachesForAuthentication), ctx, authenticationKind, authenticationNamespace, authenticationName)
+}
+
+// Start mocks base method.
+func (m *MockScaleHandler) Start(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockScaleHandlerMockRecorder) Start(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockScaleHandler)(nil).Start), ctx)
+}
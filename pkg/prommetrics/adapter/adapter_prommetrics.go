@@ -17,14 +17,18 @@ limitations under the License.
 package adapter
 
 import (
-	"log"
 	"net/http"
+	"os"
 	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+var log = logf.Log.WithName("prometheus_server")
+
 var (
 	metricLabels      = []string{"namespace", "metric", "scaledObject", "scaler", "scalerIndex"}
 	scalerErrorsTotal = prometheus.NewCounterVec(
@@ -63,6 +67,16 @@ var (
 		},
 		[]string{"namespace", "scaledObject"},
 	)
+	requestLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "keda_metrics_adapter",
+			Subsystem: "request",
+			Name:      "duration_seconds",
+			Help:      "Latency of external metric requests of the HPA served by the metrics server",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"namespace", "scaledObject", "metric"},
+	)
 )
 
 // PrometheusMetricServer the type of MetricsServer
@@ -76,6 +90,7 @@ func init() {
 	registry.MustRegister(scalerMetricsValue)
 	registry.MustRegister(scalerErrors)
 	registry.MustRegister(scaledObjectErrors)
+	registry.MustRegister(requestLatency)
 }
 
 // NewServer creates a new http serving instance of prometheus metrics
@@ -84,20 +99,23 @@ func (metricsServer PrometheusMetricServer) NewServer(address string, pattern st
 		w.WriteHeader(http.StatusOK)
 		_, err := w.Write([]byte("OK"))
 		if err != nil {
-			log.Fatalf("Unable to write to serve custom metrics: %v", err)
+			log.Error(err, "Unable to write to serve custom metrics")
 		}
 	})
-	log.Printf("Starting metrics server at %v", address)
+	log.Info("Starting metrics server", "address", address)
 	http.Handle(pattern, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
 
 	// initialize the total error metric
 	_, errscaler := scalerErrorsTotal.GetMetricWith(prometheus.Labels{})
 	if errscaler != nil {
-		log.Fatalf("Unable to initialize total error metrics as : %v", errscaler)
+		log.Error(errscaler, "Unable to initialize total error metrics")
+		os.Exit(1)
 	}
 
 	// nosemgrep: go.lang.security.audit.net.use-tls.use-tls
-	log.Fatal(http.ListenAndServe(address, nil))
+	err := http.ListenAndServe(address, nil)
+	log.Error(err, "Metrics server stopped")
+	os.Exit(1)
 }
 
 // RecordHPAScalerMetric create a measurement of the external metric used by the HPA
@@ -116,7 +134,7 @@ func (metricsServer PrometheusMetricServer) RecordHPAScalerError(namespace strin
 	// initialize metric with 0 if not already set
 	_, errscaler := scalerErrors.GetMetricWith(getLabels(namespace, scaledObject, scaler, scalerIndex, metric))
 	if errscaler != nil {
-		log.Fatalf("Unable to write to serve custom metrics: %v", errscaler)
+		log.Error(errscaler, "Unable to write to serve custom metrics")
 	}
 }
 
@@ -130,11 +148,16 @@ func (metricsServer PrometheusMetricServer) RecordScaledObjectError(namespace st
 	// initialize metric with 0 if not already set
 	_, errscaledobject := scaledObjectErrors.GetMetricWith(labels)
 	if errscaledobject != nil {
-		log.Fatalf("Unable to write to serve custom metrics: %v", errscaledobject)
+		log.Error(errscaledobject, "Unable to write to serve custom metrics")
 		return
 	}
 }
 
+// RecordRequestLatency measures the latency of an external metric request of the HPA
+func (metricsServer PrometheusMetricServer) RecordRequestLatency(namespace string, scaledObject string, metric string, latency time.Duration) {
+	requestLatency.With(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject, "metric": metric}).Observe(latency.Seconds())
+}
+
 func getLabels(namespace string, scaledObject string, scaler string, scalerIndex int, metric string) prometheus.Labels {
 	return prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject, "scaler": scaler, "scalerIndex": strconv.Itoa(scalerIndex), "metric": metric}
 }
@@ -0,0 +1,51 @@
+package adapter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordHPAScalerError(t *testing.T) {
+	metricsServer := PrometheusMetricServer{}
+	labels := getLabels("test", "consumer", "kafkaScaler", 0, "s0-kafka-topic")
+
+	metricsServer.RecordHPAScalerError("test", "consumer", "kafkaScaler", 0, "s0-kafka-topic", nil)
+	assert.Equal(t, 0.0, testutil.ToFloat64(scalerErrors.With(labels)))
+
+	metricsServer.RecordHPAScalerError("test", "consumer", "kafkaScaler", 0, "s0-kafka-topic", errors.New("dial tcp: lookup kafka: no such host"))
+	assert.Equal(t, 1.0, testutil.ToFloat64(scalerErrors.With(labels)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(scaledObjectErrors.With(prometheus.Labels{"namespace": "test", "scaledObject": "consumer"})))
+}
+
+func TestRecordRequestLatency(t *testing.T) {
+	metricsServer := PrometheusMetricServer{}
+
+	metricsServer.RecordRequestLatency("test", "consumer", "s0-kafka-topic", 100*time.Millisecond)
+	metricsServer.RecordRequestLatency("test", "consumer", "s0-kafka-topic", 300*time.Millisecond)
+
+	expected := `
+		# HELP keda_metrics_adapter_request_duration_seconds Latency of external metric requests of the HPA served by the metrics server
+		# TYPE keda_metrics_adapter_request_duration_seconds histogram
+		keda_metrics_adapter_request_duration_seconds_bucket{metric="s0-kafka-topic",namespace="test",scaledObject="consumer",le="0.005"} 0
+		keda_metrics_adapter_request_duration_seconds_bucket{metric="s0-kafka-topic",namespace="test",scaledObject="consumer",le="0.01"} 0
+		keda_metrics_adapter_request_duration_seconds_bucket{metric="s0-kafka-topic",namespace="test",scaledObject="consumer",le="0.025"} 0
+		keda_metrics_adapter_request_duration_seconds_bucket{metric="s0-kafka-topic",namespace="test",scaledObject="consumer",le="0.05"} 0
+		keda_metrics_adapter_request_duration_seconds_bucket{metric="s0-kafka-topic",namespace="test",scaledObject="consumer",le="0.1"} 1
+		keda_metrics_adapter_request_duration_seconds_bucket{metric="s0-kafka-topic",namespace="test",scaledObject="consumer",le="0.25"} 1
+		keda_metrics_adapter_request_duration_seconds_bucket{metric="s0-kafka-topic",namespace="test",scaledObject="consumer",le="0.5"} 2
+		keda_metrics_adapter_request_duration_seconds_bucket{metric="s0-kafka-topic",namespace="test",scaledObject="consumer",le="1"} 2
+		keda_metrics_adapter_request_duration_seconds_bucket{metric="s0-kafka-topic",namespace="test",scaledObject="consumer",le="2.5"} 2
+		keda_metrics_adapter_request_duration_seconds_bucket{metric="s0-kafka-topic",namespace="test",scaledObject="consumer",le="5"} 2
+		keda_metrics_adapter_request_duration_seconds_bucket{metric="s0-kafka-topic",namespace="test",scaledObject="consumer",le="10"} 2
+		keda_metrics_adapter_request_duration_seconds_bucket{metric="s0-kafka-topic",namespace="test",scaledObject="consumer",le="+Inf"} 2
+		keda_metrics_adapter_request_duration_seconds_sum{metric="s0-kafka-topic",namespace="test",scaledObject="consumer"} 0.4
+		keda_metrics_adapter_request_duration_seconds_count{metric="s0-kafka-topic",namespace="test",scaledObject="consumer"} 2
+	`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "keda_metrics_adapter_request_duration_seconds"))
+}
@@ -18,14 +18,29 @@ package prommetrics
 
 import (
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers"
 )
 
 var log = logf.Log.WithName("prometheus_server")
 
+var (
+	scaledObjectStatuses     = map[string]string{}
+	scaledObjectStatusesLock = &sync.Mutex{}
+
+	// activeScalers holds the latest activity of scaler metrics, so their activations are counted once
+	activeScalers     = map[string]bool{}
+	activeScalersLock = &sync.Mutex{}
+)
+
 const (
 	ClusterTriggerAuthenticationResource = "cluster_trigger_authentication"
 	TriggerAuthenticationResource        = "trigger_authentication"
@@ -82,6 +97,15 @@ var (
 		},
 		metricLabels,
 	)
+	scalerErrorsByCategory = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaler",
+			Name:      "errors_by_category",
+			Help:      "Number of scaler errors by category (Auth, Network, Throttling, BadQuery or Internal)",
+		},
+		append(append([]string{}, metricLabels...), "category"),
+	)
 	scaledObjectErrors = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: DefaultPromMetricsNamespace,
@@ -92,6 +116,110 @@ var (
 		[]string{"namespace", "scaledObject"},
 	)
 
+	hpaLabels          = []string{"namespace", "scaledObject", "hpa"}
+	hpaCurrentReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "hpa",
+			Name:      "current_replicas",
+			Help:      "Current number of replicas managed by the HPA generated for a ScaledObject",
+		},
+		hpaLabels,
+	)
+	hpaDesiredReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "hpa",
+			Name:      "desired_replicas",
+			Help:      "Desired number of replicas as last calculated by the HPA generated for a ScaledObject",
+		},
+		hpaLabels,
+	)
+	hpaLastScaleTime = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "hpa",
+			Name:      "last_scale_timestamp_seconds",
+			Help:      "Unix timestamp of the last time the HPA generated for a ScaledObject scaled the number of replicas",
+		},
+		hpaLabels,
+	)
+
+	scalerActivations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaler",
+			Name:      "activations_total",
+			Help:      "Number of times a Scaler Metric became active",
+		},
+		metricLabels,
+	)
+	scaledObjectScaleToZero = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "scale_to_zero_total",
+			Help:      "Number of times the scale target of a ScaledObject was scaled to zero",
+		},
+		[]string{"namespace", "scaledObject"},
+	)
+	scaledObjectRecommendedReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "recommended_replicas",
+			Help:      "Number of replicas KEDA would scale the scale target of a ScaledObject in dry-run mode to",
+		},
+		[]string{"namespace", "scaledObject"},
+	)
+	scaledObjectDesiredReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "desired_replicas",
+			Help:      "Number of replicas KEDA and the HPA scale the scale target of a ScaledObject to for the latest metric values",
+		},
+		[]string{"namespace", "scaledObject"},
+	)
+	scaledObjectPendingActivation = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "pending_activation",
+			Help:      "Whether the activated scale target of a ScaledObject is waiting to become ready (1) or not (0)",
+		},
+		[]string{"namespace", "scaledObject"},
+	)
+	scaledObjectStatusTotals = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "status_totals",
+			Help:      "Number of ScaledObjects by status (NotReady, Paused, Fallback, Active or Inactive)",
+		},
+		[]string{"namespace", "status"},
+	)
+
+	reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "operator",
+			Name:      "reconcile_duration_seconds",
+			Help:      "Duration of reconciliations of KEDA resources",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"type", "result"},
+	)
+	internalErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "operator",
+			Name:      "internal_errors_total",
+			Help:      "Number of internal errors of KEDA Operator, eg. failed reconciliations or failed updates of scale targets",
+		},
+		[]string{"component"},
+	)
+
 	triggerTotalsGaugeVec = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: DefaultPromMetricsNamespace,
@@ -117,7 +245,19 @@ func init() {
 	metrics.Registry.MustRegister(scalerMetricsLatency)
 	metrics.Registry.MustRegister(scalerActive)
 	metrics.Registry.MustRegister(scalerErrors)
+	metrics.Registry.MustRegister(scalerErrorsByCategory)
 	metrics.Registry.MustRegister(scaledObjectErrors)
+	metrics.Registry.MustRegister(hpaCurrentReplicas)
+	metrics.Registry.MustRegister(hpaDesiredReplicas)
+	metrics.Registry.MustRegister(hpaLastScaleTime)
+	metrics.Registry.MustRegister(scalerActivations)
+	metrics.Registry.MustRegister(scaledObjectScaleToZero)
+	metrics.Registry.MustRegister(scaledObjectRecommendedReplicas)
+	metrics.Registry.MustRegister(scaledObjectDesiredReplicas)
+	metrics.Registry.MustRegister(scaledObjectPendingActivation)
+	metrics.Registry.MustRegister(scaledObjectStatusTotals)
+	metrics.Registry.MustRegister(reconcileDuration)
+	metrics.Registry.MustRegister(internalErrors)
 
 	metrics.Registry.MustRegister(triggerTotalsGaugeVec)
 	metrics.Registry.MustRegister(crdTotalsGaugeVec)
@@ -133,20 +273,31 @@ func RecordScalerLatency(namespace string, scaledObject string, scaler string, s
 	scalerMetricsLatency.With(getLabels(namespace, scaledObject, scaler, scalerIndex, metric)).Set(value)
 }
 
-// RecordScalerActive create a measurement of the activity of the scaler
+// RecordScalerActive create a measurement of the activity of the scaler, the activation is counted if the scaler wasn't active before
 func RecordScalerActive(namespace string, scaledObject string, scaler string, scalerIndex int, metric string, active bool) {
 	activeVal := 0
 	if active {
 		activeVal = 1
 	}
 
-	scalerActive.With(getLabels(namespace, scaledObject, scaler, scalerIndex, metric)).Set(float64(activeVal))
+	labels := getLabels(namespace, scaledObject, scaler, scalerIndex, metric)
+	key := namespace + "/" + scaledObject + "/" + scaler + "/" + strconv.Itoa(scalerIndex) + "/" + metric
+	activeScalersLock.Lock()
+	defer activeScalersLock.Unlock()
+	if active && !activeScalers[key] {
+		scalerActivations.With(labels).Inc()
+	}
+	activeScalers[key] = active
+	scalerActive.With(labels).Set(float64(activeVal))
 }
 
 // RecordScalerError counts the number of errors occurred in trying get an external metric used by the HPA
 func RecordScalerError(namespace string, scaledObject string, scaler string, scalerIndex int, metric string, err error) {
 	if err != nil {
 		scalerErrors.With(getLabels(namespace, scaledObject, scaler, scalerIndex, metric)).Inc()
+		categoryLabels := getLabels(namespace, scaledObject, scaler, scalerIndex, metric)
+		categoryLabels["category"] = string(scalers.GetScalerErrorCategory(err))
+		scalerErrorsByCategory.With(categoryLabels).Inc()
 		RecordScaledObjectError(namespace, scaledObject, err)
 		scalerErrorsTotal.With(prometheus.Labels{}).Inc()
 		return
@@ -173,6 +324,128 @@ func RecordScaledObjectError(namespace string, scaledObject string, err error) {
 	}
 }
 
+// RecordHPAStatus create a measurement of the replicas and last scale time of the HPA generated for the ScaledObject
+func RecordHPAStatus(namespace string, scaledObject string, hpa *autoscalingv2.HorizontalPodAutoscaler) {
+	labels := prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject, "hpa": hpa.Name}
+	hpaCurrentReplicas.With(labels).Set(float64(hpa.Status.CurrentReplicas))
+	hpaDesiredReplicas.With(labels).Set(float64(hpa.Status.DesiredReplicas))
+	if hpa.Status.LastScaleTime != nil {
+		hpaLastScaleTime.With(labels).Set(float64(hpa.Status.LastScaleTime.Unix()))
+	}
+}
+
+// DeleteHPAStatus removes the measurements of the HPA generated for the ScaledObject
+func DeleteHPAStatus(namespace string, scaledObject string, hpa string) {
+	labels := prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject, "hpa": hpa}
+	hpaCurrentReplicas.Delete(labels)
+	hpaDesiredReplicas.Delete(labels)
+	hpaLastScaleTime.Delete(labels)
+}
+
+// RecordScaleToZero counts the scale target of the ScaledObject being scaled to zero
+func RecordScaleToZero(namespace string, scaledObject string) {
+	scaledObjectScaleToZero.With(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject}).Inc()
+}
+
+// RecordRecommendedReplicas create a measurement of the replica count recommended for the ScaledObject in dry-run mode
+func RecordRecommendedReplicas(namespace string, scaledObject string, replicas int32) {
+	scaledObjectRecommendedReplicas.With(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject}).Set(float64(replicas))
+}
+
+// DeleteRecommendedReplicas removes the measurement of the replica count recommended for the ScaledObject
+func DeleteRecommendedReplicas(namespace string, scaledObject string) {
+	scaledObjectRecommendedReplicas.Delete(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject})
+}
+
+// RecordDesiredReplicas create a measurement of the replica count the scale target of the ScaledObject is scaled to
+func RecordDesiredReplicas(namespace string, scaledObject string, replicas int32) {
+	scaledObjectDesiredReplicas.With(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject}).Set(float64(replicas))
+}
+
+// DeleteDesiredReplicas removes the measurement of the replica count the scale target of the ScaledObject is scaled to
+func DeleteDesiredReplicas(namespace string, scaledObject string) {
+	scaledObjectDesiredReplicas.Delete(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject})
+}
+
+// RecordPendingActivation create a measurement of whether the activation of the scale target of the ScaledObject is pending
+func RecordPendingActivation(namespace string, scaledObject string, pending bool) {
+	pendingVal := 0
+	if pending {
+		pendingVal = 1
+	}
+	scaledObjectPendingActivation.With(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject}).Set(float64(pendingVal))
+}
+
+// DeletePendingActivation removes the measurement of the pending activation of the ScaledObject
+func DeletePendingActivation(namespace string, scaledObject string) {
+	scaledObjectPendingActivation.Delete(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject})
+}
+
+// RecordScaledObjectStatus counts the ScaledObject in its current status, the previous status of the ScaledObject
+// isn't counted anymore
+func RecordScaledObjectStatus(scaledObject *kedav1alpha1.ScaledObject) {
+	scaledObjectStatusesLock.Lock()
+	defer scaledObjectStatusesLock.Unlock()
+
+	key := scaledObject.Namespace + "/" + scaledObject.Name
+	status := getScaledObjectStatus(scaledObject)
+	previous, ok := scaledObjectStatuses[key]
+	if ok && previous == status {
+		return
+	}
+	if ok {
+		scaledObjectStatusTotals.WithLabelValues(scaledObject.Namespace, previous).Dec()
+	}
+	scaledObjectStatusTotals.WithLabelValues(scaledObject.Namespace, status).Inc()
+	scaledObjectStatuses[key] = status
+}
+
+// DeleteScaledObjectStatus stops counting the deleted ScaledObject
+func DeleteScaledObjectStatus(namespace string, scaledObject string) {
+	scaledObjectStatusesLock.Lock()
+	defer scaledObjectStatusesLock.Unlock()
+
+	key := namespace + "/" + scaledObject
+	if previous, ok := scaledObjectStatuses[key]; ok {
+		scaledObjectStatusTotals.WithLabelValues(namespace, previous).Dec()
+		delete(scaledObjectStatuses, key)
+	}
+}
+
+// getScaledObjectStatus returns the status of the ScaledObject by its conditions and paused replicas, the first matching one of
+// NotReady, Paused, Fallback, Active and Inactive
+func getScaledObjectStatus(scaledObject *kedav1alpha1.ScaledObject) string {
+	conditions := scaledObject.Status.Conditions
+	ready, fallback, active := conditions.GetReadyCondition(), conditions.GetFallbackCondition(), conditions.GetActiveCondition()
+	switch {
+	case !ready.IsTrue():
+		return "NotReady"
+	case scaledObject.Status.PausedReplicaCount != nil:
+		return "Paused"
+	case fallback.IsTrue():
+		return "Fallback"
+	case active.IsTrue():
+		return "Active"
+	default:
+		return "Inactive"
+	}
+}
+
+// RecordReconcileDuration create a measurement of the duration of the reconciliation of the resource type
+func RecordReconcileDuration(resourceType string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+		RecordInternalError(resourceType + "_controller")
+	}
+	reconcileDuration.WithLabelValues(resourceType, result).Observe(duration.Seconds())
+}
+
+// RecordInternalError counts an internal error of the component of KEDA Operator
+func RecordInternalError(component string) {
+	internalErrors.WithLabelValues(component).Inc()
+}
+
 func getLabels(namespace string, scaledObject string, scaler string, scalerIndex int, metric string) prometheus.Labels {
 	return prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject, "scaler": scaler, "scalerIndex": strconv.Itoa(scalerIndex), "metric": metric}
 }
@@ -0,0 +1,87 @@
+package prommetrics
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestRecordScalerActiveCountsActivations(t *testing.T) {
+	labels := getLabels("test", "consumer", "kafkaScaler", 0, "s0-kafka-topic")
+
+	RecordScalerActive("test", "consumer", "kafkaScaler", 0, "s0-kafka-topic", true)
+	RecordScalerActive("test", "consumer", "kafkaScaler", 0, "s0-kafka-topic", true)
+	assert.Equal(t, 1.0, testutil.ToFloat64(scalerActivations.With(labels)))
+
+	RecordScalerActive("test", "consumer", "kafkaScaler", 0, "s0-kafka-topic", false)
+	RecordScalerActive("test", "consumer", "kafkaScaler", 0, "s0-kafka-topic", true)
+	assert.Equal(t, 2.0, testutil.ToFloat64(scalerActivations.With(labels)))
+}
+
+func TestRecordScalerActiveCountsConcurrentActivationOnce(t *testing.T) {
+	labels := getLabels("test", "concurrent", "kafkaScaler", 0, "s0-kafka-topic")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			RecordScalerActive("test", "concurrent", "kafkaScaler", 0, "s0-kafka-topic", true)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(scalerActivations.With(labels)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(scalerActive.With(labels)))
+}
+
+func TestRecordScaledObjectStatus(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "status"},
+		Status:     kedav1alpha1.ScaledObjectStatus{Conditions: *kedav1alpha1.GetInitializedConditions()},
+	}
+
+	RecordScaledObjectStatus(scaledObject)
+	assert.Equal(t, 1.0, testutil.ToFloat64(scaledObjectStatusTotals.WithLabelValues("status", "NotReady")))
+
+	scaledObject.Status.Conditions.SetReadyCondition(metav1.ConditionTrue, "ScaledObjectReady", "")
+	scaledObject.Status.Conditions.SetActiveCondition(metav1.ConditionTrue, "ScalerActive", "")
+	RecordScaledObjectStatus(scaledObject)
+	assert.Equal(t, 0.0, testutil.ToFloat64(scaledObjectStatusTotals.WithLabelValues("status", "NotReady")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(scaledObjectStatusTotals.WithLabelValues("status", "Active")))
+
+	scaledObject.Status.Conditions.SetFallbackCondition(metav1.ConditionTrue, "FallbackExists", "")
+	RecordScaledObjectStatus(scaledObject)
+	assert.Equal(t, 0.0, testutil.ToFloat64(scaledObjectStatusTotals.WithLabelValues("status", "Active")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(scaledObjectStatusTotals.WithLabelValues("status", "Fallback")))
+
+	DeleteScaledObjectStatus("status", "consumer")
+	assert.Equal(t, 0.0, testutil.ToFloat64(scaledObjectStatusTotals.WithLabelValues("status", "Fallback")))
+}
+
+func TestRecordPendingActivation(t *testing.T) {
+	RecordPendingActivation("pending", "consumer", true)
+	assert.Equal(t, 1.0, testutil.ToFloat64(scaledObjectPendingActivation.WithLabelValues("pending", "consumer")))
+
+	RecordPendingActivation("pending", "consumer", false)
+	assert.Equal(t, 0.0, testutil.ToFloat64(scaledObjectPendingActivation.WithLabelValues("pending", "consumer")))
+
+	DeletePendingActivation("pending", "consumer")
+	assert.Equal(t, 0, testutil.CollectAndCount(scaledObjectPendingActivation))
+}
+
+func TestRecordReconcileDuration(t *testing.T) {
+	RecordReconcileDuration(ScaledJobResource, 100*time.Millisecond, nil)
+	RecordReconcileDuration(ScaledJobResource, 200*time.Millisecond, errors.New("failed to get ScaledJob"))
+
+	// one series for each result
+	assert.Equal(t, 2, testutil.CollectAndCount(reconcileDuration))
+	assert.Equal(t, 1.0, testutil.ToFloat64(internalErrors.WithLabelValues(ScaledJobResource+"_controller")))
+}
@@ -18,9 +18,10 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -28,29 +29,27 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/metrics/pkg/apis/custom_metrics"
 	"k8s.io/metrics/pkg/apis/external_metrics"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/custom-metrics-apiserver/pkg/provider"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
-	"github.com/kedacore/keda/v2/pkg/fallback"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
 	prommetrics "github.com/kedacore/keda/v2/pkg/prommetrics/adapter"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 )
 
 //	prommetrics "github.com/kedacore/keda/v2/pkg/prommetrics/adapter"
 
 // KedaProvider implements External Metrics Provider
+// metric values are served by KEDA Operator through the gRPC Metrics Service, scalers aren't built in KEDA Metrics Server
 type KedaProvider struct {
-	client                  client.Client
-	scaleHandler            scaling.ScaleHandler
 	watchedNamespace        string
 	ctx                     context.Context
 	externalMetricsInfo     *[]provider.ExternalMetricInfo
 	externalMetricsInfoLock *sync.RWMutex
 
-	grpcClient            metricsservice.GrpcClient
-	useMetricsServiceGrpc bool
+	// grpcClients has a client of the Metrics Service of each shard of KEDA Operator, see pkg/sharding
+	grpcClients []metricsservice.GrpcClient
 }
 
 var (
@@ -60,30 +59,30 @@ var (
 	grpcClientConnected bool
 )
 
-// NewProvider returns an instance of KedaProvider
-func NewProvider(ctx context.Context, adapterLogger logr.Logger, scaleHandler scaling.ScaleHandler, client client.Client, grpcClient metricsservice.GrpcClient, useMetricsServiceGrpc bool, watchedNamespace string, externalMetricsInfo *[]provider.ExternalMetricInfo, externalMetricsInfoLock *sync.RWMutex) provider.MetricsProvider {
+// NewProvider returns an instance of KedaProvider, grpcClients are clients of the Metrics Service
+// of the shards of KEDA Operator ordered by the index of the shard, it's a single client if sharding is disabled
+func NewProvider(ctx context.Context, adapterLogger logr.Logger, grpcClients []metricsservice.GrpcClient, watchedNamespace string, externalMetricsInfo *[]provider.ExternalMetricInfo, externalMetricsInfoLock *sync.RWMutex) provider.MetricsProvider {
 	provider := &KedaProvider{
-		client:                  client,
-		scaleHandler:            scaleHandler,
 		watchedNamespace:        watchedNamespace,
 		ctx:                     ctx,
 		externalMetricsInfo:     externalMetricsInfo,
 		externalMetricsInfoLock: externalMetricsInfoLock,
-		grpcClient:              grpcClient,
-		useMetricsServiceGrpc:   useMetricsServiceGrpc,
+		grpcClients:             grpcClients,
 	}
 	logger = adapterLogger.WithName("provider")
 	logger.Info("starting")
 
-	go func() {
-		if !grpcClient.WaitForConnectionReady(ctx, logger) {
-			grpcClientConnected = false
-			logger.Error(fmt.Errorf("timeout while waiting to establish gRPC connection to KEDA Metrics Service server"), "timeout", "server", grpcClient.GetServerURL())
-		} else if !grpcClientConnected {
-			grpcClientConnected = true
-			logger.Info("Connection to KEDA Metrics Service gRPC server has been successfully established", "server", grpcClient.GetServerURL())
-		}
-	}()
+	for _, grpcClient := range grpcClients {
+		go func(grpcClient metricsservice.GrpcClient) {
+			if !grpcClient.WaitForConnectionReady(ctx, logger) {
+				grpcClientConnected = false
+				logger.Error(fmt.Errorf("timeout while waiting to establish gRPC connection to KEDA Metrics Service server"), "timeout", "server", grpcClient.GetServerURL())
+			} else if !grpcClientConnected {
+				grpcClientConnected = true
+				logger.Info("Connection to KEDA Metrics Service gRPC server has been successfully established", "server", grpcClient.GetServerURL())
+			}
+		}(grpcClient)
+	}
 
 	return provider
 }
@@ -103,127 +102,58 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 		return nil, err
 	}
 
-	// Get Metrics from Metrics Service gRPC Server
-	if p.useMetricsServiceGrpc {
-		if !p.grpcClient.WaitForConnectionReady(ctx, logger) {
-			grpcClientConnected = false
-			logger.Error(fmt.Errorf("timeout while waiting to establish gRPC connection to KEDA Metrics Service server"), "timeout", "server", p.grpcClient.GetServerURL())
-			return nil, err
-		}
-		if !grpcClientConnected {
-			grpcClientConnected = true
-			logger.Info("Connection to KEDA Metrics Service gRPC server has been successfully established", "server", p.grpcClient.GetServerURL())
-		}
-
-		// selector is in form: `scaledobject.keda.sh/name: scaledobject-name`
-		scaledObjectName := selector.Get(kedav1alpha1.ScaledObjectOwnerAnnotation)
-		if scaledObjectName == "" {
-			err := fmt.Errorf("scaledObject name is not specified")
-			logger.Error(err, fmt.Sprintf("please specify scaledObject name, it needs to be set as value of label selector %q on the query", kedav1alpha1.ScaledObjectOwnerAnnotation))
-
-			return &external_metrics.ExternalMetricValueList{}, err
-		}
-
-		metrics, promMetrics, err := p.grpcClient.GetMetrics(ctx, scaledObjectName, namespace, info.Metric)
-		logger.V(1).WithValues("scaledObjectName", scaledObjectName, "scaledObjectNamespace", namespace, "metrics", metrics).Info("Receiving metrics")
-
-		// [DEPRECATED] handle exporting Prometheus metrics from Operator to Metrics Server
-		if promMetrics != nil {
-			var scaledObjectErr error
-			if promMetrics.ScaledObjectErr {
-				scaledObjectErr = fmt.Errorf("scaledObject error")
-			}
-			promMetricsServer.RecordScaledObjectError(namespace, scaledObjectName, scaledObjectErr)
-			for _, scalerMetric := range promMetrics.ScalerMetric {
-				promMetricsServer.RecordHPAScalerMetric(namespace, scaledObjectName, scalerMetric.ScalerName, int(scalerMetric.ScalerIndex), scalerMetric.MetricName, float64(scalerMetric.MetricValue))
-			}
-			for _, scalerError := range promMetrics.ScalerError {
-				var scalerErr error
-				if scalerError.Error {
-					scalerErr = fmt.Errorf("scaler error")
-				}
-				promMetricsServer.RecordHPAScalerError(namespace, scaledObjectName, scalerError.ScalerName, int(scalerError.ScalerIndex), scalerError.MetricName, scalerErr)
-			}
-		}
+	// selector is in form: `scaledobject.keda.sh/name: scaledobject-name`
+	scaledObjectName := selector.Get(kedav1alpha1.ScaledObjectOwnerAnnotation)
+	if scaledObjectName == "" {
+		err := fmt.Errorf("scaledObject name is not specified")
+		logger.Error(err, fmt.Sprintf("please specify scaledObject name, it needs to be set as value of label selector %q on the query", kedav1alpha1.ScaledObjectOwnerAnnotation))
 
-		return metrics, err
+		return &external_metrics.ExternalMetricValueList{}, err
 	}
 
-	// ------ Deprecated way of getting metric directly from MS ------ //
-	// --------------------------------------------------------------- //
-	// Get Metrics by querying directly the external service
-	scaledObjects := &kedav1alpha1.ScaledObjectList{}
-	opts := []client.ListOption{
-		client.InNamespace(namespace),
-		client.MatchingLabels(selector),
+	// Get Metrics from Metrics Service gRPC Server of the shard running the scale loop of the ScaledObject
+	grpcClient := p.grpcClients[sharding.ShardOf(namespace, scaledObjectName, len(p.grpcClients))]
+	if !grpcClient.WaitForConnectionReady(ctx, logger) {
+		grpcClientConnected = false
+		err := fmt.Errorf("timeout while waiting to establish gRPC connection to KEDA Metrics Service server")
+		logger.Error(err, "timeout", "server", grpcClient.GetServerURL())
+		return nil, apiErrors.NewServiceUnavailable(err.Error())
 	}
-	err = p.client.List(ctx, scaledObjects, opts...)
-	if err != nil {
-		return nil, err
-	} else if len(scaledObjects.Items) != 1 {
-		return nil, fmt.Errorf("exactly one ScaledObject should match label %s", metricSelector.String())
+	if !grpcClientConnected {
+		grpcClientConnected = true
+		logger.Info("Connection to KEDA Metrics Service gRPC server has been successfully established", "server", grpcClient.GetServerURL())
 	}
 
-	scaledObject := &scaledObjects.Items[0]
-	var matchingMetrics []external_metrics.ExternalMetricValue
+	requestStart := time.Now()
+	metrics, promMetrics, err := grpcClient.GetMetrics(ctx, scaledObjectName, namespace, info.Metric)
+	promMetricsServer.RecordRequestLatency(namespace, scaledObjectName, info.Metric, time.Since(requestStart))
+	logger.V(1).WithValues("scaledObjectName", scaledObjectName, "scaledObjectNamespace", namespace, "metrics", metrics).Info("Receiving metrics")
 
-	cache, err := p.scaleHandler.GetScalersCache(ctx, scaledObject)
-	promMetricsServer.RecordScaledObjectError(scaledObject.Namespace, scaledObject.Name, err)
-	if err != nil {
-		return nil, fmt.Errorf("error when getting scalers %w", err)
-	}
-
-	// let's check metrics for all scalers in a ScaledObject
-	scalerError := false
-	scalers, scalerConfigs := cache.GetScalers()
-	for scalerIndex := 0; scalerIndex < len(scalers); scalerIndex++ {
-		metricSpecs := scalers[scalerIndex].GetMetricSpecForScaling(ctx)
-		scalerName := strings.Replace(fmt.Sprintf("%T", scalers[scalerIndex]), "*scalers.", "", 1)
-		if scalerConfigs[scalerIndex].TriggerName != "" {
-			scalerName = scalerConfigs[scalerIndex].TriggerName
+	// export metric values and errors of the scalers reported by Operator, so broken scalers can be alerted on from Metrics Server
+	if promMetrics != nil {
+		var scaledObjectErr error
+		if promMetrics.ScaledObjectErr {
+			scaledObjectErr = fmt.Errorf("scaledObject error")
 		}
-
-		for _, metricSpec := range metricSpecs {
-			// skip cpu/memory resource scaler
-			if metricSpec.External == nil {
-				continue
-			}
-			// Filter only the desired metric
-			if strings.EqualFold(metricSpec.External.Metric.Name, info.Metric) {
-				metrics, _, _, err := cache.GetMetricsAndActivityForScaler(ctx, scalerIndex, info.Metric)
-				metrics, err = fallback.GetMetricsWithFallback(ctx, p.client, metrics, err, info.Metric, scaledObject, metricSpec)
-				if err != nil {
-					scalerError = true
-					logger.Error(err, "error getting metric for scaler", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "scaler", scalerName)
-				} else {
-					for _, metric := range metrics {
-						metricValue := metric.Value.AsApproximateFloat64()
-						promMetricsServer.RecordHPAScalerMetric(namespace, scaledObject.Name, scalerName, scalerIndex, metric.MetricName, metricValue)
-					}
-					matchingMetrics = append(matchingMetrics, metrics...)
-				}
-				promMetricsServer.RecordHPAScalerError(namespace, scaledObject.Name, scalerName, scalerIndex, info.Metric, err)
-			}
+		promMetricsServer.RecordScaledObjectError(namespace, scaledObjectName, scaledObjectErr)
+		for _, scalerMetric := range promMetrics.ScalerMetric {
+			promMetricsServer.RecordHPAScalerMetric(namespace, scaledObjectName, scalerMetric.ScalerName, int(scalerMetric.ScalerIndex), scalerMetric.MetricName, float64(scalerMetric.MetricValue))
 		}
-	}
-
-	// invalidate the cache for the ScaledObject, if we hit an error in any scaler
-	// in this case we try to build all scalers (and resolve all secrets/creds) again in the next call
-	if scalerError {
-		err := p.scaleHandler.ClearScalersCache(ctx, scaledObject)
-		if err != nil {
-			logger.Error(err, "error clearing scalers cache")
+		for _, scalerError := range promMetrics.ScalerError {
+			var scalerErr error
+			if scalerError.Error {
+				scalerErr = fmt.Errorf("scaler error")
+			}
+			promMetricsServer.RecordHPAScalerError(namespace, scaledObjectName, scalerError.ScalerName, int(scalerError.ScalerIndex), scalerError.MetricName, scalerErr)
 		}
-		logger.V(1).Info("scaler error encountered, clearing scaler cache")
 	}
 
-	if len(matchingMetrics) == 0 {
-		return nil, fmt.Errorf("no matching metrics found for " + info.Metric)
+	// a failing scaler is reported as unavailable metric, so the HPA doesn't act on it
+	var unavailableErr *scaling.MetricUnavailableError
+	if errors.As(err, &unavailableErr) {
+		return nil, apiErrors.NewServiceUnavailable(err.Error())
 	}
-
-	return &external_metrics.ExternalMetricValueList{
-		Items: matchingMetrics,
-	}, nil
+	return metrics, err
 }
 
 // ListAllExternalMetrics returns the supported external metrics for this provider
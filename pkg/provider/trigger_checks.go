@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
+	"github.com/kedacore/keda/v2/pkg/sharding"
+)
+
+// TriggerChecksPath is the path of the endpoint of KEDA Metrics Server checking the triggers of a ScaledObject
+// posted to it, without the ScaledObject being created
+const TriggerChecksPath = "/debug/keda/check-triggers"
+
+const (
+	triggerChecksTimeout      = 2 * time.Minute
+	triggerChecksMaxBodyBytes = 1 << 20
+)
+
+// TriggerChecker checks the triggers of a ScaledObject once, it's implemented by the Metrics Service client
+type TriggerChecker interface {
+	CheckTriggers(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) ([]*api.TriggerCheck, error)
+}
+
+// TriggerCheckResult is the result of checking a metric of a trigger returned by the endpoint
+type TriggerCheckResult struct {
+	TriggerIndex int32    `json:"triggerIndex"`
+	TriggerName  string   `json:"triggerName,omitempty"`
+	TriggerType  string   `json:"triggerType"`
+	MetricName   string   `json:"metricName,omitempty"`
+	IsActive     bool     `json:"isActive"`
+	MetricValues []string `json:"metricValues,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+type triggerChecksHandler struct {
+	logger     logr.Logger
+	authorizer authorizer.Authorizer
+	// checkers has a checker of each shard of KEDA Operator, see pkg/sharding
+	checkers []TriggerChecker
+}
+
+// NewTriggerChecksHandler returns the handler of TriggerChecksPath, the triggers of a ScaledObject are checked by KEDA Operator
+// once. The caller is authorized to create the ScaledObject in its namespace, as the checks use the TriggerAuthentications
+// and the environment of the scale target the same way the ScaledObject would.
+func NewTriggerChecksHandler(logger logr.Logger, authz authorizer.Authorizer, checkers []TriggerChecker) http.Handler {
+	return &triggerChecksHandler{
+		logger:     logger.WithName("trigger_checks"),
+		authorizer: authz,
+		checkers:   checkers,
+	}
+}
+
+func (h *triggerChecksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	user, ok := request.UserFrom(r.Context())
+	if !ok {
+		http.Error(w, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, triggerChecksMaxBodyBytes))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading ScaledObject: %v", err), http.StatusBadRequest)
+		return
+	}
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	if err := json.Unmarshal(body, scaledObject); err != nil {
+		http.Error(w, fmt.Sprintf("error decoding ScaledObject: %v", err), http.StatusBadRequest)
+		return
+	}
+	if scaledObject.Namespace == "" {
+		http.Error(w, "namespace of ScaledObject is required", http.StatusBadRequest)
+		return
+	}
+
+	decision, reason, err := h.authorizer.Authorize(r.Context(), authorizer.AttributesRecord{
+		User:            user,
+		Verb:            "create",
+		Namespace:       scaledObject.Namespace,
+		APIGroup:        kedav1alpha1.SchemeGroupVersion.Group,
+		APIVersion:      kedav1alpha1.SchemeGroupVersion.Version,
+		Resource:        "scaledobjects",
+		Name:            scaledObject.Name,
+		ResourceRequest: true,
+	})
+	if err != nil {
+		h.logger.Error(err, "error authorizing check of triggers", "user", user.GetName())
+		http.Error(w, "error authorizing request", http.StatusInternalServerError)
+		return
+	}
+	if decision != authorizer.DecisionAllow {
+		http.Error(w, fmt.Sprintf("user %q can't create ScaledObjects in namespace %q: %s", user.GetName(), scaledObject.Namespace, reason), http.StatusForbidden)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), triggerChecksTimeout)
+	defer cancel()
+	checker := h.checkers[sharding.ShardOf(scaledObject.Namespace, scaledObject.Name, len(h.checkers))]
+	checks, err := checker.CheckTriggers(ctx, scaledObject)
+	if err != nil {
+		h.logger.V(1).Info("Checking triggers failed", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "error", err.Error())
+		http.Error(w, fmt.Sprintf("error checking triggers: %v", err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	results := make([]TriggerCheckResult, 0, len(checks))
+	for _, check := range checks {
+		results = append(results, TriggerCheckResult{
+			TriggerIndex: check.GetTriggerIndex(),
+			TriggerName:  check.GetTriggerName(),
+			TriggerType:  check.GetTriggerType(),
+			MetricName:   check.GetMetricName(),
+			IsActive:     check.GetIsActive(),
+			MetricValues: check.GetMetricValues(),
+			Error:        check.GetError(),
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		h.logger.Error(err, "error writing results of trigger checks")
+	}
+}
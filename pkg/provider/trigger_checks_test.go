@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
+)
+
+type fakeTriggerChecker struct {
+	checks []*api.TriggerCheck
+	err    error
+}
+
+func (c *fakeTriggerChecker) CheckTriggers(_ context.Context, _ *kedav1alpha1.ScaledObject) ([]*api.TriggerCheck, error) {
+	return c.checks, c.err
+}
+
+func TestTriggerChecksHandler(t *testing.T) {
+	allowNamespace := authorizer.AuthorizerFunc(func(_ context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+		if a.GetVerb() == "create" && a.GetResource() == "scaledobjects" && a.GetNamespace() == "allowed" {
+			return authorizer.DecisionAllow, "", nil
+		}
+		return authorizer.DecisionDeny, "denied", nil
+	})
+	checker := &fakeTriggerChecker{
+		checks: []*api.TriggerCheck{
+			{TriggerIndex: 0, TriggerType: "cron", MetricName: "s0-cron", IsActive: true, MetricValues: []string{"3"}},
+			{TriggerIndex: 1, TriggerType: "unknown", Error: "no scaler found for type: unknown"},
+		},
+	}
+	handler := NewTriggerChecksHandler(logr.Discard(), allowNamespace, []TriggerChecker{checker})
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		anonymous  bool
+		checkErr   error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "wrong method", method: http.MethodGet, wantStatus: http.StatusMethodNotAllowed},
+		{name: "unauthenticated", anonymous: true, body: `{}`, wantStatus: http.StatusUnauthorized},
+		{name: "invalid body", body: `{"spec": 1}`, wantStatus: http.StatusBadRequest, wantBody: "error decoding ScaledObject"},
+		{name: "no namespace", body: `{"metadata": {"name": "so"}}`, wantStatus: http.StatusBadRequest, wantBody: "namespace of ScaledObject is required"},
+		{name: "forbidden", body: `{"metadata": {"name": "so", "namespace": "other"}}`, wantStatus: http.StatusForbidden, wantBody: "can't create ScaledObjects in namespace \"other\""},
+		{name: "check error", body: `{"metadata": {"name": "so", "namespace": "allowed"}}`, checkErr: errors.New("no triggers defined"), wantStatus: http.StatusUnprocessableEntity, wantBody: "no triggers defined"},
+		{
+			name:       "checked",
+			body:       `{"metadata": {"name": "so", "namespace": "allowed"}}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"triggerIndex":0,"triggerType":"cron","metricName":"s0-cron","isActive":true,"metricValues":["3"]},{"triggerIndex":1,"triggerType":"unknown","isActive":false,"error":"no scaler found for type: unknown"}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checker.err = test.checkErr
+			method := test.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, TriggerChecksPath, strings.NewReader(test.body))
+			if !test.anonymous {
+				req = req.WithContext(request.WithUser(req.Context(), &user.DefaultInfo{Name: "jane"}))
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), test.wantBody)
+		})
+	}
+}
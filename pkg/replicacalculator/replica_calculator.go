@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicacalculator
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const (
+	defaultMaxReplicas        = 100
+	defaultLogisticGrowthRate = 1.0
+)
+
+var log = logf.Log.WithName("replica_calculator")
+
+// ReplicaCalculator is an interface for post-processing the number of replicas proportional to the metric value
+type ReplicaCalculator interface {
+	GetReplicas(proportionalReplicas float64) float64
+}
+
+type defaultReplicaCalculator struct{}
+
+func (s defaultReplicaCalculator) GetReplicas(proportionalReplicas float64) float64 {
+	return proportionalReplicas
+}
+
+type stepReplicaCalculator struct {
+	stepSize float64
+}
+
+func (s stepReplicaCalculator) GetReplicas(proportionalReplicas float64) float64 {
+	return math.Ceil(proportionalReplicas/s.stepSize) * s.stepSize
+}
+
+type logisticReplicaCalculator struct {
+	maxReplicas float64
+	midpoint    float64
+	growthRate  float64
+}
+
+func (s logisticReplicaCalculator) GetReplicas(proportionalReplicas float64) float64 {
+	if proportionalReplicas <= 0 {
+		return 0
+	}
+	return s.maxReplicas / (1 + math.Exp(-s.growthRate*(proportionalReplicas-s.midpoint)))
+}
+
+// NewReplicaCalculator returns the ReplicaCalculator configured for the ScaledObject,
+// the default one is used if the configuration is missing or invalid
+func NewReplicaCalculator(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) ReplicaCalculator {
+	if scaledObject.Spec.Advanced == nil || scaledObject.Spec.Advanced.ReplicaCalculator == nil {
+		return defaultReplicaCalculator{}
+	}
+	config := scaledObject.Spec.Advanced.ReplicaCalculator
+	// invalid configurations are rejected by the admission webhook and reported in the Ready condition
+	if err := config.Validate(); err != nil {
+		logger.V(1).Info("Invalid replica calculator configuration, selecting replica calculator", "error", err.Error(), "specified", config.Strategy, "selected", "default")
+		return defaultReplicaCalculator{}
+	}
+
+	switch config.Strategy {
+	case "step":
+		return stepReplicaCalculator{stepSize: float64(*config.StepSize)}
+	case "logistic":
+		maxReplicas := float64(defaultMaxReplicas)
+		if scaledObject.Spec.MaxReplicaCount != nil {
+			maxReplicas = float64(*scaledObject.Spec.MaxReplicaCount)
+		}
+		calculator := logisticReplicaCalculator{
+			maxReplicas: maxReplicas,
+			midpoint:    maxReplicas / 2,
+			growthRate:  defaultLogisticGrowthRate,
+		}
+		if config.LogisticMidpoint != "" {
+			calculator.midpoint, _ = strconv.ParseFloat(config.LogisticMidpoint, 64)
+		}
+		if config.LogisticGrowthRate != "" {
+			calculator.growthRate, _ = strconv.ParseFloat(config.LogisticGrowthRate, 64)
+		}
+		return calculator
+	default:
+		return defaultReplicaCalculator{}
+	}
+}
+
+// GetMetricsWithReplicaCalculator rescales the metric values so the HPA calculates the number of replicas
+// returned by the ReplicaCalculator configured for the ScaledObject
+func GetMetricsWithReplicaCalculator(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec, metrics []external_metrics.ExternalMetricValue) []external_metrics.ExternalMetricValue {
+	if scaledObject.Spec.Advanced == nil || scaledObject.Spec.Advanced.ReplicaCalculator == nil {
+		return metrics
+	}
+
+	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	if metricSpec.External == nil || metricSpec.External.Target.Type != v2.AverageValueMetricType || metricSpec.External.Target.AverageValue == nil {
+		logger.V(1).Info("Replica calculator can only be used for triggers with metric of type AverageValue")
+		return metrics
+	}
+
+	targetValue := metricSpec.External.Target.AverageValue.AsApproximateFloat64()
+	if targetValue <= 0 {
+		return metrics
+	}
+
+	calculator := NewReplicaCalculator(logger, scaledObject)
+	if _, ok := calculator.(defaultReplicaCalculator); ok {
+		return metrics
+	}
+
+	calculatedMetrics := make([]external_metrics.ExternalMetricValue, 0, len(metrics))
+	for _, metric := range metrics {
+		replicas := calculator.GetReplicas(metric.Value.AsApproximateFloat64() / targetValue)
+		metric.Value = *resource.NewMilliQuantity(int64(math.Round(replicas*targetValue*1000)), resource.DecimalSI)
+		calculatedMetrics = append(calculatedMetrics, metric)
+	}
+	return calculatedMetrics
+}
+
+// GetProportionalReplicaCount returns the number of replicas the HPA would calculate for the metric values of a trigger
+// with metric of type AverageValue, including the ReplicaCalculator configured for the ScaledObject.
+// It returns false for other metric types, the HPA calculates them from the current state of the scale target.
+func GetProportionalReplicaCount(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec, metrics []external_metrics.ExternalMetricValue) (int32, bool) {
+	if metricSpec.External == nil || metricSpec.External.Target.Type != v2.AverageValueMetricType || metricSpec.External.Target.AverageValue == nil {
+		return 0, false
+	}
+
+	targetValue := metricSpec.External.Target.AverageValue.AsApproximateFloat64()
+	if targetValue <= 0 {
+		return 0, false
+	}
+
+	value := 0.0
+	for _, metric := range GetMetricsWithReplicaCalculator(scaledObject, metricSpec, metrics) {
+		value += metric.Value.AsApproximateFloat64()
+	}
+	return int32(math.Ceil(value / targetValue)), true
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicacalculator
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func newScaledObject(replicaCalculator *kedav1alpha1.ReplicaCalculator, maxReplicaCount int32) *kedav1alpha1.ScaledObject {
+	return &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			MaxReplicaCount: &maxReplicaCount,
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ReplicaCalculator: replicaCalculator,
+			},
+		},
+	}
+}
+
+func newMetricSpec(targetType v2.MetricTargetType, target int64) v2.MetricSpec {
+	quantity := resource.NewQuantity(target, resource.DecimalSI)
+	metricTarget := v2.MetricTarget{Type: targetType}
+	if targetType == v2.AverageValueMetricType {
+		metricTarget.AverageValue = quantity
+	} else {
+		metricTarget.Value = quantity
+	}
+	return v2.MetricSpec{
+		Type: v2.ExternalMetricSourceType,
+		External: &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{Name: "s0-metric"},
+			Target: metricTarget,
+		},
+	}
+}
+
+func newMetrics(value int64) []external_metrics.ExternalMetricValue {
+	return []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-metric", Value: *resource.NewQuantity(value, resource.DecimalSI)},
+	}
+}
+
+func TestNewReplicaCalculator(t *testing.T) {
+	stepSize := int32(5)
+	invalidStepSize := int32(0)
+
+	tests := []struct {
+		name              string
+		replicaCalculator *kedav1alpha1.ReplicaCalculator
+		expected          ReplicaCalculator
+	}{
+		{"not configured", nil, defaultReplicaCalculator{}},
+		{"default", &kedav1alpha1.ReplicaCalculator{Strategy: "default"}, defaultReplicaCalculator{}},
+		{"unknown", &kedav1alpha1.ReplicaCalculator{Strategy: "unknown"}, defaultReplicaCalculator{}},
+		{"step", &kedav1alpha1.ReplicaCalculator{Strategy: "step", StepSize: &stepSize}, stepReplicaCalculator{stepSize: 5}},
+		{"step without stepSize", &kedav1alpha1.ReplicaCalculator{Strategy: "step"}, defaultReplicaCalculator{}},
+		{"step with invalid stepSize", &kedav1alpha1.ReplicaCalculator{Strategy: "step", StepSize: &invalidStepSize}, defaultReplicaCalculator{}},
+		{"logistic", &kedav1alpha1.ReplicaCalculator{Strategy: "logistic"}, logisticReplicaCalculator{maxReplicas: 20, midpoint: 10, growthRate: 1}},
+		{"logistic with parameters", &kedav1alpha1.ReplicaCalculator{Strategy: "logistic", LogisticMidpoint: "4", LogisticGrowthRate: "0.5"}, logisticReplicaCalculator{maxReplicas: 20, midpoint: 4, growthRate: 0.5}},
+		{"logistic with invalid midpoint", &kedav1alpha1.ReplicaCalculator{Strategy: "logistic", LogisticMidpoint: "a"}, defaultReplicaCalculator{}},
+		{"logistic with invalid growthRate", &kedav1alpha1.ReplicaCalculator{Strategy: "logistic", LogisticGrowthRate: "-1"}, defaultReplicaCalculator{}},
+	}
+
+	for _, test := range tests {
+		calculator := NewReplicaCalculator(logr.Discard(), newScaledObject(test.replicaCalculator, 20))
+		assert.Equal(t, test.expected, calculator, test.name)
+	}
+}
+
+func TestGetReplicas(t *testing.T) {
+	tests := []struct {
+		name                 string
+		calculator           ReplicaCalculator
+		proportionalReplicas float64
+		expected             float64
+	}{
+		{"default", defaultReplicaCalculator{}, 3.5, 3.5},
+		{"step rounds up", stepReplicaCalculator{stepSize: 5}, 3.5, 5},
+		{"step keeps multiple", stepReplicaCalculator{stepSize: 5}, 10, 10},
+		{"step keeps zero", stepReplicaCalculator{stepSize: 5}, 0, 0},
+		{"logistic midpoint", logisticReplicaCalculator{maxReplicas: 20, midpoint: 10, growthRate: 1}, 10, 10},
+		{"logistic keeps zero", logisticReplicaCalculator{maxReplicas: 20, midpoint: 10, growthRate: 1}, 0, 0},
+	}
+
+	for _, test := range tests {
+		assert.InDelta(t, test.expected, test.calculator.GetReplicas(test.proportionalReplicas), 0.0001, test.name)
+	}
+
+	logistic := logisticReplicaCalculator{maxReplicas: 20, midpoint: 10, growthRate: 1}
+	assert.Less(t, logistic.GetReplicas(2), 1.0)
+	assert.Greater(t, logistic.GetReplicas(18), 19.0)
+	assert.LessOrEqual(t, logistic.GetReplicas(1000), 20.0)
+}
+
+func TestGetMetricsWithReplicaCalculator(t *testing.T) {
+	stepSize := int32(5)
+	step := &kedav1alpha1.ReplicaCalculator{Strategy: "step", StepSize: &stepSize}
+
+	tests := []struct {
+		name         string
+		scaledObject *kedav1alpha1.ScaledObject
+		metricSpec   v2.MetricSpec
+		value        int64
+		expected     float64
+	}{
+		{"not configured", &kedav1alpha1.ScaledObject{}, newMetricSpec(v2.AverageValueMetricType, 10), 35, 35},
+		{"default", newScaledObject(&kedav1alpha1.ReplicaCalculator{Strategy: "default"}, 20), newMetricSpec(v2.AverageValueMetricType, 10), 35, 35},
+		{"step", newScaledObject(step, 20), newMetricSpec(v2.AverageValueMetricType, 10), 35, 50},
+		{"step with Value metric", newScaledObject(step, 20), newMetricSpec(v2.ValueMetricType, 10), 35, 35},
+		{"logistic", newScaledObject(&kedav1alpha1.ReplicaCalculator{Strategy: "logistic"}, 20), newMetricSpec(v2.AverageValueMetricType, 10), 100, 100},
+	}
+
+	for _, test := range tests {
+		metrics := GetMetricsWithReplicaCalculator(test.scaledObject, test.metricSpec, newMetrics(test.value))
+		assert.Len(t, metrics, 1, test.name)
+		assert.Equal(t, "s0-metric", metrics[0].MetricName, test.name)
+		assert.InDelta(t, test.expected, metrics[0].Value.AsApproximateFloat64(), 0.001, test.name)
+	}
+}
+
+func TestGetProportionalReplicaCount(t *testing.T) {
+	stepSize := int32(5)
+	step := &kedav1alpha1.ReplicaCalculator{Strategy: "step", StepSize: &stepSize}
+
+	tests := []struct {
+		name         string
+		scaledObject *kedav1alpha1.ScaledObject
+		metricSpec   v2.MetricSpec
+		value        int64
+		expected     int32
+		expectedOk   bool
+	}{
+		{"not configured", &kedav1alpha1.ScaledObject{}, newMetricSpec(v2.AverageValueMetricType, 10), 35, 4, true},
+		{"zero", &kedav1alpha1.ScaledObject{}, newMetricSpec(v2.AverageValueMetricType, 10), 0, 0, true},
+		{"step", newScaledObject(step, 20), newMetricSpec(v2.AverageValueMetricType, 10), 35, 5, true},
+		{"Value metric", &kedav1alpha1.ScaledObject{}, newMetricSpec(v2.ValueMetricType, 10), 35, 0, false},
+	}
+
+	for _, test := range tests {
+		replicas, ok := GetProportionalReplicaCount(test.scaledObject, test.metricSpec, newMetrics(test.value))
+		assert.Equal(t, test.expectedOk, ok, test.name)
+		assert.Equal(t, test.expected, replicas, test.name)
+	}
+}
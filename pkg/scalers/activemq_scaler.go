@@ -63,7 +63,10 @@ func NewActiveMQScaler(config *ScalerConfig) (Scaler, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error parsing ActiveMQ metadata: %w", err)
 	}
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient, err := newHTTPClientFromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ActiveMQ HTTP client: %w", err)
+	}
 
 	return &activeMQScaler{
 		metricType: metricType,
@@ -60,7 +60,10 @@ func NewArtemisQueueScaler(config *ScalerConfig) (Scaler, error) {
 	// do we need to guarantee this timeout for a specific
 	// reason? if not, we can have buildScaler pass in
 	// the global client
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient, err := newHTTPClientFromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating artemis HTTP client: %w", err)
+	}
 
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
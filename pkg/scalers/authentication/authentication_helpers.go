@@ -41,6 +41,9 @@ func GetAuthConfigs(triggerMetadata, authParams map[string]string) (out *AuthMet
 			if out.EnableBasicAuth {
 				return nil, errors.New("both bearer and basic authentication can not be set")
 			}
+			if out.EnableOAuth {
+				return nil, errors.New("oauth can not be set with bearer or basic authentication")
+			}
 
 			out.BearerToken = authParams["bearerToken"]
 			out.EnableBearerAuth = true
@@ -51,6 +54,9 @@ func GetAuthConfigs(triggerMetadata, authParams map[string]string) (out *AuthMet
 			if out.EnableBearerAuth {
 				return nil, errors.New("both bearer and basic authentication can not be set")
 			}
+			if out.EnableOAuth {
+				return nil, errors.New("oauth can not be set with bearer or basic authentication")
+			}
 
 			out.Username = authParams["username"]
 			// password is optional. For convenience, many application implement basic auth with
@@ -80,6 +86,31 @@ func GetAuthConfigs(triggerMetadata, authParams map[string]string) (out *AuthMet
 			}
 			out.CustomAuthValue = authParams["customAuthValue"]
 			out.EnableCustomAuth = true
+		case OAuthType:
+			if len(authParams["oauthTokenURI"]) == 0 {
+				return nil, errors.New("no oauth token uri given")
+			}
+			if len(authParams["clientID"]) == 0 {
+				return nil, errors.New("no client id given")
+			}
+			if len(authParams["clientSecret"]) == 0 {
+				return nil, errors.New("no client secret given")
+			}
+			if out.EnableBearerAuth || out.EnableBasicAuth {
+				return nil, errors.New("oauth can not be set with bearer or basic authentication")
+			}
+
+			out.OauthTokenURI = authParams["oauthTokenURI"]
+			out.ClientID = authParams["clientID"]
+			out.ClientSecret = authParams["clientSecret"]
+			for _, scope := range strings.Split(authParams["scopes"], ",") {
+				if scope = strings.TrimSpace(scope); scope != "" {
+					out.Scopes = append(out.Scopes, scope)
+				}
+			}
+			out.EnableOAuth = true
+		case AWSSigV4AuthType:
+			out.EnableAWSSigV4 = true
 		default:
 			return nil, fmt.Errorf("incorrect value for authMode is given: %s", t)
 		}
@@ -16,6 +16,10 @@ const (
 	BearerAuthType Type = "bearer"
 	// CustomAuthType is a auth type using a custom header
 	CustomAuthType Type = "custom"
+	// OAuthType is a auth type using an OAuth2 access token of client credentials flow
+	OAuthType Type = "oauth"
+	// AWSSigV4AuthType is a auth type signing requests with AWS Signature Version 4
+	AWSSigV4AuthType Type = "sigv4"
 )
 
 // TransportType is type of http transport
@@ -46,6 +50,16 @@ type AuthMeta struct {
 	EnableCustomAuth bool
 	CustomAuthHeader string
 	CustomAuthValue  string
+
+	// oauth2 client credentials flow
+	EnableOAuth   bool
+	OauthTokenURI string
+	ClientID      string
+	ClientSecret  string
+	Scopes        []string
+
+	// aws signature version 4, the credentials are given by aws parameters of the scaler
+	EnableAWSSigV4 bool
 }
 
 type HTTPTransport struct {
@@ -13,6 +13,9 @@ import (
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/metricsproxy"
+	"github.com/kedacore/keda/v2/pkg/scalers/ratelimiter"
 )
 
 const (
@@ -51,6 +54,8 @@ type awsCloudwatchMetadata struct {
 
 	awsAuthorization awsAuthorizationMetadata
 
+	metricsProxyKey string
+
 	scalerIndex int
 }
 
@@ -221,12 +226,17 @@ func parseAwsCloudwatchMetadata(config *ScalerConfig) (*awsCloudwatchMetadata, e
 		meta.awsEndpoint = val
 	}
 
-	awsAuthorization, err := getAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+	awsAuthorization, err := getAwsAuthorization(config.PodIdentity, config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
 	if err != nil {
 		return nil, err
 	}
 	meta.awsAuthorization = awsAuthorization
 
+	meta.metricsProxyKey, err = getMetricsProxyKey(config, "targetMetricValue", "activationTargetMetricValue")
+	if err != nil {
+		return nil, err
+	}
+
 	meta.scalerIndex = config.ScalerIndex
 
 	return &meta, nil
@@ -279,7 +289,14 @@ func computeQueryWindow(current time.Time, metricPeriodSec, metricEndTimeOffsetS
 }
 
 func (s *awsCloudwatchScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	metricValue, err := s.GetCloudwatchMetrics()
+	metricValue, err := metricsproxy.Query(ctx, "aws-cloudwatch", s.metadata.metricsProxyKey, func(ctx context.Context) (float64, error) {
+		// CloudWatch limits requests per account and region
+		account := ratelimiter.AccountKey(s.metadata.awsRegion, s.metadata.awsAuthorization.awsRoleArn, s.metadata.awsAuthorization.awsAccessKeyID)
+		if err := ratelimiter.Wait(ctx, "aws-cloudwatch", account); err != nil {
+			return 0, err
+		}
+		return s.GetCloudwatchMetrics()
+	})
 
 	if err != nil {
 		s.logger.Error(err, "Error getting metric value")
@@ -8,6 +8,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
 // ErrAwsNoAccessKey is returned when awsAccessKeyID is missing.
@@ -60,10 +62,14 @@ func getAwsConfig(awsRegion string, awsEndpoint string, awsAuthorization awsAuth
 	}
 }
 
-func getAwsAuthorization(authParams, metadata, resolvedEnv map[string]string) (awsAuthorizationMetadata, error) {
+func getAwsAuthorization(podIdentity kedav1alpha1.AuthPodIdentity, authParams, metadata, resolvedEnv map[string]string) (awsAuthorizationMetadata, error) {
 	meta := awsAuthorizationMetadata{}
 
-	if metadata["identityOwner"] == "operator" {
+	// identityOwner: keda of the TriggerAuthentication uses the credentials of KEDA Operator unless a role is given
+	operatorIdentity := (podIdentity.Provider == kedav1alpha1.PodIdentityProviderAwsEKS || podIdentity.Provider == kedav1alpha1.PodIdentityProviderAwsKiam) &&
+		!podIdentity.IsWorkloadIdentityOwner() && podIdentity.RoleArn == ""
+
+	if metadata["identityOwner"] == "operator" || operatorIdentity {
 		meta.podIdentityOwner = false
 	} else if metadata["identityOwner"] == "" || metadata["identityOwner"] == "pod" {
 		meta.podIdentityOwner = true
@@ -0,0 +1,45 @@
+package scalers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestGetAwsAuthorizationPodIdentity(t *testing.T) {
+	tests := []struct {
+		name                string
+		podIdentity         kedav1alpha1.AuthPodIdentity
+		authParams          map[string]string
+		expectedIdentityPod bool
+		expectedRoleArn     string
+	}{
+		{
+			name:                "role of the workload",
+			podIdentity:         kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS},
+			authParams:          map[string]string{"awsRoleArn": "arn:aws:iam::123456789012:role/workload"},
+			expectedIdentityPod: true,
+			expectedRoleArn:     "arn:aws:iam::123456789012:role/workload",
+		},
+		{
+			name:                "role of the TriggerAuthentication",
+			podIdentity:         kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS, RoleArn: "arn:aws:iam::123456789012:role/keda", IdentityOwner: kedav1alpha1.IdentityOwnerKeda},
+			authParams:          map[string]string{"awsRoleArn": "arn:aws:iam::123456789012:role/keda"},
+			expectedIdentityPod: true,
+			expectedRoleArn:     "arn:aws:iam::123456789012:role/keda",
+		},
+		{
+			name:        "identity of KEDA Operator",
+			podIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsKiam, IdentityOwner: kedav1alpha1.IdentityOwnerKeda},
+			authParams:  map[string]string{},
+		},
+	}
+	for _, test := range tests {
+		meta, err := getAwsAuthorization(test.podIdentity, test.authParams, map[string]string{}, map[string]string{})
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expectedIdentityPod, meta.podIdentityOwner, test.name)
+		assert.Equal(t, test.expectedRoleArn, meta.awsRoleArn, test.name)
+	}
+}
@@ -98,7 +98,7 @@ func parseAwsKinesisStreamMetadata(config *ScalerConfig, logger logr.Logger) (*a
 		meta.awsEndpoint = val
 	}
 
-	auth, err := getAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+	auth, err := getAwsAuthorization(config.PodIdentity, config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
 	if err != nil {
 		return nil, err
 	}
@@ -147,6 +147,11 @@ func (s *awsKinesisStreamScaler) GetMetricsAndActivity(ctx context.Context, metr
 	return []external_metrics.ExternalMetricValue{metric}, shardCount > s.metadata.activationTargetShardCount, nil
 }
 
+// GetMaxParallelism returns the number of open shards of the stream
+func (s *awsKinesisStreamScaler) GetMaxParallelism(context.Context) (int64, error) {
+	return s.GetAwsKinesisOpenShardCount()
+}
+
 // Get Kinesis open shard count
 func (s *awsKinesisStreamScaler) GetAwsKinesisOpenShardCount() (int64, error) {
 	input := &kinesis.DescribeStreamSummaryInput{
@@ -0,0 +1,49 @@
+package scalers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+// awsSigV4Service is the signing name of Amazon Managed Service for Prometheus
+const awsSigV4Service = "aps"
+
+// awsSigV4RoundTripper signs the requests with AWS Signature Version 4 before sending them by the next round tripper
+type awsSigV4RoundTripper struct {
+	next   http.RoundTripper
+	signer *v4.Signer
+	region string
+}
+
+func newAWSSigV4RoundTripper(next http.RoundTripper, awsRegion string, awsAuthorization awsAuthorizationMetadata) http.RoundTripper {
+	sess, cfg := getAwsConfig(awsRegion, "", awsAuthorization)
+	creds := cfg.Credentials
+	if creds == nil {
+		creds = sess.Config.Credentials
+	}
+	return newAWSSigV4RoundTripperWithCredentials(next, awsRegion, creds)
+}
+
+func newAWSSigV4RoundTripperWithCredentials(next http.RoundTripper, awsRegion string, creds *credentials.Credentials) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &awsSigV4RoundTripper{
+		next:   next,
+		signer: v4.NewSigner(creds),
+		region: awsRegion,
+	}
+}
+
+// RoundTrip signs a copy of the request, the queries of the scaler have no body
+func (rt *awsSigV4RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	signed := req.Clone(req.Context())
+	if _, err := rt.signer.Sign(signed, nil, awsSigV4Service, rt.region, time.Now()); err != nil {
+		return nil, fmt.Errorf("error signing request with aws signature version 4: %w", err)
+	}
+	return rt.next.RoundTrip(signed)
+}
@@ -25,8 +25,13 @@ import (
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
+// maxPeekMessages is the maximum number of messages returned by a single peek of Azure Storage Queue
+const maxPeekMessages = 32
+
 // GetAzureQueueLength returns the length of a queue in int, see https://learn.microsoft.com/en-us/azure/storage/queues/storage-dotnet-how-to-use-queues?tabs=dotnet#get-the-queue-length
-func GetAzureQueueLength(ctx context.Context, httpClient util.HTTPDoer, podIdentity kedav1alpha1.AuthPodIdentity, connectionString, queueName, accountName, endpointSuffix string) (int64, error) {
+// if visibleMessagesOnly is set the visible messages are peeked and counted, the approximate count including
+// the invisible messages is returned only if there are more visible messages than can be peeked at once
+func GetAzureQueueLength(ctx context.Context, httpClient util.HTTPDoer, podIdentity kedav1alpha1.AuthPodIdentity, connectionString, queueName, accountName, endpointSuffix string, visibleMessagesOnly bool) (int64, error) {
 	credential, endpoint, err := ParseAzureStorageQueueConnection(ctx, httpClient, podIdentity, connectionString, accountName, endpointSuffix)
 	if err != nil {
 		return -1, err
@@ -36,6 +41,16 @@ func GetAzureQueueLength(ctx context.Context, httpClient util.HTTPDoer, podIdent
 	serviceURL := azqueue.NewServiceURL(*endpoint, p)
 	queueURL := serviceURL.NewQueueURL(queueName)
 
+	if visibleMessagesOnly {
+		peeked, err := queueURL.NewMessagesURL().Peek(ctx, maxPeekMessages)
+		if err != nil {
+			return -1, err
+		}
+		if visible := peeked.NumMessages(); visible < maxPeekMessages {
+			return int64(visible), nil
+		}
+	}
+
 	props, err := queueURL.GetProperties(ctx)
 	if err != nil {
 		return -1, err
@@ -4,14 +4,16 @@ import (
 	"context"
 	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
 func TestGetQueueLength(t *testing.T) {
-	length, err := GetAzureQueueLength(context.TODO(), http.DefaultClient, kedav1alpha1.AuthPodIdentity{}, "", "queueName", "", "")
+	length, err := GetAzureQueueLength(context.TODO(), http.DefaultClient, kedav1alpha1.AuthPodIdentity{}, "", "queueName", "", "", false)
 	if length != -1 {
 		t.Error("Expected length to be -1, but got", length)
 	}
@@ -24,7 +26,7 @@ func TestGetQueueLength(t *testing.T) {
 		t.Error("Expected error to contain parsing error message, but got", err.Error())
 	}
 
-	length, err = GetAzureQueueLength(context.TODO(), http.DefaultClient, kedav1alpha1.AuthPodIdentity{}, "DefaultEndpointsProtocol=https;AccountName=name;AccountKey=key==;EndpointSuffix=core.windows.net", "queueName", "", "")
+	length, err = GetAzureQueueLength(context.TODO(), http.DefaultClient, kedav1alpha1.AuthPodIdentity{}, "DefaultEndpointsProtocol=https;AccountName=name;AccountKey=key==;EndpointSuffix=core.windows.net", "queueName", "", "", false)
 
 	if length != -1 {
 		t.Error("Expected length to be -1, but got", length)
@@ -39,3 +41,44 @@ func TestGetQueueLength(t *testing.T) {
 		t.Error("Expected error to contain base64 error message, but got", err.Error())
 	}
 }
+
+func TestGetQueueLengthVisibleMessagesOnly(t *testing.T) {
+	var visibleMessages int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("peekonly") == "true" {
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, "<QueueMessagesList>")
+			for i := 0; i < visibleMessages; i++ {
+				fmt.Fprintf(w, "<QueueMessage><MessageId>%d</MessageId><MessageText>m</MessageText></QueueMessage>", i)
+			}
+			fmt.Fprint(w, "</QueueMessagesList>")
+			return
+		}
+		// approximate count including invisible messages
+		w.Header().Set("x-ms-approximate-messages-count", "100")
+	}))
+	defer server.Close()
+
+	connectionString := fmt.Sprintf("QueueEndpoint=%s/name;AccountName=name;AccountKey=%s", server.URL, base64.StdEncoding.EncodeToString([]byte("key")))
+	tests := []struct {
+		visibleMessages     int
+		visibleMessagesOnly bool
+		expected            int64
+	}{
+		{visibleMessages: 3, visibleMessagesOnly: false, expected: 100},
+		{visibleMessages: 3, visibleMessagesOnly: true, expected: 3},
+		{visibleMessages: 0, visibleMessagesOnly: true, expected: 0},
+		// more visible messages than can be peeked
+		{visibleMessages: 32, visibleMessagesOnly: true, expected: 100},
+	}
+	for _, test := range tests {
+		visibleMessages = test.visibleMessages
+		length, err := GetAzureQueueLength(context.TODO(), http.DefaultClient, kedav1alpha1.AuthPodIdentity{}, connectionString, "queue", "", "", test.visibleMessagesOnly)
+		if err != nil {
+			t.Fatal("Expected success but got error", err)
+		}
+		if length != test.expected {
+			t.Errorf("Expected length %d for %d visible messages, but got %d", test.expected, test.visibleMessages, length)
+		}
+	}
+}
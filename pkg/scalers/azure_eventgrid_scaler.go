@@ -0,0 +1,164 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/eventgrid"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// azureEventGridScaler is a push scaler activated by events of Azure Event Grid delivered to the Event Grid receiver
+// of KEDA Operator, the metric is the number of matching events received within activeDuration
+type azureEventGridScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *azureEventGridMetadata
+	namespace  string
+	name       string
+	logger     logr.Logger
+
+	lock   sync.Mutex
+	events []time.Time
+	now    func() time.Time
+}
+
+type azureEventGridMetadata struct {
+	EventTypes                 []string `keda:"name=eventTypes, order=triggerMetadata, optional"`
+	SubjectBeginsWith          string   `keda:"name=subjectBeginsWith, order=triggerMetadata, optional"`
+	SubjectEndsWith            string   `keda:"name=subjectEndsWith, order=triggerMetadata, optional"`
+	ActiveDuration             string   `keda:"name=activeDuration, order=triggerMetadata, default=5m"`
+	TargetEventCount           int64    `keda:"name=targetEventCount, order=triggerMetadata, default=100"`
+	ActivationTargetEventCount int64    `keda:"name=activationTargetEventCount, order=triggerMetadata, optional"`
+	WebhookSecret              string   `keda:"name=webhookSecret, order=authParams, optional"`
+	activeDuration             time.Duration
+	scalerIndex                int
+}
+
+// NewAzureEventGridScaler creates a new Azure Event Grid Scaler
+func NewAzureEventGridScaler(config *ScalerConfig) (PushScaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseAzureEventGridMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing azure event grid metadata: %w", err)
+	}
+
+	return &azureEventGridScaler{
+		metricType: metricType,
+		metadata:   meta,
+		namespace:  config.ScalableObjectNamespace,
+		name:       config.ScalableObjectName,
+		logger:     InitializeLogger(config, "azure_eventgrid_scaler"),
+		now:        time.Now,
+	}, nil
+}
+
+func parseAzureEventGridMetadata(config *ScalerConfig) (*azureEventGridMetadata, error) {
+	meta := azureEventGridMetadata{}
+	if err := config.TypedConfig(&meta); err != nil {
+		return nil, err
+	}
+
+	activeDuration, err := time.ParseDuration(meta.ActiveDuration)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing activeDuration: %w", err)
+	}
+	if activeDuration <= 0 {
+		return nil, fmt.Errorf("activeDuration must be positive, got %s", meta.ActiveDuration)
+	}
+	meta.activeDuration = activeDuration
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+// Run subscribes to the events of the ScaledObject delivered to the Event Grid receiver and signals every matching
+// event, so the scale loop checks the ScaledObject immediately
+func (s *azureEventGridScaler) Run(ctx context.Context, active chan<- bool) {
+	defer close(active)
+
+	subscription, err := eventgrid.Subscribe(s.namespace, s.name, eventgrid.Filter{
+		EventTypes:        s.metadata.EventTypes,
+		SubjectBeginsWith: s.metadata.SubjectBeginsWith,
+		SubjectEndsWith:   s.metadata.SubjectEndsWith,
+		Secret:            s.metadata.WebhookSecret,
+	})
+	if err != nil {
+		s.logger.Error(err, "error subscribing to azure event grid events")
+		return
+	}
+	defer subscription.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+			s.logger.V(1).Info("received azure event grid event", "eventType", event.Type, "subject", event.Subject)
+			s.recordEvent()
+			select {
+			case active <- true:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func (s *azureEventGridScaler) recordEvent() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.events = append(s.pruneEvents(), s.now())
+}
+
+// pruneEvents drops events received before activeDuration, the lock must be held
+func (s *azureEventGridScaler) pruneEvents() []time.Time {
+	since := s.now().Add(-s.metadata.activeDuration)
+	i := 0
+	for i < len(s.events) && s.events[i].Before(since) {
+		i++
+	}
+	s.events = s.events[i:]
+	return s.events
+}
+
+func (s *azureEventGridScaler) getEventCount() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return int64(len(s.pruneEvents()))
+}
+
+func (s *azureEventGridScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	eventCount := s.getEventCount()
+	metric := GenerateMetricInMili(metricName, float64(eventCount))
+
+	return []external_metrics.ExternalMetricValue{metric}, eventCount > s.metadata.ActivationTargetEventCount, nil
+}
+
+func (s *azureEventGridScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("azure-eventgrid-%s", s.name))),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetEventCount),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *azureEventGridScaler) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,96 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/eventgrid"
+)
+
+type parseAzureEventGridMetadataTestData struct {
+	testName string
+	metadata map[string]string
+	isError  bool
+}
+
+var testAzureEventGridMetadata = []parseAzureEventGridMetadataTestData{
+	{"defaults", map[string]string{}, false},
+	{"properly formed", map[string]string{"eventTypes": "Microsoft.Storage.BlobCreated,Microsoft.Storage.BlobDeleted", "subjectBeginsWith": "/blobServices/default/containers/uploads/", "activeDuration": "90s", "targetEventCount": "10", "activationTargetEventCount": "2"}, false},
+	{"invalid activeDuration", map[string]string{"activeDuration": "soon"}, true},
+	{"negative activeDuration", map[string]string{"activeDuration": "-1m"}, true},
+	{"invalid targetEventCount", map[string]string{"targetEventCount": "a"}, true},
+}
+
+func TestAzureEventGridParseMetadata(t *testing.T) {
+	for _, testData := range testAzureEventGridMetadata {
+		t.Run(testData.testName, func(t *testing.T) {
+			_, err := parseAzureEventGridMetadata(&ScalerConfig{TriggerMetadata: testData.metadata})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAzureEventGridGetMetricSpecForScaling(t *testing.T) {
+	scaler, err := NewAzureEventGridScaler(&ScalerConfig{
+		TriggerMetadata:         map[string]string{},
+		ScalableObjectNamespace: "default",
+		ScalableObjectName:      "worker",
+		ScalerIndex:             1,
+	})
+	assert.NoError(t, err)
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s1-azure-eventgrid-worker", metricSpec[0].External.Metric.Name)
+}
+
+func TestAzureEventGridActivatedByEvents(t *testing.T) {
+	receiver := eventgrid.NewReceiver("")
+	eventgrid.SetDefaultReceiver(receiver)
+	defer eventgrid.SetDefaultReceiver(nil)
+
+	scaler, err := NewAzureEventGridScaler(&ScalerConfig{
+		TriggerMetadata:         map[string]string{"eventTypes": "Microsoft.Storage.BlobCreated", "activeDuration": "1m"},
+		ScalableObjectNamespace: "default",
+		ScalableObjectName:      "worker",
+	})
+	assert.NoError(t, err)
+	now := time.Now()
+	scaler.(*azureEventGridScaler).now = func() time.Time { return now }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	active := make(chan bool)
+	go scaler.Run(ctx, active)
+
+	// the subscription is established asynchronously by Run, the receiver responds 404 until then
+	assert.Eventually(t, func() bool {
+		recorder := httptest.NewRecorder()
+		body := strings.NewReader(`[{"eventType": "Microsoft.Storage.BlobCreated", "subject": "a.png"}]`)
+		receiver.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/default/worker", body))
+		return recorder.Code == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+	assert.True(t, <-active)
+
+	_, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-azure-eventgrid-worker")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+
+	// events are forgotten after activeDuration
+	now = now.Add(2 * time.Minute)
+	metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-azure-eventgrid-worker")
+	assert.NoError(t, err)
+	assert.False(t, isActive)
+	assert.Equal(t, int64(0), metrics[0].Value.Value())
+
+	cancel()
+	_, ok := <-active
+	assert.False(t, ok)
+}
@@ -360,6 +360,15 @@ func (s *azureEventHubScaler) Close(ctx context.Context) error {
 	return nil
 }
 
+// GetMaxParallelism returns the number of partitions of the event hub
+func (s *azureEventHubScaler) GetMaxParallelism(ctx context.Context) (int64, error) {
+	runtimeInfo, err := s.client.GetRuntimeInformation(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get runtimeInfo: %w", err)
+	}
+	return int64(len(runtimeInfo.PartitionIDs)), nil
+}
+
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *azureEventHubScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	totalUnprocessedEventCount := int64(0)
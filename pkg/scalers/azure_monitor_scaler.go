@@ -29,6 +29,8 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/azure"
+	"github.com/kedacore/keda/v2/pkg/scalers/metricsproxy"
+	"github.com/kedacore/keda/v2/pkg/scalers/ratelimiter"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -49,6 +51,7 @@ type azureMonitorMetadata struct {
 	azureMonitorInfo      azure.MonitorInfo
 	targetValue           float64
 	activationTargetValue float64
+	metricsProxyKey       string
 	scalerIndex           int
 }
 
@@ -166,6 +169,12 @@ func parseAzureMonitorMetadata(config *ScalerConfig, logger logr.Logger) (*azure
 	meta.azureMonitorInfo.ClientID = clientID
 	meta.azureMonitorInfo.ClientPassword = clientPassword
 
+	metricsProxyKey, err := getMetricsProxyKey(config, targetValueName, activationTargetValueName)
+	if err != nil {
+		return nil, err
+	}
+	meta.metricsProxyKey = metricsProxyKey
+
 	meta.scalerIndex = config.ScalerIndex
 
 	azureResourceManagerEndpointProvider := func(env az.Environment) (string, error) {
@@ -230,7 +239,13 @@ func (s *azureMonitorScaler) GetMetricSpecForScaling(context.Context) []v2.Metri
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *azureMonitorScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	val, err := azure.GetAzureMetricValue(ctx, s.metadata.azureMonitorInfo, s.podIdentity)
+	val, err := metricsproxy.Query(ctx, "azure-monitor", s.metadata.metricsProxyKey, func(ctx context.Context) (float64, error) {
+		// Azure Resource Manager limits reads per subscription
+		if err := ratelimiter.Wait(ctx, "azure-monitor", ratelimiter.AccountKey(s.metadata.azureMonitorInfo.SubscriptionID)); err != nil {
+			return 0, err
+		}
+		return azure.GetAzureMetricValue(ctx, s.metadata.azureMonitorInfo, s.podIdentity)
+	})
 	if err != nil {
 		s.logger.Error(err, "error getting azure monitor metric")
 		return []external_metrics.ExternalMetricValue{}, false, err
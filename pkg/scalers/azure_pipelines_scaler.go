@@ -10,15 +10,21 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/azure"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 const (
 	defaultTargetPipelinesQueueLength = 1
+	// azureDevOpsScope is the scope of Azure AD tokens of Azure DevOps REST API
+	azureDevOpsScope = "499b84ac-1321-427f-aa17-267ca6975798/.default"
 )
 
 type JobRequests struct {
@@ -128,6 +134,7 @@ type azurePipelinesMetadata struct {
 	organizationURL                      string
 	organizationName                     string
 	personalAccessToken                  string
+	credential                           azcore.TokenCredential
 	parent                               string
 	demands                              string
 	poolID                               int
@@ -140,7 +147,10 @@ type azurePipelinesMetadata struct {
 
 // NewAzurePipelinesScaler creates a new AzurePipelinesScaler
 func NewAzurePipelinesScaler(ctx context.Context, config *ScalerConfig) (Scaler, error) {
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient, err := newHTTPClientFromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Azure Pipelines HTTP client: %w", err)
+	}
 
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -198,13 +208,25 @@ func parseAzurePipelinesMetadata(ctx context.Context, config *ScalerConfig, http
 		return nil, fmt.Errorf("failed to extract organization name from organizationURL")
 	}
 
-	if val, ok := config.AuthParams["personalAccessToken"]; ok && val != "" {
-		// Found the personalAccessToken in a parameter from TriggerAuthentication
-		meta.personalAccessToken = config.AuthParams["personalAccessToken"]
-	} else if val, ok := config.TriggerMetadata["personalAccessTokenFromEnv"]; ok && val != "" {
-		meta.personalAccessToken = config.ResolvedEnv[config.TriggerMetadata["personalAccessTokenFromEnv"]]
-	} else {
-		return nil, fmt.Errorf("no personalAccessToken given")
+	switch config.PodIdentity.Provider {
+	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+		// requests are authorized by Azure AD tokens of the identity instead of personalAccessToken
+		credential, err := azure.NewChainedCredential(config.PodIdentity.IdentityID, config.PodIdentity.Provider)
+		if err != nil {
+			return nil, err
+		}
+		meta.credential = credential
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		if val, ok := config.AuthParams["personalAccessToken"]; ok && val != "" {
+			// Found the personalAccessToken in a parameter from TriggerAuthentication
+			meta.personalAccessToken = config.AuthParams["personalAccessToken"]
+		} else if val, ok := config.TriggerMetadata["personalAccessTokenFromEnv"]; ok && val != "" {
+			meta.personalAccessToken = config.ResolvedEnv[config.TriggerMetadata["personalAccessTokenFromEnv"]]
+		} else {
+			return nil, fmt.Errorf("no personalAccessToken given")
+		}
+	default:
+		return nil, fmt.Errorf("pod identity %s not supported for azure pipelines", config.PodIdentity.Provider)
 	}
 
 	if val, ok := config.TriggerMetadata["parent"]; ok && val != "" {
@@ -311,7 +333,15 @@ func getAzurePipelineRequest(ctx context.Context, url string, metadata *azurePip
 		return []byte{}, err
 	}
 
-	req.SetBasicAuth("", metadata.personalAccessToken)
+	if metadata.credential != nil {
+		token, err := metadata.credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{azureDevOpsScope}})
+		if err != nil {
+			return []byte{}, fmt.Errorf("error getting Azure AD token of Azure DevOps: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token.Token)
+	} else {
+		req.SetBasicAuth("", metadata.personalAccessToken)
+	}
 
 	r, err := httpClient.Do(req)
 	if err != nil {
@@ -6,6 +6,11 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
 const loadCount = 1000 // the size of the pretend pool completed of job requests
@@ -79,6 +84,40 @@ func TestParseAzurePipelinesMetadata(t *testing.T) {
 	}
 }
 
+type fakeAzureDevOpsCredential struct{}
+
+func (fakeAzureDevOpsCredential) GetToken(_ context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "aad-token-of-" + opts.Scopes[0]}, nil
+}
+
+func TestAzurePipelinesPodIdentityAuthorization(t *testing.T) {
+	var authorization string
+	apiStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"count":1,"value":[{"id":1}]}`))
+	}))
+	defer apiStub.Close()
+
+	meta := &azurePipelinesMetadata{organizationURL: apiStub.URL, credential: fakeAzureDevOpsCredential{}}
+	_, err := getAzurePipelineRequest(context.TODO(), apiStub.URL, meta, http.DefaultClient)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if authorization != "Bearer aad-token-of-"+azureDevOpsScope {
+		t.Errorf("Expected bearer token of Azure DevOps scope but got %s", authorization)
+	}
+
+	// personalAccessToken isn't required with azure pod identities, other identities aren't supported
+	config := &ScalerConfig{
+		TriggerMetadata: map[string]string{"poolID": "1"},
+		AuthParams:      map[string]string{"organizationURL": apiStub.URL},
+		PodIdentity:     kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS},
+	}
+	if _, err := parseAzurePipelinesMetadata(context.TODO(), config, http.DefaultClient); err == nil {
+		t.Error("Expected error for unsupported pod identity but got success")
+	}
+}
+
 type validateAzurePipelinesPoolTestData struct {
 	testName   string
 	metadata   map[string]string
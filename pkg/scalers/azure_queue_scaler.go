@@ -53,7 +53,10 @@ type azureQueueMetadata struct {
 	connection                  string
 	accountName                 string
 	endpointSuffix              string
-	scalerIndex                 int
+	// visibleMessagesOnly counts only the visible messages instead of the approximate count including the messages
+	// being processed, the approximate count is used if there are more visible messages than can be peeked
+	visibleMessagesOnly bool
+	scalerIndex         int
 }
 
 // NewAzureQueueScaler creates a new scaler for queue
@@ -106,6 +109,16 @@ func parseAzureQueueMetadata(config *ScalerConfig, logger logr.Logger) (*azureQu
 		meta.activationTargetQueueLength = activationQueueLength
 	}
 
+	if val, ok := config.TriggerMetadata["visibleMessagesOnly"]; ok && val != "" {
+		visibleMessagesOnly, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, kedav1alpha1.AuthPodIdentity{},
+				fmt.Errorf("error parsing azure queue metadata visibleMessagesOnly: %w", err)
+		}
+
+		meta.visibleMessagesOnly = visibleMessagesOnly
+	}
+
 	endpointSuffix, err := azure.ParseAzureStorageEndpointSuffix(config.TriggerMetadata, azure.QueueEndpoint)
 	if err != nil {
 		return nil, kedav1alpha1.AuthPodIdentity{}, err
@@ -183,6 +196,7 @@ func (s *azureQueueScaler) GetMetricsAndActivity(ctx context.Context, metricName
 		s.metadata.queueName,
 		s.metadata.accountName,
 		s.metadata.endpointSuffix,
+		s.metadata.visibleMessagesOnly,
 	)
 
 	if err != nil {
@@ -95,6 +95,10 @@ var testAzQueueMetadata = []parseAzQueueMetadataTestData{
 	{map[string]string{"accountName": "sample_acc", "queueName": "sample_queue", "cloud": "", "endpointSuffix": "ignored"}, false, testAzQueueResolvedEnv, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
 	// connection from authParams
 	{map[string]string{"queueName": "sample", "queueLength": "5"}, false, testAzQueueResolvedEnv, map[string]string{"connection": "value"}, kedav1alpha1.PodIdentityProviderNone},
+	// visibleMessagesOnly
+	{map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "visibleMessagesOnly": "true"}, false, testAzQueueResolvedEnv, map[string]string{}, ""},
+	// invalid visibleMessagesOnly
+	{map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "visibleMessagesOnly": "AA"}, true, testAzQueueResolvedEnv, map[string]string{}, ""},
 }
 
 var azQueueMetricIdentifiers = []azQueueMetricIdentifier{
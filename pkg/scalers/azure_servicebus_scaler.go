@@ -19,10 +19,21 @@ limitations under the License.
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
-
+	"time"
+
+	"github.com/Azure/azure-amqp-common-go/v3/auth"
+	"github.com/Azure/azure-amqp-common-go/v3/cbs"
+	"github.com/Azure/azure-amqp-common-go/v3/conn"
+	"github.com/Azure/azure-amqp-common-go/v3/rpc"
+	"github.com/Azure/azure-amqp-common-go/v3/sas"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
 	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/Azure/go-amqp"
 	az "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -42,15 +53,66 @@ const (
 	messageCountMetricName                      = "messageCount"
 	activationMessageCountMetricName            = "activationMessageCount"
 	defaultTargetMessageCount                   = 5
+	defaultPeekLimit                            = 1000
+	// peekBatchSize is the number of messages peeked in a single request
+	peekBatchSize = 250
+)
+
+const (
+	// primaryServiceBusNamespace and secondaryServiceBusNamespace identify the namespaces of a Geo-DR pair in failover
+	primaryServiceBusNamespace   = "primary"
+	secondaryServiceBusNamespace = "secondary"
+)
+
+// serviceBusFailoverCooldown is how long a failing namespace isn't preferred when a secondary namespace is given
+var serviceBusFailoverCooldown = 30 * time.Second
+
+const (
+	// runtimePropertiesCountMode reads the message count from the runtime properties of the entity, requires Manage rights
+	runtimePropertiesCountMode = "runtimeProperties"
+	// peekCountMode counts the messages by peeking them, requires only Listen rights
+	peekCountMode = "peek"
+	// sessionsCountMode counts the sessions with active messages by enumerating them, for session-enabled entities whose
+	// sessions are processed one by one, requires only Listen rights
+	sessionsCountMode = "sessions"
+)
+
+const (
+	// getMessageSessionsOperation enumerates the sessions of an entity through its management node
+	getMessageSessionsOperation = "com.microsoft:get-message-sessions"
+	// sessionsPageSize is the number of sessions enumerated in a single request
+	sessionsPageSize = 100
+	// serviceBusScope is the scope of the tokens of pod identities put on the AMQP connection
+	serviceBusScope = "https://servicebus.azure.net//.default"
 )
 
+// activeSessionsLastUpdatedTime is DateTime.MaxValue of .NET, the management node returns only the sessions
+// with messages when it's given as the last updated time of the sessions
+var activeSessionsLastUpdatedTime = time.Date(9999, time.December, 31, 23, 59, 59, 999000000, time.UTC)
+
 type azureServiceBusScaler struct {
 	ctx         context.Context
 	metricType  v2.MetricTargetType
 	metadata    *azureServiceBusMetadata
 	podIdentity kedav1alpha1.AuthPodIdentity
-	client      *admin.Client
-	logger      logr.Logger
+	// namespaces are the primary namespace and the optional secondary namespace of a Geo-DR pair,
+	// keyed by primaryServiceBusNamespace and secondaryServiceBusNamespace
+	namespaces map[string]*serviceBusNamespace
+	// failover is set only if the secondary namespace is given
+	failover *kedautil.EndpointFailover
+	logger   logr.Logger
+}
+
+// serviceBusNamespace is a namespace the entity is queried in, with the clients connected to it
+type serviceBusNamespace struct {
+	connection              string
+	fullyQualifiedNamespace string
+	client                  *admin.Client
+	// peekClient and receiver are used to peek messages when the message count mode is peek
+	peekClient *azservicebus.Client
+	receiver   *azservicebus.Receiver
+	// sessionLister is used to enumerate sessions when the message count mode is sessions
+	sessionLister *amqpSessionLister
 }
 
 type azureServiceBusMetadata struct {
@@ -62,10 +124,16 @@ type azureServiceBusMetadata struct {
 	connection              string
 	entityType              entityType
 	fullyQualifiedNamespace string
-	useRegex                bool
-	entityNameRegex         *regexp.Regexp
-	operation               string
-	scalerIndex             int
+	// secondaryConnection and secondaryFullyQualifiedNamespace identify the secondary namespace of a Geo-DR pair,
+	// the entity is queried there while the primary namespace is unavailable
+	secondaryConnection              string
+	secondaryFullyQualifiedNamespace string
+	useRegex                         bool
+	entityNameRegex                  *regexp.Regexp
+	operation                        string
+	messageCountMode                 string
+	peekLimit                        int64
+	scalerIndex                      int
 }
 
 // NewAzureServiceBusScaler creates a new AzureServiceBusScaler
@@ -82,13 +150,32 @@ func NewAzureServiceBusScaler(ctx context.Context, config *ScalerConfig) (Scaler
 		return nil, fmt.Errorf("error parsing azure service bus metadata: %w", err)
 	}
 
-	return &azureServiceBusScaler{
+	scaler := &azureServiceBusScaler{
 		ctx:         ctx,
 		metricType:  metricType,
 		metadata:    meta,
 		podIdentity: config.PodIdentity,
+		namespaces:  newServiceBusNamespaces(meta),
 		logger:      logger,
-	}, nil
+	}
+	if _, ok := scaler.namespaces[secondaryServiceBusNamespace]; ok {
+		scaler.failover = kedautil.NewEndpointFailover([]string{primaryServiceBusNamespace, secondaryServiceBusNamespace}, serviceBusFailoverCooldown)
+	}
+	return scaler, nil
+}
+
+// newServiceBusNamespaces returns the primary namespace and the secondary namespace if it's given
+func newServiceBusNamespaces(meta *azureServiceBusMetadata) map[string]*serviceBusNamespace {
+	namespaces := map[string]*serviceBusNamespace{
+		primaryServiceBusNamespace: {connection: meta.connection, fullyQualifiedNamespace: meta.fullyQualifiedNamespace},
+	}
+	if meta.secondaryConnection != "" || meta.secondaryFullyQualifiedNamespace != "" {
+		namespaces[secondaryServiceBusNamespace] = &serviceBusNamespace{
+			connection:              meta.secondaryConnection,
+			fullyQualifiedNamespace: meta.secondaryFullyQualifiedNamespace,
+		}
+	}
+	return namespaces
 }
 
 // Creates an azureServiceBusMetadata struct from input metadata/env variables
@@ -186,6 +273,29 @@ func parseAzureServiceBusMetadata(config *ScalerConfig, logger logr.Logger) (*az
 		return nil, fmt.Errorf("no service bus entity type set")
 	}
 
+	meta.messageCountMode = runtimePropertiesCountMode
+	if val, ok := config.TriggerMetadata["messageCountMode"]; ok && val != "" {
+		switch val {
+		case runtimePropertiesCountMode, peekCountMode, sessionsCountMode:
+			meta.messageCountMode = val
+		default:
+			return nil, fmt.Errorf("messageCountMode must be one of %s, %s or %s", runtimePropertiesCountMode, peekCountMode, sessionsCountMode)
+		}
+	}
+
+	if meta.messageCountMode != runtimePropertiesCountMode && meta.useRegex {
+		return nil, fmt.Errorf("useRegex is not supported with messageCountMode %s", meta.messageCountMode)
+	}
+
+	meta.peekLimit = defaultPeekLimit
+	if val, ok := config.TriggerMetadata["peekLimit"]; ok && val != "" {
+		peekLimit, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || peekLimit <= 0 {
+			return nil, fmt.Errorf("peekLimit must be a positive number, got %s", val)
+		}
+		meta.peekLimit = peekLimit
+	}
+
 	switch config.PodIdentity.Provider {
 	case "", kedav1alpha1.PodIdentityProviderNone:
 		// get servicebus connection string
@@ -198,6 +308,16 @@ func parseAzureServiceBusMetadata(config *ScalerConfig, logger logr.Logger) (*az
 		if len(meta.connection) == 0 {
 			return nil, fmt.Errorf("no connection setting given")
 		}
+
+		// get connection string of the secondary namespace of a Geo-DR pair
+		if config.AuthParams["secondaryConnection"] != "" {
+			meta.secondaryConnection = config.AuthParams["secondaryConnection"]
+		} else if config.TriggerMetadata["secondaryConnectionFromEnv"] != "" {
+			meta.secondaryConnection = config.ResolvedEnv[config.TriggerMetadata["secondaryConnectionFromEnv"]]
+			if len(meta.secondaryConnection) == 0 {
+				return nil, fmt.Errorf("no secondary connection setting given")
+			}
+		}
 	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
 		if val, ok := config.TriggerMetadata["namespace"]; ok {
 			envSuffixProvider := func(env az.Environment) (string, error) {
@@ -209,6 +329,9 @@ func parseAzureServiceBusMetadata(config *ScalerConfig, logger logr.Logger) (*az
 				return nil, err
 			}
 			meta.fullyQualifiedNamespace = fmt.Sprintf("%s.%s", val, endpointSuffix)
+			if secondary := config.TriggerMetadata["secondaryNamespace"]; secondary != "" {
+				meta.secondaryFullyQualifiedNamespace = fmt.Sprintf("%s.%s", secondary, endpointSuffix)
+			}
 		} else {
 			return nil, fmt.Errorf("namespace are required when using pod identity")
 		}
@@ -222,9 +345,32 @@ func parseAzureServiceBusMetadata(config *ScalerConfig, logger logr.Logger) (*az
 	return &meta, nil
 }
 
-// Close - nothing to close for SB
-func (s *azureServiceBusScaler) Close(context.Context) error {
-	return nil
+// Close closes the receivers and clients used to peek messages and enumerate sessions
+func (s *azureServiceBusScaler) Close(ctx context.Context) error {
+	var closeErr error
+	for _, namespace := range s.namespaces {
+		if namespace.sessionLister != nil {
+			if err := namespace.sessionLister.Close(ctx); err != nil {
+				s.logger.Error(err, "error closing service bus session lister")
+				closeErr = err
+			}
+			namespace.sessionLister = nil
+		}
+		if namespace.receiver != nil {
+			if err := namespace.receiver.Close(ctx); err != nil {
+				s.logger.Error(err, "error closing service bus receiver")
+			}
+			namespace.receiver = nil
+		}
+		if namespace.peekClient != nil {
+			if err := namespace.peekClient.Close(ctx); err != nil {
+				s.logger.Error(err, "error closing service bus client")
+				closeErr = err
+			}
+			namespace.peekClient = nil
+		}
+	}
+	return closeErr
 }
 
 // Returns the metric spec to be used by the HPA
@@ -268,10 +414,42 @@ func (s *azureServiceBusScaler) GetMetricsAndActivity(ctx context.Context, metri
 	return []external_metrics.ExternalMetricValue{metric}, queuelen > s.metadata.activationTargetLength, nil
 }
 
-// Returns the length of the queue or subscription
+// Returns the length of the queue or subscription, the secondary namespace of a Geo-DR pair is queried
+// if the primary namespace fails
 func (s *azureServiceBusScaler) getAzureServiceBusLength(ctx context.Context) (int64, error) {
+	if s.failover == nil {
+		return s.getNamespaceLength(ctx, s.namespaces[primaryServiceBusNamespace])
+	}
+
+	var err error
+	for _, name := range s.failover.Endpoints() {
+		var length int64
+		length, err = s.getNamespaceLength(ctx, s.namespaces[name])
+		if err == nil {
+			s.failover.ReportSuccess(name)
+			return length, nil
+		}
+		s.failover.ReportFailure(name)
+		s.logger.V(1).Info("service bus namespace is unavailable, trying next namespace", "namespace", name, "error", err)
+	}
+	return -1, err
+}
+
+// Returns the length of the queue or subscription in the namespace
+func (s *azureServiceBusScaler) getNamespaceLength(ctx context.Context, namespace *serviceBusNamespace) (int64, error) {
+	switch s.metadata.messageCountMode {
+	case peekCountMode:
+		return s.getPeekedMessageCount(ctx, namespace)
+	case sessionsCountMode:
+		lister, err := s.getSessionLister(namespace)
+		if err != nil {
+			return -1, err
+		}
+		return countActiveSessions(ctx, lister)
+	}
+
 	// get adminClient
-	adminClient, err := s.getServiceBusAdminClient()
+	adminClient, err := s.getServiceBusAdminClient(namespace)
 	if err != nil {
 		return -1, err
 	}
@@ -287,29 +465,315 @@ func (s *azureServiceBusScaler) getAzureServiceBusLength(ctx context.Context) (i
 }
 
 // Returns service bus namespace object
-func (s *azureServiceBusScaler) getServiceBusAdminClient() (*admin.Client, error) {
-	if s.client != nil {
-		return s.client, nil
+func (s *azureServiceBusScaler) getServiceBusAdminClient(namespace *serviceBusNamespace) (*admin.Client, error) {
+	if namespace.client != nil {
+		return namespace.client, nil
 	}
 	var err error
 	var client *admin.Client
 	switch s.podIdentity.Provider {
 	case "", kedav1alpha1.PodIdentityProviderNone:
-		client, err = admin.NewClientFromConnectionString(s.metadata.connection, nil)
+		client, err = admin.NewClientFromConnectionString(namespace.connection, nil)
 	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
 		creds, chainedErr := azure.NewChainedCredential(s.podIdentity.IdentityID, s.podIdentity.Provider)
 		if chainedErr != nil {
 			return nil, chainedErr
 		}
-		client, err = admin.NewClient(s.metadata.fullyQualifiedNamespace, creds, nil)
+		client, err = admin.NewClient(namespace.fullyQualifiedNamespace, creds, nil)
 	default:
 		err = fmt.Errorf("incorrect podIdentity type")
 	}
 
-	s.client = client
+	namespace.client = client
 	return client, err
 }
 
+// Returns the service bus receiver of the queue or subscription used to peek messages
+func (s *azureServiceBusScaler) getServiceBusReceiver(namespace *serviceBusNamespace) (*azservicebus.Receiver, error) {
+	if namespace.receiver != nil {
+		return namespace.receiver, nil
+	}
+	if namespace.peekClient == nil {
+		var err error
+		var client *azservicebus.Client
+		switch s.podIdentity.Provider {
+		case "", kedav1alpha1.PodIdentityProviderNone:
+			client, err = azservicebus.NewClientFromConnectionString(namespace.connection, nil)
+		case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+			creds, chainedErr := azure.NewChainedCredential(s.podIdentity.IdentityID, s.podIdentity.Provider)
+			if chainedErr != nil {
+				return nil, chainedErr
+			}
+			client, err = azservicebus.NewClient(namespace.fullyQualifiedNamespace, creds, nil)
+		default:
+			err = fmt.Errorf("incorrect podIdentity type")
+		}
+		if err != nil {
+			return nil, err
+		}
+		namespace.peekClient = client
+	}
+
+	var err error
+	var receiver *azservicebus.Receiver
+	switch s.metadata.entityType {
+	case queue:
+		receiver, err = namespace.peekClient.NewReceiverForQueue(s.metadata.queueName, nil)
+	case subscription:
+		receiver, err = namespace.peekClient.NewReceiverForSubscription(s.metadata.topicName, s.metadata.subscriptionName, nil)
+	default:
+		err = fmt.Errorf("no entity type")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	namespace.receiver = receiver
+	return receiver, nil
+}
+
+// Returns the number of active messages of the queue or subscription by peeking them, which requires only Listen rights,
+// the messages are peeked from the beginning of the entity and counted up to the peek limit
+func (s *azureServiceBusScaler) getPeekedMessageCount(ctx context.Context, namespace *serviceBusNamespace) (int64, error) {
+	receiver, err := s.getServiceBusReceiver(namespace)
+	if err != nil {
+		return -1, err
+	}
+
+	var count, peeked int64
+	var sequenceNumber int64
+	for peeked < s.metadata.peekLimit {
+		batchSize := peekBatchSize
+		if remaining := s.metadata.peekLimit - peeked; remaining < int64(batchSize) {
+			batchSize = int(remaining)
+		}
+
+		messages, err := receiver.PeekMessages(ctx, batchSize, &azservicebus.PeekMessagesOptions{FromSequenceNumber: &sequenceNumber})
+		if err != nil {
+			return -1, err
+		}
+		if len(messages) == 0 {
+			break
+		}
+
+		count += countActiveMessages(messages)
+		peeked += int64(len(messages))
+
+		last := messages[len(messages)-1].SequenceNumber
+		if last == nil {
+			break
+		}
+		sequenceNumber = *last + 1
+	}
+
+	return count, nil
+}
+
+// countActiveMessages returns the number of peeked messages which are neither deferred nor scheduled
+func countActiveMessages(messages []*azservicebus.ReceivedMessage) int64 {
+	var count int64
+	for _, message := range messages {
+		if message.State == azservicebus.MessageStateActive {
+			count++
+		}
+	}
+	return count
+}
+
+// Returns the session lister of the queue or subscription, the Azure SDK doesn't support enumerating sessions
+// so they're enumerated through the management node of the entity over a dedicated AMQP connection
+func (s *azureServiceBusScaler) getSessionLister(namespace *serviceBusNamespace) (*amqpSessionLister, error) {
+	if namespace.sessionLister != nil {
+		return namespace.sessionLister, nil
+	}
+
+	var host string
+	var tokenProvider auth.TokenProvider
+	switch s.podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		parsed, err := conn.ParsedConnectionFromStr(namespace.connection)
+		if err != nil {
+			return nil, err
+		}
+		host = parsed.Host
+		tokenProvider, err = sas.NewTokenProvider(sas.TokenProviderWithKey(parsed.KeyName, parsed.Key))
+		if err != nil {
+			return nil, err
+		}
+	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+		creds, err := azure.NewChainedCredential(s.podIdentity.IdentityID, s.podIdentity.Provider)
+		if err != nil {
+			return nil, err
+		}
+		host = fmt.Sprintf("amqps://%s", namespace.fullyQualifiedNamespace)
+		tokenProvider = &credentialTokenProvider{ctx: s.ctx, credential: creds}
+	default:
+		return nil, fmt.Errorf("incorrect podIdentity type")
+	}
+
+	var entityPath string
+	switch s.metadata.entityType {
+	case queue:
+		entityPath = s.metadata.queueName
+	case subscription:
+		entityPath = fmt.Sprintf("%s/Subscriptions/%s", s.metadata.topicName, s.metadata.subscriptionName)
+	default:
+		return nil, fmt.Errorf("no entity type")
+	}
+
+	namespace.sessionLister = &amqpSessionLister{
+		host:              host,
+		audience:          fmt.Sprintf("%s/%s", host, entityPath),
+		managementAddress: fmt.Sprintf("%s/$management", entityPath),
+		tokenProvider:     tokenProvider,
+	}
+	return namespace.sessionLister, nil
+}
+
+// sessionLister enumerates the sessions with active messages of a queue or subscription, it returns the ids of
+// at most top sessions following the first skip sessions and the skip of the next page
+type sessionLister interface {
+	ListSessions(ctx context.Context, skip, top int32) ([]string, int32, error)
+}
+
+// countActiveSessions enumerates the sessions with active messages page by page and returns their count
+func countActiveSessions(ctx context.Context, lister sessionLister) (int64, error) {
+	var count int64
+	var skip int32
+	for {
+		sessionIDs, next, err := lister.ListSessions(ctx, skip, sessionsPageSize)
+		if err != nil {
+			return -1, err
+		}
+		if len(sessionIDs) == 0 {
+			return count, nil
+		}
+		count += int64(len(sessionIDs))
+
+		// don't enumerate the same page again if the next skip isn't given
+		if next <= skip {
+			next = skip + int32(len(sessionIDs))
+		}
+		skip = next
+	}
+}
+
+// amqpSessionLister enumerates the sessions of an entity with the get-message-sessions operation of its management node,
+// the connection is kept between the enumerations and is reopened after an error
+type amqpSessionLister struct {
+	host              string
+	audience          string
+	managementAddress string
+	tokenProvider     auth.TokenProvider
+
+	client *amqp.Client
+	link   *rpc.Link
+}
+
+// ListSessions returns the ids of a page of sessions with active messages and the skip of the next page
+func (l *amqpSessionLister) ListSessions(ctx context.Context, skip, top int32) ([]string, int32, error) {
+	if err := l.connect(ctx); err != nil {
+		_ = l.Close(ctx)
+		return nil, 0, err
+	}
+
+	request := &amqp.Message{
+		ApplicationProperties: map[string]interface{}{
+			"operation": getMessageSessionsOperation,
+		},
+		Value: map[string]interface{}{
+			"last-updated-time": activeSessionsLastUpdatedTime,
+			"skip":              skip,
+			"top":               top,
+		},
+	}
+	response, err := l.link.RPC(ctx, request)
+	if err != nil {
+		_ = l.Close(ctx)
+		return nil, 0, err
+	}
+	return parseMessageSessionsResponse(response)
+}
+
+// connect opens the connection and the management link if they aren't open yet, the token is put before each
+// enumeration as it expires while the connection is kept
+func (l *amqpSessionLister) connect(ctx context.Context) error {
+	if l.client == nil {
+		client, err := amqp.Dial(l.host, amqp.ConnSASLAnonymous())
+		if err != nil {
+			return err
+		}
+		l.client = client
+	}
+
+	if err := cbs.NegotiateClaim(ctx, l.audience, l.client, l.tokenProvider); err != nil {
+		return err
+	}
+
+	if l.link == nil {
+		link, err := rpc.NewLink(l.client, l.managementAddress)
+		if err != nil {
+			return err
+		}
+		l.link = link
+	}
+	return nil
+}
+
+// Close closes the management link and the connection
+func (l *amqpSessionLister) Close(ctx context.Context) error {
+	var closeErr error
+	if l.link != nil {
+		closeErr = l.link.Close(ctx)
+		l.link = nil
+	}
+	if l.client != nil {
+		if err := l.client.Close(); err != nil {
+			closeErr = err
+		}
+		l.client = nil
+	}
+	return closeErr
+}
+
+// parseMessageSessionsResponse returns the session ids and the skip of the next page of a get-message-sessions response,
+// the management node responds with no content once all the sessions are enumerated
+func parseMessageSessionsResponse(response *rpc.Response) ([]string, int32, error) {
+	switch response.Code {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		return nil, 0, nil
+	default:
+		return nil, 0, fmt.Errorf("error enumerating sessions, status code %d: %s", response.Code, response.Description)
+	}
+
+	value, ok := response.Message.Value.(map[string]interface{})
+	if !ok {
+		return nil, 0, fmt.Errorf("unexpected body of the sessions enumeration %T", response.Message.Value)
+	}
+	sessionIDs, ok := value["sessions-ids"].([]string)
+	if !ok {
+		return nil, 0, fmt.Errorf("unexpected session ids of the sessions enumeration %T", value["sessions-ids"])
+	}
+	skip, _ := value["skip"].(int32)
+	return sessionIDs, skip, nil
+}
+
+// credentialTokenProvider gets the tokens put on the AMQP connection from the credential of the pod identity
+type credentialTokenProvider struct {
+	ctx        context.Context
+	credential azcore.TokenCredential
+}
+
+// GetToken returns a token of the pod identity for Service Bus, which is valid for all the entities
+func (p *credentialTokenProvider) GetToken(string) (*auth.Token, error) {
+	token, err := p.credential.GetToken(p.ctx, policy.TokenRequestOptions{Scopes: []string{serviceBusScope}})
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewToken(auth.CBSTokenTypeJWT, token.Token, strconv.FormatInt(token.ExpiresOn.Unix(), 10)), nil
+}
+
 func getQueueLength(ctx context.Context, adminClient *admin.Client, meta *azureServiceBusMetadata) (int64, error) {
 	if !meta.useRegex {
 		queueEntity, err := adminClient.GetQueueRuntimeProperties(ctx, meta.queueName, &admin.GetQueueRuntimePropertiesOptions{})
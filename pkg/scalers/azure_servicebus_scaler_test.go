@@ -19,9 +19,13 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"testing"
 
+	"github.com/Azure/azure-amqp-common-go/v3/rpc"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+	"github.com/Azure/go-amqp"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 
@@ -126,6 +130,22 @@ var parseServiceBusMetadataDataset = []parseServiceBusMetadataTestData{
 	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "useRegex": "true", "operation": "random"}, true, subscription, defaultSuffix, map[string]string{}, ""},
 	// subscription with invalid regex string
 	{map[string]string{"topicName": topicName, "subscriptionName": "*", "connectionFromEnv": connectionSetting, "useRegex": "true", "operation": "avg"}, true, subscription, defaultSuffix, map[string]string{}, ""},
+	// queue with peek message count mode
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "messageCountMode": "peek"}, false, queue, defaultSuffix, map[string]string{}, ""},
+	// subscription with peek message count mode and peek limit
+	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "messageCountMode": "peek", "peekLimit": "100"}, false, subscription, defaultSuffix, map[string]string{}, ""},
+	// queue with runtime properties message count mode
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "messageCountMode": "runtimeProperties"}, false, queue, defaultSuffix, map[string]string{}, ""},
+	// invalid message count mode
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "messageCountMode": "browse"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// invalid peek limit
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "messageCountMode": "peek", "peekLimit": "0"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// peek message count mode with regex
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "messageCountMode": "peek", "useRegex": "true"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// queue with sessions message count mode
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "messageCountMode": "sessions"}, false, queue, defaultSuffix, map[string]string{}, ""},
+	// sessions message count mode with regex
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "messageCountMode": "sessions", "useRegex": "true"}, true, queue, defaultSuffix, map[string]string{}, ""},
 }
 
 var azServiceBusMetricIdentifiers = []azServiceBusMetricIdentifier{
@@ -202,10 +222,12 @@ func TestGetServiceBusAdminClientIsCached(t *testing.T) {
 	mockAzServiceBusScalerScaler := azureServiceBusScaler{
 		metadata:    meta,
 		podIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.metadataTestData.podIdentity},
+		namespaces:  newServiceBusNamespaces(meta),
 	}
 
-	_, _ = mockAzServiceBusScalerScaler.getServiceBusAdminClient()
-	assert.NotNil(t, mockAzServiceBusScalerScaler.client)
+	namespace := mockAzServiceBusScalerScaler.namespaces[primaryServiceBusNamespace]
+	_, _ = mockAzServiceBusScalerScaler.getServiceBusAdminClient(namespace)
+	assert.NotNil(t, namespace.client)
 }
 
 func TestGetServiceBusLength(t *testing.T) {
@@ -220,6 +242,7 @@ func TestGetServiceBusLength(t *testing.T) {
 		if connectionString != "" {
 			// Can actually test that numbers return
 			scaler.metadata.connection = connectionString
+			scaler.namespaces = newServiceBusNamespaces(scaler.metadata)
 			length, err := scaler.getAzureServiceBusLength(context.TODO())
 
 			if err != nil {
@@ -231,6 +254,7 @@ func TestGetServiceBusLength(t *testing.T) {
 			}
 		} else {
 			// Just test error message
+			scaler.namespaces = newServiceBusNamespaces(scaler.metadata)
 			length, err := scaler.getAzureServiceBusLength(context.TODO())
 
 			if length != -1 || err == nil {
@@ -240,6 +264,160 @@ func TestGetServiceBusLength(t *testing.T) {
 	}
 }
 
+func TestParseServiceBusSecondaryNamespace(t *testing.T) {
+	// secondary connection string given by env
+	meta, err := parseAzureServiceBusMetadata(&ScalerConfig{ResolvedEnv: map[string]string{connectionSetting: "primary", "SECONDARY": "secondary"},
+		TriggerMetadata: map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "secondaryConnectionFromEnv": "SECONDARY"}},
+		logr.Discard())
+	assert.NoError(t, err)
+	assert.Equal(t, "secondary", meta.secondaryConnection)
+	assert.Len(t, newServiceBusNamespaces(meta), 2)
+
+	// secondary connection string given by env isn't resolved
+	_, err = parseAzureServiceBusMetadata(&ScalerConfig{ResolvedEnv: sampleResolvedEnv,
+		TriggerMetadata: map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "secondaryConnectionFromEnv": "SECONDARY"}},
+		logr.Discard())
+	assert.Error(t, err)
+
+	// secondary connection string given by auth params
+	meta, err = parseAzureServiceBusMetadata(&ScalerConfig{TriggerMetadata: map[string]string{"queueName": queueName},
+		AuthParams: map[string]string{"connection": "primary", "secondaryConnection": "secondary"}},
+		logr.Discard())
+	assert.NoError(t, err)
+	assert.Equal(t, "secondary", meta.secondaryConnection)
+
+	// secondary namespace with pod identity
+	meta, err = parseAzureServiceBusMetadata(&ScalerConfig{TriggerMetadata: map[string]string{"queueName": queueName, "namespace": namespaceName, "secondaryNamespace": "ns-dr"},
+		PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload}},
+		logr.Discard())
+	assert.NoError(t, err)
+	assert.Equal(t, "ns-dr.servicebus.windows.net", meta.secondaryFullyQualifiedNamespace)
+
+	// no secondary namespace
+	meta, err = parseAzureServiceBusMetadata(&ScalerConfig{ResolvedEnv: sampleResolvedEnv,
+		TriggerMetadata: map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting}},
+		logr.Discard())
+	assert.NoError(t, err)
+	assert.Len(t, newServiceBusNamespaces(meta), 1)
+}
+
+func TestGetServiceBusLengthFailsOverToSecondaryNamespace(t *testing.T) {
+	scaler, err := NewAzureServiceBusScaler(context.Background(), &ScalerConfig{
+		TriggerMetadata: map[string]string{"queueName": queueName},
+		AuthParams:      map[string]string{"connection": "invalid", "secondaryConnection": "invalid"},
+	})
+	assert.NoError(t, err)
+	sbScaler := scaler.(*azureServiceBusScaler)
+	assert.Equal(t, []string{primaryServiceBusNamespace, secondaryServiceBusNamespace}, sbScaler.failover.Endpoints())
+
+	// both namespaces are tried and marked as failing, the one that failed first is tried first
+	_, err = sbScaler.getAzureServiceBusLength(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, []string{primaryServiceBusNamespace, secondaryServiceBusNamespace}, sbScaler.failover.Endpoints())
+
+	// the secondary namespace is preferred while the primary namespace is failing
+	sbScaler.failover.ReportSuccess(secondaryServiceBusNamespace)
+	assert.Equal(t, []string{secondaryServiceBusNamespace, primaryServiceBusNamespace}, sbScaler.failover.Endpoints())
+
+	// a single namespace isn't failed over
+	scaler, err = NewAzureServiceBusScaler(context.Background(), &ScalerConfig{
+		TriggerMetadata: map[string]string{"queueName": queueName},
+		AuthParams:      map[string]string{"connection": "invalid"},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, scaler.(*azureServiceBusScaler).failover)
+}
+
+func TestCountActiveMessages(t *testing.T) {
+	messages := []*azservicebus.ReceivedMessage{
+		{State: azservicebus.MessageStateActive},
+		{State: azservicebus.MessageStateDeferred},
+		{State: azservicebus.MessageStateActive},
+		{State: azservicebus.MessageStateScheduled},
+	}
+
+	if count := countActiveMessages(messages); count != 2 {
+		t.Errorf("Expected 2 active messages, got %d", count)
+	}
+}
+
+// fakeSessionLister enumerates the sessions of an entity by pages
+type fakeSessionLister struct {
+	sessionIDs []string
+	requests   int
+}
+
+func (l *fakeSessionLister) ListSessions(_ context.Context, skip, top int32) ([]string, int32, error) {
+	l.requests++
+	if int(skip) >= len(l.sessionIDs) {
+		return nil, 0, nil
+	}
+	end := int(skip + top)
+	if end > len(l.sessionIDs) {
+		end = len(l.sessionIDs)
+	}
+	return l.sessionIDs[skip:end], int32(end), nil
+}
+
+func TestCountActiveSessions(t *testing.T) {
+	lister := &fakeSessionLister{}
+	for i := 0; i < 250; i++ {
+		lister.sessionIDs = append(lister.sessionIDs, fmt.Sprintf("session-%d", i))
+	}
+
+	count, err := countActiveSessions(context.Background(), lister)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(250), count)
+	// 3 pages of sessions and the empty page ending the enumeration
+	assert.Equal(t, 4, lister.requests)
+}
+
+func TestCountActiveSessionsWithoutSessions(t *testing.T) {
+	count, err := countActiveSessions(context.Background(), &fakeSessionLister{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestParseMessageSessionsResponse(t *testing.T) {
+	sessionIDs, skip, err := parseMessageSessionsResponse(&rpc.Response{
+		Code: http.StatusOK,
+		Message: &amqp.Message{Value: map[string]interface{}{
+			"skip":         int32(2),
+			"sessions-ids": []string{"a", "b"},
+		}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, sessionIDs)
+	assert.Equal(t, int32(2), skip)
+
+	// all the sessions are enumerated
+	sessionIDs, _, err = parseMessageSessionsResponse(&rpc.Response{Code: http.StatusNoContent, Message: &amqp.Message{}})
+	assert.NoError(t, err)
+	assert.Empty(t, sessionIDs)
+
+	_, _, err = parseMessageSessionsResponse(&rpc.Response{Code: http.StatusUnauthorized, Description: "Unauthorized access", Message: &amqp.Message{}})
+	assert.Error(t, err)
+}
+
+func TestPerformOperation(t *testing.T) {
+	// message counts of sharded queues, eg. orders-0, orders-1 and orders-2
+	messageCounts := []int64{4, 10, 1}
+	tests := map[string]int64{
+		sumOperation: 15,
+		maxOperation: 10,
+		avgOperation: 5,
+	}
+	for operation, expected := range tests {
+		if result := performOperation(messageCounts, operation); result != expected {
+			t.Errorf("Expected %d for %s operation, got %d", expected, operation, result)
+		}
+		// no entity matches the regex
+		if result := performOperation([]int64{}, operation); result != 0 {
+			t.Errorf("Expected 0 for %s operation without entities, got %d", operation, result)
+		}
+	}
+}
+
 func TestAzServiceBusGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range azServiceBusMetricIdentifiers {
 		meta, err := parseAzureServiceBusMetadata(&ScalerConfig{ResolvedEnv: connectionResolvedEnv,
@@ -36,6 +36,10 @@ type couchDBMetadata struct {
 	password             string
 	dbName               string
 	query                string
+	designDoc            string
+	view                 string
+	viewKey              string
+	reduce               bool
 	queryValue           int64
 	activationQueryValue int64
 	metricName           string
@@ -73,6 +77,10 @@ func (s couchDBScaler) Close(ctx context.Context) error {
 }
 
 func (s *couchDBScaler) getQueryResult(ctx context.Context) (int64, error) {
+	if s.metadata.view != "" {
+		return s.getViewResult(ctx)
+	}
+
 	db := s.client.DB(ctx, s.metadata.dbName)
 	var request couchDBQueryRequest
 	err := json.Unmarshal([]byte(s.metadata.query), &request)
@@ -97,15 +105,84 @@ func (s *couchDBScaler) getQueryResult(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
+// getViewResult returns the reduced value of the view if reduce is enabled,
+// otherwise the number of rows of the view, optionally only of the rows with the viewKey
+func (s *couchDBScaler) getViewResult(ctx context.Context) (int64, error) {
+	db := s.client.DB(ctx, s.metadata.dbName)
+	options := kivik.Options{"reduce": s.metadata.reduce}
+	if s.metadata.viewKey != "" {
+		options["key"] = json.RawMessage(s.metadata.viewKey)
+	} else if !s.metadata.reduce {
+		// total_rows of the view is reported without reading any row
+		options["limit"] = 0
+	}
+
+	rows, err := db.Query(ctx, s.metadata.designDoc, s.metadata.view, options)
+	if err != nil {
+		s.logger.Error(err, fmt.Sprintf("failed to query view because of %v", err))
+		return 0, err
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		if !s.metadata.reduce {
+			count++
+			continue
+		}
+		var value float64
+		if err := rows.ScanValue(&value); err != nil {
+			s.logger.Error(err, fmt.Sprintf("failed to scan the reduced value because of %v", err))
+			return 0, err
+		}
+		count = int64(value)
+	}
+	if err := rows.Err(); err != nil {
+		s.logger.Error(err, fmt.Sprintf("failed to fetch rows because of %v", err))
+		return 0, err
+	}
+	if !s.metadata.reduce && s.metadata.viewKey == "" {
+		return rows.TotalRows(), nil
+	}
+	return count, nil
+}
+
 func parseCouchDBMetadata(config *ScalerConfig) (*couchDBMetadata, string, error) {
 	var connStr string
 	var err error
 	meta := couchDBMetadata{}
 
-	if val, ok := config.TriggerMetadata["query"]; ok {
-		meta.query = val
-	} else {
-		return nil, "", fmt.Errorf("no query given")
+	meta.query = config.TriggerMetadata["query"]
+	meta.designDoc = config.TriggerMetadata["designDoc"]
+	meta.view = config.TriggerMetadata["view"]
+	switch {
+	case meta.query != "" && (meta.designDoc != "" || meta.view != ""):
+		return nil, "", fmt.Errorf("only one of query or view can be given")
+	case meta.query == "" && meta.view == "":
+		return nil, "", fmt.Errorf("no query or view given")
+	case meta.view != "" && meta.designDoc == "":
+		return nil, "", fmt.Errorf("no designDoc given for view %s", meta.view)
+	}
+
+	if val, ok := config.TriggerMetadata["viewKey"]; ok && val != "" {
+		if meta.view == "" {
+			return nil, "", fmt.Errorf("viewKey can be given only with view")
+		}
+		if !json.Valid([]byte(val)) {
+			return nil, "", fmt.Errorf("viewKey must be a JSON value, got %s", val)
+		}
+		meta.viewKey = val
+	}
+
+	if val, ok := config.TriggerMetadata["reduce"]; ok && val != "" {
+		if meta.view == "" {
+			return nil, "", fmt.Errorf("reduce can be given only with view")
+		}
+		reduce, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to convert %v to bool, because of %w", val, err)
+		}
+		meta.reduce = reduce
 	}
 
 	if val, ok := config.TriggerMetadata["queryValue"]; ok {
@@ -2,6 +2,8 @@ package scalers
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	_ "github.com/go-kivik/couchdb/v3"
@@ -58,7 +60,49 @@ var testCOUCHDBMetadata = []parseCouchDBMetadataTestData{
 	},
 	// wrong activationQueryValue
 	{
-		metadata:    map[string]string{"query": `{ "selector": { "feet": { "$gt": 0 } }, "fields": ["_id", "feet", "greeting"] }`, "queryValue": "1", "activationQueryValue": "1", "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "animals"},
+		metadata:    map[string]string{"query": `{ "selector": { "feet": { "$gt": 0 } }, "fields": ["_id", "feet", "greeting"] }`, "queryValue": "1", "activationQueryValue": "a", "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "animals"},
+		authParams:  map[string]string{},
+		resolvedEnv: testCouchDBResolvedEnv,
+		raisesError: true,
+	},
+	// view
+	{
+		metadata:    map[string]string{"designDoc": "jobs", "view": "pending", "viewKey": `"queue-a"`, "queryValue": "1", "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "jobs"},
+		authParams:  map[string]string{},
+		resolvedEnv: testCouchDBResolvedEnv,
+		raisesError: false,
+	},
+	// reduced view
+	{
+		metadata:    map[string]string{"designDoc": "jobs", "view": "count", "reduce": "true", "queryValue": "1", "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "jobs"},
+		authParams:  map[string]string{},
+		resolvedEnv: testCouchDBResolvedEnv,
+		raisesError: false,
+	},
+	// both query and view
+	{
+		metadata:    map[string]string{"query": `{ "selector": { "feet": { "$gt": 0 } } }`, "designDoc": "jobs", "view": "pending", "queryValue": "1", "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "jobs"},
+		authParams:  map[string]string{},
+		resolvedEnv: testCouchDBResolvedEnv,
+		raisesError: true,
+	},
+	// view without designDoc
+	{
+		metadata:    map[string]string{"view": "pending", "queryValue": "1", "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "jobs"},
+		authParams:  map[string]string{},
+		resolvedEnv: testCouchDBResolvedEnv,
+		raisesError: true,
+	},
+	// invalid viewKey
+	{
+		metadata:    map[string]string{"designDoc": "jobs", "view": "pending", "viewKey": "queue-a", "queryValue": "1", "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "jobs"},
+		authParams:  map[string]string{},
+		resolvedEnv: testCouchDBResolvedEnv,
+		raisesError: true,
+	},
+	// invalid reduce
+	{
+		metadata:    map[string]string{"designDoc": "jobs", "view": "count", "reduce": "a", "queryValue": "1", "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "jobs"},
 		authParams:  map[string]string{},
 		resolvedEnv: testCouchDBResolvedEnv,
 		raisesError: true,
@@ -72,10 +116,13 @@ var couchDBMetricIdentifiers = []couchDBMetricIdentifier{
 
 func TestParseCouchDBMetadata(t *testing.T) {
 	for _, testData := range testCOUCHDBMetadata {
-		_, _, err := parseCouchDBMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		_, _, err := parseCouchDBMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, ResolvedEnv: testData.resolvedEnv})
 		if err != nil && !testData.raisesError {
 			t.Error("Expected success but got error:", err)
 		}
+		if err == nil && testData.raisesError {
+			t.Error("Expected error but got success", testData.metadata)
+		}
 	}
 }
 
@@ -94,3 +141,61 @@ func TestCouchDBGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestCouchDBGetViewResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.URL.Path == "/jobs/_design/jobs/_view/count" && query.Get("reduce") == "true":
+			_, _ = w.Write([]byte(`{"rows":[{"key":null,"value":7}]}`))
+		case r.URL.Path == "/jobs/_design/jobs/_view/pending" && query.Get("key") == `"queue-a"`:
+			_, _ = w.Write([]byte(`{"total_rows":10,"offset":0,"rows":[{"id":"1","key":"queue-a","value":null},{"id":"2","key":"queue-a","value":null}]}`))
+		case r.URL.Path == "/jobs/_design/jobs/_view/pending" && query.Get("limit") == "0":
+			_, _ = w.Write([]byte(`{"total_rows":10,"offset":0,"rows":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not_found","reason":"missing"}`))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		expected int64
+		isError  bool
+	}{
+		{"reduced view", map[string]string{"designDoc": "jobs", "view": "count", "reduce": "true"}, 7, false},
+		{"view rows with key", map[string]string{"designDoc": "jobs", "view": "pending", "viewKey": `"queue-a"`}, 2, false},
+		{"all view rows", map[string]string{"designDoc": "jobs", "view": "pending"}, 10, false},
+		{"missing view", map[string]string{"designDoc": "jobs", "view": "missing"}, 0, true},
+	}
+
+	client, err := kivik.New("couch", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.metadata["queryValue"] = "1"
+			test.metadata["dbName"] = "jobs"
+			test.metadata["connectionStringFromEnv"] = "CouchDB_CONN_STR"
+			meta, _, err := parseCouchDBMetadata(&ScalerConfig{TriggerMetadata: test.metadata, ResolvedEnv: testCouchDBResolvedEnv})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			scaler := couchDBScaler{"", meta, client, logr.Discard()}
+
+			result, err := scaler.getQueryResult(context.Background())
+			if test.isError && err == nil {
+				t.Error("expected error but got none")
+			}
+			if !test.isError && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+			if result != test.expected {
+				t.Errorf("expected %d but got %d", test.expected, result)
+			}
+		})
+	}
+}
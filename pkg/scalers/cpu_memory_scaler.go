@@ -63,12 +63,21 @@ func parseResourceMetadata(config *ScalerConfig, logger logr.Logger) (*cpuMemory
 	}
 	switch meta.Type {
 	case v2.AverageValueMetricType:
-		averageValueQuantity := resource.MustParse(value)
+		averageValueQuantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing value: %w", err)
+		}
+		if averageValueQuantity.Sign() <= 0 {
+			return nil, fmt.Errorf("value must be positive, got %s", value)
+		}
 		meta.AverageValue = &averageValueQuantity
 	case v2.UtilizationMetricType:
 		valueNum, err := strconv.ParseInt(value, 10, 32)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("error parsing value: %w", err)
+		}
+		if valueNum <= 0 {
+			return nil, fmt.Errorf("value must be positive, got %s", value)
 		}
 		utilizationNum := int32(valueNum)
 		meta.AverageUtilization = &utilizationNum
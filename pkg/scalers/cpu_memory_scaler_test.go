@@ -39,6 +39,11 @@ var testCPUMemoryMetadata = []parseCPUMemoryMetadataTestData{
 	{v2.ValueMetricType, map[string]string{"value": "50"}, true},
 	{"", map[string]string{"type": "AverageValue"}, true},
 	{"", map[string]string{"type": "xxx", "value": "50"}, true},
+	{v2.AverageValueMetricType, map[string]string{"value": "50x"}, true},
+	{v2.AverageValueMetricType, map[string]string{"value": "0"}, true},
+	{v2.AverageValueMetricType, map[string]string{"value": "500m"}, false},
+	{v2.UtilizationMetricType, map[string]string{"value": "0"}, true},
+	{v2.UtilizationMetricType, map[string]string{"value": "-10"}, true},
 }
 
 func TestCPUMemoryParseMetadata(t *testing.T) {
@@ -0,0 +1,163 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// daprBindingScaler scales applications using Dapr input bindings on the queue depth reported by the application,
+// the queue depth is requested through Dapr service invocation of the method of the application, which responds with
+// a JSON object of queue depths by binding name (eg. {"orders": 12, "payments": 3}) or a single number
+type daprBindingScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *daprBindingMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type daprBindingMetadata struct {
+	DaprAddress                string `keda:"name=daprAddress, order=triggerMetadata, optional"`
+	AppID                      string `keda:"name=appId, order=triggerMetadata"`
+	Method                     string `keda:"name=method, order=triggerMetadata, default=queue-depth"`
+	BindingName                string `keda:"name=bindingName, order=triggerMetadata, optional"`
+	TargetQueueDepth           int64  `keda:"name=targetQueueDepth, order=triggerMetadata, default=5"`
+	ActivationTargetQueueDepth int64  `keda:"name=activationTargetQueueDepth, order=triggerMetadata, optional"`
+	APIToken                   string `keda:"name=apiToken, order=authParams, optional"`
+	scalerIndex                int
+}
+
+// NewDaprBindingScaler creates a new Dapr Binding Scaler
+func NewDaprBindingScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseDaprBindingMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing dapr binding metadata: %w", err)
+	}
+
+	return &daprBindingScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:     InitializeLogger(config, "dapr_binding_scaler"),
+	}, nil
+}
+
+func parseDaprBindingMetadata(config *ScalerConfig) (*daprBindingMetadata, error) {
+	meta := daprBindingMetadata{}
+	if err := config.TypedConfig(&meta); err != nil {
+		return nil, err
+	}
+
+	// the Dapr sidecar injector creates <appId>-dapr service exposing the Dapr HTTP API of the application on port 80
+	if meta.DaprAddress == "" {
+		meta.DaprAddress = fmt.Sprintf("http://%s-dapr.%s.svc.cluster.local", meta.AppID, config.ScalableObjectNamespace)
+	}
+	meta.DaprAddress = strings.TrimSuffix(meta.DaprAddress, "/")
+	meta.Method = strings.TrimPrefix(meta.Method, "/")
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+// parseDaprBindingQueueDepth returns the queue depth of the binding from the response of the application,
+// or the sum of queue depths of all bindings if no binding is given
+func parseDaprBindingQueueDepth(body []byte, bindingName string) (int64, error) {
+	var queueDepth int64
+	if err := json.Unmarshal(body, &queueDepth); err == nil {
+		if bindingName != "" {
+			return -1, fmt.Errorf("application reported a single queue depth, queue depth of binding %s not found", bindingName)
+		}
+		return queueDepth, nil
+	}
+
+	var queueDepths map[string]int64
+	if err := json.Unmarshal(body, &queueDepths); err != nil {
+		return -1, fmt.Errorf("error parsing queue depth reported by the application: %w", err)
+	}
+	if bindingName != "" {
+		queueDepth, found := queueDepths[bindingName]
+		if !found {
+			return -1, fmt.Errorf("queue depth of binding %s not found", bindingName)
+		}
+		return queueDepth, nil
+	}
+	for _, depth := range queueDepths {
+		queueDepth += depth
+	}
+	return queueDepth, nil
+}
+
+// GetQueueDepth invokes the method of the application through Dapr and returns the queue depth
+func (s *daprBindingScaler) GetQueueDepth(ctx context.Context) (int64, error) {
+	invokeURL := fmt.Sprintf("%s/v1.0/invoke/%s/method/%s", s.metadata.DaprAddress, url.PathEscape(s.metadata.AppID), s.metadata.Method)
+	req, err := http.NewRequestWithContext(ctx, "GET", invokeURL, nil)
+	if err != nil {
+		return -1, err
+	}
+	if s.metadata.APIToken != "" {
+		req.Header.Set("dapr-api-token", s.metadata.APIToken)
+	}
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return -1, err
+	}
+	defer r.Body.Close()
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return -1, err
+	}
+	if r.StatusCode != http.StatusOK {
+		return -1, NewHTTPStatusError(r.StatusCode, fmt.Errorf("dapr returned error invoking method %s of app %s. status: %d response: %s", s.metadata.Method, s.metadata.AppID, r.StatusCode, string(b)))
+	}
+
+	return parseDaprBindingQueueDepth(b, s.metadata.BindingName)
+}
+
+func (s *daprBindingScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	queueDepth, err := s.GetQueueDepth(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting queue depth from dapr")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(queueDepth))
+
+	return []external_metrics.ExternalMetricValue{metric}, queueDepth > s.metadata.ActivationTargetQueueDepth, nil
+}
+
+func (s *daprBindingScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := fmt.Sprintf("dapr-binding-%s", s.metadata.AppID)
+	if s.metadata.BindingName != "" {
+		metricName = fmt.Sprintf("%s-%s", metricName, s.metadata.BindingName)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetQueueDepth),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *daprBindingScaler) Close(context.Context) error {
+	return nil
+}
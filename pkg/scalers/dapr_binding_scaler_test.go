@@ -0,0 +1,137 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type parseDaprBindingMetadataTestData struct {
+	testName string
+	metadata map[string]string
+	isError  bool
+}
+
+type daprBindingMetricIdentifier struct {
+	metadata    map[string]string
+	scalerIndex int
+	name        string
+}
+
+var testDaprBindingMetadata = []parseDaprBindingMetadataTestData{
+	{"empty", map[string]string{}, true},
+	{"properly formed", map[string]string{"appId": "orders", "bindingName": "kafka", "targetQueueDepth": "10", "activationTargetQueueDepth": "2"}, false},
+	{"custom address and method", map[string]string{"appId": "orders", "daprAddress": "http://localhost:3500/", "method": "/keda/queue"}, false},
+	{"invalid targetQueueDepth", map[string]string{"appId": "orders", "targetQueueDepth": "a"}, true},
+	{"invalid activationTargetQueueDepth", map[string]string{"appId": "orders", "activationTargetQueueDepth": "a"}, true},
+}
+
+var daprBindingMetricIdentifiers = []daprBindingMetricIdentifier{
+	{map[string]string{"appId": "orders"}, 0, "s0-dapr-binding-orders"},
+	{map[string]string{"appId": "orders", "bindingName": "kafka"}, 1, "s1-dapr-binding-orders-kafka"},
+}
+
+func TestDaprBindingParseMetadata(t *testing.T) {
+	for _, testData := range testDaprBindingMetadata {
+		t.Run(testData.testName, func(t *testing.T) {
+			_, err := parseDaprBindingMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectNamespace: "default"})
+			if testData.isError && err == nil {
+				t.Error("expected error but got none")
+			}
+			if !testData.isError && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
+
+func TestDaprBindingDefaultAddress(t *testing.T) {
+	meta, err := parseDaprBindingMetadata(&ScalerConfig{TriggerMetadata: map[string]string{"appId": "orders"}, ScalableObjectNamespace: "shop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.DaprAddress != "http://orders-dapr.shop.svc.cluster.local" {
+		t.Errorf("unexpected dapr address %s", meta.DaprAddress)
+	}
+	if meta.Method != "queue-depth" {
+		t.Errorf("unexpected method %s", meta.Method)
+	}
+}
+
+func TestDaprBindingGetQueueDepth(t *testing.T) {
+	apiStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("dapr-api-token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1.0/invoke/orders/method/queue-depth":
+			_, _ = w.Write([]byte(`{"kafka": 12, "rabbitmq": 3}`))
+		case "/v1.0/invoke/single/method/queue-depth":
+			_, _ = w.Write([]byte(`7`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"errorCode":"ERR_DIRECT_INVOKE"}`))
+		}
+	}))
+	defer apiStub.Close()
+
+	tests := []struct {
+		name          string
+		metadata      map[string]string
+		expectedDepth int64
+		isError       bool
+	}{
+		{"all bindings", map[string]string{"appId": "orders"}, 15, false},
+		{"binding", map[string]string{"appId": "orders", "bindingName": "kafka"}, 12, false},
+		{"unknown binding", map[string]string{"appId": "orders", "bindingName": "sqs"}, -1, true},
+		{"single queue depth", map[string]string{"appId": "single"}, 7, false},
+		{"single queue depth with binding", map[string]string{"appId": "single", "bindingName": "kafka"}, -1, true},
+		{"invocation error", map[string]string{"appId": "unknown"}, -1, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.metadata["daprAddress"] = apiStub.URL
+			meta, err := parseDaprBindingMetadata(&ScalerConfig{TriggerMetadata: test.metadata, AuthParams: map[string]string{"apiToken": "token"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			scaler := daprBindingScaler{
+				metadata:   meta,
+				httpClient: http.DefaultClient,
+			}
+
+			depth, err := scaler.GetQueueDepth(context.Background())
+			if test.isError && err == nil {
+				t.Error("expected error but got none")
+			}
+			if !test.isError && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+			if depth != test.expectedDepth {
+				t.Errorf("expected queue depth %d but got %d", test.expectedDepth, depth)
+			}
+		})
+	}
+}
+
+func TestDaprBindingGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range daprBindingMetricIdentifiers {
+		meta, err := parseDaprBindingMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockDaprBindingScaler := daprBindingScaler{
+			metadata:   meta,
+			httpClient: http.DefaultClient,
+		}
+
+		metricSpec := mockDaprBindingScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName+"!="+testData.name)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	"github.com/kedacore/keda/v2/pkg/scalers/ratelimiter"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -247,6 +248,9 @@ func newDatadogConnection(ctx context.Context, meta *datadogMetadata, config *Sc
 
 	configuration := datadog.NewConfiguration()
 	configuration.HTTPClient = kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	if err := setHTTPProxy(configuration.HTTPClient, config); err != nil {
+		return nil, err
+	}
 	apiClient := datadog.NewAPIClient(configuration)
 
 	_, _, err := apiClient.AuthenticationApi.Validate(ctx) //nolint:bodyclose
@@ -264,6 +268,11 @@ func (s *datadogScaler) Close(context.Context) error {
 
 // getQueryResult returns result of the scaler query
 func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
+	// Datadog limits queries per organization, identified by the API key
+	if err := ratelimiter.Wait(ctx, "datadog", ratelimiter.AccountKey(s.metadata.datadogSite, s.metadata.apiKey)); err != nil {
+		return 0, err
+	}
+
 	ctx = context.WithValue(
 		ctx,
 		datadog.ContextAPIKeys,
@@ -23,6 +23,7 @@ const (
 	value                              = "value"
 	activationValue                    = "activationValue"
 	watchProgressNotifyInterval        = "watchProgressNotifyInterval"
+	enableWatch                        = "enableWatch"
 	etcdMetricType                     = "External"
 	etcdTLSEnable                      = "enable"
 	etcdTLSDisable                     = "disable"
@@ -36,12 +37,18 @@ type etcdScaler struct {
 	logger     logr.Logger
 }
 
+// etcdPushScaler watches the key, so changes of activation are propagated immediately
+type etcdPushScaler struct {
+	etcdScaler
+}
+
 type etcdMetadata struct {
 	endpoints                   []string
 	watchKey                    string
 	value                       float64
 	activationValue             float64
 	watchProgressNotifyInterval int
+	enableWatch                 bool
 	scalerIndex                 int
 	// TLS
 	enableTLS   bool
@@ -51,7 +58,7 @@ type etcdMetadata struct {
 	ca          string
 }
 
-// NewEtcdScaler creates a new etcdScaler
+// NewEtcdScaler creates a new etcdScaler, or etcdPushScaler if watching the key is enabled
 func NewEtcdScaler(config *ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -67,12 +74,16 @@ func NewEtcdScaler(config *ScalerConfig) (Scaler, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &etcdScaler{
+	scaler := etcdScaler{
 		metricType: metricType,
 		metadata:   meta,
 		client:     cli,
 		logger:     InitializeLogger(config, "etcd_scaler"),
-	}, nil
+	}
+	if meta.enableWatch {
+		return &etcdPushScaler{scaler}, nil
+	}
+	return &scaler, nil
 }
 
 func parseEtcdAuthParams(config *ScalerConfig, meta *etcdMetadata) error {
@@ -142,6 +153,15 @@ func parseEtcdMetadata(config *ScalerConfig) (*etcdMetadata, error) {
 		meta.watchProgressNotifyInterval = interval
 	}
 
+	meta.enableWatch = true
+	if val, ok := config.TriggerMetadata[enableWatch]; ok {
+		enable, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("enableWatch must be a bool")
+		}
+		meta.enableWatch = enable
+	}
+
 	if err = parseEtcdAuthParams(config, meta); err != nil {
 		return meta, err
 	}
@@ -203,7 +223,7 @@ func (s *etcdScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	return []v2.MetricSpec{metricSpec}
 }
 
-func (s *etcdScaler) Run(ctx context.Context, active chan<- bool) {
+func (s *etcdPushScaler) Run(ctx context.Context, active chan<- bool) {
 	defer close(active)
 
 	// It's possible for the watch to get terminated anytime, we need to run this in a retry loop
@@ -255,33 +275,9 @@ func (s *etcdScaler) Run(ctx context.Context, active chan<- bool) {
 		}
 	}
 
-	// retry on error from runWithWatch() starting by 2 sec backing off * 2 with a max of 1 minute
-	retryDuration := time.Second * 2
-	// the caller of this function needs to ensure that they call Stop() on the resulting
-	// timer, to release background resources.
-	retryBackoff := func() *time.Timer {
-		tmr := time.NewTimer(retryDuration)
-		retryDuration *= 2
-		if retryDuration > time.Minute*1 {
-			retryDuration = time.Minute * 1
-		}
-		return tmr
-	}
-
-	// start the first runWithWatch without delay
-	runWithWatch()
-
-	for {
-		backoffTimer := retryBackoff()
-		select {
-		case <-ctx.Done():
-			backoffTimer.Stop()
-			return
-		case <-backoffTimer.C:
-			backoffTimer.Stop()
-			runWithWatch()
-		}
-	}
+	// run again on error from runWithWatch() with backoff,
+	// the first run starts without delay
+	kedautil.RunWithBackoff(ctx, newPushScalerBackoff(), runWithWatch)
 }
 
 func (s *etcdScaler) getMetricValue(ctx context.Context) (float64, error) {
@@ -9,9 +9,10 @@ import (
 )
 
 type parseEtcdMetadataTestData struct {
-	metadata  map[string]string
-	endpoints []string
-	isError   bool
+	metadata    map[string]string
+	endpoints   []string
+	isError     bool
+	enableWatch bool
 }
 
 type parseEtcdAuthParamsTestData struct {
@@ -37,19 +38,23 @@ var validEtcdMetadata = map[string]string{
 
 var parseEtcdMetadataTestDataset = []parseEtcdMetadataTestData{
 	// success
-	{map[string]string{"endpoints": "172.0.0.1:2379,172.0.0.2:2379,172.0.0.3:2379", "watchKey": "length", "value": "5.5", "activationValue": "0.5", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379", "172.0.0.2:2379", "172.0.0.3:2379"}, false},
+	{map[string]string{"endpoints": "172.0.0.1:2379,172.0.0.2:2379,172.0.0.3:2379", "watchKey": "length", "value": "5.5", "activationValue": "0.5", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379", "172.0.0.2:2379", "172.0.0.3:2379"}, false, true},
 	// success
-	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "var", "value": "5.5", "activationValue": "0.5", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, false},
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "var", "value": "5.5", "activationValue": "0.5", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, false, true},
 	// failure, endpoints missed
-	{map[string]string{"endpoints": "", "watchKey": "length", "value": "5", "activationValue": "0", "watchProgressNotifyInterval": "600"}, []string{""}, true},
+	{map[string]string{"endpoints": "", "watchKey": "length", "value": "5", "activationValue": "0", "watchProgressNotifyInterval": "600"}, []string{""}, true, false},
 	// failure, watchKey missed
-	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "", "value": "5", "activationValue": "0", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, true},
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "", "value": "5", "activationValue": "0", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, true, false},
 	// failure, value invalid
-	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "a", "activationValue": "0", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, true},
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "a", "activationValue": "0", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, true, false},
 	// failure, activationValue invalid
-	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "5", "activationValue": "b", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, true},
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "5", "activationValue": "b", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, true, false},
 	// failure, watchProgressNotifyInterval invalid
-	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "5", "activationValue": "0", "watchProgressNotifyInterval": "0"}, []string{"172.0.0.1:2379"}, true},
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "5", "activationValue": "0", "watchProgressNotifyInterval": "0"}, []string{"172.0.0.1:2379"}, true, false},
+	// success, watch disabled
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "var", "value": "5.5", "enableWatch": "false"}, []string{"172.0.0.1:2379"}, false, false},
+	// failure, enableWatch invalid
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "var", "value": "5.5", "enableWatch": "maybe"}, []string{"172.0.0.1:2379"}, true, false},
 }
 
 var parseEtcdAuthParamsTestDataset = []parseEtcdAuthParamsTestData{
@@ -86,6 +91,9 @@ func TestParseEtcdMetadata(t *testing.T) {
 		if err == nil && !reflect.DeepEqual(meta.endpoints, testData.endpoints) {
 			t.Errorf("Expected  %v but got %v\n", testData.endpoints, meta.endpoints)
 		}
+		if err == nil && meta.enableWatch != testData.enableWatch {
+			t.Errorf("Expected enableWatch to be set to %v but got %v\n", testData.enableWatch, meta.enableWatch)
+		}
 	}
 }
 
@@ -0,0 +1,347 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventgrid receives events of Azure Event Grid delivered to a webhook and dispatches them to the triggers
+// subscribed to the events, so ScaledObjects are checked immediately on events of Azure-native sources (eg. blob
+// created or active messages of Service Bus) instead of waiting for the next poll. The receiver is shared by all
+// triggers and it's enabled only if the operator is started with its bind address.
+package eventgrid
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// subscriptionValidationEventType is the type of the event validating the endpoint of a new event subscription
+	subscriptionValidationEventType = "Microsoft.EventGrid.SubscriptionValidationEvent"
+
+	// maxRequestBodySize is the maximum size of a batch of events delivered by Event Grid
+	maxRequestBodySize = 1024 * 1024
+
+	// subscriberBufferSize is the number of events buffered for a subscriber, further events are dropped until
+	// the subscriber catches up as every event only signals that the source should be checked
+	subscriberBufferSize = 16
+
+	serverShutdownTimeout = 5 * time.Second
+)
+
+// ErrReceiverDisabled is returned by Subscribe if the Event Grid receiver isn't enabled
+var ErrReceiverDisabled = errors.New("azure event grid receiver isn't enabled, start KEDA Operator with --eventgrid-bind-address")
+
+var (
+	defaultReceiver     *Receiver
+	defaultReceiverLock sync.RWMutex
+)
+
+// SetDefaultReceiver sets the receiver used by the triggers
+func SetDefaultReceiver(receiver *Receiver) {
+	defaultReceiverLock.Lock()
+	defer defaultReceiverLock.Unlock()
+	defaultReceiver = receiver
+}
+
+// Subscribe subscribes to events matching the filter delivered for the ScaledObject to the default receiver,
+// ErrReceiverDisabled is returned if there's no default receiver
+func Subscribe(namespace, name string, filter Filter) (*Subscription, error) {
+	defaultReceiverLock.RLock()
+	receiver := defaultReceiver
+	defaultReceiverLock.RUnlock()
+	if receiver == nil {
+		return nil, ErrReceiverDisabled
+	}
+	return receiver.Subscribe(namespace, name, filter), nil
+}
+
+// Event is an event of Event Grid, delivered either in Event Grid schema or in CloudEvents schema
+type Event struct {
+	Type    string
+	Subject string
+	Source  string
+}
+
+// Filter selects events a subscription receives, empty fields match all events
+type Filter struct {
+	// EventTypes are the types of the events, eg. Microsoft.Storage.BlobCreated
+	EventTypes []string
+	// SubjectBeginsWith is the prefix of the subject of the events
+	SubjectBeginsWith string
+	// SubjectEndsWith is the suffix of the subject of the events
+	SubjectEndsWith string
+	// Secret is the value of the code query parameter of the endpoint of the event subscription
+	Secret string
+}
+
+func (f Filter) matches(event Event) bool {
+	if len(f.EventTypes) > 0 {
+		found := false
+		for _, eventType := range f.EventTypes {
+			if strings.EqualFold(eventType, event.Type) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return strings.HasPrefix(event.Subject, f.SubjectBeginsWith) && strings.HasSuffix(event.Subject, f.SubjectEndsWith)
+}
+
+func (f Filter) accepts(code string) bool {
+	return f.Secret == "" || subtle.ConstantTimeCompare([]byte(f.Secret), []byte(code)) == 1
+}
+
+// Subscription receives events delivered for a ScaledObject matching its filter
+type Subscription struct {
+	key      string
+	filter   Filter
+	events   chan Event
+	receiver *Receiver
+}
+
+// Events returns the channel of events of the subscription, it's closed by Close
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close unsubscribes from the events
+func (s *Subscription) Close() {
+	s.receiver.unsubscribe(s)
+}
+
+// Receiver serves the webhook Event Grid delivers events to, the endpoint of the event subscription
+// of a ScaledObject is /<namespace>/<name>, optionally with ?code=<secret> query parameter
+type Receiver struct {
+	bindAddress   string
+	logger        logr.Logger
+	lock          sync.Mutex
+	subscriptions map[string]map[*Subscription]bool
+}
+
+// NewReceiver creates a new Receiver serving the webhook on the bind address
+func NewReceiver(bindAddress string) *Receiver {
+	return &Receiver{
+		bindAddress:   bindAddress,
+		logger:        logf.Log.WithName("eventgrid_receiver"),
+		subscriptions: map[string]map[*Subscription]bool{},
+	}
+}
+
+func subscriptionKey(namespace, name string) string {
+	return fmt.Sprintf("/%s/%s", namespace, name)
+}
+
+// Subscribe subscribes to events matching the filter delivered for the ScaledObject
+func (r *Receiver) Subscribe(namespace, name string, filter Filter) *Subscription {
+	subscription := &Subscription{
+		key:      subscriptionKey(namespace, name),
+		filter:   filter,
+		events:   make(chan Event, subscriberBufferSize),
+		receiver: r,
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.subscriptions[subscription.key] == nil {
+		r.subscriptions[subscription.key] = map[*Subscription]bool{}
+	}
+	r.subscriptions[subscription.key][subscription] = true
+	return subscription
+}
+
+func (r *Receiver) unsubscribe(subscription *Subscription) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	subscriptions := r.subscriptions[subscription.key]
+	if !subscriptions[subscription] {
+		return
+	}
+	delete(subscriptions, subscription)
+	if len(subscriptions) == 0 {
+		delete(r.subscriptions, subscription.key)
+	}
+	close(subscription.events)
+}
+
+// authorizedSubscriptions returns the subscriptions of the ScaledObject of the path accepting the secret,
+// false is returned if there's no subscription of the ScaledObject
+func (r *Receiver) authorizedSubscriptions(path, code string) ([]*Subscription, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	subscriptions, found := r.subscriptions[strings.TrimSuffix(path, "/")]
+	if !found {
+		return nil, false
+	}
+	var authorized []*Subscription
+	for subscription := range subscriptions {
+		if subscription.filter.accepts(code) {
+			authorized = append(authorized, subscription)
+		}
+	}
+	return authorized, true
+}
+
+func (r *Receiver) dispatch(subscriptions []*Subscription, events []Event) {
+	// the lock prevents sending to subscriptions closed in the meantime
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, event := range events {
+		for _, subscription := range subscriptions {
+			if !r.subscriptions[subscription.key][subscription] || !subscription.filter.matches(event) {
+				continue
+			}
+			select {
+			case subscription.events <- event:
+			default:
+			}
+		}
+	}
+}
+
+// ServeHTTP handles validation of event subscriptions and deliveries of events in Event Grid or CloudEvents schema
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	subscriptions, found := r.authorizedSubscriptions(req.URL.Path, req.URL.Query().Get("code"))
+	if !found {
+		http.Error(w, "no trigger subscribed to events of this ScaledObject", http.StatusNotFound)
+		return
+	}
+	if len(subscriptions) == 0 {
+		http.Error(w, "invalid code", http.StatusUnauthorized)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodOptions:
+		// abuse protection of CloudEvents webhooks, https://github.com/cloudevents/spec/blob/v1.0/http-webhook.md#4-abuse-protection
+		origin := req.Header.Get("WebHook-Request-Origin")
+		if origin == "" {
+			http.Error(w, "missing WebHook-Request-Origin header", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("WebHook-Allowed-Origin", origin)
+		w.Header().Set("WebHook-Allowed-Rate", "*")
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPost:
+		body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxRequestBodySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		events, validationCode, err := parseEvents(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationCode != "" {
+			r.logger.Info("Validating Event Grid subscription", "path", req.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]string{"validationResponse": validationCode})
+			return
+		}
+		r.logger.V(1).Info("Received Event Grid events", "path", req.URL.Path, "count", len(events))
+		r.dispatch(subscriptions, events)
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// rawEvent has fields of events in both Event Grid schema (eventType, topic) and CloudEvents schema (type, source)
+type rawEvent struct {
+	EventType string          `json:"eventType"`
+	Type      string          `json:"type"`
+	Subject   string          `json:"subject"`
+	Topic     string          `json:"topic"`
+	Source    string          `json:"source"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// parseEvents parses a batch of events or a single event, the validation code is returned
+// if the events are the validation of an event subscription
+func parseEvents(body []byte) ([]Event, string, error) {
+	var rawEvents []rawEvent
+	if trimmed := strings.TrimSpace(string(body)); strings.HasPrefix(trimmed, "{") {
+		var single rawEvent
+		if err := json.Unmarshal(body, &single); err != nil {
+			return nil, "", fmt.Errorf("error parsing event: %w", err)
+		}
+		rawEvents = []rawEvent{single}
+	} else if err := json.Unmarshal(body, &rawEvents); err != nil {
+		return nil, "", fmt.Errorf("error parsing events: %w", err)
+	}
+
+	events := make([]Event, 0, len(rawEvents))
+	for _, raw := range rawEvents {
+		event := Event{Type: raw.EventType, Subject: raw.Subject, Source: raw.Topic}
+		if event.Type == "" {
+			event.Type = raw.Type
+		}
+		if event.Source == "" {
+			event.Source = raw.Source
+		}
+		if event.Type == subscriptionValidationEventType {
+			var data struct {
+				ValidationCode string `json:"validationCode"`
+			}
+			if err := json.Unmarshal(raw.Data, &data); err != nil || data.ValidationCode == "" {
+				return nil, "", fmt.Errorf("subscription validation event without validation code")
+			}
+			return nil, data.ValidationCode, nil
+		}
+		events = append(events, event)
+	}
+	return events, "", nil
+}
+
+// Start serves the webhook until the context is done, it implements manager.Runnable
+func (r *Receiver) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              r.bindAddress,
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		r.logger.Info("Starting Event Grid receiver", "address", r.bindAddress)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
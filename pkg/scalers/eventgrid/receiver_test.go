@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventgrid
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func post(receiver *Receiver, target, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, target, strings.NewReader(body)))
+	return recorder
+}
+
+func TestReceiverDispatchesMatchingEvents(t *testing.T) {
+	receiver := NewReceiver("")
+	blobs := receiver.Subscribe("default", "worker", Filter{
+		EventTypes:        []string{"Microsoft.Storage.BlobCreated"},
+		SubjectBeginsWith: "/blobServices/default/containers/uploads/",
+	})
+	all := receiver.Subscribe("default", "worker", Filter{})
+	other := receiver.Subscribe("default", "other", Filter{})
+
+	recorder := post(receiver, "/default/worker", `[
+		{"eventType": "Microsoft.Storage.BlobCreated", "subject": "/blobServices/default/containers/uploads/blobs/a.png"},
+		{"eventType": "Microsoft.Storage.BlobCreated", "subject": "/blobServices/default/containers/archive/blobs/b.png"},
+		{"eventType": "Microsoft.Storage.BlobDeleted", "subject": "/blobServices/default/containers/uploads/blobs/c.png"}
+	]`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	assert.Len(t, blobs.Events(), 1)
+	assert.Equal(t, "/blobServices/default/containers/uploads/blobs/a.png", (<-blobs.Events()).Subject)
+	assert.Len(t, all.Events(), 3)
+	assert.Len(t, other.Events(), 0)
+}
+
+func TestReceiverCloudEvents(t *testing.T) {
+	receiver := NewReceiver("")
+	subscription := receiver.Subscribe("default", "worker", Filter{
+		EventTypes: []string{"Microsoft.ServiceBus.ActiveMessagesAvailableWithNoListeners"},
+	})
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodOptions, "/default/worker", nil)
+	req.Header.Set("WebHook-Request-Origin", "eventgrid.azure.net")
+	receiver.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "eventgrid.azure.net", recorder.Header().Get("WebHook-Allowed-Origin"))
+
+	recorder = post(receiver, "/default/worker/", `{"specversion": "1.0", "type": "Microsoft.ServiceBus.ActiveMessagesAvailableWithNoListeners", "source": "/subscriptions/x", "subject": "topics/orders"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	event := <-subscription.Events()
+	assert.Equal(t, "topics/orders", event.Subject)
+	assert.Equal(t, "/subscriptions/x", event.Source)
+}
+
+func TestReceiverSubscriptionValidation(t *testing.T) {
+	receiver := NewReceiver("")
+	receiver.Subscribe("default", "worker", Filter{})
+
+	recorder := post(receiver, "/default/worker", `[{"eventType": "Microsoft.EventGrid.SubscriptionValidationEvent", "data": {"validationCode": "512d38b6"}}]`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response map[string]string
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "512d38b6", response["validationResponse"])
+}
+
+func TestReceiverRejectsRequests(t *testing.T) {
+	receiver := NewReceiver("")
+	subscription := receiver.Subscribe("default", "worker", Filter{Secret: "s3cret"})
+
+	assert.Equal(t, http.StatusNotFound, post(receiver, "/default/unknown", "[]").Code)
+	assert.Equal(t, http.StatusUnauthorized, post(receiver, "/default/worker", "[]").Code)
+	assert.Equal(t, http.StatusUnauthorized, post(receiver, "/default/worker?code=wrong", "[]").Code)
+	assert.Equal(t, http.StatusBadRequest, post(receiver, "/default/worker?code=s3cret", "not json").Code)
+	assert.Equal(t, http.StatusOK, post(receiver, "/default/worker?code=s3cret", `[{"eventType": "a"}]`).Code)
+	assert.Len(t, subscription.Events(), 1)
+
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/default/worker?code=s3cret", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestSubscriptionClose(t *testing.T) {
+	receiver := NewReceiver("")
+	subscription := receiver.Subscribe("default", "worker", Filter{})
+	subscription.Close()
+	subscription.Close()
+
+	_, ok := <-subscription.Events()
+	assert.False(t, ok)
+	assert.Empty(t, receiver.subscriptions)
+	assert.Equal(t, http.StatusNotFound, post(receiver, "/default/worker", "[]").Code)
+}
+
+func TestSubscribeWithoutDefaultReceiver(t *testing.T) {
+	SetDefaultReceiver(nil)
+	_, err := Subscribe("default", "worker", Filter{})
+	assert.ErrorIs(t, err, ErrReceiverDisabled)
+}
@@ -5,18 +5,21 @@ import (
 	"fmt"
 	"strings"
 	"sync"
-	"time"
+	"sync/atomic"
 
 	"github.com/go-logr/logr"
 	"github.com/mitchellh/hashstructure"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
 	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 type externalScaler struct {
@@ -24,6 +27,10 @@ type externalScaler struct {
 	metadata        externalScalerMetadata
 	scaledObjectRef pb.ScaledObjectRef
 	logger          logr.Logger
+
+	// getMetricsAndActivityUnsupported is set once the external scaler reports GetMetricsAndActivity as unimplemented,
+	// from then on separate GetMetrics and IsActive calls are used
+	getMetricsAndActivityUnsupported atomic.Bool
 }
 
 type externalPushScaler struct {
@@ -33,6 +40,7 @@ type externalPushScaler struct {
 type externalScalerMetadata struct {
 	scalerAddress    string
 	tlsCertFile      string
+	tls              tlsAuthMetadata
 	originalMetadata map[string]string
 	scalerIndex      int
 }
@@ -111,6 +119,12 @@ func parseExternalScalerMetadata(config *ScalerConfig) (externalScalerMetadata,
 		meta.tlsCertFile = val
 	}
 
+	tlsAuth, err := parseTLSAuth(config.AuthParams)
+	if err != nil {
+		return meta, err
+	}
+	meta.tls = tlsAuth
+
 	meta.originalMetadata = make(map[string]string)
 
 	// Add elements to metadata
@@ -170,7 +184,6 @@ func (s *externalScaler) GetMetricSpecForScaling(ctx context.Context) []v2.Metri
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *externalScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	var metrics []external_metrics.ExternalMetricValue
 	grpcClient, err := getClientForConnectionPool(s.metadata)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, err
@@ -187,24 +200,44 @@ func (s *externalScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 		ScaledObjectRef: &s.scaledObjectRef,
 	}
 
+	// try to get both metrics and activity in a single call first, external scalers
+	// that don't implement GetMetricsAndActivity are queried through GetMetrics and IsActive
+	if !s.getMetricsAndActivityUnsupported.Load() {
+		response, err := grpcClient.GetMetricsAndActivity(ctx, request)
+		switch {
+		case err == nil:
+			return generateExternalMetrics(metricName, response.MetricValues), response.IsActive, nil
+		case status.Code(err) == codes.Unimplemented:
+			s.logger.V(1).Info("external scaler doesn't implement GetMetricsAndActivity, falling back to GetMetrics and IsActive")
+			s.getMetricsAndActivityUnsupported.Store(true)
+		default:
+			s.logger.Error(err, "error calling GetMetricsAndActivity on external scaler")
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+	}
+
 	metricsResponse, err := grpcClient.GetMetrics(ctx, request)
 	if err != nil {
 		s.logger.Error(err, "error")
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 
-	for _, metricResult := range metricsResponse.MetricValues {
-		metric := GenerateMetricInMili(metricName, float64(metricResult.MetricValue))
-		metrics = append(metrics, metric)
-	}
-
 	isActiveResponse, err := grpcClient.IsActive(ctx, &s.scaledObjectRef)
 	if err != nil {
 		s.logger.Error(err, "error calling IsActive on external scaler")
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 
-	return metrics, isActiveResponse.Result, nil
+	return generateExternalMetrics(metricName, metricsResponse.MetricValues), isActiveResponse.Result, nil
+}
+
+func generateExternalMetrics(metricName string, metricValues []*pb.MetricValue) []external_metrics.ExternalMetricValue {
+	var metrics []external_metrics.ExternalMetricValue
+	for _, metricResult := range metricValues {
+		metric := GenerateMetricInMili(metricName, float64(metricResult.MetricValue))
+		metrics = append(metrics, metric)
+	}
+	return metrics
 }
 
 // handleIsActiveStream is the only writer to the active channel and will close it on return.
@@ -223,33 +256,9 @@ func (s *externalPushScaler) Run(ctx context.Context, active chan<- bool) {
 		}
 	}
 
-	// retry on error from runWithLog() starting by 2 sec backing off * 2 with a max of 1 minute
-	retryDuration := time.Second * 2
-	// the caller of this function needs to ensure that they call Stop() on the resulting
-	// timer, to release background resources.
-	retryBackoff := func() *time.Timer {
-		tmr := time.NewTimer(retryDuration)
-		retryDuration *= 2
-		if retryDuration > time.Minute*1 {
-			retryDuration = time.Minute * 1
-		}
-		return tmr
-	}
-
-	// start the first run without delay
-	runWithLog()
-
-	for {
-		backoffTimer := retryBackoff()
-		select {
-		case <-ctx.Done():
-			backoffTimer.Stop()
-			return
-		case <-backoffTimer.C:
-			backoffTimer.Stop()
-			runWithLog()
-		}
-	}
+	// run again on error from runWithLog() with backoff,
+	// the first run starts without delay
+	kedautil.RunWithBackoff(ctx, newPushScalerBackoff(), runWithLog)
 }
 
 // handleIsActiveStream calls blocks on a stream call from the GRPC server. It'll only terminate on error, stream completion, or ctx cancellation.
@@ -278,6 +287,13 @@ func getClientForConnectionPool(metadata externalScalerMetadata) (pb.ExternalSca
 	defer connectionPoolMutex.Unlock()
 
 	buildGRPCConnection := func(metadata externalScalerMetadata) (*grpc.ClientConn, error) {
+		if metadata.tls.isSet() {
+			tlsConfig, err := metadata.tls.newTLSConfig(false)
+			if err != nil {
+				return nil, err
+			}
+			return grpc.Dial(metadata.scalerAddress, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+		}
 		if metadata.tlsCertFile != "" {
 			creds, err := credentials.NewClientTLSFromFile(metadata.tlsCertFile, "")
 			if err != nil {
@@ -290,8 +306,8 @@ func getClientForConnectionPool(metadata externalScalerMetadata) (pb.ExternalSca
 	}
 
 	// create a unique key per-metadata. If scaledObjects share the same connection properties
-	// in the metadata, they will share the same grpc.ClientConn
-	key, err := hashstructure.Hash(metadata.scalerAddress, nil)
+	// in the metadata and the same certificates, they will share the same grpc.ClientConn
+	key, err := hashstructure.Hash([]string{metadata.scalerAddress, metadata.tlsCertFile, metadata.tls.ca, metadata.tls.cert, metadata.tls.key}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -248,3 +248,75 @@ func TestWaitForState(t *testing.T) {
 		t.Error("waitForState should be get connectivity.Shutdown.")
 	}
 }
+
+type testMetricsExternalScaler struct {
+	pb.UnimplementedExternalScalerServer
+
+	supportsGetMetricsAndActivity bool
+	getMetricsAndActivityCalls    int32
+	getMetricsCalls               int32
+	isActiveCalls                 int32
+}
+
+func (e *testMetricsExternalScaler) IsActive(context.Context, *pb.ScaledObjectRef) (*pb.IsActiveResponse, error) {
+	atomic.AddInt32(&e.isActiveCalls, 1)
+	return &pb.IsActiveResponse{Result: true}, nil
+}
+
+func (e *testMetricsExternalScaler) GetMetrics(_ context.Context, request *pb.GetMetricsRequest) (*pb.GetMetricsResponse, error) {
+	atomic.AddInt32(&e.getMetricsCalls, 1)
+	return &pb.GetMetricsResponse{MetricValues: []*pb.MetricValue{{MetricName: request.MetricName, MetricValue: 10}}}, nil
+}
+
+func (e *testMetricsExternalScaler) GetMetricsAndActivity(ctx context.Context, request *pb.GetMetricsRequest) (*pb.GetMetricsAndActivityResponse, error) {
+	if !e.supportsGetMetricsAndActivity {
+		return e.UnimplementedExternalScalerServer.GetMetricsAndActivity(ctx, request)
+	}
+	atomic.AddInt32(&e.getMetricsAndActivityCalls, 1)
+	return &pb.GetMetricsAndActivityResponse{MetricValues: []*pb.MetricValue{{MetricName: request.MetricName, MetricValue: 10}}, IsActive: true}, nil
+}
+
+func TestExternalScalerGetMetricsAndActivity(t *testing.T) {
+	for i, supportsGetMetricsAndActivity := range []bool{true, false} {
+		server := &testMetricsExternalScaler{supportsGetMetricsAndActivity: supportsGetMetricsAndActivity}
+		grpcServer := grpc.NewServer()
+		address := fmt.Sprintf("127.0.0.1:%d", 15060+i)
+		lis, err := net.Listen("tcp", address)
+		if err != nil {
+			t.Fatalf("start grpcServer with %s failed: %s", address, err)
+		}
+		pb.RegisterExternalScalerServer(grpcServer, server)
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				t.Error(err, "error from grpcServer")
+			}
+		}()
+
+		scaler, err := NewExternalScaler(&ScalerConfig{TriggerMetadata: map[string]string{"scalerAddress": address}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for j := 0; j < 2; j++ {
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-metric")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(metrics) != 1 || metrics[0].Value.MilliValue() != 10000 || !isActive {
+				t.Errorf("unexpected metrics %v and activity %v", metrics, isActive)
+			}
+		}
+
+		if supportsGetMetricsAndActivity {
+			if server.getMetricsAndActivityCalls != 2 || server.getMetricsCalls != 0 || server.isActiveCalls != 0 {
+				t.Errorf("expected only GetMetricsAndActivity calls, got %d GetMetricsAndActivity, %d GetMetrics and %d IsActive calls",
+					server.getMetricsAndActivityCalls, server.getMetricsCalls, server.isActiveCalls)
+			}
+		} else if server.getMetricsCalls != 2 || server.isActiveCalls != 2 {
+			t.Errorf("expected fallback to GetMetrics and IsActive, got %d GetMetrics and %d IsActive calls",
+				server.getMetricsCalls, server.isActiveCalls)
+		}
+
+		grpcServer.Stop()
+	}
+}
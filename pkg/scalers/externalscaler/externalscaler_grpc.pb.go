@@ -19,10 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ExternalScaler_IsActive_FullMethodName       = "/externalscaler.ExternalScaler/IsActive"
-	ExternalScaler_StreamIsActive_FullMethodName = "/externalscaler.ExternalScaler/StreamIsActive"
-	ExternalScaler_GetMetricSpec_FullMethodName  = "/externalscaler.ExternalScaler/GetMetricSpec"
-	ExternalScaler_GetMetrics_FullMethodName     = "/externalscaler.ExternalScaler/GetMetrics"
+	ExternalScaler_IsActive_FullMethodName              = "/externalscaler.ExternalScaler/IsActive"
+	ExternalScaler_StreamIsActive_FullMethodName        = "/externalscaler.ExternalScaler/StreamIsActive"
+	ExternalScaler_GetMetricSpec_FullMethodName         = "/externalscaler.ExternalScaler/GetMetricSpec"
+	ExternalScaler_GetMetrics_FullMethodName            = "/externalscaler.ExternalScaler/GetMetrics"
+	ExternalScaler_GetMetricsAndActivity_FullMethodName = "/externalscaler.ExternalScaler/GetMetricsAndActivity"
 )
 
 // ExternalScalerClient is the client API for ExternalScaler service.
@@ -33,6 +34,7 @@ type ExternalScalerClient interface {
 	StreamIsActive(ctx context.Context, in *ScaledObjectRef, opts ...grpc.CallOption) (ExternalScaler_StreamIsActiveClient, error)
 	GetMetricSpec(ctx context.Context, in *ScaledObjectRef, opts ...grpc.CallOption) (*GetMetricSpecResponse, error)
 	GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsResponse, error)
+	GetMetricsAndActivity(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsAndActivityResponse, error)
 }
 
 type externalScalerClient struct {
@@ -102,6 +104,15 @@ func (c *externalScalerClient) GetMetrics(ctx context.Context, in *GetMetricsReq
 	return out, nil
 }
 
+func (c *externalScalerClient) GetMetricsAndActivity(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsAndActivityResponse, error) {
+	out := new(GetMetricsAndActivityResponse)
+	err := c.cc.Invoke(ctx, ExternalScaler_GetMetricsAndActivity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExternalScalerServer is the server API for ExternalScaler service.
 // All implementations must embed UnimplementedExternalScalerServer
 // for forward compatibility
@@ -110,6 +121,7 @@ type ExternalScalerServer interface {
 	StreamIsActive(*ScaledObjectRef, ExternalScaler_StreamIsActiveServer) error
 	GetMetricSpec(context.Context, *ScaledObjectRef) (*GetMetricSpecResponse, error)
 	GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsResponse, error)
+	GetMetricsAndActivity(context.Context, *GetMetricsRequest) (*GetMetricsAndActivityResponse, error)
 	mustEmbedUnimplementedExternalScalerServer()
 }
 
@@ -129,6 +141,9 @@ func (UnimplementedExternalScalerServer) GetMetricSpec(context.Context, *ScaledO
 func (UnimplementedExternalScalerServer) GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
 }
+func (UnimplementedExternalScalerServer) GetMetricsAndActivity(context.Context, *GetMetricsRequest) (*GetMetricsAndActivityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetricsAndActivity not implemented")
+}
 func (UnimplementedExternalScalerServer) mustEmbedUnimplementedExternalScalerServer() {}
 
 // UnsafeExternalScalerServer may be embedded to opt out of forward compatibility for this service.
@@ -217,6 +232,24 @@ func _ExternalScaler_GetMetrics_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExternalScaler_GetMetricsAndActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalScalerServer).GetMetricsAndActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExternalScaler_GetMetricsAndActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalScalerServer).GetMetricsAndActivity(ctx, req.(*GetMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ExternalScaler_ServiceDesc is the grpc.ServiceDesc for ExternalScaler service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -236,6 +269,10 @@ var ExternalScaler_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMetrics",
 			Handler:    _ExternalScaler_GetMetrics_Handler,
 		},
+		{
+			MethodName: "GetMetricsAndActivity",
+			Handler:    _ExternalScaler_GetMetricsAndActivity_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
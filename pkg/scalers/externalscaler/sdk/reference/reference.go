@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reference is a reference implementation of an external (push) scaler built with the sdk package,
+// it scales on the number of files matching the pattern in a directory, eg. a spool directory of jobs:
+//
+//	server := sdk.NewServer(&reference.FileCountScaler{})
+//	err := server.ListenAndServe(":6000")
+//
+// and the trigger of the ScaledObject:
+//
+//	triggers:
+//	- type: external-push
+//	  metadata:
+//	    scalerAddress: file-count-scaler.default:6000
+//	    path: /var/spool/jobs
+//	    pattern: "*.job"
+//	    targetSize: "5"
+package reference
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/externalscaler/sdk"
+)
+
+const (
+	defaultPattern      = "*"
+	defaultTargetSize   = 5
+	defaultPushInterval = 5 * time.Second
+)
+
+// FileCountScaler scales on the number of files matching the pattern in a directory
+type FileCountScaler struct{}
+
+type fileCountMetadata struct {
+	path                 string
+	pattern              string
+	targetSize           int64
+	activationTargetSize int64
+	pushInterval         time.Duration
+}
+
+func parseFileCountMetadata(metadata map[string]string) (*fileCountMetadata, error) {
+	meta := fileCountMetadata{}
+
+	meta.path = metadata["path"]
+	if meta.path == "" {
+		return nil, fmt.Errorf("%w: no path given", sdk.ErrInvalidMetadata)
+	}
+
+	meta.pattern = defaultPattern
+	if val := metadata["pattern"]; val != "" {
+		if _, err := filepath.Match(val, ""); err != nil {
+			return nil, fmt.Errorf("%w: invalid pattern: %s", sdk.ErrInvalidMetadata, err)
+		}
+		meta.pattern = val
+	}
+
+	meta.targetSize = defaultTargetSize
+	if val := metadata["targetSize"]; val != "" {
+		targetSize, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || targetSize <= 0 {
+			return nil, fmt.Errorf("%w: targetSize must be a positive number, got %s", sdk.ErrInvalidMetadata, val)
+		}
+		meta.targetSize = targetSize
+	}
+
+	if val := metadata["activationTargetSize"]; val != "" {
+		activationTargetSize, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: error parsing activationTargetSize: %s", sdk.ErrInvalidMetadata, err)
+		}
+		meta.activationTargetSize = activationTargetSize
+	}
+
+	meta.pushInterval = defaultPushInterval
+	if val := metadata["pushInterval"]; val != "" {
+		pushInterval, err := time.ParseDuration(val)
+		if err != nil || pushInterval <= 0 {
+			return nil, fmt.Errorf("%w: pushInterval must be a positive duration, got %s", sdk.ErrInvalidMetadata, val)
+		}
+		meta.pushInterval = pushInterval
+	}
+
+	return &meta, nil
+}
+
+func getMetricName(meta *fileCountMetadata) string {
+	return "files-" + filepath.Base(meta.path)
+}
+
+func countFiles(meta *fileCountMetadata) (int64, error) {
+	matches, err := filepath.Glob(filepath.Join(meta.path, meta.pattern))
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(matches)), nil
+}
+
+// GetMetricSpec implements sdk.Scaler
+func (s *FileCountScaler) GetMetricSpec(_ context.Context, ref sdk.ScaledObjectRef) ([]sdk.MetricSpec, error) {
+	meta, err := parseFileCountMetadata(ref.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return []sdk.MetricSpec{{MetricName: getMetricName(meta), TargetSize: meta.targetSize}}, nil
+}
+
+// GetMetricsAndActivity implements sdk.Scaler
+func (s *FileCountScaler) GetMetricsAndActivity(_ context.Context, ref sdk.ScaledObjectRef, metricName string) ([]sdk.MetricValue, bool, error) {
+	meta, err := parseFileCountMetadata(ref.Metadata)
+	if err != nil {
+		return nil, false, err
+	}
+	count, err := countFiles(meta)
+	if err != nil {
+		return nil, false, err
+	}
+	return []sdk.MetricValue{{MetricName: metricName, Value: count}}, count > meta.activationTargetSize, nil
+}
+
+// StreamIsActive implements sdk.PushScaler, the activity is sent every pushInterval
+func (s *FileCountScaler) StreamIsActive(ctx context.Context, ref sdk.ScaledObjectRef, active chan<- bool) error {
+	meta, err := parseFileCountMetadata(ref.Metadata)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(meta.pushInterval)
+	defer ticker.Stop()
+	for {
+		count, err := countFiles(meta)
+		if err != nil {
+			return err
+		}
+		select {
+		case active <- count > meta.activationTargetSize:
+		case <-ctx.Done():
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
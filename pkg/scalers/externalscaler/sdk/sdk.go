@@ -0,0 +1,190 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sdk helps to build external scalers in Go, a type implementing Scaler (and optionally PushScaler)
+// is served by Server over the gRPC contract of KEDA external scalers, including the gRPC health check service.
+package sdk
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+)
+
+// ScaledObjectRef references the ScaledObject or ScaledJob of the trigger and contains the trigger metadata
+type ScaledObjectRef struct {
+	Name      string
+	Namespace string
+	Metadata  map[string]string
+}
+
+// MetricSpec is a metric of the trigger with its target value
+type MetricSpec struct {
+	MetricName string
+	TargetSize int64
+}
+
+// MetricValue is the current value of a metric of the trigger
+type MetricValue struct {
+	MetricName string
+	Value      int64
+}
+
+// Scaler is implemented by external scalers
+type Scaler interface {
+	// GetMetricSpec returns the metrics of the trigger with their target values
+	GetMetricSpec(ctx context.Context, ref ScaledObjectRef) ([]MetricSpec, error)
+	// GetMetricsAndActivity returns the current values of the metric and whether the trigger is active
+	GetMetricsAndActivity(ctx context.Context, ref ScaledObjectRef, metricName string) ([]MetricValue, bool, error)
+}
+
+// PushScaler is implemented by external push scalers, which notify KEDA about changes of activity of the trigger
+type PushScaler interface {
+	Scaler
+	// StreamIsActive sends the activity of the trigger to the active channel until the context is done,
+	// sending to the channel must not block once the context is done
+	StreamIsActive(ctx context.Context, ref ScaledObjectRef, active chan<- bool) error
+}
+
+// ErrInvalidMetadata is returned (wrapped) by scalers for invalid trigger metadata,
+// it's reported to KEDA as InvalidArgument status
+var ErrInvalidMetadata = errors.New("invalid metadata")
+
+// externalScalerServer adapts Scaler to the gRPC contract of KEDA external scalers
+type externalScalerServer struct {
+	scaler Scaler
+	pb.UnimplementedExternalScalerServer
+}
+
+func newScaledObjectRef(ref *pb.ScaledObjectRef) ScaledObjectRef {
+	if ref == nil {
+		return ScaledObjectRef{Metadata: map[string]string{}}
+	}
+	metadata := ref.ScalerMetadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	return ScaledObjectRef{Name: ref.Name, Namespace: ref.Namespace, Metadata: metadata}
+}
+
+// toStatus converts errors of the scaler to gRPC status errors
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, ErrInvalidMetadata):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Unknown, err.Error())
+	}
+}
+
+func toMetricValues(values []MetricValue) []*pb.MetricValue {
+	metricValues := make([]*pb.MetricValue, 0, len(values))
+	for _, value := range values {
+		metricValues = append(metricValues, &pb.MetricValue{MetricName: value.MetricName, MetricValue: value.Value})
+	}
+	return metricValues
+}
+
+func (s *externalScalerServer) GetMetricSpec(ctx context.Context, ref *pb.ScaledObjectRef) (*pb.GetMetricSpecResponse, error) {
+	specs, err := s.scaler.GetMetricSpec(ctx, newScaledObjectRef(ref))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	metricSpecs := make([]*pb.MetricSpec, 0, len(specs))
+	for _, spec := range specs {
+		metricSpecs = append(metricSpecs, &pb.MetricSpec{MetricName: spec.MetricName, TargetSize: spec.TargetSize})
+	}
+	return &pb.GetMetricSpecResponse{MetricSpecs: metricSpecs}, nil
+}
+
+func (s *externalScalerServer) GetMetricsAndActivity(ctx context.Context, request *pb.GetMetricsRequest) (*pb.GetMetricsAndActivityResponse, error) {
+	values, isActive, err := s.scaler.GetMetricsAndActivity(ctx, newScaledObjectRef(request.ScaledObjectRef), request.MetricName)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.GetMetricsAndActivityResponse{MetricValues: toMetricValues(values), IsActive: isActive}, nil
+}
+
+func (s *externalScalerServer) GetMetrics(ctx context.Context, request *pb.GetMetricsRequest) (*pb.GetMetricsResponse, error) {
+	values, _, err := s.scaler.GetMetricsAndActivity(ctx, newScaledObjectRef(request.ScaledObjectRef), request.MetricName)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.GetMetricsResponse{MetricValues: toMetricValues(values)}, nil
+}
+
+// IsActive is used by KEDA versions not calling GetMetricsAndActivity,
+// the trigger is active if any of its metrics is active
+func (s *externalScalerServer) IsActive(ctx context.Context, ref *pb.ScaledObjectRef) (*pb.IsActiveResponse, error) {
+	scaledObjectRef := newScaledObjectRef(ref)
+	specs, err := s.scaler.GetMetricSpec(ctx, scaledObjectRef)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	for _, spec := range specs {
+		_, isActive, err := s.scaler.GetMetricsAndActivity(ctx, scaledObjectRef, spec.MetricName)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		if isActive {
+			return &pb.IsActiveResponse{Result: true}, nil
+		}
+	}
+	return &pb.IsActiveResponse{Result: false}, nil
+}
+
+func (s *externalScalerServer) StreamIsActive(ref *pb.ScaledObjectRef, stream pb.ExternalScaler_StreamIsActiveServer) error {
+	pushScaler, ok := s.scaler.(PushScaler)
+	if !ok {
+		return status.Error(codes.Unimplemented, "scaler doesn't support StreamIsActive")
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	active := make(chan bool)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- pushScaler.StreamIsActive(ctx, newScaledObjectRef(ref), active)
+	}()
+
+	for {
+		select {
+		case isActive := <-active:
+			if err := stream.Send(&pb.IsActiveResponse{Result: isActive}); err != nil {
+				return err
+			}
+		case err := <-errCh:
+			return toStatus(err)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+	"github.com/kedacore/keda/v2/pkg/scalers/externalscaler/sdk"
+	"github.com/kedacore/keda/v2/pkg/scalers/externalscaler/sdk/reference"
+)
+
+// startServer serves the reference scaler on a random local port and returns its address
+func startServer(t *testing.T, options ...grpc.ServerOption) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := sdk.NewServer(&reference.FileCountScaler{}, options...)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+func dial(t *testing.T, address string, creds credentials.TransportCredentials) *grpc.ClientConn {
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// spoolDir creates a directory with the number of job files
+func spoolDir(t *testing.T, jobs int) string {
+	dir := filepath.Join(t.TempDir(), "jobs")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	for i := 0; i < jobs; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("%d.job", i)), nil, 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), nil, 0o600))
+	return dir
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	client := pb.NewExternalScalerClient(dial(t, startServer(t), insecure.NewCredentials()))
+	ref := &pb.ScaledObjectRef{Name: "consumer", Namespace: "default", ScalerMetadata: map[string]string{"path": spoolDir(t, 3), "pattern": "*.job", "targetSize": "2"}}
+
+	specResponse, err := client.GetMetricSpec(ctx, ref)
+	require.NoError(t, err)
+	require.Len(t, specResponse.MetricSpecs, 1)
+	assert.Equal(t, "files-jobs", specResponse.MetricSpecs[0].MetricName)
+	assert.Equal(t, int64(2), specResponse.MetricSpecs[0].TargetSize)
+
+	request := &pb.GetMetricsRequest{ScaledObjectRef: ref, MetricName: "files-jobs"}
+	metricsAndActivityResponse, err := client.GetMetricsAndActivity(ctx, request)
+	require.NoError(t, err)
+	assert.True(t, metricsAndActivityResponse.IsActive)
+	require.Len(t, metricsAndActivityResponse.MetricValues, 1)
+	assert.Equal(t, int64(3), metricsAndActivityResponse.MetricValues[0].MetricValue)
+
+	// the contract of KEDA versions not calling GetMetricsAndActivity
+	metricsResponse, err := client.GetMetrics(ctx, request)
+	require.NoError(t, err)
+	require.Len(t, metricsResponse.MetricValues, 1)
+	assert.Equal(t, int64(3), metricsResponse.MetricValues[0].MetricValue)
+
+	isActiveResponse, err := client.IsActive(ctx, ref)
+	require.NoError(t, err)
+	assert.True(t, isActiveResponse.Result)
+
+	ref.ScalerMetadata["activationTargetSize"] = "3"
+	isActiveResponse, err = client.IsActive(ctx, ref)
+	require.NoError(t, err)
+	assert.False(t, isActiveResponse.Result)
+}
+
+func TestServerInvalidMetadata(t *testing.T) {
+	client := pb.NewExternalScalerClient(dial(t, startServer(t), insecure.NewCredentials()))
+
+	_, err := client.GetMetricSpec(context.Background(), &pb.ScaledObjectRef{ScalerMetadata: map[string]string{"targetSize": "2"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServerStreamIsActive(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client := pb.NewExternalScalerClient(dial(t, startServer(t), insecure.NewCredentials()))
+	dir := spoolDir(t, 0)
+
+	stream, err := client.StreamIsActive(ctx, &pb.ScaledObjectRef{ScalerMetadata: map[string]string{"path": dir, "pattern": "*.job", "pushInterval": "10ms"}})
+	require.NoError(t, err)
+
+	response, err := stream.Recv()
+	require.NoError(t, err)
+	assert.False(t, response.Result)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "1.job"), nil, 0o600))
+	for !response.Result {
+		response, err = stream.Recv()
+		require.NoError(t, err)
+	}
+}
+
+func TestServerHealth(t *testing.T) {
+	client := healthpb.NewHealthClient(dial(t, startServer(t), insecure.NewCredentials()))
+
+	for _, service := range []string{"", pb.ExternalScaler_ServiceDesc.ServiceName} {
+		response, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.Status)
+	}
+}
+
+// TestKEDAExternalScaler checks the contract with the external scaler client of KEDA
+func TestKEDAExternalScaler(t *testing.T) {
+	ctx := context.Background()
+	scaler, err := scalers.NewExternalScaler(&scalers.ScalerConfig{
+		TriggerMetadata: map[string]string{"scalerAddress": startServer(t), "path": spoolDir(t, 3), "pattern": "*.job", "targetSize": "2"},
+		ScalerIndex:     1,
+	})
+	require.NoError(t, err)
+	defer scaler.Close(ctx)
+
+	metricSpecs := scaler.GetMetricSpecForScaling(ctx)
+	require.Len(t, metricSpecs, 1)
+	metricName := metricSpecs[0].External.Metric.Name
+	assert.Equal(t, "s1-files-jobs", metricName)
+
+	metrics, isActive, err := scaler.GetMetricsAndActivity(ctx, metricName)
+	require.NoError(t, err)
+	assert.True(t, isActive)
+	require.Len(t, metrics, 1)
+	assert.Equal(t, int64(3), metrics[0].Value.Value())
+}
+
+func TestServerMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	caCert, caKey := writeCertificate(t, dir, "ca", nil, nil)
+	writeCertificate(t, dir, "server", caCert, caKey)
+	writeCertificate(t, dir, "client", caCert, caKey)
+	file := func(name string) string { return filepath.Join(dir, name) }
+
+	serverCreds, err := sdk.ServerTLSCredentials(file("server.crt"), file("server.key"), file("ca.crt"))
+	require.NoError(t, err)
+	address := startServer(t, grpc.Creds(serverCreds))
+
+	clientCreds, err := sdk.ClientTLSCredentials(file("ca.crt"), file("client.crt"), file("client.key"))
+	require.NoError(t, err)
+	_, err = healthpb.NewHealthClient(dial(t, address, clientCreds)).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+
+	// clients without certificate are rejected
+	clientCreds, err = sdk.ClientTLSCredentials(file("ca.crt"), "", "")
+	require.NoError(t, err)
+	_, err = healthpb.NewHealthClient(dial(t, address, clientCreds)).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.Error(t, err)
+}
+
+// writeCertificate writes the certificate and key signed by the CA to the directory, a CA is created if no CA is given
+func writeCertificate(t *testing.T, dir, name string, caCert *x509.Certificate, caKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	parent, signer := caCert, caKey
+	if caCert == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, signer = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return cert, key
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+)
+
+// Server serves a Scaler over the gRPC contract of KEDA external scalers,
+// the gRPC health check service reports the external scaler service as serving until the server is stopped
+type Server struct {
+	grpcServer *grpc.Server
+	health     *health.Server
+}
+
+// NewServer creates a new Server for the scaler, options are passed to the gRPC server,
+// eg. grpc.Creds(creds) with credentials from ServerTLSCredentials to serve over (m)TLS
+func NewServer(scaler Scaler, options ...grpc.ServerOption) *Server {
+	grpcServer := grpc.NewServer(options...)
+	pb.RegisterExternalScalerServer(grpcServer, &externalScalerServer{scaler: scaler})
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(pb.ExternalScaler_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	return &Server{
+		grpcServer: grpcServer,
+		health:     healthServer,
+	}
+}
+
+// Serve accepts connections on the listener until the server is stopped
+func (s *Server) Serve(lis net.Listener) error {
+	if err := s.grpcServer.Serve(lis); err != nil {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
+
+// ListenAndServe listens on the TCP address and accepts connections until the server is stopped
+func (s *Server) ListenAndServe(address string) error {
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	return s.Serve(lis)
+}
+
+// Stop reports the server as not serving and stops it after all pending requests are finished,
+// streams of push scalers are closed
+func (s *Server) Stop() {
+	s.health.Shutdown()
+	s.grpcServer.GracefulStop()
+}
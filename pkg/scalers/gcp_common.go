@@ -3,6 +3,8 @@ package scalers
 import (
 	"fmt"
 
+	"google.golang.org/api/option"
+
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
@@ -10,6 +12,7 @@ type gcpAuthorizationMetadata struct {
 	GoogleApplicationCredentials     string
 	GoogleApplicationCredentialsFile string
 	podIdentityProviderEnabled       bool
+	podIdentityServiceAccount        string
 }
 
 func getGcpAuthorization(config *ScalerConfig, resolvedEnv map[string]string) (*gcpAuthorizationMetadata, error) {
@@ -21,6 +24,7 @@ func getGcpAuthorization(config *ScalerConfig, resolvedEnv map[string]string) (*
 	case config.PodIdentity.Provider == kedav1alpha1.PodIdentityProviderGCP:
 		// do nothing, rely on underneath metadata google
 		meta.podIdentityProviderEnabled = true
+		meta.podIdentityServiceAccount = config.PodIdentity.IdentityID
 	case authParams["GoogleApplicationCredentials"] != "":
 		meta.GoogleApplicationCredentials = authParams["GoogleApplicationCredentials"]
 	default:
@@ -35,3 +39,12 @@ func getGcpAuthorization(config *ScalerConfig, resolvedEnv map[string]string) (*
 	}
 	return &meta, nil
 }
+
+// podIdentityClientOptions returns the options to impersonate the service account given by the TriggerAuthentication
+// with the credentials of KEDA Operator, none if the credentials of KEDA Operator are used as they are
+func (meta *gcpAuthorizationMetadata) podIdentityClientOptions() []option.ClientOption {
+	if meta.podIdentityServiceAccount == "" {
+		return nil
+	}
+	return []option.ClientOption{option.ImpersonateCredentials(meta.podIdentityServiceAccount)}
+}
@@ -194,7 +194,7 @@ func (s *pubsubScaler) setStackdriverClient(ctx context.Context) error {
 	var client *StackDriverClient
 	var err error
 	if s.metadata.gcpAuthorization.podIdentityProviderEnabled {
-		client, err = NewStackDriverClientPodIdentity(ctx)
+		client, err = NewStackDriverClientPodIdentity(ctx, s.metadata.gcpAuthorization.podIdentityClientOptions()...)
 	} else {
 		client, err = NewStackDriverClient(ctx, s.metadata.gcpAuthorization.GoogleApplicationCredentials)
 	}
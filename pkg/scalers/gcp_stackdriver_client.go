@@ -22,6 +22,9 @@ import (
 // for a stackdriver scaler in the future
 type StackDriverClient struct {
 	metricsClient *monitoring.MetricClient
+	// queryClient runs MQL queries, it's created by EnableQueryClient only when needed
+	queryClient   *monitoring.QueryClient
+	clientOptions []option.ClientOption
 	credentials   GoogleApplicationCredentials
 	projectID     string
 }
@@ -43,13 +46,14 @@ func NewStackDriverClient(ctx context.Context, credentials string) (*StackDriver
 
 	return &StackDriverClient{
 		metricsClient: client,
+		clientOptions: []option.ClientOption{clientOption},
 		credentials:   gcpCredentials,
 	}, nil
 }
 
-// NewStackDriverClient creates a new stackdriver client with the credentials underlying
-func NewStackDriverClientPodIdentity(ctx context.Context) (*StackDriverClient, error) {
-	client, err := monitoring.NewMetricClient(ctx)
+// NewStackDriverClientPodIdentity creates a new stackdriver client with the credentials underlying
+func NewStackDriverClientPodIdentity(ctx context.Context, opts ...option.ClientOption) (*StackDriverClient, error) {
+	client, err := monitoring.NewMetricClient(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -66,10 +70,35 @@ func NewStackDriverClientPodIdentity(ctx context.Context) (*StackDriverClient, e
 
 	return &StackDriverClient{
 		metricsClient: client,
+		clientOptions: opts,
 		projectID:     project,
 	}, nil
 }
 
+// EnableQueryClient creates the client running MQL queries by QueryMetrics with the credentials of the metrics client
+func (s *StackDriverClient) EnableQueryClient(ctx context.Context) error {
+	if s.queryClient != nil {
+		return nil
+	}
+	client, err := monitoring.NewQueryClient(ctx, s.clientOptions...)
+	if err != nil {
+		return err
+	}
+	s.queryClient = client
+	return nil
+}
+
+// Close closes the metrics client and the query client if it's enabled
+func (s *StackDriverClient) Close() error {
+	err := s.metricsClient.Close()
+	if s.queryClient != nil {
+		if queryErr := s.queryClient.Close(); err == nil {
+			err = queryErr
+		}
+	}
+	return err
+}
+
 func NewStackdriverAggregator(period int64, aligner string, reducer string) (*monitoringpb.Aggregation, error) {
 	sdAggregation := monitoringpb.Aggregation{
 		AlignmentPeriod: &durationpb.Duration{
@@ -192,16 +221,7 @@ func (s StackDriverClient) GetMetrics(
 		Aggregation: aggregation,
 	}
 
-	switch projectID {
-	case "":
-		if len(s.projectID) > 0 {
-			req.Name = "projects/" + s.projectID
-		} else {
-			req.Name = "projects/" + s.credentials.ProjectID
-		}
-	default:
-		req.Name = "projects/" + projectID
-	}
+	req.Name = s.projectName(projectID)
 
 	// Get an iterator with the list of time series
 	it := s.metricsClient.ListTimeSeries(ctx, req)
@@ -231,9 +251,59 @@ func (s StackDriverClient) GetMetrics(
 	return value, nil
 }
 
+// QueryMetrics fetches metrics from stackdriver by a MQL query, the value of the first time series is returned,
+// the time range and the aggregation are given by the query, see https://cloud.google.com/monitoring/mql
+func (s StackDriverClient) QueryMetrics(ctx context.Context, projectID string, query string) (float64, error) {
+	if s.queryClient == nil {
+		return -1, fmt.Errorf("query client of stackdriver isn't enabled")
+	}
+
+	req := &monitoringpb.QueryTimeSeriesRequest{
+		Name:  s.projectName(projectID),
+		Query: query,
+	}
+
+	it := s.queryClient.QueryTimeSeries(ctx, req)
+
+	resp, err := it.Next()
+	if err == iterator.Done {
+		return -1, fmt.Errorf("could not find stackdriver metric with query %s", query)
+	}
+	if err != nil {
+		return -1, err
+	}
+
+	return extractValueFromTimeSeriesData(resp)
+}
+
+// projectName returns the resource name of the project given by projectID or the project of the credentials
+func (s StackDriverClient) projectName(projectID string) string {
+	switch projectID {
+	case "":
+		if len(s.projectID) > 0 {
+			return "projects/" + s.projectID
+		}
+		return "projects/" + s.credentials.ProjectID
+	default:
+		return "projects/" + projectID
+	}
+}
+
+// extractValueFromTimeSeriesData returns the first value of the latest point of the time series returned by a MQL query
+func extractValueFromTimeSeriesData(data *monitoringpb.TimeSeriesData) (float64, error) {
+	points := data.GetPointData()
+	if len(points) == 0 || len(points[0].GetValues()) == 0 {
+		return -1, fmt.Errorf("no value in time series returned by the query")
+	}
+	return extractValueFromTypedValue(points[0].GetValues()[0])
+}
+
 // extractValueFromPoint attempts to extract a float64 by asserting the point's value type
 func extractValueFromPoint(point *monitoringpb.Point) (float64, error) {
-	typedValue := point.GetValue()
+	return extractValueFromTypedValue(point.GetValue())
+}
+
+func extractValueFromTypedValue(typedValue *monitoringpb.TypedValue) (float64, error) {
 	switch typedValue.Value.(type) {
 	case *monitoringpb.TypedValue_DoubleValue:
 		return typedValue.GetDoubleValue(), nil
@@ -10,6 +10,7 @@ import (
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	"github.com/kedacore/keda/v2/pkg/scalers/metricsproxy"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -27,12 +28,14 @@ type stackdriverScaler struct {
 type stackdriverMetadata struct {
 	projectID             string
 	filter                string
+	query                 string
 	targetValue           float64
 	activationTargetValue float64
 	metricName            string
 
 	gcpAuthorization *gcpAuthorizationMetadata
 	aggregation      *monitoringpb.Aggregation
+	metricsProxyKey  string
 }
 
 // NewStackdriverScaler creates a new stackdriverScaler
@@ -54,6 +57,13 @@ func NewStackdriverScaler(ctx context.Context, config *ScalerConfig) (Scaler, er
 		logger.Error(err, "Failed to create stack driver client")
 		return nil, err
 	}
+	if meta.query != "" {
+		if err := client.EnableQueryClient(ctx); err != nil {
+			logger.Error(err, "Failed to create stack driver query client")
+			_ = client.Close()
+			return nil, err
+		}
+	}
 
 	return &stackdriverScaler{
 		metricType: metricType,
@@ -77,14 +87,14 @@ func parseStackdriverMetadata(config *ScalerConfig, logger logr.Logger) (*stackd
 		return nil, fmt.Errorf("no projectId name given")
 	}
 
-	if val, ok := config.TriggerMetadata["filter"]; ok {
-		if val == "" {
-			return nil, fmt.Errorf("no filter given")
-		}
-
-		meta.filter = val
-	} else {
-		return nil, fmt.Errorf("no filter given")
+	// the metric is given either by a filter with an optional aggregation or by a MQL query
+	meta.filter = config.TriggerMetadata["filter"]
+	meta.query = config.TriggerMetadata["query"]
+	switch {
+	case meta.filter == "" && meta.query == "":
+		return nil, fmt.Errorf("no filter or query given")
+	case meta.filter != "" && meta.query != "":
+		return nil, fmt.Errorf("only one of filter or query can be given")
 	}
 
 	name := kedautil.NormalizeString(fmt.Sprintf("gcp-stackdriver-%s", meta.projectID))
@@ -119,8 +129,16 @@ func parseStackdriverMetadata(config *ScalerConfig, logger logr.Logger) (*stackd
 	if err != nil {
 		return nil, err
 	}
+	if aggregation != nil && meta.query != "" {
+		return nil, fmt.Errorf("alignmentPeriodSeconds can't be given with query, the aggregation is part of the query")
+	}
 	meta.aggregation = aggregation
 
+	meta.metricsProxyKey, err = getMetricsProxyKey(config, "targetValue", "activationTargetValue")
+	if err != nil {
+		return nil, err
+	}
+
 	return &meta, nil
 }
 
@@ -150,7 +168,7 @@ func initializeStackdriverClient(ctx context.Context, gcpAuthorization *gcpAutho
 	var client *StackDriverClient
 	var err error
 	if gcpAuthorization.podIdentityProviderEnabled {
-		client, err = NewStackDriverClientPodIdentity(ctx)
+		client, err = NewStackDriverClientPodIdentity(ctx, gcpAuthorization.podIdentityClientOptions()...)
 	} else {
 		client, err = NewStackDriverClient(ctx, gcpAuthorization.GoogleApplicationCredentials)
 	}
@@ -164,7 +182,7 @@ func initializeStackdriverClient(ctx context.Context, gcpAuthorization *gcpAutho
 
 func (s *stackdriverScaler) Close(context.Context) error {
 	if s.client != nil {
-		err := s.client.metricsClient.Close()
+		err := s.client.Close()
 		s.client = nil
 		if err != nil {
 			s.logger.Error(err, "error closing StackDriver client")
@@ -207,8 +225,19 @@ func (s *stackdriverScaler) GetMetricsAndActivity(ctx context.Context, metricNam
 
 // getMetrics gets metric type value from stackdriver api
 func (s *stackdriverScaler) getMetrics(ctx context.Context) (float64, error) {
-	val, err := s.client.GetMetrics(ctx, s.metadata.filter, s.metadata.projectID, s.metadata.aggregation)
-	if err == nil {
+	val, err := metricsproxy.Query(ctx, "gcp-stackdriver", s.metadata.metricsProxyKey, func(ctx context.Context) (float64, error) {
+		if s.metadata.query != "" {
+			return s.client.QueryMetrics(ctx, s.metadata.projectID, s.metadata.query)
+		}
+		return s.client.GetMetrics(ctx, s.metadata.filter, s.metadata.projectID, s.metadata.aggregation)
+	})
+	if err == nil && s.metadata.query != "" {
+		s.logger.V(1).Info(
+			fmt.Sprintf("Getting metrics for project %s and query %s. Result: %f",
+				s.metadata.projectID,
+				s.metadata.query,
+				val))
+	} else if err == nil {
 		s.logger.V(1).Info(
 			fmt.Sprintf("Getting metrics for project %s, filter %s and aggregation %v. Result: %f",
 				s.metadata.projectID,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	monitoringpb "cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
 	"github.com/go-logr/logr"
 )
 
@@ -25,6 +26,8 @@ type gcpStackdriverMetricIdentifier struct {
 
 var sdFilter = "metric.type=\"storage.googleapis.com/storage/object_count\" resource.type=\"gcs_bucket\""
 
+var sdQuery = "fetch gcs_bucket | metric 'storage.googleapis.com/storage/object_count' | group_by [], sum(val()) | within 5m"
+
 var testStackdriverMetadata = []parseStackdriverMetadataTestData{
 	{map[string]string{}, map[string]string{}, true},
 	// all properly formed
@@ -55,6 +58,12 @@ var testStackdriverMetadata = []parseStackdriverMetadataTestData{
 	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "alignmentPeriodSeconds": "a"}, true},
 	// properly formed float targetValue and activationTargetValue
 	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "targetValue": "1.1", "activationTargetValue": "2.1"}, false},
+	// MQL query
+	{nil, map[string]string{"projectId": "myProject", "query": sdQuery, "credentialsFromEnv": "SAMPLE_CREDS"}, false},
+	// both filter and query
+	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "query": sdQuery, "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// aggregation with query
+	{nil, map[string]string{"projectId": "myProject", "query": sdQuery, "credentialsFromEnv": "SAMPLE_CREDS", "alignmentPeriodSeconds": "120"}, true},
 }
 
 var gcpStackdriverMetricIdentifiers = []gcpStackdriverMetricIdentifier{
@@ -89,3 +98,21 @@ func TestGcpStackdriverGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestStackdriverExtractValueFromTimeSeriesData(t *testing.T) {
+	data := &monitoringpb.TimeSeriesData{PointData: []*monitoringpb.TimeSeriesData_PointData{
+		{Values: []*monitoringpb.TypedValue{{Value: &monitoringpb.TypedValue_Int64Value{Int64Value: 7}}}},
+		{Values: []*monitoringpb.TypedValue{{Value: &monitoringpb.TypedValue_Int64Value{Int64Value: 3}}}},
+	}}
+	value, err := extractValueFromTimeSeriesData(data)
+	if err != nil {
+		t.Error("Expected success but got error", err)
+	}
+	if value != 7 {
+		t.Errorf("Expected value 7 of the latest point but got %f", value)
+	}
+
+	if _, err := extractValueFromTimeSeriesData(&monitoringpb.TimeSeriesData{}); err == nil {
+		t.Error("Expected error for time series without points but got success")
+	}
+}
@@ -62,7 +62,7 @@ func NewGcsScaler(config *ScalerConfig) (Scaler, error) {
 
 	switch {
 	case meta.gcpAuthorization.podIdentityProviderEnabled:
-		client, err = storage.NewClient(ctx)
+		client, err = storage.NewClient(ctx, meta.gcpAuthorization.podIdentityClientOptions()...)
 	case meta.gcpAuthorization.GoogleApplicationCredentialsFile != "":
 		client, err = storage.NewClient(
 			ctx, option.WithCredentialsFile(meta.gcpAuthorization.GoogleApplicationCredentialsFile))
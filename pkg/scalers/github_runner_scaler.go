@@ -2,15 +2,19 @@ package scalers
 
 import (
 	"context"
+	"crypto/rsa"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/golang-jwt/jwt/v4"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
@@ -32,6 +36,11 @@ type githubRunnerScaler struct {
 	metadata   *githubRunnerMetadata
 	httpClient *http.Client
 	logger     logr.Logger
+
+	// installation access token of the GitHub App, refreshed before it expires
+	installationTokenLock   sync.Mutex
+	installationToken       string
+	installationTokenExpiry time.Time
 }
 
 type githubRunnerMetadata struct {
@@ -39,6 +48,9 @@ type githubRunnerMetadata struct {
 	owner                     string
 	runnerScope               string
 	personalAccessToken       string
+	applicationID             int64
+	installationID            int64
+	appKey                    *rsa.PrivateKey
 	repos                     []string
 	labels                    []string
 	targetWorkflowQueueLength int64
@@ -322,7 +334,10 @@ type Job struct {
 
 // NewGitHubRunnerScaler creates a new GitHub Runner Scaler
 func NewGitHubRunnerScaler(config *ScalerConfig) (Scaler, error) {
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient, err := newHTTPClientFromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating GitHub HTTP client: %w", err)
+	}
 
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -405,8 +420,12 @@ func parseGitHubRunnerMetadata(config *ScalerConfig) (*githubRunnerMetadata, err
 	if val, ok := config.AuthParams["personalAccessToken"]; ok && val != "" {
 		// Found the organizationURL in a parameter from TriggerAuthentication
 		meta.personalAccessToken = val
+	} else if val, ok := config.AuthParams["appKey"]; ok && val != "" {
+		if err := parseGitHubAppAuth(&meta, config, val); err != nil {
+			return nil, err
+		}
 	} else {
-		return nil, fmt.Errorf("no personalAccessToken given")
+		return nil, fmt.Errorf("no personalAccessToken or appKey given")
 	}
 
 	meta.scalerIndex = config.ScalerIndex
@@ -414,6 +433,28 @@ func parseGitHubRunnerMetadata(config *ScalerConfig) (*githubRunnerMetadata, err
 	return &meta, nil
 }
 
+// parseGitHubAppAuth parses the GitHub App ID, installation ID and the private key of the GitHub App
+func parseGitHubAppAuth(meta *githubRunnerMetadata, config *ScalerConfig, appKey string) error {
+	applicationID, err := getInt64ValueFromMetaOrEnv("applicationID", config)
+	if err != nil {
+		return err
+	}
+	meta.applicationID = applicationID
+
+	installationID, err := getInt64ValueFromMetaOrEnv("installationID", config)
+	if err != nil {
+		return err
+	}
+	meta.installationID = installationID
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(appKey))
+	if err != nil {
+		return fmt.Errorf("error parsing appKey: %w", err)
+	}
+	meta.appKey = key
+	return nil
+}
+
 // getRepositories returns a list of repositories for a given organization, user or enterprise
 func (s *githubRunnerScaler) getRepositories(ctx context.Context) ([]string, error) {
 	if s.metadata.repos != nil {
@@ -431,7 +472,7 @@ func (s *githubRunnerScaler) getRepositories(ctx context.Context) ([]string, err
 	default:
 		return nil, fmt.Errorf("runnerScope %s not supported", s.metadata.runnerScope)
 	}
-	body, err := getGithubRequest(ctx, url, s.metadata, s.httpClient)
+	body, err := s.getGithubRequest(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -450,17 +491,87 @@ func (s *githubRunnerScaler) getRepositories(ctx context.Context) ([]string, err
 	return repoList, nil
 }
 
-func getGithubRequest(ctx context.Context, url string, metadata *githubRunnerMetadata, httpClient *http.Client) ([]byte, error) {
+// getAccessToken returns the personal access token or the installation access token of the GitHub App
+func (s *githubRunnerScaler) getAccessToken(ctx context.Context) (string, error) {
+	if s.metadata.appKey == nil {
+		return s.metadata.personalAccessToken, nil
+	}
+
+	s.installationTokenLock.Lock()
+	defer s.installationTokenLock.Unlock()
+
+	// installation tokens are valid for an hour, request a new one a bit before it expires
+	if s.installationToken != "" && time.Until(s.installationTokenExpiry) > time.Minute {
+		return s.installationToken, nil
+	}
+
+	// the JWT authenticating as the GitHub App can be valid for 10 minutes at most,
+	// it's issued in the past to allow for clock drift
+	now := time.Now()
+	appToken, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+		Issuer:    strconv.FormatInt(s.metadata.applicationID, 10),
+	}).SignedString(s.metadata.appKey)
+	if err != nil {
+		return "", fmt.Errorf("error signing GitHub App JWT: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", s.metadata.githubAPIURL, s.metadata.installationID)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Authorization", "Bearer "+appToken)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer r.Body.Close()
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", err
+	}
+	if r.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("the GitHub REST API returned error creating installation access token. url: %s status: %d response: %s", url, r.StatusCode, string(b))
+	}
+
+	var installationToken struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.Unmarshal(b, &installationToken); err != nil {
+		return "", err
+	}
+	if installationToken.Token == "" {
+		return "", errors.New("the GitHub REST API returned empty installation access token")
+	}
+
+	s.installationToken = installationToken.Token
+	s.installationTokenExpiry = installationToken.ExpiresAt
+	return s.installationToken, nil
+}
+
+func (s *githubRunnerScaler) getGithubRequest(ctx context.Context, url string) ([]byte, error) {
+	token, err := s.getAccessToken(ctx)
+	if err != nil {
+		return []byte{}, err
+	}
+
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return []byte{}, err
 	}
 
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("Authorization", "Bearer "+metadata.personalAccessToken)
+	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
-	r, err := httpClient.Do(req)
+	r, err := s.httpClient.Do(req)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -500,7 +611,7 @@ func stripDeadRuns(allWfrs []WorkflowRuns) []WorkflowRun {
 // getWorkflowRunJobs returns a list of jobs for a given workflow run
 func (s *githubRunnerScaler) getWorkflowRunJobs(ctx context.Context, workflowRunID int64, repoName string) ([]Job, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/jobs", s.metadata.githubAPIURL, s.metadata.owner, repoName, workflowRunID)
-	body, err := getGithubRequest(ctx, url, s.metadata, s.httpClient)
+	body, err := s.getGithubRequest(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -517,7 +628,7 @@ func (s *githubRunnerScaler) getWorkflowRunJobs(ctx context.Context, workflowRun
 // getWorkflowRuns returns a list of workflow runs for a given repository
 func (s *githubRunnerScaler) getWorkflowRuns(ctx context.Context, repoName string) (*WorkflowRuns, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs", s.metadata.githubAPIURL, s.metadata.owner, repoName)
-	body, err := getGithubRequest(ctx, url, s.metadata, s.httpClient)
+	body, err := s.getGithubRequest(ctx, url)
 	if err != nil {
 		return nil, err
 	}
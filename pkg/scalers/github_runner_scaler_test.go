@@ -2,12 +2,18 @@ package scalers
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/golang-jwt/jwt/v4"
 )
 
 const ghLoadCount = 2 // the size of the pretend pool completed of job requests
@@ -536,3 +542,99 @@ func TestGithubRunnerGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func generateGitHubAppKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return key, string(keyPEM)
+}
+
+func TestGitHubRunnerParseAppAuth(t *testing.T) {
+	_, appKey := generateGitHubAppKey(t)
+	metadata := map[string]string{"runnerScope": "org", "owner": "ownername", "applicationID": "1", "installationID": "2"}
+
+	meta, err := parseGitHubRunnerMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{"appKey": appKey}})
+	if err != nil {
+		t.Fatal("expected no error but got", err)
+	}
+	if meta.applicationID != 1 || meta.installationID != 2 || meta.appKey == nil {
+		t.Errorf("unexpected GitHub App auth: %d %d", meta.applicationID, meta.installationID)
+	}
+
+	if _, err := parseGitHubRunnerMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{"appKey": "not a key"}}); err == nil {
+		t.Error("expected error for invalid appKey but got none")
+	}
+
+	metadata = map[string]string{"runnerScope": "org", "owner": "ownername", "applicationID": "1"}
+	if _, err := parseGitHubRunnerMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{"appKey": appKey}}); err == nil {
+		t.Error("expected error without installationID but got none")
+	}
+
+	metadata = map[string]string{"runnerScope": "org", "owner": "ownername"}
+	_, err = parseGitHubRunnerMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{}})
+	if err == nil || err.Error() != "no personalAccessToken or appKey given" {
+		t.Error("expected error without auth but got", err)
+	}
+}
+
+func TestNewGitHubRunnerScaler_QueueLength_AppAuth(t *testing.T) {
+	key, _ := generateGitHubAppKey(t)
+	tokenRequests := 0
+	apiStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/app/installations/2/access_tokens" {
+			tokenRequests++
+			token, err := jwt.ParseWithClaims(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), &jwt.RegisteredClaims{}, func(*jwt.Token) (interface{}, error) {
+				return &key.PublicKey, nil
+			})
+			if err != nil || token.Claims.(*jwt.RegisteredClaims).Issuer != "1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"token":"ghs_test","expires_at":"%s"}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer ghs_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "50")
+		w.WriteHeader(http.StatusOK)
+		if strings.HasSuffix(r.URL.String(), "jobs") {
+			_, _ = w.Write([]byte(testGhWFJobResponse))
+		}
+		if strings.HasSuffix(r.URL.String(), "runs") {
+			_, _ = w.Write(buildQueueJSON())
+		}
+	}))
+	defer apiStub.Close()
+
+	meta := getGitHubTestMetaData(apiStub.URL)
+	meta.personalAccessToken = ""
+	meta.applicationID = 1
+	meta.installationID = 2
+	meta.appKey = key
+	meta.repos = []string{"test"}
+	meta.labels = []string{"foo", "bar"}
+
+	mockGitHubRunnerScaler := githubRunnerScaler{
+		metadata:   meta,
+		httpClient: http.DefaultClient,
+	}
+
+	queueLen, err := mockGitHubRunnerScaler.GetWorkflowQueueLength(context.TODO())
+	if err != nil {
+		t.Fatal("expected no error but got", err)
+	}
+	if queueLen != 1 {
+		t.Errorf("expected queue length 1 but got %d", queueLen)
+	}
+
+	// the installation access token is reused until it expires
+	if tokenRequests != 1 {
+		t.Errorf("expected 1 installation access token request but got %d", tokenRequests)
+	}
+}
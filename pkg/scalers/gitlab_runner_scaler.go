@@ -0,0 +1,291 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	defaultTargetPendingJobs = 1
+	defaultGitlabAPIURL      = "https://gitlab.com"
+	gitlabPageSize           = 100
+)
+
+type gitlabRunnerScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *gitlabRunnerMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type gitlabRunnerMetadata struct {
+	gitlabAPIURL                string
+	projectID                   string
+	groupID                     string
+	personalAccessToken         string
+	tags                        []string
+	runUntagged                 bool
+	targetPendingJobs           int64
+	activationTargetPendingJobs int64
+	scalerIndex                 int
+}
+
+type gitlabJob struct {
+	ID      int64    `json:"id"`
+	Status  string   `json:"status"`
+	TagList []string `json:"tag_list"`
+}
+
+type gitlabProject struct {
+	ID int64 `json:"id"`
+}
+
+// NewGitLabRunnerScaler creates a new GitLab Runner Scaler
+func NewGitLabRunnerScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseGitLabRunnerMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing GitLab Runner metadata: %w", err)
+	}
+
+	unsafeSsl := false
+	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok && val != "" {
+		unsafeSsl, err = strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing unsafeSsl: %w", err)
+		}
+	}
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, unsafeSsl)
+	if err := setHTTPProxy(httpClient, config); err != nil {
+		return nil, err
+	}
+
+	return &gitlabRunnerScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "gitlab_runner_scaler"),
+	}, nil
+}
+
+func parseGitLabRunnerMetadata(config *ScalerConfig) (*gitlabRunnerMetadata, error) {
+	meta := gitlabRunnerMetadata{}
+
+	if val, err := getValueFromMetaOrEnv("gitlabAPIURL", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
+		meta.gitlabAPIURL = strings.TrimSuffix(val, "/")
+	} else {
+		meta.gitlabAPIURL = defaultGitlabAPIURL
+	}
+
+	if val, err := getValueFromMetaOrEnv("projectID", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
+		meta.projectID = val
+	}
+	if val, err := getValueFromMetaOrEnv("groupID", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
+		meta.groupID = val
+	}
+	if meta.projectID == "" && meta.groupID == "" {
+		return nil, fmt.Errorf("no projectID or groupID given")
+	}
+	if meta.projectID != "" && meta.groupID != "" {
+		return nil, fmt.Errorf("projectID and groupID can't be used together")
+	}
+
+	if val, err := getValueFromMetaOrEnv("tags", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
+		for _, tag := range strings.Split(val, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				meta.tags = append(meta.tags, tag)
+			}
+		}
+	}
+
+	// runners without tags pick up untagged jobs only, runners with tags have to be configured to run untagged jobs
+	meta.runUntagged = len(meta.tags) == 0
+	if val, ok := config.TriggerMetadata["runUntagged"]; ok && val != "" {
+		runUntagged, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing runUntagged: %w", err)
+		}
+		meta.runUntagged = runUntagged
+	}
+
+	meta.targetPendingJobs = defaultTargetPendingJobs
+	if val, ok := config.TriggerMetadata["targetPendingJobs"]; ok && val != "" {
+		targetPendingJobs, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing targetPendingJobs: %w", err)
+		}
+		meta.targetPendingJobs = targetPendingJobs
+	}
+
+	meta.activationTargetPendingJobs = 0
+	if val, ok := config.TriggerMetadata["activationTargetPendingJobs"]; ok && val != "" {
+		activationTargetPendingJobs, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetPendingJobs: %w", err)
+		}
+		meta.activationTargetPendingJobs = activationTargetPendingJobs
+	}
+
+	if val, ok := config.AuthParams["personalAccessToken"]; ok && val != "" {
+		meta.personalAccessToken = val
+	} else {
+		return nil, fmt.Errorf("no personalAccessToken given")
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+// getGitlabRequest requests all pages of the GitLab API resource, handling the body of every page
+func (s *gitlabRunnerScaler) getGitlabRequest(ctx context.Context, path string, query url.Values, handlePage func([]byte) error) error {
+	query.Set("per_page", strconv.Itoa(gitlabPageSize))
+	for page := "1"; page != ""; {
+		query.Set("page", page)
+		requestURL := fmt.Sprintf("%s/api/v4/%s?%s", s.metadata.gitlabAPIURL, path, query.Encode())
+		req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("PRIVATE-TOKEN", s.metadata.personalAccessToken)
+
+		r, err := s.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		b, err := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			return err
+		}
+		if r.StatusCode != http.StatusOK {
+			return fmt.Errorf("the GitLab API returned error. url: %s status: %d response: %s", requestURL, r.StatusCode, string(b))
+		}
+
+		if err := handlePage(b); err != nil {
+			return err
+		}
+
+		// X-Next-Page is empty on the last page
+		page = r.Header.Get("X-Next-Page")
+	}
+	return nil
+}
+
+// getProjects returns IDs of the projects to count pending jobs in
+func (s *gitlabRunnerScaler) getProjects(ctx context.Context) ([]string, error) {
+	if s.metadata.projectID != "" {
+		return []string{s.metadata.projectID}, nil
+	}
+
+	query := url.Values{}
+	query.Set("include_subgroups", "true")
+	query.Set("archived", "false")
+	var projectIDs []string
+	err := s.getGitlabRequest(ctx, "groups/"+url.PathEscape(s.metadata.groupID)+"/projects", query, func(body []byte) error {
+		var projects []gitlabProject
+		if err := json.Unmarshal(body, &projects); err != nil {
+			return err
+		}
+		for _, project := range projects {
+			projectIDs = append(projectIDs, strconv.FormatInt(project.ID, 10))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projectIDs, nil
+}
+
+// canRunnerRunJob returns whether a runner with the tags can pick up the job,
+// the runner needs to have all tags of the job
+func (s *gitlabRunnerScaler) canRunnerRunJob(job gitlabJob) bool {
+	if len(job.TagList) == 0 {
+		return s.metadata.runUntagged
+	}
+	for _, jobTag := range job.TagList {
+		if !contains(s.metadata.tags, jobTag) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetPendingJobsCount returns the number of pending jobs the runners can pick up
+func (s *gitlabRunnerScaler) GetPendingJobsCount(ctx context.Context) (int64, error) {
+	projects, err := s.getProjects(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	var count int64
+	for _, project := range projects {
+		query := url.Values{}
+		query.Set("scope[]", "pending")
+		err := s.getGitlabRequest(ctx, "projects/"+url.PathEscape(project)+"/jobs", query, func(body []byte) error {
+			var jobs []gitlabJob
+			if err := json.Unmarshal(body, &jobs); err != nil {
+				return err
+			}
+			for _, job := range jobs {
+				if job.Status == "pending" && s.canRunnerRunJob(job) {
+					count++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return -1, err
+		}
+	}
+
+	return count, nil
+}
+
+func (s *gitlabRunnerScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	count, err := s.GetPendingJobsCount(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting pending jobs count")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(count))
+
+	return []external_metrics.ExternalMetricValue{metric}, count > s.metadata.activationTargetPendingJobs, nil
+}
+
+func (s *gitlabRunnerScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	id := s.metadata.projectID
+	if id == "" {
+		id = s.metadata.groupID
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("gitlab-runner-%s", id))),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.targetPendingJobs),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *gitlabRunnerScaler) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,172 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type parseGitLabRunnerMetadataTestData struct {
+	testName  string
+	metadata  map[string]string
+	authParam map[string]string
+	isError   bool
+}
+
+type gitlabRunnerMetricIdentifier struct {
+	metadata    map[string]string
+	scalerIndex int
+	name        string
+}
+
+var testGitLabRunnerAuthParams = map[string]string{
+	"personalAccessToken": "sample",
+}
+
+var testGitLabRunnerMetadata = []parseGitLabRunnerMetadataTestData{
+	// nothing passed
+	{"empty", map[string]string{}, testGitLabRunnerAuthParams, true},
+	// properly formed project
+	{"properly formed project", map[string]string{"gitlabAPIURL": "https://gitlab.example.com", "projectID": "group/project", "tags": "docker,linux", "targetPendingJobs": "2"}, testGitLabRunnerAuthParams, false},
+	// properly formed group
+	{"properly formed group", map[string]string{"groupID": "42"}, testGitLabRunnerAuthParams, false},
+	// both project and group
+	{"project and group", map[string]string{"projectID": "1", "groupID": "42"}, testGitLabRunnerAuthParams, true},
+	// invalid runUntagged
+	{"invalid runUntagged", map[string]string{"projectID": "1", "runUntagged": "a"}, testGitLabRunnerAuthParams, true},
+	// invalid targetPendingJobs
+	{"invalid targetPendingJobs", map[string]string{"projectID": "1", "targetPendingJobs": "a"}, testGitLabRunnerAuthParams, true},
+	// invalid activationTargetPendingJobs
+	{"invalid activationTargetPendingJobs", map[string]string{"projectID": "1", "activationTargetPendingJobs": "a"}, testGitLabRunnerAuthParams, true},
+	// missing personalAccessToken
+	{"missing personalAccessToken", map[string]string{"projectID": "1"}, map[string]string{}, true},
+}
+
+var gitlabRunnerMetricIdentifiers = []gitlabRunnerMetricIdentifier{
+	{map[string]string{"projectID": "group/project"}, 0, "s0-gitlab-runner-group-project"},
+	{map[string]string{"groupID": "42"}, 1, "s1-gitlab-runner-42"},
+}
+
+func TestGitLabRunnerParseMetadata(t *testing.T) {
+	for _, testData := range testGitLabRunnerMetadata {
+		t.Run(testData.testName, func(t *testing.T) {
+			_, err := parseGitLabRunnerMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParam})
+			if testData.isError && err == nil {
+				t.Error("expected error but got none")
+			}
+			if !testData.isError && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
+
+func TestGitLabRunnerRunUntaggedDefault(t *testing.T) {
+	meta, err := parseGitLabRunnerMetadata(&ScalerConfig{TriggerMetadata: map[string]string{"projectID": "1"}, AuthParams: testGitLabRunnerAuthParams})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !meta.runUntagged {
+		t.Error("expected runners without tags to run untagged jobs")
+	}
+
+	meta, err = parseGitLabRunnerMetadata(&ScalerConfig{TriggerMetadata: map[string]string{"projectID": "1", "tags": "docker"}, AuthParams: testGitLabRunnerAuthParams})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.runUntagged {
+		t.Error("expected runners with tags not to run untagged jobs")
+	}
+}
+
+func gitlabAPIStubHandler(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "sample" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("scope[]") != "" && r.URL.Query().Get("scope[]") != "pending" {
+			t.Errorf("unexpected scope %s", r.URL.Query().Get("scope[]"))
+		}
+		switch r.URL.EscapedPath() + "?page=" + r.URL.Query().Get("page") {
+		case "/api/v4/groups/42/projects?page=1":
+			_, _ = w.Write([]byte(`[{"id":1},{"id":2}]`))
+		case "/api/v4/projects/1/jobs?page=1":
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = w.Write([]byte(`[{"id":10,"status":"pending","tag_list":["docker"]},{"id":11,"status":"pending","tag_list":[]}]`))
+		case "/api/v4/projects/1/jobs?page=2":
+			_, _ = w.Write([]byte(`[{"id":12,"status":"pending","tag_list":["docker","linux"]},{"id":13,"status":"pending","tag_list":["windows"]}]`))
+		case "/api/v4/projects/2/jobs?page=1":
+			_, _ = w.Write([]byte(`[{"id":20,"status":"pending","tag_list":["linux"]}]`))
+		case "/api/v4/projects/group%2Fproject/jobs?page=1":
+			_, _ = w.Write([]byte(`[{"id":30,"status":"pending","tag_list":["docker"]}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"404 Not Found"}`))
+		}
+	}))
+}
+
+func TestGitLabRunnerPendingJobsCount(t *testing.T) {
+	apiStub := gitlabAPIStubHandler(t)
+	defer apiStub.Close()
+
+	tests := []struct {
+		name          string
+		metadata      map[string]string
+		expectedCount int64
+		isError       bool
+	}{
+		{"project with tags", map[string]string{"projectID": "1", "tags": "docker,linux"}, 2, false},
+		{"project with tags running untagged", map[string]string{"projectID": "1", "tags": "docker,linux,windows", "runUntagged": "true"}, 4, false},
+		{"project without tags", map[string]string{"projectID": "1"}, 1, false},
+		{"project path", map[string]string{"projectID": "group/project", "tags": "docker"}, 1, false},
+		{"group", map[string]string{"groupID": "42", "tags": "docker,linux"}, 3, false},
+		{"unknown project", map[string]string{"projectID": "3"}, -1, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.metadata["gitlabAPIURL"] = apiStub.URL
+			meta, err := parseGitLabRunnerMetadata(&ScalerConfig{TriggerMetadata: test.metadata, AuthParams: testGitLabRunnerAuthParams})
+			if err != nil {
+				t.Fatal(err)
+			}
+			scaler := gitlabRunnerScaler{
+				metadata:   meta,
+				httpClient: http.DefaultClient,
+			}
+
+			count, err := scaler.GetPendingJobsCount(context.Background())
+			if test.isError && err == nil {
+				t.Error("expected error but got none")
+			}
+			if !test.isError && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+			if count != test.expectedCount {
+				t.Errorf("expected %d pending jobs but got %d", test.expectedCount, count)
+			}
+		})
+	}
+}
+
+func TestGitLabRunnerGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range gitlabRunnerMetricIdentifiers {
+		meta, err := parseGitLabRunnerMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testGitLabRunnerAuthParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockGitLabRunnerScaler := gitlabRunnerScaler{
+			metadata:   meta,
+			httpClient: http.DefaultClient,
+		}
+
+		metricSpec := mockGitLabRunnerScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName+"!="+testData.name)
+		}
+	}
+}
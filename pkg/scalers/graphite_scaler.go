@@ -70,7 +70,10 @@ func NewGraphiteScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing graphite metadata: %w", err)
 	}
 
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient, err := newHTTPClientFromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating graphite HTTP client: %w", err)
+	}
 
 	return &graphiteScaler{
 		metricType: metricType,
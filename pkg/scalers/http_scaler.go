@@ -0,0 +1,229 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	httpBackendPrometheus  = "prometheus"
+	httpBackendInterceptor = "interceptor"
+
+	httpInterceptorMetricConcurrency = "concurrency"
+	httpInterceptorMetricRate        = "rate"
+)
+
+// httpScaler scales on the request rate or the number of pending requests of a host, sourced from a Prometheus
+// query (eg. of a recording rule over ingress metrics) or from the queue endpoint of an HTTP interceptor
+type httpScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *httpMetadata
+	prometheus *prometheusScaler
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type httpMetadata struct {
+	Backend               string  `keda:"name=backend, order=triggerMetadata, default=prometheus, enum=prometheus;interceptor"`
+	Host                  string  `keda:"name=host, order=triggerMetadata"`
+	ServerAddress         string  `keda:"name=serverAddress, order=triggerMetadata, optional"`
+	Query                 string  `keda:"name=query, order=triggerMetadata, optional"`
+	RecordingRule         string  `keda:"name=recordingRule, order=triggerMetadata, optional"`
+	InterceptorURL        string  `keda:"name=interceptorURL, order=triggerMetadata, optional"`
+	InterceptorMetric     string  `keda:"name=interceptorMetric, order=triggerMetadata, default=concurrency, enum=concurrency;rate"`
+	TargetValue           float64 `keda:"name=targetValue, order=triggerMetadata, default=100"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+	UnsafeSsl             bool    `keda:"name=unsafeSsl, order=triggerMetadata, optional"`
+
+	scalerIndex int
+}
+
+// httpInterceptorCounts is the count of a host returned by the queue endpoint of an interceptor, older interceptors
+// return only the number of pending requests of each host
+type httpInterceptorCounts struct {
+	Concurrency float64 `json:"Concurrency"`
+	RPS         float64 `json:"RPS"`
+}
+
+// NewHTTPScaler returns a new httpScaler
+func NewHTTPScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, "http_scaler")
+
+	meta, err := parseHTTPMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing http metadata: %w", err)
+	}
+
+	s := &httpScaler{
+		metricType: metricType,
+		metadata:   meta,
+		logger:     logger,
+	}
+
+	if meta.Backend == httpBackendPrometheus {
+		// the prometheus scaler queries the backend, so its authentication and failover options are supported as well
+		prometheusConfig := *config
+		prometheusConfig.TriggerMetadata = make(map[string]string, len(config.TriggerMetadata))
+		for key, value := range config.TriggerMetadata {
+			prometheusConfig.TriggerMetadata[key] = value
+		}
+		prometheusConfig.TriggerMetadata[promQuery] = meta.getPrometheusQuery()
+		prometheusConfig.TriggerMetadata[promThreshold] = strconv.FormatFloat(meta.TargetValue, 'f', -1, 64)
+		prometheusConfig.TriggerMetadata[promActivationThreshold] = strconv.FormatFloat(meta.ActivationTargetValue, 'f', -1, 64)
+		prometheus, err := NewPrometheusScaler(&prometheusConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error creating prometheus backend: %w", err)
+		}
+		s.prometheus = prometheus.(*prometheusScaler)
+	} else {
+		s.httpClient = kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	}
+
+	return s, nil
+}
+
+func parseHTTPMetadata(config *ScalerConfig) (*httpMetadata, error) {
+	meta := &httpMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+
+	switch meta.Backend {
+	case httpBackendPrometheus:
+		if meta.ServerAddress == "" {
+			return nil, fmt.Errorf("no serverAddress given, it's required by the prometheus backend")
+		}
+		if meta.Query == "" && meta.RecordingRule == "" {
+			return nil, fmt.Errorf("either query or recordingRule must be given with the prometheus backend")
+		}
+		if meta.Query != "" && meta.RecordingRule != "" {
+			return nil, fmt.Errorf("only one of query or recordingRule can be given")
+		}
+	case httpBackendInterceptor:
+		if meta.InterceptorURL == "" {
+			return nil, fmt.Errorf("no interceptorURL given, it's required by the interceptor backend")
+		}
+	}
+
+	if meta.TargetValue <= 0 {
+		return nil, fmt.Errorf("targetValue must be positive, got %v", meta.TargetValue)
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+	return meta, nil
+}
+
+// getPrometheusQuery returns the query of the metric of the host, the recording rule is expected to have host label
+func (m *httpMetadata) getPrometheusQuery() string {
+	if m.Query != "" {
+		return m.Query
+	}
+	return fmt.Sprintf("sum(%s{host=%q})", m.RecordingRule, m.Host)
+}
+
+// Close closes the prometheus backend
+func (s *httpScaler) Close(ctx context.Context) error {
+	if s.prometheus != nil {
+		return s.prometheus.Close(ctx)
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *httpScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("http-%s", s.metadata.Host))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the request rate or the number of pending requests of the host
+func (s *httpScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var val float64
+	var err error
+	if s.prometheus != nil {
+		val, err = s.prometheus.ExecutePromQuery(ctx)
+	} else {
+		val, err = s.getInterceptorValue(ctx)
+	}
+	if err != nil {
+		s.logger.Error(err, "error getting http metric", "backend", s.metadata.Backend)
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, val)
+
+	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.ActivationTargetValue, nil
+}
+
+// getInterceptorValue returns the value of the host from the queue endpoint of the interceptor, hosts without
+// requests aren't returned by the interceptor so their value is 0
+func (s *httpScaler) getInterceptorValue(ctx context.Context) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.InterceptorURL, nil)
+	if err != nil {
+		return -1, err
+	}
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return -1, err
+	}
+	defer r.Body.Close()
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return -1, err
+	}
+
+	if !(r.StatusCode >= 200 && r.StatusCode <= 299) {
+		return -1, NewHTTPStatusError(r.StatusCode, fmt.Errorf("interceptor returned error. status: %d response: %s", r.StatusCode, string(b)))
+	}
+
+	var hosts map[string]json.RawMessage
+	if err := json.Unmarshal(b, &hosts); err != nil {
+		return -1, fmt.Errorf("error decoding interceptor response: %w", err)
+	}
+
+	raw, found := hosts[s.metadata.Host]
+	if !found {
+		return 0, nil
+	}
+
+	var pending float64
+	if err := json.Unmarshal(raw, &pending); err == nil {
+		if s.metadata.InterceptorMetric == httpInterceptorMetricRate {
+			return -1, fmt.Errorf("interceptor returns only the number of pending requests of host %s, set interceptorMetric to %s", s.metadata.Host, httpInterceptorMetricConcurrency)
+		}
+		return pending, nil
+	}
+
+	var counts httpInterceptorCounts
+	if err := json.Unmarshal(raw, &counts); err != nil {
+		return -1, fmt.Errorf("error decoding interceptor counts of host %s: %w", s.metadata.Host, err)
+	}
+	if s.metadata.InterceptorMetric == httpInterceptorMetricRate {
+		return counts.RPS, nil
+	}
+	return counts.Concurrency, nil
+}
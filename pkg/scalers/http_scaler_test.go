@@ -0,0 +1,145 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type parseHTTPMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+var testHTTPMetadata = []parseHTTPMetadataTestData{
+	{map[string]string{}, true},
+	// prometheus backend with query
+	{map[string]string{"host": "example.com", "serverAddress": "http://localhost:9090", "query": "sum(rate(nginx_ingress_controller_requests{host=\"example.com\"}[1m]))"}, false},
+	// prometheus backend with recording rule
+	{map[string]string{"backend": "prometheus", "host": "example.com", "serverAddress": "http://localhost:9090", "recordingRule": "host:http_requests:rate1m", "targetValue": "50"}, false},
+	// missing host
+	{map[string]string{"serverAddress": "http://localhost:9090", "recordingRule": "host:http_requests:rate1m"}, true},
+	// missing serverAddress
+	{map[string]string{"host": "example.com", "recordingRule": "host:http_requests:rate1m"}, true},
+	// missing query and recordingRule
+	{map[string]string{"host": "example.com", "serverAddress": "http://localhost:9090"}, true},
+	// both query and recordingRule
+	{map[string]string{"host": "example.com", "serverAddress": "http://localhost:9090", "query": "up", "recordingRule": "host:http_requests:rate1m"}, true},
+	// interceptor backend
+	{map[string]string{"backend": "interceptor", "host": "example.com", "interceptorURL": "http://interceptor-admin:9090/queue", "interceptorMetric": "rate"}, false},
+	// missing interceptorURL
+	{map[string]string{"backend": "interceptor", "host": "example.com"}, true},
+	// unknown backend
+	{map[string]string{"backend": "ingress", "host": "example.com", "interceptorURL": "http://interceptor-admin:9090/queue"}, true},
+	// unknown interceptorMetric
+	{map[string]string{"backend": "interceptor", "host": "example.com", "interceptorURL": "http://interceptor-admin:9090/queue", "interceptorMetric": "latency"}, true},
+	// non positive targetValue
+	{map[string]string{"backend": "interceptor", "host": "example.com", "interceptorURL": "http://interceptor-admin:9090/queue", "targetValue": "0"}, true},
+	// malformed activationTargetValue
+	{map[string]string{"backend": "interceptor", "host": "example.com", "interceptorURL": "http://interceptor-admin:9090/queue", "activationTargetValue": "one"}, true},
+}
+
+func TestHTTPParseMetadata(t *testing.T) {
+	for _, testData := range testHTTPMetadata {
+		_, err := parseHTTPMetadata(&ScalerConfig{TriggerMetadata: testData.metadata})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error %s for %v", err, testData.metadata)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for %v", testData.metadata)
+		}
+	}
+}
+
+func TestHTTPScalerGetMetricSpecForScaling(t *testing.T) {
+	scaler, err := NewHTTPScaler(&ScalerConfig{
+		TriggerMetadata: map[string]string{"backend": "interceptor", "host": "example.com", "interceptorURL": "http://interceptor-admin:9090/queue"},
+		ScalerIndex:     1,
+	})
+	assert.NoError(t, err)
+
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s1-http-example-com", metricSpec[0].External.Metric.Name)
+	assert.Equal(t, int64(100), metricSpec[0].External.Target.AverageValue.Value())
+}
+
+func TestHTTPScalerPrometheusBackend(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		query = request.URL.Query().Get("query")
+		_, _ = writer.Write([]byte(`{"data":{"result":[{"value":[1, "12.5"]}]}}`))
+	}))
+	defer server.Close()
+
+	scaler, err := NewHTTPScaler(&ScalerConfig{
+		TriggerMetadata: map[string]string{"host": "example.com", "serverAddress": server.URL, "recordingRule": "host:http_requests:rate1m", "activationTargetValue": "10"},
+	})
+	assert.NoError(t, err)
+
+	metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "http-example-com")
+	assert.NoError(t, err)
+	assert.Equal(t, `sum(host:http_requests:rate1m{host="example.com"})`, query)
+	assert.Equal(t, int64(12500), metrics[0].Value.MilliValue())
+	assert.True(t, isActive)
+}
+
+func TestHTTPScalerInterceptorBackend(t *testing.T) {
+	type testCase struct {
+		name           string
+		response       string
+		metric         string
+		expectedValue  float64
+		expectedActive bool
+		isError        bool
+	}
+	testCases := []testCase{
+		{"pending requests", `{"example.com": 7, "other.com": 3}`, "concurrency", 7, true, false},
+		{"concurrency", `{"example.com": {"Concurrency": 4, "RPS": 20.5}}`, "concurrency", 4, true, false},
+		{"rate", `{"example.com": {"Concurrency": 4, "RPS": 20.5}}`, "rate", 20.5, true, false},
+		{"host without requests", `{"other.com": 3}`, "concurrency", 0, false, false},
+		{"rate of pending requests", `{"example.com": 7}`, "rate", 0, false, true},
+		{"malformed response", `[]`, "concurrency", 0, false, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				_, _ = writer.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			scaler, err := NewHTTPScaler(&ScalerConfig{
+				TriggerMetadata: map[string]string{"backend": "interceptor", "host": "example.com", "interceptorURL": server.URL, "interceptorMetric": tc.metric},
+			})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "http-example-com")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.expectedValue, metrics[0].Value.AsApproximateFloat64(), 1e-9)
+			assert.Equal(t, tc.expectedActive, isActive)
+		})
+	}
+}
+
+func TestHTTPScalerInterceptorError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	scaler, err := NewHTTPScaler(&ScalerConfig{
+		TriggerMetadata: map[string]string{"backend": "interceptor", "host": "example.com", "interceptorURL": server.URL},
+	})
+	assert.NoError(t, err)
+
+	_, _, err = scaler.GetMetricsAndActivity(context.Background(), "http-example-com")
+	assert.Error(t, err)
+}
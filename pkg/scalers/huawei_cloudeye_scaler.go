@@ -3,6 +3,7 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -23,6 +24,9 @@ const (
 	defaultCloudeyeMetricPeriod         = "300"
 
 	defaultHuaweiCloud = "myhuaweicloud.com"
+
+	// maxCloudeyeDimensions is the maximum number of dimensions of a metric supported by Cloudeye
+	maxCloudeyeDimensions = 4
 )
 
 type huaweiCloudeyeScaler struct {
@@ -32,10 +36,9 @@ type huaweiCloudeyeScaler struct {
 }
 
 type huaweiCloudeyeMetadata struct {
-	namespace      string
-	metricsName    string
-	dimensionName  string
-	dimensionValue string
+	namespace   string
+	metricsName string
+	dimensions  []map[string]string
 
 	targetMetricValue           float64
 	activationTargetMetricValue float64
@@ -110,17 +113,11 @@ func parseHuaweiCloudeyeMetadata(config *ScalerConfig, logger logr.Logger) (*hua
 		return nil, fmt.Errorf("metric Name not given")
 	}
 
-	if val, ok := config.TriggerMetadata["dimensionName"]; ok && val != "" {
-		meta.dimensionName = val
-	} else {
-		return nil, fmt.Errorf("dimension Name not given")
-	}
-
-	if val, ok := config.TriggerMetadata["dimensionValue"]; ok && val != "" {
-		meta.dimensionValue = val
-	} else {
-		return nil, fmt.Errorf("dimension Value not given")
+	dimensions, err := parseHuaweiCloudeyeDimensions(config)
+	if err != nil {
+		return nil, err
 	}
+	meta.dimensions = dimensions
 
 	if val, ok := config.TriggerMetadata["targetMetricValue"]; ok && val != "" {
 		targetMetricValue, err := strconv.ParseFloat(val, 64)
@@ -252,6 +249,51 @@ func (s *huaweiCloudeyeScaler) GetMetricsAndActivity(ctx context.Context, metric
 	return []external_metrics.ExternalMetricValue{metric}, metricValue > s.metadata.activationTargetMetricValue, nil
 }
 
+// parseHuaweiCloudeyeDimensions parses either a single dimension given by dimensionName and dimensionValue
+// or a comma separated list of name=value pairs given by dimensions
+func parseHuaweiCloudeyeDimensions(config *ScalerConfig) ([]map[string]string, error) {
+	if val, ok := config.TriggerMetadata["dimensions"]; ok && val != "" {
+		if config.TriggerMetadata["dimensionName"] != "" || config.TriggerMetadata["dimensionValue"] != "" {
+			return nil, fmt.Errorf("dimensions can't be given together with dimension Name and dimension Value")
+		}
+
+		parsed, err := kedautil.ParseStringList(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing dimensions: %w", err)
+		}
+		if len(parsed) > maxCloudeyeDimensions {
+			return nil, fmt.Errorf("at most %d dimensions can be given, got %d", maxCloudeyeDimensions, len(parsed))
+		}
+
+		names := make([]string, 0, len(parsed))
+		for name := range parsed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		dimensions := make([]map[string]string, 0, len(names))
+		for _, name := range names {
+			if name == "" || parsed[name] == "" {
+				return nil, fmt.Errorf("error parsing dimensions: dimension name and value can't be empty")
+			}
+			dimensions = append(dimensions, map[string]string{"name": name, "value": parsed[name]})
+		}
+		return dimensions, nil
+	}
+
+	dimensionName, ok := config.TriggerMetadata["dimensionName"]
+	if !ok || dimensionName == "" {
+		return nil, fmt.Errorf("dimension Name not given")
+	}
+
+	dimensionValue, ok := config.TriggerMetadata["dimensionValue"]
+	if !ok || dimensionValue == "" {
+		return nil, fmt.Errorf("dimension Value not given")
+	}
+
+	return []map[string]string{{"name": dimensionName, "value": dimensionValue}}, nil
+}
+
 func (s *huaweiCloudeyeScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
@@ -298,13 +340,8 @@ func (s *huaweiCloudeyeScaler) GetCloudeyeMetrics() (float64, error) {
 	opts := metricdata.BatchQueryOpts{
 		Metrics: []metricdata.Metric{
 			{
-				Namespace: s.metadata.namespace,
-				Dimensions: []map[string]string{
-					{
-						"name":  s.metadata.dimensionName,
-						"value": s.metadata.dimensionValue,
-					},
-				},
+				Namespace:  s.metadata.namespace,
+				Dimensions: s.metadata.dimensions,
 				MetricName: s.metadata.metricsName,
 			},
 		},
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
 )
 
 var (
@@ -151,6 +152,44 @@ var testHuaweiCloudeyeMetadata = []parseHuaweiCloudeyeMetadataTestData{
 		testHuaweiAuthenticationWithCloud,
 		true,
 		"invalid activationTargetMetricValue"},
+	{map[string]string{
+		"namespace":         "SYS.ECS",
+		"dimensions":        "instance_id=5e052238-0346-xxb0-86ea-92d9f33e29d2,disk=vda",
+		"metricName":        "disk_util_inband",
+		"targetMetricValue": "100",
+		"minMetricValue":    "1"},
+		testHuaweiAuthenticationWithCloud,
+		false,
+		"multiple dimensions"},
+	{map[string]string{
+		"namespace":         "SYS.ECS",
+		"dimensions":        "instance_id=5e052238-0346-xxb0-86ea-92d9f33e29d2,disk",
+		"metricName":        "disk_util_inband",
+		"targetMetricValue": "100",
+		"minMetricValue":    "1"},
+		testHuaweiAuthenticationWithCloud,
+		true,
+		"malformed dimensions"},
+	{map[string]string{
+		"namespace":         "SYS.ECS",
+		"dimensions":        "a=1,b=2,c=3,d=4,e=5",
+		"metricName":        "disk_util_inband",
+		"targetMetricValue": "100",
+		"minMetricValue":    "1"},
+		testHuaweiAuthenticationWithCloud,
+		true,
+		"too many dimensions"},
+	{map[string]string{
+		"namespace":         "SYS.ECS",
+		"dimensions":        "instance_id=5e052238-0346-xxb0-86ea-92d9f33e29d2,disk=vda",
+		"dimensionName":     "lbaas_instance_id",
+		"dimensionValue":    "5e052238-0346-xxb0-86ea-92d9f33e29d2",
+		"metricName":        "disk_util_inband",
+		"targetMetricValue": "100",
+		"minMetricValue":    "1"},
+		testHuaweiAuthenticationWithCloud,
+		true,
+		"dimensions together with dimensionName and dimensionValue"},
 }
 
 var huaweiCloudeyeMetricIdentifiers = []huaweiCloudeyeMetricIdentifier{
@@ -185,3 +224,26 @@ func TestHuaweiCloudeyeGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestHuaweiCloudeyeParseDimensions(t *testing.T) {
+	meta, err := parseHuaweiCloudeyeMetadata(&ScalerConfig{TriggerMetadata: testHuaweiCloudeyeMetadata[0].metadata, AuthParams: testHuaweiCloudeyeMetadata[0].authParams}, logr.Discard())
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	assert.Equal(t, []map[string]string{{"name": "lbaas_instance_id", "value": "5e052238-0346-xxb0-86ea-92d9f33e29d2"}}, meta.dimensions)
+
+	meta, err = parseHuaweiCloudeyeMetadata(&ScalerConfig{TriggerMetadata: map[string]string{
+		"namespace":         "SYS.ECS",
+		"dimensions":        "instance_id=5e052238-0346-xxb0-86ea-92d9f33e29d2, disk=vda",
+		"metricName":        "disk_util_inband",
+		"targetMetricValue": "100",
+		"minMetricValue":    "1",
+	}, AuthParams: testHuaweiAuthenticationWithCloud}, logr.Discard())
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	assert.Equal(t, []map[string]string{
+		{"name": "disk", "value": "vda"},
+		{"name": "instance_id", "value": "5e052238-0346-xxb0-86ea-92d9f33e29d2"},
+	}, meta.dimensions)
+}
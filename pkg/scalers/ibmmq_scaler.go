@@ -118,7 +118,6 @@ func parseIBMMQMetadata(config *ScalerConfig) (*IBMMQMetadata, error) {
 		}
 		meta.queueDepth = queueDepth
 	} else {
-		fmt.Println("No target depth defined - setting default")
 		meta.queueDepth = defaultTargetQueueDepth
 	}
 
@@ -138,7 +137,6 @@ func parseIBMMQMetadata(config *ScalerConfig) (*IBMMQMetadata, error) {
 		}
 		meta.tlsDisabled = tlsDisabled
 	} else {
-		fmt.Println("No tls setting defined - setting default")
 		meta.tlsDisabled = defaultTLSDisabled
 	}
 	val, ok := config.AuthParams["username"]
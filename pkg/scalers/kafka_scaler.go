@@ -31,16 +31,17 @@ const (
 )
 
 type kafkaMetadata struct {
-	bootstrapServers       []string
-	group                  string
-	topic                  string
-	partitionLimitation    []int32
-	lagThreshold           int64
-	activationLagThreshold int64
-	offsetResetPolicy      offsetResetPolicy
-	allowIdleConsumers     bool
-	excludePersistentLag   bool
-	version                sarama.KafkaVersion
+	bootstrapServers         []string
+	group                    string
+	topics                   []string
+	partitionLimitation      []int32
+	lagThreshold             int64
+	activationLagThreshold   int64
+	offsetResetPolicy        offsetResetPolicy
+	allowIdleConsumers       bool
+	excludePersistentLag     bool
+	limitToPartitionsWithLag bool
+	version                  sarama.KafkaVersion
 
 	// If an invalid offset is found, whether to scale to 1 (false - the default) so consumption can
 	// occur or scale to 0 (true). See discussion in https://github.com/kedacore/keda/issues/2612
@@ -54,13 +55,11 @@ type kafkaMetadata struct {
 	// OAUTHBEARER
 	scopes                []string
 	oauthTokenEndpointURI string
+	oauthExtensions       map[string]string
 
 	// TLS
-	enableTLS   bool
-	cert        string
-	key         string
-	keyPassword string
-	ca          string
+	enableTLS bool
+	tlsAuthMetadata
 
 	scalerIndex int
 }
@@ -83,6 +82,22 @@ const (
 	KafkaSASLTypeOAuthbearer kafkaSaslType = "oauthbearer"
 )
 
+// kafkaSaslTypeAliases are the names of SASL mechanisms used by Kafka clients, e.g. sasl.mechanism of librdkafka
+var kafkaSaslTypeAliases = map[string]kafkaSaslType{
+	"plain":         KafkaSASLTypePlaintext,
+	"scram-sha-256": KafkaSASLTypeSCRAMSHA256,
+	"scram-sha-512": KafkaSASLTypeSCRAMSHA512,
+}
+
+// parseKafkaSaslType returns the SASL type given by its name or by the name of the SASL mechanism, case-insensitive
+func parseKafkaSaslType(value string) kafkaSaslType {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if mode, ok := kafkaSaslTypeAliases[value]; ok {
+		return mode
+	}
+	return kafkaSaslType(value)
+}
+
 const (
 	lagThresholdMetricName             = "lagThreshold"
 	activationLagThresholdMetricName   = "activationLagThreshold"
@@ -142,7 +157,7 @@ func parseKafkaAuthParams(config *ScalerConfig, meta *kafkaMetadata) error {
 	}
 
 	if saslAuthType != "" {
-		mode := kafkaSaslType(saslAuthType)
+		mode := parseKafkaSaslType(saslAuthType)
 
 		if mode == KafkaSASLTypePlaintext || mode == KafkaSASLTypeSCRAMSHA256 || mode == KafkaSASLTypeSCRAMSHA512 || mode == KafkaSASLTypeOAuthbearer {
 			if config.AuthParams["username"] == "" {
@@ -163,6 +178,18 @@ func parseKafkaAuthParams(config *ScalerConfig, meta *kafkaMetadata) error {
 					return errors.New("no oauth token endpoint uri given")
 				}
 				meta.oauthTokenEndpointURI = strings.TrimSpace(config.AuthParams["oauthTokenEndpointUri"])
+
+				// SASL extensions required by some providers, e.g. logicalCluster and identityPoolId of Confluent Cloud
+				if config.AuthParams["oauthExtensions"] != "" {
+					meta.oauthExtensions = make(map[string]string)
+					for _, extension := range strings.Split(config.AuthParams["oauthExtensions"], ",") {
+						key, value, found := strings.Cut(strings.TrimSpace(extension), "=")
+						if !found || key == "" {
+							return fmt.Errorf("invalid oauth extension %s, expected key=value", extension)
+						}
+						meta.oauthExtensions[key] = value
+					}
+				}
 			}
 		} else {
 			return fmt.Errorf("err SASL mode %s given", mode)
@@ -198,22 +225,11 @@ func parseKafkaAuthParams(config *ScalerConfig, meta *kafkaMetadata) error {
 	}
 
 	if enableTLS {
-		certGiven := config.AuthParams["cert"] != ""
-		keyGiven := config.AuthParams["key"] != ""
-		if certGiven && !keyGiven {
-			return errors.New("key must be provided with cert")
-		}
-		if keyGiven && !certGiven {
-			return errors.New("cert must be provided with key")
-		}
-		meta.ca = config.AuthParams["ca"]
-		meta.cert = config.AuthParams["cert"]
-		meta.key = config.AuthParams["key"]
-		if value, found := config.AuthParams["keyPassword"]; found {
-			meta.keyPassword = value
-		} else {
-			meta.keyPassword = ""
+		tlsAuth, err := parseTLSAuth(config.AuthParams)
+		if err != nil {
+			return err
 		}
+		meta.tlsAuthMetadata = tlsAuth
 		meta.enableTLS = true
 	}
 
@@ -240,13 +256,20 @@ func parseKafkaMetadata(config *ScalerConfig, logger logr.Logger) (kafkaMetadata
 		return meta, errors.New("no consumer group given")
 	}
 
+	var topic string
 	switch {
 	case config.TriggerMetadata["topicFromEnv"] != "":
-		meta.topic = config.ResolvedEnv[config.TriggerMetadata["topicFromEnv"]]
+		topic = config.ResolvedEnv[config.TriggerMetadata["topicFromEnv"]]
 	case config.TriggerMetadata["topic"] != "":
-		meta.topic = config.TriggerMetadata["topic"]
-	default:
-		meta.topic = ""
+		topic = config.TriggerMetadata["topic"]
+	}
+	meta.topics = nil
+	for _, t := range strings.Split(topic, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			meta.topics = append(meta.topics, t)
+		}
+	}
+	if len(meta.topics) == 0 {
 		logger.V(1).Info(fmt.Sprintf("consumer group %q has no topic specified, "+
 			"will use all topics subscribed by the consumer group for scaling", meta.group))
 	}
@@ -254,8 +277,8 @@ func parseKafkaMetadata(config *ScalerConfig, logger logr.Logger) (kafkaMetadata
 	meta.partitionLimitation = nil
 	partitionLimitationMetadata := strings.TrimSpace(config.TriggerMetadata["partitionLimitation"])
 	if partitionLimitationMetadata != "" {
-		if meta.topic == "" {
-			logger.V(1).Info("no specific topic set, ignoring partitionLimitation setting")
+		if len(meta.topics) != 1 {
+			logger.V(1).Info("no single topic set, ignoring partitionLimitation setting")
 		} else {
 			pattern := config.TriggerMetadata["partitionLimitation"]
 			parsed, err := kedautil.ParseInt32List(pattern)
@@ -325,6 +348,19 @@ func parseKafkaMetadata(config *ScalerConfig, logger logr.Logger) (kafkaMetadata
 		meta.excludePersistentLag = t
 	}
 
+	meta.limitToPartitionsWithLag = false
+	if val, ok := config.TriggerMetadata["limitToPartitionsWithLag"]; ok {
+		t, err := strconv.ParseBool(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing limitToPartitionsWithLag: %w", err)
+		}
+		meta.limitToPartitionsWithLag = t
+
+		if meta.allowIdleConsumers && meta.limitToPartitionsWithLag {
+			return meta, fmt.Errorf("allowIdleConsumers and limitToPartitionsWithLag cannot be set simultaneously")
+		}
+	}
+
 	meta.scaleToZeroOnInvalidOffset = false
 	if val, ok := config.TriggerMetadata["scaleToZeroOnInvalidOffset"]; ok {
 		t, err := strconv.ParseBool(val)
@@ -359,7 +395,7 @@ func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin
 
 	if metadata.enableTLS {
 		config.Net.TLS.Enable = true
-		tlsConfig, err := kedautil.NewTLSConfigWithPassword(metadata.cert, metadata.key, metadata.keyPassword, metadata.ca, false)
+		tlsConfig, err := metadata.newTLSConfig(false)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -382,7 +418,7 @@ func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin
 
 	if metadata.saslType == KafkaSASLTypeOAuthbearer {
 		config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
-		config.Net.SASL.TokenProvider = OAuthBearerTokenProvider(metadata.username, metadata.password, metadata.oauthTokenEndpointURI, metadata.scopes)
+		config.Net.SASL.TokenProvider = OAuthBearerTokenProvider(metadata.username, metadata.password, metadata.oauthTokenEndpointURI, metadata.scopes, metadata.oauthExtensions)
 	}
 
 	client, err := sarama.NewClient(metadata.bootstrapServers, config)
@@ -405,7 +441,7 @@ func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
 	var topicsToDescribe = make([]string, 0)
 
 	// when no topic is specified, query to cg group to fetch all subscribed topics
-	if s.metadata.topic == "" {
+	if len(s.metadata.topics) == 0 {
 		listCGOffsetResponse, err := s.admin.ListConsumerGroupOffsets(s.metadata.group, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error listing cg offset: %w", err)
@@ -420,7 +456,7 @@ func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
 			topicsToDescribe = append(topicsToDescribe, topicName)
 		}
 	} else {
-		topicsToDescribe = []string{s.metadata.topic}
+		topicsToDescribe = s.metadata.topics
 	}
 
 	topicsMetadata, err := s.admin.DescribeTopics(topicsToDescribe)
@@ -428,8 +464,8 @@ func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
 		return nil, fmt.Errorf("error describing topics: %w", err)
 	}
 
-	if s.metadata.topic != "" && len(topicsMetadata) != 1 {
-		return nil, fmt.Errorf("expected only 1 topic metadata, got %d", len(topicsMetadata))
+	if len(s.metadata.topics) > 0 && len(topicsMetadata) != len(s.metadata.topics) {
+		return nil, fmt.Errorf("expected %d topic metadata, got %d", len(s.metadata.topics), len(topicsMetadata))
 	}
 
 	topicPartitions := make(map[string][]int32, len(topicsMetadata))
@@ -512,7 +548,19 @@ func (s *kafkaScaler) getLagForPartition(topic string, partitionID int32, offset
 	}
 	latestOffset := topicPartitionOffsets[topic][partitionID]
 	if consumerOffset == invalidOffset && s.metadata.offsetResetPolicy == earliest {
-		return latestOffset, latestOffset, nil
+		// the consumer starts from the oldest message still retained in the partition, not necessarily from offset 0
+		oldestOffset, err := s.client.GetOffset(topic, partitionID, sarama.OffsetOldest)
+		if err != nil {
+			return 0, 0, fmt.Errorf("error finding oldest offset for topic %s and partition %d: %w", topic, partitionID, err)
+		}
+		lag := latestOffset - oldestOffset
+		if lag < 0 {
+			lag = 0
+		}
+		s.logger.V(1).Info(fmt.Sprintf(
+			"invalid offset found for topic %s in group %s and partition %d, probably no offset is committed yet. Returning with lag of %d from the oldest offset",
+			topic, s.metadata.group, partitionID, lag))
+		return lag, lag, nil
 	}
 
 	// This code block tries to prevent KEDA Kafka trigger from scaling the scale target based on erroneous events
@@ -550,8 +598,8 @@ func (s *kafkaScaler) Close(context.Context) error {
 
 func (s *kafkaScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	var metricName string
-	if s.metadata.topic != "" {
-		metricName = fmt.Sprintf("kafka-%s", s.metadata.topic)
+	if len(s.metadata.topics) > 0 {
+		metricName = fmt.Sprintf("kafka-%s", strings.Join(s.metadata.topics, "-"))
 	} else {
 		metricName = fmt.Sprintf("kafka-%s-topics", s.metadata.group)
 	}
@@ -630,6 +678,7 @@ func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
 	totalLag := int64(0)
 	totalLagWithPersistent := int64(0)
 	totalTopicPartitions := int64(0)
+	partitionsWithLag := int64(0)
 
 	for topic, partitionsOffsets := range producerOffsets {
 		for partition := range partitionsOffsets {
@@ -639,20 +688,45 @@ func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
 			}
 			totalLag += lag
 			totalLagWithPersistent += lagWithPersistent
+			if lag > 0 {
+				partitionsWithLag++
+			}
 		}
 		totalTopicPartitions += (int64)(len(partitionsOffsets))
 	}
 	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, topicPartitions %v, threshold %v", totalLag, len(topicPartitions), s.metadata.lagThreshold))
 
-	if !s.metadata.allowIdleConsumers {
-		// don't scale out beyond the number of topicPartitions
-		if (totalLag / s.metadata.lagThreshold) > totalTopicPartitions {
-			totalLag = totalTopicPartitions * s.metadata.lagThreshold
+	if !s.metadata.allowIdleConsumers || s.metadata.limitToPartitionsWithLag {
+		// don't scale out beyond the number of topicPartitions or partitionsWithLag depending on settings
+		upperBound := totalTopicPartitions
+		if s.metadata.limitToPartitionsWithLag {
+			upperBound = partitionsWithLag
+		}
+		if (totalLag / s.metadata.lagThreshold) > upperBound {
+			totalLag = upperBound * s.metadata.lagThreshold
 		}
 	}
 	return totalLag, totalLagWithPersistent, nil
 }
 
+// GetMaxParallelism returns the number of partitions of the topics, the parallelism isn't limited if idle consumers are allowed
+func (s *kafkaScaler) GetMaxParallelism(context.Context) (int64, error) {
+	if s.metadata.allowIdleConsumers {
+		return 0, nil
+	}
+
+	topicPartitions, err := s.getTopicPartitions()
+	if err != nil {
+		return 0, err
+	}
+
+	var partitions int64
+	for _, topicPartition := range topicPartitions {
+		partitions += int64(len(topicPartition))
+	}
+	return partitions, nil
+}
+
 type brokerOffsetResult struct {
 	offsetResp *sarama.OffsetResponse
 	err        error
@@ -112,6 +112,10 @@ var parseKafkaMetadataTestDataset = []parseKafkaMetadataTestData{
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "excludePersistentLag": "true"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, true},
 	// success, version supported
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "allowIdleConsumers": "true", "version": "1.0.0"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), true, false},
+	// success, multiple topics
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic, other-topic"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic,other-topic", nil, offsetResetPolicy("latest"), false, false},
+	// success, ignore partitionLimitation if multiple topics
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic,other-topic", "partitionLimitation": "1,2"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic,other-topic", nil, offsetResetPolicy("latest"), false, false},
 }
 
 var parseKafkaAuthParamsTestDataset = []parseKafkaAuthParamsTestData{
@@ -121,6 +125,10 @@ var parseKafkaAuthParamsTestDataset = []parseKafkaAuthParamsTestData{
 	{map[string]string{"sasl": "scram_sha256", "username": "admin", "password": "admin"}, false, false},
 	// success, SASL only
 	{map[string]string{"sasl": "scram_sha512", "username": "admin", "password": "admin"}, false, false},
+	// success, SASL mechanism names
+	{map[string]string{"sasl": "PLAIN", "username": "$ConnectionString", "password": "Endpoint=sb://keda.servicebus.windows.net/"}, false, false},
+	// success, SASL mechanism names
+	{map[string]string{"sasl": "SCRAM-SHA-512", "username": "admin", "password": "admin"}, false, false},
 	// success, TLS only
 	{map[string]string{"tls": "enable", "ca": "caaa", "cert": "ceert", "key": "keey"}, false, true},
 	// success, TLS cert/key and assumed public CA
@@ -235,12 +243,17 @@ var parseKafkaOAuthbrearerAuthParamsTestDataset = []parseKafkaAuthParamsTestData
 	{map[string]string{"sasl": "foo", "username": "admin", "password": "admin", "scopes": "scope", "oauthTokenEndpointUri": "https://website.com", "tls": "disable"}, true, false},
 	// failure, SASL OAUTHBEARER + TLS missing oauthTokenEndpointUri
 	{map[string]string{"sasl": "oauthbearer", "username": "admin", "password": "admin", "scopes": "scope", "oauthTokenEndpointUri": "", "tls": "disable"}, true, false},
+	// success, SASL OAUTHBEARER + extensions
+	{map[string]string{"sasl": "OAUTHBEARER", "username": "admin", "password": "admin", "scopes": "scope", "oauthTokenEndpointUri": "https://website.com", "oauthExtensions": "logicalCluster=lkc-1234, identityPoolId=pool-1234", "tls": "disable"}, false, false},
+	// failure, SASL OAUTHBEARER + malformed extensions
+	{map[string]string{"sasl": "oauthbearer", "username": "admin", "password": "admin", "scopes": "scope", "oauthTokenEndpointUri": "https://website.com", "oauthExtensions": "logicalCluster", "tls": "disable"}, true, false},
 }
 
 var kafkaMetricIdentifiers = []kafkaMetricIdentifier{
 	{&parseKafkaMetadataTestDataset[10], 0, "s0-kafka-my-topic"},
 	{&parseKafkaMetadataTestDataset[10], 1, "s1-kafka-my-topic"},
 	{&parseKafkaMetadataTestDataset[2], 1, "s1-kafka-my-group-topics"},
+	{&parseKafkaMetadataTestDataset[len(parseKafkaMetadataTestDataset)-2], 0, "s0-kafka-my-topic-other-topic"},
 }
 
 func TestGetBrokers(t *testing.T) {
@@ -262,8 +275,8 @@ func TestGetBrokers(t *testing.T) {
 		if meta.group != testData.group {
 			t.Errorf("Expected group %s but got %s\n", testData.group, meta.group)
 		}
-		if meta.topic != testData.topic {
-			t.Errorf("Expected topic %s but got %s\n", testData.topic, meta.topic)
+		if strings.Join(meta.topics, ",") != testData.topic {
+			t.Errorf("Expected topic %s but got %s\n", testData.topic, meta.topics)
 		}
 		if !reflect.DeepEqual(testData.partitionLimitation, meta.partitionLimitation) {
 			t.Errorf("Expected %v but got %v\n", testData.partitionLimitation, meta.partitionLimitation)
@@ -289,8 +302,8 @@ func TestGetBrokers(t *testing.T) {
 		if meta.group != testData.group {
 			t.Errorf("Expected group %s but got %s\n", testData.group, meta.group)
 		}
-		if meta.topic != testData.topic {
-			t.Errorf("Expected topic %s but got %s\n", testData.topic, meta.topic)
+		if strings.Join(meta.topics, ",") != testData.topic {
+			t.Errorf("Expected topic %s but got %s\n", testData.topic, meta.topics)
 		}
 		if !reflect.DeepEqual(testData.partitionLimitation, meta.partitionLimitation) {
 			t.Errorf("Expected %v but got %v\n", testData.partitionLimitation, meta.partitionLimitation)
@@ -387,6 +400,60 @@ func TestKafkaOAuthbrearerAuthParams(t *testing.T) {
 	}
 }
 
+func TestKafkaLimitToPartitionsWithLag(t *testing.T) {
+	testData := []struct {
+		metadata map[string]string
+		isError  bool
+		expected bool
+	}{
+		{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, false},
+		{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "limitToPartitionsWithLag": "true"}, false, true},
+		{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "limitToPartitionsWithLag": "notvalid"}, true, false},
+		{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "limitToPartitionsWithLag": "true", "allowIdleConsumers": "true"}, true, false},
+	}
+	for _, testData := range testData {
+		meta, err := parseKafkaMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: validWithAuthParams}, logr.Discard())
+		if testData.isError {
+			if err == nil {
+				t.Errorf("Expected error for %v but got success\n", testData.metadata)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error("Expected success but got error", err)
+		}
+		if meta.limitToPartitionsWithLag != testData.expected {
+			t.Errorf("Expected limitToPartitionsWithLag %t but got %t\n", testData.expected, meta.limitToPartitionsWithLag)
+		}
+	}
+}
+
+func TestKafkaSaslTypeNames(t *testing.T) {
+	for name, expected := range map[string]kafkaSaslType{
+		"plaintext":     KafkaSASLTypePlaintext,
+		"PLAIN":         KafkaSASLTypePlaintext,
+		"scram_sha256":  KafkaSASLTypeSCRAMSHA256,
+		"SCRAM-SHA-256": KafkaSASLTypeSCRAMSHA256,
+		"scram-sha-512": KafkaSASLTypeSCRAMSHA512,
+		"OAUTHBEARER":   KafkaSASLTypeOAuthbearer,
+	} {
+		if mode := parseKafkaSaslType(name); mode != expected {
+			t.Errorf("Expected SASL type %s for %s but got %s\n", expected, name, mode)
+		}
+	}
+
+	meta, err := parseKafkaMetadata(&ScalerConfig{TriggerMetadata: validKafkaMetadata, AuthParams: map[string]string{
+		"sasl": "oauthbearer", "username": "admin", "password": "admin", "oauthTokenEndpointUri": "https://website.com",
+		"oauthExtensions": "logicalCluster=lkc-1234,identityPoolId=pool-1234",
+	}}, logr.Discard())
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if !reflect.DeepEqual(meta.oauthExtensions, map[string]string{"logicalCluster": "lkc-1234", "identityPoolId": "pool-1234"}) {
+		t.Errorf("Unexpected oauth extensions %v\n", meta.oauthExtensions)
+	}
+}
+
 func TestKafkaGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range kafkaMetricIdentifiers {
 		meta, err := parseKafkaMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: validWithAuthParams, ScalerIndex: testData.scalerIndex}, logr.Discard())
@@ -413,6 +480,7 @@ func TestGetTopicPartitions(t *testing.T) {
 		{"success_all_partitions_explicit", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "partitionLimitation": "1,2"}, []int32{1, 2}, map[string][]int32{"my-topic": {1, 2}}},
 		{"success_partial_partitions_explicit", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "partitionLimitation": "1,2,3"}, []int32{1, 2, 3, 4, 5, 6}, map[string][]int32{"my-topic": {1, 2, 3}}},
 		{"success_all_partitions_implicit", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "partitionLimitation": ""}, []int32{1, 2, 3, 4, 5, 6}, map[string][]int32{"my-topic": {1, 2, 3, 4, 5, 6}}},
+		{"success_multiple_topics", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic,other-topic"}, []int32{1, 2}, map[string][]int32{"my-topic": {1, 2}, "other-topic": {1, 2}}},
 	}
 
 	for _, tt := range testData {
@@ -436,6 +504,52 @@ func TestGetTopicPartitions(t *testing.T) {
 	}
 }
 
+func TestKafkaGetLagForPartitionWithInvalidOffset(t *testing.T) {
+	offsets := &sarama.OffsetFetchResponse{}
+	offsets.AddBlock("my-topic", 0, &sarama.OffsetFetchResponseBlock{Offset: invalidOffset})
+	producerOffsets := map[string]map[int32]int64{"my-topic": {0: 100}}
+
+	testData := []struct {
+		name     string
+		metadata map[string]string
+		expected int64
+	}{
+		{"latest", map[string]string{"offsetResetPolicy": "latest"}, 1},
+		{"latest_scale_to_zero", map[string]string{"offsetResetPolicy": "latest", "scaleToZeroOnInvalidOffset": "true"}, 0},
+		{"earliest_from_oldest_offset", map[string]string{"offsetResetPolicy": "earliest"}, 60},
+	}
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}
+			for k, v := range tt.metadata {
+				metadata[k] = v
+			}
+			meta, err := parseKafkaMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: validWithAuthParams}, logr.Discard())
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			mockKafkaScaler := kafkaScaler{"", meta, &MockClient{oldestOffset: 40}, nil, logr.Discard(), make(map[string]map[int32]int64)}
+
+			lag, _, err := mockKafkaScaler.getLagForPartition("my-topic", 0, offsets, producerOffsets)
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if lag != tt.expected {
+				t.Errorf("Expected lag %d but got %d\n", tt.expected, lag)
+			}
+		})
+	}
+}
+
+type MockClient struct {
+	sarama.Client
+	oldestOffset int64
+}
+
+func (m *MockClient) GetOffset(topic string, partitionID int32, time int64) (int64, error) {
+	return m.oldestOffset, nil
+}
+
 type MockClusterAdmin struct {
 	partitionIds []int32
 }
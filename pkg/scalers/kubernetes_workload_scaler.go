@@ -3,7 +3,6 @@ package scalers
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -24,9 +23,6 @@ type kubernetesWorkloadScaler struct {
 
 const (
 	kubernetesWorkloadMetricType = "External"
-	podSelectorKey               = "podSelector"
-	valueKey                     = "value"
-	activationValueKey           = "activationValue"
 )
 
 var phasesCountedAsTerminated = []corev1.PodPhase{
@@ -35,11 +31,15 @@ var phasesCountedAsTerminated = []corev1.PodPhase{
 }
 
 type kubernetesWorkloadMetadata struct {
-	podSelector     labels.Selector
-	namespace       string
-	value           float64
-	activationValue float64
-	scalerIndex     int
+	PodSelector     string  `keda:"name=podSelector, order=triggerMetadata"`
+	Value           float64 `keda:"name=value, order=triggerMetadata"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, optional"`
+	// CountReadyOnly counts only ready pods, eg. serving frontends, instead of all pods which aren't terminated
+	CountReadyOnly bool `keda:"name=countReadyOnly, order=triggerMetadata, optional"`
+
+	podSelector labels.Selector
+	namespace   string
+	scalerIndex int
 }
 
 // NewKubernetesWorkloadScaler creates a new kubernetesWorkloadScaler
@@ -64,27 +64,18 @@ func NewKubernetesWorkloadScaler(kubeClient client.Client, config *ScalerConfig)
 
 func parseWorkloadMetadata(config *ScalerConfig) (*kubernetesWorkloadMetadata, error) {
 	meta := &kubernetesWorkloadMetadata{}
-	var err error
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
 	meta.namespace = config.ScalableObjectNamespace
-	podSelector, err := labels.Parse(config.TriggerMetadata[podSelectorKey])
+	podSelector, err := labels.Parse(meta.PodSelector)
 	if err != nil || podSelector.String() == "" {
 		return nil, fmt.Errorf("invalid pod selector")
 	}
 	meta.podSelector = podSelector
-	value, err := strconv.ParseFloat(config.TriggerMetadata[valueKey], 64)
-	if err != nil || value == 0 {
+	if meta.Value <= 0 {
 		return nil, fmt.Errorf("value must be a float greater than 0")
 	}
-	meta.value = value
-
-	meta.activationValue = 0
-	if val, ok := config.TriggerMetadata[activationValueKey]; ok {
-		activationValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("value must be a float")
-		}
-		meta.activationValue = activationValue
-	}
 
 	meta.scalerIndex = config.ScalerIndex
 	return meta, nil
@@ -101,7 +92,7 @@ func (s *kubernetesWorkloadScaler) GetMetricSpecForScaling(context.Context) []v2
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("workload-%s", s.metadata.namespace))),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.value),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: kubernetesWorkloadMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -116,7 +107,7 @@ func (s *kubernetesWorkloadScaler) GetMetricsAndActivity(ctx context.Context, me
 
 	metric := GenerateMetricInMili(metricName, float64(pods))
 
-	return []external_metrics.ExternalMetricValue{metric}, float64(pods) > s.metadata.activationValue, nil
+	return []external_metrics.ExternalMetricValue{metric}, float64(pods) > s.metadata.ActivationValue, nil
 }
 
 func (s *kubernetesWorkloadScaler) getMetricValue(ctx context.Context) (int64, error) {
@@ -135,17 +126,33 @@ func (s *kubernetesWorkloadScaler) getMetricValue(ctx context.Context) (int64, e
 
 	var count int64
 	for _, pod := range podList.Items {
-		count += getCountValue(pod)
+		count += getCountValue(pod, s.metadata.CountReadyOnly)
 	}
 
 	return count, nil
 }
 
-func getCountValue(pod corev1.Pod) int64 {
+func getCountValue(pod corev1.Pod, readyOnly bool) int64 {
 	for _, ignore := range phasesCountedAsTerminated {
 		if pod.Status.Phase == ignore {
 			return 0
 		}
 	}
+	// terminating pods are already replaced, eg. during a rollout of the workload
+	if pod.DeletionTimestamp != nil {
+		return 0
+	}
+	if readyOnly && !isPodReady(pod) {
+		return 0
+	}
 	return 1
 }
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -195,3 +195,56 @@ func TestWorkloadPhase(t *testing.T) {
 		}
 	}
 }
+
+func TestWorkloadCountsPods(t *testing.T) {
+	now := metav1.Now()
+	newPod := func(name string, ready bool, deletionTimestamp *metav1.Time) v1.Pod {
+		status := v1.ConditionFalse
+		if ready {
+			status = v1.ConditionTrue
+		}
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				Labels:            map[string]string{"app": "frontend"},
+				DeletionTimestamp: deletionTimestamp,
+				Finalizers:        []string{"test"},
+			},
+			Status: v1.PodStatus{
+				Phase:      v1.PodRunning,
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}},
+			},
+		}
+	}
+	list := &v1.PodList{Items: []v1.Pod{
+		newPod("ready", true, nil),
+		newPod("not-ready", false, nil),
+		newPod("terminating", true, &now),
+	}}
+
+	for countReadyOnly, expected := range map[string]int64{"false": 2, "true": 1} {
+		s, err := NewKubernetesWorkloadScaler(
+			fake.NewClientBuilder().WithRuntimeObjects(list).Build(),
+			&ScalerConfig{
+				TriggerMetadata: map[string]string{
+					"podSelector":    "app=frontend",
+					"value":          "1",
+					"countReadyOnly": countReadyOnly,
+				},
+				AuthParams:              map[string]string{},
+				ScalableObjectNamespace: "default",
+			},
+		)
+		if err != nil {
+			t.Fatalf("Failed to create test scaler -- %v", err)
+		}
+		metrics, _, err := s.GetMetricsAndActivity(context.TODO(), "Metric")
+		if err != nil {
+			t.Fatalf("Failed to count pods -- %v", err)
+		}
+		if count := metrics[0].Value.Value(); count != expected {
+			t.Errorf("Expected %d pods with countReadyOnly %s but got %d", expected, countReadyOnly, count)
+		}
+	}
+}
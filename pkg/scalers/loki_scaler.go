@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -18,18 +19,8 @@ import (
 )
 
 const (
-	lokiServerAddress       = "serverAddress"
-	lokiQuery               = "query"
-	lokiThreshold           = "threshold"
-	lokiActivationThreshold = "activationThreshold"
-	lokiNamespace           = "namespace"
-	tenantName              = "tenantName"
-	tenantNameHeaderKey     = "X-Scope-OrgID"
-	lokiIgnoreNullValues    = "ignoreNullValues"
-)
-
-var (
-	lokiDefaultIgnoreNullValues = true
+	tenantNameHeaderKey = "X-Scope-OrgID"
+	lokiQueryPath       = "/loki/api/v1/query"
 )
 
 type lokiScaler struct {
@@ -40,15 +31,16 @@ type lokiScaler struct {
 }
 
 type lokiMetadata struct {
-	serverAddress       string
-	query               string
-	threshold           float64
-	activationThreshold float64
-	lokiAuth            *authentication.AuthMeta
-	scalerIndex         int
-	tenantName          string
-	ignoreNullValues    bool
-	unsafeSsl           bool
+	ServerAddress       string  `keda:"name=serverAddress, order=triggerMetadata"`
+	Query               string  `keda:"name=query, order=triggerMetadata"`
+	Threshold           float64 `keda:"name=threshold, order=triggerMetadata"`
+	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, optional"`
+	TenantName          string  `keda:"name=tenantName, order=triggerMetadata, optional"`
+	IgnoreNullValues    bool    `keda:"name=ignoreNullValues, order=triggerMetadata, default=true"`
+	UnsafeSsl           bool    `keda:"name=unsafeSsl, order=triggerMetadata, optional"`
+
+	lokiAuth    *authentication.AuthMeta
+	scalerIndex int
 }
 
 type lokiQueryResult struct {
@@ -77,7 +69,10 @@ func NewLokiScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing loki metadata: %w", err)
 	}
 
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl)
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if err := setHTTPProxy(httpClient, config); err != nil {
+		return nil, err
+	}
 
 	return &lokiScaler{
 		metricType: metricType,
@@ -89,62 +84,8 @@ func NewLokiScaler(config *ScalerConfig) (Scaler, error) {
 
 func parseLokiMetadata(config *ScalerConfig) (meta *lokiMetadata, err error) {
 	meta = &lokiMetadata{}
-
-	if val, ok := config.TriggerMetadata[lokiServerAddress]; ok && val != "" {
-		meta.serverAddress = val
-	} else {
-		return nil, fmt.Errorf("no %s given", lokiServerAddress)
-	}
-
-	if val, ok := config.TriggerMetadata[lokiQuery]; ok && val != "" {
-		meta.query = val
-	} else {
-		return nil, fmt.Errorf("no %s given", lokiQuery)
-	}
-
-	if val, ok := config.TriggerMetadata[lokiThreshold]; ok && val != "" {
-		t, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing %s: %w", lokiThreshold, err)
-		}
-
-		meta.threshold = t
-	} else {
-		return nil, fmt.Errorf("no %s given", lokiThreshold)
-	}
-
-	meta.activationThreshold = 0
-	if val, ok := config.TriggerMetadata[lokiActivationThreshold]; ok {
-		t, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("activationThreshold parsing error %w", err)
-		}
-
-		meta.activationThreshold = t
-	}
-
-	if val, ok := config.TriggerMetadata[tenantName]; ok && val != "" {
-		meta.tenantName = val
-	}
-
-	meta.ignoreNullValues = lokiDefaultIgnoreNullValues
-	if val, ok := config.TriggerMetadata[lokiIgnoreNullValues]; ok && val != "" {
-		ignoreNullValues, err := strconv.ParseBool(val)
-		if err != nil {
-			return nil, fmt.Errorf("err incorrect value for ignoreNullValues given: %s, "+
-				"please use true or false", val)
-		}
-		meta.ignoreNullValues = ignoreNullValues
-	}
-
-	meta.unsafeSsl = false
-	if val, ok := config.TriggerMetadata[unsafeSsl]; ok && val != "" {
-		unsafeSslValue, err := strconv.ParseBool(val)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing %s: %w", unsafeSsl, err)
-		}
-
-		meta.unsafeSsl = unsafeSslValue
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
 	}
 
 	meta.scalerIndex = config.ScalerIndex
@@ -170,7 +111,7 @@ func (s *lokiScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, "loki"),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.threshold),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Threshold),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
@@ -180,14 +121,15 @@ func (s *lokiScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 
 // ExecuteLokiQuery returns the result of the LogQL query execution
 func (s *lokiScaler) ExecuteLokiQuery(ctx context.Context) (float64, error) {
-	u, err := url.ParseRequestURI(s.metadata.serverAddress)
+	u, err := url.ParseRequestURI(s.metadata.ServerAddress)
 	if err != nil {
 		return -1, err
 	}
-	u.Path = "/loki/api/v1/query"
+	// keep the path prefix of the server address, eg. when Loki is exposed through a gateway
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), lokiQueryPath) + lokiQueryPath
 
 	u.RawQuery = url.Values{
-		"query": []string{s.metadata.query},
+		"query": []string{s.metadata.Query},
 	}.Encode()
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
@@ -201,8 +143,8 @@ func (s *lokiScaler) ExecuteLokiQuery(ctx context.Context) (float64, error) {
 		req.SetBasicAuth(s.metadata.lokiAuth.Username, s.metadata.lokiAuth.Password)
 	}
 
-	if s.metadata.tenantName != "" {
-		req.Header.Add(tenantNameHeaderKey, s.metadata.tenantName)
+	if s.metadata.TenantName != "" {
+		req.Header.Add(tenantNameHeaderKey, s.metadata.TenantName)
 	}
 
 	r, err := s.httpClient.Do(req)
@@ -217,7 +159,7 @@ func (s *lokiScaler) ExecuteLokiQuery(ctx context.Context) (float64, error) {
 	}
 
 	if !(r.StatusCode >= 200 && r.StatusCode <= 299) {
-		err := fmt.Errorf("loki query api returned error. status: %d response: %s", r.StatusCode, string(b))
+		err := NewHTTPStatusError(r.StatusCode, fmt.Errorf("loki query api returned error. status: %d response: %s", r.StatusCode, string(b)))
 		s.logger.Error(err, "loki query api returned error")
 		return -1, err
 	}
@@ -232,22 +174,22 @@ func (s *lokiScaler) ExecuteLokiQuery(ctx context.Context) (float64, error) {
 
 	// allow for zero element or single element result sets
 	if len(result.Data.Result) == 0 {
-		if s.metadata.ignoreNullValues {
+		if s.metadata.IgnoreNullValues {
 			return 0, nil
 		}
 		return -1, fmt.Errorf("loki metrics may be lost, the result is empty")
 	} else if len(result.Data.Result) > 1 {
-		return -1, fmt.Errorf("loki query %s returned multiple elements", s.metadata.query)
+		return -1, fmt.Errorf("loki query %s returned multiple elements", s.metadata.Query)
 	}
 
 	valueLen := len(result.Data.Result[0].Value)
 	if valueLen == 0 {
-		if s.metadata.ignoreNullValues {
+		if s.metadata.IgnoreNullValues {
 			return 0, nil
 		}
 		return -1, fmt.Errorf("loki metrics may be lost, the value list is empty")
 	} else if valueLen < 2 {
-		return -1, fmt.Errorf("loki query %s didn't return enough values", s.metadata.query)
+		return -1, fmt.Errorf("loki query %s didn't return enough values", s.metadata.Query)
 	}
 
 	val := result.Data.Result[0].Value[1]
@@ -273,5 +215,5 @@ func (s *lokiScaler) GetMetricsAndActivity(ctx context.Context, metricName strin
 
 	metric := GenerateMetricInMili(metricName, val)
 
-	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.activationThreshold, nil
+	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.ActivationThreshold, nil
 }
@@ -195,9 +195,9 @@ func TestLokiScalerExecuteLogQLQuery(t *testing.T) {
 
 			scaler := lokiScaler{
 				metadata: &lokiMetadata{
-					serverAddress:    server.URL,
-					ignoreNullValues: testData.ignoreNullValues,
-					unsafeSsl:        testData.unsafeSsl,
+					ServerAddress:    server.URL,
+					IgnoreNullValues: testData.ignoreNullValues,
+					UnsafeSsl:        testData.unsafeSsl,
 				},
 				httpClient: http.DefaultClient,
 				logger:     logr.Discard(),
@@ -237,9 +237,9 @@ func TestLokiScalerTenantHeader(t *testing.T) {
 
 	scaler := lokiScaler{
 		metadata: &lokiMetadata{
-			serverAddress:    server.URL,
-			tenantName:       tenantName,
-			ignoreNullValues: testData.ignoreNullValues,
+			ServerAddress:    server.URL,
+			TenantName:       tenantName,
+			IgnoreNullValues: testData.ignoreNullValues,
 		},
 		httpClient: http.DefaultClient,
 	}
@@ -248,3 +248,38 @@ func TestLokiScalerTenantHeader(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestLokiScalerServerAddressPath(t *testing.T) {
+	testData := []struct {
+		path         string
+		expectedPath string
+	}{
+		{"", "/loki/api/v1/query"},
+		{"/", "/loki/api/v1/query"},
+		{"/gateway", "/gateway/loki/api/v1/query"},
+		{"/gateway/", "/gateway/loki/api/v1/query"},
+		{"/gateway/loki/api/v1/query", "/gateway/loki/api/v1/query"},
+	}
+
+	for _, data := range testData {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, data.expectedPath, request.URL.Path)
+			writer.WriteHeader(http.StatusOK)
+			if _, err := writer.Write([]byte(`{"data":{"result":[]}}`)); err != nil {
+				t.Fatal(err)
+			}
+		}))
+
+		scaler := lokiScaler{
+			metadata: &lokiMetadata{
+				ServerAddress:    server.URL + data.path,
+				IgnoreNullValues: true,
+			},
+			httpClient: http.DefaultClient,
+		}
+
+		_, err := scaler.ExecuteLokiQuery(context.TODO())
+		assert.NoError(t, err)
+		server.Close()
+	}
+}
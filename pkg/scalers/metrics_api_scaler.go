@@ -2,6 +2,7 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -14,6 +15,7 @@ import (
 	"github.com/tidwall/gjson"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/util/jsonpath"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
@@ -85,6 +87,9 @@ func NewMetricsAPIScaler(config *ScalerConfig) (Scaler, error) {
 		}
 		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(config)
 	}
+	if err := setHTTPProxy(httpClient, config); err != nil {
+		return nil, err
+	}
 
 	return &metricsAPIScaler{
 		metricType: metricType,
@@ -138,6 +143,12 @@ func parseMetricsAPIMetadata(config *ScalerConfig) (*metricsAPIScalerMetadata, e
 		return nil, fmt.Errorf("no valueLocation given in metadata")
 	}
 
+	if isJSONPathValueLocation(meta.valueLocation) {
+		if _, err := parseJSONPathValueLocation(meta.valueLocation); err != nil {
+			return nil, fmt.Errorf("error parsing valueLocation: %w", err)
+		}
+	}
+
 	authMode, ok := config.TriggerMetadata["authMode"]
 	// no authMode specified
 	if !ok {
@@ -206,8 +217,26 @@ func parseMetricsAPIMetadata(config *ScalerConfig) (*metricsAPIScalerMetadata, e
 	return &meta, nil
 }
 
+// isJSONPathValueLocation returns true if valueLocation is a JSONPath expression (eg. `$.components[0].tasks`),
+// otherwise it's handled as GJSON path
+func isJSONPathValueLocation(valueLocation string) bool {
+	return strings.HasPrefix(valueLocation, "$")
+}
+
+func parseJSONPathValueLocation(valueLocation string) (*jsonpath.JSONPath, error) {
+	j := jsonpath.New("valueLocation")
+	if err := j.Parse(fmt.Sprintf("{%s}", valueLocation)); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
 // GetValueFromResponse uses provided valueLocation to access the numeric value in provided body
 func GetValueFromResponse(body []byte, valueLocation string) (float64, error) {
+	if isJSONPathValueLocation(valueLocation) {
+		return getValueFromResponseWithJSONPath(body, valueLocation)
+	}
+
 	r := gjson.GetBytes(body, valueLocation)
 	errorMsg := "valueLocation must point to value of type number or a string representing a Quantity got: '%s'"
 	if r.Type == gjson.String {
@@ -223,6 +252,40 @@ func GetValueFromResponse(body []byte, valueLocation string) (float64, error) {
 	return r.Num, nil
 }
 
+func getValueFromResponseWithJSONPath(body []byte, valueLocation string) (float64, error) {
+	j, err := parseJSONPathValueLocation(valueLocation)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing valueLocation: %w", err)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return 0, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	results, err := j.FindResults(data)
+	if err != nil {
+		return 0, err
+	}
+	if len(results) != 1 || len(results[0]) != 1 {
+		return 0, fmt.Errorf("valueLocation must point to a single value")
+	}
+
+	errorMsg := "valueLocation must point to value of type number or a string representing a Quantity got: '%v'"
+	switch v := results[0][0].Interface().(type) {
+	case float64:
+		return v, nil
+	case string:
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return 0, fmt.Errorf(errorMsg, v)
+		}
+		return q.AsApproximateFloat64(), nil
+	default:
+		return 0, fmt.Errorf(errorMsg, v)
+	}
+}
+
 func (s *metricsAPIScaler) getMetricValue(ctx context.Context) (float64, error) {
 	request, err := getMetricAPIServerRequest(ctx, s.metadata)
 	if err != nil {
@@ -237,7 +300,7 @@ func (s *metricsAPIScaler) getMetricValue(ctx context.Context) (float64, error)
 
 	if r.StatusCode != http.StatusOK {
 		msg := fmt.Sprintf("%s: api returned %d", r.Request.URL.Path, r.StatusCode)
-		return 0, errors.New(msg)
+		return 0, NewHTTPStatusError(r.StatusCode, errors.New(msg))
 	}
 
 	b, err := io.ReadAll(r.Body)
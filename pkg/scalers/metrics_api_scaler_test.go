@@ -22,6 +22,10 @@ var testMetricsAPIMetadata = []metricsAPIMetadataTestData{
 	{metadata: map[string]string{}, raisesError: true},
 	// OK
 	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric.test", "targetValue": "42"}, raisesError: false},
+	// OK, JSONPath valueLocation
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "$.metric.test", "targetValue": "42"}, raisesError: false},
+	// Malformed JSONPath valueLocation
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "$.metric[", "targetValue": "42"}, raisesError: true},
 	// Target not an int
 	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "aa"}, raisesError: true},
 	// Activation target not an int
@@ -160,6 +164,43 @@ func TestGetValueFromResponse(t *testing.T) {
 	}
 }
 
+func TestGetValueFromResponseWithJSONPath(t *testing.T) {
+	d := []byte(`{"components":[{"id": "82328e93e", "tasks": 32, "str": "64", "k":"1k","wrong":"NaN"},{"id": "1a2b3c4d5", "tasks": 8}],"count":2.43}`)
+	testData := []struct {
+		valueLocation string
+		expected      float64
+		isError       bool
+	}{
+		{"$.components[0].tasks", 32, false},
+		{"$.count", 2.43, false},
+		{"$.components[0].str", 64, false},
+		{"$.components[0].k", 1000, false},
+		{"$.components[?(@.id==\"1a2b3c4d5\")].tasks", 8, false},
+		{"$.components[0].wrong", 0, true},
+		{"$.components[*].tasks", 0, true},
+		{"$.components[0]", 0, true},
+		{"$.missing", 0, true},
+		{"$.components[", 0, true},
+	}
+
+	for _, testData := range testData {
+		v, err := GetValueFromResponse(d, testData.valueLocation)
+		if testData.isError {
+			if err == nil {
+				t.Errorf("%s: expected error but got success", testData.valueLocation)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: expected success but got error %s", testData.valueLocation, err)
+			continue
+		}
+		if v != testData.expected {
+			t.Errorf("%s: expected %f got %f", testData.valueLocation, testData.expected, v)
+		}
+	}
+}
+
 func TestMetricAPIScalerAuthParams(t *testing.T) {
 	for _, testData := range testMetricsAPIAuthMetadata {
 		meta, err := parseMetricsAPIMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metricsproxy centralizes queries of cloud metric APIs (CloudWatch, Azure Monitor, Stackdriver)
+// shared by triggers opting into it, identical queries are deduplicated and their results cached
+// and the queries to each API are rate limited, so many ScaledObjects don't exhaust the API quota.
+package metricsproxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
+)
+
+// DefaultCacheTTL is for how long results of queries are cached by default
+const DefaultCacheTTL = 30 * time.Second
+
+var (
+	defaultProxy     = NewProxy(DefaultCacheTTL, 0)
+	defaultProxyLock sync.RWMutex
+)
+
+// Configure sets the cache TTL and the maximum number of queries per second to each API
+// of the metrics proxy shared by all triggers, zero queriesPerSecond means no limit
+func Configure(cacheTTL time.Duration, queriesPerSecond int) {
+	defaultProxyLock.Lock()
+	defer defaultProxyLock.Unlock()
+	defaultProxy = NewProxy(cacheTTL, queriesPerSecond)
+}
+
+// Query returns the result of the query from the shared metrics proxy,
+// the query is called directly if the key is empty, ie. the trigger doesn't opt into the proxy
+func Query(ctx context.Context, api, key string, query func(context.Context) (float64, error)) (float64, error) {
+	if key == "" {
+		return query(ctx)
+	}
+	defaultProxyLock.RLock()
+	proxy := defaultProxy
+	defaultProxyLock.RUnlock()
+	return proxy.Query(ctx, api, key, query)
+}
+
+// QueryKey returns a key identifying the query by the trigger metadata and credentials,
+// metadata not affecting the query (eg. targets) are ignored so triggers with different targets share the query
+func QueryKey(metadata, resolvedEnv, authParams map[string]string, identity string, ignoredMetadata ...string) string {
+	ignored := map[string]bool{}
+	for _, key := range ignoredMetadata {
+		ignored[key] = true
+	}
+
+	hash := sha256.New()
+	writeSorted := func(prefix string, values map[string]string) {
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			if !ignored[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(hash, "%s/%q=%q\n", prefix, key, values[key])
+		}
+	}
+	writeSorted("metadata", metadata)
+	// values from the environment of the scale target are referenced by *FromEnv metadata
+	fromEnv := map[string]string{}
+	for key, value := range metadata {
+		if strings.HasSuffix(key, "FromEnv") {
+			fromEnv[key] = resolvedEnv[value]
+		}
+	}
+	writeSorted("env", fromEnv)
+	writeSorted("auth", authParams)
+	fmt.Fprintf(hash, "identity/%q\n", identity)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Proxy caches results of queries to cloud metric APIs and limits the rate of the queries to each API
+type Proxy struct {
+	cacheTTL         time.Duration
+	queriesPerSecond int
+
+	lock     sync.Mutex
+	results  map[string]result
+	limiters map[string]*rate.Limiter
+	inflight singleflight.Group
+}
+
+type result struct {
+	value  float64
+	expiry time.Time
+}
+
+// NewProxy creates a new Proxy
+func NewProxy(cacheTTL time.Duration, queriesPerSecond int) *Proxy {
+	return &Proxy{
+		cacheTTL:         cacheTTL,
+		queriesPerSecond: queriesPerSecond,
+		results:          map[string]result{},
+		limiters:         map[string]*rate.Limiter{},
+	}
+}
+
+// Query returns the cached result of the query identified by the API and the key, if it's not expired yet,
+// otherwise it calls the query once for all concurrent callers, waiting for the rate limit of the API
+func (p *Proxy) Query(ctx context.Context, api, key string, query func(context.Context) (float64, error)) (float64, error) {
+	key = api + "/" + key
+
+	p.lock.Lock()
+	cached, found := p.results[key]
+	p.lock.Unlock()
+	if found && time.Now().Before(cached.expiry) {
+		return cached.value, nil
+	}
+
+	value, err, _ := p.inflight.Do(key, func() (interface{}, error) {
+		if err := p.getLimiter(api).Wait(ctx); err != nil {
+			return nil, fmt.Errorf("metrics proxy rate limit of %s: %w", api, err)
+		}
+		value, err := query(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.store(key, value)
+		return value, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return value.(float64), nil
+}
+
+func (p *Proxy) getLimiter(api string) *rate.Limiter {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	limiter, found := p.limiters[api]
+	if !found {
+		limit := rate.Inf
+		if p.queriesPerSecond > 0 {
+			limit = rate.Limit(p.queriesPerSecond)
+		}
+		limiter = rate.NewLimiter(limit, 1)
+		p.limiters[api] = limiter
+	}
+	return limiter
+}
+
+func (p *Proxy) store(key string, value float64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := time.Now()
+	// drop expired results of queries nobody uses anymore
+	for k, r := range p.results {
+		if now.After(r.expiry) {
+			delete(p.results, k)
+		}
+	}
+	p.results[key] = result{value: value, expiry: now.Add(p.cacheTTL)}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsproxy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyCachesQueries(t *testing.T) {
+	ctx := context.Background()
+	proxy := NewProxy(time.Minute, 0)
+
+	var calls int32
+	query := func(context.Context) (float64, error) {
+		atomic.AddInt32(&calls, 1)
+		return 5, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		value, err := proxy.Query(ctx, "api", "key", query)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(5), value)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// different query isn't shared
+	_, err := proxy.Query(ctx, "api", "other", query)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestProxyDeduplicatesConcurrentQueries(t *testing.T) {
+	ctx := context.Background()
+	proxy := NewProxy(time.Minute, 0)
+
+	var calls int32
+	release := make(chan struct{})
+	query := func(context.Context) (float64, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 5, nil
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := proxy.Query(ctx, "api", "key", query)
+			assert.NoError(t, err)
+			assert.Equal(t, float64(5), value)
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestProxyDoesNotCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	proxy := NewProxy(time.Minute, 0)
+
+	_, err := proxy.Query(ctx, "api", "key", func(context.Context) (float64, error) {
+		return 0, errors.New("quota exceeded")
+	})
+	assert.Error(t, err)
+
+	value, err := proxy.Query(ctx, "api", "key", func(context.Context) (float64, error) {
+		return 5, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(5), value)
+}
+
+func TestProxyExpiresResults(t *testing.T) {
+	ctx := context.Background()
+	proxy := NewProxy(time.Millisecond, 0)
+
+	var calls int32
+	query := func(context.Context) (float64, error) {
+		return float64(atomic.AddInt32(&calls, 1)), nil
+	}
+
+	value, err := proxy.Query(ctx, "api", "key", query)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), value)
+
+	time.Sleep(5 * time.Millisecond)
+	value, err = proxy.Query(ctx, "api", "key", query)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), value)
+}
+
+func TestProxyRateLimit(t *testing.T) {
+	proxy := NewProxy(time.Minute, 1)
+	query := func(context.Context) (float64, error) {
+		return 5, nil
+	}
+
+	_, err := proxy.Query(context.Background(), "api", "key1", query)
+	assert.NoError(t, err)
+
+	// the next query would exceed the rate limit of the API before the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = proxy.Query(ctx, "api", "key2", query)
+	assert.Error(t, err)
+
+	// other APIs have their own limit
+	_, err = proxy.Query(ctx, "other-api", "key2", query)
+	assert.NoError(t, err)
+}
+
+func TestQueryKey(t *testing.T) {
+	metadata := map[string]string{"metricName": "cpu", "targetValue": "5", "regionFromEnv": "REGION"}
+	key := QueryKey(metadata, map[string]string{"REGION": "eu"}, map[string]string{"secret": "s"}, "", "targetValue")
+
+	// ignored metadata don't change the key
+	assert.Equal(t, key, QueryKey(map[string]string{"metricName": "cpu", "targetValue": "10", "regionFromEnv": "REGION"}, map[string]string{"REGION": "eu"}, map[string]string{"secret": "s"}, "", "targetValue"))
+
+	assert.NotEqual(t, key, QueryKey(map[string]string{"metricName": "memory", "targetValue": "5", "regionFromEnv": "REGION"}, map[string]string{"REGION": "eu"}, map[string]string{"secret": "s"}, "", "targetValue"))
+	assert.NotEqual(t, key, QueryKey(metadata, map[string]string{"REGION": "us"}, map[string]string{"secret": "s"}, "", "targetValue"))
+	assert.NotEqual(t, key, QueryKey(metadata, map[string]string{"REGION": "eu"}, map[string]string{"secret": "other"}, "", "targetValue"))
+	assert.NotEqual(t, key, QueryKey(metadata, map[string]string{"REGION": "eu"}, map[string]string{"secret": "s"}, "azure-workload", "targetValue"))
+}
+
+func TestQueryWithoutKey(t *testing.T) {
+	var calls int32
+	query := func(context.Context) (float64, error) {
+		return float64(atomic.AddInt32(&calls, 1)), nil
+	}
+
+	// triggers not opting into the proxy query the API directly
+	for i := 1; i <= 2; i++ {
+		value, err := Query(context.Background(), "api", "", query)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(i), value)
+	}
+}
@@ -45,6 +45,7 @@ type natsJetStreamMetadata struct {
 	activationLagThreshold int64
 	clusterSize            int
 	scalerIndex            int
+	tls                    tlsAuthMetadata
 }
 
 type jetStreamEndpointResponse struct {
@@ -118,11 +119,20 @@ func NewNATSJetStreamScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing NATS JetStream metadata: %w", err)
 	}
 
+	unsafeSsl, err := parseUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := jsMetadata.tls.newHTTPClient(config.GlobalHTTPTimeout, unsafeSsl)
+	if err != nil {
+		return nil, err
+	}
+
 	return &natsJetStreamScaler{
 		metricType: metricType,
 		stream:     &streamDetail{},
 		metadata:   jsMetadata,
-		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		httpClient: httpClient,
 		logger:     InitializeLogger(config, "nats_jetstream_scaler"),
 	}, nil
 }
@@ -180,6 +190,14 @@ func parseNATSJetStreamMetadata(config *ScalerConfig) (natsJetStreamMetadata, er
 	}
 	meta.monitoringURL = getNATSJetStreamMonitoringURL(useHTTPS, natsServerEndpoint, meta.account)
 
+	meta.tls, err = parseTLSAuth(config.AuthParams)
+	if err != nil {
+		return meta, err
+	}
+	if meta.tls.isSet() && !useHTTPS {
+		return meta, errors.New("useHttps must be enabled with ca or cert")
+	}
+
 	return meta, nil
 }
 
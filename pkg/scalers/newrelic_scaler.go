@@ -3,7 +3,6 @@ package scalers
 import (
 	"context"
 	"fmt"
-	"log"
 	"strconv"
 
 	"github.com/go-logr/logr"
@@ -61,7 +60,7 @@ func NewNewRelicScaler(config *ScalerConfig) (Scaler, error) {
 		newrelic.ConfigRegion(meta.region))
 
 	if err != nil {
-		log.Fatal("error initializing client:", err)
+		return nil, fmt.Errorf("error initializing %s client: %w", scalerName, err)
 	}
 
 	logMsg := fmt.Sprintf("Initializing New Relic Scaler (account %d in region %s)", meta.account, meta.region)
@@ -132,6 +132,26 @@ func parsePostgreSQLMetadata(config *ScalerConfig) (*postgreSQLMetadata, error)
 		params = append(params, "dbname="+escapePostgreConnectionParameter(dbName))
 		params = append(params, "sslmode="+escapePostgreConnectionParameter(sslmode))
 		params = append(params, "password="+escapePostgreConnectionParameter(password))
+
+		tlsAuth, err := parseTLSAuth(config.AuthParams)
+		if err != nil {
+			return nil, err
+		}
+		if tlsAuth.isSet() {
+			// lib/pq reads inline certificates only together with the client certificate
+			if tlsAuth.cert == "" {
+				return nil, fmt.Errorf("cert and key must be provided with ca")
+			}
+			if tlsAuth.keyPassword != "" {
+				return nil, fmt.Errorf("keyPassword isn't supported, the key must be unencrypted")
+			}
+			params = append(params, "sslinline=true")
+			params = append(params, "sslcert="+escapePostgreConnectionParameter(tlsAuth.cert))
+			params = append(params, "sslkey="+escapePostgreConnectionParameter(tlsAuth.key))
+			if tlsAuth.ca != "" {
+				params = append(params, "sslrootcert="+escapePostgreConnectionParameter(tlsAuth.ca))
+			}
+		}
 		meta.connection = strings.Join(params, " ")
 	}
 
@@ -71,6 +71,8 @@ var testPostgreSQLConnectionstring = []postgreSQLConnectionStringTestData{
 	{metadata: map[string]string{"query": "test_query", "targetQueryValue": "5"}, authParam: map[string]string{"connection": "test_connection_from_auth"}, connectionString: "test_connection_from_auth"},
 	// from meta
 	{metadata: map[string]string{"query": "test_query", "targetQueryValue": "5", "host": "localhost", "port": "1234", "dbName": "testDb", "userName": "user", "sslmode": "required"}, connectionString: "host=localhost port=1234 user=user dbname=testDb sslmode=required password="},
+	// from meta with client certificate
+	{metadata: map[string]string{"query": "test_query", "targetQueryValue": "5", "host": "localhost", "port": "1234", "dbName": "testDb", "userName": "user", "sslmode": "verify-ca"}, authParam: map[string]string{"ca": "caaa", "cert": "ceert", "key": "keey"}, connectionString: "host=localhost port=1234 user=user dbname=testDb sslmode=verify-ca password= sslinline=true sslcert=ceert sslkey=keey sslrootcert=caaa"},
 }
 
 func TestPosgresSQLConnectionStringGeneration(t *testing.T) {
@@ -0,0 +1,69 @@
+package scalers
+
+import (
+	"fmt"
+)
+
+// forecastLinear fits a line to the samples, taken at equal steps, by least squares and
+// returns its value horizon steps after the last sample
+func forecastLinear(samples []float64, horizon int) (float64, error) {
+	n := len(samples)
+	if n < 2 {
+		return 0, fmt.Errorf("linear model needs at least 2 samples, got %d", n)
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range samples {
+		x := float64(i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	count := float64(n)
+	slope := (count*sumXY - sumX*sumY) / (count*sumXX - sumX*sumX)
+	intercept := (sumY - slope*sumX) / count
+
+	return intercept + slope*float64(n-1+horizon), nil
+}
+
+// forecastHoltWinters smooths the samples, taken at equal steps, by additive Holt-Winters (triple exponential smoothing)
+// with the season of seasonLength steps and returns the forecast horizon steps after the last sample. The level, trend
+// and seasonal components are smoothed by alpha, beta and gamma, they are initialized from the first two seasons.
+func forecastHoltWinters(samples []float64, seasonLength, horizon int, alpha, beta, gamma float64) (float64, error) {
+	n := len(samples)
+	if seasonLength < 2 {
+		return 0, fmt.Errorf("holt-winters model needs a season of at least 2 samples, got %d", seasonLength)
+	}
+	if n < 2*seasonLength {
+		return 0, fmt.Errorf("holt-winters model needs at least 2 seasons of samples (%d), got %d", 2*seasonLength, n)
+	}
+
+	var firstSeason, secondSeason float64
+	for i := 0; i < seasonLength; i++ {
+		firstSeason += samples[i]
+		secondSeason += samples[seasonLength+i]
+	}
+	firstSeason /= float64(seasonLength)
+	secondSeason /= float64(seasonLength)
+
+	// the mean of the first season is the level at its middle, the seasonal components are the deviations
+	// from the trend line through it and the level is moved to the last sample of the first season
+	trend := (secondSeason - firstSeason) / float64(seasonLength)
+	middle := float64(seasonLength-1) / 2
+	seasonal := make([]float64, seasonLength)
+	for i := 0; i < seasonLength; i++ {
+		seasonal[i] = samples[i] - (firstSeason + trend*(float64(i)-middle))
+	}
+	level := firstSeason + trend*middle
+
+	for i := seasonLength; i < n; i++ {
+		season := i % seasonLength
+		previousLevel := level
+		level = alpha*(samples[i]-seasonal[season]) + (1-alpha)*(level+trend)
+		trend = beta*(level-previousLevel) + (1-beta)*trend
+		seasonal[season] = gamma*(samples[i]-level) + (1-gamma)*seasonal[season]
+	}
+
+	return level + float64(horizon)*trend + seasonal[(n-1+horizon)%seasonLength], nil
+}
@@ -0,0 +1,297 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
+)
+
+const (
+	predictQueryRangePath = "/api/v1/query_range"
+
+	predictModelLinear      = "linear"
+	predictModelHoltWinters = "holtWinters"
+)
+
+// predictScaler scales ahead of the load projected by a model fitted to the history of a Prometheus query,
+// the metric is the forecast of the query predictHorizon ahead, or its current value if it's higher
+type predictScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *predictMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type predictMetadata struct {
+	ServerAddress       string  `keda:"name=serverAddress, order=triggerMetadata"`
+	Query               string  `keda:"name=query, order=triggerMetadata"`
+	Threshold           float64 `keda:"name=threshold, order=triggerMetadata"`
+	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, optional"`
+	Model               string  `keda:"name=model, order=triggerMetadata, default=linear, enum=linear;holtWinters"`
+	HistoryTimeWindow   string  `keda:"name=historyTimeWindow, order=triggerMetadata, default=1h"`
+	QueryStep           string  `keda:"name=queryStep, order=triggerMetadata, default=1m"`
+	PredictHorizon      string  `keda:"name=predictHorizon, order=triggerMetadata, default=5m"`
+	SeasonLength        string  `keda:"name=seasonLength, order=triggerMetadata, optional"`
+	Alpha               float64 `keda:"name=alpha, order=triggerMetadata, default=0.5"`
+	Beta                float64 `keda:"name=beta, order=triggerMetadata, default=0.1"`
+	Gamma               float64 `keda:"name=gamma, order=triggerMetadata, default=0.1"`
+
+	historyTimeWindow time.Duration
+	queryStep         time.Duration
+	horizonSteps      int
+	seasonSteps       int
+	prometheusAuth    *authentication.AuthMeta
+	scalerIndex       int
+}
+
+type predictQueryRangeResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Values [][]interface{} `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// NewPredictScaler creates a new Predict Scaler
+func NewPredictScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parsePredictMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing predict metadata: %w", err)
+	}
+
+	httpClient, err := newHTTPClientFromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating predict HTTP client: %w", err)
+	}
+
+	return &predictScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "predict_scaler"),
+	}, nil
+}
+
+func parsePredictMetadata(config *ScalerConfig) (*predictMetadata, error) {
+	meta := predictMetadata{}
+	if err := config.TypedConfig(&meta); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if meta.historyTimeWindow, err = parsePositiveDuration("historyTimeWindow", meta.HistoryTimeWindow); err != nil {
+		return nil, err
+	}
+	if meta.queryStep, err = parsePositiveDuration("queryStep", meta.QueryStep); err != nil {
+		return nil, err
+	}
+	predictHorizon, err := parsePositiveDuration("predictHorizon", meta.PredictHorizon)
+	if err != nil {
+		return nil, err
+	}
+	meta.horizonSteps = int(math.Ceil(float64(predictHorizon) / float64(meta.queryStep)))
+
+	if meta.Model == predictModelHoltWinters {
+		if meta.SeasonLength == "" {
+			return nil, fmt.Errorf("%w: no seasonLength given", ErrScalerConfigMissingField)
+		}
+		seasonLength, err := parsePositiveDuration("seasonLength", meta.SeasonLength)
+		if err != nil {
+			return nil, err
+		}
+		meta.seasonSteps = int(seasonLength / meta.queryStep)
+		if meta.historyTimeWindow < 2*seasonLength {
+			return nil, fmt.Errorf("historyTimeWindow must cover at least 2 seasons of seasonLength %s", meta.SeasonLength)
+		}
+		for name, value := range map[string]float64{"alpha": meta.Alpha, "beta": meta.Beta, "gamma": meta.Gamma} {
+			if value < 0 || value > 1 {
+				return nil, fmt.Errorf("%s must be between 0 and 1, got %v", name, value)
+			}
+		}
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	// parse auth configs from ScalerConfig
+	auth, err := authentication.GetAuthConfigs(config.TriggerMetadata, config.AuthParams)
+	if err != nil {
+		return nil, err
+	}
+	meta.prometheusAuth = auth
+
+	return &meta, nil
+}
+
+func parsePositiveDuration(name, value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing %s: %w", name, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("%s must be positive, got %s", name, value)
+	}
+	return duration, nil
+}
+
+// queryHistory returns the samples of the query within historyTimeWindow, the query must return a single series
+func (s *predictScaler) queryHistory(ctx context.Context) ([]float64, error) {
+	u, err := url.ParseRequestURI(s.metadata.ServerAddress)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + predictQueryRangePath
+
+	end := time.Now()
+	u.RawQuery = url.Values{
+		"query": []string{s.metadata.Query},
+		"start": []string{strconv.FormatInt(end.Add(-s.metadata.historyTimeWindow).Unix(), 10)},
+		"end":   []string{strconv.FormatInt(end.Unix(), 10)},
+		"step":  []string{strconv.FormatFloat(s.metadata.queryStep.Seconds(), 'f', -1, 64)},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.metadata.prometheusAuth != nil && s.metadata.prometheusAuth.EnableBearerAuth {
+		req.Header.Add("Authorization", authentication.GetBearerToken(s.metadata.prometheusAuth))
+	} else if s.metadata.prometheusAuth != nil && s.metadata.prometheusAuth.EnableBasicAuth {
+		req.SetBasicAuth(s.metadata.prometheusAuth.Username, s.metadata.prometheusAuth.Password)
+	}
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if r.StatusCode != http.StatusOK {
+		return nil, NewHTTPStatusError(r.StatusCode, fmt.Errorf("prometheus query api returned error. status: %d response: %s", r.StatusCode, string(b)))
+	}
+
+	return parsePredictQueryRangeResult(b)
+}
+
+func parsePredictQueryRangeResult(body []byte) ([]float64, error) {
+	var result predictQueryRangeResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error parsing prometheus response: %w", err)
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed: %s", result.Error)
+	}
+	if result.Data.ResultType != "matrix" {
+		return nil, fmt.Errorf("prometheus query returned %s instead of matrix", result.Data.ResultType)
+	}
+	switch len(result.Data.Result) {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		return nil, fmt.Errorf("prometheus query returned %d series, it must return a single series", len(result.Data.Result))
+	}
+
+	values := result.Data.Result[0].Values
+	samples := make([]float64, 0, len(values))
+	for _, value := range values {
+		if len(value) != 2 {
+			return nil, fmt.Errorf("unexpected sample %v in prometheus response", value)
+		}
+		str, ok := value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected sample %v in prometheus response", value)
+		}
+		sample, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing sample %s: %w", str, err)
+		}
+		if math.IsNaN(sample) || math.IsInf(sample, 0) {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// forecast returns the value projected predictHorizon after the last sample by the model
+func (s *predictScaler) forecast(samples []float64) (float64, error) {
+	switch s.metadata.Model {
+	case predictModelHoltWinters:
+		return forecastHoltWinters(samples, s.metadata.seasonSteps, s.metadata.horizonSteps, s.metadata.Alpha, s.metadata.Beta, s.metadata.Gamma)
+	default:
+		return forecastLinear(samples, s.metadata.horizonSteps)
+	}
+}
+
+// getPredictedValue returns the forecast of the query, or its current value if it's higher so the scale target
+// isn't scaled in before the load decreases, the history too short for the model falls back to the current value
+func (s *predictScaler) getPredictedValue(ctx context.Context) (float64, error) {
+	samples, err := s.queryHistory(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(samples) == 0 {
+		return 0, nil
+	}
+
+	current := samples[len(samples)-1]
+	predicted, err := s.forecast(samples)
+	if err != nil {
+		s.logger.V(1).Info("Not enough history to forecast, using the current value", "reason", err.Error())
+		return current, nil
+	}
+	s.logger.V(1).Info("Forecast of the query", "current", current, "predicted", predicted)
+	return math.Max(math.Max(predicted, current), 0), nil
+}
+
+func (s *predictScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getPredictedValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error predicting the value of the query")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationThreshold, nil
+}
+
+func (s *predictScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, "predict"),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Threshold),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *predictScaler) Close(context.Context) error {
+	return nil
+}
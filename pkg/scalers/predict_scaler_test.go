@@ -0,0 +1,140 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type parsePredictMetadataTestData struct {
+	testName string
+	metadata map[string]string
+	isError  bool
+}
+
+var testPredictMetadata = []parsePredictMetadataTestData{
+	{"empty", map[string]string{}, true},
+	{"linear with defaults", map[string]string{"serverAddress": "http://prometheus:9090", "query": "sum(rate(http_requests_total[1m]))", "threshold": "100"}, false},
+	{"holt-winters", map[string]string{"serverAddress": "http://prometheus:9090", "query": "q", "threshold": "100", "model": "holtWinters", "seasonLength": "1h", "historyTimeWindow": "3h", "alpha": "0.3"}, false},
+	{"holt-winters without seasonLength", map[string]string{"serverAddress": "http://prometheus:9090", "query": "q", "threshold": "100", "model": "holtWinters"}, true},
+	{"holt-winters with short history", map[string]string{"serverAddress": "http://prometheus:9090", "query": "q", "threshold": "100", "model": "holtWinters", "seasonLength": "1h", "historyTimeWindow": "1h"}, true},
+	{"holt-winters with invalid alpha", map[string]string{"serverAddress": "http://prometheus:9090", "query": "q", "threshold": "100", "model": "holtWinters", "seasonLength": "1h", "historyTimeWindow": "2h", "alpha": "2"}, true},
+	{"unknown model", map[string]string{"serverAddress": "http://prometheus:9090", "query": "q", "threshold": "100", "model": "arima"}, true},
+	{"invalid predictHorizon", map[string]string{"serverAddress": "http://prometheus:9090", "query": "q", "threshold": "100", "predictHorizon": "soon"}, true},
+	{"negative queryStep", map[string]string{"serverAddress": "http://prometheus:9090", "query": "q", "threshold": "100", "queryStep": "-1m"}, true},
+}
+
+func TestPredictParseMetadata(t *testing.T) {
+	for _, testData := range testPredictMetadata {
+		t.Run(testData.testName, func(t *testing.T) {
+			_, err := parsePredictMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: map[string]string{}})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPredictHorizonSteps(t *testing.T) {
+	meta, err := parsePredictMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{"serverAddress": "http://prometheus:9090", "query": "q", "threshold": "1", "queryStep": "2m", "predictHorizon": "5m"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, meta.horizonSteps)
+}
+
+func TestForecastLinear(t *testing.T) {
+	forecast, err := forecastLinear([]float64{10, 12, 14, 16, 18}, 5)
+	assert.NoError(t, err)
+	assert.InDelta(t, 28, forecast, 1e-9)
+
+	_, err = forecastLinear([]float64{10}, 5)
+	assert.Error(t, err)
+}
+
+func TestForecastHoltWinters(t *testing.T) {
+	// a repeating daily pattern of 4 steps on top of a steady growth of 1 per step
+	pattern := []float64{0, 10, 20, 10}
+	var samples []float64
+	for i := 0; i < 12; i++ {
+		samples = append(samples, pattern[i%4]+float64(i))
+	}
+
+	// the next sample is at the start of the pattern, 2 steps later it's at the peak
+	forecast, err := forecastHoltWinters(samples, 4, 1, 0.5, 0.1, 0.1)
+	assert.NoError(t, err)
+	assert.InDelta(t, 12, forecast, 1e-9)
+	forecast, err = forecastHoltWinters(samples, 4, 3, 0.5, 0.1, 0.1)
+	assert.NoError(t, err)
+	assert.InDelta(t, 34, forecast, 1e-9)
+
+	_, err = forecastHoltWinters(samples[:7], 4, 1, 0.5, 0.1, 0.1)
+	assert.Error(t, err)
+}
+
+func newPredictTestServer(t *testing.T, samples ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, predictQueryRangePath, r.URL.Path)
+		assert.Equal(t, "q", r.URL.Query().Get("query"))
+		assert.Equal(t, "60", r.URL.Query().Get("step"))
+		values := make([]string, len(samples))
+		for i, sample := range samples {
+			values[i] = fmt.Sprintf(`[%d, "%s"]`, 1680000000+i*60, sample)
+		}
+		fmt.Fprintf(w, `{"status": "success", "data": {"resultType": "matrix", "result": [{"metric": {}, "values": [%s]}]}}`, strings.Join(values, ","))
+	}))
+}
+
+func TestPredictGetMetricsAndActivity(t *testing.T) {
+	tests := []struct {
+		name     string
+		samples  []string
+		expected int64
+		active   bool
+	}{
+		{"growing load is forecast", []string{"10", "20", "30", "40"}, 90, true},
+		{"decreasing load keeps the current value", []string{"40", "30", "20", "10"}, 10, true},
+		{"single sample is used as it is", []string{"7"}, 7, true},
+		{"no load", []string{"0", "0", "0"}, 0, false},
+		{"non-numeric samples are skipped", []string{"NaN", "5", "5"}, 5, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newPredictTestServer(t, test.samples...)
+			defer server.Close()
+
+			scaler, err := NewPredictScaler(&ScalerConfig{
+				TriggerMetadata: map[string]string{"serverAddress": server.URL, "query": "q", "threshold": "10", "predictHorizon": "5m"},
+				AuthParams:      map[string]string{},
+			})
+			assert.NoError(t, err)
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-predict")
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, metrics[0].Value.Value())
+			assert.Equal(t, test.active, active)
+		})
+	}
+}
+
+func TestParsePredictQueryRangeResult(t *testing.T) {
+	_, err := parsePredictQueryRangeResult([]byte(`{"status": "error", "error": "bad query"}`))
+	assert.ErrorContains(t, err, "bad query")
+
+	_, err = parsePredictQueryRangeResult([]byte(`{"status": "success", "data": {"resultType": "vector", "result": []}}`))
+	assert.Error(t, err)
+
+	_, err = parsePredictQueryRangeResult([]byte(`{"status": "success", "data": {"resultType": "matrix", "result": [{"values": []}, {"values": []}]}}`))
+	assert.Error(t, err)
+
+	samples, err := parsePredictQueryRangeResult([]byte(`{"status": "success", "data": {"resultType": "matrix", "result": []}}`))
+	assert.NoError(t, err)
+	assert.Empty(t, samples)
+}
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
@@ -38,17 +40,22 @@ const (
 
 var (
 	defaultIgnoreNullValues = true
+
+	// promFailoverCooldown is how long a failing server isn't preferred when multiple server addresses are given
+	promFailoverCooldown = 30 * time.Second
 )
 
 type prometheusScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *prometheusMetadata
 	httpClient *http.Client
+	failover   *kedautil.EndpointFailover
 	logger     logr.Logger
 }
 
 type prometheusMetadata struct {
 	serverAddress       string
+	serverAddresses     []string
 	metricName          string
 	query               string
 	threshold           float64
@@ -63,6 +70,10 @@ type prometheusMetadata struct {
 	// https://github.com/kedacore/keda/issues/3065
 	ignoreNullValues bool
 	unsafeSsl        bool
+
+	// the region and the credentials of sigv4 authMode
+	awsRegion        string
+	awsAuthorization awsAuthorizationMetadata
 }
 
 type promQueryResult struct {
@@ -104,8 +115,38 @@ func NewPrometheusScaler(config *ScalerConfig) (Scaler, error) {
 				logger.V(1).Error(err, "init Prometheus client http transport")
 				return nil, err
 			}
+			// keep unsafeSsl working when a custom CA or client certificate is supplied
+			if t, ok := transport.(*http.Transport); ok && t.TLSClientConfig != nil {
+				t.TLSClientConfig.InsecureSkipVerify = meta.unsafeSsl
+			}
 			httpClient.Transport = transport
 		}
+	}
+	if err := setHTTPProxy(httpClient, config); err != nil {
+		return nil, err
+	}
+
+	if meta.prometheusAuth != nil {
+		if meta.prometheusAuth.EnableOAuth {
+			oauthConfig := clientcredentials.Config{
+				ClientID:     meta.prometheusAuth.ClientID,
+				ClientSecret: meta.prometheusAuth.ClientSecret,
+				TokenURL:     meta.prometheusAuth.OauthTokenURI,
+				Scopes:       meta.prometheusAuth.Scopes,
+			}
+			// the token endpoint is requested by the same client settings as prometheus
+			tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{
+				Transport: httpClient.Transport,
+				Timeout:   httpClient.Timeout,
+			})
+			httpClient.Transport = &oauth2.Transport{
+				Source: oauthConfig.TokenSource(tokenCtx),
+				Base:   httpClient.Transport,
+			}
+		}
+		if meta.prometheusAuth.EnableAWSSigV4 {
+			httpClient.Transport = newAWSSigV4RoundTripper(httpClient.Transport, meta.awsRegion, meta.awsAuthorization)
+		}
 	} else {
 		// could be the case of azure managed prometheus. Try and get the roundtripper.
 		// If its not the case of azure managed prometheus, we will get both transport and err as nil and proceed assuming no auth.
@@ -126,6 +167,7 @@ func NewPrometheusScaler(config *ScalerConfig) (Scaler, error) {
 		metricType: metricType,
 		metadata:   meta,
 		httpClient: httpClient,
+		failover:   kedautil.NewEndpointFailover(meta.serverAddresses, promFailoverCooldown),
 		logger:     logger,
 	}, nil
 }
@@ -139,6 +181,12 @@ func parsePrometheusMetadata(config *ScalerConfig) (meta *prometheusMetadata, er
 		return nil, fmt.Errorf("no %s given", promServerAddress)
 	}
 
+	// serverAddress can be a comma separated list of servers in order of preference (eg. Prometheus replicas)
+	meta.serverAddresses = kedautil.ParseCommaSeparatedList(meta.serverAddress)
+	if len(meta.serverAddresses) == 0 {
+		return nil, fmt.Errorf("no %s given", promServerAddress)
+	}
+
 	if val, ok := config.TriggerMetadata[promQuery]; ok && val != "" {
 		meta.query = val
 	} else {
@@ -227,7 +275,17 @@ func parseAuthConfig(config *ScalerConfig, meta *prometheusMetadata) error {
 		return err
 	}
 
-	if auth != nil && config.PodIdentity.Provider != "" {
+	if auth != nil && auth.EnableAWSSigV4 {
+		// sigv4 signs the requests with the credentials given by the aws parameters or by aws pod identity
+		if config.TriggerMetadata["awsRegion"] == "" {
+			return fmt.Errorf("no awsRegion given for sigv4 authMode")
+		}
+		meta.awsRegion = config.TriggerMetadata["awsRegion"]
+		meta.awsAuthorization, err = getAwsAuthorization(config.PodIdentity, config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+		if err != nil {
+			return err
+		}
+	} else if auth != nil && config.PodIdentity.Provider != "" {
 		return fmt.Errorf("pod identity cannot be enabled with other auth types")
 	}
 	meta.prometheusAuth = auth
@@ -254,48 +312,30 @@ func (s *prometheusScaler) GetMetricSpecForScaling(context.Context) []v2.MetricS
 }
 
 func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error) {
-	t := time.Now().UTC().Format(time.RFC3339)
-	queryEscaped := url_pkg.QueryEscape(s.metadata.query)
-	url := fmt.Sprintf("%s/api/v1/query?query=%s&time=%s", s.metadata.serverAddress, queryEscaped, t)
-
-	// set 'namespace' parameter for namespaced Prometheus requests (eg. for Thanos Querier)
-	if s.metadata.namespace != "" {
-		url = fmt.Sprintf("%s&namespace=%s", url, s.metadata.namespace)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return -1, err
-	}
-
-	for headerName, headerValue := range s.metadata.customHeaders {
-		req.Header.Add(headerName, headerValue)
-	}
-
-	switch {
-	case s.metadata.prometheusAuth == nil:
+	var b []byte
+	var statusCode int
+	var err error
+	for _, serverAddress := range s.getServerAddresses() {
+		b, statusCode, err = s.queryServer(ctx, serverAddress)
+		// fail over to the next server only if the server is unavailable, other errors would be returned by every server
+		if err != nil || statusCode >= http.StatusInternalServerError {
+			if s.failover != nil {
+				s.failover.ReportFailure(serverAddress)
+			}
+			s.logger.V(1).Info("prometheus server is unavailable, trying next server", "serverAddress", serverAddress, "statusCode", statusCode, "error", err)
+			continue
+		}
+		if s.failover != nil {
+			s.failover.ReportSuccess(serverAddress)
+		}
 		break
-	case s.metadata.prometheusAuth.EnableBearerAuth:
-		req.Header.Set("Authorization", authentication.GetBearerToken(s.metadata.prometheusAuth))
-	case s.metadata.prometheusAuth.EnableBasicAuth:
-		req.SetBasicAuth(s.metadata.prometheusAuth.Username, s.metadata.prometheusAuth.Password)
-	case s.metadata.prometheusAuth.EnableCustomAuth:
-		req.Header.Set(s.metadata.prometheusAuth.CustomAuthHeader, s.metadata.prometheusAuth.CustomAuthValue)
 	}
-
-	r, err := s.httpClient.Do(req)
 	if err != nil {
 		return -1, err
 	}
 
-	b, err := io.ReadAll(r.Body)
-	if err != nil {
-		return -1, err
-	}
-	_ = r.Body.Close()
-
-	if !(r.StatusCode >= 200 && r.StatusCode <= 299) {
-		err := fmt.Errorf("prometheus query api returned error. status: %d response: %s", r.StatusCode, string(b))
+	if !(statusCode >= 200 && statusCode <= 299) {
+		err := NewHTTPStatusError(statusCode, fmt.Errorf("prometheus query api returned error. status: %d response: %s", statusCode, string(b)))
 		s.logger.Error(err, "prometheus query api returned error")
 		return -1, err
 	}
@@ -313,9 +353,10 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		if s.metadata.ignoreNullValues {
 			return 0, nil
 		}
-		return -1, fmt.Errorf("prometheus metrics %s target may be lost, the result is empty", s.metadata.metricName)
+		return -1, fmt.Errorf("prometheus query %s returned empty result, set %s to true to use 0 instead", s.metadata.query, ignoreNullValues)
 	} else if len(result.Data.Result) > 1 {
-		return -1, fmt.Errorf("prometheus query %s returned multiple elements", s.metadata.query)
+		// the series to scale on can't be chosen, the query has to aggregate them
+		return -1, fmt.Errorf("prometheus query %s returned %d series instead of one, aggregate them in the query", s.metadata.query, len(result.Data.Result))
 	}
 
 	valueLen := len(result.Data.Result[0].Value)
@@ -323,7 +364,7 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		if s.metadata.ignoreNullValues {
 			return 0, nil
 		}
-		return -1, fmt.Errorf("prometheus metrics %s target may be lost, the value list is empty", s.metadata.metricName)
+		return -1, fmt.Errorf("prometheus query %s returned empty value list, set %s to true to use 0 instead", s.metadata.query, ignoreNullValues)
 	} else if valueLen < 2 {
 		return -1, fmt.Errorf("prometheus query %s didn't return enough values", s.metadata.query)
 	}
@@ -350,6 +391,59 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 	return v, nil
 }
 
+// getServerAddresses returns the Prometheus servers in the order they should be queried
+func (s *prometheusScaler) getServerAddresses() []string {
+	if s.failover != nil {
+		return s.failover.Endpoints()
+	}
+	return []string{s.metadata.serverAddress}
+}
+
+// queryServer executes the query against a single Prometheus server, returning the response body and status code
+func (s *prometheusScaler) queryServer(ctx context.Context, serverAddress string) ([]byte, int, error) {
+	t := time.Now().UTC().Format(time.RFC3339)
+	queryEscaped := url_pkg.QueryEscape(s.metadata.query)
+	url := fmt.Sprintf("%s/api/v1/query?query=%s&time=%s", serverAddress, queryEscaped, t)
+
+	// set 'namespace' parameter for namespaced Prometheus requests (eg. for Thanos Querier)
+	if s.metadata.namespace != "" {
+		url = fmt.Sprintf("%s&namespace=%s", url, s.metadata.namespace)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for headerName, headerValue := range s.metadata.customHeaders {
+		req.Header.Add(headerName, headerValue)
+	}
+
+	switch {
+	case s.metadata.prometheusAuth == nil:
+		break
+	case s.metadata.prometheusAuth.EnableBearerAuth:
+		req.Header.Set("Authorization", authentication.GetBearerToken(s.metadata.prometheusAuth))
+	case s.metadata.prometheusAuth.EnableBasicAuth:
+		req.SetBasicAuth(s.metadata.prometheusAuth.Username, s.metadata.prometheusAuth.Password)
+	case s.metadata.prometheusAuth.EnableCustomAuth:
+		req.Header.Set(s.metadata.prometheusAuth.CustomAuthHeader, s.metadata.prometheusAuth.CustomAuthValue)
+	}
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	_ = r.Body.Close()
+
+	return b, r.StatusCode, nil
+}
+
 func (s *prometheusScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	val, err := s.ExecutePromQuery(ctx)
 	if err != nil {
@@ -6,11 +6,13 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 type parsePrometheusMetadataTestData struct {
@@ -34,8 +36,12 @@ var testPromMetadata = []parsePrometheusMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "ignoreNullValues": "false"}, false},
 	// all properly formed, with activationThreshold
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "activationThreshold": "50"}, false},
+	// multiple serverAddresses
+	{map[string]string{"serverAddress": "http://prometheus-0:9090,http://prometheus-1:9090", "threshold": "100", "query": "up"}, false},
 	// missing serverAddress
 	{map[string]string{"serverAddress": "", "metricName": "http_requests_total", "threshold": "100", "query": "up"}, true},
+	// empty list of serverAddresses
+	{map[string]string{"serverAddress": " , ", "threshold": "100", "query": "up"}, true},
 	// missing threshold
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "query": "up"}, true},
 	// malformed threshold
@@ -103,6 +109,18 @@ var testPrometheusAuthMetadata = []prometheusAuthMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up"}, nil, "azure-workload", false},
 	// azure pod identity
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up"}, nil, "azure", false},
+	// success oauth
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "authModes": "oauth"}, map[string]string{"oauthTokenURI": "http://localhost:8080/token", "clientID": "id", "clientSecret": "secret", "scopes": "read, write"}, "", false},
+	// fail oauth with no clientSecret
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "authModes": "oauth"}, map[string]string{"oauthTokenURI": "http://localhost:8080/token", "clientID": "id"}, "", true},
+	// fail oauth with bearer
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "authModes": "oauth,bearer"}, map[string]string{"oauthTokenURI": "http://localhost:8080/token", "clientID": "id", "clientSecret": "secret", "bearerToken": "token"}, "", true},
+	// success sigv4 with static credentials
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "authModes": "sigv4", "awsRegion": "eu-west-1"}, map[string]string{"awsAccessKeyID": "id", "awsSecretAccessKey": "secret"}, "", false},
+	// success sigv4 with aws pod identity
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "authModes": "sigv4", "awsRegion": "eu-west-1"}, map[string]string{"awsRoleArn": "arn:aws:iam::123456789012:role/keda"}, "aws-eks", false},
+	// fail sigv4 with no awsRegion
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "authModes": "sigv4"}, map[string]string{"awsAccessKeyID": "id", "awsSecretAccessKey": "secret"}, "", true},
 }
 
 func TestPrometheusParseMetadata(t *testing.T) {
@@ -152,7 +170,9 @@ func TestPrometheusScalerAuthParams(t *testing.T) {
 				if (meta.prometheusAuth.EnableBearerAuth && !strings.Contains(testData.metadata["authModes"], "bearer")) ||
 					(meta.prometheusAuth.EnableBasicAuth && !strings.Contains(testData.metadata["authModes"], "basic")) ||
 					(meta.prometheusAuth.EnableTLS && !strings.Contains(testData.metadata["authModes"], "tls")) ||
-					(meta.prometheusAuth.EnableCustomAuth && !strings.Contains(testData.metadata["authModes"], "custom")) {
+					(meta.prometheusAuth.EnableCustomAuth && !strings.Contains(testData.metadata["authModes"], "custom")) ||
+					(meta.prometheusAuth.EnableOAuth && !strings.Contains(testData.metadata["authModes"], "oauth")) ||
+					(meta.prometheusAuth.EnableAWSSigV4 && !strings.Contains(testData.metadata["authModes"], "sigv4")) {
 					t.Error("wrong auth mode detected")
 				}
 			}
@@ -288,6 +308,15 @@ var testPromQueryResult = []prometheusQromQueryResultTestData{
 		ignoreNullValues: false,
 		unsafeSsl:        true,
 	},
+	{
+		name:             "multiple results with values",
+		bodyStr:          `{"data":{"result":[{"value": ["1", "2"]},{"value": ["1", "3"]}]}}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    -1,
+		isError:          true,
+		ignoreNullValues: false,
+		unsafeSsl:        true,
+	},
 }
 
 func TestPrometheusScalerExecutePromQuery(t *testing.T) {
@@ -363,3 +392,108 @@ func TestPrometheusScalerCustomHeaders(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestPrometheusScalerServerFailover(t *testing.T) {
+	unavailableRequests := 0
+	unavailableServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		unavailableRequests++
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailableServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+		if _, err := writer.Write([]byte(`{"data":{"result":[{"value": ["1", "2"]}]}}`)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	badRequestServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusBadRequest)
+	}))
+	defer badRequestServer.Close()
+
+	serverAddresses := []string{unavailableServer.URL, server.URL}
+	scaler := prometheusScaler{
+		metadata: &prometheusMetadata{
+			serverAddress:   strings.Join(serverAddresses, ","),
+			serverAddresses: serverAddresses,
+		},
+		httpClient: http.DefaultClient,
+		failover:   kedautil.NewEndpointFailover(serverAddresses, time.Minute),
+		logger:     logr.Discard(),
+	}
+
+	value, err := scaler.ExecutePromQuery(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), value)
+	assert.Equal(t, 1, unavailableRequests)
+
+	// the failing server isn't queried again during its cooldown
+	value, err = scaler.ExecutePromQuery(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), value)
+	assert.Equal(t, 1, unavailableRequests)
+
+	// client errors aren't failed over
+	serverAddresses = []string{badRequestServer.URL, server.URL}
+	scaler.failover = kedautil.NewEndpointFailover(serverAddresses, time.Minute)
+	_, err = scaler.ExecutePromQuery(context.TODO())
+	assert.Error(t, err)
+
+	// error of the last server is returned if all servers are unavailable
+	scaler.failover = kedautil.NewEndpointFailover([]string{unavailableServer.URL, "http://127.0.0.1:0"}, time.Minute)
+	_, err = scaler.ExecutePromQuery(context.TODO())
+	assert.Error(t, err)
+}
+
+func TestPrometheusScalerOAuth(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.NoError(t, request.ParseForm())
+		assert.Equal(t, "client_credentials", request.Form.Get("grant_type"))
+		assert.Equal(t, "read write", request.Form.Get("scope"))
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(`{"access_token": "access-token", "token_type": "bearer", "expires_in": 3600}`))
+	}))
+	defer tokenServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "Bearer access-token", request.Header.Get("Authorization"))
+		_, _ = writer.Write([]byte(`{"data":{"result":[{"value": ["1", "2"]}]}}`))
+	}))
+	defer server.Close()
+
+	scaler, err := NewPrometheusScaler(&ScalerConfig{
+		TriggerMetadata:   map[string]string{"serverAddress": server.URL, "threshold": "100", "query": "up", "authModes": "oauth"},
+		AuthParams:        map[string]string{"oauthTokenURI": tokenServer.URL, "clientID": "id", "clientSecret": "secret", "scopes": "read,write"},
+		GlobalHTTPTimeout: time.Second,
+	})
+	assert.NoError(t, err)
+
+	value, err := scaler.(*prometheusScaler).ExecutePromQuery(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), value)
+}
+
+func TestPrometheusScalerSigV4(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		authorization := request.Header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=id/"), authorization)
+		assert.Contains(t, authorization, "/eu-west-1/aps/aws4_request")
+		assert.NotEmpty(t, request.Header.Get("X-Amz-Date"))
+		_, _ = writer.Write([]byte(`{"data":{"result":[{"value": ["1", "2"]}]}}`))
+	}))
+	defer server.Close()
+
+	scaler, err := NewPrometheusScaler(&ScalerConfig{
+		TriggerMetadata:   map[string]string{"serverAddress": server.URL, "threshold": "100", "query": "up", "authModes": "sigv4", "awsRegion": "eu-west-1"},
+		AuthParams:        map[string]string{"awsAccessKeyID": "id", "awsSecretAccessKey": "secret"},
+		GlobalHTTPTimeout: time.Second,
+	})
+	assert.NoError(t, err)
+
+	value, err := scaler.(*prometheusScaler).ExecutePromQuery(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), value)
+}
@@ -0,0 +1,45 @@
+package scalers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// parseProxyURL parses the proxy of the trigger given by proxyURL parameter of TriggerAuthentication or proxyURL
+// metadata, the parameter is preferred as the URL of the proxy might contain its credentials
+func parseProxyURL(config *ScalerConfig) (*url.URL, error) {
+	val := config.AuthParams["proxyURL"]
+	if val == "" {
+		val = config.TriggerMetadata["proxyURL"]
+	}
+	if val == "" {
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(val)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing proxyURL: %w", err)
+	}
+	if proxyURL.Scheme != "http" && proxyURL.Scheme != "https" && proxyURL.Scheme != "socks5" {
+		return nil, fmt.Errorf("proxyURL must use http, https or socks5 scheme, got %q", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("proxyURL must contain the host of the proxy")
+	}
+	return proxyURL, nil
+}
+
+// setHTTPProxy routes the requests of the HTTP client through the proxy of the trigger if it's given, the proxy set by
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of KEDA is used otherwise
+func setHTTPProxy(httpClient *http.Client, config *ScalerConfig) error {
+	proxyURL, err := parseProxyURL(config)
+	if err != nil || proxyURL == nil {
+		return err
+	}
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("proxyURL isn't supported by the HTTP transport of the scaler")
+	}
+	transport.Proxy = http.ProxyURL(proxyURL)
+	return nil
+}
@@ -12,7 +12,6 @@ import (
 
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
-	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
@@ -20,9 +19,10 @@ import (
 )
 
 type pulsarScaler struct {
-	metadata pulsarMetadata
-	client   *http.Client
-	logger   logr.Logger
+	metricType v2.MetricTargetType
+	metadata   pulsarMetadata
+	client     *http.Client
+	logger     logr.Logger
 }
 
 type pulsarMetadata struct {
@@ -95,6 +95,11 @@ type pulsarStats struct {
 
 // NewPulsarScaler creates a new PulsarScaler
 func NewPulsarScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
 	pulsarMetadata, err := parsePulsarMetadata(config)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing pulsar metadata: %w", err)
@@ -122,10 +127,15 @@ func NewPulsarScaler(config *ScalerConfig) (Scaler, error) {
 		}
 	}
 
+	if err := setHTTPProxy(client, config); err != nil {
+		return nil, err
+	}
+
 	return &pulsarScaler{
-		client:   client,
-		metadata: pulsarMetadata,
-		logger:   InitializeLogger(config, "pulsar_scaler"),
+		metricType: metricType,
+		client:     client,
+		metadata:   pulsarMetadata,
+		logger:     InitializeLogger(config, "pulsar_scaler"),
 	}, nil
 }
 
@@ -271,16 +281,11 @@ func (s *pulsarScaler) GetMetricsAndActivity(ctx context.Context, metricName str
 }
 
 func (s *pulsarScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
-	targetMetricValue := resource.NewQuantity(s.metadata.msgBacklogThreshold, resource.DecimalSI)
-
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(s.metadata.metricName)),
 		},
-		Target: v2.MetricTarget{
-			Type:         v2.AverageValueMetricType,
-			AverageValue: targetMetricValue,
-		},
+		Target: GetMetricTarget(s.metricType, s.metadata.msgBacklogThreshold),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: pulsarMetricType}
 	return []v2.MetricSpec{metricSpec}
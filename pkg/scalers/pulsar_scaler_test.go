@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
 )
 
 type parsePulsarMetadataTestData struct {
@@ -211,7 +212,7 @@ func TestPulsarGetMetricSpecForScaling(t *testing.T) {
 			}
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockPulsarScaler := pulsarScaler{meta, nil, logr.Discard()}
+		mockPulsarScaler := pulsarScaler{v2.AverageValueMetricType, meta, nil, logr.Discard()}
 
 		metricSpec := mockPulsarScaler.GetMetricSpecForScaling(context.TODO())
 		metricName := metricSpec[0].External.Metric.Name
@@ -290,3 +291,16 @@ func TestPulsarGetMetric(t *testing.T) {
 		fmt.Printf("%+v\n", metric)
 	}
 }
+
+func TestPulsarGetMetricSpecForScalingWithValueMetricType(t *testing.T) {
+	metadata := map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}
+	scaler, err := NewPulsarScaler(&ScalerConfig{TriggerMetadata: metadata, MetricType: v2.ValueMetricType})
+	if err != nil {
+		t.Fatal("Could not create scaler:", err)
+	}
+
+	target := scaler.GetMetricSpecForScaling(context.TODO())[0].External.Target
+	if target.Type != v2.ValueMetricType || target.AverageValue != nil || target.Value.Value() != defaultMsgBacklogThreshold {
+		t.Error("Wrong External metric target:", target)
+	}
+}
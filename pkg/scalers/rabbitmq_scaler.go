@@ -79,11 +79,9 @@ type rabbitMQMetadata struct {
 	scalerIndex           int           // scaler index
 
 	// TLS
-	ca          string
-	cert        string
-	key         string
-	keyPassword string
-	enableTLS   bool
+	tlsAuthMetadata
+	enableTLS bool
+	unsafeSsl bool
 }
 
 type queueInfo struct {
@@ -124,7 +122,17 @@ func NewRabbitMQScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing rabbitmq metadata: %w", err)
 	}
 	s.metadata = meta
-	s.httpClient = kedautil.CreateHTTPClient(meta.timeout, false)
+	s.httpClient = kedautil.CreateHTTPClient(meta.timeout, meta.unsafeSsl)
+	if meta.enableTLS {
+		tlsConfig, err := meta.newTLSConfig(meta.unsafeSsl)
+		if err != nil {
+			return nil, fmt.Errorf("error creating rabbitmq TLS config: %w", err)
+		}
+		s.httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
+	if err := setHTTPProxy(s.httpClient, config); err != nil {
+		return nil, err
+	}
 
 	if meta.protocol == amqpProtocol {
 		// Override vhost if requested.
@@ -181,22 +189,26 @@ func parseRabbitMQMetadata(config *ScalerConfig) (*rabbitMQMetadata, error) {
 	if val, ok := config.AuthParams["tls"]; ok {
 		val = strings.TrimSpace(val)
 		if val == rmqTLSEnable {
-			meta.ca = config.AuthParams["ca"]
-			meta.cert = config.AuthParams["cert"]
-			meta.key = config.AuthParams["key"]
 			meta.enableTLS = true
 		} else if val != "disable" {
 			return nil, fmt.Errorf("err incorrect value for TLS given: %s", val)
 		}
 	}
 
-	meta.keyPassword = config.AuthParams["keyPassword"]
-
-	certGiven := meta.cert != ""
-	keyGiven := meta.key != ""
-	if certGiven != keyGiven {
+	tlsAuth, err := parseTLSAuth(config.AuthParams)
+	if err != nil {
 		return nil, fmt.Errorf("both key and cert must be provided")
 	}
+	meta.tlsAuthMetadata = tlsAuth
+
+	meta.unsafeSsl = false
+	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok {
+		unsafeSsl, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing unsafeSsl: %w", err)
+		}
+		meta.unsafeSsl = unsafeSsl
+	}
 
 	// If the protocol is auto, check the host scheme.
 	if meta.protocol == autoProtocol {
@@ -226,7 +238,7 @@ func parseRabbitMQMetadata(config *ScalerConfig) (*rabbitMQMetadata, error) {
 		meta.vhostName = val
 	}
 
-	err := parseRabbitMQHttpProtocolMetadata(config, &meta)
+	err = parseRabbitMQHttpProtocolMetadata(config, &meta)
 	if err != nil {
 		return nil, err
 	}
@@ -311,7 +323,12 @@ func parseRabbitMQHttpProtocolMetadata(config *ScalerConfig, meta *rabbitMQMetad
 	// Resolve operation
 	meta.operation = defaultOperation
 	if val, ok := config.TriggerMetadata["operation"]; ok {
-		meta.operation = val
+		switch val {
+		case sumOperation, avgOperation, maxOperation:
+			meta.operation = val
+		default:
+			return fmt.Errorf("operation mode %s must be one of %s, %s, %s", val, sumOperation, avgOperation, maxOperation)
+		}
 	}
 
 	return nil
@@ -396,10 +413,11 @@ func getConnectionAndChannel(host string, meta *rabbitMQMetadata) (*amqp.Connect
 	var conn *amqp.Connection
 	var err error
 	if meta.enableTLS {
-		tlsConfig, configErr := kedautil.NewTLSConfigWithPassword(meta.cert, meta.key, meta.keyPassword, meta.ca, false)
-		if configErr == nil {
-			conn, err = amqp.DialTLS(host, tlsConfig)
+		tlsConfig, configErr := meta.newTLSConfig(meta.unsafeSsl)
+		if configErr != nil {
+			return nil, nil, configErr
 		}
+		conn, err = amqp.DialTLS(host, tlsConfig)
 	} else {
 		conn, err = amqp.Dial(host)
 	}
@@ -450,34 +468,19 @@ func (s *rabbitMQScaler) getQueueStatus() (int64, float64, error) {
 	return int64(items.Messages), 0, nil
 }
 
-func getJSON(s *rabbitMQScaler, url string) (queueInfo, error) {
-	var result queueInfo
+func getJSON(s *rabbitMQScaler, url string, result interface{}) error {
 	r, err := s.httpClient.Get(url)
 	if err != nil {
-		return result, err
+		return err
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == 200 {
-		if s.metadata.useRegex {
-			var queues regexQueueInfo
-			err = json.NewDecoder(r.Body).Decode(&queues)
-			if err != nil {
-				return queueInfo{}, err
-			}
-			if queues.TotalPages > 1 {
-				return queueInfo{}, fmt.Errorf("regex matches more queues than can be recovered at once")
-			}
-			result, err := getComposedQueue(s, queues.Queues)
-			return result, err
-		}
-
-		err = json.NewDecoder(r.Body).Decode(&result)
-		return result, err
+		return json.NewDecoder(r.Body).Decode(result)
 	}
 
 	body, _ := io.ReadAll(r.Body)
-	return result, fmt.Errorf("error requesting rabbitMQ API status: %s, response: %s, from: %s", r.Status, body, url)
+	return fmt.Errorf("error requesting rabbitMQ API status: %s, response: %s, from: %s", r.Status, body, url)
 }
 
 func (s *rabbitMQScaler) getQueueInfoViaHTTP() (*queueInfo, error) {
@@ -501,20 +504,35 @@ func (s *rabbitMQScaler) getQueueInfoViaHTTP() (*queueInfo, error) {
 	// Clear URL path to get the correct host.
 	parsedURL.Path = ""
 
-	var getQueueInfoManagementURI string
-	if s.metadata.useRegex {
-		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s?page=1&use_regex=true&pagination=false&name=%s&page_size=%d", parsedURL.String(), vhost, url.QueryEscape(s.metadata.queueName), s.metadata.pageSize)
-	} else {
-		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s/%s", parsedURL.String(), vhost, url.QueryEscape(s.metadata.queueName))
+	if !s.metadata.useRegex {
+		getQueueInfoManagementURI := fmt.Sprintf("%s/api/queues%s/%s", parsedURL.String(), vhost, url.QueryEscape(s.metadata.queueName))
+
+		var info queueInfo
+		if err := getJSON(s, getQueueInfoManagementURI, &info); err != nil {
+			return nil, err
+		}
+		return &info, nil
 	}
 
-	var info queueInfo
-	info, err = getJSON(s, getQueueInfoManagementURI)
+	// queues matching the regex are aggregated over all pages
+	var queues []queueInfo
+	for page := 1; ; page++ {
+		getQueueInfoManagementURI := fmt.Sprintf("%s/api/queues%s?page=%d&use_regex=true&pagination=false&name=%s&page_size=%d", parsedURL.String(), vhost, page, url.QueryEscape(s.metadata.queueName), s.metadata.pageSize)
+
+		var pageInfo regexQueueInfo
+		if err := getJSON(s, getQueueInfoManagementURI, &pageInfo); err != nil {
+			return nil, err
+		}
+		queues = append(queues, pageInfo.Queues...)
+		if page >= pageInfo.TotalPages {
+			break
+		}
+	}
 
+	info, err := getComposedQueue(s, queues)
 	if err != nil {
 		return nil, err
 	}
-
 	return &info, nil
 }
 
@@ -2,6 +2,7 @@ package scalers
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -126,6 +127,8 @@ var testRabbitMQMetadata = []parseRabbitMQMetadataTestData{
 	{map[string]string{"mode": "QueueLength", "value": "1000", "queueName": "sample", "host": "http://", "useRegex": "true", "excludeUnacknowledged": "true"}, false, map[string]string{}},
 	// amqp and excludeUnacknowledged
 	{map[string]string{"mode": "QueueLength", "value": "1000", "queueName": "sample", "host": "amqp://", "useRegex": "true", "excludeUnacknowledged": "true"}, true, map[string]string{}},
+	// useRegex and invalid operation
+	{map[string]string{"mode": "QueueLength", "value": "1000", "queueName": "sample", "host": "http://", "useRegex": "true", "operation": "min"}, true, map[string]string{}},
 }
 
 var testRabbitMQAuthParamData = []parseRabbitMQAuthParamTestData{
@@ -602,61 +605,80 @@ func TestRabbitMQAnonimizeRabbitMQError(t *testing.T) {
 	}
 }
 
-type getQueueInfoNavigationTestData struct {
-	response string
-	isError  bool
-}
-
-var testRegexQueueInfoNavigationTestData = []getQueueInfoNavigationTestData{
-	// sum queue length
-	{`{"items":[], "filtered_count": 250, "page": 1, "page_count": 3}`, true},
-	{`{"items":[], "filtered_count": 250, "page": 1, "page_count": 1}`, false},
-}
-
-func TestRegexQueueMissingError(t *testing.T) {
-	for _, testData := range testRegexQueueInfoNavigationTestData {
-		var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			expectedPath := "/api/queues/%2F?page=1&use_regex=true&pagination=false&name=evaluate_trials&page_size=100"
-			if r.RequestURI != expectedPath {
-				t.Error("Expect request path to =", expectedPath, "but it is", r.RequestURI)
-			}
-
-			w.WriteHeader(http.StatusOK)
-			_, err := w.Write([]byte(testData.response))
-			if err != nil {
-				t.Error("Expect request path to =", testData.response, "but it is", err)
-			}
-		}))
-
-		resolvedEnv := map[string]string{host: apiStub.URL, "plainHost": apiStub.URL}
-
-		metadata := map[string]string{
-			"queueName":   "evaluate_trials",
-			"hostFromEnv": host,
-			"protocol":    "http",
-			"useRegex":    "true",
+func TestRegexQueuePagination(t *testing.T) {
+	var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		expectedPath := fmt.Sprintf("/api/queues/%%2F?page=%s&use_regex=true&pagination=false&name=evaluate_trials&page_size=1", page)
+		if r.RequestURI != expectedPath {
+			t.Error("Expect request path to =", expectedPath, "but it is", r.RequestURI)
 		}
 
-		s, err := NewRabbitMQScaler(
-			&ScalerConfig{
-				ResolvedEnv:       resolvedEnv,
-				TriggerMetadata:   metadata,
-				AuthParams:        map[string]string{},
-				GlobalHTTPTimeout: 1000 * time.Millisecond,
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprintf(w, `{"items":[{"messages": 2, "messages_unacknowledged": 0, "message_stats": {"publish_details": {"rate": 0}}, "name": "evaluate_trials_%s"}], "filtered_count": 3, "page": %s, "page_count": 3}`, page, page)
+		if err != nil {
+			t.Error("Expect response to be written but it is", err)
+		}
+	}))
+	defer apiStub.Close()
+
+	s, err := NewRabbitMQScaler(
+		&ScalerConfig{
+			ResolvedEnv: map[string]string{host: apiStub.URL},
+			TriggerMetadata: map[string]string{
+				"queueName":   "evaluate_trials",
+				"hostFromEnv": host,
+				"protocol":    "http",
+				"useRegex":    "true",
+				"pageSize":    "1",
+				"operation":   "sum",
 			},
-		)
+			AuthParams:        map[string]string{},
+			GlobalHTTPTimeout: 1000 * time.Millisecond,
+		},
+	)
+	if err != nil {
+		t.Fatal("Expect success", err)
+	}
 
+	metrics, _, err := s.GetMetricsAndActivity(context.TODO(), "Metric")
+	if err != nil {
+		t.Fatal("Expect success", err)
+	}
+	assert.Equal(t, int64(6), metrics[0].Value.Value())
+}
+
+func TestRabbitMQHTTPWithTLS(t *testing.T) {
+	apiStub := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"messages": 4, "messages_unacknowledged": 1, "message_stats": {"publish_details": {"rate": 1.4}}, "name": "evaluate_trials"}`)
+	}))
+	defer apiStub.Close()
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: apiStub.Certificate().Raw}))
+
+	testData := []struct {
+		name       string
+		metadata   map[string]string
+		authParams map[string]string
+		isError    bool
+	}{
+		{"untrusted certificate", map[string]string{}, map[string]string{}, true},
+		{"ca of TriggerAuthentication", map[string]string{}, map[string]string{"tls": "enable", "ca": ca}, false},
+		{"unsafeSsl", map[string]string{"unsafeSsl": "true"}, map[string]string{}, false},
+	}
+	for _, tt := range testData {
+		metadata := map[string]string{"queueName": "evaluate_trials", "host": apiStub.URL, "protocol": "http"}
+		for k, v := range tt.metadata {
+			metadata[k] = v
+		}
+		s, err := NewRabbitMQScaler(&ScalerConfig{TriggerMetadata: metadata, AuthParams: tt.authParams, GlobalHTTPTimeout: 1000 * time.Millisecond})
 		if err != nil {
-			t.Error("Expect success", err)
+			t.Fatal(tt.name, err)
 		}
 
-		ctx := context.TODO()
-		_, _, err = s.GetMetricsAndActivity(ctx, "Metric")
-		if err != nil && !testData.isError {
-			t.Error("Expected success but got error", err)
-		}
-		if testData.isError && err == nil {
-			t.Error("Expected error but got success")
+		_, _, err = s.GetMetricsAndActivity(context.TODO(), "Metric")
+		if tt.isError {
+			assert.Error(t, err, tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
 		}
 	}
 }
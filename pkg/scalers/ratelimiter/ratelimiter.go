@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimiter limits the rate of requests of all triggers to rate limited cloud metric APIs (Azure Monitor,
+// CloudWatch, Datadog) per API and account, the requests over the limit are queued and spread by a random jitter,
+// so many ScaledObjects querying the same account don't get throttled collectively.
+package ratelimiter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultJitter is the default longest random delay of requests to rate limited APIs
+const DefaultJitter = 250 * time.Millisecond
+
+var (
+	defaultLimiter     = NewLimiter(nil, DefaultJitter)
+	defaultLimiterLock sync.RWMutex
+)
+
+// Configure sets the requests per second to each account of the APIs and the longest random delay of the requests
+// of the limiter shared by all triggers, requests to APIs without a limit aren't delayed
+func Configure(limits map[string]float64, jitter time.Duration) {
+	defaultLimiterLock.Lock()
+	defer defaultLimiterLock.Unlock()
+	defaultLimiter = NewLimiter(limits, jitter)
+}
+
+// Wait blocks until a request to the account of the API is allowed by the shared limiter
+func Wait(ctx context.Context, api, account string) error {
+	defaultLimiterLock.RLock()
+	limiter := defaultLimiter
+	defaultLimiterLock.RUnlock()
+	return limiter.Wait(ctx, api, account)
+}
+
+// ParseLimits parses the limits of APIs given as comma separated api=requestsPerSecond pairs,
+// eg. azure-monitor=3,aws-cloudwatch=50
+func ParseLimits(value string) (map[string]float64, error) {
+	limits := map[string]float64{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		api, limit, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid rate limit %q, expected api=requestsPerSecond", pair)
+		}
+		requestsPerSecond, err := strconv.ParseFloat(strings.TrimSpace(limit), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit of %s: %w", api, err)
+		}
+		if requestsPerSecond <= 0 {
+			return nil, fmt.Errorf("rate limit of %s must be positive, got %s", api, limit)
+		}
+		limits[strings.TrimSpace(api)] = requestsPerSecond
+	}
+	return limits, nil
+}
+
+// AccountKey identifies the account of an API by the given values, they are hashed as they could contain credentials
+func AccountKey(values ...string) string {
+	hash := sha256.New()
+	for _, value := range values {
+		fmt.Fprintf(hash, "%q\n", value)
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// Limiter limits the rate of requests to each account of the APIs
+type Limiter struct {
+	limits map[string]float64
+	jitter time.Duration
+
+	lock     sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewLimiter creates a new Limiter
+func NewLimiter(limits map[string]float64, jitter time.Duration) *Limiter {
+	return &Limiter{
+		limits:   limits,
+		jitter:   jitter,
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+// Wait blocks until a request to the account of the API is allowed, the requests are queued in the order of calls
+// and delayed by a random jitter, it returns an error if the context is done before
+func (l *Limiter) Wait(ctx context.Context, api, account string) error {
+	limiter := l.getLimiter(api, account)
+	if limiter == nil {
+		return nil
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limit of %s: %w", api, err)
+	}
+	if l.jitter <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("rate limit of %s: %w", api, ctx.Err())
+	case <-time.After(time.Duration(rand.Int63n(int64(l.jitter)))):
+		return nil
+	}
+}
+
+func (l *Limiter) getLimiter(api, account string) *rate.Limiter {
+	requestsPerSecond, found := l.limits[api]
+	if !found {
+		return nil
+	}
+
+	key := api + "/" + account
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	limiter, found := l.limiters[key]
+	if !found {
+		limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), 1)
+		l.limiters[key] = limiter
+	}
+	return limiter
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ParseLimits("")
+	assert.NoError(t, err)
+	assert.Empty(t, limits)
+
+	limits, err = ParseLimits("azure-monitor=3, datadog=0.5")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"azure-monitor": 3, "datadog": 0.5}, limits)
+
+	for _, value := range []string{"azure-monitor", "azure-monitor=a", "azure-monitor=0"} {
+		_, err = ParseLimits(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestLimiterLimitsRequestsPerAccount(t *testing.T) {
+	limiter := NewLimiter(map[string]float64{"datadog": 10}, 0)
+
+	// the first request of each account isn't delayed
+	start := time.Now()
+	assert.NoError(t, limiter.Wait(context.Background(), "datadog", AccountKey("a")))
+	assert.NoError(t, limiter.Wait(context.Background(), "datadog", AccountKey("b")))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	// the following request of the account is queued
+	assert.NoError(t, limiter.Wait(context.Background(), "datadog", AccountKey("a")))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestLimiterDoesntLimitOtherAPIs(t *testing.T) {
+	limiter := NewLimiter(map[string]float64{"datadog": 0.001}, time.Hour)
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, limiter.Wait(context.Background(), "azure-monitor", AccountKey("a")))
+	}
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestLimiterRespectsContext(t *testing.T) {
+	limiter := NewLimiter(map[string]float64{"datadog": 0.001}, 0)
+	assert.NoError(t, limiter.Wait(context.Background(), "datadog", AccountKey("a")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, limiter.Wait(ctx, "datadog", AccountKey("a")))
+}
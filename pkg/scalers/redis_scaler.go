@@ -32,6 +32,9 @@ var (
 
 	// ErrRedisUnequalHostsAndPorts is returned when the number of hosts and ports are unequal.
 	ErrRedisUnequalHostsAndPorts = errors.New("not enough hosts or ports given. number of hosts should be equal to the number of ports")
+
+	// ErrRedisClusterKeyspaceNotifications is returned when "enableKeyspaceNotifications" is set for a Redis Cluster.
+	ErrRedisClusterKeyspaceNotifications = errors.New("enableKeyspaceNotifications isn't supported for redis cluster, keyspace notifications are published only by the node of the key")
 )
 
 type redisAddressParser func(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error)
@@ -41,9 +44,15 @@ type redisScaler struct {
 	metadata        *redisMetadata
 	closeFn         func() error
 	getListLengthFn func(context.Context) (int64, error)
+	subscribeFn     func(context.Context, string) *redis.PubSub
 	logger          logr.Logger
 }
 
+// redisPushScaler subscribes to keyspace notifications of the list, so its changes are checked immediately
+type redisPushScaler struct {
+	redisScaler
+}
+
 type redisConnectionInfo struct {
 	addresses        []string
 	username         string
@@ -55,6 +64,7 @@ type redisConnectionInfo struct {
 	ports            []string
 	enableTLS        bool
 	unsafeSsl        bool
+	tls              tlsAuthMetadata
 }
 
 type redisMetadata struct {
@@ -63,7 +73,9 @@ type redisMetadata struct {
 	listName             string
 	databaseIndex        int
 	connectionInfo       redisConnectionInfo
-	scalerIndex          int
+	// enableKeyspaceNotifications requires notify-keyspace-events to be configured on the Redis server
+	enableKeyspaceNotifications bool
+	scalerIndex                 int
 }
 
 // NewRedisScaler creates a new redisScaler
@@ -94,6 +106,9 @@ func NewRedisScaler(ctx context.Context, isClustered, isSentinel bool, config *S
 		if err != nil {
 			return nil, fmt.Errorf("error parsing redis metadata: %w", err)
 		}
+		if meta.enableKeyspaceNotifications {
+			return nil, ErrRedisClusterKeyspaceNotifications
+		}
 		return createClusteredRedisScaler(ctx, meta, luaScript, metricType, logger)
 	} else if isSentinel {
 		meta, err := parseRedisMetadata(config, parseRedisSentinelAddress)
@@ -179,13 +194,22 @@ func createRedisScalerWithClient(client *redis.Client, meta *redisMetadata, scri
 		return cmd.Int64()
 	}
 
-	return &redisScaler{
+	subscribeFn := func(ctx context.Context, channel string) *redis.PubSub {
+		return client.Subscribe(ctx, channel)
+	}
+
+	scaler := redisScaler{
 		metricType:      metricType,
 		metadata:        meta,
 		closeFn:         closeFn,
 		getListLengthFn: listLengthFn,
+		subscribeFn:     subscribeFn,
 		logger:          logger,
 	}
+	if meta.enableKeyspaceNotifications {
+		return &redisPushScaler{scaler}
+	}
+	return &scaler
 }
 
 func parseRedisMetadata(config *ScalerConfig, parserFn redisAddressParser) (*redisMetadata, error) {
@@ -215,6 +239,12 @@ func parseRedisMetadata(config *ScalerConfig, parserFn redisAddressParser) (*red
 		meta.connectionInfo.unsafeSsl = parsedVal
 	}
 
+	tlsAuth, err := parseTLSAuth(config.AuthParams)
+	if err != nil {
+		return nil, err
+	}
+	meta.connectionInfo.tls = tlsAuth
+
 	meta.listLength = defaultListLength
 	if val, ok := config.TriggerMetadata["listLength"]; ok {
 		listLength, err := strconv.ParseInt(val, 10, 64)
@@ -247,6 +277,14 @@ func parseRedisMetadata(config *ScalerConfig, parserFn redisAddressParser) (*red
 		}
 		meta.databaseIndex = int(dbIndex)
 	}
+
+	if val, ok := config.TriggerMetadata["enableKeyspaceNotifications"]; ok {
+		enableKeyspaceNotifications, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("enableKeyspaceNotifications parsing error %w", err)
+		}
+		meta.enableKeyspaceNotifications = enableKeyspaceNotifications
+	}
 	meta.scalerIndex = config.ScalerIndex
 	return &meta, nil
 }
@@ -284,6 +322,35 @@ func (s *redisScaler) GetMetricsAndActivity(ctx context.Context, metricName stri
 	return []external_metrics.ExternalMetricValue{metric}, listLen > s.metadata.activationListLength, nil
 }
 
+// Run subscribes to keyspace notifications of the list and signals every change of it, the activity isn't known
+// until the list length is queried, so the scale loop checks it immediately
+func (s *redisPushScaler) Run(ctx context.Context, active chan<- bool) {
+	defer close(active)
+
+	channel := fmt.Sprintf("__keyspace@%d__:%s", s.metadata.databaseIndex, s.metadata.listName)
+	pubsub := s.subscribeFn(ctx, channel)
+	defer pubsub.Close()
+
+	// the channel of go-redis resubscribes on connection errors
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			s.logger.V(1).Info("received keyspace notification", "channel", msg.Channel, "event", msg.Payload)
+			select {
+			case active <- true:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
 func parseRedisAddress(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error) {
 	info := redisConnectionInfo{}
 	switch {
@@ -463,7 +530,11 @@ func getRedisClusterClient(ctx context.Context, info redisConnectionInfo) (*redi
 		Password: info.password,
 	}
 	if info.enableTLS {
-		options.TLSConfig = util.CreateTLSClientConfig(info.unsafeSsl)
+		tlsConfig, err := info.tls.newTLSConfig(info.unsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		options.TLSConfig = tlsConfig
 	}
 
 	// confirm if connected
@@ -485,7 +556,11 @@ func getRedisSentinelClient(ctx context.Context, info redisConnectionInfo, dbInd
 		MasterName:       info.sentinelMaster,
 	}
 	if info.enableTLS {
-		options.TLSConfig = util.CreateTLSClientConfig(info.unsafeSsl)
+		tlsConfig, err := info.tls.newTLSConfig(info.unsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		options.TLSConfig = tlsConfig
 	}
 
 	// confirm if connected
@@ -504,7 +579,11 @@ func getRedisClient(ctx context.Context, info redisConnectionInfo, dbIndex int)
 		DB:       dbIndex,
 	}
 	if info.enableTLS {
-		options.TLSConfig = util.CreateTLSClientConfig(info.unsafeSsl)
+		tlsConfig, err := info.tls.newTLSConfig(info.unsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		options.TLSConfig = tlsConfig
 	}
 
 	// confirm if connected
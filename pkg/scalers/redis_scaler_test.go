@@ -57,7 +57,11 @@ var testRedisMetadata = []parseRedisMetadataTestData{
 	// host and port is defined in the authParams
 	{map[string]string{"listName": "mylist", "listLength": "0"}, false, map[string]string{"host": "localhost", "port": "6379"}},
 	// host only is defined in the authParams
-	{map[string]string{"listName": "mylist", "listLength": "0"}, true, map[string]string{"host": "localhost"}}}
+	{map[string]string{"listName": "mylist", "listLength": "0"}, true, map[string]string{"host": "localhost"}},
+	// keyspace notifications enabled
+	{map[string]string{"listName": "mylist", "listLength": "0", "enableKeyspaceNotifications": "true"}, false, map[string]string{"address": "localhost:6379"}},
+	// improperly formed enableKeyspaceNotifications
+	{map[string]string{"listName": "mylist", "listLength": "0", "enableKeyspaceNotifications": "AA"}, true, map[string]string{"address": "localhost:6379"}}}
 
 var redisMetricIdentifiers = []redisMetricIdentifier{
 	{&testRedisMetadata[1], 0, "s0-redis-mylist"},
@@ -78,6 +82,16 @@ func TestRedisParseMetadata(t *testing.T) {
 	}
 }
 
+func TestRedisClusterKeyspaceNotifications(t *testing.T) {
+	config := &ScalerConfig{
+		TriggerMetadata: map[string]string{"listName": "mylist", "addresses": "a:1, b:2", "enableKeyspaceNotifications": "true"},
+		ResolvedEnv:     testRedisResolvedEnv,
+		AuthParams:      map[string]string{},
+	}
+	_, err := NewRedisScaler(context.Background(), true, false, config)
+	assert.ErrorIs(t, err, ErrRedisClusterKeyspaceNotifications)
+}
+
 func TestRedisGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range redisMetricIdentifiers {
 		meta, err := parseRedisMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ResolvedEnv: testRedisResolvedEnv, AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex}, parseRedisAddress)
@@ -91,6 +105,7 @@ func TestRedisGetMetricSpecForScaling(t *testing.T) {
 			meta,
 			closeFn,
 			lengthFn,
+			nil,
 			logr.Discard(),
 		}
 
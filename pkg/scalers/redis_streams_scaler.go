@@ -185,6 +185,12 @@ func parseRedisStreamsMetadata(config *ScalerConfig, parseFn redisAddressParser)
 		meta.connectionInfo.unsafeSsl = parsedVal
 	}
 
+	tlsAuth, err := parseTLSAuth(config.AuthParams)
+	if err != nil {
+		return nil, err
+	}
+	meta.connectionInfo.tls = tlsAuth
+
 	meta.targetPendingEntriesCount = defaultTargetPendingEntriesCount
 
 	if val, ok := config.TriggerMetadata[pendingEntriesCountMetadata]; ok {
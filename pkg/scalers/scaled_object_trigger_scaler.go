@@ -0,0 +1,118 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// TriggerMetricsGetter returns the last metrics recorded for the named trigger of a ScaledObject,
+// the metrics are read from the scale loop of the ScaledObject, the scaler of the trigger isn't queried
+type TriggerMetricsGetter func(ctx context.Context, scaledObjectNamespace, scaledObjectName, triggerName string) ([]external_metrics.ExternalMetricValue, error)
+
+// scaledObjectTriggerScaler reuses the metric of a named trigger of another ScaledObject in the same namespace,
+// so several workloads can scale on a single measurement without querying the source for each of them
+type scaledObjectTriggerScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *scaledObjectTriggerMetadata
+	getMetrics TriggerMetricsGetter
+	logger     logr.Logger
+}
+
+type scaledObjectTriggerMetadata struct {
+	ScaledObjectName      string  `keda:"name=scaledObjectName, order=triggerMetadata"`
+	TriggerName           string  `keda:"name=triggerName, order=triggerMetadata"`
+	TargetValue           float64 `keda:"name=targetValue, order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+
+	scaledObjectNamespace string
+	scalerIndex           int
+}
+
+// NewScaledObjectTriggerScaler creates a new scaledObjectTriggerScaler
+func NewScaledObjectTriggerScaler(getMetrics TriggerMetricsGetter, config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseScaledObjectTriggerMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing scaled object trigger metadata: %w", err)
+	}
+
+	return &scaledObjectTriggerScaler{
+		metricType: metricType,
+		metadata:   meta,
+		getMetrics: getMetrics,
+		logger:     InitializeLogger(config, "scaled_object_trigger_scaler"),
+	}, nil
+}
+
+func parseScaledObjectTriggerMetadata(config *ScalerConfig) (*scaledObjectTriggerMetadata, error) {
+	meta := scaledObjectTriggerMetadata{}
+	if err := config.TypedConfig(&meta); err != nil {
+		return nil, err
+	}
+
+	if meta.ScaledObjectName == config.ScalableObjectName && config.ScalableObjectType == "ScaledObject" {
+		return nil, errors.New("scaledObjectName must reference another ScaledObject")
+	}
+	meta.scaledObjectNamespace = config.ScalableObjectNamespace
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+// getTriggerValue returns the sum of the metric values recorded for the referenced trigger
+func (s *scaledObjectTriggerScaler) getTriggerValue(ctx context.Context) (float64, error) {
+	if s.getMetrics == nil {
+		return -1, errors.New("metrics of other ScaledObjects are not available")
+	}
+
+	metrics, err := s.getMetrics(ctx, s.metadata.scaledObjectNamespace, s.metadata.ScaledObjectName, s.metadata.TriggerName)
+	if err != nil {
+		return -1, err
+	}
+
+	var value float64
+	for _, metric := range metrics {
+		value += metric.Value.AsApproximateFloat64()
+	}
+	return value, nil
+}
+
+func (s *scaledObjectTriggerScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getTriggerValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting metrics of trigger", "scaledObjectName", s.metadata.ScaledObjectName, "triggerName", s.metadata.TriggerName)
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *scaledObjectTriggerScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("scaled-object-trigger-%s-%s", s.metadata.ScaledObjectName, s.metadata.TriggerName))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *scaledObjectTriggerScaler) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,107 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/metrics/pkg/apis/external_metrics"
+)
+
+type parseScaledObjectTriggerMetadataTestData struct {
+	testName string
+	metadata map[string]string
+	isError  bool
+}
+
+var testScaledObjectTriggerMetadata = []parseScaledObjectTriggerMetadataTestData{
+	{"empty", map[string]string{}, true},
+	{"properly formed", map[string]string{"scaledObjectName": "producer", "triggerName": "backlog", "targetValue": "10", "activationTargetValue": "0.5"}, false},
+	{"no scaledObjectName", map[string]string{"triggerName": "backlog", "targetValue": "10"}, true},
+	{"self reference", map[string]string{"scaledObjectName": "consumer", "triggerName": "backlog", "targetValue": "10"}, true},
+	{"no triggerName", map[string]string{"scaledObjectName": "producer", "targetValue": "10"}, true},
+	{"no targetValue", map[string]string{"scaledObjectName": "producer", "triggerName": "backlog"}, true},
+	{"invalid targetValue", map[string]string{"scaledObjectName": "producer", "triggerName": "backlog", "targetValue": "a"}, true},
+	{"invalid activationTargetValue", map[string]string{"scaledObjectName": "producer", "triggerName": "backlog", "targetValue": "10", "activationTargetValue": "a"}, true},
+}
+
+func TestScaledObjectTriggerParseMetadata(t *testing.T) {
+	for _, testData := range testScaledObjectTriggerMetadata {
+		t.Run(testData.testName, func(t *testing.T) {
+			_, err := parseScaledObjectTriggerMetadata(&ScalerConfig{
+				TriggerMetadata:         testData.metadata,
+				ScalableObjectName:      "consumer",
+				ScalableObjectNamespace: "default",
+				ScalableObjectType:      "ScaledObject",
+			})
+			if testData.isError && err == nil {
+				t.Error("expected error but got none")
+			}
+			if !testData.isError && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
+
+func TestScaledObjectTriggerGetMetricsAndActivity(t *testing.T) {
+	getMetrics := func(ctx context.Context, scaledObjectNamespace, scaledObjectName, triggerName string) ([]external_metrics.ExternalMetricValue, error) {
+		if scaledObjectNamespace != "default" || scaledObjectName != "producer" || triggerName != "backlog" {
+			return nil, errors.New("trigger not found")
+		}
+		return []external_metrics.ExternalMetricValue{GenerateMetricInMili("s0-backlog", 12)}, nil
+	}
+
+	tests := []struct {
+		name           string
+		metadata       map[string]string
+		expectedValue  int64
+		expectedActive bool
+		isError        bool
+	}{
+		{"active", map[string]string{"scaledObjectName": "producer", "triggerName": "backlog", "targetValue": "5"}, 12, true, false},
+		{"inactive", map[string]string{"scaledObjectName": "producer", "triggerName": "backlog", "targetValue": "5", "activationTargetValue": "12"}, 12, false, false},
+		{"unknown trigger", map[string]string{"scaledObjectName": "producer", "triggerName": "lag", "targetValue": "5"}, 0, false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaler, err := NewScaledObjectTriggerScaler(getMetrics, &ScalerConfig{TriggerMetadata: test.metadata, ScalableObjectNamespace: "default"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s1-scaled-object-trigger-producer-backlog")
+			if test.isError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error but got %s", err)
+			}
+			if metrics[0].Value.Value() != test.expectedValue {
+				t.Errorf("expected value %d but got %d", test.expectedValue, metrics[0].Value.Value())
+			}
+			if isActive != test.expectedActive {
+				t.Errorf("expected activity %v but got %v", test.expectedActive, isActive)
+			}
+		})
+	}
+}
+
+func TestScaledObjectTriggerGetMetricSpecForScaling(t *testing.T) {
+	scaler, err := NewScaledObjectTriggerScaler(nil, &ScalerConfig{
+		TriggerMetadata: map[string]string{"scaledObjectName": "producer", "triggerName": "backlog", "targetValue": "5"},
+		ScalerIndex:     1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metricName := scaler.GetMetricSpecForScaling(context.Background())[0].External.Metric.Name
+	if metricName != "s1-scaled-object-trigger-producer-backlog" {
+		t.Error("Wrong External metric source name:", metricName)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,6 +34,9 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/formula"
+	"github.com/kedacore/keda/v2/pkg/scalers/metricsproxy"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 func init() {
@@ -61,6 +66,25 @@ type PushScaler interface {
 	Run(ctx context.Context, active chan<- bool)
 }
 
+// newPushScalerBackoff returns the backoff of push scalers recovering their connections (eg. streams terminated by the server),
+// the delays start at 2 seconds and they are doubled up to 1 minute
+func newPushScalerBackoff() *kedautil.Backoff {
+	return &kedautil.Backoff{
+		InitialInterval: 2 * time.Second,
+		MaxInterval:     time.Minute,
+		Jitter:          0.2,
+	}
+}
+
+// ParallelismLimitedScaler interface is implemented by scalers of partitioned sources (eg. Kafka topics), where
+// the number of consumers processing the source in parallel is limited by the number of partitions
+type ParallelismLimitedScaler interface {
+	Scaler
+
+	// GetMaxParallelism returns the maximum number of consumers processing the source in parallel, 0 if it isn't limited
+	GetMaxParallelism(ctx context.Context) (int64, error)
+}
+
 // ScalerConfig contains config fields common for all scalers
 type ScalerConfig struct {
 	// ScalableObjectName specifies name of the ScaledObject/ScaledJob that owns this scaler
@@ -78,10 +102,26 @@ type ScalerConfig struct {
 	// Name of the trigger
 	TriggerName string
 
+	// Type of the trigger
+	TriggerType string
+
 	// Marks whether we should query metrics only during the polling interval
 	// Any requests for metrics in between are read from the cache
 	TriggerUseCachedMetrics bool
 
+	// Windows during which the trigger is ignored
+	TriggerMaintenanceWindows []kedav1alpha1.MaintenanceWindow
+
+	// Timeout of a single check of the trigger given by checkTimeout metadata,
+	// the global timeout of scaler checks is used if it's zero
+	TriggerCheckTimeout time.Duration
+
+	// Number of retries of a failed check of the trigger given by checkRetries metadata
+	TriggerCheckRetries int
+
+	// Expression transforming the metric value of the trigger, it's parsed once the scaler is built
+	TriggerMetricFormula *formula.Formula
+
 	// TriggerMetadata
 	TriggerMetadata map[string]string
 
@@ -106,6 +146,9 @@ var (
 	// is provided as the metric target type for scaler.
 	ErrScalerUnsupportedUtilizationMetricType = errors.New("'Utilization' metric type is unsupported for external metrics, allowed values are 'Value' or 'AverageValue'")
 
+	// ErrScalerUnsupportedMetricType is returned when an unknown metric target type is provided for scaler.
+	ErrScalerUnsupportedMetricType = errors.New("metric type is unsupported, allowed values are 'Value' or 'AverageValue'")
+
 	// ErrScalerConfigMissingField is returned when a required field is missing from the scaler config.
 	ErrScalerConfigMissingField = errors.New("missing required field in scaler config")
 )
@@ -125,11 +168,97 @@ func GetFromAuthOrMeta(config *ScalerConfig, field string) (string, error) {
 	return result, err
 }
 
+// ParseTriggerCheckPolicy parses the optional checkTimeout and checkRetries metadata of a trigger,
+// they are shared by all scalers and don't affect the query of the scaler
+func ParseTriggerCheckPolicy(metadata map[string]string) (time.Duration, int, error) {
+	var timeout time.Duration
+	var retries int
+	if val, ok := metadata["checkTimeout"]; ok && val != "" {
+		t, err := time.ParseDuration(val)
+		if err != nil {
+			return 0, 0, fmt.Errorf("error parsing checkTimeout: %w", err)
+		}
+		if t <= 0 {
+			return 0, 0, fmt.Errorf("checkTimeout must be positive, got %s", val)
+		}
+		timeout = t
+	}
+	if val, ok := metadata["checkRetries"]; ok && val != "" {
+		r, err := strconv.Atoi(val)
+		if err != nil {
+			return 0, 0, fmt.Errorf("error parsing checkRetries: %w", err)
+		}
+		if r < 0 {
+			return 0, 0, fmt.Errorf("checkRetries must not be negative, got %d", r)
+		}
+		retries = r
+	}
+	return timeout, retries, nil
+}
+
+// getMetricsProxyKey returns the key of the query in the shared metrics proxy if the trigger opts into it with useMetricsProxy,
+// the key is empty otherwise, metadata not affecting the query (eg. targets) are ignored
+func getMetricsProxyKey(config *ScalerConfig, ignoredMetadata ...string) (string, error) {
+	val, ok := config.TriggerMetadata["useMetricsProxy"]
+	if !ok || val == "" {
+		return "", nil
+	}
+	useMetricsProxy, err := strconv.ParseBool(val)
+	if err != nil {
+		return "", fmt.Errorf("error parsing useMetricsProxy: %w", err)
+	}
+	if !useMetricsProxy {
+		return "", nil
+	}
+
+	ignoredMetadata = append(ignoredMetadata, "useMetricsProxy", "checkTimeout", "checkRetries")
+	identity := fmt.Sprintf("%s/%s", config.PodIdentity.Provider, config.PodIdentity.IdentityID)
+	return metricsproxy.QueryKey(config.TriggerMetadata, config.ResolvedEnv, config.AuthParams, identity, ignoredMetadata...), nil
+}
+
 // GenerateMetricNameWithIndex helps adding the index prefix to the metric name
 func GenerateMetricNameWithIndex(scalerIndex int, metricName string) string {
 	return fmt.Sprintf("s%d-%s", scalerIndex, metricName)
 }
 
+// triggerNameInvalidChars are characters of trigger names replaced by SanitizeTriggerName
+var triggerNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// triggerNameSeparator separates the trigger name from the rest of the metric name,
+// it can't appear in a sanitized trigger name, so metric names of distinct sanitized trigger names never collide
+const triggerNameSeparator = "."
+
+// SanitizeTriggerName returns the trigger name as it's used in metric names, characters other than letters, digits,
+// underscores and hyphens are replaced by hyphens, trigger names of a scalable object must differ once they are sanitized
+func SanitizeTriggerName(triggerName string) string {
+	return triggerNameInvalidChars.ReplaceAllString(triggerName, "-")
+}
+
+// GenerateMetricNameWithTriggerName replaces the index prefix of the metric name of a named trigger by the name of the trigger,
+// eg. s1-rabbitmq-orders of trigger orders-queue becomes s.orders-queue.rabbitmq-orders, so the metric name doesn't change
+// once the triggers are reordered. Metric names of unnamed triggers and the ones without the index prefix are kept.
+func GenerateMetricNameWithTriggerName(scalerIndex int, triggerName, metricName string) string {
+	indexPrefix := fmt.Sprintf("s%d-", scalerIndex)
+	if triggerName == "" || !strings.HasPrefix(metricName, indexPrefix) {
+		return metricName
+	}
+	return triggerNamePrefix(triggerName) + strings.TrimPrefix(metricName, indexPrefix)
+}
+
+// RemoveTriggerNameFromMetricName reverts GenerateMetricNameWithTriggerName, it returns the metric name of the scaler
+func RemoveTriggerNameFromMetricName(scalerIndex int, triggerName, metricName string) string {
+	namePrefix := triggerNamePrefix(triggerName)
+	if triggerName == "" || !strings.HasPrefix(metricName, namePrefix) {
+		return metricName
+	}
+	return fmt.Sprintf("s%d-%s", scalerIndex, strings.TrimPrefix(metricName, namePrefix))
+}
+
+// triggerNamePrefix never matches the index prefix, the index follows s right away
+func triggerNamePrefix(triggerName string) string {
+	return "s" + triggerNameSeparator + SanitizeTriggerName(triggerName) + triggerNameSeparator
+}
+
 // RemoveIndexFromMetricName removes the index prefix from the metric name
 func RemoveIndexFromMetricName(scalerIndex int, metricName string) (string, error) {
 	metricNameSplit := strings.SplitN(metricName, "-", 2)
@@ -157,8 +286,10 @@ func GetMetricTargetType(config *ScalerConfig) (v2.MetricTargetType, error) {
 	case "":
 		// Use AverageValue if no metric type was provided
 		return v2.AverageValueMetricType, nil
-	default:
+	case v2.AverageValueMetricType, v2.ValueMetricType:
 		return config.MetricType, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrScalerUnsupportedMetricType, config.MetricType)
 	}
 }
 
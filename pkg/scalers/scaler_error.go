@@ -0,0 +1,106 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ScalerErrorCategory tells users what kind of failure a scaler hit (eg. a rejected credential or an unreachable source)
+// without reading the logs of KEDA, it's reported in the health status of triggers, events and Prometheus metrics
+type ScalerErrorCategory string
+
+const (
+	// ScalerErrorCategoryAuth is for credentials rejected by the source or missing permissions
+	ScalerErrorCategoryAuth ScalerErrorCategory = "Auth"
+	// ScalerErrorCategoryNetwork is for sources which can't be reached, eg. DNS failures, refused connections or timeouts
+	ScalerErrorCategoryNetwork ScalerErrorCategory = "Network"
+	// ScalerErrorCategoryThrottling is for requests rejected by the source because of rate limits
+	ScalerErrorCategoryThrottling ScalerErrorCategory = "Throttling"
+	// ScalerErrorCategoryBadQuery is for queries or metadata rejected by the source, eg. unknown metrics or queues
+	ScalerErrorCategoryBadQuery ScalerErrorCategory = "BadQuery"
+	// ScalerErrorCategoryInternal is for all other errors
+	ScalerErrorCategoryInternal ScalerErrorCategory = "Internal"
+)
+
+// ScalerError is an error returned by a scaler together with its category
+type ScalerError struct {
+	Category ScalerErrorCategory
+	Err      error
+}
+
+func (e *ScalerError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ScalerError) Unwrap() error {
+	return e.Err
+}
+
+// NewScalerError returns the error with the category
+func NewScalerError(category ScalerErrorCategory, err error) error {
+	return &ScalerError{Category: category, Err: err}
+}
+
+// NewHTTPStatusError returns the error with the category matching the HTTP status code returned by the source
+func NewHTTPStatusError(statusCode int, err error) error {
+	return NewScalerError(getHTTPStatusErrorCategory(statusCode), err)
+}
+
+func getHTTPStatusErrorCategory(statusCode int) ScalerErrorCategory {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ScalerErrorCategoryAuth
+	case statusCode == http.StatusTooManyRequests:
+		return ScalerErrorCategoryThrottling
+	case statusCode == http.StatusBadGateway || statusCode == http.StatusServiceUnavailable || statusCode == http.StatusGatewayTimeout:
+		return ScalerErrorCategoryNetwork
+	case statusCode >= 400 && statusCode < 500:
+		return ScalerErrorCategoryBadQuery
+	default:
+		return ScalerErrorCategoryInternal
+	}
+}
+
+// GetScalerErrorCategory returns the category of the error, errors without category are categorized by their type,
+// eg. errors of the HTTP client or gRPC status errors, empty category is returned for nil error
+func GetScalerErrorCategory(err error) ScalerErrorCategory {
+	if err == nil {
+		return ""
+	}
+
+	var scalerErr *ScalerError
+	if errors.As(err, &scalerErr) {
+		return scalerErr.Category
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return ScalerErrorCategoryNetwork
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unauthenticated, codes.PermissionDenied:
+			return ScalerErrorCategoryAuth
+		case codes.ResourceExhausted:
+			return ScalerErrorCategoryThrottling
+		case codes.Unavailable, codes.DeadlineExceeded:
+			return ScalerErrorCategoryNetwork
+		case codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition, codes.OutOfRange:
+			return ScalerErrorCategoryBadQuery
+		}
+	}
+
+	return ScalerErrorCategoryInternal
+}
+
+// GetScalerErrorMessage returns the message of the error prefixed by its category, eg. for events of failed scalers
+func GetScalerErrorMessage(err error) string {
+	return fmt.Sprintf("%s: %s", GetScalerErrorCategory(err), err)
+}
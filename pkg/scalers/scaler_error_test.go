@@ -0,0 +1,56 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type scalerErrorCategoryTestData struct {
+	name     string
+	err      error
+	category ScalerErrorCategory
+}
+
+var scalerErrorCategoryTestDataset = []scalerErrorCategoryTestData{
+	{"nil error", nil, ""},
+	{"unauthorized", NewHTTPStatusError(http.StatusUnauthorized, errors.New("error")), ScalerErrorCategoryAuth},
+	{"forbidden", NewHTTPStatusError(http.StatusForbidden, errors.New("error")), ScalerErrorCategoryAuth},
+	{"too many requests", NewHTTPStatusError(http.StatusTooManyRequests, errors.New("error")), ScalerErrorCategoryThrottling},
+	{"bad request", NewHTTPStatusError(http.StatusBadRequest, errors.New("error")), ScalerErrorCategoryBadQuery},
+	{"not found", NewHTTPStatusError(http.StatusNotFound, errors.New("error")), ScalerErrorCategoryBadQuery},
+	{"service unavailable", NewHTTPStatusError(http.StatusServiceUnavailable, errors.New("error")), ScalerErrorCategoryNetwork},
+	{"internal server error", NewHTTPStatusError(http.StatusInternalServerError, errors.New("error")), ScalerErrorCategoryInternal},
+	{"wrapped scaler error", fmt.Errorf("error getting metric: %w", NewScalerError(ScalerErrorCategoryThrottling, errors.New("error"))), ScalerErrorCategoryThrottling},
+	{"dns error", fmt.Errorf("error requesting metric: %w", &net.DNSError{Err: "no such host", Name: "prometheus"}), ScalerErrorCategoryNetwork},
+	{"deadline exceeded", fmt.Errorf("error requesting metric: %w", context.DeadlineExceeded), ScalerErrorCategoryNetwork},
+	{"grpc unauthenticated", status.Error(codes.Unauthenticated, "error"), ScalerErrorCategoryAuth},
+	{"grpc resource exhausted", status.Error(codes.ResourceExhausted, "error"), ScalerErrorCategoryThrottling},
+	{"grpc unavailable", status.Error(codes.Unavailable, "error"), ScalerErrorCategoryNetwork},
+	{"grpc invalid argument", status.Error(codes.InvalidArgument, "error"), ScalerErrorCategoryBadQuery},
+	{"grpc internal", status.Error(codes.Internal, "error"), ScalerErrorCategoryInternal},
+	{"other error", errors.New("error"), ScalerErrorCategoryInternal},
+}
+
+func TestGetScalerErrorCategory(t *testing.T) {
+	for _, testData := range scalerErrorCategoryTestDataset {
+		category := GetScalerErrorCategory(testData.err)
+		if category != testData.category {
+			t.Errorf("%s: expected category %q but got %q", testData.name, testData.category, category)
+		}
+	}
+}
+
+func TestGetScalerErrorMessage(t *testing.T) {
+	err := NewHTTPStatusError(http.StatusUnauthorized, errors.New("prometheus query api returned error. status: 401"))
+	expected := "Auth: prometheus query api returned error. status: 401"
+	if message := GetScalerErrorMessage(err); message != expected {
+		t.Errorf("expected message %q but got %q", expected, message)
+	}
+}
@@ -2,6 +2,7 @@ package scalers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -33,6 +34,12 @@ func TestGetMetricTargetType(t *testing.T) {
 			wantmetricType: v2.ValueMetricType,
 			wantErr:        nil,
 		},
+		{
+			name:           "unknown metric type",
+			config:         &ScalerConfig{MetricType: "Average"},
+			wantmetricType: "",
+			wantErr:        ErrScalerUnsupportedMetricType,
+		},
 		{
 			name:           "no metric type",
 			config:         &ScalerConfig{},
@@ -121,3 +128,81 @@ func TestRemoveIndexFromMetricName(t *testing.T) {
 		}
 	}
 }
+
+func TestGetMetricsProxyKey(t *testing.T) {
+	key, err := getMetricsProxyKey(&ScalerConfig{TriggerMetadata: map[string]string{"metricName": "cpu"}})
+	assert.NoError(t, err)
+	assert.Empty(t, key)
+
+	key, err = getMetricsProxyKey(&ScalerConfig{TriggerMetadata: map[string]string{"metricName": "cpu", "useMetricsProxy": "false"}})
+	assert.NoError(t, err)
+	assert.Empty(t, key)
+
+	_, err = getMetricsProxyKey(&ScalerConfig{TriggerMetadata: map[string]string{"metricName": "cpu", "useMetricsProxy": "a"}})
+	assert.Error(t, err)
+
+	// triggers with different targets share the query
+	key, err = getMetricsProxyKey(&ScalerConfig{TriggerMetadata: map[string]string{"metricName": "cpu", "targetValue": "5", "useMetricsProxy": "true"}}, "targetValue")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, key)
+	otherKey, err := getMetricsProxyKey(&ScalerConfig{TriggerMetadata: map[string]string{"metricName": "cpu", "targetValue": "10", "useMetricsProxy": "true"}}, "targetValue")
+	assert.NoError(t, err)
+	assert.Equal(t, key, otherKey)
+}
+
+func TestParseTriggerCheckPolicy(t *testing.T) {
+	timeout, retries, err := ParseTriggerCheckPolicy(map[string]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), timeout)
+	assert.Equal(t, 0, retries)
+
+	timeout, retries, err = ParseTriggerCheckPolicy(map[string]string{"checkTimeout": "5s", "checkRetries": "3"})
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, timeout)
+	assert.Equal(t, 3, retries)
+
+	for _, metadata := range []map[string]string{
+		{"checkTimeout": "5"},
+		{"checkTimeout": "-1s"},
+		{"checkRetries": "a"},
+		{"checkRetries": "-1"},
+	} {
+		_, _, err = ParseTriggerCheckPolicy(metadata)
+		assert.Error(t, err, metadata)
+	}
+}
+
+func TestGenerateMetricNameWithTriggerName(t *testing.T) {
+	cases := []struct {
+		name        string
+		scalerIndex int
+		triggerName string
+		metricName  string
+		expected    string
+	}{
+		{name: "named trigger", scalerIndex: 1, triggerName: "orders-queue", metricName: "s1-rabbitmq-orders", expected: "s.orders-queue.rabbitmq-orders"},
+		{name: "unnamed trigger", scalerIndex: 1, triggerName: "", metricName: "s1-rabbitmq-orders", expected: "s1-rabbitmq-orders"},
+		{name: "metric name without index prefix", scalerIndex: 1, triggerName: "orders-queue", metricName: "rabbitmq-orders", expected: "rabbitmq-orders"},
+		{name: "invalid characters of trigger name", scalerIndex: 0, triggerName: "orders queue/eu", metricName: "s0-rabbitmq-orders", expected: "s.orders-queue-eu.rabbitmq-orders"},
+	}
+
+	for _, testCase := range cases {
+		c := testCase
+		t.Run(c.name, func(t *testing.T) {
+			metricName := GenerateMetricNameWithTriggerName(c.scalerIndex, c.triggerName, c.metricName)
+			assert.Equal(t, c.expected, metricName)
+			assert.Equal(t, c.metricName, RemoveTriggerNameFromMetricName(c.scalerIndex, c.triggerName, metricName))
+		})
+	}
+}
+
+func TestGenerateMetricNameWithTriggerNameDoesNotCollide(t *testing.T) {
+	// names of the triggers differ by the position of the hyphen only
+	first := GenerateMetricNameWithTriggerName(0, "a", "s0-b-c")
+	second := GenerateMetricNameWithTriggerName(1, "a-b", "s1-c")
+	assert.NotEqual(t, first, second)
+
+	// metric of the other trigger isn't mapped back to the scaler
+	assert.Equal(t, second, RemoveTriggerNameFromMetricName(0, "a", second))
+	assert.Equal(t, first, RemoveTriggerNameFromMetricName(1, "a-b", first))
+}
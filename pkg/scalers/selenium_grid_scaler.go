@@ -36,6 +36,10 @@ type seleniumGridScalerMetadata struct {
 	unsafeSsl           bool
 	scalerIndex         int
 	platformName        string
+
+	// basic auth
+	username string
+	password string
 }
 
 type seleniumResponse struct {
@@ -89,6 +93,9 @@ func NewSeleniumGridScaler(config *ScalerConfig) (Scaler, error) {
 	}
 
 	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl)
+	if err := setHTTPProxy(httpClient, config); err != nil {
+		return nil, err
+	}
 
 	return &seleniumGridScaler{
 		metricType: metricType,
@@ -127,7 +134,7 @@ func parseSeleniumGridScalerMetadata(config *ScalerConfig) (*seleniumGridScalerM
 	if val, ok := config.TriggerMetadata["activationThreshold"]; ok {
 		activationThreshold, err := strconv.ParseInt(val, 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing unsafeSsl: %w", err)
+			return nil, fmt.Errorf("error parsing activationThreshold: %w", err)
 		}
 		meta.activationThreshold = activationThreshold
 	}
@@ -152,6 +159,13 @@ func parseSeleniumGridScalerMetadata(config *ScalerConfig) (*seleniumGridScalerM
 		meta.platformName = DefaultPlatformName
 	}
 
+	// Selenium Grid can be protected with basic auth
+	meta.username = config.AuthParams["username"]
+	meta.password = config.AuthParams["password"]
+	if meta.username == "" && meta.password != "" {
+		return nil, fmt.Errorf("no username given for basic auth")
+	}
+
 	meta.scalerIndex = config.ScalerIndex
 	return &meta, nil
 }
@@ -200,17 +214,21 @@ func (s *seleniumGridScaler) getSessionsCount(ctx context.Context, logger logr.L
 		return -1, err
 	}
 
+	if s.metadata.username != "" {
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	}
+
 	res, err := s.client.Do(req)
 	if err != nil {
 		return -1, err
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
 		msg := fmt.Sprintf("selenium grid returned %d", res.StatusCode)
 		return -1, errors.New(msg)
 	}
 
-	defer res.Body.Close()
 	b, err := io.ReadAll(res.Body)
 	if err != nil {
 		return -1, err
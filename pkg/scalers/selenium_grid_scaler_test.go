@@ -654,6 +654,47 @@ func Test_parseSeleniumGridScalerMetadata(t *testing.T) {
 				platformName:        "Windows 11",
 			},
 		},
+		{
+			name: "valid basic auth should return metadata",
+			args: args{
+				config: &ScalerConfig{
+					AuthParams: map[string]string{
+						"username": "user",
+						"password": "pass",
+					},
+					TriggerMetadata: map[string]string{
+						"url":         "http://selenium-hub:4444/graphql",
+						"browserName": "chrome",
+					},
+				},
+			},
+			wantErr: false,
+			want: &seleniumGridScalerMetadata{
+				url:                "http://selenium-hub:4444/graphql",
+				browserName:        "chrome",
+				sessionBrowserName: "chrome",
+				targetValue:        1,
+				browserVersion:     "latest",
+				platformName:       "linux",
+				username:           "user",
+				password:           "pass",
+			},
+		},
+		{
+			name: "password without username should throw error",
+			args: args{
+				config: &ScalerConfig{
+					AuthParams: map[string]string{
+						"password": "pass",
+					},
+					TriggerMetadata: map[string]string{
+						"url":         "http://selenium-hub:4444/graphql",
+						"browserName": "chrome",
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
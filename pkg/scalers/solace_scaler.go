@@ -114,7 +114,10 @@ type solaceSEMPMetadata struct {
 // Constructor for SolaceScaler
 func NewSolaceScaler(config *ScalerConfig) (Scaler, error) {
 	// Create HTTP Client
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient, err := newHTTPClientFromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating solace HTTP client: %w", err)
+	}
 
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -0,0 +1,262 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	defaultTemporalNamespace       = "default"
+	defaultTemporalTargetQueueSize = 5
+	temporalQueueTypeWorkflow      = "workflow"
+	temporalQueueTypeActivity      = "activity"
+)
+
+// temporalTaskQueueTypes maps the queue types to the values of the Temporal API
+var temporalTaskQueueTypes = map[string]string{
+	temporalQueueTypeWorkflow: "TASK_QUEUE_TYPE_WORKFLOW",
+	temporalQueueTypeActivity: "TASK_QUEUE_TYPE_ACTIVITY",
+}
+
+type temporalScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *temporalMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type temporalMetadata struct {
+	endpoint                  string
+	namespace                 string
+	taskQueue                 string
+	queueTypes                []string
+	targetQueueSize           int64
+	activationTargetQueueSize int64
+	unsafeSsl                 bool
+	scalerIndex               int
+
+	// auth
+	apiKey string
+	cert   string
+	key    string
+	ca     string
+}
+
+// temporalDescribeTaskQueueResponse is the subset of DescribeTaskQueue response of Temporal HTTP API,
+// stats are reported by newer Temporal versions, older versions report the backlog count hint only
+type temporalDescribeTaskQueueResponse struct {
+	Stats *struct {
+		ApproximateBacklogCount json.Number `json:"approximateBacklogCount"`
+	} `json:"stats"`
+	TaskQueueStatus *struct {
+		BacklogCountHint json.Number `json:"backlogCountHint"`
+	} `json:"taskQueueStatus"`
+}
+
+// NewTemporalScaler creates a new Temporal Scaler
+func NewTemporalScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseTemporalMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing temporal metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl)
+	if meta.cert != "" || meta.ca != "" {
+		tlsConfig, err := kedautil.NewTLSConfig(meta.cert, meta.key, meta.ca, meta.unsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
+	if err := setHTTPProxy(httpClient, config); err != nil {
+		return nil, err
+	}
+
+	return &temporalScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "temporal_scaler"),
+	}, nil
+}
+
+func parseTemporalMetadata(config *ScalerConfig) (*temporalMetadata, error) {
+	meta := temporalMetadata{}
+
+	if val, ok := config.TriggerMetadata["endpoint"]; ok && val != "" {
+		meta.endpoint = strings.TrimSuffix(val, "/")
+	} else {
+		return nil, errors.New("no endpoint given")
+	}
+
+	meta.namespace = defaultTemporalNamespace
+	if val, ok := config.TriggerMetadata["namespace"]; ok && val != "" {
+		meta.namespace = val
+	}
+
+	if val, ok := config.TriggerMetadata["taskQueue"]; ok && val != "" {
+		meta.taskQueue = val
+	} else {
+		return nil, errors.New("no taskQueue given")
+	}
+
+	meta.queueTypes = []string{temporalQueueTypeWorkflow, temporalQueueTypeActivity}
+	if val, ok := config.TriggerMetadata["queueTypes"]; ok && val != "" {
+		meta.queueTypes = nil
+		for _, queueType := range strings.Split(val, ",") {
+			queueType = strings.ToLower(strings.TrimSpace(queueType))
+			if _, ok := temporalTaskQueueTypes[queueType]; !ok {
+				return nil, fmt.Errorf("unsupported queueType %q, must be %s or %s", queueType, temporalQueueTypeWorkflow, temporalQueueTypeActivity)
+			}
+			meta.queueTypes = append(meta.queueTypes, queueType)
+		}
+	}
+
+	meta.targetQueueSize = defaultTemporalTargetQueueSize
+	if val, ok := config.TriggerMetadata["targetQueueSize"]; ok && val != "" {
+		targetQueueSize, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing targetQueueSize: %w", err)
+		}
+		meta.targetQueueSize = targetQueueSize
+	}
+
+	meta.activationTargetQueueSize = 0
+	if val, ok := config.TriggerMetadata["activationTargetQueueSize"]; ok && val != "" {
+		activationTargetQueueSize, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetQueueSize: %w", err)
+		}
+		meta.activationTargetQueueSize = activationTargetQueueSize
+	}
+
+	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok && val != "" {
+		unsafeSsl, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing unsafeSsl: %w", err)
+		}
+		meta.unsafeSsl = unsafeSsl
+	}
+
+	meta.apiKey = config.AuthParams["apiKey"]
+
+	// mTLS
+	meta.cert = config.AuthParams["cert"]
+	meta.key = config.AuthParams["key"]
+	meta.ca = config.AuthParams["ca"]
+	if (meta.cert == "") != (meta.key == "") {
+		return nil, errors.New("both cert and key must be given for mTLS")
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+// getBacklogCount returns the approximate backlog count of the task queue of the queue type
+func (s *temporalScaler) getBacklogCount(ctx context.Context, queueType string) (int64, error) {
+	query := url.Values{}
+	query.Set("taskQueueType", temporalTaskQueueTypes[queueType])
+	query.Set("reportStats", "true")
+	query.Set("includeTaskQueueStatus", "true")
+	requestURL := fmt.Sprintf("%s/api/v1/namespaces/%s/task-queues/%s?%s", s.metadata.endpoint, url.PathEscape(s.metadata.namespace), url.PathEscape(s.metadata.taskQueue), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return -1, err
+	}
+	if s.metadata.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.metadata.apiKey)
+		req.Header.Set("temporal-namespace", s.metadata.namespace)
+	}
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return -1, err
+	}
+	defer r.Body.Close()
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return -1, err
+	}
+	if r.StatusCode != http.StatusOK {
+		return -1, fmt.Errorf("temporal returned error describing task queue. status: %d response: %s", r.StatusCode, string(b))
+	}
+
+	var response temporalDescribeTaskQueueResponse
+	if err := json.Unmarshal(b, &response); err != nil {
+		return -1, fmt.Errorf("error parsing temporal response: %w", err)
+	}
+
+	var count json.Number
+	switch {
+	case response.Stats != nil:
+		count = response.Stats.ApproximateBacklogCount
+	case response.TaskQueueStatus != nil:
+		count = response.TaskQueueStatus.BacklogCountHint
+	}
+	if count == "" {
+		// zero values are omitted from the response
+		return 0, nil
+	}
+	return count.Int64()
+}
+
+// getQueueSize returns the sum of backlog counts of the task queue of all queue types
+func (s *temporalScaler) getQueueSize(ctx context.Context) (int64, error) {
+	var queueSize int64
+	for _, queueType := range s.metadata.queueTypes {
+		count, err := s.getBacklogCount(ctx, queueType)
+		if err != nil {
+			return -1, err
+		}
+		queueSize += count
+	}
+	return queueSize, nil
+}
+
+func (s *temporalScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	queueSize, err := s.getQueueSize(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting temporal task queue backlog")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(queueSize))
+
+	return []external_metrics.ExternalMetricValue{metric}, queueSize > s.metadata.activationTargetQueueSize, nil
+}
+
+func (s *temporalScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("temporal-%s-%s", s.metadata.namespace, s.metadata.taskQueue))),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.targetQueueSize),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *temporalScaler) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,161 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type parseTemporalMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type temporalMetricIdentifier struct {
+	metadataTestData *parseTemporalMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testTemporalMetadata = []parseTemporalMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed
+	{map[string]string{"endpoint": "http://temporal:7243", "namespace": "orders", "taskQueue": "payments", "targetQueueSize": "10", "activationTargetQueueSize": "2"}, map[string]string{}, false},
+	// default namespace
+	{map[string]string{"endpoint": "http://temporal:7243", "taskQueue": "payments"}, map[string]string{}, false},
+	// missing endpoint
+	{map[string]string{"taskQueue": "payments"}, map[string]string{}, true},
+	// missing taskQueue
+	{map[string]string{"endpoint": "http://temporal:7243"}, map[string]string{}, true},
+	// queue types
+	{map[string]string{"endpoint": "http://temporal:7243", "taskQueue": "payments", "queueTypes": "Activity"}, map[string]string{}, false},
+	// invalid queue type
+	{map[string]string{"endpoint": "http://temporal:7243", "taskQueue": "payments", "queueTypes": "nexus"}, map[string]string{}, true},
+	// invalid targetQueueSize
+	{map[string]string{"endpoint": "http://temporal:7243", "taskQueue": "payments", "targetQueueSize": "a"}, map[string]string{}, true},
+	// invalid activationTargetQueueSize
+	{map[string]string{"endpoint": "http://temporal:7243", "taskQueue": "payments", "activationTargetQueueSize": "a"}, map[string]string{}, true},
+	// invalid unsafeSsl
+	{map[string]string{"endpoint": "http://temporal:7243", "taskQueue": "payments", "unsafeSsl": "a"}, map[string]string{}, true},
+	// mTLS
+	{map[string]string{"endpoint": "https://temporal:7243", "taskQueue": "payments"}, map[string]string{"cert": "cert", "key": "key", "ca": "ca"}, false},
+	// cert without key
+	{map[string]string{"endpoint": "https://temporal:7243", "taskQueue": "payments"}, map[string]string{"cert": "cert"}, true},
+	// api key
+	{map[string]string{"endpoint": "https://temporal:7243", "taskQueue": "payments"}, map[string]string{"apiKey": "key"}, false},
+}
+
+var temporalMetricIdentifiers = []temporalMetricIdentifier{
+	{&testTemporalMetadata[1], 0, "s0-temporal-orders-payments"},
+	{&testTemporalMetadata[2], 1, "s1-temporal-default-payments"},
+}
+
+func TestTemporalParseMetadata(t *testing.T) {
+	for _, testData := range testTemporalMetadata {
+		_, err := parseTemporalMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for %v", testData.metadata)
+		}
+	}
+}
+
+func TestTemporalGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range temporalMetricIdentifiers {
+		meta, err := parseTemporalMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockTemporalScaler := temporalScaler{metadata: meta}
+
+		metricSpec := mockTemporalScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestTemporalGetQueueSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/orders/task-queues/payments" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Query().Get("taskQueueType") {
+		case "TASK_QUEUE_TYPE_WORKFLOW":
+			// newer Temporal reports stats, int64 are strings in protojson
+			_, _ = w.Write([]byte(`{"pollers":[],"stats":{"approximateBacklogCount":"7","approximateBacklogAge":"1s"}}`))
+		case "TASK_QUEUE_TYPE_ACTIVITY":
+			// older Temporal reports backlog count hint only
+			_, _ = w.Write([]byte(`{"pollers":[],"taskQueueStatus":{"backlogCountHint":"3"}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		metadata          map[string]string
+		apiKey            string
+		expectedQueueSize int64
+		isError           bool
+	}{
+		{map[string]string{"namespace": "orders", "taskQueue": "payments"}, "secret", 10, false},
+		{map[string]string{"namespace": "orders", "taskQueue": "payments", "queueTypes": "workflow"}, "secret", 7, false},
+		{map[string]string{"namespace": "orders", "taskQueue": "payments", "queueTypes": "activity"}, "secret", 3, false},
+		{map[string]string{"namespace": "orders", "taskQueue": "payments"}, "", -1, true},
+		{map[string]string{"taskQueue": "payments"}, "secret", -1, true},
+	}
+
+	for _, test := range tests {
+		test.metadata["endpoint"] = server.URL
+		meta, err := parseTemporalMetadata(&ScalerConfig{TriggerMetadata: test.metadata, AuthParams: map[string]string{"apiKey": test.apiKey}})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := temporalScaler{metadata: meta, httpClient: http.DefaultClient}
+
+		queueSize, err := scaler.getQueueSize(context.Background())
+		if err != nil && !test.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if test.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+		if queueSize != test.expectedQueueSize {
+			t.Errorf("Expected queue size %d but got %d", test.expectedQueueSize, queueSize)
+		}
+	}
+}
+
+func TestTemporalGetQueueSizeEmptyBacklog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// zero values are omitted in protojson
+		_, _ = w.Write([]byte(`{"pollers":[],"stats":{}}`))
+	}))
+	defer server.Close()
+
+	meta, err := parseTemporalMetadata(&ScalerConfig{TriggerMetadata: map[string]string{"endpoint": server.URL, "taskQueue": "payments"}, AuthParams: map[string]string{}})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := temporalScaler{metadata: meta, httpClient: http.DefaultClient}
+
+	queueSize, err := scaler.getQueueSize(context.Background())
+	if err != nil {
+		t.Error("Expected success but got error", err)
+	}
+	if queueSize != 0 {
+		t.Errorf("Expected empty queue but got %d", queueSize)
+	}
+}
@@ -0,0 +1,97 @@
+package scalers
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// tlsAuthMetadata is the client certificate and the CA certificate given by
+// ca, cert, key and keyPassword parameters of TriggerAuthentication
+type tlsAuthMetadata struct {
+	ca          string
+	cert        string
+	key         string
+	keyPassword string
+}
+
+func parseTLSAuth(authParams map[string]string) (tlsAuthMetadata, error) {
+	meta := tlsAuthMetadata{
+		ca:          authParams["ca"],
+		cert:        authParams["cert"],
+		key:         authParams["key"],
+		keyPassword: authParams["keyPassword"],
+	}
+
+	if meta.cert != "" && meta.key == "" {
+		return meta, errors.New("key must be provided with cert")
+	}
+	if meta.key != "" && meta.cert == "" {
+		return meta, errors.New("cert must be provided with key")
+	}
+	return meta, nil
+}
+
+// isSet returns true if the CA certificate or the client certificate is given
+func (meta tlsAuthMetadata) isSet() bool {
+	return meta.ca != "" || meta.cert != ""
+}
+
+// newTLSConfig returns the TLS config trusting the CA certificate and presenting the client certificate if they're given
+func (meta tlsAuthMetadata) newTLSConfig(unsafeSsl bool) (*tls.Config, error) {
+	return kedautil.NewTLSConfigWithPassword(meta.cert, meta.key, meta.keyPassword, meta.ca, unsafeSsl)
+}
+
+// newHTTPClient returns the HTTP client trusting the CA certificate, in addition to the system certificates and the ones
+// mounted to KEDA, and presenting the client certificate if they're given
+func (meta tlsAuthMetadata) newHTTPClient(timeout time.Duration, unsafeSsl bool) (*http.Client, error) {
+	httpClient := kedautil.CreateHTTPClient(timeout, unsafeSsl)
+	if meta.isSet() {
+		tlsConfig, err := meta.newTLSConfig(unsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
+	return httpClient, nil
+}
+
+// parseUnsafeSsl parses unsafeSsl metadata of the trigger, it skips the verification of the certificates of the source
+func parseUnsafeSsl(metadata map[string]string) (bool, error) {
+	val, ok := metadata["unsafeSsl"]
+	if !ok || val == "" {
+		return false, nil
+	}
+	unsafeSsl, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, fmt.Errorf("error parsing unsafeSsl: %w", err)
+	}
+	return unsafeSsl, nil
+}
+
+// newHTTPClientFromConfig returns the HTTP client of a scaler calling an HTTP API of the source, the certificates of the
+// source are verified by the CA certificate given by ca parameter of TriggerAuthentication, unless unsafeSsl is set,
+// and the requests are routed through the proxy given by proxyURL
+func newHTTPClientFromConfig(config *ScalerConfig) (*http.Client, error) {
+	unsafeSsl, err := parseUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	tlsAuth, err := parseTLSAuth(config.AuthParams)
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := tlsAuth.newHTTPClient(config.GlobalHTTPTimeout, unsafeSsl)
+	if err != nil {
+		return nil, err
+	}
+	if err := setHTTPProxy(httpClient, config); err != nil {
+		return nil, err
+	}
+	return httpClient, nil
+}
@@ -0,0 +1,92 @@
+package scalers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTLSAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		authParams map[string]string
+		isSet      bool
+		isError    bool
+	}{
+		{name: "nothing given", authParams: map[string]string{}},
+		{name: "only ca", authParams: map[string]string{"ca": "caaa"}, isSet: true},
+		{name: "cert and key", authParams: map[string]string{"cert": "ceert", "key": "keey", "keyPassword": "keeyPassword"}, isSet: true},
+		{name: "cert without key", authParams: map[string]string{"cert": "ceert"}, isError: true},
+		{name: "key without cert", authParams: map[string]string{"ca": "caaa", "key": "keey"}, isError: true},
+	}
+	for _, test := range tests {
+		meta, err := parseTLSAuth(test.authParams)
+		if test.isError {
+			assert.Error(t, err, test.name)
+			continue
+		}
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.isSet, meta.isSet(), test.name)
+		assert.Equal(t, test.authParams["keyPassword"], meta.keyPassword, test.name)
+	}
+}
+
+func TestTLSAuthNewTLSConfig(t *testing.T) {
+	meta := tlsAuthMetadata{}
+	config, err := meta.newTLSConfig(true)
+	assert.NoError(t, err)
+	assert.True(t, config.InsecureSkipVerify)
+	assert.Empty(t, config.Certificates)
+
+	meta = tlsAuthMetadata{cert: "ceert", key: "keey"}
+	_, err = meta.newTLSConfig(false)
+	assert.Error(t, err)
+}
+
+func TestParseUnsafeSsl(t *testing.T) {
+	tests := []struct {
+		metadata  map[string]string
+		unsafeSsl bool
+		isError   bool
+	}{
+		{metadata: map[string]string{}},
+		{metadata: map[string]string{"unsafeSsl": ""}},
+		{metadata: map[string]string{"unsafeSsl": "true"}, unsafeSsl: true},
+		{metadata: map[string]string{"unsafeSsl": "false"}},
+		{metadata: map[string]string{"unsafeSsl": "yes"}, isError: true},
+	}
+	for _, test := range tests {
+		unsafeSsl, err := parseUnsafeSsl(test.metadata)
+		if test.isError {
+			assert.Error(t, err, test.metadata)
+			continue
+		}
+		assert.NoError(t, err, test.metadata)
+		assert.Equal(t, test.unsafeSsl, unsafeSsl, test.metadata)
+	}
+}
+
+func TestNewHTTPClientFromConfig(t *testing.T) {
+	client, err := newHTTPClientFromConfig(&ScalerConfig{
+		TriggerMetadata:   map[string]string{"unsafeSsl": "true"},
+		AuthParams:        map[string]string{},
+		GlobalHTTPTimeout: time.Second,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, client.Timeout)
+	assert.True(t, client.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify)
+
+	_, err = newHTTPClientFromConfig(&ScalerConfig{
+		TriggerMetadata: map[string]string{},
+		AuthParams:      map[string]string{"cert": "ceert"},
+	})
+	assert.Error(t, err)
+
+	_, err = newHTTPClientFromConfig(&ScalerConfig{
+		TriggerMetadata: map[string]string{"unsafeSsl": "maybe"},
+		AuthParams:      map[string]string{},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,342 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Sources of values of typed metadata fields
+const (
+	// TriggerMetadataSource is the metadata of the trigger
+	TriggerMetadataSource = "triggerMetadata"
+	// ResolvedEnvSource is the environment variable of the scale target given in <name>FromEnv metadata
+	ResolvedEnvSource = "resolvedEnv"
+	// AuthParamsSource is the parameter given by TriggerAuthentication
+	AuthParamsSource = "authParams"
+)
+
+// Types of values of typed metadata fields, the types of OpenAPI
+const (
+	MetadataFieldTypeString  = "string"
+	MetadataFieldTypeBoolean = "boolean"
+	MetadataFieldTypeInteger = "integer"
+	MetadataFieldTypeNumber  = "number"
+	MetadataFieldTypeArray   = "array"
+)
+
+const kedaTag = "keda"
+
+// MetadataField is the declaration of a metadata field of a scaler, it's declared by keda struct tag
+// of the field of the typed metadata struct, eg.
+//
+//	type exampleMetadata struct {
+//		QueueName   string  `keda:"name=queueName, order=triggerMetadata;resolvedEnv"`
+//		Password    string  `keda:"name=password, order=authParams;triggerMetadata, optional"`
+//		TargetValue float64 `keda:"name=targetValue, order=triggerMetadata, default=5"`
+//		Mode        string  `keda:"name=mode, order=triggerMetadata, default=queue, enum=queue;topic"`
+//	}
+//
+// Options of the tag are separated by commas:
+//   - name: name of the metadata, parameter or <name>FromEnv metadata with the environment variable
+//   - order: sources of the value separated by semicolons, the first given value is used, triggerMetadata by default
+//   - optional: the field isn't required, fields with default are optional too
+//   - default: value of the field if it isn't given
+//   - enum: allowed values separated by semicolons
+//
+// Supported field types are string, bool, int, int64, float64 and []string given as comma separated list.
+type MetadataField struct {
+	Name     string
+	Type     string
+	Order    []string
+	Optional bool
+	Default  string
+	Enum     []string
+}
+
+// typedTriggerMetadata has the typed metadata structs of scalers by trigger type, the declarations of their
+// fields are used to validate triggers in KEDA Admission Webhooks and in the CRDs (see hack/trigger-schema-gen)
+var typedTriggerMetadata = map[string]interface{}{
+	"azure-eventgrid":       azureEventGridMetadata{},
+	"dapr-binding":          daprBindingMetadata{},
+	"http":                  httpMetadata{},
+	"kubernetes-workload":   kubernetesWorkloadMetadata{},
+	"loki":                  lokiMetadata{},
+	"predict":               predictMetadata{},
+	"scaled-object-trigger": scaledObjectTriggerMetadata{},
+}
+
+// TypedConfig parses the metadata of the trigger into the fields of typedConfig declared by keda struct tags,
+// see MetadataField for the options of the tag
+func (c *ScalerConfig) TypedConfig(typedConfig interface{}) error {
+	value := reflect.ValueOf(typedConfig)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("typed config must be a pointer to struct, got %T", typedConfig)
+	}
+	value = value.Elem()
+
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+		tag, ok := structField.Tag.Lookup(kedaTag)
+		if !ok {
+			continue
+		}
+		field, err := parseMetadataField(structField, tag)
+		if err != nil {
+			return err
+		}
+
+		fieldValue, found := c.getMetadataFieldValue(field)
+		if !found {
+			if field.Default != "" {
+				fieldValue = field.Default
+			} else if !field.Optional {
+				return fmt.Errorf("%w: no %s given", ErrScalerConfigMissingField, field.Name)
+			} else {
+				continue
+			}
+		}
+		if err := setMetadataFieldValue(value.Field(i), field, fieldValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ScalerConfig) getMetadataFieldValue(field MetadataField) (string, bool) {
+	for _, source := range field.Order {
+		var value string
+		switch source {
+		case TriggerMetadataSource:
+			value = c.TriggerMetadata[field.Name]
+		case ResolvedEnvSource:
+			if envName := c.TriggerMetadata[field.Name+"FromEnv"]; envName != "" {
+				value = c.ResolvedEnv[envName]
+			}
+		case AuthParamsSource:
+			value = c.AuthParams[field.Name]
+		}
+		if value != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// GetMetadataFields returns the declarations of metadata fields of the typed metadata struct
+func GetMetadataFields(typedConfig interface{}) ([]MetadataField, error) {
+	typ := reflect.TypeOf(typedConfig)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("typed config must be a struct, got %T", typedConfig)
+	}
+
+	var fields []MetadataField
+	for i := 0; i < typ.NumField(); i++ {
+		tag, ok := typ.Field(i).Tag.Lookup(kedaTag)
+		if !ok {
+			continue
+		}
+		field, err := parseMetadataField(typ.Field(i), tag)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// GetTriggerMetadataFields returns the declarations of metadata fields of the trigger type,
+// false is returned if the scaler of the trigger type doesn't have typed metadata
+func GetTriggerMetadataFields(triggerType string) ([]MetadataField, bool, error) {
+	typedConfig, ok := typedTriggerMetadata[triggerType]
+	if !ok {
+		return nil, false, nil
+	}
+	fields, err := GetMetadataFields(typedConfig)
+	return fields, true, err
+}
+
+// GetTypedTriggerTypes returns sorted trigger types of scalers with typed metadata
+func GetTypedTriggerTypes() []string {
+	triggerTypes := make([]string, 0, len(typedTriggerMetadata))
+	for triggerType := range typedTriggerMetadata {
+		triggerTypes = append(triggerTypes, triggerType)
+	}
+	sort.Strings(triggerTypes)
+	return triggerTypes
+}
+
+// ValidateMetadata validates the metadata of a trigger by the declarations of metadata fields without resolving
+// the values, required fields which can be given by TriggerAuthentication or by environment variable aren't checked
+func ValidateMetadata(fields []MetadataField, metadata map[string]string) error {
+	for _, field := range fields {
+		value, given := "", false
+		if field.HasSource(TriggerMetadataSource) {
+			value, given = metadata[field.Name], metadata[field.Name] != ""
+		}
+		if !given {
+			if !field.IsRequired() || (field.HasSource(ResolvedEnvSource) && metadata[field.Name+"FromEnv"] != "") {
+				continue
+			}
+			return fmt.Errorf("%w: no %s given", ErrScalerConfigMissingField, field.Name)
+		}
+		if err := setMetadataFieldValue(reflect.New(metadataFieldGoTypes[field.Type]).Elem(), field, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsRequired returns true if the field must be given in the metadata of the trigger, ie. it isn't optional
+// and it can't be given by TriggerAuthentication
+func (f MetadataField) IsRequired() bool {
+	return !f.Optional && f.Default == "" && !f.HasSource(AuthParamsSource)
+}
+
+// HasSource returns true if the value of the field can be given by the source
+func (f MetadataField) HasSource(source string) bool {
+	for _, s := range f.Order {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// metadataFieldGoTypes are Go types used to validate values of the metadata field types
+var metadataFieldGoTypes = map[string]reflect.Type{
+	MetadataFieldTypeString:  reflect.TypeOf(""),
+	MetadataFieldTypeBoolean: reflect.TypeOf(false),
+	MetadataFieldTypeInteger: reflect.TypeOf(int64(0)),
+	MetadataFieldTypeNumber:  reflect.TypeOf(float64(0)),
+	MetadataFieldTypeArray:   reflect.TypeOf([]string{}),
+}
+
+func getMetadataFieldType(typ reflect.Type) (string, error) {
+	switch typ.Kind() {
+	case reflect.String:
+		return MetadataFieldTypeString, nil
+	case reflect.Bool:
+		return MetadataFieldTypeBoolean, nil
+	case reflect.Int, reflect.Int64:
+		return MetadataFieldTypeInteger, nil
+	case reflect.Float64:
+		return MetadataFieldTypeNumber, nil
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.String {
+			return MetadataFieldTypeArray, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported type %s of typed metadata field", typ)
+}
+
+func parseMetadataField(structField reflect.StructField, tag string) (MetadataField, error) {
+	fieldType, err := getMetadataFieldType(structField.Type)
+	if err != nil {
+		return MetadataField{}, fmt.Errorf("field %s: %w", structField.Name, err)
+	}
+
+	field := MetadataField{Type: fieldType}
+	for _, option := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "name":
+			field.Name = value
+		case "order":
+			field.Order = splitTagValues(value)
+		case "optional":
+			field.Optional = true
+		case "default":
+			field.Default = value
+		case "enum":
+			field.Enum = splitTagValues(value)
+		default:
+			return MetadataField{}, fmt.Errorf("field %s: unknown option %q of keda tag", structField.Name, key)
+		}
+	}
+	if field.Name == "" {
+		return MetadataField{}, fmt.Errorf("field %s: no name given in keda tag", structField.Name)
+	}
+	if len(field.Order) == 0 {
+		field.Order = []string{TriggerMetadataSource}
+	}
+	return field, nil
+}
+
+func splitTagValues(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func setMetadataFieldValue(fieldValue reflect.Value, field MetadataField, value string) error {
+	if len(field.Enum) > 0 {
+		allowed := false
+		for _, enumValue := range field.Enum {
+			if value == enumValue {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%s must be one of %s, got %s", field.Name, strings.Join(field.Enum, ", "), value)
+		}
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %w", field.Name, err)
+		}
+		fieldValue.SetBool(parsed)
+	case reflect.Int, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %w", field.Name, err)
+		}
+		fieldValue.SetInt(parsed)
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %w", field.Name, err)
+		}
+		fieldValue.SetFloat(parsed)
+	case reflect.Slice:
+		var values []string
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		fieldValue.Set(reflect.ValueOf(values))
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTypedMetadata struct {
+	QueueName   string   `keda:"name=queueName, order=triggerMetadata;resolvedEnv"`
+	Password    string   `keda:"name=password, order=authParams;triggerMetadata, optional"`
+	TargetValue float64  `keda:"name=targetValue, order=triggerMetadata, default=5"`
+	Mode        string   `keda:"name=mode, order=triggerMetadata, default=queue, enum=queue;topic"`
+	Enabled     bool     `keda:"name=enabled, order=triggerMetadata, optional"`
+	Count       int      `keda:"name=count, order=triggerMetadata, optional"`
+	Hosts       []string `keda:"name=hosts, order=triggerMetadata, optional"`
+	notTyped    string
+}
+
+func TestTypedConfig(t *testing.T) {
+	config := &ScalerConfig{
+		TriggerMetadata: map[string]string{"queueName": "orders", "password": "metadata", "enabled": "true", "count": "3", "hosts": "a, b,"},
+		AuthParams:      map[string]string{"password": "auth"},
+	}
+	meta := testTypedMetadata{}
+	require.NoError(t, config.TypedConfig(&meta))
+	assert.Equal(t, testTypedMetadata{
+		QueueName:   "orders",
+		Password:    "auth",
+		TargetValue: 5,
+		Mode:        "queue",
+		Enabled:     true,
+		Count:       3,
+		Hosts:       []string{"a", "b"},
+	}, meta)
+
+	// value given by environment variable of the scale target
+	config = &ScalerConfig{
+		TriggerMetadata: map[string]string{"queueNameFromEnv": "QUEUE", "mode": "topic"},
+		ResolvedEnv:     map[string]string{"QUEUE": "payments"},
+	}
+	meta = testTypedMetadata{}
+	require.NoError(t, config.TypedConfig(&meta))
+	assert.Equal(t, "payments", meta.QueueName)
+	assert.Equal(t, "topic", meta.Mode)
+}
+
+func TestTypedConfigErrors(t *testing.T) {
+	err := (&ScalerConfig{TriggerMetadata: map[string]string{}}).TypedConfig(&testTypedMetadata{})
+	assert.True(t, errors.Is(err, ErrScalerConfigMissingField))
+	assert.EqualError(t, err, "missing required field in scaler config: no queueName given")
+
+	err = (&ScalerConfig{TriggerMetadata: map[string]string{"queueName": "orders", "targetValue": "five"}}).TypedConfig(&testTypedMetadata{})
+	assert.ErrorContains(t, err, "error parsing targetValue")
+
+	err = (&ScalerConfig{TriggerMetadata: map[string]string{"queueName": "orders", "mode": "stream"}}).TypedConfig(&testTypedMetadata{})
+	assert.EqualError(t, err, "mode must be one of queue, topic, got stream")
+
+	err = (&ScalerConfig{}).TypedConfig(testTypedMetadata{})
+	assert.EqualError(t, err, "typed config must be a pointer to struct, got scalers.testTypedMetadata")
+}
+
+func TestValidateMetadata(t *testing.T) {
+	fields, err := GetMetadataFields(testTypedMetadata{})
+	require.NoError(t, err)
+	require.Len(t, fields, 7)
+	assert.Equal(t, MetadataField{Name: "queueName", Type: MetadataFieldTypeString, Order: []string{TriggerMetadataSource, ResolvedEnvSource}}, fields[0])
+	assert.True(t, fields[0].IsRequired())
+	assert.False(t, fields[1].IsRequired())
+	assert.Equal(t, MetadataFieldTypeArray, fields[6].Type)
+
+	assert.NoError(t, ValidateMetadata(fields, map[string]string{"queueName": "orders"}))
+	assert.NoError(t, ValidateMetadata(fields, map[string]string{"queueNameFromEnv": "QUEUE"}))
+	assert.EqualError(t, ValidateMetadata(fields, map[string]string{}), "missing required field in scaler config: no queueName given")
+	assert.ErrorContains(t, ValidateMetadata(fields, map[string]string{"queueName": "orders", "count": "1.5"}), "error parsing count")
+	assert.EqualError(t, ValidateMetadata(fields, map[string]string{"queueName": "orders", "mode": "stream"}), "mode must be one of queue, topic, got stream")
+}
+
+// TestTypedTriggerMetadata checks that the keda tags of all typed metadata of scalers are valid
+func TestTypedTriggerMetadata(t *testing.T) {
+	for _, triggerType := range GetTypedTriggerTypes() {
+		fields, typed, err := GetTriggerMetadataFields(triggerType)
+		assert.NoError(t, err, triggerType)
+		assert.True(t, typed, triggerType)
+		assert.NotEmpty(t, fields, triggerType)
+	}
+
+	_, typed, err := GetTriggerMetadataFields("prometheus")
+	assert.NoError(t, err)
+	assert.False(t, typed)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const defaultAdaptivePollingStableChecks = 3
+
+// adaptivePolling computes the polling interval of a scalable object from the metric values observed by its checks,
+// the interval is doubled after every stableChecks checks with stable values up to maxPollingInterval
+// and it's reset to pollingInterval as soon as any of the values changes by more than the tolerance or a check fails
+type adaptivePolling struct {
+	pollingInterval    time.Duration
+	maxPollingInterval time.Duration
+	stableChecks       int
+	tolerance          float64
+
+	interval    time.Duration
+	stableCount int
+	lastValues  map[string]float64
+}
+
+// newAdaptivePolling returns nil if adaptive polling isn't enabled for the scalable object
+func newAdaptivePolling(withTriggers *kedav1alpha1.WithTriggers) *adaptivePolling {
+	config := withTriggers.Spec.AdaptivePolling
+	if config == nil {
+		return nil
+	}
+
+	pollingInterval := withTriggers.GetPollingInterval()
+	maxPollingInterval := time.Second * time.Duration(config.MaxPollingInterval)
+	if maxPollingInterval < pollingInterval {
+		maxPollingInterval = pollingInterval
+	}
+
+	stableChecks := defaultAdaptivePollingStableChecks
+	if config.StableChecks != nil && *config.StableChecks > 0 {
+		stableChecks = int(*config.StableChecks)
+	}
+
+	tolerance := 0.0
+	if config.Tolerance != "" {
+		if value, err := strconv.ParseFloat(config.Tolerance, 64); err == nil && value > 0 {
+			tolerance = value
+		} else {
+			log.Error(err, "invalid adaptivePolling tolerance, metric values have to be equal to be stable", "tolerance", config.Tolerance,
+				"type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
+		}
+	}
+
+	return &adaptivePolling{
+		pollingInterval:    pollingInterval,
+		maxPollingInterval: maxPollingInterval,
+		stableChecks:       stableChecks,
+		tolerance:          tolerance,
+		interval:           pollingInterval,
+	}
+}
+
+// nextInterval returns the polling interval to wait for before the next check,
+// values are the metric values observed by the last check, nil if the check failed
+func (a *adaptivePolling) nextInterval(values map[string]float64) time.Duration {
+	if values == nil || !a.isStable(values) {
+		a.interval = a.pollingInterval
+		a.stableCount = 0
+	} else {
+		a.stableCount++
+		if a.stableCount >= a.stableChecks {
+			a.stableCount = 0
+			a.interval *= 2
+			if a.interval > a.maxPollingInterval {
+				a.interval = a.maxPollingInterval
+			}
+		}
+	}
+	a.lastValues = values
+	return a.interval
+}
+
+// isStable returns whether none of the values changed since the previous check by more than the tolerance
+func (a *adaptivePolling) isStable(values map[string]float64) bool {
+	if a.lastValues == nil || len(values) != len(a.lastValues) {
+		return false
+	}
+	for name, value := range values {
+		lastValue, found := a.lastValues[name]
+		if !found || math.Abs(value-lastValue) > a.tolerance*math.Abs(lastValue) {
+			return false
+		}
+	}
+	return true
+}
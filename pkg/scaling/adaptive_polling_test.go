@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func withAdaptivePolling(pollingInterval int32, config *kedav1alpha1.AdaptivePollingConfig) *kedav1alpha1.WithTriggers {
+	return &kedav1alpha1.WithTriggers{
+		Spec: kedav1alpha1.WithTriggersSpec{
+			PollingInterval: pointer.Int32(pollingInterval),
+			AdaptivePolling: config,
+		},
+	}
+}
+
+func TestAdaptivePollingDisabled(t *testing.T) {
+	assert.Nil(t, newAdaptivePolling(withAdaptivePolling(30, nil)))
+}
+
+func TestAdaptivePollingLengthensIntervalWhileStable(t *testing.T) {
+	adaptive := newAdaptivePolling(withAdaptivePolling(10, &kedav1alpha1.AdaptivePollingConfig{MaxPollingInterval: 60, StableChecks: pointer.Int32(2)}))
+
+	zero := map[string]float64{"s0-metric": 0}
+	expected := []time.Duration{10, 10, 20, 20, 40, 40, 60, 60}
+	for i, interval := range expected {
+		assert.Equal(t, interval*time.Second, adaptive.nextInterval(zero), "check %d", i)
+	}
+
+	// the value starts moving
+	assert.Equal(t, 10*time.Second, adaptive.nextInterval(map[string]float64{"s0-metric": 5}))
+	assert.Equal(t, 10*time.Second, adaptive.nextInterval(map[string]float64{"s0-metric": 5}))
+	assert.Equal(t, 20*time.Second, adaptive.nextInterval(map[string]float64{"s0-metric": 5}))
+
+	// failed check
+	assert.Equal(t, 10*time.Second, adaptive.nextInterval(nil))
+}
+
+func TestAdaptivePollingTolerance(t *testing.T) {
+	adaptive := newAdaptivePolling(withAdaptivePolling(10, &kedav1alpha1.AdaptivePollingConfig{MaxPollingInterval: 60, StableChecks: pointer.Int32(1), Tolerance: "0.1"}))
+
+	assert.Equal(t, 10*time.Second, adaptive.nextInterval(map[string]float64{"s0-metric": 100}))
+	assert.Equal(t, 20*time.Second, adaptive.nextInterval(map[string]float64{"s0-metric": 109}))
+	assert.Equal(t, 10*time.Second, adaptive.nextInterval(map[string]float64{"s0-metric": 130}))
+
+	// any of the metrics changing resets the interval
+	assert.Equal(t, 10*time.Second, adaptive.nextInterval(map[string]float64{"s0-metric": 130, "s1-metric": 1}))
+	assert.Equal(t, 20*time.Second, adaptive.nextInterval(map[string]float64{"s0-metric": 130, "s1-metric": 1}))
+	assert.Equal(t, 10*time.Second, adaptive.nextInterval(map[string]float64{"s0-metric": 130, "s1-metric": 2}))
+}
+
+func TestAdaptivePollingMaxBelowPollingInterval(t *testing.T) {
+	adaptive := newAdaptivePolling(withAdaptivePolling(30, &kedav1alpha1.AdaptivePollingConfig{MaxPollingInterval: 10, StableChecks: pointer.Int32(1)}))
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 30*time.Second, adaptive.nextInterval(map[string]float64{"s0-metric": 0}))
+	}
+}
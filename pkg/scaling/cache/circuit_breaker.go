@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCircuitBreakerFailureThreshold is the default number of consecutive failures of a scaler opening its circuit
+	DefaultCircuitBreakerFailureThreshold = 5
+	// DefaultCircuitBreakerMaxOpenPeriod is the default longest period between probes of a scaler with open circuit
+	DefaultCircuitBreakerMaxOpenPeriod = 10 * time.Minute
+
+	// circuitInitialOpenPeriod is the period before the first probe of a scaler with open circuit,
+	// it's doubled by each failed probe up to the max open period
+	circuitInitialOpenPeriod = 30 * time.Second
+)
+
+// ErrScalerCircuitOpen is returned instead of querying a scaler with open circuit
+var ErrScalerCircuitOpen = errors.New("scaler circuit is open after consecutive failures")
+
+// circuitBreakerConfig is set by ConfigureCircuitBreaker on start of KEDA Operator
+var circuitBreakerConfig = struct {
+	failureThreshold int
+	maxOpenPeriod    time.Duration
+}{
+	failureThreshold: DefaultCircuitBreakerFailureThreshold,
+	maxOpenPeriod:    DefaultCircuitBreakerMaxOpenPeriod,
+}
+
+// ConfigureCircuitBreaker sets the number of consecutive failures of a scaler opening its circuit and the longest period
+// between probes of the scaler, circuits are disabled if the failure threshold isn't positive
+func ConfigureCircuitBreaker(failureThreshold int, maxOpenPeriod time.Duration) {
+	if maxOpenPeriod < circuitInitialOpenPeriod {
+		maxOpenPeriod = circuitInitialOpenPeriod
+	}
+	circuitBreakerConfig.failureThreshold = failureThreshold
+	circuitBreakerConfig.maxOpenPeriod = maxOpenPeriod
+}
+
+// circuit tracks consecutive failures of a scaler, once they reach the threshold the circuit opens and the scaler
+// isn't queried until a probe is allowed, the period between probes grows exponentially while they fail
+type circuit struct {
+	mutex      sync.Mutex
+	failures   int
+	openPeriod time.Duration
+	openUntil  time.Time
+}
+
+func (c *circuit) isOpen() bool {
+	return circuitBreakerConfig.failureThreshold > 0 && c.failures >= circuitBreakerConfig.failureThreshold
+}
+
+// allow returns whether the scaler could be queried, a probe of open circuit postpones the next one
+// so concurrent requests don't probe the scaler at once
+func (c *circuit) allow(now time.Time) (bool, time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.isOpen() {
+		return true, time.Time{}
+	}
+	if now.Before(c.openUntil) {
+		return false, c.openUntil
+	}
+	c.openUntil = now.Add(c.openPeriod)
+	return true, time.Time{}
+}
+
+// record counts the result of a query of the scaler and returns whether it opened the circuit
+func (c *circuit) record(now time.Time, err error) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err == nil {
+		c.failures = 0
+		c.openPeriod = 0
+		return false
+	}
+
+	wasOpen := c.isOpen()
+	c.failures++
+	if !c.isOpen() {
+		return false
+	}
+	if c.openPeriod == 0 {
+		c.openPeriod = circuitInitialOpenPeriod
+	} else if c.openPeriod *= 2; c.openPeriod > circuitBreakerConfig.maxOpenPeriod {
+		c.openPeriod = circuitBreakerConfig.maxOpenPeriod
+	}
+	c.openUntil = now.Add(c.openPeriod)
+	return !wasOpen
+}
+
+func (c *ScalersCache) getCircuit(index int) *circuit {
+	cb, _ := c.circuits.LoadOrStore(index, &circuit{})
+	return cb.(*circuit)
+}
+
+// GetOpenCircuits returns the sorted indexes of scalers with open circuit
+func (c *ScalersCache) GetOpenCircuits() []int {
+	var indexes []int
+	c.circuits.Range(func(key, value interface{}) bool {
+		cb := value.(*circuit)
+		cb.mutex.Lock()
+		defer cb.mutex.Unlock()
+		if cb.isOpen() {
+			indexes = append(indexes, key.(int))
+		}
+		return true
+	})
+	sort.Ints(indexes)
+	return indexes
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+func TestCircuitOpensAfterConsecutiveFailures(t *testing.T) {
+	defer ConfigureCircuitBreaker(DefaultCircuitBreakerFailureThreshold, DefaultCircuitBreakerMaxOpenPeriod)
+	ConfigureCircuitBreaker(2, time.Minute)
+
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	metricValue := scalers.GenerateMetricInMili("metric-name", 1)
+
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler: scaler,
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return scaler, &scalers.ScalerConfig{}, nil
+			},
+		}},
+	}
+	scaler.EXPECT().Close(gomock.Any()).AnyTimes()
+
+	// each failing check queries the scaler and the refreshed scaler
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "metric-name").Return(nil, false, fmt.Errorf("access denied")).Times(4)
+	for i := 0; i < 2; i++ {
+		_, _, _, err := cache.GetMetricsAndActivityForScaler(context.Background(), 0, "metric-name")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrScalerCircuitOpen)
+	}
+	assert.Equal(t, []int{0}, cache.GetOpenCircuits())
+
+	// the scaler isn't queried while the circuit is open
+	_, _, _, err := cache.GetMetricsAndActivityForScaler(context.Background(), 0, "metric-name")
+	assert.ErrorIs(t, err, ErrScalerCircuitOpen)
+
+	// a failed probe doubles the period before the next one
+	circuit := cache.getCircuit(0)
+	circuit.openUntil = time.Now()
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "metric-name").Return(nil, false, fmt.Errorf("access denied")).Times(2)
+	_, _, _, err = cache.GetMetricsAndActivityForScaler(context.Background(), 0, "metric-name")
+	assert.Error(t, err)
+	assert.Equal(t, 2*circuitInitialOpenPeriod, circuit.openPeriod)
+	_, _, _, err = cache.GetMetricsAndActivityForScaler(context.Background(), 0, "metric-name")
+	assert.ErrorIs(t, err, ErrScalerCircuitOpen)
+
+	// a successful probe closes the circuit
+	circuit.openUntil = time.Now()
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "metric-name").Return([]external_metrics.ExternalMetricValue{metricValue}, true, nil)
+	_, isActive, _, err := cache.GetMetricsAndActivityForScaler(context.Background(), 0, "metric-name")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+	assert.Empty(t, cache.GetOpenCircuits())
+}
+
+func TestCircuitOpenEventReportsTriggerIndex(t *testing.T) {
+	defer ConfigureCircuitBreaker(DefaultCircuitBreakerFailureThreshold, DefaultCircuitBreakerMaxOpenPeriod)
+	ConfigureCircuitBreaker(1, time.Minute)
+
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	recorder := record.NewFakeRecorder(1)
+
+	// the first trigger is disabled, the only scaler in the cache belongs to the second trigger
+	config := scalers.ScalerConfig{ScalerIndex: 1}
+	cache := ScalersCache{
+		ScaledObject: &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}},
+		Recorder:     recorder,
+		Scalers: []ScalerBuilder{{
+			Scaler:       scaler,
+			ScalerConfig: config,
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return scaler, &config, nil
+			},
+		}},
+	}
+	scaler.EXPECT().Close(gomock.Any()).AnyTimes()
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "metric-name").Return(nil, false, fmt.Errorf("access denied")).Times(2)
+
+	_, _, _, err := cache.GetMetricsAndActivityForScaler(context.Background(), 0, "metric-name")
+	assert.Error(t, err)
+	assert.Equal(t, "Warning KEDAScalerCircuitOpen Checks of trigger 1 are skipped after consecutive failures", <-recorder.Events)
+}
+
+func TestCircuitOpenPeriodIsCapped(t *testing.T) {
+	defer ConfigureCircuitBreaker(DefaultCircuitBreakerFailureThreshold, DefaultCircuitBreakerMaxOpenPeriod)
+	ConfigureCircuitBreaker(1, time.Minute)
+
+	c := circuit{}
+	now := time.Now()
+	assert.True(t, c.record(now, fmt.Errorf("error")))
+	assert.Equal(t, circuitInitialOpenPeriod, c.openPeriod)
+	for i := 0; i < 5; i++ {
+		assert.False(t, c.record(now, fmt.Errorf("error")))
+	}
+	assert.Equal(t, time.Minute, c.openPeriod)
+
+	allowed, _ := c.allow(now)
+	assert.False(t, allowed)
+	allowed, _ = c.allow(now.Add(time.Minute))
+	assert.True(t, allowed)
+	// concurrent requests don't probe the scaler at once
+	allowed, _ = c.allow(now.Add(time.Minute))
+	assert.False(t, allowed)
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	defer ConfigureCircuitBreaker(DefaultCircuitBreakerFailureThreshold, DefaultCircuitBreakerMaxOpenPeriod)
+	ConfigureCircuitBreaker(0, time.Minute)
+
+	c := circuit{}
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		assert.False(t, c.record(now, fmt.Errorf("error")))
+		allowed, _ := c.allow(now)
+		assert.True(t, allowed)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+var maintenanceWindowParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// ValidateMaintenanceWindows returns an error if any of the trigger maintenance windows is invalid
+func ValidateMaintenanceWindows(windows []kedav1alpha1.MaintenanceWindow) error {
+	for i, window := range windows {
+		if _, _, _, err := parseMaintenanceWindow(window); err != nil {
+			return fmt.Errorf("error parsing maintenance window %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func parseMaintenanceWindow(window kedav1alpha1.MaintenanceWindow) (cron.Schedule, cron.Schedule, *time.Location, error) {
+	if window.Start == window.End {
+		return nil, nil, nil, fmt.Errorf("start and end can not have exactly same time input")
+	}
+	start, err := maintenanceWindowParser.Parse(window.Start)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error parsing start schedule: %w", err)
+	}
+	end, err := maintenanceWindowParser.Parse(window.End)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error parsing end schedule: %w", err)
+	}
+	location := time.UTC
+	if window.Timezone != "" {
+		location, err = time.LoadLocation(window.Timezone)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to load timezone: %w", err)
+		}
+	}
+	return start, end, location, nil
+}
+
+// isInMaintenanceWindow returns whether the time is within any of the maintenance windows,
+// which is when the window ends sooner than it starts again
+func isInMaintenanceWindow(windows []kedav1alpha1.MaintenanceWindow, now time.Time) bool {
+	for _, window := range windows {
+		start, end, location, err := parseMaintenanceWindow(window)
+		if err != nil {
+			// windows are validated when the scaler is built
+			log.Error(err, "error parsing maintenance window")
+			continue
+		}
+		now := now.In(location)
+		if end.Next(now).Before(start.Next(now)) {
+			return true
+		}
+	}
+	return false
+}
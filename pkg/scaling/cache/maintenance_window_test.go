@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+func TestValidateMaintenanceWindows(t *testing.T) {
+	assert.NoError(t, ValidateMaintenanceWindows(nil))
+	assert.NoError(t, ValidateMaintenanceWindows([]kedav1alpha1.MaintenanceWindow{{Start: "0 1 * * *", End: "0 3 * * *", Timezone: "Europe/Prague"}}))
+	assert.Error(t, ValidateMaintenanceWindows([]kedav1alpha1.MaintenanceWindow{{Start: "0 1 * * *", End: "0 1 * * *"}}))
+	assert.Error(t, ValidateMaintenanceWindows([]kedav1alpha1.MaintenanceWindow{{Start: "invalid", End: "0 3 * * *"}}))
+	assert.Error(t, ValidateMaintenanceWindows([]kedav1alpha1.MaintenanceWindow{{Start: "0 1 * * *", End: "invalid"}}))
+	assert.Error(t, ValidateMaintenanceWindows([]kedav1alpha1.MaintenanceWindow{{Start: "0 1 * * *", End: "0 3 * * *", Timezone: "Invalid/Zone"}}))
+}
+
+func TestIsInMaintenanceWindow(t *testing.T) {
+	nightly := []kedav1alpha1.MaintenanceWindow{{Start: "0 1 * * *", End: "0 3 * * *"}}
+	overMidnight := []kedav1alpha1.MaintenanceWindow{{Start: "0 22 * * *", End: "0 2 * * *"}}
+	prague := []kedav1alpha1.MaintenanceWindow{{Start: "0 1 * * *", End: "0 3 * * *", Timezone: "Europe/Prague"}}
+
+	tests := []struct {
+		name     string
+		windows  []kedav1alpha1.MaintenanceWindow
+		now      time.Time
+		expected bool
+	}{
+		{"no windows", nil, time.Date(2023, 3, 1, 2, 0, 0, 0, time.UTC), false},
+		{"before window", nightly, time.Date(2023, 3, 1, 0, 59, 0, 0, time.UTC), false},
+		{"in window", nightly, time.Date(2023, 3, 1, 2, 0, 0, 0, time.UTC), true},
+		{"after window", nightly, time.Date(2023, 3, 1, 3, 0, 0, 0, time.UTC), false},
+		{"in window over midnight", overMidnight, time.Date(2023, 3, 1, 1, 0, 0, 0, time.UTC), true},
+		{"out of window over midnight", overMidnight, time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC), false},
+		{"in window in timezone", prague, time.Date(2023, 3, 1, 1, 0, 0, 0, time.UTC), true},
+		{"out of window in timezone", prague, time.Date(2023, 3, 1, 2, 0, 0, 0, time.UTC), false},
+		{"any of windows", append(nightly, overMidnight...), time.Date(2023, 3, 1, 23, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, isInMaintenanceWindow(test.windows, test.now), test.name)
+	}
+}
+
+func TestGetMetricsAndActivityForScalerInMaintenanceWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+
+	// the window is open from the beginning of the current hour for two hours, the scaler isn't called
+	hour := time.Now().UTC().Hour()
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler: scaler,
+			ScalerConfig: scalers.ScalerConfig{
+				TriggerMaintenanceWindows: []kedav1alpha1.MaintenanceWindow{{Start: fmt.Sprintf("0 %d * * *", hour), End: fmt.Sprintf("0 %d * * *", (hour+2)%24)}},
+			},
+		}},
+	}
+
+	metrics, isActive, latency, err := cache.GetMetricsAndActivityForScaler(context.Background(), 0, "s0-metric")
+	assert.NoError(t, err)
+	assert.False(t, isActive)
+	assert.Equal(t, int64(-1), latency)
+	assert.Len(t, metrics, 1)
+	assert.Equal(t, "s0-metric", metrics[0].MetricName)
+	assert.Equal(t, int64(0), metrics[0].Value.MilliValue())
+}
@@ -2,6 +2,7 @@ package metricscache
 
 import (
 	"sync"
+	"time"
 
 	"k8s.io/metrics/pkg/apis/external_metrics"
 )
@@ -14,12 +15,15 @@ type MetricsRecord struct {
 
 type MetricsCache struct {
 	metricRecords map[string]map[string]MetricsRecord
-	lock          *sync.RWMutex
+	// storedAt is when the records of a ScaledObject were stored
+	storedAt map[string]time.Time
+	lock     *sync.RWMutex
 }
 
 func NewMetricsCache() MetricsCache {
 	return MetricsCache{
 		metricRecords: map[string]map[string]MetricsRecord{},
+		storedAt:      map[string]time.Time{},
 		lock:          &sync.RWMutex{},
 	}
 }
@@ -32,14 +36,35 @@ func (mc *MetricsCache) ReadRecord(scaledObjectIdentifier, metricName string) (M
 	return record, ok
 }
 
+// ReadFreshRecord returns the record like ReadRecord, unless the records of the ScaledObject are older than maxAge
+func (mc *MetricsCache) ReadFreshRecord(scaledObjectIdentifier, metricName string, maxAge time.Duration) (MetricsRecord, bool) {
+	mc.lock.RLock()
+	defer mc.lock.RUnlock()
+	if time.Since(mc.storedAt[scaledObjectIdentifier]) > maxAge {
+		return MetricsRecord{}, false
+	}
+	record, ok := mc.metricRecords[scaledObjectIdentifier][metricName]
+
+	return record, ok
+}
+
+// ReadRecords returns the records of all metrics of the ScaledObject by metric name
+func (mc *MetricsCache) ReadRecords(scaledObjectIdentifier string) map[string]MetricsRecord {
+	mc.lock.RLock()
+	defer mc.lock.RUnlock()
+	return mc.metricRecords[scaledObjectIdentifier]
+}
+
 func (mc *MetricsCache) StoreRecords(scaledObjectIdentifier string, metricsRecords map[string]MetricsRecord) {
 	mc.lock.Lock()
 	defer mc.lock.Unlock()
 	mc.metricRecords[scaledObjectIdentifier] = metricsRecords
+	mc.storedAt[scaledObjectIdentifier] = time.Now()
 }
 
 func (mc *MetricsCache) Delete(scaledObjectIdentifier string) {
 	mc.lock.Lock()
 	defer mc.lock.Unlock()
 	delete(mc.metricRecords, scaledObjectIdentifier)
+	delete(mc.storedAt, scaledObjectIdentifier)
 }
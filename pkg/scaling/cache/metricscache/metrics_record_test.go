@@ -0,0 +1,25 @@
+package metricscache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFreshRecord(t *testing.T) {
+	cache := NewMetricsCache()
+	cache.StoreRecords("so", map[string]MetricsRecord{"metric": {IsActive: true}})
+
+	record, found := cache.ReadFreshRecord("so", "metric", time.Minute)
+	assert.True(t, found)
+	assert.True(t, record.IsActive)
+
+	// records of a stalled scale loop are stale
+	cache.storedAt["so"] = time.Now().Add(-2 * time.Minute)
+	_, found = cache.ReadFreshRecord("so", "metric", time.Minute)
+	assert.False(t, found)
+
+	_, found = cache.ReadFreshRecord("other", "metric", time.Minute)
+	assert.False(t, found)
+}
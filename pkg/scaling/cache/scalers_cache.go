@@ -18,28 +18,52 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	v2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventemitter"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/formula"
 	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/telemetry"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 var log = logf.Log.WithName("scalers_cache")
 
+// ScalersCache keeps the scalers of a ScaledObject or ScaledJob, and their connections to the sources, alive across polls,
+// the cache is replaced once the Generation of the object changes and closed once the object is deleted,
+// a failing scaler is rebuilt on its own by refreshScaler, the other scalers keep their connections,
+// the same cache serves the scale loop (IsActive) and the metrics requests of the HPA (GetMetrics)
 type ScalersCache struct {
 	ScaledObject             *kedav1alpha1.ScaledObject
 	Scalers                  []ScalerBuilder
 	ScalableObjectGeneration int64
-	Recorder                 record.EventRecorder
+	// AuthenticationRefs are identifiers of the TriggerAuthentications and ClusterTriggerAuthentications
+	// referenced by the triggers, the cache is replaced once one of them changes
+	AuthenticationRefs []string
+	Recorder           record.EventRecorder
+	// CheckTimeout bounds the check of a single scaler of a ScaledJob, the checkTimeout of the trigger overrides it,
+	// zero means the check isn't limited
+	CheckTimeout time.Duration
+
+	// scalersLock guards Scalers, the scalers are checked concurrently and a failing one is replaced by refreshScaler
+	scalersLock sync.RWMutex
+	// circuits track consecutive failures of the scalers, keyed by their index
+	circuits sync.Map
 }
 
 type ScalerBuilder struct {
@@ -48,11 +72,29 @@ type ScalerBuilder struct {
 	Factory      func() (scalers.Scaler, *scalers.ScalerConfig, error)
 }
 
+// getScalerBuilders returns a snapshot of the scalers stored in the cache
+func (c *ScalersCache) getScalerBuilders() []ScalerBuilder {
+	c.scalersLock.RLock()
+	defer c.scalersLock.RUnlock()
+	return append([]ScalerBuilder(nil), c.Scalers...)
+}
+
+// getScalerBuilder returns the scaler with the index stored in the cache
+func (c *ScalersCache) getScalerBuilder(index int) (ScalerBuilder, error) {
+	c.scalersLock.RLock()
+	defer c.scalersLock.RUnlock()
+	if index < 0 || index >= len(c.Scalers) {
+		return ScalerBuilder{}, fmt.Errorf("scaler with id %d not found. Len = %d", index, len(c.Scalers))
+	}
+	return c.Scalers[index], nil
+}
+
 // GetScalers returns array of scalers and scaler config stored in the cache
 func (c *ScalersCache) GetScalers() ([]scalers.Scaler, []scalers.ScalerConfig) {
-	scalersList := make([]scalers.Scaler, 0, len(c.Scalers))
-	configsList := make([]scalers.ScalerConfig, 0, len(c.Scalers))
-	for _, s := range c.Scalers {
+	builders := c.getScalerBuilders()
+	scalersList := make([]scalers.Scaler, 0, len(builders))
+	configsList := make([]scalers.ScalerConfig, 0, len(builders))
+	for _, s := range builders {
 		scalersList = append(scalersList, s.Scaler)
 		configsList = append(configsList, s.ScalerConfig)
 	}
@@ -63,7 +105,7 @@ func (c *ScalersCache) GetScalers() ([]scalers.Scaler, []scalers.ScalerConfig) {
 // GetPushScaler returns array of push scalers stored in the cache
 func (c *ScalersCache) GetPushScalers() []scalers.PushScaler {
 	var result []scalers.PushScaler
-	for _, s := range c.Scalers {
+	for _, s := range c.getScalerBuilders() {
 		if ps, ok := s.Scaler.(scalers.PushScaler); ok {
 			result = append(result, ps)
 		}
@@ -73,8 +115,10 @@ func (c *ScalersCache) GetPushScalers() []scalers.PushScaler {
 
 // Close closes all scalers in the cache
 func (c *ScalersCache) Close(ctx context.Context) {
+	c.scalersLock.Lock()
 	scalers := c.Scalers
 	c.Scalers = nil
+	c.scalersLock.Unlock()
 	for _, s := range scalers {
 		err := s.Scaler.Close(ctx)
 		if err != nil {
@@ -86,22 +130,59 @@ func (c *ScalersCache) Close(ctx context.Context) {
 // GetMetricSpecForScaling returns metrics specs for all scalers in the cache
 func (c *ScalersCache) GetMetricSpecForScaling(ctx context.Context) []v2.MetricSpec {
 	var spec []v2.MetricSpec
-	for _, s := range c.Scalers {
-		spec = append(spec, s.Scaler.GetMetricSpecForScaling(ctx)...)
+	for _, s := range c.getScalerBuilders() {
+		spec = append(spec, withTriggerNames(s.ScalerConfig, s.Scaler.GetMetricSpecForScaling(ctx))...)
 	}
 	return spec
 }
 
+// withTriggerNames returns copies of the metric specs of the scaler with external metric names derived from the name
+// of the trigger, see scalers.GenerateMetricNameWithTriggerName, the metrics are queried by these names
+func withTriggerNames(config scalers.ScalerConfig, metricSpecs []v2.MetricSpec) []v2.MetricSpec {
+	if config.TriggerName == "" {
+		return metricSpecs
+	}
+	result := make([]v2.MetricSpec, 0, len(metricSpecs))
+	for _, metricSpec := range metricSpecs {
+		// the specs may be kept by the scaler, they aren't modified
+		spec := metricSpec.DeepCopy()
+		if spec.External != nil {
+			spec.External.Metric.Name = scalers.GenerateMetricNameWithTriggerName(config.ScalerIndex, config.TriggerName, spec.External.Metric.Name)
+		}
+		result = append(result, *spec)
+	}
+	return result
+}
+
+// GetMaxParallelism returns the lowest parallelism limit of partitioned sources of the scalers,
+// 0 if none of the scalers is limited or the limit couldn't be determined
+func (c *ScalersCache) GetMaxParallelism(ctx context.Context) int64 {
+	var maxParallelism int64
+	for _, s := range c.getScalerBuilders() {
+		ps, ok := s.Scaler.(scalers.ParallelismLimitedScaler)
+		if !ok {
+			continue
+		}
+		parallelism, err := ps.GetMaxParallelism(ctx)
+		if err != nil {
+			log.Error(err, "error getting max parallelism of scaler", "scaler", s.ScalerConfig.TriggerName)
+			continue
+		}
+		if parallelism > 0 && (maxParallelism == 0 || parallelism < maxParallelism) {
+			maxParallelism = parallelism
+		}
+	}
+	return maxParallelism
+}
+
 // GetMetricSpecForScalingForScaler returns metrics spec for a scaler identified by the metric name
 func (c *ScalersCache) GetMetricSpecForScalingForScaler(ctx context.Context, index int) ([]v2.MetricSpec, error) {
-	var err error
-
-	scalersList, _ := c.GetScalers()
-	if index < 0 || index >= len(scalersList) {
-		return nil, fmt.Errorf("scaler with id %d not found. Len = %d", index, len(c.Scalers))
+	sb, err := c.getScalerBuilder(index)
+	if err != nil {
+		return nil, err
 	}
 
-	metricSpecs := scalersList[index].GetMetricSpecForScaling(ctx)
+	metricSpecs := withTriggerNames(sb.ScalerConfig, sb.Scaler.GetMetricSpecForScaling(ctx))
 
 	// no metric spec returned for a scaler -> this could signal error during connection to the scaler
 	// usually in case this is an external scaler
@@ -110,7 +191,7 @@ func (c *ScalersCache) GetMetricSpecForScalingForScaler(ctx context.Context, ind
 		var ns scalers.Scaler
 		ns, err = c.refreshScaler(ctx, index)
 		if err == nil {
-			metricSpecs = ns.GetMetricSpecForScaling(ctx)
+			metricSpecs = withTriggerNames(sb.ScalerConfig, ns.GetMetricSpecForScaling(ctx))
 			if len(metricSpecs) < 1 {
 				err = fmt.Errorf("got empty metric spec")
 			}
@@ -120,24 +201,121 @@ func (c *ScalersCache) GetMetricSpecForScalingForScaler(ctx context.Context, ind
 	return metricSpecs, err
 }
 
+// checkRetryInitialBackoff and checkRetryMaxBackoff bound the exponential backoff between retries of failed checks
+// of triggers with checkRetries, the delays are randomized by checkRetryJitter
+const (
+	checkRetryInitialBackoff = 200 * time.Millisecond
+	checkRetryMaxBackoff     = 5 * time.Second
+	checkRetryJitter         = 0.2
+)
+
+// WithCheckTimeout returns the context bounding the check of a scaler by the checkTimeout of its trigger,
+// or by the timeout if the trigger doesn't set any, zero timeout means the check isn't limited
+func WithCheckTimeout(ctx context.Context, timeout time.Duration, config scalers.ScalerConfig) (context.Context, context.CancelFunc) {
+	if config.TriggerCheckTimeout > 0 {
+		timeout = config.TriggerCheckTimeout
+	}
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
 // GetMetricsAndActivityForScaler returns metric value, activity and latency for a scaler identified by the metric name
 // and by the input index (from the list of scalers in this ScaledObject)
+// the check is bounded by the timeout of the trigger and it's retried with exponential backoff by the retries of the trigger,
+// the scaler isn't queried while its circuit is open after consecutive failures
 func (c *ScalersCache) GetMetricsAndActivityForScaler(ctx context.Context, index int, metricName string) ([]external_metrics.ExternalMetricValue, bool, int64, error) {
-	if index < 0 || index >= len(c.Scalers) {
-		return nil, false, -1, fmt.Errorf("scaler with id %d not found. Len = %d", index, len(c.Scalers))
+	sb, err := c.getScalerBuilder(index)
+	if err != nil {
+		return nil, false, -1, err
+	}
+	config := sb.ScalerConfig
+	if isInMaintenanceWindow(config.TriggerMaintenanceWindows, time.Now()) {
+		// the trigger is ignored, zero metric value doesn't add any replicas to the other triggers
+		log.V(1).Info("Trigger is in maintenance window, ignoring it", "scalerIndex", config.ScalerIndex, "metricName", metricName)
+		return []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(metricName, 0)}, false, -1, nil
+	}
+
+	circuit := c.getCircuit(index)
+	if allowed, probeTime := circuit.allow(time.Now()); !allowed {
+		return nil, false, -1, fmt.Errorf("%w, the next probe is at %s", ErrScalerCircuitOpen, probeTime.Format(time.RFC3339))
+	}
+	ctx, span := telemetry.Tracer().Start(ctx, "scaler "+config.TriggerType, trace.WithAttributes(
+		attribute.String("k8s.namespace.name", config.ScalableObjectNamespace),
+		attribute.String("keda.name", config.ScalableObjectName),
+		attribute.String("keda.trigger.type", config.TriggerType),
+		attribute.String("keda.trigger.name", config.TriggerName),
+		attribute.String("keda.metric.name", metricName),
+	))
+	// metrics of named triggers are renamed by the cache, the scaler knows its own metric names only
+	scalerMetricName := scalers.RemoveTriggerNameFromMetricName(config.ScalerIndex, config.TriggerName, metricName)
+	metric, activity, latency, err := c.getMetricsAndActivityWithRetries(ctx, index, sb, scalerMetricName)
+	for i := range metric {
+		if metric[i].MetricName == scalerMetricName {
+			metric[i].MetricName = metricName
+		}
 	}
-	startTime := time.Now()
-	metric, activity, err := c.Scalers[index].Scaler.GetMetricsAndActivity(ctx, metricName)
 	if err == nil {
-		return metric, activity, time.Since(startTime).Milliseconds(), nil
+		span.SetAttributes(attribute.Bool("keda.active", activity))
+	}
+	telemetry.EndSpan(span, err)
+	if circuit.record(time.Now(), err) {
+		log.Info("Opening circuit of the scaler after consecutive failures", "scalerIndex", config.ScalerIndex, "metricName", metricName, "error", err.Error())
+		if c.ScaledObject != nil && c.Recorder != nil {
+			c.Recorder.Eventf(c.ScaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerCircuitOpen, "Checks of trigger %d are skipped after consecutive failures", config.ScalerIndex)
+		}
+	}
+	if err == nil && config.TriggerMetricFormula != nil {
+		metric, err = applyMetricFormula(config.TriggerMetricFormula, metric)
+	}
+	return metric, activity, latency, err
+}
+
+// applyMetricFormula transforms the metric values by the formula of the trigger
+func applyMetricFormula(f *formula.Formula, metrics []external_metrics.ExternalMetricValue) ([]external_metrics.ExternalMetricValue, error) {
+	result := make([]external_metrics.ExternalMetricValue, 0, len(metrics))
+	for _, metric := range metrics {
+		value, err := f.Evaluate(metric.Value.AsApproximateFloat64())
+		if err != nil {
+			return nil, err
+		}
+		metric.Value = *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI)
+		result = append(result, metric)
+	}
+	return result, nil
+}
+
+func (c *ScalersCache) getMetricsAndActivityWithRetries(ctx context.Context, index int, sb ScalerBuilder, metricName string) ([]external_metrics.ExternalMetricValue, bool, int64, error) {
+	config := sb.ScalerConfig
+	if config.TriggerCheckTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.TriggerCheckTimeout)
+		defer cancel()
+	}
+
+	backoff := &kedautil.Backoff{InitialInterval: checkRetryInitialBackoff, MaxInterval: checkRetryMaxBackoff, Jitter: checkRetryJitter}
+	for attempt := 0; ; attempt++ {
+		startTime := time.Now()
+		metric, activity, err := sb.Scaler.GetMetricsAndActivity(ctx, metricName)
+		if err == nil {
+			return metric, activity, time.Since(startTime).Milliseconds(), nil
+		}
+		if attempt >= config.TriggerCheckRetries {
+			break
+		}
+		log.V(1).Info("Error getting metrics of trigger, retrying", "scalerIndex", config.ScalerIndex, "metricName", metricName, "attempt", attempt+1, "error", err.Error())
+		if err := backoff.Wait(ctx); err != nil {
+			return nil, false, -1, err
+		}
 	}
 
 	ns, err := c.refreshScaler(ctx, index)
 	if err != nil {
 		return nil, false, -1, err
 	}
-	startTime = time.Now()
-	metric, activity, err = ns.GetMetricsAndActivity(ctx, metricName)
+	startTime := time.Now()
+	metric, activity, err := ns.GetMetricsAndActivity(ctx, metricName)
 	return metric, activity, time.Since(startTime).Milliseconds(), err
 }
 
@@ -153,7 +331,8 @@ func (c *ScalersCache) IsScaledJobActive(ctx context.Context, scaledJob *kedav1a
 	switch scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation {
 	case "min":
 		for _, metrics := range scalersMetrics {
-			if (queueLength == 0 || metrics.queueLength < queueLength) && metrics.isActive {
+			// the first active scaler sets the minimum, even if its queue length is 0
+			if (!isActive || metrics.queueLength < queueLength) && metrics.isActive {
 				queueLength = metrics.queueLength
 				maxValue = metrics.maxValue
 				isActive = metrics.isActive
@@ -203,26 +382,35 @@ func (c *ScalersCache) IsScaledJobActive(ctx context.Context, scaledJob *kedav1a
 	return isActive, ceilToInt64(queueLength), ceilToInt64(maxValue)
 }
 
+// refreshScaler replaces the scaler with a new one built by its factory, resolving secrets and credentials again,
+// the scaler is closed only once the new one is built, so it isn't torn down if eg. the API server is unavailable,
+// the scaler that is replaced is closed, it could be already a scaler built by a concurrent refresh
 func (c *ScalersCache) refreshScaler(ctx context.Context, id int) (scalers.Scaler, error) {
-	if id < 0 || id >= len(c.Scalers) {
-		return nil, fmt.Errorf("scaler with id %d not found, len = %d, cache has been probably already invalidated", id, len(c.Scalers))
+	sb, err := c.getScalerBuilder(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w, cache has been probably already invalidated", err)
 	}
 
-	sb := c.Scalers[id]
-	defer sb.Scaler.Close(ctx)
 	ns, sConfig, err := sb.Factory()
 	if err != nil {
 		return nil, err
 	}
 
-	if id < 0 || id >= len(c.Scalers) {
-		return nil, fmt.Errorf("scaler with id %d not found, len = %d, cache has been probably already invalidated", id, len(c.Scalers))
+	c.scalersLock.Lock()
+	if id >= len(c.Scalers) {
+		length := len(c.Scalers)
+		c.scalersLock.Unlock()
+		ns.Close(ctx)
+		return nil, fmt.Errorf("scaler with id %d not found, len = %d, cache has been probably already invalidated", id, length)
 	}
+	replaced := c.Scalers[id]
 	c.Scalers[id] = ScalerBuilder{
 		Scaler:       ns,
 		ScalerConfig: *sConfig,
 		Factory:      sb.Factory,
 	}
+	c.scalersLock.Unlock()
+	replaced.Scaler.Close(ctx)
 
 	return ns, nil
 }
@@ -236,66 +424,81 @@ type scalerMetrics struct {
 func (c *ScalersCache) getScaledJobMetrics(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) []scalerMetrics {
 	// TODO this loop should be probably done similar way the ScaledObject loop is done
 	var scalersMetrics []scalerMetrics
-	for i, s := range c.Scalers {
-		var queueLength float64
-		var targetAverageValue float64
-		isActive := false
-		maxValue := float64(0)
-		scalerType := fmt.Sprintf("%T:", s)
+	for i, s := range c.getScalerBuilders() {
+		if sm, ok := c.getScaledJobScalerMetrics(ctx, scaledJob, i, s); ok {
+			scalersMetrics = append(scalersMetrics, sm)
+		}
+	}
+	return scalersMetrics
+}
 
-		scalerLogger := log.WithValues("ScaledJob", scaledJob.Name, "Scaler", scalerType)
+// getScaledJobScalerMetrics checks the scaler given by index within CheckTimeout of the cache or the checkTimeout of the trigger,
+// the scaler is checked the same way the scalers of ScaledObjects are, with retries, circuit breaker and metricFormula,
+// false is returned if the scaler should be skipped
+func (c *ScalersCache) getScaledJobScalerMetrics(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, index int, s ScalerBuilder) (scalerMetrics, bool) {
+	ctx, cancel := WithCheckTimeout(ctx, c.CheckTimeout, s.ScalerConfig)
+	defer cancel()
 
-		metricSpecs := s.Scaler.GetMetricSpecForScaling(ctx)
+	var queueLength float64
+	var targetAverageValue float64
+	isActive := false
+	maxValue := float64(0)
+	scalerType := fmt.Sprintf("%T:", s)
 
-		// skip scaler that doesn't return any metric specs (usually External scaler with incorrect metadata)
-		// or skip cpu/memory resource scaler
-		if len(metricSpecs) < 1 || metricSpecs[0].External == nil {
-			continue
-		}
+	scalerLogger := log.WithValues("ScaledJob", scaledJob.Name, "Scaler", scalerType)
 
-		// TODO here we should probably loop through all metrics in a Scaler
-		// as it is done for ScaledObject
-		metrics, isTriggerActive, err := s.Scaler.GetMetricsAndActivity(ctx, metricSpecs[0].External.Metric.Name)
-		if err != nil {
-			var ns scalers.Scaler
-			ns, err = c.refreshScaler(ctx, i)
-			if err == nil {
-				metrics, isTriggerActive, err = ns.GetMetricsAndActivity(ctx, metricSpecs[0].External.Metric.Name)
-			}
-		}
+	metricSpecs := withTriggerNames(s.ScalerConfig, s.Scaler.GetMetricSpecForScaling(ctx))
 
-		if err != nil {
-			scalerLogger.V(1).Info("Error getting scaler metrics and activity, but continue", "error", err)
-			c.Recorder.Event(scaledJob, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
-			continue
-		}
+	// skip scaler that doesn't return any metric specs (usually External scaler with incorrect metadata)
+	// or skip cpu/memory resource scaler
+	if len(metricSpecs) < 1 || metricSpecs[0].External == nil {
+		return scalerMetrics{}, false
+	}
 
-		targetAverageValue = getTargetAverageValue(metricSpecs)
+	if isInMaintenanceWindow(s.ScalerConfig.TriggerMaintenanceWindows, time.Now()) {
+		scalerLogger.V(1).Info("Trigger is in maintenance window, ignoring it")
+		return scalerMetrics{}, false
+	}
 
-		var metricValue float64
-		for _, m := range metrics {
-			if m.MetricName == metricSpecs[0].External.Metric.Name {
-				metricValue = m.Value.AsApproximateFloat64()
-				queueLength += metricValue
-			}
-		}
-		scalerLogger.V(1).Info("Scaler Metric value", "isTriggerActive", isTriggerActive, metricSpecs[0].External.Metric.Name, queueLength, "targetAverageValue", targetAverageValue)
+	// TODO here we should probably loop through all metrics in a Scaler
+	// as it is done for ScaledObject
+	metricName := metricSpecs[0].External.Metric.Name
+	metrics, isTriggerActive, _, err := c.GetMetricsAndActivityForScaler(ctx, index, metricName)
+	if errors.Is(err, ErrScalerCircuitOpen) {
+		// the failure was already reported when the circuit opened
+		scalerLogger.V(1).Info("Skipping check of scaler with open circuit", "error", err.Error())
+		return scalerMetrics{}, false
+	}
+	if err != nil {
+		scalerLogger.V(1).Info("Error getting scaler metrics and activity, but continue", "error", err)
+		c.Recorder.AnnotatedEventf(scaledJob, eventemitter.TriggerAnnotations(s.ScalerConfig.TriggerName, s.ScalerConfig.TriggerType), corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "%s", scalers.GetScalerErrorMessage(err))
+		return scalerMetrics{}, false
+	}
 
-		if isTriggerActive {
-			isActive = true
-		}
+	targetAverageValue = getTargetAverageValue(metricSpecs)
 
-		if targetAverageValue != 0 {
-			averageLength := queueLength / targetAverageValue
-			maxValue = min(float64(scaledJob.MaxReplicaCount()), averageLength)
+	var metricValue float64
+	for _, m := range metrics {
+		if m.MetricName == metricName {
+			metricValue = m.Value.AsApproximateFloat64()
+			queueLength += metricValue
 		}
-		scalersMetrics = append(scalersMetrics, scalerMetrics{
-			queueLength: queueLength,
-			maxValue:    maxValue,
-			isActive:    isActive,
-		})
 	}
-	return scalersMetrics
+	scalerLogger.V(1).Info("Scaler Metric value", "isTriggerActive", isTriggerActive, metricName, queueLength, "targetAverageValue", targetAverageValue)
+
+	if isTriggerActive {
+		isActive = true
+	}
+
+	if targetAverageValue != 0 {
+		averageLength := queueLength / targetAverageValue
+		maxValue = min(float64(scaledJob.MaxReplicaCount()), averageLength)
+	}
+	return scalerMetrics{
+		queueLength: queueLength,
+		maxValue:    maxValue,
+		isActive:    isActive,
+	}, true
 }
 
 func getTargetAverageValue(metricSpecs []v2.MetricSpec) float64 {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +14,7 @@ import (
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/formula"
 	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 )
@@ -114,6 +116,7 @@ func TestIsScaledJobActive(t *testing.T) {
 		newScalerTestData("messageCount", 100, "avg", 20, 1, true, 10, 2, true, 5, 3, true, 7, 4, false, true, 12, 9),
 		newScalerTestData("s3-messageCount", 100, "sum", 20, 1, true, 10, 2, true, 5, 3, true, 7, 4, false, true, 35, 27),
 		newScalerTestData("s10-messageCount", 25, "sum", 20, 1, true, 10, 2, true, 5, 3, true, 7, 4, false, true, 35, 25),
+		newScalerTestData("queueLength", 100, "min", 0, 1, true, 10, 2, true, 5, 3, true, 7, 4, false, true, 0, 0),
 	}
 
 	for index, scalerTestData := range scalerTestDatam {
@@ -180,6 +183,50 @@ func TestIsScaledJobActiveIfQueueEmptyButMinReplicaCountGreaterZero(t *testing.T
 	cache.Close(context.Background())
 }
 
+func TestIsScaledJobActiveWithHungScaler(t *testing.T) {
+	metricName := "s0-queueLength"
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(2)
+
+	waitForTimeout := func(ctx context.Context, _ string) ([]external_metrics.ExternalMetricValue, bool, error) {
+		<-ctx.Done()
+		return nil, false, ctx.Err()
+	}
+	createHungScaler := func() scalers.Scaler {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(1, metricName)}).AnyTimes()
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), metricName).DoAndReturn(waitForTimeout).AnyTimes()
+		scaler.EXPECT().Close(gomock.Any()).AnyTimes()
+		return scaler
+	}
+
+	scaledJob := createScaledJob(0, 100, "")
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler: createHungScaler(),
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return createHungScaler(), &scalers.ScalerConfig{}, nil
+			},
+		}, {
+			Scaler: createScaler(ctrl, int64(20), int64(2), true, metricName),
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return createScaler(ctrl, int64(20), int64(2), true, metricName), &scalers.ScalerConfig{}, nil
+			},
+		}},
+		Recorder:     recorder,
+		CheckTimeout: 50 * time.Millisecond,
+	}
+
+	// the hung scaler is skipped once the check times out, the other scaler is still checked
+	start := time.Now()
+	isActive, queueLength, maxValue := cache.IsScaledJobActive(context.TODO(), scaledJob)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, true, isActive)
+	assert.Equal(t, int64(20), queueLength)
+	assert.Equal(t, int64(10), maxValue)
+	cache.Close(context.Background())
+}
+
 func newScalerTestData(
 	metricName string,
 	maxReplicaCount int,
@@ -278,3 +325,195 @@ func createScaler(ctrl *gomock.Controller, queueLength int64, averageValue int64
 	scaler.EXPECT().Close(gomock.Any())
 	return scaler
 }
+
+// parallelismLimitedScaler is a scaler of a partitioned source with the number of partitions
+type parallelismLimitedScaler struct {
+	*mock_scalers.MockScaler
+	partitions int64
+	err        error
+}
+
+func (s *parallelismLimitedScaler) GetMaxParallelism(context.Context) (int64, error) {
+	return s.partitions, s.err
+}
+
+func TestGetMaxParallelism(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{
+			{Scaler: mock_scalers.NewMockScaler(ctrl)},
+			{Scaler: &parallelismLimitedScaler{partitions: 12}},
+			{Scaler: &parallelismLimitedScaler{partitions: 0}},
+			{Scaler: &parallelismLimitedScaler{err: fmt.Errorf("broker unavailable")}},
+		},
+	}
+	assert.Equal(t, int64(12), cache.GetMaxParallelism(context.Background()))
+
+	cache.Scalers = append(cache.Scalers, ScalerBuilder{Scaler: &parallelismLimitedScaler{partitions: 4}})
+	assert.Equal(t, int64(4), cache.GetMaxParallelism(context.Background()))
+
+	cache.Scalers = []ScalerBuilder{{Scaler: mock_scalers.NewMockScaler(ctrl)}}
+	assert.Equal(t, int64(0), cache.GetMaxParallelism(context.Background()))
+}
+
+func TestRefreshScalerKeepsScalerOnFactoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	newScaler := mock_scalers.NewMockScaler(ctrl)
+
+	factoryErr := fmt.Errorf("error resolving secrets: the server is currently unable to handle the request")
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler: scaler,
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return nil, nil, factoryErr
+			},
+		}},
+	}
+
+	// the scaler isn't closed, if the new one couldn't be built
+	_, err := cache.refreshScaler(context.Background(), 0)
+	assert.ErrorIs(t, err, factoryErr)
+	assert.Equal(t, scaler, cache.Scalers[0].Scaler)
+
+	cache.Scalers[0].Factory = func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+		return newScaler, &scalers.ScalerConfig{}, nil
+	}
+	scaler.EXPECT().Close(gomock.Any())
+	ns, err := cache.refreshScaler(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, newScaler, ns)
+	assert.Equal(t, newScaler, cache.Scalers[0].Scaler)
+}
+
+func TestGetMetricsAndActivityForScalerRetries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	metricValue := scalers.GenerateMetricInMili("metric-name", 1)
+
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler:       scaler,
+			ScalerConfig: scalers.ScalerConfig{TriggerCheckRetries: 1},
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				t.Fatal("the scaler shouldn't be refreshed if a retry succeeds")
+				return nil, nil, nil
+			},
+		}},
+	}
+
+	gomock.InOrder(
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "metric-name").Return(nil, false, fmt.Errorf("connection reset by peer")),
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "metric-name").Return([]external_metrics.ExternalMetricValue{metricValue}, true, nil),
+	)
+
+	metrics, isActive, _, err := cache.GetMetricsAndActivityForScaler(context.Background(), 0, "metric-name")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+	assert.Equal(t, []external_metrics.ExternalMetricValue{metricValue}, metrics)
+}
+
+func TestGetMetricsAndActivityForScalerTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler:       scaler,
+			ScalerConfig: scalers.ScalerConfig{TriggerCheckTimeout: 50 * time.Millisecond, TriggerCheckRetries: 3},
+		}},
+	}
+
+	// the retries stop once the timeout of the trigger expires
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "metric-name").DoAndReturn(func(ctx context.Context, _ string) ([]external_metrics.ExternalMetricValue, bool, error) {
+		<-ctx.Done()
+		return nil, false, ctx.Err()
+	})
+
+	_, _, _, err := cache.GetMetricsAndActivityForScaler(context.Background(), 0, "metric-name")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGetMetricsAndActivityForScalerAppliesMetricFormula(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	metricFormula, err := formula.Parse("max(value / 4, 1)")
+	assert.NoError(t, err)
+
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler:       scaler,
+			ScalerConfig: scalers.ScalerConfig{TriggerMetricFormula: metricFormula},
+		}},
+	}
+
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "metric-name").Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("metric-name", 10)}, true, nil)
+	metrics, isActive, _, err := cache.GetMetricsAndActivityForScaler(context.Background(), 0, "metric-name")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+	assert.Equal(t, "metric-name", metrics[0].MetricName)
+	assert.Equal(t, 2.5, metrics[0].Value.AsApproximateFloat64())
+
+	// the activity of the trigger isn't affected by the formula
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "metric-name").Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("metric-name", 0)}, false, nil)
+	metrics, isActive, _, err = cache.GetMetricsAndActivityForScaler(context.Background(), 0, "metric-name")
+	assert.NoError(t, err)
+	assert.False(t, isActive)
+	assert.Equal(t, float64(1), metrics[0].Value.AsApproximateFloat64())
+}
+
+func TestIsScaledJobActiveAppliesMetricFormula(t *testing.T) {
+	metricName := "s0-queueLength"
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+	metricFormula, err := formula.Parse("value / 4")
+	assert.NoError(t, err)
+
+	scaledJob := createScaledJob(0, 100, "")
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler:       createScaler(ctrl, int64(20), int64(1), true, metricName),
+			ScalerConfig: scalers.ScalerConfig{TriggerMetricFormula: metricFormula},
+		}},
+		Recorder: recorder,
+	}
+
+	isActive, queueLength, maxValue := cache.IsScaledJobActive(context.TODO(), scaledJob)
+	assert.Equal(t, true, isActive)
+	assert.Equal(t, int64(5), queueLength)
+	assert.Equal(t, int64(5), maxValue)
+	cache.Close(context.Background())
+}
+
+func TestMetricNamesOfNamedTriggers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	named := mock_scalers.NewMockScaler(ctrl)
+	unnamed := mock_scalers.NewMockScaler(ctrl)
+	// the scaler keeps its spec, it mustn't be renamed in place
+	namedSpecs := []v2.MetricSpec{createMetricSpec(10, "s1-rabbitmq-orders")}
+	named.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(namedSpecs).AnyTimes()
+	unnamed.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(10, "s0-kafka-events")}).AnyTimes()
+
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{
+			{Scaler: unnamed, ScalerConfig: scalers.ScalerConfig{ScalerIndex: 0}},
+			{Scaler: named, ScalerConfig: scalers.ScalerConfig{ScalerIndex: 1, TriggerName: "orders-queue"}},
+		},
+	}
+
+	specs := cache.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s0-kafka-events", specs[0].External.Metric.Name)
+	assert.Equal(t, "s.orders-queue.rabbitmq-orders", specs[1].External.Metric.Name)
+	assert.Equal(t, "s1-rabbitmq-orders", namedSpecs[0].External.Metric.Name)
+
+	specs, err := cache.GetMetricSpecForScalingForScaler(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "s.orders-queue.rabbitmq-orders", specs[0].External.Metric.Name)
+
+	// the scaler is queried by its own metric name, the values are returned with the name of the trigger
+	named.EXPECT().GetMetricsAndActivity(gomock.Any(), "s1-rabbitmq-orders").Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s1-rabbitmq-orders", 5)}, true, nil)
+	metrics, isActive, _, err := cache.GetMetricsAndActivityForScaler(context.Background(), 1, "s.orders-queue.rabbitmq-orders")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+	assert.Equal(t, "s.orders-queue.rabbitmq-orders", metrics[0].MetricName)
+}
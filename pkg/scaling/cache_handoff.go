@@ -0,0 +1,300 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+)
+
+const (
+	// CacheHandoffConfigMapName is the name of the ConfigMap used to hand off the cache to the next leader,
+	// each shard of KEDA Operator hands off its cache in its own ConfigMap suffixed by the index of the shard
+	CacheHandoffConfigMapName = "keda-operator-cache-handoff"
+	cacheHandoffConfigMapKey  = "scaledObjects"
+
+	// cacheHandoffLoadTimeout is how long scale loops wait for the handed off cache to be loaded
+	cacheHandoffLoadTimeout = 10 * time.Second
+
+	// cacheHandoffMaxSize is the maximum size of the handed off records, it leaves room for the rest of the ConfigMap
+	// below the 1 MiB limit of Kubernetes objects, the oldest records are dropped to fit
+	cacheHandoffMaxSize = 900 * 1024
+)
+
+// cacheHandoffRecord is the fingerprint of a ScaledObject together with its last metric values
+type cacheHandoffRecord struct {
+	Generation int64                                `json:"generation"`
+	UpdatedAt  time.Time                            `json:"updatedAt"`
+	Metrics    map[string]cacheHandoffMetricsRecord `json:"metrics"`
+}
+
+type cacheHandoffMetricsRecord struct {
+	IsActive bool                                   `json:"isActive"`
+	Metric   []external_metrics.ExternalMetricValue `json:"metric"`
+}
+
+// CacheHandoff periodically stores fingerprints of ScaledObjects and their last metric values in a ConfigMap,
+// so a new leader (eg. on operator upgrade) can warm up its cache from them instead of querying all scalers at once.
+// It implements manager.Runnable and is started only on the leader.
+type CacheHandoff struct {
+	client    client.Client
+	reader    client.Reader
+	namespace string
+	name      string
+	interval  time.Duration
+	maxSize   int
+	now       func() time.Time
+
+	lock     sync.Mutex
+	records  map[string]cacheHandoffRecord
+	restored map[string]cacheHandoffRecord
+	loaded   chan struct{}
+}
+
+// NewCacheHandoff creates a new CacheHandoff storing the cache in the ConfigMap with the name in the namespace
+// every interval, reader is used to load the cache handed off by the previous leader
+func NewCacheHandoff(client client.Client, reader client.Reader, namespace, name string, interval time.Duration) *CacheHandoff {
+	return &CacheHandoff{
+		client:    client,
+		reader:    reader,
+		namespace: namespace,
+		name:      name,
+		interval:  interval,
+		maxSize:   cacheHandoffMaxSize,
+		now:       time.Now,
+		records:   map[string]cacheHandoffRecord{},
+		restored:  map[string]cacheHandoffRecord{},
+		loaded:    make(chan struct{}),
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only the leader hands off its cache
+func (c *CacheHandoff) NeedLeaderElection() bool {
+	return true
+}
+
+// Start loads the cache handed off by the previous leader and then stores the current cache every interval
+// and once more when the context is canceled
+func (c *CacheHandoff) Start(ctx context.Context) error {
+	if err := c.load(ctx); err != nil {
+		log.Error(err, "error loading handed off cache", "configMap", c.name)
+	}
+	close(c.loaded)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.store(ctx); err != nil {
+				log.Error(err, "error handing off cache", "configMap", c.name)
+			}
+		case <-ctx.Done():
+			// the manager context is already canceled, give the last update a chance to finish
+			storeCtx, cancel := context.WithTimeout(context.Background(), cacheHandoffLoadTimeout)
+			defer cancel()
+			if err := c.store(storeCtx); err != nil {
+				log.Error(err, "error handing off cache", "configMap", c.name)
+			}
+			return nil
+		}
+	}
+}
+
+// StoreRecords remembers the last metric records of the ScaledObject, records with scaler errors are skipped
+func (c *CacheHandoff) StoreRecords(scaledObject *kedav1alpha1.ScaledObject, metricsRecords map[string]metricscache.MetricsRecord) {
+	record := cacheHandoffRecord{
+		Generation: scaledObject.Generation,
+		UpdatedAt:  c.now(),
+		Metrics:    map[string]cacheHandoffMetricsRecord{},
+	}
+	for metricName, metricsRecord := range metricsRecords {
+		if metricsRecord.ScalerError != nil {
+			continue
+		}
+		record.Metrics[metricName] = cacheHandoffMetricsRecord{
+			IsActive: metricsRecord.IsActive,
+			Metric:   metricsRecord.Metric,
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.records[scaledObject.GenerateIdentifier()] = record
+}
+
+// Delete forgets the records of the ScaledObject
+func (c *CacheHandoff) Delete(scaledObjectIdentifier string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.records, scaledObjectIdentifier)
+	delete(c.restored, scaledObjectIdentifier)
+}
+
+// RestoreRecords returns the metric records handed off by the previous leader for the ScaledObject,
+// records are returned only once and only if the ScaledObject hasn't changed since they were stored
+func (c *CacheHandoff) RestoreRecords(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (map[string]metricscache.MetricsRecord, bool) {
+	select {
+	case <-c.loaded:
+	case <-ctx.Done():
+		return nil, false
+	case <-time.After(cacheHandoffLoadTimeout):
+		return nil, false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	identifier := scaledObject.GenerateIdentifier()
+	record, ok := c.restored[identifier]
+	if !ok {
+		return nil, false
+	}
+	delete(c.restored, identifier)
+	if record.Generation != scaledObject.Generation || len(record.Metrics) == 0 {
+		return nil, false
+	}
+
+	metricsRecords := make(map[string]metricscache.MetricsRecord, len(record.Metrics))
+	for metricName, metricsRecord := range record.Metrics {
+		metricsRecords[metricName] = metricscache.MetricsRecord{
+			IsActive: metricsRecord.IsActive,
+			Metric:   metricsRecord.Metric,
+		}
+	}
+	return metricsRecords, true
+}
+
+func (c *CacheHandoff) load(ctx context.Context) error {
+	configMap := &corev1.ConfigMap{}
+	err := c.reader.Get(ctx, types.NamespacedName{Name: c.name, Namespace: c.namespace}, configMap)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	restored := map[string]cacheHandoffRecord{}
+	if err := json.Unmarshal([]byte(configMap.Data[cacheHandoffConfigMapKey]), &restored); err != nil {
+		return fmt.Errorf("error parsing handed off cache: %w", err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.restored = restored
+	log.V(1).Info("Loaded handed off cache", "configMap", c.name, "scaledObjects", len(restored))
+	return nil
+}
+
+func (c *CacheHandoff) store(ctx context.Context) error {
+	c.lock.Lock()
+	// records not restored until now are handed off once more and then dropped,
+	// otherwise records of ScaledObjects deleted in the meantime would be kept forever
+	records := make(map[string]cacheHandoffRecord, len(c.records)+len(c.restored))
+	for identifier, record := range c.restored {
+		records[identifier] = record
+	}
+	c.restored = map[string]cacheHandoffRecord{}
+	for identifier, record := range c.records {
+		records[identifier] = record
+	}
+	c.lock.Unlock()
+
+	data, err := c.marshalRecords(records)
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.name,
+			Namespace: c.namespace,
+		},
+		Data: map[string]string{cacheHandoffConfigMapKey: string(data)},
+	}
+	err = c.client.Update(ctx, configMap)
+	if errors.IsNotFound(err) {
+		return c.client.Create(ctx, configMap)
+	}
+	return err
+}
+
+// marshalRecords marshals the newest records fitting maxSize, the oldest records are dropped if they don't fit,
+// so the ConfigMap doesn't exceed the size limit and the handoff keeps working with many ScaledObjects
+func (c *CacheHandoff) marshalRecords(records map[string]cacheHandoffRecord) ([]byte, error) {
+	type marshaledRecord struct {
+		identifier string
+		updatedAt  time.Time
+		size       int
+	}
+	marshaled := make([]marshaledRecord, 0, len(records))
+	size := len("{}")
+	for identifier, record := range records {
+		key, err := json.Marshal(identifier)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		// the key, colon and value of the entry in the marshaled map, entries are separated by commas
+		recordSize := len(key) + 1 + len(value)
+		if len(marshaled) > 0 {
+			size++
+		}
+		marshaled = append(marshaled, marshaledRecord{identifier: identifier, updatedAt: record.UpdatedAt, size: recordSize})
+		size += recordSize
+	}
+	if size <= c.maxSize {
+		return json.Marshal(records)
+	}
+
+	sort.Slice(marshaled, func(i, j int) bool {
+		return marshaled[i].updatedAt.After(marshaled[j].updatedAt)
+	})
+	kept := make(map[string]cacheHandoffRecord, len(records))
+	size = len("{}")
+	for _, record := range marshaled {
+		recordSize := record.size
+		if len(kept) > 0 {
+			recordSize++
+		}
+		if size+recordSize > c.maxSize {
+			break
+		}
+		kept[record.identifier] = records[record.identifier]
+		size += recordSize
+	}
+	err := fmt.Errorf("handed off records exceed %d bytes", c.maxSize)
+	log.Error(err, "dropping the oldest records of handed off cache", "configMap", c.name, "scaledObjects", len(records), "dropped", len(records)-len(kept))
+	return json.Marshal(kept)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+)
+
+func TestCacheHandoff(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Generation: 2},
+	}
+	deletedScaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "test", Generation: 1},
+	}
+	metricsRecords := map[string]metricscache.MetricsRecord{
+		"s0-metric": {
+			IsActive: true,
+			Metric:   []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-metric", 5)},
+		},
+		"s1-metric": {
+			ScalerError: errors.New("scaler error"),
+		},
+	}
+
+	// leader stores the records
+	leader := NewCacheHandoff(client, client, "keda", CacheHandoffConfigMapName, time.Minute)
+	leader.StoreRecords(scaledObject, metricsRecords)
+	leader.StoreRecords(deletedScaledObject, metricsRecords)
+	leader.Delete(deletedScaledObject.GenerateIdentifier())
+	assert.NoError(t, leader.store(ctx))
+
+	configMap := &corev1.ConfigMap{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: CacheHandoffConfigMapName, Namespace: "keda"}, configMap))
+	assert.Contains(t, configMap.Data[cacheHandoffConfigMapKey], scaledObject.GenerateIdentifier())
+	assert.NotContains(t, configMap.Data[cacheHandoffConfigMapKey], deletedScaledObject.GenerateIdentifier())
+
+	// storing again updates the existing ConfigMap
+	assert.NoError(t, leader.store(ctx))
+
+	// next leader restores them
+	next := NewCacheHandoff(client, client, "keda", CacheHandoffConfigMapName, time.Minute)
+	assert.NoError(t, next.load(ctx))
+	close(next.loaded)
+
+	restored, ok := next.RestoreRecords(ctx, scaledObject)
+	assert.True(t, ok)
+	assert.Len(t, restored, 1)
+	assert.True(t, restored["s0-metric"].IsActive)
+	assert.Equal(t, "s0-metric", restored["s0-metric"].Metric[0].MetricName)
+	assert.Equal(t, int64(5000), restored["s0-metric"].Metric[0].Value.MilliValue())
+
+	// records are restored only once
+	_, ok = next.RestoreRecords(ctx, scaledObject)
+	assert.False(t, ok)
+}
+
+func TestCacheHandoffRestoreChangedScaledObject(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Generation: 2},
+	}
+	leader := NewCacheHandoff(client, client, "keda", CacheHandoffConfigMapName, time.Minute)
+	leader.StoreRecords(scaledObject, map[string]metricscache.MetricsRecord{
+		"s0-metric": {Metric: []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-metric", 5)}},
+	})
+	assert.NoError(t, leader.store(ctx))
+
+	next := NewCacheHandoff(client, client, "keda", CacheHandoffConfigMapName, time.Minute)
+	assert.NoError(t, next.load(ctx))
+	close(next.loaded)
+
+	// the ScaledObject was updated since the records were stored
+	scaledObject.Generation = 3
+	_, ok := next.RestoreRecords(ctx, scaledObject)
+	assert.False(t, ok)
+}
+
+func TestCacheHandoffDropsOldestRecordsExceedingMaxSize(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+	leader := NewCacheHandoff(client, client, "keda", CacheHandoffConfigMapName, time.Minute)
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	leader.now = func() time.Time { return now }
+
+	metricsRecords := map[string]metricscache.MetricsRecord{
+		"s0-metric": {Metric: []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-metric", 5)}},
+	}
+	var scaledObjects []*kedav1alpha1.ScaledObject
+	for _, name := range []string{"oldest", "older", "newest"} {
+		scaledObject := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", Generation: 1}}
+		leader.StoreRecords(scaledObject, metricsRecords)
+		scaledObjects = append(scaledObjects, scaledObject)
+		now = now.Add(time.Minute)
+	}
+
+	// only the two newest records fit
+	newest, err := json.Marshal(map[string]cacheHandoffRecord{
+		scaledObjects[1].GenerateIdentifier(): leader.records[scaledObjects[1].GenerateIdentifier()],
+		scaledObjects[2].GenerateIdentifier(): leader.records[scaledObjects[2].GenerateIdentifier()],
+	})
+	assert.NoError(t, err)
+	leader.maxSize = len(newest)
+	assert.NoError(t, leader.store(ctx))
+
+	configMap := &corev1.ConfigMap{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: CacheHandoffConfigMapName, Namespace: "keda"}, configMap))
+	data := configMap.Data[cacheHandoffConfigMapKey]
+	assert.LessOrEqual(t, len(data), leader.maxSize)
+	assert.NotContains(t, data, scaledObjects[0].GenerateIdentifier())
+	assert.Contains(t, data, scaledObjects[1].GenerateIdentifier())
+	assert.Contains(t, data, scaledObjects[2].GenerateIdentifier())
+
+	// all records fit again once the size limit allows them
+	leader.maxSize = cacheHandoffMaxSize
+	assert.NoError(t, leader.store(ctx))
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: CacheHandoffConfigMapName, Namespace: "keda"}, configMap))
+	assert.Contains(t, configMap.Data[cacheHandoffConfigMapKey], scaledObjects[0].GenerateIdentifier())
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+)
+
+const (
+	// Default number of scaling decisions kept in the status of a ScaledObject
+	defaultDecisionHistoryLimit int32 = 10
+
+	// Default number of scaling decisions kept in the ConfigMap of a ScaledObject
+	defaultDecisionHistoryConfigMapLimit int32 = 100
+)
+
+// scalingInput holds the state of the triggers a scaling decision is made on
+type scalingInput struct {
+	isActive     bool
+	isError      bool
+	isPoll       bool
+	metricValues map[string]float64
+}
+
+// recordScalingDecision records the decision to scale the scale target of the ScaledObject in its status
+// and in the ConfigMap of its decision history, if the decision history is enabled
+func (e *scaleExecutor) recordScalingDecision(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, input scalingInput,
+	action string, currentReplicas int32, targetReplicas int32, scaleErr error) {
+	config := scaledObject.Spec.DecisionHistory
+	if config == nil {
+		return
+	}
+
+	decision := newScalingDecision(input, action, currentReplicas, targetReplicas, scaleErr)
+
+	status := scaledObject.Status.DeepCopy()
+	status.ScalingDecisions = appendScalingDecision(status.ScalingDecisions, decision, decisionHistoryLimit(config.Limit, defaultDecisionHistoryLimit))
+	if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, e.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "Error recording the scaling decision in status", "action", action)
+	}
+
+	if config.ConfigMapName != "" {
+		if err := e.storeScalingDecision(ctx, scaledObject, decision); err != nil {
+			logger.Error(err, "Error recording the scaling decision in ConfigMap", "action", action, "configMap", config.ConfigMapName)
+		}
+	}
+}
+
+func newScalingDecision(input scalingInput, action string, currentReplicas int32, targetReplicas int32, scaleErr error) kedav1alpha1.ScalingDecision {
+	decision := kedav1alpha1.ScalingDecision{
+		Time:            metav1.Now(),
+		Action:          action,
+		Active:          input.isActive,
+		TriggerError:    input.isError,
+		CurrentReplicas: currentReplicas,
+		TargetReplicas:  targetReplicas,
+	}
+	if len(input.metricValues) > 0 {
+		decision.Metrics = make(map[string]string, len(input.metricValues))
+		for metricName, value := range input.metricValues {
+			decision.Metrics[metricName] = strconv.FormatFloat(value, 'f', -1, 64)
+		}
+	}
+	if scaleErr != nil {
+		decision.Error = scaleErr.Error()
+	}
+	return decision
+}
+
+// appendScalingDecision appends the decision to the history, dropping the oldest decisions exceeding the limit
+func appendScalingDecision(history []kedav1alpha1.ScalingDecision, decision kedav1alpha1.ScalingDecision, limit int32) []kedav1alpha1.ScalingDecision {
+	history = append(history, decision)
+	if excess := len(history) - int(limit); excess > 0 {
+		history = append([]kedav1alpha1.ScalingDecision(nil), history[excess:]...)
+	}
+	return history
+}
+
+func decisionHistoryLimit(limit *int32, defaultLimit int32) int32 {
+	if limit == nil || *limit < 1 {
+		return defaultLimit
+	}
+	return *limit
+}
+
+// storeScalingDecision stores the decision in the ConfigMap of the decision history, under the key of the ScaledObject,
+// the ConfigMap is created if it doesn't exist
+func (e *scaleExecutor) storeScalingDecision(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, decision kedav1alpha1.ScalingDecision) error {
+	config := scaledObject.Spec.DecisionHistory
+	limit := decisionHistoryLimit(config.ConfigMapLimit, defaultDecisionHistoryConfigMapLimit)
+	key := types.NamespacedName{Namespace: scaledObject.Namespace, Name: config.ConfigMapName}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap := &corev1.ConfigMap{}
+		err := e.client.Get(ctx, key, configMap)
+		notFound := apierrors.IsNotFound(err)
+		if err != nil && !notFound {
+			return err
+		}
+
+		var history []kedav1alpha1.ScalingDecision
+		if raw, ok := configMap.Data[scaledObject.Name]; ok && raw != "" {
+			// a malformed history is replaced rather than blocking the recording of new decisions
+			if err := json.Unmarshal([]byte(raw), &history); err != nil {
+				history = nil
+			}
+		}
+		history = appendScalingDecision(history, decision, limit)
+		data, err := json.Marshal(history)
+		if err != nil {
+			return err
+		}
+
+		if notFound {
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Data: map[string]string{scaledObject.Name: string(data)},
+			}
+			err = e.client.Create(ctx, configMap)
+			if apierrors.IsAlreadyExists(err) {
+				// created meanwhile by the decision of another ScaledObject, retry with the existing one
+				return apierrors.NewConflict(corev1.Resource("configmaps"), key.Name, err)
+			}
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[scaledObject.Name] = string(data)
+		return e.client.Update(ctx, configMap)
+	})
+}
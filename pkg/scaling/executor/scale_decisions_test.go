@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func newDecisionsTestExecutor(t *testing.T, objects ...runtimeclient.Object) *scaleExecutor {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	return &scaleExecutor{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		logger: logf.Log.WithName("scaleexecutor"),
+	}
+}
+
+func newDecisionsTestScaledObject(config *kedav1alpha1.DecisionHistoryConfig) *kedav1alpha1.ScaledObject {
+	return &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef:  &kedav1alpha1.ScaleTarget{Name: "name"},
+			DecisionHistory: config,
+		},
+	}
+}
+
+func TestRecordScalingDecisionDisabled(t *testing.T) {
+	scaledObject := newDecisionsTestScaledObject(nil)
+	executor := newDecisionsTestExecutor(t, scaledObject)
+
+	executor.recordScalingDecision(context.TODO(), executor.logger, scaledObject, scalingInput{isActive: true},
+		kedav1alpha1.ScalingActionActivate, 0, 1, nil)
+
+	assert.Empty(t, scaledObject.Status.ScalingDecisions)
+}
+
+func TestRecordScalingDecisionInStatus(t *testing.T) {
+	limit := int32(2)
+	scaledObject := newDecisionsTestScaledObject(&kedav1alpha1.DecisionHistoryConfig{Limit: &limit})
+	executor := newDecisionsTestExecutor(t, scaledObject)
+
+	input := scalingInput{isActive: true, metricValues: map[string]float64{"s0-queue": 2.5}}
+	executor.recordScalingDecision(context.TODO(), executor.logger, scaledObject, input, kedav1alpha1.ScalingActionActivate, 0, 1, nil)
+	executor.recordScalingDecision(context.TODO(), executor.logger, scaledObject, scalingInput{isError: true},
+		kedav1alpha1.ScalingActionFallback, 1, 3, errors.New("scale failed"))
+	executor.recordScalingDecision(context.TODO(), executor.logger, scaledObject, scalingInput{},
+		kedav1alpha1.ScalingActionDeactivate, 3, 0, nil)
+
+	stored := &kedav1alpha1.ScaledObject{}
+	assert.NoError(t, executor.client.Get(context.TODO(), types.NamespacedName{Namespace: "namespace", Name: "name"}, stored))
+	decisions := stored.Status.ScalingDecisions
+	assert.Len(t, decisions, 2)
+	assert.Equal(t, kedav1alpha1.ScalingActionFallback, decisions[0].Action)
+	assert.True(t, decisions[0].TriggerError)
+	assert.Equal(t, int32(1), decisions[0].CurrentReplicas)
+	assert.Equal(t, int32(3), decisions[0].TargetReplicas)
+	assert.Equal(t, "scale failed", decisions[0].Error)
+	assert.Equal(t, kedav1alpha1.ScalingActionDeactivate, decisions[1].Action)
+	assert.False(t, decisions[1].Active)
+}
+
+func TestRecordScalingDecisionMetrics(t *testing.T) {
+	decision := newScalingDecision(scalingInput{isActive: true, metricValues: map[string]float64{"s0-queue": 2.5, "s1-lag": 100}},
+		kedav1alpha1.ScalingActionActivate, 0, 1, nil)
+
+	assert.Equal(t, map[string]string{"s0-queue": "2.5", "s1-lag": "100"}, decision.Metrics)
+	assert.True(t, decision.Active)
+	assert.Empty(t, decision.Error)
+}
+
+func TestRecordScalingDecisionInConfigMap(t *testing.T) {
+	limit := int32(2)
+	scaledObject := newDecisionsTestScaledObject(&kedav1alpha1.DecisionHistoryConfig{ConfigMapName: "decisions", ConfigMapLimit: &limit})
+	executor := newDecisionsTestExecutor(t, scaledObject)
+
+	for _, action := range []string{kedav1alpha1.ScalingActionActivate, kedav1alpha1.ScalingActionScaleToMinReplicas, kedav1alpha1.ScalingActionDeactivate} {
+		executor.recordScalingDecision(context.TODO(), executor.logger, scaledObject, scalingInput{}, action, 1, 0, nil)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	assert.NoError(t, executor.client.Get(context.TODO(), types.NamespacedName{Namespace: "namespace", Name: "decisions"}, configMap))
+	var decisions []kedav1alpha1.ScalingDecision
+	assert.NoError(t, json.Unmarshal([]byte(configMap.Data["name"]), &decisions))
+	assert.Len(t, decisions, 2)
+	assert.Equal(t, kedav1alpha1.ScalingActionScaleToMinReplicas, decisions[0].Action)
+	assert.Equal(t, kedav1alpha1.ScalingActionDeactivate, decisions[1].Action)
+
+	// the status keeps its own, default, limit
+	assert.Len(t, scaledObject.Status.ScalingDecisions, 3)
+}
+
+func TestRecordScalingDecisionKeepsOtherKeysOfConfigMap(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "decisions", Namespace: "namespace"},
+		Data:       map[string]string{"other": "[]"},
+	}
+	scaledObject := newDecisionsTestScaledObject(&kedav1alpha1.DecisionHistoryConfig{ConfigMapName: "decisions"})
+	executor := newDecisionsTestExecutor(t, scaledObject, configMap)
+
+	executor.recordScalingDecision(context.TODO(), executor.logger, scaledObject, scalingInput{isActive: true},
+		kedav1alpha1.ScalingActionActivate, 0, 1, nil)
+
+	stored := &corev1.ConfigMap{}
+	assert.NoError(t, executor.client.Get(context.TODO(), types.NamespacedName{Namespace: "namespace", Name: "decisions"}, stored))
+	assert.Equal(t, "[]", stored.Data["other"])
+	assert.Contains(t, stored.Data["name"], kedav1alpha1.ScalingActionActivate)
+}
@@ -19,27 +19,57 @@ package executor
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/record"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/prommetrics"
 )
 
 const (
 	// Default cooldown period for a ScaleTarget if no cooldownPeriod is defined on the scaledObject
 	defaultCooldownPeriod = 5 * 60 // 5 minutes
+
+	// Default maxReplicaCount of the HPA if no maxReplicaCount is defined on the scaledObject
+	defaultMaxReplicaCount int32 = 100
+
+	// Default timeout for the scale target to become ready after activation if no activationReadiness.timeoutSeconds is defined on the scaledObject
+	defaultActivationReadinessTimeout = 5 * 60 // 5 minutes
 )
 
+// scaleUpdateBackoff is used to retry updates of the scale subresource failing on transient errors of the API server,
+// eg. during its rolling update, so the scaling isn't postponed to the next poll (~3s in total)
+var scaleUpdateBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 200 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
 // ScaleExecutor contains methods RequestJobScale and RequestScale
 type ScaleExecutor interface {
 	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64)
-	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool)
+	// RequestScale scales the scale target of the ScaledObject by the activity and errors of its triggers,
+	// the metric values of the triggers are recorded in the decision history. isPoll is false for checks
+	// requested by push scalers, they aren't counted as inactive checks before scaling to zero
+	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, isPoll bool, metricValues map[string]float64)
+	RequestDryRunScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, metricReplicaCount int32)
+	// RecordDesiredReplicas records the replica count the scale target of the ScaledObject is scaled to in
+	// status.desiredReplicaCount and in Prometheus metrics, metricReplicaCount is the replica count the HPA
+	// would calculate for the metric values of the triggers
+	RecordDesiredReplicas(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, metricReplicaCount int32)
+	// ResetInactiveChecks clears the number of consecutive inactive polls of the ScaledObject, it's called once
+	// the scale loop of the ScaledObject is replaced or deleted
+	ResetInactiveChecks(scaledObject *kedav1alpha1.ScaledObject)
 }
 
 type scaleExecutor struct {
@@ -48,6 +78,9 @@ type scaleExecutor struct {
 	reconcilerScheme *runtime.Scheme
 	logger           logr.Logger
 	recorder         record.EventRecorder
+
+	// inactiveChecks holds the number of consecutive inactive polls of ScaledObjects, keyed by their identifier
+	inactiveChecks sync.Map
 }
 
 // NewScaleExecutor creates a ScaleExecutor object
@@ -82,8 +115,13 @@ func (e *scaleExecutor) updateLastActiveTime(ctx context.Context, logger logr.Lo
 	err := e.client.Status().Patch(ctx, runtimeObj, patch)
 	if err != nil {
 		logger.Error(err, "Failed to patch Objects Status")
+		prommetrics.RecordInternalError("scale_executor")
+		return err
 	}
-	return err
+	if scaledObject, ok := runtimeObj.(*kedav1alpha1.ScaledObject); ok {
+		prommetrics.RecordScaledObjectStatus(scaledObject)
+	}
+	return nil
 }
 
 func (e *scaleExecutor) setCondition(ctx context.Context, logger logr.Logger, object interface{}, status metav1.ConditionStatus, reason string, message string, setCondition func(kedav1alpha1.Conditions, metav1.ConditionStatus, string, string)) error {
@@ -124,6 +124,7 @@ func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scal
 	for key, value := range scaledJob.ObjectMeta.Labels {
 		labels[key] = value
 	}
+	labels[kedav1alpha1.ScaledJobGenerationLabel] = strconv.FormatInt(scaledJob.GetGeneration(), 10)
 
 	for i := 0; i < int(scaleTo); i++ {
 		job := &batchv1.Job{
@@ -325,6 +326,9 @@ func (e *scaleExecutor) cleanUp(ctx context.Context, scaledJob *kedav1alpha1.Sca
 }
 
 func (e *scaleExecutor) deleteJobsWithHistoryLimit(ctx context.Context, logger logr.Logger, jobs []batchv1.Job, historyLimit int32) error {
+	if historyLimit < 0 {
+		historyLimit = 0
+	}
 	if len(jobs) <= int(historyLimit) {
 		return nil
 	}
@@ -171,6 +171,35 @@ func TestCleanUpMixedCaseWithSortByTime(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestCleanUpNegativeHistoryLimit(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup ScaledJob
+	// successfulJobHistoryLimit = -1, handled as 0
+	// failedJobHistoryLimit = 1
+	scaledJob := getMockScaledJob(-1, 1)
+
+	var actualDeletedJobName = make(map[string]string)
+
+	client := getMockClient(t, ctrl, &[]mockJobParameter{
+		{Name: "success1", CompletionTime: "2020-07-29T15:37:00Z", JobConditionType: batchv1.JobComplete},
+		{Name: "fail1", CompletionTime: "2020-07-29T15:37:00Z", JobConditionType: batchv1.JobFailed},
+	}, &actualDeletedJobName)
+
+	scaleExecutor := getMockScaleExecutor(client)
+
+	err := scaleExecutor.cleanUp(ctx, scaledJob)
+	if err != nil {
+		t.Errorf("Unable to cleanup as: %v", err)
+		return
+	}
+	assert.Equal(t, 1, len(actualDeletedJobName))
+	_, ok := actualDeletedJobName["success1"]
+	assert.True(t, ok)
+}
+
 func TestRunningJobCountSmallerMinReplicaCount(t *testing.T) {
 	scaleExecutor := getMockScaleExecutor(nil)
 	scaledJob := getMockScaledJobWithMinReplicaCountAndDefaultStrategy(2)
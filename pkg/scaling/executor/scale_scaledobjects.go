@@ -18,6 +18,7 @@ package executor
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -25,18 +26,39 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/eventemitter"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/prommetrics"
 )
 
-func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool) {
+func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, isPoll bool, metricValues map[string]float64) {
 	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name,
 		"scaledObject.Namespace", scaledObject.Namespace,
 		"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
+	input := scalingInput{isActive: isActive, isError: isError, isPoll: isPoll, metricValues: metricValues}
+
+	if isActive {
+		e.ResetInactiveChecks(scaledObject)
+	}
+
+	// the ScaledObject isn't in dry-run mode anymore, the recommended replica count is obsolete
+	if scaledObject.Status.RecommendedReplicaCount != nil {
+		prommetrics.DeleteRecommendedReplicas(scaledObject.Namespace, scaledObject.Name)
+		status := scaledObject.Status.DeepCopy()
+		status.RecommendedReplicaCount = nil
+		if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, e.client, logger, scaledObject, status); err != nil {
+			logger.Error(err, "Error clearing recommended replica count")
+		}
+	}
 
 	// Get the current replica count. As a special case, Deployments and StatefulSets fetch directly from the object so they can use the informer cache
 	// to reduce API calls. Everything else uses the scale subresource.
@@ -92,11 +114,11 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		return
 	}
 
-	status := scaledObject.Status.DeepCopy()
 	if pausedCount != nil {
 		// Scale the target to the paused replica count
 		if *pausedCount != currentReplicas {
 			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, *pausedCount)
+			e.recordScalingDecision(ctx, logger, scaledObject, input, kedav1alpha1.ScalingActionPause, currentReplicas, *pausedCount, err)
 			if err != nil {
 				logger.Error(err, "error scaling target to paused replicas count", "paused replicas", *pausedCount)
 				if err := e.setReadyCondition(ctx, logger, scaledObject, metav1.ConditionUnknown,
@@ -105,6 +127,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 				}
 				return
 			}
+			status := scaledObject.Status.DeepCopy()
 			status.PausedReplicaCount = pausedCount
 			err = kedacontrollerutil.UpdateScaledObjectStatus(ctx, e.client, logger, scaledObject, status)
 			if err != nil {
@@ -135,7 +158,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// replica count is equal to 0
 
 			// Scale the ScaleTarget up
-			e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale)
+			e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale, input)
 		case isError:
 			// some triggers are active, but some responded with error
 
@@ -166,7 +189,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// there is a fallback replicas count defined
 
 			// Scale to the fallback replicas count
-			e.doFallbackScaling(ctx, scaledObject, currentScale, logger, currentReplicas)
+			e.doFallbackScaling(ctx, scaledObject, currentScale, logger, currentReplicas, input)
 		case isError && scaledObject.Spec.Fallback == nil:
 			// there are no active triggers, but a scaler responded with an error
 			// AND
@@ -191,7 +214,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// there is no minimum configured or minimum is set to ZERO
 
 			// Try to scale the deployment down, HPA will handle other scale in operations
-			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale)
+			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale, input)
 		case currentReplicas < minReplicas && scaledObject.Spec.IdleReplicaCount == nil:
 			// there are no active triggers
 			// AND
@@ -201,6 +224,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 
 			// ScaleTarget replicas count to correct value
 			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, *scaledObject.Spec.MinReplicaCount)
+			e.recordScalingDecision(ctx, logger, scaledObject, input, kedav1alpha1.ScalingActionScaleToMinReplicas, currentReplicas, *scaledObject.Spec.MinReplicaCount, err)
 			if err == nil {
 				logger.Info("Successfully set ScaleTarget replicas count to ScaledObject minReplicaCount",
 					"Original Replicas Count", currentReplicas,
@@ -214,8 +238,11 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		}
 	}
 
+	// while the activated scale target isn't ready, the Active condition is managed by checkPendingActivation
+	pendingActivation := e.checkPendingActivation(ctx, logger, scaledObject, isActive)
+
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
-	if condition.IsUnknown() || condition.IsTrue() != isActive {
+	if !pendingActivation && (condition.IsUnknown() || condition.IsTrue() != isActive) {
 		if isActive {
 			if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionTrue, "ScalerActive", "Scaling is performed because triggers are active"); err != nil {
 				logger.Error(err, "Error setting active condition when triggers are active")
@@ -230,8 +257,108 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 	}
 }
 
-func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, currentScale *autoscalingv1.Scale, logger logr.Logger, currentReplicas int32) {
+// RequestDryRunScale records the replica count the ScaledObject would scale its target to in status.recommendedReplicaCount
+// and in Prometheus metrics, instead of scaling the target. metricReplicaCount is the replica count the HPA would calculate
+// for the metric values of the triggers, it's used while the triggers are active.
+func (e *scaleExecutor) RequestDryRunScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, metricReplicaCount int32) {
+	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name,
+		"scaledObject.Namespace", scaledObject.Namespace,
+		"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
+
+	recommendedReplicas := getRecommendedReplicaCount(scaledObject, isActive, isError, metricReplicaCount)
+	logger.V(1).Info("ScaledObject is in dry-run mode, ScaleTarget isn't scaled", "Recommended Replicas Count", recommendedReplicas)
+	prommetrics.RecordRecommendedReplicas(scaledObject.Namespace, scaledObject.Name, recommendedReplicas)
+
+	if scaledObject.Status.RecommendedReplicaCount == nil || *scaledObject.Status.RecommendedReplicaCount != recommendedReplicas {
+		status := scaledObject.Status.DeepCopy()
+		status.RecommendedReplicaCount = &recommendedReplicas
+		if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, e.client, logger, scaledObject, status); err != nil {
+			logger.Error(err, "Error setting recommended replica count")
+		}
+	}
+
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	if condition.IsUnknown() || condition.IsTrue() != isActive {
+		if isActive {
+			if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionTrue, "ScalerActiveDryRun", "Scaling isn't performed in dry-run mode, triggers are active"); err != nil {
+				logger.Error(err, "Error setting active condition when triggers are active")
+			}
+		} else {
+			if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "ScalerNotActiveDryRun", "Scaling isn't performed in dry-run mode, triggers are not active"); err != nil {
+				logger.Error(err, "Error setting active condition when triggers are not active")
+			}
+		}
+	}
+}
+
+// RecordDesiredReplicas records the replica count the scale target of the ScaledObject is scaled to, the paused replica
+// count of paused ScaledObjects, otherwise the replica count recommended for the activity and metric values of the triggers
+func (e *scaleExecutor) RecordDesiredReplicas(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, metricReplicaCount int32) {
+	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name,
+		"scaledObject.Namespace", scaledObject.Namespace,
+		"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
+
+	desiredReplicas, err := getDesiredReplicaCount(scaledObject, isActive, isError, metricReplicaCount)
+	if err != nil {
+		logger.Error(err, "error getting the paused replica count on the current ScaledObject.")
+		return
+	}
+	prommetrics.RecordDesiredReplicas(scaledObject.Namespace, scaledObject.Name, desiredReplicas)
+
+	if scaledObject.Status.DesiredReplicaCount == nil || *scaledObject.Status.DesiredReplicaCount != desiredReplicas {
+		status := scaledObject.Status.DeepCopy()
+		status.DesiredReplicaCount = &desiredReplicas
+		if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, e.client, logger, scaledObject, status); err != nil {
+			logger.Error(err, "Error setting desired replica count")
+		}
+	}
+}
+
+func getDesiredReplicaCount(scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, metricReplicaCount int32) (int32, error) {
+	pausedCount, err := GetPausedReplicaCount(scaledObject)
+	if err != nil {
+		return 0, err
+	}
+	if pausedCount != nil {
+		return *pausedCount, nil
+	}
+	return getRecommendedReplicaCount(scaledObject, isActive, isError, metricReplicaCount), nil
+}
+
+// getRecommendedReplicaCount returns the replica count KEDA would scale the scale target to, ie. fallback replicas
+// if the inactive triggers fail, idleReplicaCount or minReplicaCount if the triggers are inactive,
+// otherwise the replica count calculated for the metric values within the bounds of the HPA
+func getRecommendedReplicaCount(scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, metricReplicaCount int32) int32 {
+	if !isActive {
+		if isError && scaledObject.Spec.Fallback != nil && scaledObject.Spec.Fallback.Replicas != 0 {
+			return scaledObject.Spec.Fallback.Replicas
+		}
+		_, replicas := getIdleOrMinimumReplicaCount(scaledObject)
+		return replicas
+	}
+
+	minReplicas := int32(1)
+	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > minReplicas {
+		minReplicas = *scaledObject.Spec.MinReplicaCount
+	}
+	maxReplicas := defaultMaxReplicaCount
+	if scaledObject.Spec.MaxReplicaCount != nil {
+		maxReplicas = *scaledObject.Spec.MaxReplicaCount
+	}
+
+	switch {
+	case metricReplicaCount < minReplicas:
+		return minReplicas
+	case metricReplicaCount > maxReplicas:
+		return maxReplicas
+	default:
+		return metricReplicaCount
+	}
+}
+
+func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, currentScale *autoscalingv1.Scale, logger logr.Logger, currentReplicas int32, input scalingInput) {
 	_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, scaledObject.Spec.Fallback.Replicas)
+	e.recordScalingDecision(ctx, logger, scaledObject, input, kedav1alpha1.ScalingActionFallback, currentReplicas, scaledObject.Spec.Fallback.Replicas, err)
 	if err == nil {
 		logger.Info("Successfully set ScaleTarget replicas count to ScaledObject fallback.replicas",
 			"Original Replicas Count", currentReplicas,
@@ -243,8 +370,40 @@ func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *ked
 }
 
 // An object will be scaled down to 0 only if it's passed its cooldown period
-// or if LastActiveTime is nil
-func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
+// or if LastActiveTime is nil, and the triggers were inactive for advanced.scaleToZeroInactiveChecks consecutive polls.
+// A freshly created object isn't scaled down to 0 before its initialCooldownPeriod is over.
+func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, input scalingInput) {
+	if scaledObject.Spec.InitialCooldownPeriod != nil {
+		initialCooldownPeriod := time.Second * time.Duration(*scaledObject.Spec.InitialCooldownPeriod)
+		if scaledObject.CreationTimestamp.Add(initialCooldownPeriod).After(time.Now()) {
+			logger.V(1).Info("ScaleTarget in initial cooldown",
+				"CreationTimestamp", scaledObject.CreationTimestamp,
+				"InitialCooldownPeriod", initialCooldownPeriod)
+
+			activeCondition := scaledObject.Status.Conditions.GetActiveCondition()
+			if !activeCondition.IsFalse() || activeCondition.Reason != "ScalerInitialCooldown" {
+				if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "ScalerInitialCooldown", "Scaler in initial cooldown after creation of the ScaledObject"); err != nil {
+					logger.Error(err, "Error in setting active condition")
+				}
+			}
+			return
+		}
+	}
+
+	if checks, required := e.countInactiveCheck(scaledObject, input.isPoll); checks < required {
+		logger.V(1).Info("ScaleTarget stabilizing before scale to zero",
+			"InactiveChecks", checks,
+			"ScaleToZeroInactiveChecks", required)
+
+		activeCondition := scaledObject.Status.Conditions.GetActiveCondition()
+		if !activeCondition.IsFalse() || activeCondition.Reason != "ScalerStabilizing" {
+			if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "ScalerStabilizing", "Scaler waiting for consecutive inactive checks before scaling to zero"); err != nil {
+				logger.Error(err, "Error in setting active condition")
+			}
+		}
+		return
+	}
+
 	var cooldownPeriod time.Duration
 
 	if scaledObject.Spec.CooldownPeriod != nil {
@@ -262,7 +421,9 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 		idleValue, scaleToReplicas := getIdleOrMinimumReplicaCount(scaledObject)
 
 		currentReplicas, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, scaleToReplicas)
+		e.recordScalingDecision(ctx, logger, scaledObject, input, kedav1alpha1.ScalingActionDeactivate, currentReplicas, scaleToReplicas, err)
 		if err == nil {
+			e.ResetInactiveChecks(scaledObject)
 			msg := "Successfully set ScaleTarget replicas count to ScaledObject"
 			if idleValue {
 				msg += " idleReplicaCount"
@@ -270,8 +431,11 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 				msg += " minReplicaCount"
 			}
 			logger.Info(msg, "Original Replicas Count", currentReplicas, "New Replicas Count", scaleToReplicas)
+			if scaleToReplicas == 0 {
+				prommetrics.RecordScaleToZero(scaledObject.Namespace, scaledObject.Name)
+			}
 
-			e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetDeactivated,
+			e.recorder.AnnotatedEventf(scaledObject, map[string]string{eventemitter.ReplicasAnnotation: strconv.Itoa(int(scaleToReplicas))}, corev1.EventTypeNormal, eventreason.KEDAScaleTargetDeactivated,
 				"Deactivated %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, scaleToReplicas)
 			if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "ScalerNotActive", "Scaling is not performed because triggers are not active"); err != nil {
 				logger.Error(err, "Error in setting active condition")
@@ -296,7 +460,7 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 	}
 }
 
-func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
+func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, input scalingInput) {
 	var replicas int32
 	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > 0 {
 		replicas = *scaledObject.Spec.MinReplicaCount
@@ -305,45 +469,207 @@ func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Log
 	}
 
 	currentReplicas, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, replicas)
+	e.recordScalingDecision(ctx, logger, scaledObject, input, kedav1alpha1.ScalingActionActivate, currentReplicas, replicas, err)
 
 	if err == nil {
 		logger.Info("Successfully updated ScaleTarget",
 			"Original Replicas Count", currentReplicas,
 			"New Replicas Count", replicas)
-		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
 
 		// Scale was successful. Update lastScaleTime and lastActiveTime on the scaledObject
 		if err := e.updateLastActiveTime(ctx, logger, scaledObject); err != nil {
 			logger.Error(err, "Error in Updating lastScaleTime and lastActiveTime on the scaledObject")
 			return
 		}
+
+		// activation is reported once the scale target is ready, if requested
+		if getActivationReadinessConfig(scaledObject) != nil {
+			e.startPendingActivation(ctx, logger, scaledObject)
+			return
+		}
+		e.recorder.AnnotatedEventf(scaledObject, map[string]string{eventemitter.ReplicasAnnotation: strconv.Itoa(int(replicas))}, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
 	} else {
 		e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetActivationFailed, "Failed to scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
 	}
 }
 
-func (e *scaleExecutor) getScaleTargetScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (*autoscalingv1.Scale, error) {
-	return e.scaleClient.Scales(scaledObject.Namespace).Get(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+// countInactiveCheck records an inactive poll of the ScaledObject, it returns the number of consecutive
+// inactive polls and the number required before scaling to zero (0 if the stabilization isn't enabled),
+// checks requested by push scalers aren't counted, so they don't shorten the stabilization
+func (e *scaleExecutor) countInactiveCheck(scaledObject *kedav1alpha1.ScaledObject, isPoll bool) (int32, int32) {
+	if scaledObject.Spec.Advanced == nil || scaledObject.Spec.Advanced.ScaleToZeroInactiveChecks == nil {
+		return 0, 0
+	}
+
+	key := scaledObject.GenerateIdentifier()
+	checks := int32(0)
+	if value, ok := e.inactiveChecks.Load(key); ok {
+		checks = value.(int32)
+	}
+	if isPoll {
+		checks++
+		e.inactiveChecks.Store(key, checks)
+	}
+	return checks, *scaledObject.Spec.Advanced.ScaleToZeroInactiveChecks
 }
 
-func (e *scaleExecutor) updateScaleOnScaleTarget(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, replicas int32) (int32, error) {
-	if scale == nil {
-		// Wasn't retrieved earlier, grab it now.
+// ResetInactiveChecks clears the number of consecutive inactive polls of the ScaledObject
+func (e *scaleExecutor) ResetInactiveChecks(scaledObject *kedav1alpha1.ScaledObject) {
+	e.inactiveChecks.Delete(scaledObject.GenerateIdentifier())
+}
+
+func getActivationReadinessConfig(scaledObject *kedav1alpha1.ScaledObject) *kedav1alpha1.ActivationReadinessConfig {
+	if scaledObject.Spec.Advanced == nil {
+		return nil
+	}
+	return scaledObject.Spec.Advanced.ActivationReadiness
+}
+
+// startPendingActivation marks the activation of the scale target as pending until the scale target is ready
+func (e *scaleExecutor) startPendingActivation(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) {
+	now := metav1.Now()
+	status := scaledObject.Status.DeepCopy()
+	status.PendingActivationTime = &now
+	status.Conditions.SetActiveCondition(metav1.ConditionUnknown, "ScaleTargetNotReady", "Scale target was activated, waiting for it to become ready")
+	if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, e.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "Error setting pending activation")
+		return
+	}
+	prommetrics.RecordPendingActivation(scaledObject.Namespace, scaledObject.Name, true)
+}
+
+// checkPendingActivation reports the activation of the scale target once it is ready, or emits an event if it doesn't become ready in time.
+// Returns true if the activation is still pending.
+func (e *scaleExecutor) checkPendingActivation(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, isActive bool) bool {
+	pendingActivationTime := scaledObject.Status.PendingActivationTime
+	if pendingActivationTime == nil {
+		prommetrics.RecordPendingActivation(scaledObject.Namespace, scaledObject.Name, false)
+		return false
+	}
+
+	readinessConfig := getActivationReadinessConfig(scaledObject)
+	ready := false
+	if isActive && readinessConfig != nil {
 		var err error
-		scale, err = e.getScaleTargetScale(ctx, scaledObject)
+		ready, err = e.isScaleTargetReady(ctx, scaledObject, readinessConfig.Condition)
 		if err != nil {
-			return -1, err
+			logger.Error(err, "Error checking readiness of the scale target")
+		}
+		if !ready {
+			timeout := time.Second * time.Duration(defaultActivationReadinessTimeout)
+			if readinessConfig.TimeoutSeconds != nil {
+				timeout = time.Second * time.Duration(*readinessConfig.TimeoutSeconds)
+			}
+			activeCondition := scaledObject.Status.Conditions.GetActiveCondition()
+			if pendingActivationTime.Add(timeout).Before(time.Now()) && activeCondition.Reason != "ScaleTargetReadinessTimeout" {
+				msg := fmt.Sprintf("%s %s/%s isn't ready %s after activation", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, timeout)
+				e.recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetReadinessTimeout, msg)
+				if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionUnknown, "ScaleTargetReadinessTimeout", msg); err != nil {
+					logger.Error(err, "Error setting active condition")
+				}
+			}
+			prommetrics.RecordPendingActivation(scaledObject.Namespace, scaledObject.Name, true)
+			return true
 		}
+		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Activated %s %s/%s, scale target became ready after %s",
+			scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, time.Since(pendingActivationTime.Time).Round(time.Second))
 	}
 
-	// Update with requested repliacs.
-	currentReplicas := scale.Spec.Replicas
-	scale.Spec.Replicas = replicas
+	// the scale target is ready, triggers aren't active anymore or readiness isn't required anymore
+	status := scaledObject.Status.DeepCopy()
+	status.PendingActivationTime = nil
+	if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, e.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "Error clearing pending activation")
+	}
+	prommetrics.RecordPendingActivation(scaledObject.Namespace, scaledObject.Name, false)
+	return false
+}
+
+// isScaleTargetReady checks whether the scale target has the condition set to True,
+// or whether it has at least one ready replica if no condition is specified
+func (e *scaleExecutor) isScaleTargetReady(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, conditionType string) (bool, error) {
+	if scaledObject.Status.ScaleTargetGVKR == nil {
+		return false, fmt.Errorf("scale target of ScaledObject %s/%s isn't resolved yet", scaledObject.Namespace, scaledObject.Name)
+	}
+
+	target := &unstructured.Unstructured{}
+	target.SetGroupVersionKind(scaledObject.Status.ScaleTargetGVKR.GroupVersionKind())
+	if err := e.client.Get(ctx, client.ObjectKey{Name: scaledObject.Spec.ScaleTargetRef.Name, Namespace: scaledObject.Namespace}, target); err != nil {
+		return false, err
+	}
+
+	if conditionType == "" {
+		readyReplicas, _, err := unstructured.NestedInt64(target.Object, "status", "readyReplicas")
+		return readyReplicas > 0, err
+	}
 
-	_, err := e.scaleClient.Scales(scaledObject.Namespace).Update(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scale, metav1.UpdateOptions{})
+	conditions, _, err := unstructured.NestedSlice(target.Object, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == conditionType {
+			return condition["status"] == string(metav1.ConditionTrue), nil
+		}
+	}
+	return false, nil
+}
+
+func (e *scaleExecutor) getScaleTargetScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (*autoscalingv1.Scale, error) {
+	return e.scaleClient.Scales(scaledObject.Namespace).Get(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+}
+
+// updateScaleOnScaleTarget updates the scale subresource of the scale target with the replicas,
+// the update is retried with backoff on transient errors of the API server and on conflicts
+func (e *scaleExecutor) updateScaleOnScaleTarget(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, replicas int32) (int32, error) {
+	currentReplicas := int32(-1)
+	err := retry.OnError(scaleUpdateBackoff, isRetriableScaleError, func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if scale == nil {
+			// Wasn't retrieved earlier (or it's outdated), grab it now.
+			var err error
+			scale, err = e.getScaleTargetScale(ctx, scaledObject)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Update with requested repliacs.
+		currentReplicas = scale.Spec.Replicas
+		scale.Spec.Replicas = replicas
+
+		_, err := e.scaleClient.Scales(scaledObject.Namespace).Update(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scale, metav1.UpdateOptions{})
+		if err != nil {
+			scale.Spec.Replicas = currentReplicas
+			if apierrors.IsConflict(err) {
+				// the scale target was changed meanwhile, the scale with the current resourceVersion is needed
+				scale = nil
+			}
+		}
+		return err
+	})
+	if err != nil {
+		prommetrics.RecordInternalError("scale_executor")
+	}
 	return currentReplicas, err
 }
 
+// isRetriableScaleError returns true for errors of the API server, which are likely to disappear on retry
+func isRetriableScaleError(err error) bool {
+	return apierrors.IsConflict(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err) ||
+		utilnet.IsConnectionRefused(err) ||
+		utilnet.IsConnectionReset(err) ||
+		utilnet.IsProbableEOF(err)
+}
+
 // getIdleOrMinimumReplicaCount returns true if the second value returned is from IdleReplicaCount
 // it returns false if it is from MinReplicaCount followed by the actual value
 func getIdleOrMinimumReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (bool, int32) {
@@ -18,14 +18,20 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
@@ -87,7 +93,7 @@ func TestScaleToFallbackReplicasWhenNotActiveAndIsError(t *testing.T) {
 	client.EXPECT().Status().Times(2).Return(statusWriter)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, true)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, true, true, nil)
 
 	assert.Equal(t, int32(5), scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetFallbackCondition()
@@ -148,7 +154,7 @@ func TestScaleToMinReplicasWhenNotActive(t *testing.T) {
 	client.EXPECT().Status().Return(statusWriter).Times(2)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, true, nil)
 
 	assert.Equal(t, minReplicas, scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
@@ -209,7 +215,7 @@ func TestScaleToMinReplicasFromLowerInitialReplicaCount(t *testing.T) {
 	client.EXPECT().Status().Return(statusWriter).Times(2)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, true, nil)
 
 	assert.Equal(t, minReplicas, scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
@@ -268,7 +274,7 @@ func TestScaleFromMinReplicasWhenActive(t *testing.T) {
 	client.EXPECT().Status().Times(2).Return(statusWriter).Times(3)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, true, nil)
 
 	assert.Equal(t, int32(1), scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
@@ -331,7 +337,7 @@ func TestScaleToIdleReplicasWhenNotActive(t *testing.T) {
 	client.EXPECT().Status().Return(statusWriter).Times(2)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, true, nil)
 
 	assert.Equal(t, idleReplicas, scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
@@ -392,7 +398,7 @@ func TestScaleFromIdleToMinReplicasWhenActive(t *testing.T) {
 	client.EXPECT().Status().Times(2).Return(statusWriter).Times(3)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, true, nil)
 
 	assert.Equal(t, minReplicas, scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
@@ -454,9 +460,502 @@ func TestScaleToPausedReplicasCount(t *testing.T) {
 	client.EXPECT().Status().Return(statusWriter).Times(2)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, true, nil)
 
 	assert.Equal(t, pausedReplicaCount, scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
 	assert.Equal(t, false, condition.IsTrue())
 }
+
+func TestActivationPendingUntilScaleTargetIsReady(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	scaledObject := newActivationReadinessScaledObject(nil)
+
+	expectScaleTargetGet(client, 0, 0)
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: 0,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+
+	client.EXPECT().Status().Return(statusWriter).Times(2)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, true, nil)
+
+	assert.Equal(t, int32(1), scale.Spec.Replicas)
+	assert.NotNil(t, scaledObject.Status.PendingActivationTime)
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.Equal(t, true, condition.IsUnknown())
+	assert.Equal(t, "ScaleTargetNotReady", condition.Reason)
+	assert.Equal(t, 0, len(recorder.Events))
+}
+
+func TestActivationReadinessTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	pendingActivationTime := v1.NewTime(time.Now().Add(-10 * time.Minute))
+	scaledObject := newActivationReadinessScaledObject(&pendingActivationTime)
+
+	expectScaleTargetGet(client, 1, 0)
+
+	client.EXPECT().Status().Return(statusWriter).Times(2)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, true, nil)
+
+	assert.NotNil(t, scaledObject.Status.PendingActivationTime)
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.Equal(t, true, condition.IsUnknown())
+	assert.Equal(t, "ScaleTargetReadinessTimeout", condition.Reason)
+	assert.Equal(t, 1, len(recorder.Events))
+	assert.Contains(t, <-recorder.Events, "KEDAScaleTargetReadinessTimeout")
+}
+
+func TestActivationReportedWhenScaleTargetIsReady(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	pendingActivationTime := v1.NewTime(time.Now().Add(-10 * time.Second))
+	scaledObject := newActivationReadinessScaledObject(&pendingActivationTime)
+
+	expectScaleTargetGet(client, 1, 1)
+
+	client.EXPECT().Status().Return(statusWriter).Times(3)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, true, nil)
+
+	assert.Nil(t, scaledObject.Status.PendingActivationTime)
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.Equal(t, true, condition.IsTrue())
+	assert.Equal(t, 1, len(recorder.Events))
+	assert.Contains(t, <-recorder.Events, "KEDAScaleTargetActivated")
+}
+
+func TestScaleToZeroAfterConsecutiveInactiveChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	minReplicas := int32(0)
+	inactiveChecks := int32(2)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			MinReplicaCount: &minReplicas,
+			Advanced: &v1alpha1.AdvancedConfig{
+				ScaleToZeroInactiveChecks: &inactiveChecks,
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	numberOfReplicas := int32(10)
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+		},
+	}).Times(3)
+
+	client.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	// the first inactive check doesn't scale to zero
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, true, nil)
+
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.Equal(t, true, condition.IsFalse())
+	assert.Equal(t, "ScalerStabilizing", condition.Reason)
+
+	// an active check resets the number of inactive checks
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, true, nil)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, true, nil)
+
+	condition = scaledObject.Status.Conditions.GetActiveCondition()
+	assert.Equal(t, "ScalerStabilizing", condition.Reason)
+
+	// the second consecutive inactive check scales to zero once the cooldown period is over
+	scaledObject.Status.LastActiveTime = nil
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+		},
+	})
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: numberOfReplicas,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, true, nil)
+
+	assert.Equal(t, minReplicas, scale.Spec.Replicas)
+	condition = scaledObject.Status.Conditions.GetActiveCondition()
+	assert.Equal(t, "ScalerNotActive", condition.Reason)
+}
+
+func TestNoScaleToZeroDuringInitialCooldownPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	minReplicas := int32(0)
+	initialCooldownPeriod := int32(300)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:              "name",
+			Namespace:         "namespace",
+			CreationTimestamp: v1.Now(),
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			MinReplicaCount:       &minReplicas,
+			InitialCooldownPeriod: &initialCooldownPeriod,
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	numberOfReplicas := int32(10)
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+		},
+	})
+
+	client.EXPECT().Status().Return(statusWriter).Times(2)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, true, nil)
+
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.Equal(t, true, condition.IsFalse())
+	assert.Equal(t, "ScalerInitialCooldown", condition.Reason)
+}
+
+func TestDryRunRecordsRecommendedReplicaCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	minReplicas := int32(2)
+	maxReplicas := int32(10)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			MinReplicaCount: &minReplicas,
+			MaxReplicaCount: &maxReplicas,
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	// the scale target isn't scaled, only the status is patched
+	client.EXPECT().Status().Return(statusWriter).Times(2)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RequestDryRunScale(context.TODO(), &scaledObject, true, false, 4)
+
+	assert.Equal(t, int32(4), *scaledObject.Status.RecommendedReplicaCount)
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.Equal(t, true, condition.IsTrue())
+	assert.Equal(t, "ScalerActiveDryRun", condition.Reason)
+}
+
+func TestGetRecommendedReplicaCount(t *testing.T) {
+	minReplicas := int32(2)
+	maxReplicas := int32(10)
+	idleReplicas := int32(0)
+
+	scaledObject := &v1alpha1.ScaledObject{
+		Spec: v1alpha1.ScaledObjectSpec{
+			MinReplicaCount: &minReplicas,
+			MaxReplicaCount: &maxReplicas,
+			Fallback: &v1alpha1.Fallback{
+				FailureThreshold: 3,
+				Replicas:         5,
+			},
+		},
+	}
+	idleScaledObject := scaledObject.DeepCopy()
+	idleScaledObject.Spec.IdleReplicaCount = &idleReplicas
+
+	tests := []struct {
+		name               string
+		scaledObject       *v1alpha1.ScaledObject
+		isActive           bool
+		isError            bool
+		metricReplicaCount int32
+		expected           int32
+	}{
+		{"active", scaledObject, true, false, 4, 4},
+		{"active below min", scaledObject, true, false, 1, 2},
+		{"active above max", scaledObject, true, false, 20, 10},
+		{"active without metric replicas", &v1alpha1.ScaledObject{}, true, false, 0, 1},
+		{"inactive", scaledObject, false, false, 4, 2},
+		{"inactive with idle", idleScaledObject, false, false, 4, 0},
+		{"inactive with error", scaledObject, false, true, 0, 5},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, getRecommendedReplicaCount(test.scaledObject, test.isActive, test.isError, test.metricReplicaCount), test.name)
+	}
+}
+
+func TestRecordDesiredReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	scaleExecutor := NewScaleExecutor(client, nil, nil, record.NewFakeRecorder(1))
+
+	minReplicas := int32(1)
+	scaledObject := &v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{Name: "name", Namespace: "namespace"},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef:  &v1alpha1.ScaleTarget{Name: "name"},
+			MinReplicaCount: &minReplicas,
+		},
+	}
+
+	client.EXPECT().Status().Return(statusWriter).Times(2)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RecordDesiredReplicas(context.TODO(), scaledObject, true, false, 4)
+	assert.Equal(t, int32(4), *scaledObject.Status.DesiredReplicaCount)
+
+	// the status isn't patched if the desired replica count didn't change
+	scaleExecutor.RecordDesiredReplicas(context.TODO(), scaledObject, true, false, 4)
+
+	// paused ScaledObjects are scaled to the paused replica count
+	scaledObject.Annotations = map[string]string{"autoscaling.keda.sh/paused-replicas": "2"}
+	scaleExecutor.RecordDesiredReplicas(context.TODO(), scaledObject, true, false, 4)
+	assert.Equal(t, int32(2), *scaledObject.Status.DesiredReplicaCount)
+}
+
+func TestUpdateScaleOnScaleTargetRetriesTransientErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	scaleExecutor := &scaleExecutor{scaleClient: mockScaleClient}
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+	scale := &autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: 2}}
+	currentScale := &autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: 3}}
+	groupResource := scaledObject.Status.ScaleTargetGVKR.GroupResource()
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).AnyTimes()
+	gomock.InOrder(
+		// the API server is unavailable
+		mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, apierrors.NewServiceUnavailable("unavailable")),
+		// the scale target was changed meanwhile, the current scale is fetched
+		mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, apierrors.NewConflict(groupResource, "name", errors.New("conflict"))),
+		mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(currentScale, nil),
+		mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(currentScale), gomock.Any()).Return(currentScale, nil),
+	)
+
+	currentReplicas, err := scaleExecutor.updateScaleOnScaleTarget(context.TODO(), &scaledObject, scale, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), currentReplicas)
+	assert.Equal(t, int32(5), currentScale.Spec.Replicas)
+
+	// other errors aren't retried
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, apierrors.NewForbidden(groupResource, "name", errors.New("forbidden")))
+	_, err = scaleExecutor.updateScaleOnScaleTarget(context.TODO(), &scaledObject, scale, 5)
+	assert.True(t, apierrors.IsForbidden(err))
+}
+
+func newActivationReadinessScaledObject(pendingActivationTime *v1.Time) v1alpha1.ScaledObject {
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			Advanced: &v1alpha1.AdvancedConfig{
+				ActivationReadiness: &v1alpha1.ActivationReadinessConfig{},
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group:   "apps",
+				Version: "v1",
+				Kind:    "Deployment",
+			},
+			PendingActivationTime: pendingActivationTime,
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+	scaledObject.Status.Conditions.SetReadyCondition(v1.ConditionTrue, v1alpha1.ScaledObjectConditionReadySucccesReason, v1alpha1.ScaledObjectConditionReadySuccessMessage)
+	if pendingActivationTime != nil {
+		scaledObject.Status.Conditions.SetActiveCondition(v1.ConditionUnknown, "ScaleTargetNotReady", "")
+	}
+	return scaledObject
+}
+
+// expectScaleTargetGet mocks getting the Deployment both as typed and unstructured object
+func expectScaleTargetGet(client *mock_client.MockClient, replicas int32, readyReplicas int64) {
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ types.NamespacedName, obj runtimeclient.Object, _ ...runtimeclient.GetOption) error {
+			switch o := obj.(type) {
+			case *appsv1.Deployment:
+				o.Spec.Replicas = &replicas
+			case *unstructured.Unstructured:
+				return unstructured.SetNestedField(o.Object, readyReplicas, "status", "readyReplicas")
+			}
+			return nil
+		}).AnyTimes()
+}
+
+func TestResetInactiveChecks(t *testing.T) {
+	scaleExecutor := &scaleExecutor{}
+
+	inactiveChecks := int32(3)
+	scaledObject := &v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			Advanced: &v1alpha1.AdvancedConfig{
+				ScaleToZeroInactiveChecks: &inactiveChecks,
+			},
+		},
+	}
+
+	scaleExecutor.countInactiveCheck(scaledObject, true)
+	checks, required := scaleExecutor.countInactiveCheck(scaledObject, true)
+	assert.Equal(t, int32(2), checks)
+	assert.Equal(t, inactiveChecks, required)
+
+	scaleExecutor.ResetInactiveChecks(scaledObject)
+	_, found := scaleExecutor.inactiveChecks.Load(scaledObject.GenerateIdentifier())
+	assert.False(t, found, "inactive checks of the ScaledObject should be cleared")
+
+	checks, _ = scaleExecutor.countInactiveCheck(scaledObject, true)
+	assert.Equal(t, int32(1), checks)
+}
+
+func TestPushChecksAreNotCountedAsInactiveChecks(t *testing.T) {
+	scaleExecutor := &scaleExecutor{}
+
+	inactiveChecks := int32(2)
+	scaledObject := &v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			Advanced: &v1alpha1.AdvancedConfig{
+				ScaleToZeroInactiveChecks: &inactiveChecks,
+			},
+		},
+	}
+
+	checks, _ := scaleExecutor.countInactiveCheck(scaledObject, true)
+	assert.Equal(t, int32(1), checks)
+
+	// checks requested by push scalers don't count towards the inactive polls
+	checks, _ = scaleExecutor.countInactiveCheck(scaledObject, false)
+	assert.Equal(t, int32(1), checks)
+	checks, _ = scaleExecutor.countInactiveCheck(scaledObject, false)
+	assert.Equal(t, int32(1), checks)
+
+	checks, required := scaleExecutor.countInactiveCheck(scaledObject, true)
+	assert.Equal(t, int32(2), checks)
+	assert.Equal(t, inactiveChecks, required)
+}
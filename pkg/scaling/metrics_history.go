@@ -0,0 +1,318 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const (
+	// metricsHistoryMetricName is the metric recorded by KEDA Operator with the values of the triggers of ScaledObjects
+	metricsHistoryMetricName = "keda_scaler_metrics_value"
+
+	// metricsHistoryLoadTimeout is how long scale loops wait for the history to be loaded
+	metricsHistoryLoadTimeout = 10 * time.Second
+)
+
+// MetricsHistory reads recent metric values of ScaledObjects from a Prometheus remote-read endpoint at startup,
+// the values are replayed to windowed calculations of the scale loops (eg. adaptive polling) so they aren't reset
+// to empty on restart of KEDA Operator. It implements manager.Runnable and is started only on the leader.
+type MetricsHistory struct {
+	url        string
+	lookback   time.Duration
+	httpClient *http.Client
+
+	lock    sync.Mutex
+	history map[string][]map[string]float64
+	loaded  chan struct{}
+}
+
+// remoteReadSeries is a time series of a remote-read response
+type remoteReadSeries struct {
+	labels  map[string]string
+	samples []remoteReadSample
+}
+
+type remoteReadSample struct {
+	timestamp int64
+	value     float64
+}
+
+// NewMetricsHistory creates a new MetricsHistory reading the values of the last lookback from the remote-read url
+func NewMetricsHistory(url string, lookback time.Duration, httpClient *http.Client) *MetricsHistory {
+	return &MetricsHistory{
+		url:        url,
+		lookback:   lookback,
+		httpClient: httpClient,
+		history:    map[string][]map[string]float64{},
+		loaded:     make(chan struct{}),
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only the leader runs scale loops
+func (m *MetricsHistory) NeedLeaderElection() bool {
+	return true
+}
+
+// Start loads the history once, scale loops started before are waiting for it
+func (m *MetricsHistory) Start(ctx context.Context) error {
+	if err := m.load(ctx, time.Now()); err != nil {
+		log.Error(err, "error reading metrics history", "url", m.url)
+	}
+	close(m.loaded)
+	return nil
+}
+
+// ReplayHistory returns the metric values of the ScaledObject ordered from the oldest, by metric name,
+// the history is returned only once, so it isn't replayed again once the scale loop is restarted
+func (m *MetricsHistory) ReplayHistory(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) []map[string]float64 {
+	select {
+	case <-m.loaded:
+	case <-ctx.Done():
+		return nil
+	case <-time.After(metricsHistoryLoadTimeout):
+		return nil
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	identifier := scaledObject.GenerateIdentifier()
+	history := m.history[identifier]
+	delete(m.history, identifier)
+	return history
+}
+
+func (m *MetricsHistory) load(ctx context.Context, now time.Time) error {
+	series, err := m.read(ctx, now.Add(-m.lookback), now)
+	if err != nil {
+		return err
+	}
+
+	history := buildMetricsHistory(series)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.history = history
+	log.V(1).Info("Loaded metrics history", "url", m.url, "scaledObjects", len(history))
+	return nil
+}
+
+// buildMetricsHistory groups the samples by ScaledObject and timestamp, series with the same metric name
+// (eg. recorded by a previous KEDA Operator pod) are merged
+func buildMetricsHistory(series []remoteReadSeries) map[string][]map[string]float64 {
+	samplesByScaledObject := map[string]map[int64]map[string]float64{}
+	for _, s := range series {
+		namespace, scaledObject, metric := s.labels["namespace"], s.labels["scaledObject"], s.labels["metric"]
+		if scaledObject == "" || metric == "" {
+			continue
+		}
+		identifier := kedav1alpha1.GenerateIdentifier("ScaledObject", namespace, scaledObject)
+		if _, ok := samplesByScaledObject[identifier]; !ok {
+			samplesByScaledObject[identifier] = map[int64]map[string]float64{}
+		}
+		for _, sample := range s.samples {
+			if math.IsNaN(sample.value) {
+				continue
+			}
+			values, ok := samplesByScaledObject[identifier][sample.timestamp]
+			if !ok {
+				values = map[string]float64{}
+				samplesByScaledObject[identifier][sample.timestamp] = values
+			}
+			values[metric] = sample.value
+		}
+	}
+
+	history := make(map[string][]map[string]float64, len(samplesByScaledObject))
+	for identifier, samples := range samplesByScaledObject {
+		timestamps := make([]int64, 0, len(samples))
+		for timestamp := range samples {
+			timestamps = append(timestamps, timestamp)
+		}
+		sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+		for _, timestamp := range timestamps {
+			history[identifier] = append(history[identifier], samples[timestamp])
+		}
+	}
+	return history
+}
+
+// read queries the samples of metricsHistoryMetricName between start and end, using the protocol of Prometheus remote-read
+// with snappy compressed protobuf messages, https://prometheus.io/docs/prometheus/latest/querying/remote_read_api/
+func (m *MetricsHistory) read(ctx context.Context, start, end time.Time) ([]remoteReadSeries, error) {
+	body := snappy.Encode(nil, encodeRemoteReadRequest(start.UnixMilli(), end.UnixMilli(), map[string]string{"__name__": metricsHistoryMetricName}))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Read-Version", "0.1.0")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote-read returned status %d, response: %s", resp.StatusCode, string(b))
+	}
+
+	b, err = snappy.Decode(nil, b)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing remote-read response: %w", err)
+	}
+	return decodeRemoteReadResponse(b)
+}
+
+// encodeRemoteReadRequest encodes prometheus.ReadRequest with a single query of series matching all labels,
+// only raw samples are accepted in the response
+func encodeRemoteReadRequest(startMs, endMs int64, labels map[string]string) []byte {
+	var query []byte
+	query = protowire.AppendTag(query, 1, protowire.VarintType)
+	query = protowire.AppendVarint(query, uint64(startMs))
+	query = protowire.AppendTag(query, 2, protowire.VarintType)
+	query = protowire.AppendVarint(query, uint64(endMs))
+	for name, value := range labels {
+		// the type of the matcher is omitted, EQ is the default value
+		var matcher []byte
+		matcher = protowire.AppendTag(matcher, 2, protowire.BytesType)
+		matcher = protowire.AppendString(matcher, name)
+		matcher = protowire.AppendTag(matcher, 3, protowire.BytesType)
+		matcher = protowire.AppendString(matcher, value)
+		query = protowire.AppendTag(query, 3, protowire.BytesType)
+		query = protowire.AppendBytes(query, matcher)
+	}
+
+	var request []byte
+	request = protowire.AppendTag(request, 1, protowire.BytesType)
+	request = protowire.AppendBytes(request, query)
+	// accepted response type SAMPLES
+	request = protowire.AppendTag(request, 2, protowire.VarintType)
+	request = protowire.AppendVarint(request, 0)
+	return request
+}
+
+// decodeRemoteReadResponse decodes the series of all query results of prometheus.ReadResponse
+func decodeRemoteReadResponse(b []byte) ([]remoteReadSeries, error) {
+	var series []remoteReadSeries
+	err := forEachField(b, func(num protowire.Number, result []byte, _ uint64) error {
+		if num != 1 {
+			return nil
+		}
+		return forEachField(result, func(num protowire.Number, timeSeries []byte, _ uint64) error {
+			if num != 1 {
+				return nil
+			}
+			s, err := decodeRemoteReadSeries(timeSeries)
+			if err != nil {
+				return err
+			}
+			series = append(series, s)
+			return nil
+		})
+	})
+	return series, err
+}
+
+func decodeRemoteReadSeries(b []byte) (remoteReadSeries, error) {
+	series := remoteReadSeries{labels: map[string]string{}}
+	err := forEachField(b, func(num protowire.Number, value []byte, _ uint64) error {
+		switch num {
+		case 1:
+			var name, labelValue string
+			err := forEachField(value, func(num protowire.Number, value []byte, _ uint64) error {
+				switch num {
+				case 1:
+					name = string(value)
+				case 2:
+					labelValue = string(value)
+				}
+				return nil
+			})
+			series.labels[name] = labelValue
+			return err
+		case 2:
+			var sample remoteReadSample
+			err := forEachField(value, func(num protowire.Number, _ []byte, value uint64) error {
+				switch num {
+				case 1:
+					sample.value = math.Float64frombits(value)
+				case 2:
+					sample.timestamp = int64(value)
+				}
+				return nil
+			})
+			series.samples = append(series.samples, sample)
+			return err
+		}
+		return nil
+	})
+	return series, err
+}
+
+// forEachField calls fn with each field of the protobuf message, with the value of length-delimited fields
+// as bytes and the value of varint and fixed64 fields as number, other fields are skipped
+func forEachField(b []byte, fn func(num protowire.Number, bytes []byte, number uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var bytes []byte
+		var number uint64
+		switch typ {
+		case protowire.BytesType:
+			bytes, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			number, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			number, n = protowire.ConsumeFixed64(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if err := fn(num, bytes, number); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// encodeRemoteReadResponse encodes prometheus.ReadResponse with a single query result of the series
+func encodeRemoteReadResponse(series []remoteReadSeries) []byte {
+	var result []byte
+	for _, s := range series {
+		var timeSeries []byte
+		for name, value := range s.labels {
+			var label []byte
+			label = protowire.AppendTag(label, 1, protowire.BytesType)
+			label = protowire.AppendString(label, name)
+			label = protowire.AppendTag(label, 2, protowire.BytesType)
+			label = protowire.AppendString(label, value)
+			timeSeries = protowire.AppendTag(timeSeries, 1, protowire.BytesType)
+			timeSeries = protowire.AppendBytes(timeSeries, label)
+		}
+		for _, sample := range s.samples {
+			var encodedSample []byte
+			encodedSample = protowire.AppendTag(encodedSample, 1, protowire.Fixed64Type)
+			encodedSample = protowire.AppendFixed64(encodedSample, math.Float64bits(sample.value))
+			encodedSample = protowire.AppendTag(encodedSample, 2, protowire.VarintType)
+			encodedSample = protowire.AppendVarint(encodedSample, uint64(sample.timestamp))
+			timeSeries = protowire.AppendTag(timeSeries, 2, protowire.BytesType)
+			timeSeries = protowire.AppendBytes(timeSeries, encodedSample)
+		}
+		result = protowire.AppendTag(result, 1, protowire.BytesType)
+		result = protowire.AppendBytes(result, timeSeries)
+	}
+
+	var response []byte
+	response = protowire.AppendTag(response, 1, protowire.BytesType)
+	response = protowire.AppendBytes(response, result)
+	return response
+}
+
+func TestMetricsHistory(t *testing.T) {
+	now := time.Now()
+	series := []remoteReadSeries{
+		{
+			labels:  map[string]string{"__name__": metricsHistoryMetricName, "namespace": "default", "scaledObject": "consumer", "metric": "s0-queue", "scaler": "rabbitMQScaler"},
+			samples: []remoteReadSample{{timestamp: 2000, value: 12}, {timestamp: 1000, value: 10}},
+		},
+		{
+			// values of the same metric recorded by the previous KEDA Operator pod
+			labels:  map[string]string{"__name__": metricsHistoryMetricName, "namespace": "default", "scaledObject": "consumer", "metric": "s0-queue", "scaler": "rabbitMQScaler", "pod": "previous"},
+			samples: []remoteReadSample{{timestamp: 500, value: 9}},
+		},
+		{
+			labels:  map[string]string{"__name__": metricsHistoryMetricName, "namespace": "default", "scaledObject": "consumer", "metric": "s1-cpu", "scaler": "cpuMemoryScaler"},
+			samples: []remoteReadSample{{timestamp: 1000, value: 50}, {timestamp: 2000, value: math.NaN()}},
+		},
+		{
+			labels:  map[string]string{"__name__": metricsHistoryMetricName, "namespace": "other", "scaledObject": "consumer", "metric": "s0-queue", "scaler": "rabbitMQScaler"},
+			samples: []remoteReadSample{{timestamp: 1000, value: 3}},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		request, err := snappy.Decode(nil, body)
+		require.NoError(t, err)
+		assert.Equal(t, encodeRemoteReadRequest(now.Add(-5*time.Minute).UnixMilli(), now.UnixMilli(), map[string]string{"__name__": metricsHistoryMetricName}), request)
+
+		_, _ = w.Write(snappy.Encode(nil, encodeRemoteReadResponse(series)))
+	}))
+	defer server.Close()
+
+	metricsHistory := NewMetricsHistory(server.URL, 5*time.Minute, http.DefaultClient)
+	require.NoError(t, metricsHistory.load(context.Background(), now))
+	close(metricsHistory.loaded)
+
+	scaledObject := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default"}}
+	expected := []map[string]float64{
+		{"s0-queue": 9},
+		{"s0-queue": 10, "s1-cpu": 50},
+		{"s0-queue": 12},
+	}
+	assert.Equal(t, expected, metricsHistory.ReplayHistory(context.Background(), scaledObject))
+
+	// the history is replayed only once
+	assert.Nil(t, metricsHistory.ReplayHistory(context.Background(), scaledObject))
+}
+
+func TestMetricsHistoryReadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	metricsHistory := NewMetricsHistory(server.URL, 5*time.Minute, http.DefaultClient)
+	assert.Error(t, metricsHistory.load(context.Background(), time.Now()))
+}
+
+func TestReplayMetricsHistoryToAdaptivePolling(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			PollingInterval: pointer.Int32(10),
+			AdaptivePolling: &kedav1alpha1.AdaptivePollingConfig{MaxPollingInterval: 60},
+		},
+	}
+	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scaledObject)
+	require.NoError(t, err)
+
+	metricsHistory := NewMetricsHistory("", time.Minute, http.DefaultClient)
+	metricsHistory.history[scaledObject.GenerateIdentifier()] = []map[string]float64{
+		{"s0-queue": 5}, {"s0-queue": 5}, {"s0-queue": 5}, {"s0-queue": 5},
+	}
+	close(metricsHistory.loaded)
+	sh := scaleHandler{metricsHistory: metricsHistory}
+
+	adaptivePolling := newAdaptivePolling(withTriggers)
+	assert.True(t, sh.replayMetricsHistory(context.Background(), scaledObject, adaptivePolling))
+	assert.Equal(t, 20*time.Second, adaptivePolling.interval)
+
+	// without history the adaptive polling starts from scratch
+	adaptivePolling = newAdaptivePolling(withTriggers)
+	assert.False(t, sh.replayMetricsHistory(context.Background(), scaledObject, adaptivePolling))
+	assert.Equal(t, 10*time.Second, adaptivePolling.interval)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/go-logr/logr"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// AwsSecretManagerHandler reads secrets of AWS Secrets Manager given in TriggerAuthentication
+type AwsSecretManagerHandler struct {
+	secretManager *kedav1alpha1.AwsSecretManager
+	client        secretsmanageriface.SecretsManagerAPI
+}
+
+// NewAwsSecretManagerHandler creates a AwsSecretManagerHandler object
+func NewAwsSecretManagerHandler(v *kedav1alpha1.AwsSecretManager) *AwsSecretManagerHandler {
+	return &AwsSecretManagerHandler{
+		secretManager: v,
+	}
+}
+
+// Initialize creates the client of AWS Secrets Manager authenticated by static credentials given by Kubernetes Secrets
+// or by the identity of KEDA Operator (IRSA) with aws-eks pod identity, the role given by roleArn is assumed if it's set
+func (ash *AwsSecretManagerHandler) Initialize(ctx context.Context, client client.Client, logger logr.Logger, triggerNamespace string, secretsLister corev1listers.SecretLister) error {
+	config := aws.NewConfig()
+	if ash.secretManager.Region != "" {
+		config = config.WithRegion(ash.secretManager.Region)
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return err
+	}
+
+	creds, err := ash.getCredentials(ctx, client, logger, sess, triggerNamespace, secretsLister)
+	if err != nil {
+		return err
+	}
+
+	ash.client = secretsmanager.New(sess, config.WithCredentials(creds))
+	return nil
+}
+
+func (ash *AwsSecretManagerHandler) getCredentials(ctx context.Context, client client.Client, logger logr.Logger, sess *session.Session,
+	triggerNamespace string, secretsLister corev1listers.SecretLister) (*credentials.Credentials, error) {
+	podIdentity := ash.secretManager.PodIdentity
+	if podIdentity == nil {
+		podIdentity = &kedav1alpha1.AuthPodIdentity{}
+	}
+	switch podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		missingErr := fmt.Errorf("accessKey and accessSecretKey are expected when not using a pod identity provider")
+		credentialsSpec := ash.secretManager.Credentials
+		if credentialsSpec == nil || credentialsSpec.AccessKey == nil || credentialsSpec.AccessSecretKey == nil {
+			return nil, missingErr
+		}
+
+		resolve := func(value *kedav1alpha1.AwsSecretManagerValue) string {
+			if value == nil {
+				return ""
+			}
+			return resolveAuthSecret(ctx, client, logger, value.ValueFrom.SecretKeyRef.Name, triggerNamespace, value.ValueFrom.SecretKeyRef.Key, secretsLister)
+		}
+		accessKey := resolve(credentialsSpec.AccessKey)
+		accessSecretKey := resolve(credentialsSpec.AccessSecretKey)
+		if accessKey == "" || accessSecretKey == "" {
+			return nil, missingErr
+		}
+		return credentials.NewStaticCredentials(accessKey, accessSecretKey, resolve(credentialsSpec.AccessToken)), nil
+	case kedav1alpha1.PodIdentityProviderAwsEKS:
+		if podIdentity.RoleArn != "" {
+			return stscreds.NewCredentials(sess, podIdentity.RoleArn), nil
+		}
+		return sess.Config.Credentials, nil
+	default:
+		return nil, fmt.Errorf("aws secret manager does not support pod identity provider - %s", podIdentity.Provider)
+	}
+}
+
+// Read returns the value of the secret, the value of the key of the JSON secret value is returned if secretKey is given
+func (ash *AwsSecretManagerHandler) Read(ctx context.Context, secret kedav1alpha1.AwsSecretManagerSecret) (string, error) {
+	input := &secretsmanager.GetSecretValueInput{SecretId: aws.String(secret.Name)}
+	if secret.VersionID != "" {
+		input.VersionId = aws.String(secret.VersionID)
+	}
+	if secret.VersionStage != "" {
+		input.VersionStage = aws.String(secret.VersionStage)
+	}
+	output, err := ash.client.GetSecretValueWithContext(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	var value string
+	switch {
+	case output.SecretString != nil:
+		value = *output.SecretString
+	case output.SecretBinary != nil:
+		value = string(output.SecretBinary)
+	default:
+		return "", fmt.Errorf("secret %s has no value", secret.Name)
+	}
+	if secret.SecretKey == "" {
+		return value, nil
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return "", fmt.Errorf("error parsing JSON value of secret %s: %w", secret.Name, err)
+	}
+	keyValue, ok := values[secret.SecretKey]
+	if !ok {
+		return "", fmt.Errorf("key '%s' not found in secret %s", secret.SecretKey, secret.Name)
+	}
+	if s, ok := keyValue.(string); ok {
+		return s, nil
+	}
+	// numbers, booleans and nested objects are returned as JSON
+	data, err := json.Marshal(keyValue)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+type mockSecretsManager struct {
+	secretsmanageriface.SecretsManagerAPI
+	secrets map[string]string
+}
+
+func (m *mockSecretsManager) GetSecretValueWithContext(_ context.Context, input *secretsmanager.GetSecretValueInput, _ ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+	id := *input.SecretId
+	if input.VersionStage != nil {
+		id += ":" + *input.VersionStage
+	}
+	value, ok := m.secrets[id]
+	if !ok {
+		return nil, fmt.Errorf("secret %s not found", id)
+	}
+	return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(value)}, nil
+}
+
+func TestAwsSecretManagerRead(t *testing.T) {
+	handler := NewAwsSecretManagerHandler(&kedav1alpha1.AwsSecretManager{})
+	handler.client = &mockSecretsManager{secrets: map[string]string{
+		"plain":                "connection-string",
+		"json":                 `{"username": "keda", "port": 5432}`,
+		"plain:AWSPREVIOUS":    "previous-connection-string",
+		"invalid-json":         "not json",
+		"json-without-the-key": `{"username": "keda"}`,
+	}}
+
+	tests := []struct {
+		secret   kedav1alpha1.AwsSecretManagerSecret
+		expected string
+		isError  bool
+	}{
+		{secret: kedav1alpha1.AwsSecretManagerSecret{Name: "plain"}, expected: "connection-string"},
+		{secret: kedav1alpha1.AwsSecretManagerSecret{Name: "plain", VersionStage: "AWSPREVIOUS"}, expected: "previous-connection-string"},
+		{secret: kedav1alpha1.AwsSecretManagerSecret{Name: "json", SecretKey: "username"}, expected: "keda"},
+		{secret: kedav1alpha1.AwsSecretManagerSecret{Name: "json", SecretKey: "port"}, expected: "5432"},
+		{secret: kedav1alpha1.AwsSecretManagerSecret{Name: "invalid-json", SecretKey: "username"}, isError: true},
+		{secret: kedav1alpha1.AwsSecretManagerSecret{Name: "json-without-the-key", SecretKey: "password"}, isError: true},
+		{secret: kedav1alpha1.AwsSecretManagerSecret{Name: "missing"}, isError: true},
+	}
+	for _, test := range tests {
+		value, err := handler.Read(context.Background(), test.secret)
+		if test.isError {
+			assert.Error(t, err, test.secret.Name)
+			continue
+		}
+		assert.NoError(t, err, test.secret.Name)
+		assert.Equal(t, test.expected, value, test.secret.Name)
+	}
+}
+
+func TestAwsSecretManagerCredentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		secretManager kedav1alpha1.AwsSecretManager
+		isError       bool
+	}{
+		{name: "no credentials", secretManager: kedav1alpha1.AwsSecretManager{}, isError: true},
+		{name: "no secret key", secretManager: kedav1alpha1.AwsSecretManager{Credentials: &kedav1alpha1.AwsSecretManagerCredentials{
+			AccessKey: &kedav1alpha1.AwsSecretManagerValue{},
+		}}, isError: true},
+		{name: "unsupported pod identity", secretManager: kedav1alpha1.AwsSecretManager{
+			PodIdentity: &kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzure},
+		}, isError: true},
+		{name: "aws-eks pod identity", secretManager: kedav1alpha1.AwsSecretManager{
+			PodIdentity: &kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS},
+			Region:      "eu-west-1",
+		}},
+		{name: "aws-eks pod identity with role", secretManager: kedav1alpha1.AwsSecretManager{
+			PodIdentity: &kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS, RoleArn: "arn:aws:iam::123456789012:role/keda"},
+			Region:      "eu-west-1",
+		}},
+	}
+	for _, test := range tests {
+		handler := NewAwsSecretManagerHandler(&test.secretManager)
+		err := handler.Initialize(context.Background(), nil, logr.Discard(), "default", nil)
+		if test.isError {
+			assert.Error(t, err, test.name)
+		} else {
+			assert.NoError(t, err, test.name)
+		}
+	}
+}
@@ -72,6 +72,9 @@ func (vh *AzureKeyVaultHandler) Read(ctx context.Context, secretName string, ver
 	if err != nil {
 		return "", err
 	}
+	if result.Value == nil {
+		return "", fmt.Errorf("secret %s has no value", secretName)
+	}
 
 	return *result.Value, nil
 }
@@ -110,7 +113,7 @@ func (vh *AzureKeyVaultHandler) getAuthConfig(ctx context.Context, client client
 	switch podIdentity.Provider {
 	case "", kedav1alpha1.PodIdentityProviderNone:
 		missingErr := fmt.Errorf("clientID, tenantID and clientSecret are expected when not using a pod identity provider")
-		if vh.vault.Credentials == nil {
+		if vh.vault.Credentials == nil || vh.vault.Credentials.ClientSecret == nil {
 			return nil, missingErr
 		}
 
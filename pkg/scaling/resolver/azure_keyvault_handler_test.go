@@ -17,9 +17,11 @@ limitations under the License.
 package resolver
 
 import (
+	"context"
 	"testing"
 
 	az "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/go-logr/logr"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
@@ -133,3 +135,18 @@ func TestGetPropertiesForCloud(t *testing.T) {
 		}
 	}
 }
+
+func TestGetAuthConfigWithoutClientSecret(t *testing.T) {
+	vh := NewAzureKeyVaultHandler(&kedav1alpha1.AzureKeyVault{
+		VaultURI: "https://keda.vault.azure.net",
+		Credentials: &kedav1alpha1.AzureKeyVaultCredentials{
+			ClientID: "clientID",
+			TenantID: "tenantID",
+		},
+	})
+
+	_, err := vh.getAuthConfig(context.Background(), nil, logr.Discard(), "default", testResourceURL, testActiveDirectoryEndpoint, nil)
+	if err == nil {
+		t.Fatal("expected error for credentials without clientSecret but got success")
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const (
+	gcpSecretManagerEndpoint = "https://secretmanager.googleapis.com/v1"
+	gcpCloudPlatformScope    = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// GCPSecretManagerHandler reads secrets of GCP Secret Manager given in TriggerAuthentication
+type GCPSecretManagerHandler struct {
+	secretManager *kedav1alpha1.GCPSecretManager
+	httpClient    *http.Client
+	endpoint      string
+	projectID     string
+}
+
+// NewGCPSecretManagerHandler creates a GCPSecretManagerHandler object
+func NewGCPSecretManagerHandler(v *kedav1alpha1.GCPSecretManager) *GCPSecretManagerHandler {
+	return &GCPSecretManagerHandler{
+		secretManager: v,
+		endpoint:      gcpSecretManagerEndpoint,
+	}
+}
+
+// Initialize creates the client of GCP Secret Manager authenticated by the service account key given by a Kubernetes Secret
+// or by the identity of KEDA Operator (Workload Identity) with gcp pod identity
+func (gsh *GCPSecretManagerHandler) Initialize(ctx context.Context, client client.Client, logger logr.Logger, triggerNamespace string, secretsLister corev1listers.SecretLister) error {
+	credentials, err := gsh.getCredentials(ctx, client, logger, triggerNamespace, secretsLister)
+	if err != nil {
+		return err
+	}
+
+	gsh.projectID = gsh.secretManager.ProjectID
+	if gsh.projectID == "" {
+		gsh.projectID = credentials.ProjectID
+	}
+	gsh.httpClient = oauth2.NewClient(ctx, credentials.TokenSource)
+	return nil
+}
+
+func (gsh *GCPSecretManagerHandler) getCredentials(ctx context.Context, client client.Client, logger logr.Logger,
+	triggerNamespace string, secretsLister corev1listers.SecretLister) (*google.Credentials, error) {
+	podIdentity := gsh.secretManager.PodIdentity
+	if podIdentity == nil {
+		podIdentity = &kedav1alpha1.AuthPodIdentity{}
+	}
+	switch podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		if gsh.secretManager.Credentials == nil {
+			return nil, fmt.Errorf("clientSecret is expected when not using a pod identity provider")
+		}
+		secretKeyRef := gsh.secretManager.Credentials.ClientSecret.ValueFrom.SecretKeyRef
+		clientSecret := resolveAuthSecret(ctx, client, logger, secretKeyRef.Name, triggerNamespace, secretKeyRef.Key, secretsLister)
+		if clientSecret == "" {
+			return nil, fmt.Errorf("clientSecret is expected when not using a pod identity provider")
+		}
+		return google.CredentialsFromJSON(ctx, []byte(clientSecret), gcpCloudPlatformScope)
+	case kedav1alpha1.PodIdentityProviderGCP:
+		return google.FindDefaultCredentials(ctx, gcpCloudPlatformScope)
+	default:
+		return nil, fmt.Errorf("gcp secret manager does not support pod identity provider - %s", podIdentity.Provider)
+	}
+}
+
+// Read returns the payload of the version of the secret
+func (gsh *GCPSecretManagerHandler) Read(ctx context.Context, secret kedav1alpha1.GCPSecretManagerSecret) (string, error) {
+	name, err := gsh.versionName(secret)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s:access", gsh.endpoint, name), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := gsh.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error accessing secret version %s: %s %s", name, resp.Status, string(body))
+	}
+
+	var accessResponse struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &accessResponse); err != nil {
+		return "", fmt.Errorf("error parsing secret version %s: %w", name, err)
+	}
+	data, err := base64.StdEncoding.DecodeString(accessResponse.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("error decoding payload of secret version %s: %w", name, err)
+	}
+	return string(data), nil
+}
+
+// versionName returns the resource name of the version of the secret, projects/<project>/secrets/<id>/versions/<version>
+func (gsh *GCPSecretManagerHandler) versionName(secret kedav1alpha1.GCPSecretManagerSecret) (string, error) {
+	version := secret.Version
+	if version == "" {
+		version = "latest"
+	}
+	if strings.HasPrefix(secret.ID, "projects/") {
+		return fmt.Sprintf("%s/versions/%s", secret.ID, version), nil
+	}
+	if gsh.projectID == "" {
+		return "", fmt.Errorf("projectId is expected for secret %s if the project isn't known from the credentials", secret.ID)
+	}
+	return fmt.Sprintf("projects/%s/secrets/%s/versions/%s", gsh.projectID, secret.ID, version), nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestGCPSecretManagerRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := map[string]string{
+			"/projects/keda/secrets/password/versions/latest:access": "latest-password",
+			"/projects/keda/secrets/password/versions/2:access":      "second-password",
+			"/projects/other/secrets/token/versions/latest:access":   "token",
+		}
+		data, ok := payload[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "status": "NOT_FOUND"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"name": "%s", "payload": {"data": "%s"}}`, r.URL.Path, base64.StdEncoding.EncodeToString([]byte(data)))
+	}))
+	defer server.Close()
+
+	handler := NewGCPSecretManagerHandler(&kedav1alpha1.GCPSecretManager{})
+	handler.httpClient = server.Client()
+	handler.endpoint = server.URL
+	handler.projectID = "keda"
+
+	tests := []struct {
+		secret   kedav1alpha1.GCPSecretManagerSecret
+		expected string
+		isError  bool
+	}{
+		{secret: kedav1alpha1.GCPSecretManagerSecret{ID: "password"}, expected: "latest-password"},
+		{secret: kedav1alpha1.GCPSecretManagerSecret{ID: "password", Version: "2"}, expected: "second-password"},
+		{secret: kedav1alpha1.GCPSecretManagerSecret{ID: "projects/other/secrets/token"}, expected: "token"},
+		{secret: kedav1alpha1.GCPSecretManagerSecret{ID: "missing"}, isError: true},
+	}
+	for _, test := range tests {
+		value, err := handler.Read(context.Background(), test.secret)
+		if test.isError {
+			assert.Error(t, err, test.secret.ID)
+			continue
+		}
+		assert.NoError(t, err, test.secret.ID)
+		assert.Equal(t, test.expected, value, test.secret.ID)
+	}
+
+	// the project must be known for secrets given by their IDs
+	handler.projectID = ""
+	_, err := handler.Read(context.Background(), kedav1alpha1.GCPSecretManagerSecret{ID: "password"})
+	assert.Error(t, err)
+}
+
+func TestGCPSecretManagerCredentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		secretManager kedav1alpha1.GCPSecretManager
+	}{
+		{name: "no credentials", secretManager: kedav1alpha1.GCPSecretManager{}},
+		{name: "unsupported pod identity", secretManager: kedav1alpha1.GCPSecretManager{
+			PodIdentity: &kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS},
+		}},
+	}
+	for _, test := range tests {
+		handler := NewGCPSecretManagerHandler(&test.secretManager)
+		err := handler.Initialize(context.Background(), nil, logr.Discard(), "default", nil)
+		assert.Error(t, err, test.name)
+	}
+}
@@ -17,9 +17,17 @@ limitations under the License.
 package resolver
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
 	vaultapi "github.com/hashicorp/vault/api"
@@ -27,17 +35,41 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
+// vaultTokenExpiryMargin is how long before the expiration of a token which can't be renewed a new one is requested
+const vaultTokenExpiryMargin = 30 * time.Second
+
+// vaultHandlers caches authenticated Vault clients by the configuration of HashiCorpVault, so KEDA doesn't log in
+// to Vault each time scalers are built, the tokens are renewed in the background while they are cached
+var vaultHandlers = &hashicorpVaultHandlerCache{handlers: map[string]*HashicorpVaultHandler{}}
+
 // HashicorpVaultHandler is specification of Hashi Corp Vault
 type HashicorpVaultHandler struct {
 	vault  *kedav1alpha1.HashiCorpVault
 	client *vaultapi.Client
-	stopCh chan struct{}
+
+	// expiresAt is the expiration of a token which isn't renewed, it's zero for renewed tokens
+	expiresAt time.Time
+	// expired is set once the token can't be used anymore, eg. its renewal failed
+	expired  int32
+	stopCh   chan struct{}
+	stopOnce sync.Once
+
+	kvMountsLock sync.Mutex
+	kvMounts     map[string]vaultKVMount
+}
+
+// vaultKVMount is the mount of the secrets engine of a path and the version of KV secrets engine
+type vaultKVMount struct {
+	path    string
+	version string
 }
 
 // NewHashicorpVaultHandler creates a HashicorpVaultHandler object
 func NewHashicorpVaultHandler(v *kedav1alpha1.HashiCorpVault) *HashicorpVaultHandler {
 	return &HashicorpVaultHandler{
-		vault: v,
+		vault:    v,
+		stopCh:   make(chan struct{}),
+		kvMounts: map[string]vaultKVMount{},
 	}
 }
 
@@ -73,14 +105,15 @@ func (vh *HashicorpVaultHandler) Initialize(logger logr.Logger) error {
 		return err
 	}
 
-	renew := lookup.Data["renewable"].(bool)
+	vh.client = client
+
+	renew, _ := lookup.Data["renewable"].(bool)
 	if renew {
-		vh.stopCh = make(chan struct{})
 		go vh.renewToken(logger)
+	} else if ttl, err := lookup.TokenTTL(); err == nil && ttl > 0 {
+		vh.expiresAt = time.Now().Add(ttl)
 	}
 
-	vh.client = client
-
 	return nil
 }
 
@@ -93,7 +126,7 @@ func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error)
 		switch {
 		case len(client.Token()) > 0:
 			break
-		case len(vh.vault.Credential.Token) > 0:
+		case vh.vault.Credential != nil && len(vh.vault.Credential.Token) > 0:
 			token = vh.vault.Credential.Token
 		default:
 			return token, errors.New("could not get Vault token")
@@ -107,7 +140,7 @@ func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error)
 			return token, errors.New("k8s role not in config")
 		}
 
-		if len(vh.vault.Credential.ServiceAccount) == 0 {
+		if vh.vault.Credential == nil || len(vh.vault.Credential.ServiceAccount) == 0 {
 			return token, errors.New("k8s SA file not in config")
 		}
 
@@ -122,6 +155,9 @@ func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error)
 		if err != nil {
 			return token, err
 		}
+		if secret == nil || secret.Auth == nil {
+			return token, fmt.Errorf("no token returned by Vault login at auth/%s/login", vh.vault.Mount)
+		}
 
 		token = secret.Auth.ClientToken
 	default:
@@ -132,47 +168,151 @@ func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error)
 }
 
 func (vh *HashicorpVaultHandler) renewToken(logger logr.Logger) {
+	// the token can't be used anymore once it isn't renewed
+	defer atomic.StoreInt32(&vh.expired, 1)
+
 	secret, err := vh.client.Auth().Token().RenewSelf(0)
 	if err != nil {
 		logger.Error(err, "Vault renew token: failed to create the payload")
+		return
 	}
 
-	renewer, err := vh.client.NewLifetimeWatcher(&vaultapi.RenewerInput{
+	renewer, err := vh.client.NewLifetimeWatcher(&vaultapi.LifetimeWatcherInput{
 		Secret: secret,
-		//Grace:  time.Duration(15 * time.Second),
-		//Increment: 60,
 	})
 	if err != nil {
 		logger.Error(err, "Vault renew token: cannot create the renewer")
+		return
 	}
 
 	go renewer.Renew()
-	defer func() {
-		renewer.Stop()
-		close(vh.stopCh)
-	}()
+	defer renewer.Stop()
 
-RenewWatcherLoop:
 	for {
 		select {
 		case <-vh.stopCh:
-			break RenewWatcherLoop
+			return
 		case err := <-renewer.DoneCh():
 			if err != nil {
 				logger.Error(err, "error renewing token")
 			}
-			break RenewWatcherLoop
+			return
+		case <-renewer.RenewCh():
+			logger.V(1).Info("Renewed Vault token", "address", vh.vault.Address)
 		}
 	}
 }
 
+// Read reads the secret at the path, the data/ prefix of paths of KV v2 secrets engines is optional,
+// it's added after the mount of the secrets engine like by `vault kv get`
 func (vh *HashicorpVaultHandler) Read(path string) (*vaultapi.Secret, error) {
-	return vh.client.Logical().Read(path)
+	secret, err := vh.client.Logical().Read(vh.kvPath(path))
+	var responseErr *vaultapi.ResponseError
+	if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusForbidden {
+		// the token may have been revoked, a new one is requested on the next use of the cached handler
+		atomic.StoreInt32(&vh.expired, 1)
+	}
+	return secret, err
+}
+
+// kvPath returns the API path of the secret, the path is used as given if it isn't a path of KV v2 secrets engine
+// or if its mount can't be looked up
+func (vh *HashicorpVaultHandler) kvPath(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	mount, err := vh.kvMount(path)
+	if err != nil || mount.version != "2" || !strings.HasPrefix(path, mount.path) {
+		return path
+	}
+	relativePath := strings.TrimPrefix(path, mount.path)
+	if strings.HasPrefix(relativePath, "data/") {
+		return path
+	}
+	return mount.path + "data/" + relativePath
+}
+
+func (vh *HashicorpVaultHandler) kvMount(path string) (vaultKVMount, error) {
+	vh.kvMountsLock.Lock()
+	defer vh.kvMountsLock.Unlock()
+	if mount, ok := vh.kvMounts[path]; ok {
+		return mount, nil
+	}
+
+	secret, err := vh.client.Logical().Read("sys/internal/ui/mounts/" + path)
+	if err != nil {
+		return vaultKVMount{}, err
+	}
+	if secret == nil || secret.Data == nil {
+		return vaultKVMount{}, fmt.Errorf("no mount found for path %s", path)
+	}
+	mount := vaultKVMount{}
+	mount.path, _ = secret.Data["path"].(string)
+	if options, ok := secret.Data["options"].(map[string]interface{}); ok {
+		mount.version, _ = options["version"].(string)
+	}
+	vh.kvMounts[path] = mount
+	return mount, nil
+}
+
+// isValid returns true if the token of the handler can still be used
+func (vh *HashicorpVaultHandler) isValid() bool {
+	if atomic.LoadInt32(&vh.expired) == 1 {
+		return false
+	}
+	return vh.expiresAt.IsZero() || time.Now().Add(vaultTokenExpiryMargin).Before(vh.expiresAt)
 }
 
 // Stop is responsible for stoping the renew token process
 func (vh *HashicorpVaultHandler) Stop() {
-	if vh.stopCh != nil {
-		vh.stopCh <- struct{}{}
+	vh.stopOnce.Do(func() {
+		close(vh.stopCh)
+	})
+}
+
+// hashicorpVaultHandlerCache has initialized HashicorpVaultHandlers by the configuration of HashiCorpVault
+type hashicorpVaultHandlerCache struct {
+	lock     sync.Mutex
+	handlers map[string]*HashicorpVaultHandler
+}
+
+// Get returns the cached handler of the configuration, a new handler is initialized if there isn't any
+// or if the token of the cached one can't be used anymore
+func (c *hashicorpVaultHandlerCache) Get(vault *kedav1alpha1.HashiCorpVault, logger logr.Logger) (*HashicorpVaultHandler, error) {
+	key, err := hashicorpVaultHandlerKey(vault)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// handlers of configurations no longer used are dropped once their tokens expire
+	for k, vh := range c.handlers {
+		if !vh.isValid() {
+			vh.Stop()
+			delete(c.handlers, k)
+		}
+	}
+	if vh, ok := c.handlers[key]; ok {
+		return vh, nil
+	}
+
+	vh := NewHashicorpVaultHandler(vault)
+	if err := vh.Initialize(logger); err != nil {
+		vh.Stop()
+		return nil, err
+	}
+	c.handlers[key] = vh
+	return vh, nil
+}
+
+// hashicorpVaultHandlerKey returns the key of the configuration of HashiCorpVault without the secrets read,
+// it's hashed so credentials aren't kept in the keys
+func hashicorpVaultHandlerKey(vault *kedav1alpha1.HashiCorpVault) (string, error) {
+	config := vault.DeepCopy()
+	config.Secrets = nil
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
 	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
 }
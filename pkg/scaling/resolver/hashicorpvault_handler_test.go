@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// newFakeVault returns a Vault server with KV v1 secrets engine mounted at kv/ and KV v2 at secret/,
+// lookupCount counts logins (token lookups)
+func newFakeVault(t *testing.T, lookupCount *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/auth/token/lookup-self":
+			atomic.AddInt32(lookupCount, 1)
+			fmt.Fprint(w, `{"data": {"renewable": false, "ttl": 3600}}`)
+		case "/v1/sys/internal/ui/mounts/secret/keda", "/v1/sys/internal/ui/mounts/secret/data/keda":
+			fmt.Fprint(w, `{"data": {"path": "secret/", "type": "kv", "options": {"version": "2"}}}`)
+		case "/v1/sys/internal/ui/mounts/kv/keda":
+			fmt.Fprint(w, `{"data": {"path": "kv/", "type": "kv", "options": null}}`)
+		case "/v1/secret/data/keda":
+			fmt.Fprint(w, `{"data": {"data": {"password": "v2"}, "metadata": {"version": 1}}}`)
+		case "/v1/kv/keda":
+			fmt.Fprint(w, `{"data": {"password": "v1"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors": []}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHashicorpVaultHandlerReadKVPaths(t *testing.T) {
+	t.Setenv("VAULT_TOKEN", "")
+	var lookupCount int32
+	server := newFakeVault(t, &lookupCount)
+
+	vh := NewHashicorpVaultHandler(&kedav1alpha1.HashiCorpVault{
+		Address:        server.URL,
+		Authentication: kedav1alpha1.VaultAuthenticationToken,
+		Credential:     &kedav1alpha1.Credential{Token: "token"},
+	})
+	assert.NoError(t, vh.Initialize(logr.Discard()))
+	defer vh.Stop()
+
+	for _, test := range []struct {
+		path     string
+		expected string
+	}{
+		{"secret/keda", "v2"},
+		{"secret/data/keda", "v2"},
+		{"/secret/keda", "v2"},
+		{"kv/keda", "v1"},
+	} {
+		secret, err := vh.Read(test.path)
+		assert.NoError(t, err, test.path)
+		if assert.NotNil(t, secret, test.path) {
+			assert.Equal(t, test.expected, resolveVaultSecret(logr.Discard(), secret.Data, "password"), test.path)
+		}
+	}
+}
+
+func TestHashicorpVaultHandlerCache(t *testing.T) {
+	t.Setenv("VAULT_TOKEN", "")
+	var lookupCount int32
+	server := newFakeVault(t, &lookupCount)
+	cache := &hashicorpVaultHandlerCache{handlers: map[string]*HashicorpVaultHandler{}}
+
+	vault := &kedav1alpha1.HashiCorpVault{
+		Address:        server.URL,
+		Authentication: kedav1alpha1.VaultAuthenticationToken,
+		Credential:     &kedav1alpha1.Credential{Token: "token"},
+		Secrets:        []kedav1alpha1.VaultSecret{{Parameter: "password", Path: "secret/keda", Key: "password"}},
+	}
+	first, err := cache.Get(vault, logr.Discard())
+	assert.NoError(t, err)
+
+	// the handler is reused for the same configuration even if other secrets are read
+	otherSecrets := vault.DeepCopy()
+	otherSecrets.Secrets = []kedav1alpha1.VaultSecret{{Parameter: "username", Path: "kv/keda", Key: "username"}}
+	second, err := cache.Get(otherSecrets, logr.Discard())
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&lookupCount))
+
+	// another token is another login
+	otherToken := vault.DeepCopy()
+	otherToken.Credential.Token = "other-token"
+	_, err = cache.Get(otherToken, logr.Discard())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&lookupCount))
+
+	// a new token is requested once the cached one is about to expire
+	first.expiresAt = time.Now().Add(vaultTokenExpiryMargin / 2)
+	third, err := cache.Get(vault, logr.Discard())
+	assert.NoError(t, err)
+	assert.NotSame(t, first, third)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&lookupCount))
+}
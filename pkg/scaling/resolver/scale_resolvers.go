@@ -159,17 +159,38 @@ func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, log
 	if podTemplateSpec != nil {
 		authParams, podIdentity := resolveAuthRef(ctx, client, logger, triggerAuthRef, &podTemplateSpec.Spec, namespace, secretsLister)
 
-		if podIdentity.Provider == kedav1alpha1.PodIdentityProviderAwsEKS {
-			serviceAccountName := podTemplateSpec.Spec.ServiceAccountName
-			serviceAccount := &corev1.ServiceAccount{}
-			err := client.Get(ctx, types.NamespacedName{Name: serviceAccountName, Namespace: namespace}, serviceAccount)
-			if err != nil {
-				return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
-					fmt.Errorf("error getting service account: '%s', error: %w", serviceAccountName, err)
+		switch podIdentity.Provider {
+		case kedav1alpha1.PodIdentityProviderAwsEKS, kedav1alpha1.PodIdentityProviderAwsKiam:
+			switch {
+			case podIdentity.RoleArn != "":
+				authParams["awsRoleArn"] = podIdentity.RoleArn
+			case !podIdentity.IsWorkloadIdentityOwner():
+				// the credentials of KEDA Operator are used without assuming any role
+			case podIdentity.Provider == kedav1alpha1.PodIdentityProviderAwsKiam:
+				authParams["awsRoleArn"] = podTemplateSpec.ObjectMeta.Annotations[kedav1alpha1.PodIdentityAnnotationKiam]
+			default:
+				serviceAccount, err := getServiceAccount(ctx, client, podTemplateSpec, namespace)
+				if err != nil {
+					return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, err
+				}
+				authParams["awsRoleArn"] = serviceAccount.Annotations[kedav1alpha1.PodIdentityAnnotationEKS]
+			}
+		case kedav1alpha1.PodIdentityProviderAzureWorkload, kedav1alpha1.PodIdentityProviderGCP:
+			if podIdentity.IdentityID == "" && podIdentity.IsWorkloadIdentityOwner() {
+				serviceAccount, err := getServiceAccount(ctx, client, podTemplateSpec, namespace)
+				if err != nil {
+					return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, err
+				}
+				annotation := kedav1alpha1.PodIdentityAnnotationAzureWorkload
+				if podIdentity.Provider == kedav1alpha1.PodIdentityProviderGCP {
+					annotation = kedav1alpha1.PodIdentityAnnotationGCP
+				}
+				podIdentity.IdentityID = serviceAccount.Annotations[annotation]
+				if podIdentity.IdentityID == "" {
+					return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
+						fmt.Errorf("service account '%s' of the workload has no annotation %s", serviceAccount.Name, annotation)
+				}
 			}
-			authParams["awsRoleArn"] = serviceAccount.Annotations[kedav1alpha1.PodIdentityAnnotationEKS]
-		} else if podIdentity.Provider == kedav1alpha1.PodIdentityProviderAwsKiam {
-			authParams["awsRoleArn"] = podTemplateSpec.ObjectMeta.Annotations[kedav1alpha1.PodIdentityAnnotationKiam]
 		}
 		return authParams, podIdentity, nil
 	}
@@ -210,12 +231,17 @@ func resolveAuthRef(ctx context.Context, client client.Client, logger logr.Logge
 			}
 			if triggerAuthSpec.SecretTargetRef != nil {
 				for _, e := range triggerAuthSpec.SecretTargetRef {
-					result[e.Parameter] = resolveAuthSecret(ctx, client, logger, e.Name, triggerNamespace, e.Key, secretsLister)
+					secretNamespace, err := getSecretTargetRefNamespace(logger, triggerAuthRef, e, triggerNamespace)
+					if err != nil {
+						logger.Error(err, "error getting secret", "triggerAuthRef.Name", triggerAuthRef.Name, "Secret.Name", e.Name)
+						result[e.Parameter] = ""
+						continue
+					}
+					result[e.Parameter] = resolveAuthSecret(ctx, client, logger, e.Name, secretNamespace, e.Key, secretsLister)
 				}
 			}
 			if triggerAuthSpec.HashiCorpVault != nil && len(triggerAuthSpec.HashiCorpVault.Secrets) > 0 {
-				vault := NewHashicorpVaultHandler(triggerAuthSpec.HashiCorpVault)
-				err := vault.Initialize(logger)
+				vault, err := vaultHandlers.Get(triggerAuthSpec.HashiCorpVault, logger)
 				if err != nil {
 					logger.Error(err, "error authenticate to Vault", "triggerAuthRef.Name", triggerAuthRef.Name)
 				} else {
@@ -234,8 +260,6 @@ func resolveAuthRef(ctx context.Context, client client.Client, logger logr.Logge
 							}
 						}
 					}
-
-					vault.Stop()
 				}
 			}
 			if triggerAuthSpec.AzureKeyVault != nil && len(triggerAuthSpec.AzureKeyVault.Secrets) > 0 {
@@ -255,12 +279,60 @@ func resolveAuthRef(ctx context.Context, client client.Client, logger logr.Logge
 					}
 				}
 			}
+			if triggerAuthSpec.AwsSecretManager != nil && len(triggerAuthSpec.AwsSecretManager.Secrets) > 0 {
+				secretManagerHandler := NewAwsSecretManagerHandler(triggerAuthSpec.AwsSecretManager)
+				err := secretManagerHandler.Initialize(ctx, client, logger, triggerNamespace, secretsLister)
+				if err != nil {
+					logger.Error(err, "error authenticating to AWS Secrets Manager", "triggerAuthRef.Name", triggerAuthRef.Name)
+				} else {
+					for _, secret := range triggerAuthSpec.AwsSecretManager.Secrets {
+						res, err := secretManagerHandler.Read(ctx, secret)
+						if err != nil {
+							logger.Error(err, "error trying to read secret from AWS Secrets Manager", "triggerAuthRef.Name", triggerAuthRef.Name,
+								"secret.Name", secret.Name, "secret.VersionID", secret.VersionID, "secret.VersionStage", secret.VersionStage)
+						} else {
+							result[secret.Parameter] = res
+						}
+					}
+				}
+			}
+			if triggerAuthSpec.GCPSecretManager != nil && len(triggerAuthSpec.GCPSecretManager.Secrets) > 0 {
+				secretManagerHandler := NewGCPSecretManagerHandler(triggerAuthSpec.GCPSecretManager)
+				err := secretManagerHandler.Initialize(ctx, client, logger, triggerNamespace, secretsLister)
+				if err != nil {
+					logger.Error(err, "error authenticating to GCP Secret Manager", "triggerAuthRef.Name", triggerAuthRef.Name)
+				} else {
+					for _, secret := range triggerAuthSpec.GCPSecretManager.Secrets {
+						res, err := secretManagerHandler.Read(ctx, secret)
+						if err != nil {
+							logger.Error(err, "error trying to read secret from GCP Secret Manager", "triggerAuthRef.Name", triggerAuthRef.Name,
+								"secret.ID", secret.ID, "secret.Version", secret.Version)
+						} else {
+							result[secret.Parameter] = res
+						}
+					}
+				}
+			}
 		}
 	}
 
 	return result, podIdentity
 }
 
+// getServiceAccount returns the service account of the pods of the workload
+func getServiceAccount(ctx context.Context, client client.Client, podTemplateSpec *corev1.PodTemplateSpec, namespace string) (*corev1.ServiceAccount, error) {
+	serviceAccountName := podTemplateSpec.Spec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = "default"
+	}
+	serviceAccount := &corev1.ServiceAccount{}
+	err := client.Get(ctx, types.NamespacedName{Name: serviceAccountName, Namespace: namespace}, serviceAccount)
+	if err != nil {
+		return nil, fmt.Errorf("error getting service account: '%s', error: %w", serviceAccountName, err)
+	}
+	return serviceAccount, nil
+}
+
 func getTriggerAuthSpec(ctx context.Context, client client.Client, triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, namespace string) (*kedav1alpha1.TriggerAuthenticationSpec, string, error) {
 	if triggerAuthRef.Kind == "" || triggerAuthRef.Kind == "TriggerAuthentication" {
 		triggerAuth := &kedav1alpha1.TriggerAuthentication{}
@@ -284,6 +356,26 @@ func getTriggerAuthSpec(ctx context.Context, client client.Client, triggerAuthRe
 	return nil, "", fmt.Errorf("unknown trigger auth kind %s", triggerAuthRef.Kind)
 }
 
+// getSecretTargetRefNamespace returns the namespace of the secret referenced by the authentication, ClusterTriggerAuthentications
+// read secrets of other namespaces than the cluster object namespace only if they are allowed by KEDA_CLUSTER_TRIGGER_AUTHENTICATION_ALLOWED_NAMESPACES
+func getSecretTargetRefNamespace(logger logr.Logger, triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, secretTargetRef kedav1alpha1.AuthSecretTargetRef, triggerNamespace string) (string, error) {
+	if secretTargetRef.Namespace == "" || secretTargetRef.Namespace == triggerNamespace {
+		return triggerNamespace, nil
+	}
+	if triggerAuthRef.Kind != "ClusterTriggerAuthentication" {
+		return "", fmt.Errorf("namespace of secretTargetRef is supported by ClusterTriggerAuthentication only")
+	}
+	if isSecretAccessRestricted(logger) {
+		return "", fmt.Errorf("secrets of namespace %s can't be read, the access to secrets is restricted to namespace %s", secretTargetRef.Namespace, kedaNamespace)
+	}
+	for _, namespace := range util.GetClusterTriggerAuthenticationAllowedNamespaces() {
+		if namespace == secretTargetRef.Namespace {
+			return namespace, nil
+		}
+	}
+	return "", fmt.Errorf("secrets of namespace %s can't be read, the namespace isn't allowed by %s", secretTargetRef.Namespace, util.ClusterTriggerAuthenticationAllowedNamespacesEnvVar)
+}
+
 func resolveEnv(ctx context.Context, client client.Client, logger logr.Logger, container *corev1.Container, namespace string, secretsLister corev1listers.SecretLister) (map[string]string, error) {
 	resolved := make(map[string]string)
 
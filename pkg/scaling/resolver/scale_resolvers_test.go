@@ -31,6 +31,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 var (
@@ -249,6 +250,7 @@ func TestResolveAuthRef(t *testing.T) {
 		podSpec             *corev1.PodSpec
 		expected            map[string]string
 		expectedPodIdentity kedav1alpha1.AuthPodIdentity
+		allowedNamespaces   string
 	}{
 		{
 			name:     "foo",
@@ -401,6 +403,94 @@ func TestResolveAuthRef(t *testing.T) {
 			expected:            map[string]string{"host": ""},
 			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
 		},
+		{
+			name: "clustertriggerauth and secret in allowed namespace",
+			existing: []runtime.Object{
+				&kedav1alpha1.ClusterTriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{
+								Parameter: "host",
+								Name:      secretName,
+								Key:       secretKey,
+								Namespace: "shared",
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "shared",
+						Name:      secretName,
+					},
+					Data: map[string][]byte{secretKey: []byte(secretData)}},
+			},
+			soar:              &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+			expected:          map[string]string{"host": secretData},
+			allowedNamespaces: "other, shared",
+		},
+		{
+			name: "clustertriggerauth and secret in namespace which isn't allowed",
+			existing: []runtime.Object{
+				&kedav1alpha1.ClusterTriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{
+								Parameter: "host",
+								Name:      secretName,
+								Key:       secretKey,
+								Namespace: "shared",
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "shared",
+						Name:      secretName,
+					},
+					Data: map[string][]byte{secretKey: []byte(secretData)}},
+			},
+			soar:              &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+			expected:          map[string]string{"host": ""},
+			allowedNamespaces: "other",
+		},
+		{
+			name: "triggerauth and secret in other namespace",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{
+								Parameter: "host",
+								Name:      secretName,
+								Key:       secretKey,
+								Namespace: "shared",
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "shared",
+						Name:      secretName,
+					},
+					Data: map[string][]byte{secretKey: []byte(secretData)}},
+			},
+			soar:              &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName},
+			expected:          map[string]string{"host": ""},
+			allowedNamespaces: "shared",
+		},
 	}
 	var secretsLister corev1listers.SecretLister
 	for _, test := range tests {
@@ -408,6 +498,7 @@ func TestResolveAuthRef(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			ctx := context.Background()
 			os.Setenv("KEDA_CLUSTER_OBJECT_NAMESPACE", clusterNamespace) // Inject test cluster namespace.
+			t.Setenv(util.ClusterTriggerAuthenticationAllowedNamespacesEnvVar, test.allowedNamespaces)
 			gotMap, gotPodIdentity := resolveAuthRef(
 				ctx,
 				fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(test.existing...).Build(),
@@ -426,6 +517,85 @@ func TestResolveAuthRef(t *testing.T) {
 	}
 }
 
+func TestResolveAuthRefAndPodIdentityOwner(t *testing.T) {
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "workload",
+			Annotations: map[string]string{
+				kedav1alpha1.PodIdentityAnnotationEKS:           "arn:aws:iam::123456789012:role/workload",
+				kedav1alpha1.PodIdentityAnnotationAzureWorkload: "workload-client-id",
+				kedav1alpha1.PodIdentityAnnotationGCP:           "workload@project.iam.gserviceaccount.com",
+			},
+		},
+	}
+	podTemplateSpec := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{ServiceAccountName: serviceAccount.Name}}
+
+	tests := []struct {
+		name               string
+		podIdentity        kedav1alpha1.AuthPodIdentity
+		expectedRoleArn    string
+		expectedIdentityID string
+	}{
+		{
+			name:            "aws-eks uses the role of the workload by default",
+			podIdentity:     kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS},
+			expectedRoleArn: "arn:aws:iam::123456789012:role/workload",
+		},
+		{
+			name:            "aws-eks uses the given role",
+			podIdentity:     kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS, RoleArn: "arn:aws:iam::123456789012:role/keda"},
+			expectedRoleArn: "arn:aws:iam::123456789012:role/keda",
+		},
+		{
+			name:        "aws-eks uses the identity of KEDA",
+			podIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS, IdentityOwner: kedav1alpha1.IdentityOwnerKeda},
+		},
+		{
+			name:        "azure-workload uses the identity of KEDA by default",
+			podIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload},
+		},
+		{
+			name:               "azure-workload uses the identity of the workload",
+			podIdentity:        kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload, IdentityOwner: kedav1alpha1.IdentityOwnerWorkload},
+			expectedIdentityID: "workload-client-id",
+		},
+		{
+			name:               "azure-workload uses the given identity",
+			podIdentity:        kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload, IdentityID: "client-id", IdentityOwner: kedav1alpha1.IdentityOwnerWorkload},
+			expectedIdentityID: "client-id",
+		},
+		{
+			name:               "gcp uses the service account of the workload",
+			podIdentity:        kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderGCP, IdentityOwner: kedav1alpha1.IdentityOwnerWorkload},
+			expectedIdentityID: "workload@project.iam.gserviceaccount.com",
+		},
+	}
+	var secretsLister corev1listers.SecretLister
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			podIdentity := test.podIdentity
+			triggerAuth := &kedav1alpha1.TriggerAuthentication{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: triggerAuthenticationName},
+				Spec:       kedav1alpha1.TriggerAuthenticationSpec{PodIdentity: &podIdentity},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(triggerAuth, serviceAccount).Build()
+			authParams, gotPodIdentity, err := ResolveAuthRefAndPodIdentity(context.Background(), client, logf.Log.WithName("test"),
+				&kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName}, podTemplateSpec, namespace, secretsLister)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if authParams["awsRoleArn"] != test.expectedRoleArn {
+				t.Errorf("Unexpected awsRoleArn, wanted: %q got: %q", test.expectedRoleArn, authParams["awsRoleArn"])
+			}
+			if gotPodIdentity.IdentityID != test.expectedIdentityID {
+				t.Errorf("Unexpected identityId, wanted: %q got: %q", test.expectedIdentityID, gotPodIdentity.IdentityID)
+			}
+		})
+	}
+}
+
 func TestResolveDependentEnv(t *testing.T) {
 	tests := []struct {
 		name      string
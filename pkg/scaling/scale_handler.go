@@ -18,12 +18,16 @@ package scaling
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -34,10 +38,13 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/eventemitter"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/fallback"
 	metricsserviceapi "github.com/kedacore/keda/v2/pkg/metricsservice/api"
 	"github.com/kedacore/keda/v2/pkg/prommetrics"
+	"github.com/kedacore/keda/v2/pkg/replicacalculator"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
@@ -47,6 +54,58 @@ import (
 
 var log = logf.Log.WithName("scale_handler")
 
+// Scale loops are stopped in steps on leadership change or shutdown, all of them fit into the termination grace period
+// of KEDA Operator
+const (
+	// scaleLoopsStopTimeout is how long in-flight checks of scale loops, including their status updates, are awaited
+	scaleLoopsStopTimeout = 5 * time.Second
+	// scaleLoopsCancelTimeout is how long scale loops and push scalers are awaited once their contexts are canceled
+	scaleLoopsCancelTimeout = time.Second
+	// scalersCloseTimeout is how long closing of the connections of the cached scalers is awaited
+	scalersCloseTimeout = 2 * time.Second
+)
+
+const (
+	// DefaultScalerCheckConcurrency is the default number of scalers of a ScaledObject checked concurrently
+	DefaultScalerCheckConcurrency = 10
+	// DefaultScalerCheckTimeout is the default timeout of the check of a single scaler of a ScaledObject
+	DefaultScalerCheckTimeout = 30 * time.Second
+)
+
+// scalerChecksConfig limits the checks of scalers of ScaledObjects in scale loops, it's set by ConfigureScalerChecks
+// on start of KEDA Operator
+var scalerChecksConfig = struct {
+	concurrency int
+	timeout     time.Duration
+}{
+	concurrency: DefaultScalerCheckConcurrency,
+	timeout:     DefaultScalerCheckTimeout,
+}
+
+// ConfigureScalerChecks sets the number of scalers of a ScaledObject checked concurrently and the timeout of the check
+// of a single scaler of a ScaledObject or ScaledJob, zero timeout means the check isn't limited
+func ConfigureScalerChecks(concurrency int, timeout time.Duration) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	scalerChecksConfig.concurrency = concurrency
+	scalerChecksConfig.timeout = timeout
+}
+
+// minPushCheckInterval is the shortest time between the start of a check and a following check requested by push scalers
+const minPushCheckInterval = time.Second
+
+// MetricUnavailableError is returned by GetScaledObjectMetrics if the scaler of the metric failed,
+// so the metric is reported as unavailable instead of a value the HPA would act on
+type MetricUnavailableError struct {
+	MetricName string
+	Reason     string
+}
+
+func (e *MetricUnavailableError) Error() string {
+	return fmt.Sprintf("metric %s unavailable: %s", e.MetricName, e.Reason)
+}
+
 // ScaleHandler encapsulates the logic of calling the right scalers for
 // each ScaledObject and making the final scale decision and operation
 type ScaleHandler interface {
@@ -54,8 +113,17 @@ type ScaleHandler interface {
 	DeleteScalableObject(ctx context.Context, scalableObject interface{}) error
 	GetScalersCache(ctx context.Context, scalableObject interface{}) (*cache.ScalersCache, error)
 	ClearScalersCache(ctx context.Context, scalableObject interface{}) error
+	RefreshScalersCachesForAuthentication(ctx context.Context, authenticationKind, authenticationNamespace, authenticationName string)
 
 	GetScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string) (*external_metrics.ExternalMetricValueList, *metricsserviceapi.PromMetricsMsg, error)
+	// GetScaledObjectMetricValues returns the metric values of the last check of the ScaledObject by metric name
+	GetScaledObjectMetricValues(namespace, name string) map[string]float64
+	// CheckTriggers checks the triggers of the ScaledObject once, without it being created, see CheckTriggers of scaleHandler
+	CheckTriggers(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) ([]TriggerCheck, error)
+
+	// Start and NeedLeaderElection implement manager.LeaderElectionRunnable, see Start of scaleHandler
+	Start(ctx context.Context) error
+	NeedLeaderElection() bool
 }
 
 type scaleHandler struct {
@@ -68,10 +136,25 @@ type scaleHandler struct {
 	scalerCachesLock         *sync.RWMutex
 	scaledObjectsMetricCache metricscache.MetricsCache
 	secretsLister            corev1listers.SecretLister
+	cacheHandoff             *CacheHandoff
+	metricsHistory           *MetricsHistory
+	// warmingUpScaledObjects holds ScaledObjects with metrics restored from cacheHandoff, that weren't checked yet
+	warmingUpScaledObjects sync.Map
+	// scaleLoops and pushScalers track the goroutines of the scale scheduler and push scalers, so they can be awaited
+	// on leadership change
+	scaleLoops  sync.WaitGroup
+	pushScalers sync.WaitGroup
+	// stopLock guards stopping, draining and scheduler, no scale loops are started once stopping is set
+	stopLock sync.Mutex
+	stopping bool
+	// scheduler checks the scale loops of all scalable objects, it's started with the first scale loop
+	scheduler *scaleScheduler
+	// draining is closed once scale loops are being stopped, they finish their in-flight check and return
+	draining chan struct{}
 }
 
-// NewScaleHandler creates a ScaleHandler object
-func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, secretsLister corev1listers.SecretLister) ScaleHandler {
+// NewScaleHandler creates a ScaleHandler object, cacheHandoff and metricsHistory are optional
+func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, secretsLister corev1listers.SecretLister, cacheHandoff *CacheHandoff, metricsHistory *MetricsHistory) ScaleHandler {
 	return &scaleHandler{
 		client:                   client,
 		scaleLoopContexts:        &sync.Map{},
@@ -82,6 +165,8 @@ func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, recon
 		scalerCachesLock:         &sync.RWMutex{},
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 		secretsLister:            secretsLister,
+		cacheHandoff:             cacheHandoff,
+		metricsHistory:           metricsHistory,
 	}
 }
 
@@ -98,7 +183,17 @@ func (h *scaleHandler) HandleScalableObject(ctx context.Context, scalableObject
 	}
 
 	key := withTriggers.GenerateIdentifier()
-	ctx, cancel := context.WithCancel(ctx)
+
+	h.stopLock.Lock()
+	defer h.stopLock.Unlock()
+	if h.stopping {
+		log.V(1).Info("Scale loops are being stopped, not starting scale loop", "key", key)
+		return nil
+	}
+
+	// scale loops outlive the reconcile and aren't canceled together with the manager context on shutdown,
+	// they are drained and canceled by Start
+	ctx, cancel := context.WithCancel(detachedContext{parent: ctx})
 
 	// cancel the outdated ScaleLoop for the same ScaledObject (if exists)
 	value, loaded := h.scaleLoopContexts.LoadOrStore(key, cancel)
@@ -112,21 +207,176 @@ func (h *scaleHandler) HandleScalableObject(ctx context.Context, scalableObject
 		h.recorder.Event(withTriggers, corev1.EventTypeNormal, eventreason.KEDAScalersStarted, "Started scalers watch")
 	}
 
-	// a mutex is used to synchronize scale requests per scalableObject
-	scalingMutex := &sync.Mutex{}
+	// the ScaledObject may have changed, its inactive checks are counted again by the new scale loop
+	if scaledObject, ok := scalableObject.(*kedav1alpha1.ScaledObject); ok {
+		h.scaleExecutor.ResetInactiveChecks(scaledObject)
+	}
 
-	// passing deep copy of ScaledObject/ScaledJob to the scaleLoop go routines, it's a precaution to not have global objects shared between threads
+	scheduler := h.getScheduler()
+	if outdated, running := scheduler.remove(key); outdated != nil && !running {
+		// scalers are closed in a new goroutine, so closing connections to the sources doesn't block stopLock
+		h.runScaleLoop(func() { h.stopScaleLoop(outdated.ctx, outdated.logger, outdated.scalableObject) })
+	}
+
+	// passing deep copy of ScaledObject/ScaledJob to the scale loop, it's a precaution to not have global objects shared between threads
+	var scalableObjectCopy interface{}
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
-		go h.startPushScalers(ctx, withTriggers, obj.DeepCopy(), scalingMutex)
-		go h.startScaleLoop(ctx, withTriggers, obj.DeepCopy(), scalingMutex)
+		scalableObjectCopy = obj.DeepCopy()
 	case *kedav1alpha1.ScaledJob:
-		go h.startPushScalers(ctx, withTriggers, obj.DeepCopy(), scalingMutex)
-		go h.startScaleLoop(ctx, withTriggers, obj.DeepCopy(), scalingMutex)
+		scalableObjectCopy = obj.DeepCopy()
+	default:
+		return nil
 	}
+	scheduler.add(&scaleLoop{
+		key:            key,
+		ctx:            ctx,
+		logger:         log.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name),
+		withTriggers:   withTriggers,
+		scalableObject: scalableObjectCopy,
+		// a mutex is used to synchronize scale requests per scalableObject
+		scalingMutex: &sync.Mutex{},
+	})
 	return nil
 }
 
+// getScheduler returns the scale scheduler, it's started once it's used for the first time, stopLock must be held
+func (h *scaleHandler) getScheduler() *scaleScheduler {
+	if h.scheduler == nil {
+		if h.draining == nil {
+			h.draining = make(chan struct{})
+		}
+		h.scheduler = newScaleScheduler(h.checkScaleLoop, func(loop *scaleLoop) {
+			h.stopScaleLoop(loop.ctx, loop.logger, loop.scalableObject)
+		}, minPushCheckInterval)
+		h.scheduler.start(scaleLoopWorkers, h.draining, h.runScaleLoop)
+	}
+	return h.scheduler
+}
+
+// runScaleLoop runs the dispatcher or a worker of the scale scheduler in a new goroutine tracked by scaleLoops
+func (h *scaleHandler) runScaleLoop(loop func()) {
+	h.scaleLoops.Add(1)
+	go func() {
+		defer h.scaleLoops.Done()
+		loop()
+	}()
+}
+
+// runPushScaler runs push scalers in a new goroutine tracked by pushScalers
+func (h *scaleHandler) runPushScaler(run func()) {
+	h.pushScalers.Add(1)
+	go func() {
+		defer h.pushScalers.Done()
+		run()
+	}()
+}
+
+// drainingSignal returns the channel closed once scale loops are being stopped
+func (h *scaleHandler) drainingSignal() <-chan struct{} {
+	h.stopLock.Lock()
+	defer h.stopLock.Unlock()
+	if h.draining == nil {
+		h.draining = make(chan struct{})
+	}
+	return h.draining
+}
+
+// detachedContext keeps the values of its parent, but not its deadline and cancellation
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only the leader runs scale loops
+func (h *scaleHandler) NeedLeaderElection() bool {
+	return true
+}
+
+// Start blocks until KEDA Operator stops leading (or shuts down), then it stops all scale loops and closes their
+// scalers. Leader election runnables are stopped before the leader lease is released, so the next leader doesn't
+// start scaling the workloads while scale loops of the previous one are still running or holding connections.
+func (h *scaleHandler) Start(ctx context.Context) error {
+	<-ctx.Done()
+	h.stopScaleLoops(scaleLoopsStopTimeout)
+	return nil
+}
+
+// stopScaleLoops stops all scale loops and closes their scalers:
+//  1. no new scale loops are started and the running ones finish their in-flight check, including status updates,
+//     waiting up to timeout
+//  2. contexts of scale loops are canceled, which stops push scalers and checks still in-flight
+//  3. all cached scalers are closed, including the ones used only for requests of KEDA Metrics Server, so connections
+//     to the sources (eg. AMQP, Kafka or SQL) are closed cleanly instead of being left for the brokers to time out
+func (h *scaleHandler) stopScaleLoops(timeout time.Duration) {
+	// makes sure the channel exists, it's created lazily by the first scale loop otherwise
+	h.drainingSignal()
+	h.stopLock.Lock()
+	if !h.stopping {
+		h.stopping = true
+		close(h.draining)
+	}
+	h.stopLock.Unlock()
+
+	if waitTimeout(&h.scaleLoops, timeout) {
+		log.V(1).Info("All scale loops finished their checks")
+	} else {
+		log.Info("Timeout waiting for scale loops to finish their checks, canceling them", "timeout", timeout)
+	}
+
+	h.scaleLoopContexts.Range(func(key, value interface{}) bool {
+		if cancel, ok := value.(context.CancelFunc); ok {
+			cancel()
+		}
+		h.scaleLoopContexts.Delete(key)
+		return true
+	})
+	if !waitTimeout(&h.scaleLoops, scaleLoopsCancelTimeout) || !waitTimeout(&h.pushScalers, scaleLoopsCancelTimeout) {
+		log.Info("Timeout waiting for canceled scale loops and push scalers to stop, closing their scalers anyway", "timeout", scaleLoopsCancelTimeout)
+	}
+
+	h.scalerCachesLock.Lock()
+	defer h.scalerCachesLock.Unlock()
+	closeCtx, cancel := context.WithTimeout(context.Background(), scalersCloseTimeout)
+	defer cancel()
+	closed := sync.WaitGroup{}
+	for key, scalersCache := range h.scalerCaches {
+		closed.Add(1)
+		go func(scalersCache *cache.ScalersCache) {
+			defer closed.Done()
+			scalersCache.Close(closeCtx)
+		}(scalersCache)
+		delete(h.scalerCaches, key)
+	}
+	if !waitTimeout(&closed, scalersCloseTimeout) {
+		log.Info("Timeout waiting for scalers to close", "timeout", scalersCloseTimeout)
+	}
+	log.Info("Stopped scale loops and closed scalers before handing over to the next leader")
+}
+
+// waitTimeout waits for the WaitGroup up to timeout, it returns false on timeout
+func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	tmr := time.NewTimer(timeout)
+	defer tmr.Stop()
+	select {
+	case <-done:
+		return true
+	case <-tmr.C:
+		return false
+	}
+}
+
 // DeleteScalableObject stops handling logic for input ScalableObject
 func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject interface{}) error {
 	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scalableObject)
@@ -143,6 +393,17 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 			cancel()
 		}
 		h.scaleLoopContexts.Delete(key)
+		h.stopLock.Lock()
+		if h.scheduler != nil {
+			h.scheduler.remove(key)
+		}
+		h.stopLock.Unlock()
+		if h.cacheHandoff != nil {
+			h.cacheHandoff.Delete(key)
+		}
+		if scaledObject, ok := scalableObject.(*kedav1alpha1.ScaledObject); ok {
+			h.scaleExecutor.ResetInactiveChecks(scaledObject)
+		}
 		err := h.ClearScalersCache(ctx, scalableObject)
 		if err != nil {
 			log.Error(err, "error clearing scalers cache", "scalableObject", scalableObject, "key", key)
@@ -155,34 +416,101 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 	return nil
 }
 
-// startScaleLoop blocks forever and checks the scalableObject based on its pollingInterval
-func (h *scaleHandler) startScaleLoop(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scalableObject interface{}, scalingMutex sync.Locker) {
-	logger := log.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
+// checkScaleLoop checks the scalableObject of the scale loop and returns the time of its next check based on its
+// pollingInterval, the scale scheduler checks it earlier if a push scaler requests a check
+func (h *scaleHandler) checkScaleLoop(loop *scaleLoop) time.Time {
+	if !loop.initialized {
+		loop.initialized = true
+		pollingInterval := loop.withTriggers.GetPollingInterval()
+		loop.logger.V(1).Info("Watching with pollingInterval", "PollingInterval", pollingInterval)
+		loop.interval = pollingInterval
+
+		loop.adaptivePolling = newAdaptivePolling(loop.withTriggers)
+		if loop.adaptivePolling != nil && h.replayMetricsHistory(loop.ctx, loop.scalableObject, loop.adaptivePolling) {
+			loop.interval = loop.adaptivePolling.interval
+			loop.logger.V(1).Info("Replayed metrics history, adapting pollingInterval", "PollingInterval", loop.interval)
+		}
 
-	pollingInterval := withTriggers.GetPollingInterval()
-	logger.V(1).Info("Watching with pollingInterval", "PollingInterval", pollingInterval)
-
-	for {
-		tmr := time.NewTimer(pollingInterval)
-		h.checkScalers(ctx, scalableObject, scalingMutex)
-
-		select {
-		case <-tmr.C:
-			tmr.Stop()
-		case <-ctx.Done():
-			logger.V(1).Info("Context canceled")
-			err := h.ClearScalersCache(ctx, scalableObject)
-			if err != nil {
-				logger.Error(err, "error clearing scalers cache")
-			}
-			tmr.Stop()
-			return
+		// with metrics handed off by the previous leader the first check is delayed by a random part of pollingInterval,
+		// so scalers of all ScaledObjects don't connect to their sources at once
+		if h.restoreHandedOffMetrics(loop.ctx, loop.scalableObject) && pollingInterval > 0 {
+			delay := time.Duration(rand.Int63n(int64(pollingInterval)))
+			loop.logger.V(1).Info("Restored metrics handed off by the previous leader, delaying first check", "delay", delay)
+			loop.nextPoll = time.Now().Add(delay)
+			return loop.nextPoll
+		}
+	}
+
+	checkStart := time.Now()
+	isPoll := !checkStart.Before(loop.nextPoll)
+	metricValues := h.checkScalers(loop.ctx, loop.scalableObject, loop.scalingMutex, isPoll)
+	if loop.adaptivePolling != nil {
+		nextInterval := loop.adaptivePolling.nextInterval(metricValues)
+		if nextInterval != loop.interval {
+			loop.logger.V(1).Info("Adapting pollingInterval to metric values", "PollingInterval", nextInterval)
 		}
+		loop.interval = nextInterval
+	}
+
+	// push scalers are started once the scalers are built by the first check
+	if !loop.pushScalersStarted {
+		loop.pushScalersStarted = true
+		h.startPushScalers(loop.ctx, loop.withTriggers, loop.scalableObject, func() {
+			loop.logger.V(1).Info("Check requested by push scaler")
+			h.stopLock.Lock()
+			scheduler := h.scheduler
+			h.stopLock.Unlock()
+			scheduler.requestCheck(loop)
+		})
+	}
+	loop.nextPoll = checkStart.Add(loop.interval)
+	return loop.nextPoll
+}
+
+// stopScaleLoop closes the scalers of the stopped scale loop
+func (h *scaleHandler) stopScaleLoop(ctx context.Context, logger logr.Logger, scalableObject interface{}) {
+	if err := h.ClearScalersCache(ctx, scalableObject); err != nil {
+		logger.Error(err, "error clearing scalers cache")
+	}
+}
+
+// restoreHandedOffMetrics stores metrics of the ScaledObject handed off by the previous leader to the metrics cache,
+// they are used for all triggers until the ScaledObject is checked for the first time
+func (h *scaleHandler) restoreHandedOffMetrics(ctx context.Context, scalableObject interface{}) bool {
+	scaledObject, ok := scalableObject.(*kedav1alpha1.ScaledObject)
+	if !ok || h.cacheHandoff == nil {
+		return false
+	}
+
+	metricsRecords, ok := h.cacheHandoff.RestoreRecords(ctx, scaledObject)
+	if !ok {
+		return false
+	}
+
+	identifier := scaledObject.GenerateIdentifier()
+	h.scaledObjectsMetricCache.StoreRecords(identifier, metricsRecords)
+	h.warmingUpScaledObjects.Store(identifier, true)
+	return true
+}
+
+// replayMetricsHistory replays the metric values of the ScaledObject recorded before the restart of KEDA Operator
+// to the adaptive polling, so it continues with the stability observed before instead of starting from scratch
+func (h *scaleHandler) replayMetricsHistory(ctx context.Context, scalableObject interface{}, adaptivePolling *adaptivePolling) bool {
+	scaledObject, ok := scalableObject.(*kedav1alpha1.ScaledObject)
+	if !ok || h.metricsHistory == nil {
+		return false
+	}
+
+	history := h.metricsHistory.ReplayHistory(ctx, scaledObject)
+	for _, values := range history {
+		adaptivePolling.nextInterval(values)
 	}
+	return len(history) > 0
 }
 
-// startPushScalers starts all push scalers defined in the input scalableOjbect
-func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scalableObject interface{}, scalingMutex sync.Locker) {
+// startPushScalers starts all push scalers defined in the input scalableOjbect, a signal of a push scaler requests
+// an immediate check of the scalableObject by its scale loop, so all triggers are evaluated as on every poll
+func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scalableObject interface{}, requestCheck func()) {
 	logger := log.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
 	cache, err := h.GetScalersCache(ctx, scalableObject)
 	if err != nil {
@@ -191,31 +519,30 @@ func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav
 	}
 
 	for _, ps := range cache.GetPushScalers() {
-		go func(s scalers.PushScaler) {
+		s := ps
+		h.runPushScaler(func() {
 			activeCh := make(chan bool)
-			go s.Run(ctx, activeCh)
+			h.runPushScaler(func() { s.Run(ctx, activeCh) })
 			for {
 				select {
 				case <-ctx.Done():
 					return
-				case active := <-activeCh:
-					scalingMutex.Lock()
-					switch obj := scalableObject.(type) {
-					case *kedav1alpha1.ScaledObject:
-						h.scaleExecutor.RequestScale(ctx, obj, active, false)
-					case *kedav1alpha1.ScaledJob:
-						logger.Info("Warning: External Push Scaler does not support ScaledJob", "object", scalableObject)
+				case active, ok := <-activeCh:
+					if !ok {
+						return
 					}
-					scalingMutex.Unlock()
+					logger.V(1).Info("Push scaler signalled a change of the source", "active", active)
+					requestCheck()
 				}
 			}
-		}(ps)
+		})
 	}
 }
 
 // checkScalers contains the main logic for the ScaleHandler scaling logic.
-// It'll check each trigger active status then call RequestScale
-func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker) {
+// It'll check each trigger active status then call RequestScale, isPoll is false if a push scaler requested the check,
+// it returns the metric values observed by the check, nil if the check failed
+func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker, isPoll bool) map[string]float64 {
 	scalingMutex.Lock()
 	defer scalingMutex.Unlock()
 	switch obj := scalableObject.(type) {
@@ -223,36 +550,62 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 		err := h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
 		if err != nil {
 			log.Error(err, "error getting scaledObject", "object", scalableObject)
-			return
+			return nil
 		}
-		isActive, isError, metricsRecords, err := h.getScaledObjectState(ctx, obj)
+		isActive, isError, metricsRecords, metricReplicaCount, err := h.getScaledObjectState(ctx, obj)
 		if err != nil {
 			log.Error(err, "error getting state of scaledObject", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name)
-			return
+			return nil
+		}
+		if forcedActive, forced := kedacontrollerutil.GetForcedActivation(obj); forced {
+			// the forced activity wins over the triggers, including their errors, so the fallback doesn't kick in
+			log.V(1).Info("Activity of triggers is forced by annotation", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name,
+				"isActive", isActive, "forcedActive", forcedActive)
+			isActive, isError = forcedActive, false
 		}
 
-		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError)
-
+		// the metrics are stored before scaling, so the events of the scaling report them
 		if len(metricsRecords) > 0 {
 			log.V(1).Info("Storing metrics to cache", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name, "metricsRecords", metricsRecords)
 			h.scaledObjectsMetricCache.StoreRecords(obj.GenerateIdentifier(), metricsRecords)
+			if h.cacheHandoff != nil {
+				h.cacheHandoff.StoreRecords(obj, metricsRecords)
+			}
+		}
+
+		metricValues := getMetricValues(metricsRecords)
+		h.scaleExecutor.RecordDesiredReplicas(ctx, obj, isActive, isError, metricReplicaCount)
+		if kedacontrollerutil.IsDryRunEnabled(obj) {
+			h.scaleExecutor.RequestDryRunScale(ctx, obj, isActive, isError, metricReplicaCount)
+		} else {
+			h.scaleExecutor.RequestScale(ctx, obj, isActive, isError, isPoll, metricValues)
+		}
+		h.warmingUpScaledObjects.Delete(obj.GenerateIdentifier())
+
+		if isError {
+			return nil
 		}
+		return metricValues
 	case *kedav1alpha1.ScaledJob:
 		cache, err := h.GetScalersCache(ctx, scalableObject)
 		if err != nil {
 			log.Error(err, "error getting scalers cache", "scaledJob.Namespace", obj.Namespace, "scaledJob.Name", obj.Name)
-			return
+			prommetrics.RecordInternalError("scale_handler")
+			return nil
 		}
 
 		err = h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
 		if err != nil {
 			log.Error(err, "error getting scaledJob", "scaledJob.Namespace", obj.Namespace, "scaledJob.Name", obj.Name)
-			return
+			return nil
 		}
 
 		isActive, scaleTo, maxScale := cache.IsScaledJobActive(ctx, obj)
 		h.scaleExecutor.RequestJobScale(ctx, obj, isActive, scaleTo, maxScale)
+
+		return map[string]float64{"scaleTo": float64(scaleTo), "maxScale": float64(maxScale)}
 	}
+	return nil
 }
 
 /// --------------------------------------------------------------------------- ///
@@ -298,19 +651,27 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 	}
 	h.scalerCachesLock.RUnlock()
 
+	// the replaced cache is closed after unlocking, so a slow close of its scalers doesn't block lookups of other caches
+	var replacedCache *cache.ScalersCache
+	defer func() {
+		if replacedCache != nil {
+			replacedCache.Close(ctx)
+		}
+	}()
 	h.scalerCachesLock.Lock()
 	defer h.scalerCachesLock.Unlock()
-	if cache, ok := h.scalerCaches[key]; ok {
+	outdatedCache, ok := h.scalerCaches[key]
+	if ok {
 		// generation was specified -> let's include it in the check as well
 		if scalableObjectGeneration != nil {
-			if cache.ScalableObjectGeneration == *scalableObjectGeneration {
-				return cache, nil
+			if outdatedCache.ScalableObjectGeneration == *scalableObjectGeneration {
+				return outdatedCache, nil
 			}
 			// object was found in cache, but the generation is not correct,
-			// let's close scalers in the cache and proceed further to recreate the cache
-			cache.Close(ctx)
+			// let's proceed further to recreate the cache, scalers in the outdated cache are closed
+			// once the new cache is created, so they aren't torn down if eg. the API server is unavailable
 		} else {
-			return cache, nil
+			return outdatedCache, nil
 		}
 	}
 
@@ -357,7 +718,9 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 	newCache := &cache.ScalersCache{
 		Scalers:                  scalers,
 		ScalableObjectGeneration: withTriggers.Generation,
+		AuthenticationRefs:       getAuthenticationRefs(withTriggers),
 		Recorder:                 h.recorder,
+		CheckTimeout:             scalerChecksConfig.timeout,
 	}
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
@@ -365,9 +728,10 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 	default:
 	}
 
+	replacedCache = outdatedCache
 	h.scalerCaches[key] = newCache
 
-	return h.scalerCaches[key], nil
+	return newCache, nil
 }
 
 // ClearScalersCache invalidates chache for the input scalableObject
@@ -382,20 +746,87 @@ func (h *scaleHandler) ClearScalersCache(ctx context.Context, scalableObject int
 	go h.scaledObjectsMetricCache.Delete(key)
 
 	h.scalerCachesLock.Lock()
-	defer h.scalerCachesLock.Unlock()
-	if cache, ok := h.scalerCaches[key]; ok {
+	cache, ok := h.scalerCaches[key]
+	delete(h.scalerCaches, key)
+	h.scalerCachesLock.Unlock()
+
+	// the scalers are closed after unlocking, so caches of other scalable objects aren't blocked while closing
+	if ok {
 		log.V(1).WithValues("key", key).Info("Removing entry from ScalersCache")
 		cache.Close(ctx)
-		delete(h.scalerCaches, key)
 	}
 
 	return nil
 }
 
+// RefreshScalersCachesForAuthentication invalidates caches of the scalable objects with triggers referencing the TriggerAuthentication
+// or ClusterTriggerAuthentication, the scalers are built with the updated authentication on the next poll or metrics request,
+// recorded metrics of the objects are kept
+func (h *scaleHandler) RefreshScalersCachesForAuthentication(ctx context.Context, authenticationKind, authenticationNamespace, authenticationName string) {
+	ref := getAuthenticationRefIdentifier(authenticationKind, authenticationNamespace, authenticationName)
+
+	removed := []*cache.ScalersCache{}
+	h.scalerCachesLock.Lock()
+	for key, scalersCache := range h.scalerCaches {
+		for _, cacheRef := range scalersCache.AuthenticationRefs {
+			if cacheRef == ref {
+				log.V(1).WithValues("key", key, "authenticationRef", ref).Info("Authentication changed, removing entry from ScalersCache")
+				removed = append(removed, scalersCache)
+				delete(h.scalerCaches, key)
+				break
+			}
+		}
+	}
+	h.scalerCachesLock.Unlock()
+
+	for _, scalersCache := range removed {
+		scalersCache.Close(ctx)
+	}
+}
+
+// getAuthenticationRefs returns identifiers of the TriggerAuthentications and ClusterTriggerAuthentications referenced by the triggers
+func getAuthenticationRefs(withTriggers *kedav1alpha1.WithTriggers) []string {
+	refs := []string{}
+	for _, trigger := range withTriggers.Spec.Triggers {
+		if trigger.AuthenticationRef == nil {
+			continue
+		}
+		refs = append(refs, getAuthenticationRefIdentifier(trigger.AuthenticationRef.Kind, withTriggers.Namespace, trigger.AuthenticationRef.Name))
+	}
+	return refs
+}
+
+// getAuthenticationRefIdentifier returns the identifier of the authentication, ClusterTriggerAuthentications aren't namespaced
+func getAuthenticationRefIdentifier(kind, namespace, name string) string {
+	if kind == "" {
+		kind = "TriggerAuthentication"
+	}
+	if kind == "ClusterTriggerAuthentication" {
+		namespace = ""
+	}
+	return kedav1alpha1.GenerateIdentifier(kind, namespace, name)
+}
+
 /// --------------------------------------------------------------------------- ///
 /// ----------             ScaledObject related methods               --------- ///
 /// --------------------------------------------------------------------------- ///
 
+// GetScaledObjectMetricValues returns the metric values of the last check of the ScaledObject by metric name
+func (h *scaleHandler) GetScaledObjectMetricValues(namespace, name string) map[string]float64 {
+	return getMetricValues(h.scaledObjectsMetricCache.ReadRecords(kedav1alpha1.GenerateIdentifier("ScaledObject", namespace, name)))
+}
+
+// getMetricValues returns the sums of the values of the metrics by metric name
+func getMetricValues(metricsRecords map[string]metricscache.MetricsRecord) map[string]float64 {
+	metricValues := map[string]float64{}
+	for metricName, record := range metricsRecords {
+		for _, metric := range record.Metric {
+			metricValues[metricName] += metric.Value.AsApproximateFloat64()
+		}
+	}
+	return metricValues
+}
+
 // GetScaledObjectMetrics returns metrics for specified metric name for a ScaledObject identified by it's name and namespace.
 // The second return value are Prometheus metrics that needed to be exposed (used by DEPRECATED Prometheus Server on KEDA Metrics Server)
 // It could either query the metric value directly from the scaler or from a cache, that's being stored for the scaler.
@@ -429,8 +860,9 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 		return nil, &exportedPromMetrics, err
 	}
 
-	isScalerError := false
+	var metricErr error
 	scaledObjectIdentifier := scaledObject.GenerateIdentifier()
+	_, isWarmingUp := h.warmingUpScaledObjects.Load(scaledObjectIdentifier)
 
 	// let's check metrics for all scalers in a ScaledObject
 	scalers, scalerConfigs := cache.GetScalers()
@@ -439,12 +871,13 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 		if scalerConfigs[scalerIndex].TriggerName != "" {
 			scalerName = scalerConfigs[scalerIndex].TriggerName
 		}
+		// metrics are labeled by the index of the trigger, disabled triggers don't have any scaler in the cache
+		triggerIndex := scalerConfigs[scalerIndex].ScalerIndex
 
 		metricSpecs, err := cache.GetMetricSpecForScalingForScaler(ctx, scalerIndex)
 		if err != nil {
-			isScalerError = true
 			logger.Error(err, "error getting metric spec for the scaler", "scaler", scalerName)
-			cache.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+			cache.Recorder.AnnotatedEventf(scaledObject, getScalerFailedAnnotations(scalerConfigs[scalerIndex]), corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "%s", getScalerFailedMessage(err))
 		}
 
 		for _, spec := range metricSpecs {
@@ -459,9 +892,11 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 
 				// if cache is defined for this scaler/metric, let's try to hit it first
 				metricsFoundInCache := false
-				if scalerConfigs[scalerIndex].TriggerUseCachedMetrics {
+				// records of a stalled scale loop aren't served, the scaler is queried instead
+				if scalerConfigs[scalerIndex].TriggerUseCachedMetrics || isWarmingUp {
 					var metricsRecord metricscache.MetricsRecord
-					if metricsRecord, metricsFoundInCache = h.scaledObjectsMetricCache.ReadRecord(scaledObjectIdentifier, spec.External.Metric.Name); metricsFoundInCache {
+					maxAge := getMaxMetricsRecordAge(scaledObject)
+					if metricsRecord, metricsFoundInCache = h.scaledObjectsMetricCache.ReadFreshRecord(scaledObjectIdentifier, spec.External.Metric.Name, maxAge); metricsFoundInCache {
 						logger.V(1).Info("Reading metrics from cache", "scaler", scalerName, "metricName", spec.External.Metric.Name, "metricsRecord", metricsRecord)
 						metrics = metricsRecord.Metric
 						err = metricsRecord.ScalerError
@@ -472,26 +907,31 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 					var latency int64
 					metrics, _, latency, err = cache.GetMetricsAndActivityForScaler(ctx, scalerIndex, metricName)
 					if latency != -1 {
-						prommetrics.RecordScalerLatency(scaledObjectNamespace, scaledObject.Name, scalerName, scalerIndex, metricName, float64(latency))
+						prommetrics.RecordScalerLatency(scaledObjectNamespace, scaledObject.Name, scalerName, triggerIndex, metricName, float64(latency))
 					}
 					logger.V(1).Info("Getting metrics from scaler", "scaler", scalerName, "metricName", spec.External.Metric.Name, "metrics", metrics, "scalerError", err)
 				}
 
+				// post-process the proportional number of replicas, if a replica calculator is configured
+				if err == nil {
+					metrics = replicacalculator.GetMetricsWithReplicaCalculator(scaledObject, spec, metrics)
+				}
+
 				// check if we need to set a fallback
 				metrics, err = fallback.GetMetricsWithFallback(ctx, h.client, metrics, err, metricName, scaledObject, spec)
 
 				if err != nil {
-					isScalerError = true
+					metricErr = err
 					logger.Error(err, "error getting metric for scaler", "scaler", scalerName)
 				} else {
 					for _, metric := range metrics {
 						metricValue := metric.Value.AsApproximateFloat64()
-						prommetrics.RecordScalerMetric(scaledObjectNamespace, scaledObjectName, scalerName, scalerIndex, metric.MetricName, metricValue)
+						prommetrics.RecordScalerMetric(scaledObjectNamespace, scaledObjectName, scalerName, triggerIndex, metric.MetricName, metricValue)
 
 						// [DEPRECATED] handle exporting Prometheus metrics from Operator to Metrics Server
 						scalerMetricMsg := metricsserviceapi.ScalerMetricMsg{
 							ScalerName:  scalerName,
-							ScalerIndex: int32(scalerIndex),
+							ScalerIndex: int32(triggerIndex),
 							MetricName:  metricName,
 							MetricValue: float32(metricValue),
 						}
@@ -499,12 +939,12 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 					}
 					matchingMetrics = append(matchingMetrics, metrics...)
 				}
-				prommetrics.RecordScalerError(scaledObjectNamespace, scaledObjectName, scalerName, scalerIndex, metricName, err)
+				prommetrics.RecordScalerError(scaledObjectNamespace, scaledObjectName, scalerName, triggerIndex, metricName, err)
 
 				// [DEPRECATED] handle exporting Prometheus metrics from Operator to Metrics Server
 				scalerErrMsg := metricsserviceapi.ScalerErrorMsg{
 					ScalerName:  scalerName,
-					ScalerIndex: int32(scalerIndex),
+					ScalerIndex: int32(triggerIndex),
 					MetricName:  metricName,
 					Error:       (err != nil),
 				}
@@ -513,16 +953,9 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 		}
 	}
 
-	// invalidate the cache for the ScaledObject, if we hit an error in any scaler
-	// in this case we try to build all scalers (and resolve all secrets/creds) again in the next call
-	if isScalerError {
-		err := h.ClearScalersCache(ctx, scaledObject)
-		if err != nil {
-			logger.Error(err, "error clearing scalers cache")
-		}
-		logger.V(1).Info("scaler error encountered, clearing scaler cache")
+	if metricErr != nil {
+		return nil, &exportedPromMetrics, &MetricUnavailableError{MetricName: metricName, Reason: metricErr.Error()}
 	}
-
 	if len(matchingMetrics) == 0 {
 		return nil, &exportedPromMetrics, fmt.Errorf("no matching metrics found for " + metricName)
 	}
@@ -536,10 +969,9 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 // is active as the first return value,
 // the second return value indicates whether there was any error during quering scalers,
 // the third return value is a map of metrics record - a metric value for each scaler and it's metric
-// the fourth return value contains error if is not able access scalers cache
-func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, map[string]metricscache.MetricsRecord, error) {
-	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
-
+// the fourth return value is the highest replica count the HPA would calculate for the metric values of the triggers
+// the fifth return value contains error if is not able access scalers cache
+func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, map[string]metricscache.MetricsRecord, int32, error) {
 	isScaledObjectActive := false
 	isScalerError := false
 	metricsRecord := map[string]metricscache.MetricsRecord{}
@@ -547,7 +979,8 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	cache, err := h.GetScalersCache(ctx, scaledObject)
 	prommetrics.RecordScaledObjectError(scaledObject.Namespace, scaledObject.Name, err)
 	if err != nil {
-		return false, true, map[string]metricscache.MetricsRecord{}, fmt.Errorf("error getting scalers cache %w", err)
+		prommetrics.RecordInternalError("scale_handler")
+		return false, true, map[string]metricscache.MetricsRecord{}, 0, fmt.Errorf("error getting scalers cache %w", err)
 	}
 
 	// count the number of non-external triggers (cpu/mem) in order to check for
@@ -556,87 +989,271 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	// evaluated in the loop below.
 	cpuMemCount := 0
 	for _, trigger := range scaledObject.Spec.Triggers {
-		if trigger.Type == "cpu" || trigger.Type == "memory" {
+		if trigger.IsEnabled() && (trigger.Type == "cpu" || trigger.Type == "memory") {
 			cpuMemCount++
 		}
 	}
 
-	// Let's collect status of all scalers, no matter if any scaler raises error or is active
+	// Let's collect status of all scalers, no matter if any scaler raises error or is active,
+	// the scalers are checked concurrently so a slow source doesn't delay the others
 	scalers, scalerConfigs := cache.GetScalers()
+	states := make([]scalerState, len(scalers))
+	concurrency := make(chan struct{}, scalerChecksConfig.concurrency)
+	wg := sync.WaitGroup{}
 	for scalerIndex := 0; scalerIndex < len(scalers); scalerIndex++ {
-		scalerName := strings.Replace(fmt.Sprintf("%T", scalers[scalerIndex]), "*scalers.", "", 1)
-		if scalerConfigs[scalerIndex].TriggerName != "" {
-			scalerName = scalerConfigs[scalerIndex].TriggerName
+		wg.Add(1)
+		concurrency <- struct{}{}
+		go func(scalerIndex int) {
+			defer func() {
+				<-concurrency
+				wg.Done()
+			}()
+			states[scalerIndex] = h.getScalerState(ctx, scaledObject, cache, scalerIndex, scalers[scalerIndex], scalerConfigs[scalerIndex], cpuMemCount)
+		}(scalerIndex)
+	}
+	wg.Wait()
+	// circuits are indexed by the scalers in the cache, disabled triggers don't have any
+	openCircuits := cache.GetOpenCircuits()
+	for i, index := range openCircuits {
+		if index < len(scalerConfigs) {
+			openCircuits[i] = scalerConfigs[index].ScalerIndex
+		}
+	}
+	h.updateCircuitOpenCondition(ctx, scaledObject, openCircuits)
+
+	metricReplicaCount := int32(0)
+	for _, state := range states {
+		isScaledObjectActive = isScaledObjectActive || state.isActive
+		isScalerError = isScalerError || state.isError
+		for metricName, record := range state.metricsRecord {
+			metricsRecord[metricName] = record
+		}
+		if state.metricReplicaCount > metricReplicaCount {
+			metricReplicaCount = state.metricReplicaCount
+		}
+	}
+	if scaledObject.Spec.ActivationStrategy == kedav1alpha1.ActivationStrategyAllOf {
+		isScaledObjectActive = isAllOfActive(states, isScaledObjectActive)
+	}
+
+	return isScaledObjectActive, isScalerError, metricsRecord, metricReplicaCount, nil
+}
+
+// getTriggerMetrics returns the metrics recorded in the last check of the named trigger of the ScaledObject,
+// it's used by scaled-object-trigger scalers to reuse the metrics without querying the scaler of the trigger
+func (h *scaleHandler) getTriggerMetrics(ctx context.Context, scaledObjectNamespace, scaledObjectName, triggerName string) ([]external_metrics.ExternalMetricValue, error) {
+	key := kedav1alpha1.GenerateIdentifier("ScaledObject", scaledObjectNamespace, scaledObjectName)
+
+	h.scalerCachesLock.RLock()
+	cache, ok := h.scalerCaches[key]
+	h.scalerCachesLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no metrics recorded for ScaledObject %s/%s", scaledObjectNamespace, scaledObjectName)
+	}
+
+	_, scalerConfigs := cache.GetScalers()
+	for scalerIndex, scalerConfig := range scalerConfigs {
+		if scalerConfig.TriggerName != triggerName {
+			continue
 		}
 
 		metricSpecs, err := cache.GetMetricSpecForScalingForScaler(ctx, scalerIndex)
 		if err != nil {
-			isScalerError = true
-			logger.Error(err, "error getting metric spec for the scaler", "scaler", scalerName)
-			cache.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+			return nil, err
 		}
 
+		var metrics []external_metrics.ExternalMetricValue
 		for _, spec := range metricSpecs {
-			// if cpu/memory resource scaler has minReplicas==0 & at least one external
-			// trigger exists -> object can be scaled to zero
 			if spec.External == nil {
-				if len(scaledObject.Spec.Triggers) <= cpuMemCount {
-					isScaledObjectActive = true
-				}
-				continue
+				return nil, fmt.Errorf("trigger %s of ScaledObject %s/%s isn't an external metric", triggerName, scaledObjectNamespace, scaledObjectName)
+			}
+			record, found := h.scaledObjectsMetricCache.ReadRecord(key, spec.External.Metric.Name)
+			if !found {
+				return nil, fmt.Errorf("no metrics recorded for trigger %s of ScaledObject %s/%s", triggerName, scaledObjectNamespace, scaledObjectName)
+			}
+			if record.ScalerError != nil {
+				return nil, fmt.Errorf("trigger %s of ScaledObject %s/%s failed: %w", triggerName, scaledObjectNamespace, scaledObjectName, record.ScalerError)
 			}
+			metrics = append(metrics, record.Metric...)
+		}
+		return metrics, nil
+	}
+
+	return nil, fmt.Errorf("trigger %s not found in ScaledObject %s/%s", triggerName, scaledObjectNamespace, scaledObjectName)
+}
 
-			metricName := spec.External.Metric.Name
+// isAllOfActive returns true if all scalers with external metrics are active, cpu and memory scalers don't take part,
+// failed scalers aren't active, anyOfActive is returned if there's no scaler with external metrics
+func isAllOfActive(states []scalerState, anyOfActive bool) bool {
+	checked := false
+	for _, state := range states {
+		if state.isError {
+			return false
+		}
+		if !state.hasExternalMetrics {
+			continue
+		}
+		if !state.isActive {
+			return false
+		}
+		checked = true
+	}
+	if !checked {
+		return anyOfActive
+	}
+	return true
+}
 
-			var latency int64
-			metrics, isMetricActive, latency, err := cache.GetMetricsAndActivityForScaler(ctx, scalerIndex, metricName)
-			if latency != -1 {
-				prommetrics.RecordScalerLatency(scaledObject.Namespace, scaledObject.Name, scalerName, scalerIndex, metricName, float64(latency))
+// updateCircuitOpenCondition reports the triggers with open circuit in the CircuitOpen condition of the ScaledObject,
+// the status is patched only if the condition changes
+func (h *scaleHandler) updateCircuitOpenCondition(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, openCircuits []int) {
+	condition := scaledObject.Status.Conditions.GetCircuitOpenCondition()
+	status := scaledObject.Status.DeepCopy()
+	if len(openCircuits) == 0 {
+		if !condition.IsTrue() {
+			return
+		}
+		status.Conditions.SetCircuitOpenCondition(metav1.ConditionFalse, "CircuitClosed", "All triggers are checked")
+	} else {
+		triggers := make([]string, 0, len(openCircuits))
+		for _, index := range openCircuits {
+			trigger := fmt.Sprintf("%d", index)
+			if index < len(scaledObject.Spec.Triggers) {
+				trigger = fmt.Sprintf("%d (%s)", index, scaledObject.Spec.Triggers[index].Type)
 			}
-			logger.V(1).Info("Getting metrics and activity from scaler", "scaler", scalerName, "metricName", metricName, "metrics", metrics, "activity", isMetricActive, "scalerError", err)
+			triggers = append(triggers, trigger)
+		}
+		msg := fmt.Sprintf("Checks of triggers %s are skipped after consecutive failures", strings.Join(triggers, ", "))
+		if condition.IsTrue() && condition.Message == msg {
+			return
+		}
+		status.Conditions.SetCircuitOpenCondition(metav1.ConditionTrue, "ScalerCircuitOpen", msg)
+	}
 
-			if scalerConfigs[scalerIndex].TriggerUseCachedMetrics {
-				metricsRecord[metricName] = metricscache.MetricsRecord{
-					IsActive:    isMetricActive,
-					Metric:      metrics,
-					ScalerError: err,
-				}
+	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, h.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "error setting circuit open condition")
+	}
+}
+
+// getMaxMetricsRecordAge returns how old metric records of the ScaledObject could be served to the HPA,
+// the scale loop refreshes them every polling interval, so records older than two of the longest intervals are stale
+func getMaxMetricsRecordAge(scaledObject *kedav1alpha1.ScaledObject) time.Duration {
+	withTriggers := kedav1alpha1.WithTriggers{Spec: kedav1alpha1.WithTriggersSpec{PollingInterval: scaledObject.Spec.PollingInterval}}
+	pollingInterval := withTriggers.GetPollingInterval()
+	if scaledObject.Spec.AdaptivePolling != nil {
+		if maxPollingInterval := time.Second * time.Duration(scaledObject.Spec.AdaptivePolling.MaxPollingInterval); maxPollingInterval > pollingInterval {
+			pollingInterval = maxPollingInterval
+		}
+	}
+	return 2*pollingInterval + scalerChecksConfig.timeout
+}
+
+// scalerState is the result of the check of a single scaler of a ScaledObject
+type scalerState struct {
+	isActive      bool
+	isError       bool
+	metricsRecord map[string]metricscache.MetricsRecord
+	// hasExternalMetrics is false for cpu and memory scalers, their activity isn't known to KEDA
+	hasExternalMetrics bool
+	// metricReplicaCount is the highest replica count the HPA would calculate for the metric values of the scaler
+	// with metric of type AverageValue
+	metricReplicaCount int32
+}
+
+// getScalerState checks the scaler given by scalerIndex, the metric spec and the metrics of the scaler
+// are requested within scalerChecksConfig.timeout or the checkTimeout of the trigger
+func (h *scaleHandler) getScalerState(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, scalersCache *cache.ScalersCache, scalerIndex int, scaler scalers.Scaler, scalerConfig scalers.ScalerConfig, cpuMemCount int) scalerState {
+	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	state := scalerState{metricsRecord: map[string]metricscache.MetricsRecord{}}
+
+	// the timeout of the trigger overrides the global one
+	ctx, cancel := cache.WithCheckTimeout(ctx, scalerChecksConfig.timeout, scalerConfig)
+	defer cancel()
+
+	scalerName := strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
+	if scalerConfig.TriggerName != "" {
+		scalerName = scalerConfig.TriggerName
+	}
+
+	metricSpecs, err := scalersCache.GetMetricSpecForScalingForScaler(ctx, scalerIndex)
+	if err != nil {
+		state.isError = true
+		logger.Error(err, "error getting metric spec for the scaler", "scaler", scalerName)
+		scalersCache.Recorder.AnnotatedEventf(scaledObject, getScalerFailedAnnotations(scalerConfig), corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "%s", getScalerFailedMessage(err))
+	}
+
+	for _, spec := range metricSpecs {
+		// if cpu/memory resource scaler has minReplicas==0 & at least one external
+		// trigger exists -> object can be scaled to zero
+		if spec.External == nil {
+			if scalersList, _ := scalersCache.GetScalers(); len(scalersList) <= cpuMemCount {
+				state.isActive = true
 			}
+			continue
+		}
 
-			if err != nil {
-				isScalerError = true
-				logger.Error(err, "error getting scale decision", "scaler", scalerName)
-				cache.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
-			} else {
-				for _, metric := range metrics {
-					metricValue := metric.Value.AsApproximateFloat64()
-					prommetrics.RecordScalerMetric(scaledObject.Namespace, scaledObject.Name, scalerName, scalerIndex, metric.MetricName, metricValue)
-				}
+		metricName := spec.External.Metric.Name
+		state.hasExternalMetrics = true
 
-				if isMetricActive {
-					isScaledObjectActive = true
-					if spec.External != nil {
-						logger.V(1).Info("Scaler for scaledObject is active", "scaler", scalerName, "metricName", metricName)
-					}
-					if spec.Resource != nil {
-						logger.V(1).Info("Scaler for scaledObject is active", "scaler", scalerName, "metricName", spec.Resource.Name)
-					}
+		var latency int64
+		metrics, isMetricActive, latency, err := scalersCache.GetMetricsAndActivityForScaler(ctx, scalerIndex, metricName)
+		if latency != -1 {
+			prommetrics.RecordScalerLatency(scaledObject.Namespace, scaledObject.Name, scalerName, scalerConfig.ScalerIndex, metricName, float64(latency))
+		}
+		logger.V(1).Info("Getting metrics and activity from scaler", "scaler", scalerName, "metricName", metricName, "metrics", metrics, "activity", isMetricActive, "scalerError", err)
+
+		// metric values of all triggers are recorded, they're needed to report the desired replica count, to adapt
+		// the polling interval and to record scaling decisions, metric values of named triggers could be reused by
+		// scaled-object-trigger scalers of other ScaledObjects
+		state.metricsRecord[metricName] = metricscache.MetricsRecord{
+			IsActive:    isMetricActive,
+			Metric:      metrics,
+			ScalerError: err,
+		}
+
+		switch {
+		case errors.Is(err, cache.ErrScalerCircuitOpen):
+			// the failure was already reported when the circuit opened
+			state.isError = true
+			logger.V(1).Info("Skipping check of scaler with open circuit", "scaler", scalerName, "metricName", metricName, "error", err.Error())
+		case err != nil:
+			state.isError = true
+			logger.Error(err, "error getting scale decision", "scaler", scalerName)
+			scalersCache.Recorder.AnnotatedEventf(scaledObject, getScalerFailedAnnotations(scalerConfig), corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "%s", getScalerFailedMessage(err))
+		default:
+			for _, metric := range metrics {
+				metricValue := metric.Value.AsApproximateFloat64()
+				prommetrics.RecordScalerMetric(scaledObject.Namespace, scaledObject.Name, scalerName, scalerConfig.ScalerIndex, metric.MetricName, metricValue)
+			}
+			if metricReplicas, ok := replicacalculator.GetProportionalReplicaCount(scaledObject, spec, metrics); ok && metricReplicas > state.metricReplicaCount {
+				state.metricReplicaCount = metricReplicas
+			}
+
+			if isMetricActive {
+				state.isActive = true
+				if spec.External != nil {
+					logger.V(1).Info("Scaler for scaledObject is active", "scaler", scalerName, "metricName", metricName)
+				}
+				if spec.Resource != nil {
+					logger.V(1).Info("Scaler for scaledObject is active", "scaler", scalerName, "metricName", spec.Resource.Name)
 				}
 			}
-			prommetrics.RecordScalerError(scaledObject.Namespace, scaledObject.Name, scalerName, scalerIndex, metricName, err)
-			prommetrics.RecordScalerActive(scaledObject.Namespace, scaledObject.Name, scalerName, scalerIndex, metricName, isMetricActive)
 		}
+		prommetrics.RecordScalerError(scaledObject.Namespace, scaledObject.Name, scalerName, scalerConfig.ScalerIndex, metricName, err)
+		prommetrics.RecordScalerActive(scaledObject.Namespace, scaledObject.Name, scalerName, scalerConfig.ScalerIndex, metricName, isMetricActive)
 	}
 
-	// invalidate the cache for the ScaledObject, if we hit an error in any scaler
-	// in this case we try to build all scalers (and resolve all secrets/creds) again in the next call
-	if isScalerError {
-		err := h.ClearScalersCache(ctx, scaledObject)
-		if err != nil {
-			logger.Error(err, "error clearing scalers cache")
-		}
-		logger.V(1).Info("scaler error encountered, clearing scaler cache")
-	}
+	return state
+}
+
+// getScalerFailedMessage returns the message of KEDAScalerFailed events, with the category of the error
+// so users can tell eg. rejected credentials from unreachable sources
+func getScalerFailedMessage(err error) string {
+	return scalers.GetScalerErrorMessage(err)
+}
 
-	return isScaledObjectActive, isScalerError, metricsRecord, nil
+// getScalerFailedAnnotations returns the annotations of KEDAScalerFailed events, with the trigger of the failed scaler
+func getScalerFailedAnnotations(config scalers.ScalerConfig) map[string]string {
+	return eventemitter.TriggerAnnotations(config.TriggerName, config.TriggerType)
 }
@@ -19,7 +19,9 @@ package scaling
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -53,6 +55,7 @@ func TestGetScaledObjectMetrics_DirectCall(t *testing.T) {
 	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
 
 	metricsSpecs := []v2.MetricSpec{createMetricSpec(10, metricName)}
+	metricsSpecs[0].External.Target.Type = v2.AverageValueMetricType
 	metricValue := scalers.GenerateMetricInMili(metricName, float64(10))
 
 	metricsRecord := map[string]metricscache.MetricsRecord{}
@@ -115,8 +118,10 @@ func TestGetScaledObjectMetrics_DirectCall(t *testing.T) {
 	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
 	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{metricValue}, true, nil)
-	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
-	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
+	// the desired replica count is calculated from the metric values without dry-run or decision history
+	mockExecutor.EXPECT().RecordDesiredReplicas(gomock.Any(), gomock.Any(), true, false, int32(1))
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{}, true)
 
 	mockClient.EXPECT().Status().Return(mockStatusWriter)
 	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
@@ -207,8 +212,9 @@ func TestGetScaledObjectMetrics_FromCache(t *testing.T) {
 	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
 	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{metricValue}, true, nil)
-	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
-	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
+	mockExecutor.EXPECT().RecordDesiredReplicas(gomock.Any(), gomock.Any(), true, false, gomock.Any())
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{}, true)
 
 	mockClient.EXPECT().Status().Return(mockStatusWriter)
 	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
@@ -223,6 +229,131 @@ func TestGetScaledObjectMetrics_FromCache(t *testing.T) {
 	scalerCache.Close(context.Background())
 }
 
+func TestGetScaledObjectMetrics_ScalerError(t *testing.T) {
+	metricName := "s0-metric-name"
+
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(1, metricName)})
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{}, false, errors.New("backend outage"))
+	scaler.EXPECT().Close(gomock.Any())
+
+	factory := func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{}, false, errors.New("backend outage"))
+		scaler.EXPECT().Close(gomock.Any())
+		return scaler, &scalers.ScalerConfig{}, nil
+	}
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+		},
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &cache.ScalersCache{
+		ScaledObject: &scaledObject,
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:  scaler,
+			Factory: factory,
+		}},
+		Recorder: recorder,
+	}
+
+	sh := scaleHandler{
+		client:                   mockClient,
+		scaleLoopContexts:        &sync.Map{},
+		globalHTTPTimeout:        time.Duration(1000),
+		recorder:                 recorder,
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	// the trigger is marked as failing in the health status
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	metrics, _, err := sh.GetScaledObjectMetrics(context.TODO(), "test", "test", metricName)
+	assert.Nil(t, metrics)
+	var unavailableErr *MetricUnavailableError
+	assert.ErrorAs(t, err, &unavailableErr)
+	assert.Equal(t, metricName, unavailableErr.MetricName)
+	assert.Contains(t, unavailableErr.Reason, "backend outage")
+	assert.Equal(t, kedav1alpha1.HealthStatusFailing, scaledObject.Status.Health[metricName].Status)
+
+	// the failing scaler was rebuilt, the cache is kept
+	caches[scaledObject.GenerateIdentifier()].Close(context.Background())
+}
+
+func TestGetTriggerMetrics(t *testing.T) {
+	metricName := "s0-metric-name"
+
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	// the metric spec is requested, but the metric isn't queried from the scaler
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(10, metricName)}).AnyTimes()
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "producer",
+			Namespace: "test",
+		},
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &cache.ScalersCache{
+		ScaledObject: &scaledObject,
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:       scaler,
+			ScalerConfig: scalers.ScalerConfig{TriggerName: "backlog"},
+		}},
+	}
+
+	sh := scaleHandler{
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	_, err := sh.getTriggerMetrics(context.TODO(), "test", "producer", "backlog")
+	assert.Error(t, err, "metrics weren't recorded yet")
+
+	// metrics of named triggers are recorded by the name derived from the name of the trigger
+	triggerMetricName := "s.backlog.metric-name"
+	sh.scaledObjectsMetricCache.StoreRecords(scaledObject.GenerateIdentifier(), map[string]metricscache.MetricsRecord{
+		triggerMetricName: {Metric: []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(triggerMetricName, 42)}},
+	})
+
+	metrics, err := sh.getTriggerMetrics(context.TODO(), "test", "producer", "backlog")
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 1)
+	assert.Equal(t, int64(42), metrics[0].Value.Value())
+
+	_, err = sh.getTriggerMetrics(context.TODO(), "test", "producer", "unknown")
+	assert.Error(t, err)
+
+	_, err = sh.getTriggerMetrics(context.TODO(), "test", "consumer", "backlog")
+	assert.Error(t, err)
+
+	sh.scaledObjectsMetricCache.StoreRecords(scaledObject.GenerateIdentifier(), map[string]metricscache.MetricsRecord{
+		triggerMetricName: {ScalerError: errors.New("backend outage")},
+	})
+	_, err = sh.getTriggerMetrics(context.TODO(), "test", "producer", "backlog")
+	assert.ErrorContains(t, err, "backend outage")
+}
+
 func TestCheckScaledObjectScalersWithError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockClient := mock_client.NewMockClient(ctrl)
@@ -277,13 +408,125 @@ func TestCheckScaledObjectScalersWithError(t *testing.T) {
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 
-	isActive, isError, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	isActive, isError, _, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	assert.Contains(t, sh.scalerCaches, scaledObject.GenerateIdentifier())
 	scalerCache.Close(context.Background())
 
 	assert.Equal(t, false, isActive)
 	assert.Equal(t, true, isError)
 }
 
+func TestCheckScaledObjectKeepsHealthyScalersOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(10)
+
+	// the healthy scaler is reused, it's closed only with the cache
+	healthyScaler := mock_scalers.NewMockScaler(ctrl)
+	healthyScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(1, "s0-healthy")}).Times(2)
+	healthyScaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{}, true, nil).Times(2)
+	healthyScaler.EXPECT().Close(gomock.Any())
+
+	// the failing scaler is rebuilt in each check
+	newFailingScaler := func() *mock_scalers.MockScaler {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(1, "s1-failing")}).AnyTimes()
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{}, false, errors.New("some error")).AnyTimes()
+		scaler.EXPECT().Close(gomock.Any())
+		return scaler
+	}
+	factory := func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+		return newFailingScaler(), &scalers.ScalerConfig{}, nil
+	}
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+	}
+
+	scalerCache := &cache.ScalersCache{
+		Scalers: []cache.ScalerBuilder{
+			{Scaler: healthyScaler},
+			{Scaler: newFailingScaler(), Factory: factory},
+		},
+		Recorder: recorder,
+	}
+
+	sh := scaleHandler{
+		scaleLoopContexts:        &sync.Map{},
+		recorder:                 recorder,
+		scalerCaches:             map[string]*cache.ScalersCache{scaledObject.GenerateIdentifier(): scalerCache},
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	for i := 0; i < 2; i++ {
+		isActive, isError, _, _, err := sh.getScaledObjectState(context.TODO(), &scaledObject)
+		assert.NoError(t, err)
+		assert.True(t, isActive)
+		assert.True(t, isError)
+		assert.Same(t, scalerCache, sh.scalerCaches[scaledObject.GenerateIdentifier()])
+		assert.Equal(t, healthyScaler, scalerCache.Scalers[0].Scaler)
+	}
+
+	scalerCache.Close(context.Background())
+}
+
+func TestRefreshScalersCachesForAuthentication(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	newScalerCache := func(authenticationRefs ...string) *cache.ScalersCache {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		return &cache.ScalersCache{
+			Scalers:            []cache.ScalerBuilder{{Scaler: scaler}},
+			AuthenticationRefs: authenticationRefs,
+		}
+	}
+	triggerAuthCache := newScalerCache(getAuthenticationRefIdentifier("", "test", "auth"))
+	clusterTriggerAuthCache := newScalerCache(getAuthenticationRefIdentifier("ClusterTriggerAuthentication", "test", "auth"))
+	otherNamespaceCache := newScalerCache(getAuthenticationRefIdentifier("TriggerAuthentication", "other", "auth"))
+	noAuthCache := newScalerCache()
+
+	sh := scaleHandler{
+		scalerCaches: map[string]*cache.ScalersCache{
+			"triggerauth":        triggerAuthCache,
+			"clustertriggerauth": clusterTriggerAuthCache,
+			"othernamespace":     otherNamespaceCache,
+			"noauth":             noAuthCache,
+		},
+		scalerCachesLock: &sync.RWMutex{},
+	}
+
+	// only scalers referencing the changed authentication are closed
+	triggerAuthCache.Scalers[0].Scaler.(*mock_scalers.MockScaler).EXPECT().Close(gomock.Any())
+	sh.RefreshScalersCachesForAuthentication(context.Background(), "TriggerAuthentication", "test", "auth")
+	assert.NotContains(t, sh.scalerCaches, "triggerauth")
+	assert.Len(t, sh.scalerCaches, 3)
+
+	clusterTriggerAuthCache.Scalers[0].Scaler.(*mock_scalers.MockScaler).EXPECT().Close(gomock.Any())
+	sh.RefreshScalersCachesForAuthentication(context.Background(), "ClusterTriggerAuthentication", "", "auth")
+	assert.NotContains(t, sh.scalerCaches, "clustertriggerauth")
+	assert.Len(t, sh.scalerCaches, 2)
+}
+
+func TestGetAuthenticationRefs(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "cpu"},
+				{Type: "kafka", AuthenticationRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "kafka-auth"}},
+				{Type: "redis", AuthenticationRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "redis-auth", Kind: "ClusterTriggerAuthentication"}},
+			},
+		},
+	}
+	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scaledObject)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"triggerauthentication.test.kafka-auth", "clustertriggerauthentication..redis-auth"}, getAuthenticationRefs(withTriggers))
+}
+
 func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockClient := mock_client.NewMockClient(ctrl)
@@ -352,13 +595,449 @@ func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 
-	isActive, isError, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	isActive, isError, _, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
 	scalerCache.Close(context.Background())
 
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, true, isError)
 }
 
+func TestCheckScaledObjectAllOfActivation(t *testing.T) {
+	tests := []struct {
+		name       string
+		strategy   string
+		activities []bool
+		wantActive bool
+	}{
+		{"any of with one active trigger", kedav1alpha1.ActivationStrategyAnyOf, []bool{true, false}, true},
+		{"all of with one active trigger", kedav1alpha1.ActivationStrategyAllOf, []bool{true, false}, false},
+		{"all of with all triggers active", kedav1alpha1.ActivationStrategyAllOf, []bool{true, true}, true},
+		{"all of without active triggers", kedav1alpha1.ActivationStrategyAllOf, []bool{false, false}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			recorder := record.NewFakeRecorder(1)
+
+			var builders []cache.ScalerBuilder
+			for i, active := range test.activities {
+				scaler := mock_scalers.NewMockScaler(ctrl)
+				scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(1, fmt.Sprintf("s%d-metric", i))})
+				scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{}, active, nil)
+				builders = append(builders, cache.ScalerBuilder{Scaler: scaler})
+			}
+
+			scaledObject := kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef:     &kedav1alpha1.ScaleTarget{Name: "test"},
+					ActivationStrategy: test.strategy,
+				},
+			}
+			sh := scaleHandler{
+				scaleLoopContexts: &sync.Map{},
+				recorder:          recorder,
+				scalerCaches: map[string]*cache.ScalersCache{
+					scaledObject.GenerateIdentifier(): {Scalers: builders, Recorder: recorder},
+				},
+				scalerCachesLock:         &sync.RWMutex{},
+				scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+			}
+
+			isActive, isError, _, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+			assert.Equal(t, test.wantActive, isActive)
+			assert.False(t, isError)
+		})
+	}
+}
+
+func TestIsAllOfActive(t *testing.T) {
+	active := scalerState{isActive: true, hasExternalMetrics: true}
+	inactive := scalerState{hasExternalMetrics: true}
+	failed := scalerState{isError: true}
+	cpu := scalerState{}
+
+	assert.True(t, isAllOfActive([]scalerState{active, active}, true))
+	assert.False(t, isAllOfActive([]scalerState{active, inactive}, true))
+	// failed scalers without metric specs aren't active
+	assert.False(t, isAllOfActive([]scalerState{active, failed}, true))
+	// cpu and memory scalers don't take part
+	assert.True(t, isAllOfActive([]scalerState{active, cpu}, true))
+	assert.True(t, isAllOfActive([]scalerState{cpu}, true))
+	assert.False(t, isAllOfActive([]scalerState{cpu}, false))
+}
+
+func TestCheckScaledObjectScalersConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+
+	metricsSpecs := []v2.MetricSpec{createMetricSpec(1, "metric-name")}
+
+	scalerBuilders := []cache.ScalerBuilder{}
+	for i := 0; i < 3; i++ {
+		isActive := i == 2
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, string) ([]external_metrics.ExternalMetricValue, bool, error) {
+			time.Sleep(200 * time.Millisecond)
+			return []external_metrics.ExternalMetricValue{}, isActive, nil
+		})
+		scalerBuilders = append(scalerBuilders, cache.ScalerBuilder{Scaler: scaler})
+	}
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+		},
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &cache.ScalersCache{
+		Scalers:  scalerBuilders,
+		Recorder: recorder,
+	}
+
+	sh := scaleHandler{
+		scaleLoopContexts: &sync.Map{},
+		recorder:          recorder,
+		scalerCaches:      caches,
+		scalerCachesLock:  &sync.RWMutex{},
+	}
+
+	start := time.Now()
+	isActive, isError, _, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, true, isActive)
+	assert.Equal(t, false, isError)
+}
+
+func TestCheckScaledObjectScalerTimeout(t *testing.T) {
+	defer ConfigureScalerChecks(DefaultScalerCheckConcurrency, DefaultScalerCheckTimeout)
+	ConfigureScalerChecks(DefaultScalerCheckConcurrency, 50*time.Millisecond)
+
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+
+	metricsSpecs := []v2.MetricSpec{createMetricSpec(1, "metric-name")}
+	waitForTimeout := func(ctx context.Context, _ string) ([]external_metrics.ExternalMetricValue, bool, error) {
+		<-ctx.Done()
+		return nil, false, ctx.Err()
+	}
+
+	hungFactory := func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).DoAndReturn(waitForTimeout)
+		return scaler, &scalers.ScalerConfig{}, nil
+	}
+	hungScaler := mock_scalers.NewMockScaler(ctrl)
+	hungScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
+	hungScaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).DoAndReturn(waitForTimeout)
+	hungScaler.EXPECT().Close(gomock.Any())
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+		},
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &cache.ScalersCache{
+		Scalers:  []cache.ScalerBuilder{{Scaler: hungScaler, Factory: hungFactory}},
+		Recorder: recorder,
+	}
+
+	sh := scaleHandler{
+		scaleLoopContexts: &sync.Map{},
+		recorder:          recorder,
+		scalerCaches:      caches,
+		scalerCachesLock:  &sync.RWMutex{},
+	}
+
+	isActive, isError, _, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+
+	assert.Equal(t, false, isActive)
+	assert.Equal(t, true, isError)
+}
+
+func TestCheckScaledObjectDryRun(t *testing.T) {
+	metricName := "test-metric-name"
+
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+
+	metricSpec := createMetricSpec(10, metricName)
+	metricSpec.External.Target.Type = v2.AverageValueMetricType
+	metricsSpecs := []v2.MetricSpec{metricSpec}
+	metricValue := scalers.GenerateMetricInMili(metricName, float64(35))
+
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	scalerConfig := scalers.ScalerConfig{}
+	factory := func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+		return scaler, &scalerConfig, nil
+	}
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "test",
+			Annotations: map[string]string{"autoscaling.keda.sh/dry-run": "true"},
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+		},
+	}
+
+	scalerCache := cache.ScalersCache{
+		ScaledObject: &scaledObject,
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:       scaler,
+			ScalerConfig: scalerConfig,
+			Factory:      factory,
+		}},
+		Recorder: recorder,
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &scalerCache
+
+	sh := scaleHandler{
+		client:                   mockClient,
+		scaleLoopContexts:        &sync.Map{},
+		scaleExecutor:            mockExecutor,
+		globalHTTPTimeout:        time.Duration(1000),
+		recorder:                 recorder,
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{metricValue}, true, nil)
+	mockExecutor.EXPECT().RecordDesiredReplicas(gomock.Any(), gomock.Any(), true, false, int32(4))
+	mockExecutor.EXPECT().RequestDryRunScale(gomock.Any(), gomock.Any(), true, false, int32(4))
+	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{}, true)
+}
+
+func TestCheckScaledObjectForcedActivation(t *testing.T) {
+	metricName := "test-metric-name"
+
+	tests := []struct {
+		annotation string
+		active     bool
+		scalerErr  error
+		wantActive bool
+		wantError  bool
+	}{
+		{annotation: "true", active: false, wantActive: true},
+		{annotation: "true", active: false, scalerErr: errors.New("some error"), wantActive: true},
+		{annotation: "false", active: true, wantActive: false},
+		{annotation: "unknown", active: true, wantActive: true},
+	}
+
+	for _, test := range tests {
+		ctrl := gomock.NewController(t)
+		recorder := record.NewFakeRecorder(10)
+		mockClient := mock_client.NewMockClient(ctrl)
+		mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+		mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+		metricsSpecs := []v2.MetricSpec{createMetricSpec(10, metricName)}
+		metricValue := scalers.GenerateMetricInMili(metricName, float64(10))
+
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scalerConfig := scalers.ScalerConfig{}
+		factory := func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+			return scaler, &scalerConfig, nil
+		}
+
+		scaledObject := kedav1alpha1.ScaledObject{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test",
+				Namespace:   "test",
+				Annotations: map[string]string{"autoscaling.keda.sh/force-activation": test.annotation},
+			},
+			Spec: kedav1alpha1.ScaledObjectSpec{
+				ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+					Name: "test",
+				},
+			},
+		}
+
+		scalerCache := cache.ScalersCache{
+			ScaledObject: &scaledObject,
+			Scalers: []cache.ScalerBuilder{{
+				Scaler:       scaler,
+				ScalerConfig: scalerConfig,
+				Factory:      factory,
+			}},
+			Recorder: recorder,
+		}
+
+		caches := map[string]*cache.ScalersCache{}
+		caches[scaledObject.GenerateIdentifier()] = &scalerCache
+
+		sh := scaleHandler{
+			client:                   mockClient,
+			scaleLoopContexts:        &sync.Map{},
+			scaleExecutor:            mockExecutor,
+			globalHTTPTimeout:        time.Duration(1000),
+			recorder:                 recorder,
+			scalerCaches:             caches,
+			scalerCachesLock:         &sync.RWMutex{},
+			scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+		}
+
+		mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes()
+		mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs).AnyTimes()
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{metricValue}, test.active, test.scalerErr).AnyTimes()
+		scaler.EXPECT().Close(gomock.Any()).AnyTimes()
+		mockExecutor.EXPECT().RecordDesiredReplicas(gomock.Any(), gomock.Any(), test.wantActive, test.wantError, gomock.Any())
+		mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), test.wantActive, test.wantError, true, gomock.Any())
+		sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{}, true)
+		ctrl.Finish()
+	}
+}
+
+func TestPushScalerRequestsCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+	pushScaler := mock_scalers.NewMockPushScaler(ctrl)
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+		},
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &cache.ScalersCache{
+		ScaledObject: &scaledObject,
+		Scalers:      []cache.ScalerBuilder{{Scaler: pushScaler}},
+		Recorder:     recorder,
+	}
+
+	sh := scaleHandler{
+		scaleLoopContexts: &sync.Map{},
+		recorder:          recorder,
+		scalerCaches:      caches,
+		scalerCachesLock:  &sync.RWMutex{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalled := make(chan struct{})
+	pushScaler.EXPECT().Run(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, active chan<- bool) {
+		defer close(active)
+		active <- true
+		active <- false
+		close(signalled)
+		<-ctx.Done()
+	})
+
+	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(&scaledObject)
+	assert.NoError(t, err)
+	var requested int32
+	sh.startPushScalers(ctx, withTriggers, &scaledObject, func() { atomic.AddInt32(&requested, 1) })
+
+	<-signalled
+	// every signal requests a check, they are coalesced by the scale scheduler
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&requested) == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestCheckScaleLoopDistinguishesRequestedChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+		},
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &cache.ScalersCache{
+		ScaledObject: &scaledObject,
+		Scalers:      []cache.ScalerBuilder{{Scaler: scaler}},
+		Recorder:     recorder,
+	}
+
+	sh := scaleHandler{
+		client:                   mockClient,
+		scaleLoopContexts:        &sync.Map{},
+		scaleExecutor:            mockExecutor,
+		globalHTTPTimeout:        time.Duration(1000),
+		recorder:                 recorder,
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(&scaledObject)
+	assert.NoError(t, err)
+	loop := &scaleLoop{
+		ctx:                context.Background(),
+		logger:             log,
+		withTriggers:       withTriggers,
+		scalableObject:     &scaledObject,
+		scalingMutex:       &sync.Mutex{},
+		interval:           time.Minute,
+		initialized:        true,
+		pushScalersStarted: true,
+	}
+
+	metricName := "test-metric-name"
+	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(10, metricName)}).Times(2)
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(metricName, 0)}, false, nil).Times(2)
+	mockExecutor.EXPECT().RecordDesiredReplicas(gomock.Any(), gomock.Any(), false, false, gomock.Any()).Times(2)
+
+	// the first check is scheduled by pollingInterval
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), false, false, true, gomock.Any())
+	nextCheck := sh.checkScaleLoop(loop)
+	assert.Equal(t, loop.nextPoll, nextCheck)
+
+	// a check before the next poll is requested by a push scaler
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), false, false, false, gomock.Any())
+	sh.checkScaleLoop(loop)
+}
+
 func createMetricSpec(averageValue int64, metricName string) v2.MetricSpec {
 	qty := resource.NewQuantity(averageValue, resource.DecimalSI)
 	return v2.MetricSpec{
@@ -372,3 +1051,168 @@ func createMetricSpec(averageValue int64, metricName string) v2.MetricSpec {
 		},
 	}
 }
+
+func TestStopScaleLoopsOnLeadershipChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	scaler.EXPECT().Close(gomock.Any())
+
+	sh := scaleHandler{
+		scaleLoopContexts: &sync.Map{},
+		scalerCaches: map[string]*cache.ScalersCache{
+			"scaledobject.default.test": {Scalers: []cache.ScalerBuilder{{Scaler: scaler}}},
+		},
+		scalerCachesLock: &sync.RWMutex{},
+	}
+
+	loopCtx, cancel := context.WithCancel(context.Background())
+	sh.scaleLoopContexts.Store("scaledobject.default.test", cancel)
+	draining := sh.drainingSignal()
+	loopStopped := false
+	var loopCtxErr error
+	sh.runScaleLoop(func() {
+		<-draining
+		// in-flight check finishing after the scale loops started draining, its status updates aren't canceled
+		time.Sleep(10 * time.Millisecond)
+		loopCtxErr = loopCtx.Err()
+		loopStopped = true
+	})
+	pushScalerStopped := false
+	sh.runPushScaler(func() {
+		<-loopCtx.Done()
+		pushScalerStopped = true
+	})
+
+	ctx, stopLeading := context.WithCancel(context.Background())
+	stopLeading()
+	assert.NoError(t, sh.Start(ctx))
+
+	assert.True(t, loopStopped, "scale loop should be awaited before scalers are closed")
+	assert.NoError(t, loopCtxErr, "in-flight check shouldn't be canceled")
+	assert.True(t, pushScalerStopped, "push scaler should be canceled before scalers are closed")
+	assert.Empty(t, sh.scalerCaches)
+	_, found := sh.scaleLoopContexts.Load("scaledobject.default.test")
+	assert.False(t, found)
+}
+
+func TestStopScaleLoopsTimeout(t *testing.T) {
+	sh := scaleHandler{
+		scaleLoopContexts: &sync.Map{},
+		scalerCaches:      map[string]*cache.ScalersCache{},
+		scalerCachesLock:  &sync.RWMutex{},
+	}
+	blocked := make(chan struct{})
+	defer close(blocked)
+	sh.runScaleLoop(func() { <-blocked })
+
+	start := time.Now()
+	sh.stopScaleLoops(50 * time.Millisecond)
+	assert.Less(t, time.Since(start), 50*time.Millisecond+scaleLoopsCancelTimeout+time.Second)
+}
+
+func TestHandleScalableObjectWhileStopping(t *testing.T) {
+	sh := scaleHandler{
+		scaleLoopContexts: &sync.Map{},
+		scalerCaches:      map[string]*cache.ScalersCache{},
+		scalerCachesLock:  &sync.RWMutex{},
+	}
+	sh.stopScaleLoops(50 * time.Millisecond)
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+	assert.NoError(t, sh.HandleScalableObject(context.Background(), scaledObject))
+
+	_, found := sh.scaleLoopContexts.Load(scaledObject.GenerateIdentifier())
+	assert.False(t, found, "no scale loop should be started once scale loops are stopped")
+}
+
+func TestDeleteScalableObjectResetsInactiveChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+	sh := scaleHandler{
+		scaleLoopContexts:        &sync.Map{},
+		scaleExecutor:            mockExecutor,
+		recorder:                 record.NewFakeRecorder(1),
+		scalerCaches:             map[string]*cache.ScalersCache{},
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+	_, cancel := context.WithCancel(context.Background())
+	sh.scaleLoopContexts.Store(scaledObject.GenerateIdentifier(), cancel)
+
+	// a ScaledObject recreated with the same name doesn't inherit inactive checks of the deleted one
+	mockExecutor.EXPECT().ResetInactiveChecks(scaledObject)
+	assert.NoError(t, sh.DeleteScalableObject(context.Background(), scaledObject))
+}
+
+func TestUpdateCircuitOpenCondition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockClient.EXPECT().Status().Return(mockStatusWriter).Times(2)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{{Type: "cpu"}, {Type: "kafka"}},
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			Conditions: *kedav1alpha1.GetInitializedConditions(),
+		},
+	}
+	sh := scaleHandler{client: mockClient}
+
+	// the condition isn't added until a circuit opens
+	sh.updateCircuitOpenCondition(context.TODO(), &scaledObject, nil)
+	assert.Empty(t, scaledObject.Status.Conditions.GetCircuitOpenCondition().Type)
+
+	sh.updateCircuitOpenCondition(context.TODO(), &scaledObject, []int{1})
+	condition := scaledObject.Status.Conditions.GetCircuitOpenCondition()
+	assert.True(t, condition.IsTrue())
+	assert.Equal(t, "Checks of triggers 1 (kafka) are skipped after consecutive failures", condition.Message)
+
+	// the status isn't patched again while the same circuits are open
+	sh.updateCircuitOpenCondition(context.TODO(), &scaledObject, []int{1})
+
+	sh.updateCircuitOpenCondition(context.TODO(), &scaledObject, nil)
+	condition = scaledObject.Status.Conditions.GetCircuitOpenCondition()
+	assert.True(t, condition.IsFalse())
+}
+
+func TestGetMaxMetricsRecordAge(t *testing.T) {
+	defer ConfigureScalerChecks(DefaultScalerCheckConcurrency, DefaultScalerCheckTimeout)
+	ConfigureScalerChecks(DefaultScalerCheckConcurrency, 10*time.Second)
+
+	pollingInterval := int32(60)
+	scaledObject := kedav1alpha1.ScaledObject{}
+	assert.Equal(t, 70*time.Second, getMaxMetricsRecordAge(&scaledObject))
+
+	scaledObject.Spec.PollingInterval = &pollingInterval
+	assert.Equal(t, 130*time.Second, getMaxMetricsRecordAge(&scaledObject))
+
+	scaledObject.Spec.AdaptivePolling = &kedav1alpha1.AdaptivePollingConfig{MaxPollingInterval: 300}
+	assert.Equal(t, 610*time.Second, getMaxMetricsRecordAge(&scaledObject))
+}
+
+func TestGetScaledObjectMetricValues(t *testing.T) {
+	metricsCache := metricscache.NewMetricsCache()
+	metricsCache.StoreRecords(kedav1alpha1.GenerateIdentifier("ScaledObject", "default", "so"), map[string]metricscache.MetricsRecord{
+		"s0-queue": {Metric: []external_metrics.ExternalMetricValue{
+			{Value: *resource.NewQuantity(3, resource.DecimalSI)},
+			{Value: *resource.NewMilliQuantity(2500, resource.DecimalSI)},
+		}},
+	})
+	sh := scaleHandler{scaledObjectsMetricCache: metricsCache}
+
+	assert.Equal(t, map[string]float64{"s0-queue": 5.5}, sh.GetScaledObjectMetricValues("default", "so"))
+	assert.Empty(t, sh.GetScaledObjectMetricValues("default", "other"))
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// DefaultScaleLoopWorkers is the default number of ScaledObjects and ScaledJobs checked concurrently by the scale scheduler
+const DefaultScaleLoopWorkers = 100
+
+// scaleLoopWorkers is the number of workers of the scale scheduler, it's set by ConfigureScaleLoops on start of KEDA Operator
+var scaleLoopWorkers = DefaultScaleLoopWorkers
+
+// ConfigureScaleLoops sets the number of ScaledObjects and ScaledJobs checked concurrently by the scale scheduler
+func ConfigureScaleLoops(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	scaleLoopWorkers = workers
+}
+
+// scaleLoop is the state of the periodic checks of a ScaledObject or ScaledJob, it's an entry of the scale scheduler
+// instead of a dedicated goroutine with its own timer, so the cost of a scalable object doesn't grow with its polling
+type scaleLoop struct {
+	key            string
+	ctx            context.Context
+	logger         logr.Logger
+	withTriggers   *kedav1alpha1.WithTriggers
+	scalableObject interface{}
+	scalingMutex   sync.Locker
+
+	// the following fields are used only by the worker checking the scale loop
+	adaptivePolling    *adaptivePolling
+	interval           time.Duration
+	initialized        bool
+	pushScalersStarted bool
+	// nextPoll is the time of the next check scheduled by pollingInterval, earlier checks are requested by push scalers
+	nextPoll time.Time
+
+	// the following fields are guarded by the lock of the scheduler
+	nextCheck      time.Time
+	checkStart     time.Time
+	running        bool
+	checkRequested bool
+	removed        bool
+	index          int
+}
+
+// scaleLoopQueue is a heap of scale loops ordered by their next check
+type scaleLoopQueue []*scaleLoop
+
+func (q scaleLoopQueue) Len() int           { return len(q) }
+func (q scaleLoopQueue) Less(i, j int) bool { return q[i].nextCheck.Before(q[j].nextCheck) }
+func (q scaleLoopQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *scaleLoopQueue) Push(x interface{}) {
+	loop := x.(*scaleLoop)
+	loop.index = len(*q)
+	*q = append(*q, loop)
+}
+
+func (q *scaleLoopQueue) Pop() interface{} {
+	old := *q
+	loop := old[len(old)-1]
+	old[len(old)-1] = nil
+	loop.index = -1
+	*q = old[:len(old)-1]
+	return loop
+}
+
+// scaleScheduler checks scale loops once they are due by a fixed number of workers, a single dispatcher goroutine
+// waits for the earliest check. A scale loop isn't checked concurrently, a check requested while it's running
+// is postponed until it finishes.
+type scaleScheduler struct {
+	lock   sync.Mutex
+	queue  scaleLoopQueue
+	loops  map[string]*scaleLoop
+	wakeup chan struct{}
+	work   chan *scaleLoop
+
+	// check checks the scale loop and returns the time of its next check
+	check func(loop *scaleLoop) time.Time
+	// cleanup releases resources of a removed scale loop once its in-flight check finished
+	cleanup func(loop *scaleLoop)
+	// minCheckInterval is the shortest time between the start of a check and a following requested check
+	minCheckInterval time.Duration
+	now              func() time.Time
+}
+
+func newScaleScheduler(check func(loop *scaleLoop) time.Time, cleanup func(loop *scaleLoop), minCheckInterval time.Duration) *scaleScheduler {
+	return &scaleScheduler{
+		loops:            map[string]*scaleLoop{},
+		wakeup:           make(chan struct{}, 1),
+		work:             make(chan *scaleLoop),
+		check:            check,
+		cleanup:          cleanup,
+		minCheckInterval: minCheckInterval,
+		now:              time.Now,
+	}
+}
+
+// start starts the dispatcher and the workers by run, they return once draining is closed,
+// the workers finish their in-flight checks first
+func (s *scaleScheduler) start(workers int, draining <-chan struct{}, run func(func())) {
+	run(func() { s.dispatch(draining) })
+	for i := 0; i < workers; i++ {
+		run(func() { s.runWorker(draining) })
+	}
+}
+
+// add schedules the first check of the scale loop immediately
+func (s *scaleScheduler) add(loop *scaleLoop) {
+	s.lock.Lock()
+	loop.nextCheck = s.now()
+	loop.index = -1
+	s.loops[loop.key] = loop
+	heap.Push(&s.queue, loop)
+	s.lock.Unlock()
+	s.wake()
+}
+
+// remove unschedules the scale loop of the key, it returns the removed scale loop and whether its check is in-flight,
+// the cleanup is called once the in-flight check finishes
+func (s *scaleScheduler) remove(key string) (*scaleLoop, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	loop, found := s.loops[key]
+	if !found {
+		return nil, false
+	}
+	delete(s.loops, key)
+	loop.removed = true
+	if loop.index >= 0 {
+		heap.Remove(&s.queue, loop.index)
+	}
+	return loop, loop.running
+}
+
+// requestCheck checks the scale loop as soon as minCheckInterval passes since the start of its last check,
+// pending requests are coalesced into a single check
+func (s *scaleScheduler) requestCheck(loop *scaleLoop) {
+	s.lock.Lock()
+	if loop.removed {
+		s.lock.Unlock()
+		return
+	}
+	if loop.running {
+		loop.checkRequested = true
+		s.lock.Unlock()
+		return
+	}
+	requested := s.requestedCheckTime(loop)
+	if requested.Before(loop.nextCheck) {
+		loop.nextCheck = requested
+		heap.Fix(&s.queue, loop.index)
+	}
+	s.lock.Unlock()
+	s.wake()
+}
+
+// requestedCheckTime returns the earliest time of a requested check of the scale loop, it's called with the lock held
+func (s *scaleScheduler) requestedCheckTime(loop *scaleLoop) time.Time {
+	requested := loop.checkStart.Add(s.minCheckInterval)
+	if now := s.now(); requested.Before(now) {
+		return now
+	}
+	return requested
+}
+
+// len returns the number of scheduled scale loops
+func (s *scaleScheduler) len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.loops)
+}
+
+func (s *scaleScheduler) wake() {
+	select {
+	case s.wakeup <- struct{}{}:
+	default:
+		// the dispatcher is already woken up
+	}
+}
+
+// nextDue pops the scale loop due at the time, or returns how long to wait for the next one
+func (s *scaleScheduler) nextDue(now time.Time) (*scaleLoop, time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.queue) == 0 {
+		return nil, time.Hour
+	}
+	if wait := s.queue[0].nextCheck.Sub(now); wait > 0 {
+		return nil, wait
+	}
+	loop := heap.Pop(&s.queue).(*scaleLoop)
+	loop.running = true
+	loop.checkStart = now
+	return loop, 0
+}
+
+func (s *scaleScheduler) dispatch(draining <-chan struct{}) {
+	for {
+		loop, wait := s.nextDue(s.now())
+		if loop != nil {
+			select {
+			case s.work <- loop:
+			case <-draining:
+				return
+			}
+			continue
+		}
+
+		tmr := time.NewTimer(wait)
+		select {
+		case <-tmr.C:
+		case <-s.wakeup:
+		case <-draining:
+			tmr.Stop()
+			return
+		}
+		tmr.Stop()
+	}
+}
+
+func (s *scaleScheduler) runWorker(draining <-chan struct{}) {
+	for {
+		select {
+		case <-draining:
+			return
+		default:
+		}
+
+		select {
+		case loop := <-s.work:
+			var nextCheck time.Time
+			if loop.ctx.Err() == nil {
+				nextCheck = s.check(loop)
+			}
+			s.done(loop, nextCheck)
+		case <-draining:
+			return
+		}
+	}
+}
+
+// done schedules the next check of the scale loop, or cleans it up if it was removed during the check
+func (s *scaleScheduler) done(loop *scaleLoop, nextCheck time.Time) {
+	s.lock.Lock()
+	loop.running = false
+	if loop.removed || loop.ctx.Err() != nil {
+		if !loop.removed && s.loops[loop.key] == loop {
+			delete(s.loops, loop.key)
+		}
+		loop.removed = true
+		s.lock.Unlock()
+		s.cleanup(loop)
+		return
+	}
+	if loop.checkRequested {
+		loop.checkRequested = false
+		if requested := s.requestedCheckTime(loop); requested.Before(nextCheck) {
+			nextCheck = requested
+		}
+	}
+	loop.nextCheck = nextCheck
+	heap.Push(&s.queue, loop)
+	s.lock.Unlock()
+	s.wake()
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type scaleSchedulerRecorder struct {
+	lock    sync.Mutex
+	checked []string
+	cleaned []string
+}
+
+func (r *scaleSchedulerRecorder) checkedKeys() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string{}, r.checked...)
+}
+
+func (r *scaleSchedulerRecorder) cleanedKeys() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string{}, r.cleaned...)
+}
+
+func newTestScaleScheduler(r *scaleSchedulerRecorder, check func(loop *scaleLoop) time.Time, minCheckInterval time.Duration) *scaleScheduler {
+	return newScaleScheduler(func(loop *scaleLoop) time.Time {
+		r.lock.Lock()
+		r.checked = append(r.checked, loop.key)
+		r.lock.Unlock()
+		return check(loop)
+	}, func(loop *scaleLoop) {
+		r.lock.Lock()
+		r.cleaned = append(r.cleaned, loop.key)
+		r.lock.Unlock()
+	}, minCheckInterval)
+}
+
+func checkAfter(interval time.Duration) func(loop *scaleLoop) time.Time {
+	return func(loop *scaleLoop) time.Time {
+		return time.Now().Add(interval)
+	}
+}
+
+// startTestScaleScheduler starts the scheduler and returns the function draining it
+func startTestScaleScheduler(s *scaleScheduler, workers int) func() {
+	draining := make(chan struct{})
+	var wg sync.WaitGroup
+	s.start(workers, draining, func(run func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run()
+		}()
+	})
+	return func() {
+		close(draining)
+		wg.Wait()
+	}
+}
+
+func TestScaleSchedulerOrdersScaleLoopsByNextCheck(t *testing.T) {
+	s := newTestScaleScheduler(&scaleSchedulerRecorder{}, checkAfter(time.Hour), time.Second)
+	now := time.Now()
+	nextChecks := map[string]time.Time{
+		"c": now.Add(time.Minute),
+		"a": now.Add(-2 * time.Second),
+		"b": now.Add(-time.Second),
+	}
+	for key, nextCheck := range nextChecks {
+		loop := &scaleLoop{key: key, ctx: context.Background()}
+		s.add(loop)
+		loop.nextCheck = nextCheck
+		heap.Fix(&s.queue, loop.index)
+	}
+
+	loop, _ := s.nextDue(now)
+	assert.Equal(t, "a", loop.key)
+	assert.True(t, loop.running)
+	loop, _ = s.nextDue(now)
+	assert.Equal(t, "b", loop.key)
+	loop, wait := s.nextDue(now)
+	assert.Nil(t, loop)
+	assert.Equal(t, time.Minute, wait)
+}
+
+func TestScaleSchedulerChecksScaleLoopsPeriodically(t *testing.T) {
+	r := &scaleSchedulerRecorder{}
+	s := newTestScaleScheduler(r, checkAfter(20*time.Millisecond), time.Millisecond)
+	drain := startTestScaleScheduler(s, 2)
+	defer drain()
+
+	s.add(&scaleLoop{key: "a", ctx: context.Background()})
+	s.add(&scaleLoop{key: "b", ctx: context.Background()})
+
+	assert.Eventually(t, func() bool {
+		counts := map[string]int{}
+		for _, key := range r.checkedKeys() {
+			counts[key]++
+		}
+		return counts["a"] >= 3 && counts["b"] >= 3
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestScaleSchedulerCoalescesRequestedChecks(t *testing.T) {
+	r := &scaleSchedulerRecorder{}
+	s := newTestScaleScheduler(r, checkAfter(time.Hour), 50*time.Millisecond)
+	drain := startTestScaleScheduler(s, 1)
+	defer drain()
+
+	loop := &scaleLoop{key: "a", ctx: context.Background()}
+	s.add(loop)
+	assert.Eventually(t, func() bool { return len(r.checkedKeys()) == 1 }, time.Second, time.Millisecond)
+
+	for i := 0; i < 10; i++ {
+		s.requestCheck(loop)
+	}
+	// the requested check doesn't run before minCheckInterval passes since the start of the previous one
+	time.Sleep(20 * time.Millisecond)
+	assert.Len(t, r.checkedKeys(), 1)
+	assert.Eventually(t, func() bool { return len(r.checkedKeys()) == 2 }, time.Second, time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, r.checkedKeys(), 2)
+}
+
+func TestScaleSchedulerPostponesCheckRequestedDuringCheck(t *testing.T) {
+	r := &scaleSchedulerRecorder{}
+	checking := make(chan struct{})
+	release := make(chan struct{})
+	first := true
+	s := newTestScaleScheduler(r, func(loop *scaleLoop) time.Time {
+		if first {
+			first = false
+			close(checking)
+			<-release
+		}
+		return time.Now().Add(time.Hour)
+	}, time.Millisecond)
+	drain := startTestScaleScheduler(s, 2)
+	defer drain()
+
+	loop := &scaleLoop{key: "a", ctx: context.Background()}
+	s.add(loop)
+	<-checking
+	s.requestCheck(loop)
+	// the scale loop isn't checked concurrently
+	time.Sleep(20 * time.Millisecond)
+	assert.Len(t, r.checkedKeys(), 1)
+
+	close(release)
+	assert.Eventually(t, func() bool { return len(r.checkedKeys()) == 2 }, time.Second, time.Millisecond)
+}
+
+func TestScaleSchedulerCleansUpRemovedScaleLoops(t *testing.T) {
+	r := &scaleSchedulerRecorder{}
+	checking := make(chan struct{})
+	release := make(chan struct{})
+	s := newTestScaleScheduler(r, func(loop *scaleLoop) time.Time {
+		if loop.key == "running" {
+			close(checking)
+			<-release
+		}
+		return time.Now().Add(time.Hour)
+	}, time.Millisecond)
+
+	idle := &scaleLoop{key: "idle", ctx: context.Background()}
+	s.add(idle)
+	removed, running := s.remove("idle")
+	assert.Equal(t, idle, removed)
+	assert.False(t, running)
+	assert.Equal(t, 0, s.len())
+
+	drain := startTestScaleScheduler(s, 1)
+	defer drain()
+
+	s.add(&scaleLoop{key: "running", ctx: context.Background()})
+	<-checking
+	_, running = s.remove("running")
+	assert.True(t, running)
+	assert.Empty(t, r.cleanedKeys())
+
+	// the in-flight check finishes before the removed scale loop is cleaned up
+	close(release)
+	assert.Eventually(t, func() bool { return len(r.cleanedKeys()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"running"}, r.checkedKeys())
+}
+
+func TestScaleSchedulerCleansUpCanceledScaleLoops(t *testing.T) {
+	r := &scaleSchedulerRecorder{}
+	s := newTestScaleScheduler(r, checkAfter(time.Hour), time.Millisecond)
+	drain := startTestScaleScheduler(s, 1)
+	defer drain()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.add(&scaleLoop{key: "a", ctx: ctx})
+
+	assert.Eventually(t, func() bool { return len(r.cleanedKeys()) == 1 }, time.Second, time.Millisecond)
+	assert.Empty(t, r.checkedKeys())
+	assert.Equal(t, 0, s.len())
+}
+
+func TestScaleSchedulerStopsOnDraining(t *testing.T) {
+	r := &scaleSchedulerRecorder{}
+	s := newTestScaleScheduler(r, checkAfter(time.Millisecond), time.Millisecond)
+	drain := startTestScaleScheduler(s, 4)
+	s.add(&scaleLoop{key: "a", ctx: context.Background()})
+	assert.Eventually(t, func() bool { return len(r.checkedKeys()) > 0 }, time.Second, time.Millisecond)
+
+	stopped := make(chan struct{})
+	go func() {
+		drain()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("scale scheduler didn't stop on draining")
+	}
+}
@@ -20,11 +20,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventemitter"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/formula"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
 	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
@@ -37,47 +40,19 @@ import (
 // buildScalers returns list of Scalers for the specified triggers
 func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, podTemplateSpec *corev1.PodTemplateSpec, containerName string) ([]cache.ScalerBuilder, error) {
 	logger := log.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
-	var err error
-	resolvedEnv := make(map[string]string)
 	result := make([]cache.ScalerBuilder, 0, len(withTriggers.Spec.Triggers))
 
-	for i, t := range withTriggers.Spec.Triggers {
-		triggerIndex, trigger := i, t
-
-		factory := func() (scalers.Scaler, *scalers.ScalerConfig, error) {
-			if podTemplateSpec != nil {
-				resolvedEnv, err = resolver.ResolveContainerEnv(ctx, h.client, logger, &podTemplateSpec.Spec, containerName, withTriggers.Namespace, h.secretsLister)
-				if err != nil {
-					return nil, nil, fmt.Errorf("error resolving secrets for ScaleTarget: %w", err)
-				}
-			}
-			config := &scalers.ScalerConfig{
-				ScalableObjectName:      withTriggers.Name,
-				ScalableObjectNamespace: withTriggers.Namespace,
-				ScalableObjectType:      withTriggers.Kind,
-				TriggerName:             trigger.Name,
-				TriggerMetadata:         trigger.Metadata,
-				TriggerUseCachedMetrics: trigger.UseCachedMetrics,
-				ResolvedEnv:             resolvedEnv,
-				AuthParams:              make(map[string]string),
-				GlobalHTTPTimeout:       h.globalHTTPTimeout,
-				ScalerIndex:             triggerIndex,
-				MetricType:              trigger.MetricType,
-			}
-
-			authParams, podIdentity, err := resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace, h.secretsLister)
-			if err != nil {
-				return nil, nil, err
-			}
-			config.AuthParams = authParams
-			config.PodIdentity = podIdentity
-			scaler, err := buildScaler(ctx, h.client, trigger.Type, config)
-			return scaler, config, err
+	for triggerIndex, trigger := range withTriggers.Spec.Triggers {
+		// the index of the trigger is kept, so metric names of the other triggers don't change once it's disabled
+		if !trigger.IsEnabled() {
+			logger.V(1).Info("Skipping disabled trigger", "scalerIndex", triggerIndex, "trigger.type", trigger.Type)
+			continue
 		}
+		factory := h.newScalerFactory(ctx, logger, withTriggers, triggerIndex, trigger, podTemplateSpec, containerName)
 
 		scaler, config, err := factory()
 		if err != nil {
-			h.recorder.Event(withTriggers, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+			h.recorder.AnnotatedEventf(withTriggers, eventemitter.TriggerAnnotations(trigger.Name, trigger.Type), corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "%s", err.Error())
 			logger.Error(err, "error resolving auth params", "scalerIndex", triggerIndex)
 			if scaler != nil {
 				scaler.Close(ctx)
@@ -98,8 +73,67 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 	return result, nil
 }
 
+// newScalerFactory returns the function building the scaler of the trigger, it's called again to refresh the scaler
+func (h *scaleHandler) newScalerFactory(ctx context.Context, logger logr.Logger, withTriggers *kedav1alpha1.WithTriggers, triggerIndex int, trigger kedav1alpha1.ScaleTriggers,
+	podTemplateSpec *corev1.PodTemplateSpec, containerName string) func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+	return func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+		var err error
+		resolvedEnv := make(map[string]string)
+		if podTemplateSpec != nil {
+			resolvedEnv, err = resolver.ResolveContainerEnv(ctx, h.client, logger, &podTemplateSpec.Spec, containerName, withTriggers.Namespace, h.secretsLister)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error resolving secrets for ScaleTarget: %w", err)
+			}
+		}
+		config := &scalers.ScalerConfig{
+			ScalableObjectName:        withTriggers.Name,
+			ScalableObjectNamespace:   withTriggers.Namespace,
+			ScalableObjectType:        withTriggers.Kind,
+			TriggerName:               trigger.Name,
+			TriggerType:               trigger.Type,
+			TriggerMetadata:           trigger.Metadata,
+			TriggerUseCachedMetrics:   trigger.UseCachedMetrics,
+			TriggerMaintenanceWindows: trigger.MaintenanceWindows,
+			ResolvedEnv:               resolvedEnv,
+			AuthParams:                make(map[string]string),
+			GlobalHTTPTimeout:         h.globalHTTPTimeout,
+			ScalerIndex:               triggerIndex,
+			MetricType:                trigger.MetricType,
+		}
+
+		if len(trigger.MaintenanceWindows) > 0 && (trigger.Type == "cpu" || trigger.Type == "memory") {
+			return nil, nil, fmt.Errorf("maintenance windows are not supported for %s trigger", trigger.Type)
+		}
+		if err := cache.ValidateMaintenanceWindows(trigger.MaintenanceWindows); err != nil {
+			return nil, nil, err
+		}
+		if trigger.MetricFormula != "" {
+			if trigger.Type == "cpu" || trigger.Type == "memory" {
+				return nil, nil, fmt.Errorf("metricFormula is not supported for %s trigger", trigger.Type)
+			}
+			config.TriggerMetricFormula, err = formula.Parse(trigger.MetricFormula)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		config.TriggerCheckTimeout, config.TriggerCheckRetries, err = scalers.ParseTriggerCheckPolicy(trigger.Metadata)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		authParams, podIdentity, err := resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace, h.secretsLister)
+		if err != nil {
+			return nil, nil, err
+		}
+		config.AuthParams = authParams
+		config.PodIdentity = podIdentity
+		scaler, err := buildScaler(ctx, h.client, h.getTriggerMetrics, trigger.Type, config)
+		return scaler, config, err
+	}
+}
+
 // buildScaler builds a scaler form input config and trigger type
-func buildScaler(ctx context.Context, client client.Client, triggerType string, config *scalers.ScalerConfig) (scalers.Scaler, error) {
+func buildScaler(ctx context.Context, client client.Client, getTriggerMetrics scalers.TriggerMetricsGetter, triggerType string, config *scalers.ScalerConfig) (scalers.Scaler, error) {
 	// TRIGGERS-START
 	switch triggerType {
 	case "activemq":
@@ -124,6 +158,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewAzureBlobScaler(config)
 	case "azure-data-explorer":
 		return scalers.NewAzureDataExplorerScaler(ctx, config)
+	case "azure-eventgrid":
+		return scalers.NewAzureEventGridScaler(config)
 	case "azure-eventhub":
 		return scalers.NewAzureEventHubScaler(ctx, config)
 	case "azure-log-analytics":
@@ -144,6 +180,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewCPUMemoryScaler(corev1.ResourceCPU, config)
 	case "cron":
 		return scalers.NewCronScaler(config)
+	case "dapr-binding":
+		return scalers.NewDaprBindingScaler(config)
 	case "datadog":
 		return scalers.NewDatadogScaler(ctx, config)
 	case "elasticsearch":
@@ -165,8 +203,12 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewGcsScaler(config)
 	case "github-runner":
 		return scalers.NewGitHubRunnerScaler(config)
+	case "gitlab-runner":
+		return scalers.NewGitLabRunnerScaler(config)
 	case "graphite":
 		return scalers.NewGraphiteScaler(config)
+	case "http":
+		return scalers.NewHTTPScaler(config)
 	case "huawei-cloudeye":
 		return scalers.NewHuaweiCloudeyeScaler(config)
 	case "ibmmq":
@@ -201,6 +243,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewOpenstackSwiftScaler(ctx, config)
 	case "postgresql":
 		return scalers.NewPostgreSQLScaler(config)
+	case "predict":
+		return scalers.NewPredictScaler(config)
 	case "predictkube":
 		return scalers.NewPredictKubeScaler(ctx, config)
 	case "prometheus":
@@ -221,12 +265,16 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewRedisStreamsScaler(ctx, false, true, config)
 	case "redis-streams":
 		return scalers.NewRedisStreamsScaler(ctx, false, false, config)
+	case "scaled-object-trigger":
+		return scalers.NewScaledObjectTriggerScaler(getTriggerMetrics, config)
 	case "selenium-grid":
 		return scalers.NewSeleniumGridScaler(config)
 	case "solace-event-queue":
 		return scalers.NewSolaceScaler(config)
 	case "stan":
 		return scalers.NewStanScaler(config)
+	case "temporal":
+		return scalers.NewTemporalScaler(config)
 	default:
 		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
 	}
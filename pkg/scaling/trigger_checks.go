@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+)
+
+// TriggerCheck is the result of checking a metric of a trigger once
+type TriggerCheck struct {
+	TriggerIndex int
+	TriggerName  string
+	TriggerType  string
+	// MetricName is empty if the scaler of the trigger couldn't be built
+	MetricName   string
+	IsActive     bool
+	MetricValues []string
+	Err          error
+}
+
+// CheckTriggers builds the scalers of the triggers of the ScaledObject, which doesn't need to exist in the cluster,
+// and checks each of their metrics once. The scale target is resolved for the environment of the triggers only
+// if it's referenced, no events are recorded and the scalers are closed once checked.
+func (h *scaleHandler) CheckTriggers(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) ([]TriggerCheck, error) {
+	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	if len(scaledObject.Spec.Triggers) == 0 {
+		return nil, fmt.Errorf("no triggers defined in the ScaledObject")
+	}
+	var podTemplateSpec *corev1.PodTemplateSpec
+	var containerName string
+	if scaledObject.Spec.ScaleTargetRef != nil && scaledObject.Spec.ScaleTargetRef.Name != "" {
+		gvkr, err := kedav1alpha1.ParseGVKR(h.client.RESTMapper(), scaledObject.Spec.ScaleTargetRef.APIVersion, scaledObject.Spec.ScaleTargetRef.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving scale target: %w", err)
+		}
+		scaledObject = scaledObject.DeepCopy()
+		scaledObject.Status.ScaleTargetGVKR = &gvkr
+		scaledObject.Status.ScaleTargetKind = gvkr.GVKString()
+		if podTemplateSpec, containerName, err = resolver.ResolveScaleTargetPodSpec(ctx, h.client, scaledObject); err != nil {
+			return nil, fmt.Errorf("error resolving scale target: %w", err)
+		}
+	}
+
+	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scaledObject)
+	if err != nil {
+		return nil, err
+	}
+
+	// the scalers are built one by one, so a failing trigger doesn't prevent the others from being checked
+	var checks []TriggerCheck
+	scalersCache := &cache.ScalersCache{}
+	defer scalersCache.Close(ctx)
+	for triggerIndex, trigger := range withTriggers.Spec.Triggers {
+		if !trigger.IsEnabled() {
+			continue
+		}
+		check := TriggerCheck{TriggerIndex: triggerIndex, TriggerName: trigger.Name, TriggerType: trigger.Type}
+		if err := ValidateTrigger(trigger); err != nil {
+			check.Err = err
+			checks = append(checks, check)
+			continue
+		}
+		factory := h.newScalerFactory(ctx, logger, withTriggers, triggerIndex, trigger, podTemplateSpec, containerName)
+		scaler, config, err := factory()
+		if err != nil {
+			if scaler != nil {
+				scaler.Close(ctx)
+			}
+			check.Err = err
+			checks = append(checks, check)
+			continue
+		}
+		scalersCache.Scalers = append(scalersCache.Scalers, cache.ScalerBuilder{Scaler: scaler, ScalerConfig: *config, Factory: factory})
+		cacheIndex := len(scalersCache.Scalers) - 1
+
+		metricSpecs, err := scalersCache.GetMetricSpecForScalingForScaler(ctx, cacheIndex)
+		if err != nil {
+			check.Err = err
+			checks = append(checks, check)
+			continue
+		}
+		for _, spec := range metricSpecs {
+			// cpu and memory triggers are evaluated by the HPA from resource metrics
+			if spec.External == nil {
+				continue
+			}
+			metricCheck := check
+			metricCheck.MetricName = spec.External.Metric.Name
+			metrics, isActive, _, err := scalersCache.GetMetricsAndActivityForScaler(ctx, cacheIndex, metricCheck.MetricName)
+			if err != nil {
+				metricCheck.Err = err
+			} else {
+				metricCheck.IsActive = isActive
+				for _, metric := range metrics {
+					metricCheck.MetricValues = append(metricCheck.MetricValues, metric.Value.String())
+				}
+			}
+			checks = append(checks, metricCheck)
+		}
+	}
+	return checks, nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestCheckTriggers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	recorder := record.NewFakeRecorder(10)
+	handler := NewScaleHandler(fake.NewClientBuilder().WithScheme(scheme).Build(), nil, scheme, 0, recorder, nil, nil, nil)
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{
+					Type: "cron",
+					Name: "office-hours",
+					Metadata: map[string]string{
+						"timezone":        "Etc/UTC",
+						"start":           "0 8 * * *",
+						"end":             "0 18 * * *",
+						"desiredReplicas": "3",
+					},
+				},
+				{Type: "cron", Metadata: map[string]string{"timezone": "Etc/UTC"}},
+				{Type: "unknown"},
+			},
+		},
+	}
+
+	checks, err := handler.CheckTriggers(context.TODO(), scaledObject)
+	assert.NoError(t, err)
+	assert.Len(t, checks, 3)
+
+	assert.Equal(t, 0, checks[0].TriggerIndex)
+	assert.Equal(t, "office-hours", checks[0].TriggerName)
+	assert.Equal(t, "cron", checks[0].TriggerType)
+	assert.True(t, strings.HasPrefix(checks[0].MetricName, "s.office-hours.cron-"), checks[0].MetricName)
+	assert.Len(t, checks[0].MetricValues, 1)
+	assert.NoError(t, checks[0].Err)
+
+	assert.Equal(t, 1, checks[1].TriggerIndex)
+	assert.ErrorContains(t, checks[1].Err, "missing required metadata of cron trigger: start, end, desiredReplicas")
+	assert.Empty(t, checks[1].MetricName)
+
+	assert.Equal(t, 2, checks[2].TriggerIndex)
+	assert.ErrorContains(t, checks[2].Err, "no scaler found for type: unknown")
+
+	// checks don't record events about the ScaledObject, which doesn't exist
+	assert.Empty(t, recorder.Events)
+}
+
+func TestCheckTriggersWithoutTriggers(t *testing.T) {
+	handler := NewScaleHandler(nil, nil, nil, 0, nil, nil, nil, nil)
+
+	_, err := handler.CheckTriggers(context.TODO(), &kedav1alpha1.ScaledObject{})
+	assert.ErrorContains(t, err, "no triggers defined")
+}
+
+func TestDisabledTriggersAreSkipped(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	handler := NewScaleHandler(fake.NewClientBuilder().WithScheme(scheme).Build(), nil, scheme, 0, record.NewFakeRecorder(10), nil, nil, nil).(*scaleHandler)
+
+	disabled := false
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "unknown", Enabled: &disabled},
+				{
+					Type: "cron",
+					Metadata: map[string]string{
+						"timezone":        "Etc/UTC",
+						"start":           "0 8 * * *",
+						"end":             "0 18 * * *",
+						"desiredReplicas": "3",
+					},
+				},
+			},
+		},
+	}
+
+	checks, err := handler.CheckTriggers(context.TODO(), scaledObject)
+	assert.NoError(t, err)
+	assert.Len(t, checks, 1)
+	assert.Equal(t, 1, checks[0].TriggerIndex)
+	assert.NoError(t, checks[0].Err)
+
+	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scaledObject)
+	assert.NoError(t, err)
+	builders, err := handler.buildScalers(context.TODO(), withTriggers, nil, "")
+	assert.NoError(t, err)
+	assert.Len(t, builders, 1)
+	// the index of the trigger is kept, so its metric name doesn't change
+	assert.Equal(t, 1, builders[0].ScalerConfig.ScalerIndex)
+	for _, builder := range builders {
+		builder.Scaler.Close(context.TODO())
+	}
+}
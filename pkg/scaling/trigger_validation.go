@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"fmt"
+	"strings"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+// triggerRequiredMetadata has all trigger types supported by buildScaler with the metadata required by their scalers,
+// only metadata which can't be given by TriggerAuthentication are listed, a required key is also satisfied
+// by its <key>FromEnv variant. Scalers with typed metadata have nil here, their metadata is validated by the
+// declarations of their fields (see scalers.GetTriggerMetadataFields)
+var triggerRequiredMetadata = map[string][]string{
+	"activemq":               nil,
+	"arangodb":               {"collection", "query", "queryValue"},
+	"artemis-queue":          nil,
+	"aws-cloudwatch":         nil,
+	"aws-dynamodb":           {"tableName", "awsRegion", "keyConditionExpression", "expressionAttributeNames", "expressionAttributeValues", "targetValue"},
+	"aws-dynamodb-streams":   {"tableName", "awsRegion"},
+	"aws-kinesis-stream":     {"streamName", "awsRegion"},
+	"aws-sqs-queue":          {"queueURL", "awsRegion"},
+	"azure-app-insights":     nil,
+	"azure-blob":             nil,
+	"azure-data-explorer":    nil,
+	"azure-eventgrid":        nil,
+	"azure-eventhub":         nil,
+	"azure-log-analytics":    nil,
+	"azure-monitor":          {"resourceURI", "tenantId", "subscriptionId", "resourceGroupName", "metricName", "metricAggregationType", "targetValue"},
+	"azure-pipelines":        nil,
+	"azure-queue":            nil,
+	"azure-servicebus":       nil,
+	"cassandra":              nil,
+	"couchdb":                nil,
+	"cpu":                    {"value"},
+	"cron":                   {"timezone", "start", "end", "desiredReplicas"},
+	"dapr-binding":           nil,
+	"datadog":                {"query", "queryValue"},
+	"elasticsearch":          nil,
+	"etcd":                   nil,
+	"external":               nil,
+	"external-mock":          nil,
+	"external-push":          nil,
+	"gcp-pubsub":             {"subscriptionName"},
+	"gcp-stackdriver":        {"projectId"},
+	"gcp-storage":            {"bucketName"},
+	"github-runner":          nil,
+	"gitlab-runner":          nil,
+	"graphite":               {"serverAddress", "query", "queryTime"},
+	"http":                   nil,
+	"huawei-cloudeye":        {"namespace", "metricName", "targetMetricValue", "minMetricValue"},
+	"ibmmq":                  nil,
+	"influxdb":               nil,
+	"kafka":                  {"bootstrapServers", "consumerGroup"},
+	"kubernetes-workload":    nil,
+	"liiklus":                {"address", "topic", "group"},
+	"loki":                   nil,
+	"memory":                 {"value"},
+	"metrics-api":            {"url", "valueLocation", "targetValue"},
+	"mongodb":                nil,
+	"mssql":                  nil,
+	"mysql":                  nil,
+	"nats-jetstream":         nil,
+	"new-relic":              nil,
+	"openstack-metric":       nil,
+	"openstack-swift":        nil,
+	"postgresql":             nil,
+	"predict":                nil,
+	"predictkube":            nil,
+	"prometheus":             {"serverAddress", "query", "threshold"},
+	"pulsar":                 nil,
+	"rabbitmq":               {"queueName"},
+	"redis":                  nil,
+	"redis-cluster":          nil,
+	"redis-cluster-streams":  nil,
+	"redis-sentinel":         nil,
+	"redis-sentinel-streams": nil,
+	"redis-streams":          nil,
+	"scaled-object-trigger":  nil,
+	"selenium-grid":          nil,
+	"solace-event-queue":     nil,
+	"stan":                   nil,
+	"temporal":               nil,
+}
+
+// ValidateTrigger returns an error if the type of the trigger isn't supported or if metadata required
+// by the scaler of the type are missing, it's used by the validating webhook of ScaledObjects
+func ValidateTrigger(trigger kedav1alpha1.ScaleTriggers) error {
+	requiredMetadata, ok := triggerRequiredMetadata[trigger.Type]
+	if !ok {
+		return fmt.Errorf("no scaler found for type: %s", trigger.Type)
+	}
+
+	fields, typed, err := scalers.GetTriggerMetadataFields(trigger.Type)
+	if err != nil {
+		return err
+	}
+	if typed {
+		if err := scalers.ValidateMetadata(fields, trigger.Metadata); err != nil {
+			return fmt.Errorf("invalid metadata of %s trigger: %w", trigger.Type, err)
+		}
+		return nil
+	}
+
+	var missing []string
+	for _, key := range requiredMetadata {
+		if trigger.Metadata[key] == "" && trigger.Metadata[key+"FromEnv"] == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required metadata of %s trigger: %s", trigger.Type, strings.Join(missing, ", "))
+	}
+	return nil
+}
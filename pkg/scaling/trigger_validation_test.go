@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestValidateTrigger(t *testing.T) {
+	// all metadata are given
+	err := ValidateTrigger(kedav1alpha1.ScaleTriggers{
+		Type:     "prometheus",
+		Metadata: map[string]string{"serverAddress": "http://prometheus:9090", "query": "sum(rate(http_requests_total[2m]))", "threshold": "100"},
+	})
+	assert.NoError(t, err)
+
+	// required metadata given by environment variable of the scale target
+	err = ValidateTrigger(kedav1alpha1.ScaleTriggers{
+		Type:     "kafka",
+		Metadata: map[string]string{"bootstrapServersFromEnv": "KAFKA_BROKERS", "consumerGroup": "consumer"},
+	})
+	assert.NoError(t, err)
+
+	err = ValidateTrigger(kedav1alpha1.ScaleTriggers{
+		Type:     "prometheus",
+		Metadata: map[string]string{"serverAddress": "http://prometheus:9090"},
+	})
+	assert.EqualError(t, err, "missing required metadata of prometheus trigger: query, threshold")
+
+	// scaler with typed metadata
+	err = ValidateTrigger(kedav1alpha1.ScaleTriggers{
+		Type:     "loki",
+		Metadata: map[string]string{"serverAddress": "http://loki:3100", "query": "sum(rate({app=\"demo\"}[1m]))", "threshold": "one"},
+	})
+	assert.ErrorContains(t, err, "invalid metadata of loki trigger: error parsing threshold")
+
+	err = ValidateTrigger(kedav1alpha1.ScaleTriggers{
+		Type:     "loki",
+		Metadata: map[string]string{"serverAddress": "http://loki:3100", "threshold": "1"},
+	})
+	assert.EqualError(t, err, "invalid metadata of loki trigger: missing required field in scaler config: no query given")
+
+	err = ValidateTrigger(kedav1alpha1.ScaleTriggers{Type: "prometeus"})
+	assert.EqualError(t, err, "no scaler found for type: prometeus")
+}
+
+// TestTriggerRequiredMetadataHasAllTriggerTypes checks that validated trigger types are the same as the ones of buildScaler
+func TestTriggerRequiredMetadataHasAllTriggerTypes(t *testing.T) {
+	source, err := os.ReadFile("scalers_builder.go")
+	require.NoError(t, err)
+
+	builderTypes := map[string]bool{}
+	for _, match := range regexp.MustCompile(`(?m)^\tcase "([a-z0-9-]+)":`).FindAllStringSubmatch(string(source), -1) {
+		builderTypes[match[1]] = true
+	}
+	require.NotEmpty(t, builderTypes)
+
+	for triggerType := range builderTypes {
+		assert.Contains(t, triggerRequiredMetadata, triggerType, "trigger type built by buildScaler isn't validated")
+	}
+	for triggerType := range triggerRequiredMetadata {
+		assert.Contains(t, builderTypes, triggerType, "validated trigger type isn't built by buildScaler")
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding splits ScaledObjects and ScaledJobs across multiple instances (shards) of KEDA Operator
+// by a hash of their namespace and name, so the scale loops of large clusters aren't run by a single instance.
+// Sharding is opt-in, KEDA Operator and KEDA Metrics Server have to be configured with the same number of shards.
+package sharding
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// ShardCountEnvVar is the number of shards, sharding is disabled if it isn't set or it's 1
+	ShardCountEnvVar = "KEDA_OPERATOR_SHARD_COUNT"
+	// ShardIndexEnvVar is the index of the shard of the instance, if it isn't set the index is taken
+	// from the ordinal of the pod given by POD_NAME, ie. KEDA Operator is deployed as StatefulSet
+	ShardIndexEnvVar = "KEDA_OPERATOR_SHARD_INDEX"
+)
+
+// Shard is the part of ScaledObjects and ScaledJobs owned by an instance of KEDA Operator
+type Shard struct {
+	Index int
+	Count int
+}
+
+// Enabled returns true if the objects are split across multiple shards
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Owns returns true if the object given by its namespace and name belongs to the shard
+func (s Shard) Owns(namespace, name string) bool {
+	if !s.Enabled() {
+		return true
+	}
+	return ShardOf(namespace, name, s.Count) == s.Index
+}
+
+// Name returns the name suffixed by the index of the shard, it's used for objects which are
+// singletons per KEDA Operator (eg. the leader election lease), the name is kept if sharding is disabled
+func (s Shard) Name(name string) string {
+	if !s.Enabled() {
+		return name
+	}
+	return fmt.Sprintf("%s-shard-%d", name, s.Index)
+}
+
+// Predicate filters events of objects not owned by the shard
+func (s Shard) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return s.Owns(object.GetNamespace(), object.GetName())
+	})
+}
+
+// ShardOf returns the index of the shard owning the object given by its namespace and name
+func ShardOf(namespace, name string, count int) int {
+	if count <= 1 {
+		return 0
+	}
+	hash := fnv.New32a()
+	// the separator can't be part of namespace or name, so namespace/name pairs don't collide
+	_, _ = hash.Write([]byte(namespace + "/" + name))
+	return int(hash.Sum32() % uint32(count))
+}
+
+// ResolveShard returns the shard of the instance configured by KEDA_OPERATOR_SHARD_COUNT and KEDA_OPERATOR_SHARD_INDEX
+func ResolveShard() (Shard, error) {
+	count, err := kedautil.ResolveOsEnvInt(ShardCountEnvVar, 1)
+	if err != nil {
+		return Shard{}, fmt.Errorf("invalid %s: %w", ShardCountEnvVar, err)
+	}
+	if count < 1 {
+		return Shard{}, fmt.Errorf("invalid %s: %d, it must be at least 1", ShardCountEnvVar, count)
+	}
+	if count == 1 {
+		return Shard{Index: 0, Count: 1}, nil
+	}
+
+	index, err := resolveShardIndex()
+	if err != nil {
+		return Shard{}, err
+	}
+	if index < 0 || index >= count {
+		return Shard{}, fmt.Errorf("invalid shard index %d, it must be between 0 and %d", index, count-1)
+	}
+	return Shard{Index: index, Count: count}, nil
+}
+
+func resolveShardIndex() (int, error) {
+	if value := os.Getenv(ShardIndexEnvVar); value != "" {
+		index, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s: %w", ShardIndexEnvVar, err)
+		}
+		return index, nil
+	}
+
+	// pods of a StatefulSet are named <statefulset>-<ordinal>
+	podName := os.Getenv("POD_NAME")
+	separator := strings.LastIndex(podName, "-")
+	if separator < 0 {
+		return 0, fmt.Errorf("%s must be set if KEDA Operator isn't deployed as StatefulSet with POD_NAME", ShardIndexEnvVar)
+	}
+	index, err := strconv.Atoi(podName[separator+1:])
+	if err != nil {
+		return 0, fmt.Errorf("%s must be set if KEDA Operator isn't deployed as StatefulSet, can't get ordinal of pod %s", ShardIndexEnvVar, podName)
+	}
+	return index, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardOfDistributesObjects(t *testing.T) {
+	const count = 4
+	objectsPerShard := make([]int, count)
+	for i := 0; i < 1000; i++ {
+		objectsPerShard[ShardOf("default", fmt.Sprintf("scaledobject-%d", i), count)]++
+	}
+	for index, objects := range objectsPerShard {
+		assert.Greater(t, objects, 150, "shard %d owns too few objects", index)
+	}
+}
+
+func TestShardOwnsObjectOnce(t *testing.T) {
+	shards := []Shard{{Index: 0, Count: 3}, {Index: 1, Count: 3}, {Index: 2, Count: 3}}
+	for i := 0; i < 100; i++ {
+		owners := 0
+		for _, shard := range shards {
+			if shard.Owns(fmt.Sprintf("namespace-%d", i%7), fmt.Sprintf("name-%d", i)) {
+				owners++
+			}
+		}
+		assert.Equal(t, 1, owners)
+	}
+}
+
+func TestShardOfSeparatesNamespaceAndName(t *testing.T) {
+	assert.Equal(t, ShardOf("ns", "name", 1000), ShardOf("ns", "name", 1000))
+	assert.NotEqual(t, ShardOf("a", "bc", 1000), ShardOf("ab", "c", 1000))
+}
+
+func TestDisabledShardOwnsAllObjects(t *testing.T) {
+	shard := Shard{Index: 0, Count: 1}
+	assert.False(t, shard.Enabled())
+	assert.True(t, shard.Owns("default", "scaledobject"))
+	assert.Equal(t, "operator.keda.sh", shard.Name("operator.keda.sh"))
+	assert.Equal(t, "operator.keda.sh-shard-2", Shard{Index: 2, Count: 3}.Name("operator.keda.sh"))
+}
+
+func TestResolveShard(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    string
+		index    string
+		podName  string
+		expected Shard
+		isError  bool
+	}{
+		{name: "disabled", expected: Shard{Index: 0, Count: 1}},
+		{name: "index given", count: "3", index: "2", expected: Shard{Index: 2, Count: 3}},
+		{name: "index of statefulset pod", count: "3", podName: "keda-operator-1", expected: Shard{Index: 1, Count: 3}},
+		{name: "index given overrides pod", count: "3", index: "0", podName: "keda-operator-1", expected: Shard{Index: 0, Count: 3}},
+		{name: "index out of range", count: "3", index: "3", isError: true},
+		{name: "pod without ordinal", count: "3", podName: "keda-operator-6d4b5f7c9-x2x8k", isError: true},
+		{name: "no index", count: "3", isError: true},
+		{name: "invalid count", count: "0", isError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(ShardCountEnvVar, test.count)
+			t.Setenv(ShardIndexEnvVar, test.index)
+			t.Setenv("POD_NAME", test.podName)
+
+			shard, err := ResolveShard()
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, shard)
+		})
+	}
+}
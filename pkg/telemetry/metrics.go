@@ -0,0 +1,312 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/sdk/resource"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	metricsExporterEnv = "OTEL_METRICS_EXPORTER"
+	metricsEndpointEnv = "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"
+	headersEnv         = "OTEL_EXPORTER_OTLP_HEADERS"
+	metricsHeadersEnv  = "OTEL_EXPORTER_OTLP_METRICS_HEADERS"
+	exportIntervalEnv  = "OTEL_METRIC_EXPORT_INTERVAL"
+	exportTimeoutEnv   = "OTEL_METRIC_EXPORT_TIMEOUT"
+
+	defaultExportIntervalMS = 60000
+	defaultExportTimeoutMS  = 30000
+	metricsPath             = "/v1/metrics"
+
+	// aggregationTemporalityCumulative is AGGREGATION_TEMPORALITY_CUMULATIVE, Prometheus counters are cumulative
+	aggregationTemporalityCumulative = 2
+)
+
+// MetricsExporter pushes the metrics of a Prometheus registry to an OTLP endpoint over HTTP in JSON encoding
+type MetricsExporter struct {
+	gatherer   prometheus.Gatherer
+	endpoint   string
+	headers    map[string]string
+	interval   time.Duration
+	httpClient *http.Client
+	resource   []otlpKeyValue
+	startTime  time.Time
+	logger     logr.Logger
+}
+
+// NewMetricsExporter returns an exporter of the metrics of the registry, it's nil if no OTLP endpoint is given
+func NewMetricsExporter(gatherer prometheus.Gatherer, res *resource.Resource) (*MetricsExporter, error) {
+	endpoint := getMetricsEndpoint()
+	if endpoint == "" || isSDKDisabled() || strings.EqualFold(os.Getenv(metricsExporterEnv), "none") {
+		return nil, nil
+	}
+
+	intervalMS, err := kedautil.ResolveOsEnvInt(exportIntervalEnv, defaultExportIntervalMS)
+	if err != nil || intervalMS <= 0 {
+		return nil, fmt.Errorf("invalid %s: %s", exportIntervalEnv, os.Getenv(exportIntervalEnv))
+	}
+	timeoutMS, err := kedautil.ResolveOsEnvInt(exportTimeoutEnv, defaultExportTimeoutMS)
+	if err != nil || timeoutMS <= 0 {
+		return nil, fmt.Errorf("invalid %s: %s", exportTimeoutEnv, os.Getenv(exportTimeoutEnv))
+	}
+	headers, err := parseHeaders(os.Getenv(headersEnv))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", headersEnv, err)
+	}
+	metricsHeaders, err := parseHeaders(os.Getenv(metricsHeadersEnv))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", metricsHeadersEnv, err)
+	}
+	for key, value := range metricsHeaders {
+		headers[key] = value
+	}
+
+	return &MetricsExporter{
+		gatherer:   gatherer,
+		endpoint:   endpoint,
+		headers:    headers,
+		interval:   time.Duration(intervalMS) * time.Millisecond,
+		httpClient: &http.Client{Timeout: time.Duration(timeoutMS) * time.Millisecond},
+		resource:   resourceAttributes(res),
+		startTime:  time.Now(),
+		logger:     logf.Log.WithName("telemetry"),
+	}, nil
+}
+
+// getMetricsEndpoint returns the URL the metrics are posted to, the path of the signal is appended to the generic endpoint
+func getMetricsEndpoint() string {
+	if endpoint := os.Getenv(metricsEndpointEnv); endpoint != "" {
+		return endpoint
+	}
+	if endpoint := os.Getenv(endpointEnv); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/") + metricsPath
+	}
+	return ""
+}
+
+// parseHeaders parses headers given as comma separated key=value pairs with URL encoded values
+func parseHeaders(value string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, val, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("malformed header %q", pair)
+		}
+		decoded, err := url.QueryUnescape(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("malformed value of header %q: %w", key, err)
+		}
+		headers[strings.TrimSpace(key)] = decoded
+	}
+	return headers, nil
+}
+
+// NeedLeaderElection returns false, every instance pushes its own metrics
+func (e *MetricsExporter) NeedLeaderElection() bool {
+	return false
+}
+
+// Start pushes the metrics once per export interval until the context is done, the metrics are pushed once more
+// when it's done
+func (e *MetricsExporter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := e.export(context.Background()); err != nil {
+				e.logger.Error(err, "error exporting metrics over OTLP")
+			}
+			return nil
+		case <-ticker.C:
+			if err := e.export(ctx); err != nil {
+				e.logger.Error(err, "error exporting metrics over OTLP")
+			}
+		}
+	}
+}
+
+func (e *MetricsExporter) export(ctx context.Context) error {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("error gathering metrics: %w", err)
+	}
+	body, err := json.Marshal(e.newRequest(families, time.Now()))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("collector returned status %d: %s", resp.StatusCode, string(message))
+	}
+	return nil
+}
+
+// newRequest converts the Prometheus metric families to an OTLP ExportMetricsServiceRequest, counters are converted
+// to cumulative monotonic sums, gauges and untyped metrics to gauges
+func (e *MetricsExporter) newRequest(families []*dto.MetricFamily, now time.Time) otlpExportRequest {
+	startTime, timestamp := unixNano(e.startTime), unixNano(now)
+	metrics := make([]otlpMetric, 0, len(families))
+	for _, family := range families {
+		metric := otlpMetric{Name: family.GetName(), Description: family.GetHelp()}
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			metric.Sum = &otlpSum{AggregationTemporality: aggregationTemporalityCumulative, IsMonotonic: true}
+			for _, m := range family.Metric {
+				if point, ok := newNumberDataPoint(m, m.GetCounter().GetValue(), startTime, timestamp); ok {
+					metric.Sum.DataPoints = append(metric.Sum.DataPoints, point)
+				}
+			}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			metric.Gauge = &otlpGauge{}
+			for _, m := range family.Metric {
+				value := m.GetGauge().GetValue()
+				if family.GetType() == dto.MetricType_UNTYPED {
+					value = m.GetUntyped().GetValue()
+				}
+				if point, ok := newNumberDataPoint(m, value, "", timestamp); ok {
+					metric.Gauge.DataPoints = append(metric.Gauge.DataPoints, point)
+				}
+			}
+		case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+			metric.Histogram = &otlpHistogram{AggregationTemporality: aggregationTemporalityCumulative}
+			for _, m := range family.Metric {
+				metric.Histogram.DataPoints = append(metric.Histogram.DataPoints, newHistogramDataPoint(m, startTime, timestamp))
+			}
+		case dto.MetricType_SUMMARY:
+			metric.Summary = &otlpSummary{}
+			for _, m := range family.Metric {
+				metric.Summary.DataPoints = append(metric.Summary.DataPoints, newSummaryDataPoint(m, startTime, timestamp))
+			}
+		default:
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+
+	return otlpExportRequest{ResourceMetrics: []otlpResourceMetrics{{
+		Resource: otlpResource{Attributes: e.resource},
+		ScopeMetrics: []otlpScopeMetrics{{
+			Scope:   otlpScope{Name: instrumentationName},
+			Metrics: metrics,
+		}},
+	}}}
+}
+
+// newNumberDataPoint returns the data point of the value, JSON can't encode non finite values so they are skipped
+func newNumberDataPoint(m *dto.Metric, value float64, startTime, timestamp string) (otlpNumberDataPoint, bool) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return otlpNumberDataPoint{}, false
+	}
+	return otlpNumberDataPoint{
+		Attributes:        labelAttributes(m),
+		StartTimeUnixNano: startTime,
+		TimeUnixNano:      timestamp,
+		AsDouble:          value,
+	}, true
+}
+
+// newHistogramDataPoint converts the cumulative buckets of Prometheus to the bucket counts of OTLP, the +Inf bucket
+// is implied by the count
+func newHistogramDataPoint(m *dto.Metric, startTime, timestamp string) otlpHistogramDataPoint {
+	histogram := m.GetHistogram()
+	point := otlpHistogramDataPoint{
+		Attributes:        labelAttributes(m),
+		StartTimeUnixNano: startTime,
+		TimeUnixNano:      timestamp,
+		Count:             strconv.FormatUint(histogram.GetSampleCount(), 10),
+		Sum:               histogram.GetSampleSum(),
+	}
+	var previous uint64
+	for _, bucket := range histogram.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		point.ExplicitBounds = append(point.ExplicitBounds, bucket.GetUpperBound())
+		point.BucketCounts = append(point.BucketCounts, strconv.FormatUint(bucket.GetCumulativeCount()-previous, 10))
+		previous = bucket.GetCumulativeCount()
+	}
+	point.BucketCounts = append(point.BucketCounts, strconv.FormatUint(histogram.GetSampleCount()-previous, 10))
+	return point
+}
+
+func newSummaryDataPoint(m *dto.Metric, startTime, timestamp string) otlpSummaryDataPoint {
+	summary := m.GetSummary()
+	point := otlpSummaryDataPoint{
+		Attributes:        labelAttributes(m),
+		StartTimeUnixNano: startTime,
+		TimeUnixNano:      timestamp,
+		Count:             strconv.FormatUint(summary.GetSampleCount(), 10),
+		Sum:               summary.GetSampleSum(),
+	}
+	for _, quantile := range summary.GetQuantile() {
+		if math.IsNaN(quantile.GetValue()) {
+			continue
+		}
+		point.QuantileValues = append(point.QuantileValues, otlpQuantileValue{Quantile: quantile.GetQuantile(), Value: quantile.GetValue()})
+	}
+	return point
+}
+
+func labelAttributes(m *dto.Metric) []otlpKeyValue {
+	attributes := make([]otlpKeyValue, 0, len(m.GetLabel()))
+	for _, label := range m.GetLabel() {
+		attributes = append(attributes, otlpKeyValue{Key: label.GetName(), Value: otlpAnyValue{StringValue: label.GetValue()}})
+	}
+	return attributes
+}
+
+// unixNano returns the time in the encoding of fixed64 fields of the JSON encoding of OTLP
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "keda_test_errors_total", Help: "errors"}, []string{"scaler"})
+	counter.WithLabelValues("kafka").Add(3)
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "keda_test_replicas"})
+	gauge.Set(2)
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "keda_test_latency_seconds", Buckets: []float64{0.1, 1}})
+	histogram.Observe(0.05)
+	histogram.Observe(0.5)
+	histogram.Observe(5)
+	registry.MustRegister(counter, gauge, histogram)
+	return registry
+}
+
+func TestMetricsExporterExport(t *testing.T) {
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, metricsPath, r.URL.Path)
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	t.Setenv(endpointEnv, server.URL+"/")
+	t.Setenv(headersEnv, "authorization=Bearer%20token,x-tenant=keda")
+	t.Setenv(metricsHeadersEnv, "x-tenant=metrics")
+	res, err := NewResource(context.Background(), "keda-operator")
+	require.NoError(t, err)
+	exporter, err := NewMetricsExporter(newTestRegistry(), res)
+	require.NoError(t, err)
+	require.NotNil(t, exporter)
+
+	require.NoError(t, exporter.export(context.Background()))
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+	assert.Equal(t, "metrics", header.Get("X-Tenant"))
+
+	request := otlpExportRequest{}
+	require.NoError(t, json.Unmarshal(body, &request))
+	require.Len(t, request.ResourceMetrics, 1)
+	assert.Contains(t, request.ResourceMetrics[0].Resource.Attributes, otlpKeyValue{Key: "service.name", Value: otlpAnyValue{StringValue: "keda-operator"}})
+
+	metrics := map[string]otlpMetric{}
+	for _, metric := range request.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+		metrics[metric.Name] = metric
+	}
+
+	counter := metrics["keda_test_errors_total"]
+	require.NotNil(t, counter.Sum)
+	assert.True(t, counter.Sum.IsMonotonic)
+	assert.Equal(t, aggregationTemporalityCumulative, counter.Sum.AggregationTemporality)
+	assert.Equal(t, 3.0, counter.Sum.DataPoints[0].AsDouble)
+	assert.Equal(t, []otlpKeyValue{{Key: "scaler", Value: otlpAnyValue{StringValue: "kafka"}}}, counter.Sum.DataPoints[0].Attributes)
+
+	gauge := metrics["keda_test_replicas"]
+	require.NotNil(t, gauge.Gauge)
+	assert.Equal(t, 2.0, gauge.Gauge.DataPoints[0].AsDouble)
+
+	histogram := metrics["keda_test_latency_seconds"]
+	require.NotNil(t, histogram.Histogram)
+	assert.Equal(t, "3", histogram.Histogram.DataPoints[0].Count)
+	assert.Equal(t, []float64{0.1, 1}, histogram.Histogram.DataPoints[0].ExplicitBounds)
+	assert.Equal(t, []string{"1", "1", "1"}, histogram.Histogram.DataPoints[0].BucketCounts)
+}
+
+func TestMetricsExporterCollectorError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	t.Setenv(metricsEndpointEnv, server.URL+"/custom")
+	exporter, err := NewMetricsExporter(newTestRegistry(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/custom", exporter.endpoint)
+	assert.Error(t, exporter.export(context.Background()))
+}
+
+func TestNewMetricsExporterConfig(t *testing.T) {
+	type testCase struct {
+		name     string
+		env      map[string]string
+		disabled bool
+		isError  bool
+	}
+	testCases := []testCase{
+		{name: "no endpoint", env: map[string]string{}, disabled: true},
+		{name: "sdk disabled", env: map[string]string{endpointEnv: "http://collector:4318", sdkDisabledEnv: "true"}, disabled: true},
+		{name: "exporter none", env: map[string]string{endpointEnv: "http://collector:4318", metricsExporterEnv: "none"}, disabled: true},
+		{name: "interval", env: map[string]string{endpointEnv: "http://collector:4318", exportIntervalEnv: "15000"}},
+		{name: "invalid interval", env: map[string]string{endpointEnv: "http://collector:4318", exportIntervalEnv: "0"}, isError: true},
+		{name: "invalid timeout", env: map[string]string{endpointEnv: "http://collector:4318", exportTimeoutEnv: "soon"}, isError: true},
+		{name: "invalid headers", env: map[string]string{endpointEnv: "http://collector:4318", headersEnv: "authorization"}, isError: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+			exporter, err := NewMetricsExporter(prometheus.NewRegistry(), nil)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.disabled, exporter == nil)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package telemetry exports traces and metrics of KEDA to an OpenTelemetry collector over OTLP, it's configured by
+// the standard OTEL_* environment variables, eg. OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES.
+// Traces are exported over gRPC, metrics of the Prometheus registry are pushed over HTTP with JSON encoding.
+package telemetry
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/kedacore/keda/v2"
+
+	sdkDisabledEnv    = "OTEL_SDK_DISABLED"
+	tracesExporterEnv = "OTEL_TRACES_EXPORTER"
+	endpointEnv       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	tracesEndpointEnv = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+)
+
+// Tracer returns the tracer of KEDA, spans aren't recorded unless tracing is set up by SetupTracing
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// EndSpan records the error in the span, if any, and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// NewResource returns the resource of the telemetry of the service, OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES
+// override its attributes
+func NewResource(ctx context.Context, serviceName string) (*resource.Resource, error) {
+	return resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithFromEnv(),
+	)
+}
+
+// SetupTracing sets up the export of traces over OTLP if an OTLP endpoint is given, the returned function flushes
+// and stops the export
+func SetupTracing(ctx context.Context, res *resource.Resource) (func(context.Context) error, error) {
+	if !isTracingEnabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	// the exporter reads the endpoint, headers, certificates and timeout from the environment
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the sampler is given by OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+func isTracingEnabled() bool {
+	if isSDKDisabled() || strings.EqualFold(os.Getenv(tracesExporterEnv), "none") {
+		return false
+	}
+	return os.Getenv(endpointEnv) != "" || os.Getenv(tracesEndpointEnv) != ""
+}
+
+func isSDKDisabled() bool {
+	return strings.EqualFold(os.Getenv(sdkDisabledEnv), "true")
+}
+
+// resourceAttributes returns the attributes of the resource in OTLP JSON encoding
+func resourceAttributes(res *resource.Resource) []otlpKeyValue {
+	attributes := make([]otlpKeyValue, 0, res.Len())
+	for _, kv := range res.Attributes() {
+		attributes = append(attributes, otlpKeyValue{Key: string(kv.Key), Value: otlpAnyValue{StringValue: kv.Value.Emit()}})
+	}
+	return attributes
+}
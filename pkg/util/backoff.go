@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// ErrBackoffElapsed is returned once MaxElapsedTime of the backoff passed since the first retry
+var ErrBackoffElapsed = errors.New("max elapsed time of retries passed")
+
+// Backoff computes exponentially growing delays between retries, randomized by jitter so clients recovering
+// from the same outage don't retry in lockstep. Its state isn't synchronized, it's used by a single retry loop.
+type Backoff struct {
+	// InitialInterval is the delay before the first retry, it's doubled by each retry up to MaxInterval
+	InitialInterval time.Duration
+	// MaxInterval bounds the delays, they don't grow if it's zero
+	MaxInterval time.Duration
+	// Jitter is the largest fraction of the delay it's randomly shortened by, between 0 and 1
+	Jitter float64
+	// MaxElapsedTime stops the retries once it passed since the first retry, the retries aren't stopped if it's zero
+	MaxElapsedTime time.Duration
+
+	interval time.Duration
+	start    time.Time
+	now      func() time.Time
+	random   func() float64
+}
+
+// NextDelay returns the delay before the next retry, or false if MaxElapsedTime passed since the first retry
+func (b *Backoff) NextDelay() (time.Duration, bool) {
+	now := time.Now
+	if b.now != nil {
+		now = b.now
+	}
+	if b.start.IsZero() {
+		b.start = now()
+		b.interval = b.InitialInterval
+	}
+	if b.MaxElapsedTime > 0 && now().Sub(b.start) >= b.MaxElapsedTime {
+		return 0, false
+	}
+
+	delay := b.interval
+	if b.MaxInterval > 0 && b.interval < b.MaxInterval {
+		if b.interval *= 2; b.interval > b.MaxInterval {
+			b.interval = b.MaxInterval
+		}
+	}
+	if b.Jitter > 0 {
+		random := rand.Float64
+		if b.random != nil {
+			random = b.random
+		}
+		delay -= time.Duration(float64(delay) * b.Jitter * random())
+	}
+	return delay, true
+}
+
+// Reset starts the retries over from InitialInterval, eg. once the recovered connection worked for a while
+func (b *Backoff) Reset() {
+	b.start = time.Time{}
+}
+
+// Wait waits for the delay before the next retry, it returns the error of the context once it's done
+// or ErrBackoffElapsed once MaxElapsedTime passed
+func (b *Backoff) Wait(ctx context.Context) error {
+	delay, ok := b.NextDelay()
+	if !ok {
+		return ErrBackoffElapsed
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Retry calls the function until it succeeds, it returns the last error of the function once the context is done
+// or MaxElapsedTime of the backoff passed
+func Retry(ctx context.Context, backoff *Backoff, fn func(ctx context.Context) error) error {
+	for {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if waitErr := backoff.Wait(ctx); waitErr != nil {
+			return err
+		}
+	}
+}
+
+// RunWithBackoff runs the function again once it returns, after the delay of the backoff, until the context is done,
+// eg. to recover a stream terminated by its server. The backoff is reset once the function ran longer than MaxInterval,
+// so a connection lost after it worked for a while is recovered without waiting for the longest delay.
+func RunWithBackoff(ctx context.Context, backoff *Backoff, run func()) {
+	for {
+		start := time.Now()
+		run()
+		if time.Since(start) > backoff.MaxInterval {
+			backoff.Reset()
+		}
+		if err := backoff.Wait(ctx); err != nil {
+			return
+		}
+	}
+}
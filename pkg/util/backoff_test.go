@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoffNextDelay(t *testing.T) {
+	backoff := &Backoff{InitialInterval: time.Second, MaxInterval: 5 * time.Second}
+
+	var delays []time.Duration
+	for i := 0; i < 5; i++ {
+		delay, ok := backoff.NextDelay()
+		assert.True(t, ok)
+		delays = append(delays, delay)
+	}
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, delays)
+
+	backoff.Reset()
+	delay, _ := backoff.NextDelay()
+	assert.Equal(t, time.Second, delay)
+}
+
+func TestBackoffJitter(t *testing.T) {
+	backoff := &Backoff{InitialInterval: time.Second, MaxInterval: 5 * time.Second, Jitter: 0.5, random: func() float64 { return 1 }}
+
+	delay, _ := backoff.NextDelay()
+	assert.Equal(t, 500*time.Millisecond, delay)
+	delay, _ = backoff.NextDelay()
+	assert.Equal(t, time.Second, delay)
+}
+
+func TestBackoffMaxElapsedTime(t *testing.T) {
+	now := time.Now()
+	backoff := &Backoff{InitialInterval: time.Second, MaxInterval: 5 * time.Second, MaxElapsedTime: 10 * time.Second, now: func() time.Time { return now }}
+
+	_, ok := backoff.NextDelay()
+	assert.True(t, ok)
+	now = now.Add(9 * time.Second)
+	_, ok = backoff.NextDelay()
+	assert.True(t, ok)
+	now = now.Add(time.Second)
+	_, ok = backoff.NextDelay()
+	assert.False(t, ok)
+	assert.ErrorIs(t, backoff.Wait(context.Background()), ErrBackoffElapsed)
+}
+
+func TestBackoffWaitCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	backoff := &Backoff{InitialInterval: time.Hour}
+
+	assert.ErrorIs(t, backoff.Wait(ctx), context.Canceled)
+}
+
+func TestRetry(t *testing.T) {
+	backoff := &Backoff{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}
+	calls := 0
+	err := Retry(context.Background(), backoff, func(context.Context) error {
+		if calls++; calls < 3 {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Retry(ctx, &Backoff{InitialInterval: time.Hour}, func(context.Context) error {
+		return errors.New("unavailable")
+	})
+	assert.EqualError(t, err, "unavailable")
+}
+
+func TestRunWithBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	backoff := &Backoff{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}
+
+	runs := 0
+	RunWithBackoff(ctx, backoff, func() {
+		if runs++; runs == 3 {
+			cancel()
+		}
+	})
+	assert.Equal(t, 3, runs)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// EndpointFailover tracks health of an ordered list of endpoints of a metric source, so scalers can fail over to
+// the next endpoint on errors, eg. Prometheus servers or the namespaces of an Azure Service Bus Geo-DR pair.
+type EndpointFailover struct {
+	endpoints []string
+	cooldown  time.Duration
+
+	lock           sync.Mutex
+	unhealthyUntil map[string]time.Time
+	now            func() time.Time
+}
+
+// NewEndpointFailover creates a new EndpointFailover for the endpoints in order of preference,
+// an endpoint that failed isn't preferred for the cooldown duration
+func NewEndpointFailover(endpoints []string, cooldown time.Duration) *EndpointFailover {
+	return &EndpointFailover{
+		endpoints:      endpoints,
+		cooldown:       cooldown,
+		unhealthyUntil: map[string]time.Time{},
+		now:            time.Now,
+	}
+}
+
+// Endpoints returns the endpoints in the order they should be tried, healthy endpoints in order of preference
+// go first followed by the unhealthy ones, the one closest to the end of its cooldown first
+func (f *EndpointFailover) Endpoints() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	now := f.now()
+	var healthy, unhealthy []string
+	for _, endpoint := range f.endpoints {
+		if until, ok := f.unhealthyUntil[endpoint]; ok && now.Before(until) {
+			unhealthy = append(unhealthy, endpoint)
+		} else {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return f.unhealthyUntil[unhealthy[i]].Before(f.unhealthyUntil[unhealthy[j]])
+	})
+	return append(healthy, unhealthy...)
+}
+
+// ReportSuccess marks the endpoint as healthy
+func (f *EndpointFailover) ReportSuccess(endpoint string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.unhealthyUntil, endpoint)
+}
+
+// ReportFailure marks the endpoint as unhealthy for the cooldown duration
+func (f *EndpointFailover) ReportFailure(endpoint string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.unhealthyUntil[endpoint] = f.now().Add(f.cooldown)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEndpointFailover(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewEndpointFailover([]string{"a", "b", "c"}, time.Minute)
+	f.now = func() time.Time { return now }
+
+	expectEndpoints := func(expected ...string) {
+		t.Helper()
+		if got := f.Endpoints(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v got %v", expected, got)
+		}
+	}
+
+	expectEndpoints("a", "b", "c")
+
+	f.ReportFailure("a")
+	expectEndpoints("b", "c", "a")
+
+	now = now.Add(10 * time.Second)
+	f.ReportFailure("b")
+	expectEndpoints("c", "a", "b")
+
+	// a recovers after its cooldown and is preferred again
+	now = now.Add(55 * time.Second)
+	expectEndpoints("a", "c", "b")
+
+	f.ReportSuccess("b")
+	expectEndpoints("a", "b", "c")
+}
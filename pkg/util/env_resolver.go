@@ -19,11 +19,16 @@ package util
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 const RestrictSecretAccessEnvVar = "KEDA_RESTRICT_SECRET_ACCESS"
 
+// ClusterTriggerAuthenticationAllowedNamespacesEnvVar is the comma separated list of namespaces
+// ClusterTriggerAuthentications are allowed to read Secrets of, in addition to the cluster object namespace
+const ClusterTriggerAuthenticationAllowedNamespacesEnvVar = "KEDA_CLUSTER_TRIGGER_AUTHENTICATION_ALLOWED_NAMESPACES"
+
 var clusterObjectNamespaceCache *string
 
 func ResolveOsEnvBool(envName string, defaultValue bool) (bool, error) {
@@ -90,3 +95,14 @@ func GetPodNamespace() string {
 func GetRestrictSecretAccess() string {
 	return os.Getenv(RestrictSecretAccessEnvVar)
 }
+
+// GetClusterTriggerAuthenticationAllowedNamespaces retrieves the namespaces of KEDA_CLUSTER_TRIGGER_AUTHENTICATION_ALLOWED_NAMESPACES
+func GetClusterTriggerAuthenticationAllowedNamespaces() []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(os.Getenv(ClusterTriggerAuthenticationAllowedNamespacesEnvVar), ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
@@ -41,3 +41,11 @@ func TestResolveValidOsEnvDuration(t *testing.T) {
 	assert.Equal(t, time.Duration(30)*time.Minute, *actual)
 	assert.Nil(t, err)
 }
+
+func TestGetClusterTriggerAuthenticationAllowedNamespaces(t *testing.T) {
+	t.Setenv(ClusterTriggerAuthenticationAllowedNamespacesEnvVar, "")
+	assert.Empty(t, GetClusterTriggerAuthenticationAllowedNamespaces())
+
+	t.Setenv(ClusterTriggerAuthenticationAllowedNamespacesEnvVar, "shared, credentials,,")
+	assert.Equal(t, []string{"shared", "credentials"}, GetClusterTriggerAuthenticationAllowedNamespaces())
+}
@@ -85,3 +85,15 @@ func ParseStringList(pattern string) (map[string]string, error) {
 	}
 	return parsed, nil
 }
+
+// ParseCommaSeparatedList returns the trimmed items of the comma separated list, empty items are skipped
+func ParseCommaSeparatedList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -128,3 +128,21 @@ func TestParseStringList(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCommaSeparatedList(t *testing.T) {
+	testData := []struct {
+		value string
+		exp   []string
+	}{
+		{"", nil},
+		{" , ", nil},
+		{"http://a:9090", []string{"http://a:9090"}},
+		{"team-a, team-b,", []string{"team-a", "team-b"}},
+	}
+
+	for _, tt := range testData {
+		if got := ParseCommaSeparatedList(tt.value); !reflect.DeepEqual(tt.exp, got) {
+			t.Errorf("%q: expected %v but got %v\n", tt.value, tt.exp, got)
+		}
+	}
+}
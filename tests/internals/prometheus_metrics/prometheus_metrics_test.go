@@ -230,6 +230,7 @@ func TestPrometheusMetrics(t *testing.T) {
 	testScalerMetricValue(t)
 	testScalerMetricLatency(t)
 	testScalerActiveMetric(t)
+	testHPAMetrics(t)
 	testMetricsServerScalerMetricValue(t)
 	testOperatorMetrics(t, kc, data)
 	testWebhookMetrics(t, data)
@@ -338,6 +339,31 @@ func testScalerActiveMetric(t *testing.T) {
 	}
 }
 
+func testHPAMetrics(t *testing.T) {
+	t.Log("--- testing hpa metrics ---")
+
+	family := fetchAndParsePrometheusMetrics(t, fmt.Sprintf("curl --insecure %s", kedaOperatorPrometheusURL))
+
+	for _, name := range []string{"keda_hpa_current_replicas", "keda_hpa_desired_replicas"} {
+		if val, ok := family[name]; ok {
+			var found bool
+			metrics := val.GetMetric()
+			for _, metric := range metrics {
+				labels := metric.GetLabel()
+				for _, label := range labels {
+					if *label.Name == labelScaledObject && *label.Value == scaledObjectName {
+						assert.Equal(t, float64(2), *metric.Gauge.Value)
+						found = true
+					}
+				}
+			}
+			assert.Equal(t, true, found)
+		} else {
+			t.Errorf("metric %s not available", name)
+		}
+	}
+}
+
 // [DEPRECATED] handle exporting Prometheus metrics from Operator to Metrics Server
 func testMetricsServerScalerMetricValue(t *testing.T) {
 	t.Log("--- testing scaler metric value in metrics server ---")